@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/demo"
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	internalServer "github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	gstesting "github.com/giantswarm/mcp-giantswarm-apps/pkg/testing"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/tools"
+)
+
+// newClients connects to the cluster of the kube context, or returns the
+// clients of the demo installation or empty ones for a replay
+func newClients(ctx context.Context, opts *serveOptions) (*k8s.Client, *k8s.DynamicClient, error) {
+	if opts.replay != "" {
+		// Recorded results answer the tool calls, the clients hold nothing
+		k8sClient, dynamicClient := gstesting.NewClients()
+		k8sClient.Context = "replay"
+		return k8sClient, dynamicClient, nil
+	}
+	if opts.demo {
+		k8sClient, dynamicClient, err := demo.NewClients()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load the demo installation: %w", err)
+		}
+		log.Println("Serving the bundled demo installation, no Kubernetes cluster is used")
+		return k8sClient, dynamicClient, nil
+	}
+
+	// Initialize Kubernetes client
+	kubeContext := opts.kubeContext
+	if kubeContext == "" {
+		kubeContext = os.Getenv("KUBE_CONTEXT") // Allow overriding context via env var
+	}
+
+	k8sClient, err := k8s.NewClient(ctx, kubeContext)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize Kubernetes client: %v", err)
+	}
+	log.Printf("Connected to Kubernetes cluster (context: %s)", k8sClient.GetCurrentContext())
+
+	// Initialize dynamic client for CRDs
+	dynamicClient, err := k8s.NewDynamicClient(k8sClient)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize dynamic client: %v", err)
+	}
+
+	// Check if Giant Swarm CRDs are available
+	if err := dynamicClient.CheckCRDsExist(ctx, k8sClient); err != nil {
+		log.Printf("Warning: %v", err)
+		log.Println("Make sure you're connected to a Giant Swarm management cluster")
+	}
+
+	return k8sClient, dynamicClient, nil
+}
+
+// detectDefaultOrganization sets the default organization to the one the
+// current identity belongs to, if it can be determined
+func detectDefaultOrganization(ctx context.Context, serverCtx *internalServer.Context) {
+	detectCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	id, err := serverCtx.Identity.Resolve(detectCtx)
+	if err != nil {
+		log.Printf("Warning: failed to detect the organization of the current user: %v", err)
+		return
+	}
+	if id.Organization == "" {
+		log.Printf("Authenticated as %s, no default organization detected", id.Username)
+		return
+	}
+
+	defaults := serverCtx.Defaults(ctx)
+	defaults.Organization = id.Organization
+	if err := tools.CheckDefaultsPolicy(serverCtx.NamespacePolicy, defaults); err != nil {
+		log.Printf("Authenticated as %s, not defaulting to organization %s: %v", id.Username, id.Organization, err)
+		return
+	}
+	serverCtx.SetDefaults(ctx, defaults)
+	log.Printf("Authenticated as %s, defaulting to organization %s (from %s)", id.Username, id.Organization, id.Source)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/doctor"
+)
+
+// newDoctorCmd creates the Cobra command that diagnoses the local environment
+// before the server is wired into an MCP client.
+func newDoctorCmd() *cobra.Command {
+	var kubeContext string
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check that the environment is ready to run the server",
+		Long: `Checks the kubeconfig, the connection to the management cluster, the
+availability of the App Platform CRDs and basic RBAC permissions, and prints
+how to fix every problem found. Exits with a non-zero status if a check fails.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if kubeContext == "" {
+				kubeContext = os.Getenv("KUBE_CONTEXT")
+			}
+
+			results := doctor.Run(cmd.Context(), kubeContext)
+
+			out := cmd.OutOrStdout()
+			failed, warnings := 0, 0
+			for _, result := range results {
+				marker := "[ok]  "
+				switch result.Status {
+				case doctor.StatusWarning:
+					marker = "[warn]"
+					warnings++
+				case doctor.StatusFailed:
+					marker = "[fail]"
+					failed++
+				}
+				fmt.Fprintf(out, "%s %s: %s\n", marker, result.Name, result.Message)
+				if result.Remediation != "" {
+					fmt.Fprintf(out, "       -> %s\n", result.Remediation)
+				}
+			}
+
+			fmt.Fprintf(out, "\n%d checks, %d failed, %d warnings\n", len(results), failed, warnings)
+			if doctor.Failed(results) {
+				return fmt.Errorf("%d checks failed", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&kubeContext, "kube-context", "", "Kubernetes context to check (defaults to current context)")
+	_ = cmd.RegisterFlagCompletionFunc("kube-context", completeKubeContexts)
+
+	return cmd
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// MCP clients generate-config writes configuration for
+var configClients = []string{"claude-desktop", "cursor", "vscode"}
+
+// transports the server supports
+var transports = []string{"stdio", "sse", "streamable-http"}
+
+// generateConfigOptions holds the configuration of the generate-config command
+type generateConfigOptions struct {
+	client    string
+	transport string
+	name      string
+	command   string
+	url       string
+}
+
+// newGenerateConfigCmd creates the Cobra command that prints MCP client
+// configuration for running this server.
+func newGenerateConfigCmd() *cobra.Command {
+	opts := &generateConfigOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "generate-config [-- serve flags...]",
+		Short: "Print MCP client configuration for this server",
+		Long: `Prints ready-to-paste configuration for Claude Desktop, Cursor or VS Code.
+
+With the stdio transport the client starts the server itself; arguments after
+-- are passed to the serve command, e.g.
+
+  mcp-giantswarm-apps generate-config --client cursor -- --kube-context gs-golem
+
+With the sse and streamable-http transports the client connects to a server
+started separately with 'serve --transport ...' at --url.`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := clientConfig(opts, args)
+			if err != nil {
+				return err
+			}
+
+			data, err := json.MarshalIndent(config, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal client configuration: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			fmt.Fprintf(cmd.ErrOrStderr(), "Add this to %s\n", configLocation(opts.client))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.client, "client", "claude-desktop", "MCP client: "+strings.Join(configClients, ", "))
+	cmd.Flags().StringVar(&opts.transport, "transport", "stdio", "Transport type: "+strings.Join(transports, ", "))
+	cmd.Flags().StringVar(&opts.name, "name", "giantswarm-apps", "Name of the server in the client configuration")
+	cmd.Flags().StringVar(&opts.command, "command", "", "Command that starts the server (defaults to the path of this binary)")
+	cmd.Flags().StringVar(&opts.url, "url", "", "URL of the server for the sse and streamable-http transports (defaults to the serve defaults on localhost)")
+
+	_ = cmd.RegisterFlagCompletionFunc("client", cobra.FixedCompletions(configClients, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("transport", cobra.FixedCompletions(transports, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+// clientConfig returns the configuration of the server for a client.
+// serveArgs are passed to the serve command with the stdio transport.
+func clientConfig(opts *generateConfigOptions, serveArgs []string) (map[string]any, error) {
+	server := make(map[string]any)
+
+	switch opts.transport {
+	case "stdio":
+		command := opts.command
+		if command == "" {
+			command = defaultCommand()
+		}
+		server["command"] = command
+		server["args"] = append([]string{"serve"}, serveArgs...)
+		// Clients do not start servers from a login shell, so pass on the
+		// kubeconfig location explicitly
+		if kubeconfig := os.Getenv("KUBECONFIG"); kubeconfig != "" {
+			server["env"] = map[string]string{"KUBECONFIG": kubeconfig}
+		}
+		if opts.client == "vscode" {
+			server["type"] = "stdio"
+		}
+	case "sse", "streamable-http":
+		if len(serveArgs) > 0 {
+			return nil, fmt.Errorf("serve flags only apply to the stdio transport, pass them to the separately started server instead")
+		}
+		url := opts.url
+		if url == "" {
+			url = "http://localhost:8080/mcp"
+			if opts.transport == "sse" {
+				url = "http://localhost:8080/sse"
+			}
+		}
+		switch opts.client {
+		case "claude-desktop":
+			// Claude Desktop only starts local servers; mcp-remote bridges
+			// them to a remote one
+			server["command"] = "npx"
+			server["args"] = []string{"mcp-remote", url}
+		case "vscode":
+			server["type"] = "http"
+			if opts.transport == "sse" {
+				server["type"] = "sse"
+			}
+			server["url"] = url
+		default:
+			server["url"] = url
+		}
+	default:
+		return nil, fmt.Errorf("unsupported transport type: %s (supported: %s)", opts.transport, strings.Join(transports, ", "))
+	}
+
+	switch opts.client {
+	case "claude-desktop", "cursor":
+		return map[string]any{"mcpServers": map[string]any{opts.name: server}}, nil
+	case "vscode":
+		return map[string]any{"servers": map[string]any{opts.name: server}}, nil
+	}
+	return nil, fmt.Errorf("unsupported client: %s (supported: %s)", opts.client, strings.Join(configClients, ", "))
+}
+
+// defaultCommand returns the absolute path of the running binary, since
+// clients do not necessarily search the user's PATH
+func defaultCommand() string {
+	exe, err := os.Executable()
+	if err != nil {
+		return serverName
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	return exe
+}
+
+// configLocation describes where a client reads its MCP configuration from
+func configLocation(client string) string {
+	switch client {
+	case "claude-desktop":
+		return "claude_desktop_config.json (Settings > Developer > Edit Config in Claude Desktop)"
+	case "cursor":
+		return "~/.cursor/mcp.json, or .cursor/mcp.json in a project"
+	case "vscode":
+		return ".vscode/mcp.json in the workspace, or the MCP section of the user settings"
+	}
+	return "the MCP configuration of your client"
+}
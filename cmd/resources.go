@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	internalServer "github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/organization"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/prompts"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/report"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/resources"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// initializeResources registers all MCP resources with the server (moved from original main.go)
+func initializeResources(s *server.MCPServer, hooks *server.Hooks, ctx *internalServer.Context, opts *serveOptions) error {
+	// Create resource provider
+	provider := resources.NewProvider(ctx.K8sClient, ctx.DynamicClient)
+	provider.UseAppCatalogEntryIndex(ctx.AppCatalogEntryIndex)
+	if ctx.NamespacePolicy.Active() {
+		provider.RestrictNamespaces(ctx.NamespacePolicy.Allows)
+	}
+
+	// resources/list enumerates the configured types page by page after the
+	// static resources. The provider's cursors start with a lowercase type
+	// name, so static resources, whose names are capitalized, sort before any
+	// cursor and are only listed on the first page.
+	if len(opts.listResourceTypes) > 0 {
+		listTypes, err := resources.ParseResourceTypes(opts.listResourceTypes)
+		if err != nil {
+			return fmt.Errorf("invalid --list-resource-types: %w", err)
+		}
+
+		hooks.AddAfterListResources(func(hctx context.Context, id any, message *mcp.ListResourcesRequest, result *mcp.ListResourcesResult) {
+			page, err := provider.ListResourcesPage(hctx, resources.ListOptions{
+				Types:  listTypes,
+				Cursor: string(message.Params.Cursor),
+				Limit:  opts.resourcesPageSize,
+			})
+			if err != nil {
+				log.Printf("Warning: failed to list resources: %v", err)
+				return
+			}
+
+			for _, r := range page.Resources {
+				result.Resources = append(result.Resources, mcp.NewResource(r.URI, r.Name,
+					mcp.WithResourceDescription(r.Description),
+					mcp.WithMIMEType(r.MimeType),
+				))
+			}
+			result.NextCursor = mcp.Cursor(page.NextCursor)
+		})
+	}
+
+	// readResource serves any resource URI through the provider as JSON.
+	// Resources exceeding the output budget can be read in parts with
+	// ?part=N, their _meta tells how many parts there are.
+	readResource := func(rctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		uri, part, err := resources.SplitPart(request.Params.URI)
+		if err != nil {
+			return nil, err
+		}
+		content, err := provider.GetResource(rctx, uri)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get resource %s: %w", uri, err)
+		}
+
+		// Convert to JSON
+		jsonData, err := json.MarshalIndent(content, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal resource content: %w", err)
+		}
+
+		contents := mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(jsonData),
+		}
+		if parts := ctx.OutputBudget.Parts(contents.Text); len(parts) > 1 || part > 1 {
+			if part > len(parts) {
+				return nil, fmt.Errorf("part %d is past the end of resource %s, which has %d parts", part, uri, len(parts))
+			}
+			contents.Meta = map[string]any{"parts": len(parts), "size": len(contents.Text)}
+			if part > 0 {
+				contents.Text = parts[part-1]
+				contents.Meta["part"] = part
+			}
+		}
+
+		return []mcp.ResourceContents{contents}, nil
+	}
+
+	// Register resource templates for dynamic resources
+	// App resource template
+	appTemplate := mcp.NewResourceTemplate(
+		"app://{namespace}/{name}",
+		"App Resource",
+		mcp.WithTemplateDescription("Giant Swarm app details and status"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+	s.AddResourceTemplate(appTemplate, readResource)
+
+	// README resource template
+	readmeTemplate := mcp.NewResourceTemplate(
+		"readme://{catalog}/{app}/{version}{?part}",
+		"App README",
+		mcp.WithTemplateDescription("README.md extracted from the chart package of an app version"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+	s.AddResourceTemplate(readmeTemplate, readResource)
+
+	// Cluster resource template
+	clusterTemplate := mcp.NewResourceTemplate(
+		"cluster://{namespace}/{name}",
+		"Cluster Resource",
+		mcp.WithTemplateDescription("CAPI cluster details and status"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+	s.AddResourceTemplate(clusterTemplate, readResource)
+
+	// Catalog resource template
+	catalogTemplate := mcp.NewResourceTemplate(
+		"catalog://{name}",
+		"Catalog Resource",
+		mcp.WithTemplateDescription("Giant Swarm catalog details"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+	s.AddResourceTemplate(catalogTemplate, readResource)
+
+	// Config resource template
+	configTemplate := mcp.NewResourceTemplate(
+		"config://{namespace}/{app}/values{?part}",
+		"App Configuration",
+		mcp.WithTemplateDescription("User configuration values of an app"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+	s.AddResourceTemplate(configTemplate, readResource)
+
+	// Schema resource template
+	schemaTemplate := mcp.NewResourceTemplate(
+		"schema://{catalog}/{app}/{version}{?part}",
+		"App Schema",
+		mcp.WithTemplateDescription("Configuration schema of an app version"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+	s.AddResourceTemplate(schemaTemplate, readResource)
+
+	// Default values resource template
+	valuesTemplate := mcp.NewResourceTemplate(
+		"values://{catalog}/{app}/{version}{?part}",
+		"App Default Values",
+		mcp.WithTemplateDescription("Default values.yaml of an app version, parsed to JSON"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+	s.AddResourceTemplate(valuesTemplate, readResource)
+
+	// Changelog resource template
+	changelogTemplate := mcp.NewResourceTemplate(
+		"changelog://{catalog}/{app}{?part}",
+		"App Changelog",
+		mcp.WithTemplateDescription("Versions of an app, newest first, with upgrade hints"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+	s.AddResourceTemplate(changelogTemplate, readResource)
+
+	// Release notes resource template
+	releaseNotesTemplate := mcp.NewResourceTemplate(
+		"releasenotes://{provider}/{version}{?part}",
+		"Platform Release Notes",
+		mcp.WithTemplateDescription("Release notes and component versions of a Giant Swarm platform release"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+	s.AddResourceTemplate(releaseNotesTemplate, readResource)
+
+	// Updates report, regenerated in the background
+	if reporter := ctx.UpdatesReporter; reporter != nil {
+		updatesResource := mcp.NewResource(
+			report.UpdatesURI,
+			"Fleet Updates Report",
+			mcp.WithResourceDescription("Pending app upgrades and configuration drift across the fleet, regenerated in the background"),
+			mcp.WithMIMEType("application/json"),
+		)
+		s.AddResource(updatesResource, func(rctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			latest := reporter.Latest()
+			if latest == nil {
+				return nil, fmt.Errorf("the first updates report is still being generated, try again shortly")
+			}
+			// The report covers all namespaces the server can read
+			if ctx.NamespacePolicy.Active() {
+				latest = latest.Filter(ctx.NamespacePolicy.Allows)
+			}
+
+			jsonData, err := json.MarshalIndent(latest, "", "  ")
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal updates report: %w", err)
+			}
+
+			return []mcp.ResourceContents{
+				mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "application/json",
+					Text:     string(jsonData),
+				},
+			}, nil
+		})
+	}
+
+	return nil
+}
+
+// loadSystemNamespaces combines the system namespaces of --system-namespaces
+// with those listed in --system-namespaces-configmap
+func loadSystemNamespaces(ctx context.Context, k8sClient *k8s.Client, opts *serveOptions) (organization.SystemNamespaces, error) {
+	systemNamespaces, err := organization.NewSystemNamespaces(opts.systemNamespaces)
+	if err != nil {
+		return nil, err
+	}
+	if opts.systemNamespacesConfigMap == "" {
+		return systemNamespaces, nil
+	}
+
+	parts := strings.SplitN(opts.systemNamespacesConfigMap, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid --system-namespaces-configmap %q (expected namespace/name)", opts.systemNamespacesConfigMap)
+	}
+	configured, err := organization.LoadSystemNamespacesFromConfigMap(ctx, k8sClient, parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Loaded %d system namespaces from configmap %s", len(configured), opts.systemNamespacesConfigMap)
+	return append(systemNamespaces, configured...), nil
+}
+
+// initializePrompts registers operator-defined prompts loaded from a directory or ConfigMap.
+// The built-in prompts are registered together with the tools.
+func initializePrompts(ctx context.Context, s *server.MCPServer, serverCtx *internalServer.Context, opts *serveOptions) error {
+	var customPrompts []*prompts.CustomPrompt
+
+	if opts.promptsDir != "" {
+		dirPrompts, err := prompts.LoadCustomPromptsFromDir(opts.promptsDir)
+		if err != nil {
+			return err
+		}
+		customPrompts = append(customPrompts, dirPrompts...)
+	}
+
+	if opts.promptsConfigMap != "" {
+		parts := strings.SplitN(opts.promptsConfigMap, "/", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid --prompts-configmap %q (expected namespace/name)", opts.promptsConfigMap)
+		}
+		cmPrompts, err := prompts.LoadCustomPromptsFromConfigMap(ctx, serverCtx.K8sClient, parts[0], parts[1])
+		if err != nil {
+			return err
+		}
+		customPrompts = append(customPrompts, cmPrompts...)
+	}
+
+	for _, p := range customPrompts {
+		log.Printf("Loaded custom prompt %s from %s", p.Name, p.Source)
+	}
+
+	return prompts.RegisterCustomPrompts(s, customPrompts)
+}
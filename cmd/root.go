@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"os"
+	"sort"
 
 	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
 )
 
 // rootCmd represents the base command for the mcp-giantswarm-apps application.
@@ -49,10 +52,23 @@ func Execute() {
 	}
 }
 
+// completeKubeContexts completes --kube-context flags with the contexts of
+// the kubeconfig
+func completeKubeContexts(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	contexts, _, err := k8s.ListContexts()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	sort.Strings(contexts)
+	return contexts, cobra.ShellCompDirectiveNoFileComp
+}
+
 // init is a special Go function that is executed when the package is initialized.
 // It is used here to add subcommands to the root command.
 func init() {
 	rootCmd.AddCommand(newVersionCmd())
+	rootCmd.AddCommand(newDoctorCmd())
+	rootCmd.AddCommand(newGenerateConfigCmd())
 	rootCmd.AddCommand(newSelfUpdateCmd())
 	rootCmd.AddCommand(newServeCmd())
 
@@ -2,23 +2,34 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
-	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/identity"
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/recording"
 	internalServer "github.com/giantswarm/mcp-giantswarm-apps/internal/server"
-	"github.com/giantswarm/mcp-giantswarm-apps/pkg/prompts"
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/tracing"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/alerts"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cost"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/metrics"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/organization"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/report"
 	"github.com/giantswarm/mcp-giantswarm-apps/pkg/resources"
 	"github.com/giantswarm/mcp-giantswarm-apps/pkg/tools"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	mcpserver "github.com/mark3labs/mcp-go/server"
 )
 
 const (
@@ -26,18 +37,94 @@ const (
 	serverVersion = "0.1.0"
 )
 
+// serveOptions holds the configuration of the serve command
+type serveOptions struct {
+	kubeContext string
+	demo        bool
+
+	// Recording options
+	record string
+	replay string
+
+	// Transport options
+	transport       string
+	httpAddr        string
+	sseEndpoint     string
+	messageEndpoint string
+	httpEndpoint    string
+	shutdownGrace   time.Duration
+	sessionIdentity bool
+
+	// Custom prompt options
+	promptsDir       string
+	promptsConfigMap string
+
+	// Session defaults
+	defaultOrganization string
+	defaultCluster      string
+	defaultNamespace    string
+
+	// Config history options
+	configHistoryRevisions int
+
+	// Namespace restrictions
+	allowedNamespaces []string
+	deniedNamespaces  []string
+
+	// System namespace classification
+	systemNamespaces          []string
+	systemNamespacesConfigMap string
+
+	// App catalog entry cache options
+	catalogIndexRefresh time.Duration
+	blockUntilSynced    bool
+	syncTimeout         time.Duration
+
+	// Workload cluster client options
+	workloadClientTTL time.Duration
+
+	// Result cache options
+	resultCacheTTL time.Duration
+
+	// Kubeconfig reload options
+	kubeconfigReloadInterval time.Duration
+
+	// Background report options
+	updatesReportInterval time.Duration
+
+	// Resource listing options
+	listResourceTypes []string
+	resourcesPageSize int
+
+	// Output budget options
+	maxOutputChars int
+	maxOutputItems int
+
+	// Tool selection options
+	enableTools []string
+	toolProfile string
+	strictNames bool
+
+	// Catalog options
+	validateRemote bool
+	devCatalog     string
+
+	// Cost options
+	costAPI       string
+	costAPIFlavor string
+
+	// Monitoring options
+	prometheus   string
+	alertmanager string
+	runbooks     string
+
+	// Identity options
+	organizationMapping string
+}
+
 // newServeCmd creates the Cobra command for starting the MCP server.
 func newServeCmd() *cobra.Command {
-	var (
-		kubeContext string
-
-		// Transport options
-		transport       string
-		httpAddr        string
-		sseEndpoint     string
-		messageEndpoint string
-		httpEndpoint    string
-	)
+	opts := &serveOptions{}
 
 	cmd := &cobra.Command{
 		Use:   "serve",
@@ -50,351 +137,369 @@ Supports multiple transport types:
   - sse: Server-Sent Events over HTTP
   - streamable-http: Streamable HTTP transport`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runServe(kubeContext, transport, httpAddr, sseEndpoint, messageEndpoint, httpEndpoint)
+			return runServe(opts)
 		},
 	}
 
 	// Add flags for configuring the server
-	cmd.Flags().StringVar(&kubeContext, "kube-context", "", "Kubernetes context to use (defaults to current context)")
+	cmd.Flags().StringVar(&opts.kubeContext, "kube-context", "", "Kubernetes context to use (defaults to current context)")
+	cmd.Flags().BoolVar(&opts.demo, "demo", false, "Serve a bundled demo installation of organizations, clusters, catalogs and apps instead of connecting to a cluster; changes are kept in memory")
+	cmd.Flags().StringVar(&opts.record, "record", "", "File every tool call and its result is appended to, as JSON lines")
+	cmd.Flags().StringVar(&opts.replay, "replay", "", "Recording (written with --record) whose results answer the tool calls instead of a cluster")
+	cmd.Flags().DurationVar(&opts.kubeconfigReloadInterval, "kubeconfig-reload-interval", 10*time.Second, "How often the kubeconfig file is checked for changed credentials, which are then used without a restart (0 disables reloading)")
 
 	// Transport flags
-	cmd.Flags().StringVar(&transport, "transport", "stdio", "Transport type: stdio, sse, or streamable-http")
-	cmd.Flags().StringVar(&httpAddr, "http-addr", ":8080", "HTTP server address (for sse and streamable-http transports)")
-	cmd.Flags().StringVar(&sseEndpoint, "sse-endpoint", "/sse", "SSE endpoint path (for sse transport)")
-	cmd.Flags().StringVar(&messageEndpoint, "message-endpoint", "/message", "Message endpoint path (for sse transport)")
-	cmd.Flags().StringVar(&httpEndpoint, "http-endpoint", "/mcp", "HTTP endpoint path (for streamable-http transport)")
+	cmd.Flags().StringVar(&opts.transport, "transport", "stdio", "Transport type: stdio, sse, or streamable-http")
+	_ = cmd.RegisterFlagCompletionFunc("kube-context", completeKubeContexts)
+	_ = cmd.RegisterFlagCompletionFunc("transport", cobra.FixedCompletions(transports, cobra.ShellCompDirectiveNoFileComp))
+	cmd.Flags().StringVar(&opts.httpAddr, "http-addr", ":8080", "HTTP server address (for sse and streamable-http transports)")
+	cmd.Flags().StringVar(&opts.sseEndpoint, "sse-endpoint", "/sse", "SSE endpoint path (for sse transport)")
+	cmd.Flags().StringVar(&opts.messageEndpoint, "message-endpoint", "/message", "Message endpoint path (for sse transport)")
+	cmd.Flags().StringVar(&opts.httpEndpoint, "http-endpoint", "/mcp", "HTTP endpoint path (for streamable-http transport)")
+	cmd.Flags().BoolVar(&opts.sessionIdentity, "session-identity", false, "Require every session to authenticate with a Kubernetes bearer token and make its requests impersonating that user (for sse and streamable-http transports)")
+	cmd.Flags().DurationVar(&opts.shutdownGrace, "shutdown-grace", 30*time.Second, "How long running tool calls may take to finish on shutdown (for sse and streamable-http transports)")
+
+	// Custom prompt flags
+	cmd.Flags().StringVar(&opts.promptsDir, "prompts-dir", "", "Directory with additional prompt definitions (.md or .yaml)")
+	cmd.Flags().StringVar(&opts.promptsConfigMap, "prompts-configmap", "", "ConfigMap with additional prompt definitions (namespace/name)")
+
+	// Session default flags
+	cmd.Flags().StringVar(&opts.defaultOrganization, "default-organization", "", "Organization used when a tool call omits it")
+	cmd.Flags().StringVar(&opts.defaultCluster, "default-cluster", "", "Workload cluster used when a tool call omits it")
+	cmd.Flags().StringVar(&opts.defaultNamespace, "default-namespace", "", "Namespace used when a tool call omits it")
+	cmd.Flags().StringVar(&opts.organizationMapping, "organization-mapping", "", "YAML file mapping users and groups to organizations, used to detect the default organization")
+
+	// Config history flags
+	cmd.Flags().IntVar(&opts.configHistoryRevisions, "config-history-revisions", 0, "Previous revisions to keep per ConfigMap/Secret changed by the config tools (0 disables history)")
+
+	// Namespace restriction flags
+	cmd.Flags().StringSliceVar(&opts.allowedNamespaces, "allowed-namespaces", nil, "Glob patterns of namespaces tools may operate on (e.g. org-acme,org-team-*)")
+	cmd.Flags().StringSliceVar(&opts.deniedNamespaces, "denied-namespaces", nil, "Glob patterns of namespaces tools may not operate on, applied after --allowed-namespaces")
+
+	// System namespace flags
+	cmd.Flags().StringSliceVar(&opts.systemNamespaces, "system-namespaces", []string(organization.DefaultSystemNamespaces), "Names or glob patterns of namespaces classified as system namespaces")
+	cmd.Flags().StringVar(&opts.systemNamespacesConfigMap, "system-namespaces-configmap", "", "ConfigMap (namespace/name) whose \"namespaces\" key lists additional system namespaces")
+
+	// App catalog entry cache flags
+	cmd.Flags().DurationVar(&opts.catalogIndexRefresh, "catalog-index-refresh", 5*time.Minute, "How often the in-memory index of app catalog entries is refreshed (0 disables the index)")
+	cmd.Flags().BoolVar(&opts.blockUntilSynced, "block-until-synced", false, "Wait for the app catalog entry index to load before serving requests")
+	cmd.Flags().DurationVar(&opts.syncTimeout, "sync-timeout", 2*time.Minute, "How long --block-until-synced waits before giving up")
+
+	// Workload cluster client flags
+	cmd.Flags().DurationVar(&opts.workloadClientTTL, "workload-client-ttl", 5*time.Minute, "How long a workload cluster client is reused before its kubeconfig secret is checked for rotation")
+
+	// Result cache flags
+	cmd.Flags().DurationVar(&opts.resultCacheTTL, "result-cache-ttl", 2*time.Minute, "How long results of expensive read-only tools (config_schema, appcatalogentry_search, appcatalogentry_versions, appcatalogentry_readme) answer identical calls (0 disables the cache)")
+
+	// Background report flags
+	cmd.Flags().DurationVar(&opts.updatesReportInterval, "updates-report-interval", 0, "How often the pending upgrades and drift report served as "+report.UpdatesURI+" is regenerated (0 disables the report)")
+
+	// Resource listing flags
+	cmd.Flags().StringSliceVar(&opts.listResourceTypes, "list-resource-types", []string{"app", "catalog", "cluster"}, "Resource types enumerated by resources/list (app, config, catalog, cluster, schema, changelog); empty lists none")
+	cmd.Flags().IntVar(&opts.resourcesPageSize, "resources-page-size", resources.DefaultListLimit, "Maximum number of resources per resources/list page")
+
+	// Tool selection flags
+	cmd.Flags().StringSliceVar(&opts.enableTools, "enable-tools", nil, "Tool groups to register (default: all): "+strings.Join(toolGroupNames(), ", "))
+	cmd.Flags().StringVar(&opts.toolProfile, "tool-profile", tools.ProfileAll, "Tools of the enabled groups to register: "+strings.Join(tools.Profiles, ", ")+" (read-only leaves out all tools that change resources)")
+
+	cmd.Flags().BoolVar(&opts.strictNames, "strict-names", false, "Only register the current tool names, not the deprecated dotted (app.list) and former names")
+
+	// Output budget flags
+	cmd.Flags().IntVar(&opts.maxOutputChars, "max-output-chars", 50000, "Maximum size of a tool result in bytes; listings continue on the next call, other results are cut (0 is unlimited)")
+	cmd.Flags().IntVar(&opts.maxOutputItems, "max-output-items", 100, "Maximum entries a listing tool returns per call (0 is unlimited)")
+
+	// Catalog flags
+	cmd.Flags().BoolVar(&opts.validateRemote, "validate-remote", false, "Check that repository URLs are reachable (Helm index.yaml or OCI registry) before catalog_create and catalog_update save them")
+	cmd.Flags().StringVar(&opts.devCatalog, "dev-catalog", "", "OCI catalog app_dev_deploy pushes local charts to, as namespace/name (default namespace: default); app_dev_deploy is refused without it")
+
+	// Cost flags
+	cmd.Flags().StringVar(&opts.costAPI, "cost-api", "", "OpenCost or Kubecost allocation API for app_cost: a URL, or namespace/service:port of the service in every cluster, reached through the API server proxy")
+	cmd.Flags().StringVar(&opts.costAPIFlavor, "cost-api-flavor", cost.FlavorOpenCost, "Flavor of --cost-api: opencost or kubecost")
+
+	// Monitoring flags
+	cmd.Flags().StringVar(&opts.prometheus, "prometheus", "", "Prometheus queried by cluster_metrics instead of the metrics-server: a URL, or namespace/service:port of the service in every cluster, reached through the API server proxy")
+	cmd.Flags().StringVar(&opts.alertmanager, "alertmanager", "", "Alertmanager of the installation queried by alerts_list and the troubleshoot-app prompt: a URL, or namespace/service:port of the service in the management cluster, reached through the API server proxy")
+	cmd.Flags().StringVar(&opts.runbooks, "runbooks", "", "YAML file mapping app names to troubleshooting docs shown by app_get and the troubleshoot-app prompt, replacing the built-in docs of the apps it lists")
 
 	return cmd
 }
 
 // runServe contains the main server logic with support for multiple transports
-func runServe(kubeContext, transport, httpAddr, sseEndpoint, messageEndpoint, httpEndpoint string) error {
+func runServe(opts *serveOptions) error {
 	// Initialize logger
 	log.SetFlags(log.Ldate | log.Ltime | log.Lshortfile)
 	log.Printf("Starting %s v%s", serverName, rootCmd.Version)
 
-	// Setup graceful shutdown - listen for both SIGINT and SIGTERM
-	shutdownCtx, cancel := signal.NotifyContext(context.Background(),
-		os.Interrupt, syscall.SIGTERM)
-	defer cancel()
-
-	// Initialize Kubernetes client
-	ctx := context.Background()
-	if kubeContext == "" {
-		kubeContext = os.Getenv("KUBE_CONTEXT") // Allow overriding context via env var
+	// Fail on unknown tool groups and profiles before connecting to the cluster
+	if _, err := selectToolGroups(opts.enableTools); err != nil {
+		return err
 	}
-
-	k8sClient, err := k8s.NewClient(ctx, kubeContext)
-	if err != nil {
-		return fmt.Errorf("failed to initialize Kubernetes client: %v", err)
+	if !slices.Contains(tools.Profiles, opts.toolProfile) {
+		return fmt.Errorf("unknown tool profile %q (valid: %s)", opts.toolProfile, strings.Join(tools.Profiles, ", "))
 	}
-	log.Printf("Connected to Kubernetes cluster (context: %s)", k8sClient.GetCurrentContext())
-
-	// Initialize dynamic client for CRDs
-	dynamicClient, err := k8s.NewDynamicClient(k8sClient)
-	if err != nil {
-		return fmt.Errorf("failed to initialize dynamic client: %v", err)
+	var costAPI *cost.API
+	if opts.costAPI != "" {
+		api, err := cost.ParseAPI(opts.costAPI, opts.costAPIFlavor)
+		if err != nil {
+			return err
+		}
+		costAPI = api
 	}
-
-	// Check if Giant Swarm CRDs are available
-	if err := dynamicClient.CheckCRDsExist(ctx, k8sClient); err != nil {
-		log.Printf("Warning: %v", err)
-		log.Println("Make sure you're connected to a Giant Swarm management cluster")
+	var prometheus *metrics.Prometheus
+	if opts.prometheus != "" {
+		p, err := metrics.ParsePrometheus(opts.prometheus)
+		if err != nil {
+			return err
+		}
+		prometheus = p
 	}
-
-	// Create server context
-	serverCtx := internalServer.NewContext(k8sClient, dynamicClient)
-
-	// Create MCP server
-	mcpSrv := server.NewMCPServer(
-		serverName,
-		rootCmd.Version, // Use version from root command
-		server.WithToolCapabilities(true),
-		server.WithResourceCapabilities(true, true), // subscribe, list
-		server.WithPromptCapabilities(true),
-		server.WithLogging(),
-	)
-
-	// Initialize tools
-	if err := initializeTools(mcpSrv, serverCtx); err != nil {
-		return fmt.Errorf("failed to initialize tools: %v", err)
+	var alertmanager *alerts.Alertmanager
+	if opts.alertmanager != "" {
+		am, err := alerts.ParseAlertmanager(opts.alertmanager)
+		if err != nil {
+			return err
+		}
+		alertmanager = am
 	}
-
-	// Initialize resources
-	if err := initializeResources(mcpSrv, serverCtx); err != nil {
-		return fmt.Errorf("failed to initialize resources: %v", err)
+	devCatalog, err := parseDevCatalog(opts.devCatalog)
+	if err != nil {
+		return err
 	}
-
-	// Initialize prompts
-	if err := initializePrompts(mcpSrv, serverCtx); err != nil {
-		return fmt.Errorf("failed to initialize prompts: %v", err)
+	if opts.sessionIdentity && opts.transport == "stdio" {
+		return fmt.Errorf("--session-identity requires the sse or streamable-http transport")
 	}
-
-	fmt.Printf("Starting MCP Giant Swarm Apps server with %s transport...\n", transport)
-
-	// Start the appropriate server based on transport type
-	switch transport {
-	case "stdio":
-		return runStdioServer(mcpSrv)
-	case "sse":
-		return runSSEServer(mcpSrv, httpAddr, sseEndpoint, messageEndpoint, shutdownCtx)
-	case "streamable-http":
-		return runStreamableHTTPServer(mcpSrv, httpAddr, httpEndpoint, shutdownCtx)
-	default:
-		return fmt.Errorf("unsupported transport type: %s (supported: stdio, sse, streamable-http)", transport)
+	if opts.sessionIdentity && (opts.demo || opts.replay != "") {
+		return fmt.Errorf("--session-identity cannot be used with --demo or --replay")
 	}
-}
-
-// runStdioServer runs the server with STDIO transport
-func runStdioServer(mcpSrv *mcpserver.MCPServer) error {
-	// Start the server in a goroutine so we can handle shutdown signals
-	serverDone := make(chan error, 1)
-	go func() {
-		defer close(serverDone)
-		if err := mcpserver.ServeStdio(mcpSrv); err != nil {
-			serverDone <- err
-		}
-	}()
-
-	// Wait for server completion
-	select {
-	case err := <-serverDone:
-		if err != nil {
-			return fmt.Errorf("server stopped with error: %w", err)
-		} else {
-			fmt.Println("Server stopped normally")
-		}
+	if opts.record != "" && opts.replay != "" {
+		return fmt.Errorf("--record and --replay cannot be used together")
 	}
-
-	fmt.Println("Server gracefully stopped")
-	return nil
-}
-
-// runSSEServer runs the server with SSE transport
-func runSSEServer(mcpSrv *mcpserver.MCPServer, addr, sseEndpoint, messageEndpoint string, ctx context.Context) error {
-	// Create SSE server with custom endpoints
-	sseServer := mcpserver.NewSSEServer(mcpSrv,
-		mcpserver.WithSSEEndpoint(sseEndpoint),
-		mcpserver.WithMessageEndpoint(messageEndpoint),
-	)
-
-	fmt.Printf("SSE server starting on %s\n", addr)
-	fmt.Printf("  SSE endpoint: %s\n", sseEndpoint)
-	fmt.Printf("  Message endpoint: %s\n", messageEndpoint)
-
-	// Start server in goroutine
-	serverDone := make(chan error, 1)
-	go func() {
-		defer close(serverDone)
-		if err := sseServer.Start(addr); err != nil {
-			serverDone <- err
-		}
-	}()
-
-	// Wait for either shutdown signal or server completion
-	select {
-	case <-ctx.Done():
-		fmt.Println("Shutdown signal received, stopping SSE server...")
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30)
-		defer cancel()
-		if err := sseServer.Shutdown(shutdownCtx); err != nil {
-			return fmt.Errorf("error shutting down SSE server: %w", err)
-		}
-	case err := <-serverDone:
+	var replayer *recording.Replayer
+	if opts.replay != "" {
+		r, err := recording.Load(opts.replay)
 		if err != nil {
-			return fmt.Errorf("SSE server stopped with error: %w", err)
-		} else {
-			fmt.Println("SSE server stopped normally")
+			return err
 		}
+		replayer = r
 	}
 
-	fmt.Println("SSE server gracefully stopped")
-	return nil
-}
-
-// runStreamableHTTPServer runs the server with Streamable HTTP transport
-func runStreamableHTTPServer(mcpSrv *mcpserver.MCPServer, addr, endpoint string, ctx context.Context) error {
-	// Create Streamable HTTP server with custom endpoint
-	httpServer := mcpserver.NewStreamableHTTPServer(mcpSrv,
-		mcpserver.WithEndpointPath(endpoint),
-	)
-
-	fmt.Printf("Streamable HTTP server starting on %s\n", addr)
-	fmt.Printf("  HTTP endpoint: %s\n", endpoint)
+	// Setup graceful shutdown - listen for both SIGINT and SIGTERM
+	shutdownCtx, cancel := signal.NotifyContext(context.Background(),
+		os.Interrupt, syscall.SIGTERM)
+	defer cancel()
 
-	// Start server in goroutine
-	serverDone := make(chan error, 1)
-	go func() {
-		defer close(serverDone)
-		if err := httpServer.Start(addr); err != nil {
-			serverDone <- err
-		}
-	}()
-
-	// Wait for either shutdown signal or server completion
-	select {
-	case <-ctx.Done():
-		fmt.Println("Shutdown signal received, stopping HTTP server...")
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30)
-		defer cancel()
-		if err := httpServer.Shutdown(shutdownCtx); err != nil {
-			return fmt.Errorf("error shutting down HTTP server: %w", err)
-		}
-	case err := <-serverDone:
+	// Export traces when an OTLP endpoint is configured
+	if tracing.Enabled() {
+		shutdownTracing, err := tracing.Setup(shutdownCtx, serverName, rootCmd.Version)
 		if err != nil {
-			return fmt.Errorf("HTTP server stopped with error: %w", err)
-		} else {
-			fmt.Println("HTTP server stopped normally")
+			return err
 		}
+		defer func() {
+			flushCtx, cancelFlush := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancelFlush()
+			if err := shutdownTracing(flushCtx); err != nil {
+				log.Printf("Warning: failed to flush traces: %v", err)
+			}
+		}()
+		log.Println("OpenTelemetry tracing enabled")
 	}
 
-	fmt.Println("HTTP server gracefully stopped")
-	return nil
-}
-
-// initializeTools registers all MCP tools with the server (moved from original main.go)
-func initializeTools(s *server.MCPServer, ctx *internalServer.Context) error {
-	// Register app management tools
-	if err := tools.RegisterAppTools(s, ctx); err != nil {
-		return fmt.Errorf("failed to register app tools: %w", err)
+	ctx := context.Background()
+	k8sClient, dynamicClient, err := newClients(ctx, opts)
+	if err != nil {
+		return err
 	}
 
-	// Register catalog management tools
-	if err := tools.RegisterCatalogTools(s, ctx); err != nil {
-		return fmt.Errorf("failed to register catalog tools: %w", err)
+	// Create server context
+	serverCtx := internalServer.NewContext(k8sClient, dynamicClient)
+	serverCtx.SetDefaults(ctx, internalServer.Defaults{
+		Organization: opts.defaultOrganization,
+		Cluster:      opts.defaultCluster,
+		Namespace:    opts.defaultNamespace,
+	})
+	serverCtx.ConfigHistoryRevisions = opts.configHistoryRevisions
+	serverCtx.ValidateRemote = opts.validateRemote
+	serverCtx.LocalFiles = opts.transport == "stdio"
+	serverCtx.DevCatalog = devCatalog
+	serverCtx.CostAPI = costAPI
+	serverCtx.Prometheus = prometheus
+	serverCtx.Alertmanager = alertmanager
+	serverCtx.OutputBudget = internalServer.OutputBudget{
+		MaxChars: opts.maxOutputChars,
+		MaxItems: opts.maxOutputItems,
 	}
 
-	// Register app catalog entry tools
-	if err := tools.RegisterAppCatalogEntryTools(s, ctx); err != nil {
-		return fmt.Errorf("failed to register app catalog entry tools: %w", err)
+	namespacePolicy, err := internalServer.NewNamespacePolicy(opts.allowedNamespaces, opts.deniedNamespaces)
+	if err != nil {
+		return err
 	}
-
-	// Register configuration management tools
-	if err := tools.RegisterConfigTools(s, ctx); err != nil {
-		return fmt.Errorf("failed to register config tools: %w", err)
+	serverCtx.NamespacePolicy = namespacePolicy
+	if err := tools.CheckDefaultsPolicy(namespacePolicy, serverCtx.Defaults(ctx)); err != nil {
+		return fmt.Errorf("invalid default namespace: %w", err)
 	}
 
-	// Register organization management tools
-	if err := tools.RegisterOrganizationTools(s, ctx); err != nil {
-		return fmt.Errorf("failed to register organization tools: %w", err)
+	systemNamespaces, err := loadSystemNamespaces(ctx, k8sClient, opts)
+	if err != nil {
+		return err
 	}
+	serverCtx.SystemNamespaces = systemNamespaces
 
-	// Register cluster management tools for CAPI integration
-	if err := tools.RegisterClusterTools(s, ctx); err != nil {
-		return fmt.Errorf("failed to register cluster tools: %w", err)
+	// Default the organization to the one the current identity belongs to
+	var mapping *identity.Mapping
+	if opts.organizationMapping != "" {
+		if mapping, err = identity.LoadMapping(opts.organizationMapping); err != nil {
+			return err
+		}
+	}
+	serverCtx.Identity = identity.NewResolver(k8sClient, mapping)
+	// The server's own organization is no default for the users of sessions
+	if opts.defaultOrganization == "" && !opts.demo && replayer == nil && !opts.sessionIdentity {
+		detectDefaultOrganization(ctx, serverCtx)
 	}
 
-	// Register prompts
-	if err := prompts.RegisterPrompts(s, ctx); err != nil {
-		return fmt.Errorf("failed to register prompts: %w", err)
+	// Troubleshooting docs of well-known apps, extended by --runbooks
+	if opts.runbooks != "" {
+		if serverCtx.Runbooks, err = app.LoadRunbooks(opts.runbooks); err != nil {
+			return err
+		}
 	}
 
-	// Health check tool
-	healthTool := mcp.NewTool(
-		"health",
-		mcp.WithDescription("Check MCP server and Kubernetes connection health"),
-	)
+	serverCtx.WorkloadClients = cluster.NewClientPool(k8sClient, opts.workloadClientTTL)
+	if opts.resultCacheTTL > 0 {
+		serverCtx.ResultCache = internalServer.NewResultCache(opts.resultCacheTTL)
+	}
 
-	s.AddTool(healthTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Check Kubernetes connection
-		version, err := ctx.K8sClient.Discovery().ServerVersion()
-		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Kubernetes connection failed: %v", err)), nil
+	// Serve catalog entry lookups from memory, refreshed in the background.
+	// The index lists entries as the server, so sessions acting as their own
+	// user look them up with their own permissions instead.
+	if opts.catalogIndexRefresh > 0 && !opts.sessionIdentity {
+		serverCtx.AppCatalogEntryIndex = appcatalogentry.NewIndex(dynamicClient, opts.catalogIndexRefresh)
+		serverCtx.AppCatalogEntryIndex.Start(shutdownCtx)
+
+		if opts.blockUntilSynced {
+			log.Println("Waiting for the app catalog entry index to sync...")
+			syncCtx, cancelSync := context.WithTimeout(shutdownCtx, opts.syncTimeout)
+			err := serverCtx.AppCatalogEntryIndex.WaitForSync(syncCtx)
+			cancelSync()
+			if err != nil {
+				return err
+			}
+			_, took := serverCtx.AppCatalogEntryIndex.WarmUp()
+			log.Printf("App catalog entry index synced in %s", took.Round(time.Millisecond))
 		}
+	}
 
-		// Check Giant Swarm CRDs
-		crdStatus := "available"
-		if err := ctx.DynamicClient.CheckCRDsExist(toolCtx, ctx.K8sClient); err != nil {
-			crdStatus = fmt.Sprintf("not available: %v", err)
+	// Pick up rotated credentials, e.g. from tsh, without a restart
+	k8sClient.WatchKubeconfig(shutdownCtx, opts.kubeconfigReloadInterval, func() {
+		serverCtx.WorkloadClients.InvalidateAll()
+		serverCtx.Identity.Reset()
+		serverCtx.ResetCreators()
+		if serverCtx.ResultCache != nil {
+			serverCtx.ResultCache.Clear()
+		}
+		if index := serverCtx.AppCatalogEntryIndex; index != nil {
+			if err := index.Refresh(shutdownCtx); err != nil {
+				log.Printf("Warning: failed to refresh app catalog entry index: %v", err)
+			}
 		}
-
-		healthStatus := fmt.Sprintf(`MCP Server Health Check:
-- Server: %s v%s (healthy)
-- Kubernetes: connected to %s
-  - Version: %s
-  - Context: %s
-- Giant Swarm CRDs: %s`,
-			serverName, rootCmd.Version,
-			version.GitVersion,
-			version.GitVersion,
-			ctx.K8sClient.GetCurrentContext(),
-			crdStatus,
-		)
-
-		return mcp.NewToolResultText(healthStatus), nil
 	})
 
-	// List contexts tool
-	listContextsTool := mcp.NewTool(
-		"kubernetes_contexts",
-		mcp.WithDescription("List available Kubernetes contexts"),
-	)
+	// Generate the updates report in the background. It is generated as the
+	// server, so sessions acting as their own user don't get it.
+	if opts.updatesReportInterval > 0 && !opts.sessionIdentity {
+		serverCtx.UpdatesReporter = report.NewReporter(k8sClient, dynamicClient, serverCtx.AppCatalogEntryIndex, opts.updatesReportInterval)
+	}
 
-	s.AddTool(listContextsTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		contexts, current, err := k8s.ListContexts()
+	// Create MCP server
+	hooks := &server.Hooks{}
+	aliases := tools.NewToolAliases()
+	drainer := internalServer.NewDrainer()
+	hooks.AddOnUnregisterSession(serverCtx.UnregisterSession)
+
+	// Act as the user of each session instead of the server's own identity
+	var sessions *identity.Sessions
+	if opts.sessionIdentity {
+		sessions = identity.NewSessions(k8sClient)
+		hooks.AddOnRequestInitialization(sessions.Authorize)
+		hooks.AddOnUnregisterSession(sessions.Unregister)
+		log.Println("Session identity enabled, requests impersonate the user of each session")
+	}
+	serverOpts := []server.ServerOption{
+		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(true, true), // subscribe, list
+		server.WithPromptCapabilities(true),
+		server.WithLogging(),
+		server.WithToolHandlerMiddleware(drainer.Middleware()),
+	}
+	// Record and replay the results the client gets, after all other middlewares
+	if opts.record != "" {
+		recorder, err := recording.NewRecorder(opts.record)
 		if err != nil {
-			return nil, fmt.Errorf("failed to list contexts: %w", err)
+			return err
 		}
+		defer recorder.Close()
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(recorder.Middleware()))
+		log.Printf("Recording tool calls to %s", opts.record)
+	}
+	if replayer != nil {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(replayer.Middleware()))
+		log.Printf("Replaying %d recorded tool calls from %s", replayer.Calls(), opts.replay)
+	}
+	serverOpts = append(serverOpts,
+		server.WithToolHandlerMiddleware(tracing.ToolMiddleware()),
+		server.WithToolHandlerMiddleware(tools.ResultCacheMiddleware(serverCtx, aliases)),
+		server.WithToolHandlerMiddleware(tools.OutputBudgetMiddleware(serverCtx)),
+		server.WithHooks(hooks),
+		server.WithToolFilter(aliases.Filter),
+	)
+	mcpSrv := server.NewMCPServer(
+		serverName,
+		rootCmd.Version, // Use version from root command
+		serverOpts...,
+	)
 
-		result := "Available Kubernetes contexts:\n"
-		for _, context := range contexts {
-			marker := "  "
-			if context == current {
-				marker = "* "
-			}
-			result += fmt.Sprintf("%s%s\n", marker, context)
-		}
+	// Initialize tools
+	if err := initializeTools(mcpSrv, serverCtx, opts.enableTools, opts.toolProfile); err != nil {
+		return fmt.Errorf("failed to initialize tools: %v", err)
+	}
+	if !opts.strictNames {
+		aliases.Register(mcpSrv)
+	}
 
-		return mcp.NewToolResultText(result), nil
-	})
+	// Initialize resources
+	if err := initializeResources(mcpSrv, hooks, serverCtx, opts); err != nil {
+		return fmt.Errorf("failed to initialize resources: %v", err)
+	}
 
-	return nil
-}
+	// Start the reporter once the server can notify subscribers of new reports
+	if reporter := serverCtx.UpdatesReporter; reporter != nil {
+		reporter.OnUpdate(func(*report.UpdatesReport) {
+			mcpSrv.SendNotificationToAllClients(mcp.MethodNotificationResourceUpdated, map[string]any{"uri": report.UpdatesURI})
+		})
+		reporter.Start(shutdownCtx)
+	}
 
-// initializeResources registers all MCP resources with the server (moved from original main.go)
-func initializeResources(s *server.MCPServer, ctx *internalServer.Context) error {
-	// Create resource provider
-	provider := resources.NewProvider(ctx.K8sClient, ctx.DynamicClient)
-
-	// Register resource templates for dynamic resources
-	// App resource template
-	appTemplate := mcp.NewResourceTemplate(
-		"app://{namespace}/{name}",
-		"App Resource",
-		mcp.WithTemplateDescription("Giant Swarm app details and status"),
-		mcp.WithTemplateMIMEType("application/json"),
-	)
+	// Initialize prompts
+	if err := initializePrompts(ctx, mcpSrv, serverCtx, opts); err != nil {
+		return fmt.Errorf("failed to initialize prompts: %v", err)
+	}
 
-	s.AddResourceTemplate(appTemplate, func(rctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		content, err := provider.GetResource(rctx, request.Params.URI)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get resource %s: %w", request.Params.URI, err)
-		}
+	fmt.Printf("Starting MCP Giant Swarm Apps server with %s transport...\n", opts.transport)
 
-		// Convert to JSON
-		jsonData, err := json.MarshalIndent(content, "", "  ")
+	// Start the appropriate server based on transport type
+	switch opts.transport {
+	case "stdio":
+		return runStdioServer(mcpSrv)
+	case "sse", "streamable-http":
+		listener, err := net.Listen("tcp", opts.httpAddr)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal resource content: %w", err)
+			return fmt.Errorf("failed to listen on %s: %w", opts.httpAddr, err)
 		}
-
-		return []mcp.ResourceContents{
-			mcp.TextResourceContents{
-				URI:      request.Params.URI,
-				MIMEType: "application/json",
-				Text:     string(jsonData),
-			},
-		}, nil
-	})
-
-	// Add remaining resource templates (simplified for now)
-	// Full implementation would include catalog, config, schema, changelog templates
-
-	return nil
-}
-
-// initializePrompts registers all MCP prompts with the server (moved from original main.go)
-func initializePrompts(s *server.MCPServer, ctx *internalServer.Context) error {
-	// Placeholder for prompt initialization
-	// We'll add actual prompts in subsequent commits
-	return nil
+		if opts.transport == "sse" {
+			return runSSEServer(shutdownCtx, mcpSrv, listener, opts, internalServer.ReadinessHandler(serverCtx), drainer, sessions)
+		}
+		return runStreamableHTTPServer(shutdownCtx, mcpSrv, listener, opts, internalServer.ReadinessHandler(serverCtx), drainer, sessions)
+	default:
+		return fmt.Errorf("unsupported transport type: %s (supported: stdio, sse, streamable-http)", opts.transport)
+	}
 }
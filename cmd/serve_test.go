@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	internalServer "github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	gstesting "github.com/giantswarm/mcp-giantswarm-apps/pkg/testing"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/tools"
+)
+
+// resourceClient returns a client of a server serving the resources of ctx
+func resourceClient(t *testing.T, ctx *internalServer.Context, opts *serveOptions) *client.Client {
+	t.Helper()
+
+	hooks := &mcpserver.Hooks{}
+	s := mcpserver.NewMCPServer("test", "0.0.0", mcpserver.WithResourceCapabilities(false, true), mcpserver.WithHooks(hooks))
+	if err := initializeResources(s, hooks, ctx, opts); err != nil {
+		t.Fatal(err)
+	}
+	return startClient(t, s)
+}
+
+// startClient returns an initialized in-process client of s
+func startClient(t *testing.T, s *mcpserver.MCPServer) *client.Client {
+	t.Helper()
+
+	c, err := client.NewInProcessClient(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Initialize(context.Background(), mcp.InitializeRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func readResource(c *client.Client, uri string) error {
+	req := mcp.ReadResourceRequest{}
+	req.Params.URI = uri
+	_, err := c.ReadResource(context.Background(), req)
+	return err
+}
+
+func TestReadResourceNamespacePolicy(t *testing.T) {
+	ctx := gstesting.NewServerContext(
+		gstesting.DeployedApp("org-acme", "hello-world", "giantswarm", "2.3.0"),
+		gstesting.DeployedApp("org-globex", "hello-world", "giantswarm", "2.3.0"),
+		gstesting.ProvisionedCluster("globex", "prod", "30.0.0"),
+	)
+	policy, err := internalServer.NewNamespacePolicy(nil, []string{"org-globex"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx.NamespacePolicy = policy
+	c := resourceClient(t, ctx, &serveOptions{})
+
+	if err := readResource(c, "app://org-acme/hello-world"); err != nil {
+		t.Errorf("reading an allowed app failed: %v", err)
+	}
+	for _, uri := range []string{
+		"app://org-globex/hello-world",
+		"cluster://org-globex/prod",
+		"config://org-globex/hello-world/values",
+	} {
+		if err := readResource(c, uri); err == nil || !strings.Contains(err.Error(), "not allowed") {
+			t.Errorf("reading %s of a denied namespace: error = %v, want not allowed", uri, err)
+		}
+	}
+}
+
+func TestInitializeToolsProfiles(t *testing.T) {
+	tests := []struct {
+		name        string
+		enabled     []string
+		profile     string
+		wantTools   []string
+		wantMissing []string
+		wantPrompts []string
+	}{
+		{
+			name:        "all groups",
+			profile:     tools.ProfileAll,
+			wantTools:   []string{"app_create", "catalog_delete", "app_list"},
+			wantPrompts: []string{"deploy-app", "upgrade-app", "troubleshoot-app", "create-catalog", "configure-app", "decommission-organization"},
+		},
+		{
+			name:        "catalog group",
+			enabled:     []string{"catalog"},
+			profile:     tools.ProfileAll,
+			wantTools:   []string{"catalog_create"},
+			wantMissing: []string{"app_create", "app_get"},
+			wantPrompts: []string{"create-catalog"},
+		},
+		{
+			name:        "read-only profile",
+			profile:     tools.ProfileReadOnly,
+			wantTools:   []string{"app_list", "app_get", "cluster_health", "session_set_defaults"},
+			wantMissing: []string{"app_create", "app_delete", "secret_update", "config_orphans", "manifest_apply"},
+			wantPrompts: []string{"troubleshoot-app"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := mcpserver.NewMCPServer("test", "0.0.0", mcpserver.WithToolCapabilities(false), mcpserver.WithPromptCapabilities(false))
+			if err := initializeTools(s, gstesting.NewServerContext(), tt.enabled, tt.profile); err != nil {
+				t.Fatal(err)
+			}
+			for _, name := range tt.wantTools {
+				if s.GetTool(name) == nil {
+					t.Errorf("tool %s is not registered", name)
+				}
+			}
+			for _, name := range tt.wantMissing {
+				if s.GetTool(name) != nil {
+					t.Errorf("tool %s is registered", name)
+				}
+			}
+
+			result, err := startClient(t, s).ListPrompts(context.Background(), mcp.ListPromptsRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var prompts []string
+			for _, prompt := range result.Prompts {
+				prompts = append(prompts, prompt.Name)
+			}
+			slices.Sort(prompts)
+			want := slices.Clone(tt.wantPrompts)
+			slices.Sort(want)
+			if !slices.Equal(prompts, want) {
+				t.Errorf("prompts = %v, want %v", prompts, want)
+			}
+		})
+	}
+}
+
+func TestReadOnlyProfileKeepsReadTools(t *testing.T) {
+	all := mcpserver.NewMCPServer("test", "0.0.0")
+	readOnly := mcpserver.NewMCPServer("test", "0.0.0")
+	for s, profile := range map[*mcpserver.MCPServer]string{all: tools.ProfileAll, readOnly: tools.ProfileReadOnly} {
+		if err := initializeTools(s, gstesting.NewServerContext(), nil, profile); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Every tool kept by the profile exists, and none changes resources
+	kept := readOnly.ListTools()
+	if len(kept) == 0 || len(kept) == len(all.ListTools()) {
+		t.Fatalf("read-only profile kept %d of %d tools", len(kept), len(all.ListTools()))
+	}
+	for name := range kept {
+		for _, verb := range []string{"_create", "_update", "_delete", "_set", "_apply", "_label", "_annotate", "_pause", "_resume", "_rollback", "_import", "_promote"} {
+			if strings.HasSuffix(name, verb) && name != "session_set_defaults" {
+				t.Errorf("read-only profile kept %s", name)
+			}
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	internalServer "github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/prompts"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// toolGroup is a set of tools that can be enabled with --enable-tools
+type toolGroup struct {
+	name     string
+	register func(*server.MCPServer, *internalServer.Context) error
+}
+
+// toolGroups lists all tool groups in registration order
+var toolGroups = []toolGroup{
+	{"app", tools.RegisterAppTools},
+	{"catalog", tools.RegisterCatalogTools},
+	{"appcatalogentry", tools.RegisterAppCatalogEntryTools},
+	{"config", tools.RegisterConfigTools},
+	{"organization", tools.RegisterOrganizationTools},
+	{"cluster", tools.RegisterClusterTools},
+	{"manifest", tools.RegisterManifestTools},
+	{"gitops", tools.RegisterGitOpsTools},
+	{"session", tools.RegisterSessionTools},
+	{"system", registerSystemTools},
+}
+
+// toolGroupNames returns the names of all tool groups
+func toolGroupNames() []string {
+	names := make([]string, 0, len(toolGroups))
+	for _, group := range toolGroups {
+		names = append(names, group.name)
+	}
+	return names
+}
+
+// parseDevCatalog validates --dev-catalog and returns it as namespace/name
+func parseDevCatalog(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	namespace, name, ok := strings.Cut(value, "/")
+	if !ok {
+		namespace, name = "default", value
+	}
+	for _, part := range []string{namespace, name} {
+		if errs := validation.IsDNS1123Label(part); len(errs) > 0 {
+			return "", fmt.Errorf("invalid --dev-catalog %q: use namespace/name of a Catalog", value)
+		}
+	}
+	return namespace + "/" + name, nil
+}
+
+// selectToolGroups returns the tool groups named in enabled, or all groups
+// when enabled is empty
+func selectToolGroups(enabled []string) ([]toolGroup, error) {
+	if len(enabled) == 0 {
+		return toolGroups, nil
+	}
+
+	selected := make(map[string]bool, len(enabled))
+	for _, name := range enabled {
+		name = strings.TrimSpace(name)
+		if !slices.Contains(toolGroupNames(), name) {
+			return nil, fmt.Errorf("unknown tool group %q (valid: %s)", name, strings.Join(toolGroupNames(), ", "))
+		}
+		selected[name] = true
+	}
+
+	groups := make([]toolGroup, 0, len(selected))
+	for _, group := range toolGroups {
+		if selected[group.name] {
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+// initializeTools registers the MCP tools of the enabled tool groups that
+// are part of the tool profile, and the prompts using them
+func initializeTools(s *server.MCPServer, ctx *internalServer.Context, enabled []string, profile string) error {
+	groups, err := selectToolGroups(enabled)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		if err := group.register(s, ctx); err != nil {
+			return fmt.Errorf("failed to register %s tools: %w", group.name, err)
+		}
+	}
+
+	if err := tools.ApplyProfile(s, profile); err != nil {
+		return err
+	}
+
+	// Register the prompts of the registered tools
+	if err := prompts.RegisterPrompts(s, ctx); err != nil {
+		return fmt.Errorf("failed to register prompts: %w", err)
+	}
+
+	// Check the namespaces of every call, after filling in the session defaults
+	tools.ApplyNamespacePolicy(s, ctx)
+	tools.ApplySessionDefaults(s, ctx)
+
+	return nil
+}
+
+// registerSystemTools registers the server health, server info and Kubernetes
+// context tools
+func registerSystemTools(s *server.MCPServer, ctx *internalServer.Context) error {
+	// Health check tool
+	healthTool := mcp.NewTool(
+		"health",
+		mcp.WithDescription("Check MCP server and Kubernetes connection health"),
+	)
+
+	s.AddTool(healthTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Check Kubernetes connection
+		version, err := ctx.K8sClient.Discovery().ServerVersion()
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Kubernetes connection failed: %v", err)), nil
+		}
+
+		// Check Giant Swarm CRDs
+		crdStatus := "available"
+		if err := ctx.DynamicClient.CheckCRDsExist(toolCtx, ctx.K8sClient); err != nil {
+			crdStatus = fmt.Sprintf("not available: %v", err)
+		}
+
+		healthStatus := fmt.Sprintf(`MCP Server Health Check:
+- Server: %s v%s (healthy)
+- Kubernetes: connected to %s
+  - Version: %s
+  - Context: %s
+- Giant Swarm CRDs: %s`,
+			serverName, rootCmd.Version,
+			version.GitVersion,
+			version.GitVersion,
+			ctx.K8sClient.GetCurrentContext(),
+			crdStatus,
+		)
+
+		if user, ok := k8s.ImpersonatedUser(toolCtx); ok {
+			healthStatus += fmt.Sprintf("\n- Session identity: %s (impersonated)", user.Username)
+		}
+
+		if ctx.Identity != nil {
+			if id, err := ctx.Identity.Resolve(toolCtx); err != nil {
+				healthStatus += fmt.Sprintf("\n- Identity: unknown (%v)", err)
+			} else if id.Organization != "" {
+				healthStatus += fmt.Sprintf("\n- Identity: %s (organization %s, from %s)", id.Username, id.Organization, id.Source)
+			} else {
+				healthStatus += fmt.Sprintf("\n- Identity: %s (organization not detected)", id.Username)
+			}
+		}
+
+		if index := ctx.AppCatalogEntryIndex; index != nil {
+			lastRefresh, refreshErr := index.Status()
+			started, took := index.WarmUp()
+			switch {
+			case !index.Synced() && refreshErr != nil:
+				healthStatus += fmt.Sprintf("\n- App catalog entry index: warming up, initial load failed (%v)", refreshErr)
+			case !index.Synced():
+				healthStatus += fmt.Sprintf("\n- App catalog entry index: warming up for %s", time.Since(started).Round(time.Second))
+			case refreshErr != nil:
+				healthStatus += fmt.Sprintf("\n- App catalog entry index: %d entries, stale since %s (%v)",
+					len(index.All()), lastRefresh.Format(time.RFC3339), refreshErr)
+			default:
+				healthStatus += fmt.Sprintf("\n- App catalog entry index: %d entries, refreshed %s (initial sync took %s)",
+					len(index.All()), lastRefresh.Format(time.RFC3339), took.Round(time.Millisecond))
+			}
+			if err := ctx.Ready(); err != nil {
+				healthStatus += fmt.Sprintf("\n- Ready: no, %v", err)
+			} else {
+				healthStatus += "\n- Ready: yes"
+			}
+		}
+
+		return mcp.NewToolResultText(healthStatus), nil
+	})
+
+	// Server info tool
+	serverInfoTool := mcp.NewTool(
+		"server_info",
+		mcp.WithDescription("Show the server version, git commit, build date, supported App/Catalog API versions and the minimum Giant Swarm platform release it is tested against"),
+	)
+
+	s.AddTool(serverInfoTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultJSON(getVersionInfo())
+	})
+
+	// List contexts tool
+	listContextsTool := mcp.NewTool(
+		"kubernetes_contexts",
+		mcp.WithDescription("List available Kubernetes contexts"),
+	)
+
+	s.AddTool(listContextsTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contexts, current, err := k8s.ListContexts()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list contexts: %w", err)
+		}
+
+		result := "Available Kubernetes contexts:\n"
+		for _, context := range contexts {
+			marker := "  "
+			if context == current {
+				marker = "* "
+			}
+			result += fmt.Sprintf("%s%s\n", marker, context)
+		}
+
+		return mcp.NewToolResultText(result), nil
+	})
+
+	return nil
+}
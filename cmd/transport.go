@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/identity"
+	internalServer "github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+)
+
+// runStdioServer runs the server with STDIO transport
+func runStdioServer(mcpSrv *mcpserver.MCPServer) error {
+	// Start the server in a goroutine so we can handle shutdown signals
+	serverDone := make(chan error, 1)
+	go func() {
+		defer close(serverDone)
+		if err := mcpserver.ServeStdio(mcpSrv); err != nil {
+			serverDone <- err
+		}
+	}()
+
+	// Wait for server completion
+	select {
+	case err := <-serverDone:
+		if err != nil {
+			return fmt.Errorf("server stopped with error: %w", err)
+		} else {
+			fmt.Println("Server stopped normally")
+		}
+	}
+
+	fmt.Println("Server gracefully stopped")
+	return nil
+}
+
+// httpTransport is an MCP transport served over HTTP
+type httpTransport interface {
+	Shutdown(ctx context.Context) error
+}
+
+// runSSEServer runs the server with SSE transport on listener. When
+// sessions is set, requests are authenticated and made as the session's user.
+func runSSEServer(ctx context.Context, mcpSrv *mcpserver.MCPServer, listener net.Listener, opts *serveOptions, ready http.Handler, drainer *internalServer.Drainer, sessions *identity.Sessions) error {
+	// Create SSE server with custom endpoints, next to the readiness endpoint
+	mux := http.NewServeMux()
+	httpSrv := &http.Server{Handler: mux}
+	sseOpts := []mcpserver.SSEOption{
+		mcpserver.WithSSEEndpoint(opts.sseEndpoint),
+		mcpserver.WithMessageEndpoint(opts.messageEndpoint),
+		mcpserver.WithHTTPServer(httpSrv),
+	}
+	if sessions != nil {
+		sseOpts = append(sseOpts, mcpserver.WithSSEContextFunc(sessions.HTTPContextFunc))
+	}
+	sseServer := mcpserver.NewSSEServer(mcpSrv, sseOpts...)
+	mux.Handle(internalServer.ReadinessPath, ready)
+	mux.Handle("/", sseServer)
+
+	fmt.Printf("SSE server starting on %s\n", listener.Addr())
+	fmt.Printf("  SSE endpoint: %s\n", opts.sseEndpoint)
+	fmt.Printf("  Message endpoint: %s\n", opts.messageEndpoint)
+	fmt.Printf("  Readiness endpoint: %s\n", internalServer.ReadinessPath)
+
+	// The SSE server ends its streams itself when it shuts down
+	return serveHTTP(ctx, "SSE server", httpSrv, listener, sseServer, true, drainer, opts)
+}
+
+// runStreamableHTTPServer runs the server with Streamable HTTP transport on
+// listener. When sessions is set, requests are authenticated and made as the
+// session's user.
+func runStreamableHTTPServer(ctx context.Context, mcpSrv *mcpserver.MCPServer, listener net.Listener, opts *serveOptions, ready http.Handler, drainer *internalServer.Drainer, sessions *identity.Sessions) error {
+	// Create Streamable HTTP server with custom endpoint, next to the readiness endpoint
+	mux := http.NewServeMux()
+	httpSrv := &http.Server{Handler: mux}
+	httpOpts := []mcpserver.StreamableHTTPOption{
+		mcpserver.WithEndpointPath(opts.httpEndpoint),
+		mcpserver.WithStreamableHTTPServer(httpSrv),
+	}
+	if sessions != nil {
+		httpOpts = append(httpOpts, mcpserver.WithHTTPContextFunc(sessions.HTTPContextFunc))
+	}
+	httpServer := mcpserver.NewStreamableHTTPServer(mcpSrv, httpOpts...)
+	mux.Handle(internalServer.ReadinessPath, ready)
+	mux.Handle(opts.httpEndpoint, httpServer)
+
+	fmt.Printf("Streamable HTTP server starting on %s\n", listener.Addr())
+	fmt.Printf("  HTTP endpoint: %s\n", opts.httpEndpoint)
+	fmt.Printf("  Readiness endpoint: %s\n", internalServer.ReadinessPath)
+
+	return serveHTTP(ctx, "HTTP server", httpSrv, listener, httpServer, false, drainer, opts)
+}
+
+// serveHTTP serves an HTTP transport until ctx is done. It then stops
+// accepting tool calls and waits up to the shutdown grace period for the
+// running ones to send their results, before it ends the streams clients keep
+// open for notifications and shuts the transport down. endsStreams is set for
+// transports whose shutdown ends these streams; their requests must not be
+// cancelled as well, as both close the stream's session.
+func serveHTTP(ctx context.Context, name string, httpSrv *http.Server, listener net.Listener, transport httpTransport, endsStreams bool, drainer *internalServer.Drainer, opts *serveOptions) error {
+	// Cancelling the base context ends the requests that never become idle
+	// on their own, such as SSE streams
+	requestCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+	httpSrv.BaseContext = func(net.Listener) context.Context { return requestCtx }
+
+	serverDone := make(chan error, 1)
+	go func() {
+		defer close(serverDone)
+		if err := httpSrv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverDone <- err
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+	case err := <-serverDone:
+		if err != nil {
+			return fmt.Errorf("%s stopped with error: %w", name, err)
+		}
+		fmt.Printf("%s stopped normally\n", name)
+		return nil
+	}
+
+	fmt.Printf("Shutdown signal received, stopping %s...\n", name)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), opts.shutdownGrace)
+	defer cancel()
+
+	// Tool results are sent over the open connections, so wait for the
+	// running calls before closing them
+	drainErr := drainer.Drain(shutdownCtx)
+	if !endsStreams {
+		cancelRequests()
+	}
+	if err := transport.Shutdown(shutdownCtx); err != nil {
+		_ = httpSrv.Close()
+		if drainErr == nil {
+			drainErr = err
+		}
+	}
+	if drainErr != nil {
+		return fmt.Errorf("error shutting down %s: %w", name, drainErr)
+	}
+
+	fmt.Printf("%s gracefully stopped\n", name)
+	return nil
+}
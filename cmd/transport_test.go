@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	mcptransport "github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	internalServer "github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+)
+
+// transportTest runs a server over one of the HTTP transports with a tool
+// whose first call blocks until released
+type transportTest struct {
+	started chan struct{}
+	release chan struct{}
+	drainer *internalServer.Drainer
+	http    *http.Transport
+	cancel  context.CancelFunc
+	done    chan error
+	client  *client.Client
+}
+
+func startTransport(t *testing.T, transport string, grace time.Duration) *transportTest {
+	t.Helper()
+
+	drainer := internalServer.NewDrainer()
+	tt := &transportTest{started: make(chan struct{}), release: make(chan struct{}), drainer: drainer, http: &http.Transport{}, done: make(chan error, 1)}
+	var calls atomic.Int32
+	mcpSrv := mcpserver.NewMCPServer("test", "0.0.0", mcpserver.WithToolHandlerMiddleware(drainer.Middleware()))
+	mcpSrv.AddTool(mcp.NewTool("slow"), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if calls.Add(1) > 1 {
+			return mcp.NewToolResultText("quick"), nil
+		}
+		close(tt.started)
+		<-tt.release
+		return mcp.NewToolResultText("finished"), nil
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := &serveOptions{sseEndpoint: "/sse", messageEndpoint: "/message", httpEndpoint: "/mcp", shutdownGrace: grace}
+	ready := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tt.cancel = cancel
+	base := "http://" + listener.Addr().String()
+	go func() {
+		if transport == "sse" {
+			tt.done <- runSSEServer(ctx, mcpSrv, listener, opts, ready, drainer, nil)
+		} else {
+			tt.done <- runStreamableHTTPServer(ctx, mcpSrv, listener, opts, ready, drainer, nil)
+		}
+	}()
+
+	if transport == "sse" {
+		tt.client, err = client.NewSSEMCPClient(base+"/sse", mcptransport.WithHTTPClient(&http.Client{Transport: tt.http}))
+	} else {
+		tt.client, err = client.NewStreamableHttpClient(base+"/mcp", mcptransport.WithHTTPBasicClient(&http.Client{Transport: tt.http}))
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tt.client.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	initReq := mcp.InitializeRequest{}
+	initReq.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	if _, err := tt.client.Initialize(context.Background(), initReq); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = tt.client.Close() })
+
+	return tt
+}
+
+func (tt *transportTest) call() (*mcp.CallToolResult, error) {
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "slow"
+	return tt.client.CallTool(context.Background(), req)
+}
+
+func TestHTTPTransportsDrainToolCalls(t *testing.T) {
+	for _, transport := range []string{"sse", "streamable-http"} {
+		t.Run(transport, func(t *testing.T) {
+			tt := startTransport(t, transport, 5*time.Second)
+
+			results := make(chan *mcp.CallToolResult, 1)
+			go func() {
+				result, err := tt.call()
+				if err != nil {
+					t.Errorf("running call failed: %v", err)
+				}
+				results <- result
+			}()
+			<-tt.started
+
+			tt.cancel()
+			<-tt.drainer.Draining()
+
+			// New calls are rejected while the running one finishes
+			if result, err := tt.call(); err != nil || !result.IsError {
+				t.Errorf("call after shutdown began = %+v, %v, want rejected", result, err)
+			}
+
+			// Concurrent calls may leave a connection that never sent a
+			// request, which the HTTP server only closes after 5 seconds
+			tt.http.CloseIdleConnections()
+
+			close(tt.release)
+			if result := <-results; result == nil || result.IsError {
+				t.Errorf("running call did not finish: %+v", result)
+			}
+			if err := <-tt.done; err != nil {
+				t.Errorf("shutdown failed: %v", err)
+			}
+		})
+	}
+}
+
+func TestHTTPTransportsShutdownGrace(t *testing.T) {
+	for _, transport := range []string{"sse", "streamable-http"} {
+		t.Run(transport, func(t *testing.T) {
+			// Without a grace period the running call is not waited for
+			tt := startTransport(t, transport, 0)
+			defer close(tt.release)
+
+			go func() { _, _ = tt.call() }()
+			<-tt.started
+
+			tt.cancel()
+			if err := <-tt.done; err == nil || !strings.Contains(err.Error(), "1 tool call still running") {
+				t.Errorf("shutdown error = %v, want running tool call", err)
+			}
+		})
+	}
+}
@@ -2,20 +2,114 @@ package cmd
 
 import (
 	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+)
+
+// minimumPlatformRelease is the oldest Giant Swarm platform release the
+// server is tested against. Bump it when older releases leave the test matrix.
+const minimumPlatformRelease = "25.0.0"
+
+// supportedAPIs are the API versions of the resources the server manages
+var supportedAPIs = []schema.GroupVersionResource{
+	k8s.AppGVR,
+	k8s.CatalogGVR,
+	k8s.AppCatalogEntryGVR,
+	k8s.OrganizationGVR,
+	k8s.ReleaseGVR,
+	cluster.ClusterGVR,
+}
+
+// Build metadata injected by main; empty values fall back to the VCS
+// information the Go toolchain embeds
+var (
+	buildCommit string
+	buildDate   string
 )
 
+// SetBuildInfo sets the git commit and build date of the binary.
+// It is called from the main package with values injected at build time.
+func SetBuildInfo(commit, date string) {
+	buildCommit = commit
+	buildDate = date
+}
+
+// versionInfo describes the binary and the APIs it is compatible with
+type versionInfo struct {
+	Version                string   `json:"version"`
+	Commit                 string   `json:"commit,omitempty"`
+	BuildDate              string   `json:"buildDate,omitempty"`
+	GoVersion              string   `json:"goVersion"`
+	Platform               string   `json:"platform"`
+	APIs                   []string `json:"apis"`
+	MinimumPlatformRelease string   `json:"minimumPlatformRelease"`
+}
+
+// getVersionInfo returns the version information of the running binary
+func getVersionInfo() versionInfo {
+	info := versionInfo{
+		Version:                rootCmd.Version,
+		Commit:                 buildCommit,
+		BuildDate:              buildDate,
+		GoVersion:              runtime.Version(),
+		Platform:               runtime.GOOS + "/" + runtime.GOARCH,
+		MinimumPlatformRelease: minimumPlatformRelease,
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range bi.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = setting.Value
+				if len(info.Commit) > 7 {
+					info.Commit = info.Commit[:7]
+				}
+			case setting.Key == "vcs.time" && info.BuildDate == "":
+				info.BuildDate = setting.Value
+			}
+		}
+	}
+
+	for _, gvr := range supportedAPIs {
+		info.APIs = append(info.APIs, gvr.Resource+"."+gvr.GroupVersion().String())
+	}
+	return info
+}
+
+// String renders the version information for the version command
+func (v versionInfo) String() string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "mcp-giantswarm-apps version %s\n", v.Version)
+	if v.Commit != "" {
+		fmt.Fprintf(&out, "  Commit: %s\n", v.Commit)
+	}
+	if v.BuildDate != "" {
+		fmt.Fprintf(&out, "  Built: %s\n", v.BuildDate)
+	}
+	fmt.Fprintf(&out, "  Go: %s %s\n", v.GoVersion, v.Platform)
+	fmt.Fprintf(&out, "  APIs: %s\n", strings.Join(v.APIs, ", "))
+	fmt.Fprintf(&out, "  Minimum platform release: %s\n", v.MinimumPlatformRelease)
+	return out.String()
+}
+
 // newVersionCmd creates the Cobra command for displaying the application version.
 // The actual version information is typically managed by the root command or a global variable.
 func newVersionCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "version",
 		Short: "Print the version number of mcp-giantswarm-apps",
-		Long:  `All software has versions. This is mcp-giantswarm-apps's.`,
+		Long: `Prints the version, git commit and build date of mcp-giantswarm-apps, the
+API versions it manages and the oldest Giant Swarm platform release it is
+tested against.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			// rootCmd.Version is expected to be set, typically in root.go during build time.
-			fmt.Fprintf(cmd.OutOrStdout(), "mcp-giantswarm-apps version %s\n", rootCmd.Version)
+			fmt.Fprint(cmd.OutOrStdout(), getVersionInfo())
 		},
 	}
 }
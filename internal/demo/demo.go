@@ -0,0 +1,94 @@
+// Package demo serves a bundled installation of organizations, clusters,
+// catalogs and apps from fake clients, so the server can be tried out without
+// access to a management cluster.
+package demo
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	gstesting "github.com/giantswarm/mcp-giantswarm-apps/pkg/testing"
+)
+
+// Context is the kube context name the demo clients report
+const Context = "demo"
+
+//go:embed fixtures/*.yaml
+var fixtures embed.FS
+
+// Objects returns the resources of the demo installation. Resources of
+// the core API are typed, the Giant Swarm and CAPI ones unstructured.
+func Objects() ([]runtime.Object, error) {
+	files, err := fixtures.ReadDir("fixtures")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the demo fixtures: %w", err)
+	}
+
+	var objects []runtime.Object
+	for _, file := range files {
+		data, err := fixtures.ReadFile("fixtures/" + file.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read demo fixture %s: %w", file.Name(), err)
+		}
+		decoded, err := decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse demo fixture %s: %w", file.Name(), err)
+		}
+		objects = append(objects, decoded...)
+	}
+	return objects, nil
+}
+
+// decode parses the documents of a YAML file
+func decode(data []byte) ([]runtime.Object, error) {
+	decoder := yamlutil.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	var objects []runtime.Object
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objects, nil
+			}
+			return nil, err
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		gvk := obj.GroupVersionKind()
+		if gvk.Group != "" {
+			objects = append(objects, obj)
+			continue
+		}
+		typed, err := scheme.Scheme.New(gvk)
+		if err != nil {
+			return nil, fmt.Errorf("unknown kind %s: %w", gvk.Kind, err)
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, typed); err != nil {
+			return nil, fmt.Errorf("failed to convert %s %s: %w", gvk.Kind, obj.GetName(), err)
+		}
+		objects = append(objects, typed)
+	}
+}
+
+// NewClients returns clients serving the demo installation. Changes made
+// through them are kept in memory until the server stops.
+func NewClients() (*k8s.Client, *k8s.DynamicClient, error) {
+	objects, err := Objects()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	k8sClient, dynamicClient := gstesting.NewClients(objects...)
+	k8sClient.Context = Context
+	return k8sClient, dynamicClient, nil
+}
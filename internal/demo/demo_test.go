@@ -0,0 +1,40 @@
+package demo
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+)
+
+func TestNewClients(t *testing.T) {
+	k8sClient, dynamicClient, err := NewClients()
+	if err != nil {
+		t.Fatalf("NewClients() error = %v", err)
+	}
+	ctx := context.Background()
+
+	namespaces, err := k8sClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil || len(namespaces.Items) == 0 {
+		t.Fatalf("listing namespaces returned %d, %v", len(namespaces.Items), err)
+	}
+
+	list, err := dynamicClient.Apps("org-acme").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("listing apps error = %v", err)
+	}
+	if len(list.Items) == 0 {
+		t.Fatal("the demo installation has no apps in org-acme")
+	}
+	for i := range list.Items {
+		a, err := app.NewAppFromUnstructured(&list.Items[i])
+		if err != nil {
+			t.Fatalf("app %s: %v", list.Items[i].GetName(), err)
+		}
+		if a.Spec.Catalog == "" || a.Spec.Version == "" {
+			t.Errorf("app %s misses its catalog or version", a.Name)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+// Package doctor diagnoses the local environment of the server: kubeconfig,
+// connection to the management cluster, installed CRDs and basic RBAC, with
+// remediation steps for every problem found.
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+)
+
+// Status of a check
+const (
+	StatusOK      = "ok"
+	StatusWarning = "warning"
+	StatusFailed  = "failed"
+)
+
+// Result is the outcome of one check
+type Result struct {
+	Name    string
+	Status  string
+	Message string
+	// Remediation tells the user how to fix a warning or failure
+	Remediation string
+}
+
+// API is a resource the server uses. Without a required API the server
+// cannot work; without an optional one some tools are unavailable.
+type API struct {
+	Group    string
+	Version  string
+	Resource string
+	Required bool
+	// Feature describes what depends on an optional API
+	Feature string
+}
+
+// APIs are the resources checked for
+var APIs = []API{
+	{Group: k8s.AppGVR.Group, Version: k8s.AppGVR.Version, Resource: k8s.AppGVR.Resource, Required: true},
+	{Group: k8s.CatalogGVR.Group, Version: k8s.CatalogGVR.Version, Resource: k8s.CatalogGVR.Resource, Required: true},
+	{Group: k8s.AppCatalogEntryGVR.Group, Version: k8s.AppCatalogEntryGVR.Version, Resource: k8s.AppCatalogEntryGVR.Resource, Required: true},
+	{Group: cluster.ClusterGVR.Group, Version: cluster.ClusterGVR.Version, Resource: cluster.ClusterGVR.Resource, Feature: "cluster tools"},
+	{Group: k8s.OrganizationGVR.Group, Version: k8s.OrganizationGVR.Version, Resource: k8s.OrganizationGVR.Resource, Feature: "organization tools"},
+	{Group: k8s.ReleaseGVR.Group, Version: k8s.ReleaseGVR.Version, Resource: k8s.ReleaseGVR.Resource, Feature: "release lookups"},
+}
+
+// Run performs all checks against kubeContext, or the current context when
+// empty. Checks that need the cluster are skipped when it cannot be reached.
+func Run(ctx context.Context, kubeContext string) []Result {
+	results := []Result{CheckKubeconfig()}
+	if results[0].Status == StatusFailed {
+		return results
+	}
+
+	k8sClient, err := k8s.NewClient(ctx, kubeContext)
+	if err != nil {
+		return append(results, Result{
+			Name:    "connection",
+			Status:  StatusFailed,
+			Message: err.Error(),
+			Remediation: "Check that the context points at a Giant Swarm management cluster and that its credentials are valid, " +
+				"e.g. with `kubectl cluster-info`. Log in again with `kubectl gs login <installation>` if they expired, " +
+				"or pick another context with --kube-context.",
+		})
+	}
+	results = append(results, CheckConnection(k8sClient))
+	results = append(results, CheckIdentity(ctx, k8sClient))
+	results = append(results, CheckAPIs(k8sClient.Discovery())...)
+	return append(results, CheckRBAC(ctx, k8sClient)...)
+}
+
+// Failed reports whether any check failed
+func Failed(results []Result) bool {
+	for _, result := range results {
+		if result.Status == StatusFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckKubeconfig checks that the server runs in a cluster or finds a
+// kubeconfig file
+func CheckKubeconfig() Result {
+	if _, err := rest.InClusterConfig(); err == nil {
+		return Result{Name: "kubeconfig", Status: StatusOK, Message: "running in a cluster, using its service account"}
+	}
+	return checkKubeconfigFiles(filepath.SplitList(k8s.KubeconfigPath()))
+}
+
+func checkKubeconfigFiles(paths []string) Result {
+	result := Result{
+		Name:   "kubeconfig",
+		Status: StatusFailed,
+		Remediation: "Log in to the management cluster with `kubectl gs login <installation>`, " +
+			"or set KUBECONFIG to the path of an existing kubeconfig file.",
+	}
+	if len(paths) == 0 {
+		result.Message = "no kubeconfig path: KUBECONFIG is not set and the home directory is unknown"
+		return result
+	}
+
+	var missing []string
+	for _, path := range paths {
+		if _, err := os.Stat(path); err != nil {
+			missing = append(missing, path)
+			continue
+		}
+		return Result{Name: "kubeconfig", Status: StatusOK, Message: path}
+	}
+	result.Message = "kubeconfig not found at " + strings.Join(missing, ", ")
+	return result
+}
+
+// CheckConnection reports the context and version of the cluster the client
+// is connected to
+func CheckConnection(k8sClient *k8s.Client) Result {
+	result := Result{Name: "connection", Status: StatusOK, Message: "context " + k8sClient.Context}
+	if version, err := k8sClient.Discovery().ServerVersion(); err == nil {
+		result.Message += ", Kubernetes " + version.GitVersion
+	}
+	return result
+}
+
+// CheckIdentity reports who the API server authenticates the client as
+func CheckIdentity(ctx context.Context, k8sClient kubernetes.Interface) Result {
+	user, err := k8s.ReviewSelf(ctx, k8sClient)
+	if err != nil {
+		return Result{
+			Name:        "identity",
+			Status:      StatusWarning,
+			Message:     err.Error(),
+			Remediation: "The API server does not support SelfSubjectReviews (Kubernetes < 1.28), so resources are annotated as created by an unknown user.",
+		}
+	}
+	return Result{Name: "identity", Status: StatusOK, Message: user.Username}
+}
+
+// CheckAPIs checks that the cluster serves the APIs the server uses
+func CheckAPIs(client discovery.DiscoveryInterface) []Result {
+	served := make(map[string]map[string]bool)
+	results := make([]Result, 0, len(APIs))
+	for _, api := range APIs {
+		groupVersion := api.Group + "/" + api.Version
+		resources, ok := served[groupVersion]
+		if !ok {
+			resources = make(map[string]bool)
+			if list, err := client.ServerResourcesForGroupVersion(groupVersion); err == nil {
+				for _, resource := range list.APIResources {
+					resources[resource.Name] = true
+				}
+			}
+			served[groupVersion] = resources
+		}
+
+		result := Result{Name: "API " + api.Resource + "." + api.Group, Status: StatusOK, Message: groupVersion + " is served"}
+		if !resources[api.Resource] {
+			result.Message = groupVersion + " " + api.Resource + " is not served"
+			if api.Required {
+				result.Status = StatusFailed
+				result.Remediation = "The App Platform CRDs are only installed on Giant Swarm management clusters. " +
+					"Point the server at a management cluster with --kube-context."
+			} else {
+				result.Status = StatusWarning
+				result.Remediation = fmt.Sprintf("The %s are unavailable on this cluster.", api.Feature)
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// CheckRBAC checks with SelfSubjectAccessReviews whether the client may list
+// the APIs across all namespaces. Users restricted to their organizations
+// only get a warning, since the server also works within allowed namespaces.
+func CheckRBAC(ctx context.Context, k8sClient kubernetes.Interface) []Result {
+	results := make([]Result, 0, len(APIs))
+	for _, api := range APIs {
+		review, err := k8sClient.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Verb:     "list",
+					Group:    api.Group,
+					Resource: api.Resource,
+				},
+			},
+		}, metav1.CreateOptions{})
+
+		result := Result{Name: "RBAC list " + api.Resource, Status: StatusOK, Message: "allowed in all namespaces"}
+		switch {
+		case err != nil:
+			result.Status = StatusWarning
+			result.Message = fmt.Sprintf("failed to review access: %v", err)
+			result.Remediation = "Check the permissions manually with `kubectl auth can-i list " + api.Resource + "." + api.Group + " --all-namespaces`."
+		case !review.Status.Allowed:
+			result.Status = StatusWarning
+			result.Message = "not allowed in all namespaces"
+			result.Remediation = "Tools that search all namespaces will fail. Ask an administrator for read access, " +
+				"or restrict the server to the namespaces you may use with --allowed-namespaces 'org-<name>'."
+		}
+		results = append(results, result)
+	}
+	return results
+}
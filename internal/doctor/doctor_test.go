@@ -0,0 +1,92 @@
+package doctor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCheckKubeconfigFiles(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "config")
+	if err := os.WriteFile(existing, []byte("apiVersion: v1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if result := checkKubeconfigFiles([]string{filepath.Join(dir, "missing"), existing}); result.Status != StatusOK || result.Message != existing {
+		t.Errorf("existing kubeconfig: %+v", result)
+	}
+	if result := checkKubeconfigFiles([]string{filepath.Join(dir, "missing")}); result.Status != StatusFailed || result.Remediation == "" {
+		t.Errorf("missing kubeconfig: %+v", result)
+	}
+	if result := checkKubeconfigFiles(nil); result.Status != StatusFailed {
+		t.Errorf("no kubeconfig path: %+v", result)
+	}
+}
+
+func TestCheckAPIs(t *testing.T) {
+	k8sClient := fake.NewClientset()
+	k8sClient.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "application.giantswarm.io/v1alpha1",
+			APIResources: []metav1.APIResource{{Name: "apps"}, {Name: "catalogs"}},
+		},
+		{
+			GroupVersion: "cluster.x-k8s.io/v1beta1",
+			APIResources: []metav1.APIResource{{Name: "clusters"}},
+		},
+	}
+
+	statuses := make(map[string]string)
+	for _, result := range CheckAPIs(k8sClient.Discovery()) {
+		statuses[result.Name] = result.Status
+	}
+
+	want := map[string]string{
+		"API apps.application.giantswarm.io":              StatusOK,
+		"API catalogs.application.giantswarm.io":          StatusOK,
+		"API appcatalogentries.application.giantswarm.io": StatusFailed,
+		"API clusters.cluster.x-k8s.io":                   StatusOK,
+		"API organizations.security.giantswarm.io":        StatusWarning,
+		"API releases.release.giantswarm.io":              StatusWarning,
+	}
+	for name, status := range want {
+		if statuses[name] != status {
+			t.Errorf("%s: status %q, want %q", name, statuses[name], status)
+		}
+	}
+}
+
+func TestCheckRBAC(t *testing.T) {
+	k8sClient := fake.NewClientset()
+	k8sClient.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		review.Status.Allowed = review.Spec.ResourceAttributes.Group == "application.giantswarm.io"
+		return true, review, nil
+	})
+
+	results := CheckRBAC(context.Background(), k8sClient)
+	if len(results) != len(APIs) {
+		t.Fatalf("got %d results, want %d", len(results), len(APIs))
+	}
+	for i, result := range results {
+		want := StatusWarning
+		if APIs[i].Group == "application.giantswarm.io" {
+			want = StatusOK
+		}
+		if result.Status != want {
+			t.Errorf("%s: status %q, want %q", result.Name, result.Status, want)
+		}
+	}
+	if Failed(results) {
+		t.Error("RBAC checks must not fail")
+	}
+}
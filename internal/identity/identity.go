@@ -0,0 +1,232 @@
+// Package identity determines who the server acts as and which organization
+// that identity belongs to, so that tools can default their organization
+// argument to it.
+package identity
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/organization"
+)
+
+// Sources of a detected organization
+const (
+	SourceUserMapping    = "user mapping"
+	SourceGroupMapping   = "group mapping"
+	SourceServiceAccount = "service account namespace"
+	SourceRBAC           = "RBAC"
+)
+
+// serviceAccountPrefix starts the usernames of service accounts, which are
+// system:serviceaccount:<namespace>:<name>
+const serviceAccountPrefix = "system:serviceaccount:"
+
+// Identity is the authenticated user and the organization it belongs to
+type Identity struct {
+	Username string
+	Groups   []string
+
+	// Organization is empty when it could not be determined unambiguously
+	Organization string
+
+	// Source tells how Organization was determined
+	Source string
+}
+
+// Mapping assigns organizations to users and groups, for identity providers
+// whose usernames and group claims do not reveal the organization
+type Mapping struct {
+	Users  map[string]string `json:"users,omitempty"`
+	Groups map[string]string `json:"groups,omitempty"`
+}
+
+// LoadMapping reads a mapping file, e.g.
+//
+//	users:
+//	  jane@example.com: acme
+//	groups:
+//	  acme-platform-team: acme
+func LoadMapping(path string) (*Mapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read organization mapping: %w", err)
+	}
+
+	var mapping Mapping
+	if err := yaml.UnmarshalStrict(data, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse organization mapping %s: %w", path, err)
+	}
+	return &mapping, nil
+}
+
+// organization returns the organization mapped to the user or, failing that,
+// to exactly one distinct organization across its groups
+func (m *Mapping) organization(username string, groups []string) (string, string) {
+	if m == nil {
+		return "", ""
+	}
+	if org, ok := m.Users[username]; ok {
+		return org, SourceUserMapping
+	}
+
+	orgs := make(map[string]bool)
+	for _, group := range groups {
+		if org, ok := m.Groups[group]; ok {
+			orgs[org] = true
+		}
+	}
+	if len(orgs) == 1 {
+		for org := range orgs {
+			return org, SourceGroupMapping
+		}
+	}
+	return "", ""
+}
+
+// Resolver determines the current identity once per impersonated user and
+// caches it
+type Resolver struct {
+	k8sClient kubernetes.Interface
+	mapping   *Mapping
+
+	mu         sync.Mutex
+	identities map[string]*Identity
+}
+
+// NewResolver creates a resolver; mapping may be nil
+func NewResolver(k8sClient kubernetes.Interface, mapping *Mapping) *Resolver {
+	return &Resolver{k8sClient: k8sClient, mapping: mapping}
+}
+
+// Resolve returns the current identity: the user impersonated with
+// k8s.WithImpersonation, or else the server's own. The organization is taken
+// from, in order: the mapping of the username, the mapping of its groups, the
+// namespace of a service account, and the only organization namespace the
+// identity may manage apps in.
+func (r *Resolver) Resolve(ctx context.Context) (*Identity, error) {
+	key := ""
+	if user, ok := k8s.ImpersonatedUser(ctx); ok {
+		key = user.Username
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id, ok := r.identities[key]; ok {
+		return id, nil
+	}
+
+	user, err := k8s.ReviewSelf(ctx, r.k8sClient)
+	if err != nil {
+		return nil, err
+	}
+
+	id := &Identity{Username: user.Username, Groups: user.Groups}
+	id.Organization, id.Source = r.mapping.organization(user.Username, user.Groups)
+	if id.Organization == "" {
+		if org := serviceAccountOrganization(user.Username); org != "" {
+			id.Organization, id.Source = org, SourceServiceAccount
+		}
+	}
+	if id.Organization == "" {
+		org, err := rbacOrganization(ctx, r.k8sClient)
+		if err != nil {
+			return nil, err
+		}
+		if org != "" {
+			id.Organization, id.Source = org, SourceRBAC
+		}
+	}
+
+	if r.identities == nil {
+		r.identities = make(map[string]*Identity)
+	}
+	r.identities[key] = id
+	return id, nil
+}
+
+// Reset drops the cached identities so that the next Resolve determines them
+// again, e.g. after the credentials of the client changed
+func (r *Resolver) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.identities = nil
+}
+
+// serviceAccountOrganization returns the organization of a service account
+// in an organization namespace
+func serviceAccountOrganization(username string) string {
+	rest, ok := strings.CutPrefix(username, serviceAccountPrefix)
+	if !ok {
+		return ""
+	}
+	namespace, _, _ := strings.Cut(rest, ":")
+	org, err := organization.GetOrganizationFromNamespace(namespace)
+	if err != nil {
+		return ""
+	}
+	return org
+}
+
+// rbacOrganization returns the organization whose namespace is the only one
+// the identity may list apps in. Identities allowed to list apps in all
+// namespaces, such as administrators, belong to no particular organization.
+func rbacOrganization(ctx context.Context, k8sClient kubernetes.Interface) (string, error) {
+	allowed, err := canListApps(ctx, k8sClient, "")
+	if err != nil || allowed {
+		return "", err
+	}
+
+	// Identities that cannot list namespaces cannot be matched this way
+	namespaces, err := organization.ListOrganizationNamespaces(ctx, k8sClient)
+	if err != nil {
+		return "", nil
+	}
+	sort.Strings(namespaces)
+
+	var found string
+	for _, ns := range namespaces {
+		allowed, err := canListApps(ctx, k8sClient, ns)
+		if err != nil {
+			return "", err
+		}
+		if !allowed {
+			continue
+		}
+		if found != "" {
+			return "", nil
+		}
+		found = ns
+	}
+	if found == "" {
+		return "", nil
+	}
+	return organization.GetOrganizationFromNamespace(found)
+}
+
+func canListApps(ctx context.Context, k8sClient kubernetes.Interface, namespace string) (bool, error) {
+	review, err := k8sClient.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "list",
+				Group:     "application.giantswarm.io",
+				Resource:  "apps",
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to review access to apps: %w", err)
+	}
+	return review.Status.Allowed, nil
+}
@@ -0,0 +1,143 @@
+package identity
+
+import (
+	"context"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+)
+
+// newClient returns a fake client authenticating as user, which may list
+// apps in the allowed namespaces
+func newClient(user authenticationv1.UserInfo, allowed ...string) *fake.Clientset {
+	k8sClient := fake.NewClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-acme"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-other"}},
+	)
+	k8sClient.PrependReactor("create", "selfsubjectreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.SelfSubjectReview)
+		review.Status.UserInfo = user
+		return true, review, nil
+	})
+	k8sClient.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		for _, ns := range allowed {
+			if review.Spec.ResourceAttributes.Namespace == ns {
+				review.Status.Allowed = true
+			}
+		}
+		return true, review, nil
+	})
+	return k8sClient
+}
+
+func TestResolve(t *testing.T) {
+	mapping := &Mapping{
+		Users:  map[string]string{"jane@example.com": "mapped"},
+		Groups: map[string]string{"platform-team": "team"},
+	}
+
+	tests := []struct {
+		name       string
+		user       authenticationv1.UserInfo
+		allowed    []string
+		wantOrg    string
+		wantSource string
+	}{
+		{
+			name:       "user mapping",
+			user:       authenticationv1.UserInfo{Username: "jane@example.com", Groups: []string{"platform-team"}},
+			wantOrg:    "mapped",
+			wantSource: SourceUserMapping,
+		},
+		{
+			name:       "group mapping",
+			user:       authenticationv1.UserInfo{Username: "john@example.com", Groups: []string{"platform-team"}},
+			wantOrg:    "team",
+			wantSource: SourceGroupMapping,
+		},
+		{
+			name:       "service account",
+			user:       authenticationv1.UserInfo{Username: "system:serviceaccount:org-acme:automation"},
+			wantOrg:    "acme",
+			wantSource: SourceServiceAccount,
+		},
+		{
+			name:       "single organization namespace",
+			user:       authenticationv1.UserInfo{Username: "john@example.com"},
+			allowed:    []string{"org-acme"},
+			wantOrg:    "acme",
+			wantSource: SourceRBAC,
+		},
+		{
+			name:    "several organization namespaces",
+			user:    authenticationv1.UserInfo{Username: "john@example.com"},
+			allowed: []string{"org-acme", "org-other"},
+		},
+		{
+			name:    "all namespaces",
+			user:    authenticationv1.UserInfo{Username: "admin"},
+			allowed: []string{"", "org-acme"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := NewResolver(newClient(tt.user, tt.allowed...), mapping)
+			id, err := resolver.Resolve(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id.Username != tt.user.Username || id.Organization != tt.wantOrg || id.Source != tt.wantSource {
+				t.Errorf("Resolve() = %+v, want organization %q from %q", id, tt.wantOrg, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestResolvePerImpersonatedUser(t *testing.T) {
+	// Each self review answers with the next user
+	users := []authenticationv1.UserInfo{
+		{Username: "system:serviceaccount:org-acme:mcp"},
+		{Username: "system:serviceaccount:org-other:jane"},
+	}
+	reviews := 0
+	k8sClient := newClient(authenticationv1.UserInfo{})
+	k8sClient.PrependReactor("create", "selfsubjectreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.SelfSubjectReview)
+		review.Status.UserInfo = users[reviews]
+		reviews++
+		return true, review, nil
+	})
+	resolver := NewResolver(k8sClient, nil)
+	ctx := context.Background()
+	jane := k8s.WithImpersonation(ctx, authenticationv1.UserInfo{Username: "jane"})
+
+	server, err := resolver.Resolve(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	impersonated, err := resolver.Resolve(jane)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server.Organization != "acme" || impersonated.Organization != "other" {
+		t.Errorf("organizations = %q, %q, want the server's acme and jane's other", server.Organization, impersonated.Organization)
+	}
+
+	// Both identities are cached
+	if again, err := resolver.Resolve(jane); err != nil || again != impersonated {
+		t.Errorf("Resolve() again = %+v, %v, want cached identity", again, err)
+	}
+	if reviews != 2 {
+		t.Errorf("%d self reviews, want 2", reviews)
+	}
+}
@@ -0,0 +1,152 @@
+package identity
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+)
+
+// sessionIdleTimeout is how long a binding of a session that made no
+// requests is kept, for transports that do not report closed sessions
+const sessionIdleTimeout = 24 * time.Hour
+
+type authErrorKey struct{}
+
+// Sessions binds the sessions of HTTP transports to the Kubernetes user whose
+// bearer token opened them. Requests of a bound session impersonate that user,
+// so that API server audit logs show who acted instead of the server.
+type Sessions struct {
+	k8sClient kubernetes.Interface
+	now       func() time.Time
+
+	mu       sync.Mutex
+	bindings map[string]*sessionBinding
+}
+
+type sessionBinding struct {
+	user      authenticationv1.UserInfo
+	tokenHash [sha256.Size]byte
+	lastUsed  time.Time
+}
+
+// NewSessions creates an empty set of bindings; tokens are reviewed with
+// k8sClient, which needs permission to create TokenReviews and to impersonate
+// users and groups
+func NewSessions(k8sClient kubernetes.Interface) *Sessions {
+	return &Sessions{
+		k8sClient: k8sClient,
+		now:       time.Now,
+		bindings:  make(map[string]*sessionBinding),
+	}
+}
+
+// HTTPContextFunc authenticates a request by the bearer token in its
+// Authorization header. The first token of a session is reviewed and bound to
+// it; later tokens must belong to the same user. The returned context
+// impersonates the user, or carries the error that Authorize rejects the
+// request with.
+func (s *Sessions) HTTPContextFunc(ctx context.Context, r *http.Request) context.Context {
+	user, err := s.authenticate(ctx, r)
+	if err != nil {
+		return context.WithValue(ctx, authErrorKey{}, err)
+	}
+	return k8s.WithImpersonation(ctx, user)
+}
+
+// Authorize rejects requests that HTTPContextFunc did not authenticate; it is
+// an MCP request initialization hook
+func (s *Sessions) Authorize(ctx context.Context, id any, message any) error {
+	if err, ok := ctx.Value(authErrorKey{}).(error); ok {
+		return err
+	}
+	if _, ok := k8s.ImpersonatedUser(ctx); !ok {
+		return errors.New("unauthenticated: this server requires a Kubernetes bearer token per session")
+	}
+	return nil
+}
+
+// Unregister drops the binding of a closed session; it is an MCP unregister
+// session hook
+func (s *Sessions) Unregister(ctx context.Context, session mcpserver.ClientSession) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.bindings, session.SessionID())
+}
+
+func (s *Sessions) authenticate(ctx context.Context, r *http.Request) (authenticationv1.UserInfo, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return authenticationv1.UserInfo{}, errors.New("unauthenticated: send a Kubernetes bearer token in the Authorization header")
+	}
+	session := mcpserver.ClientSessionFromContext(ctx)
+	if session == nil {
+		return authenticationv1.UserInfo{}, errors.New("unauthenticated: request without a session")
+	}
+	sessionID := session.SessionID()
+	tokenHash := sha256.Sum256([]byte(token))
+
+	s.mu.Lock()
+	binding := s.bindings[sessionID]
+	if binding != nil && binding.tokenHash == tokenHash {
+		binding.lastUsed = s.now()
+		user := binding.user
+		s.mu.Unlock()
+		return user, nil
+	}
+	s.mu.Unlock()
+
+	user, err := s.review(ctx, token)
+	if err != nil {
+		return authenticationv1.UserInfo{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if binding := s.bindings[sessionID]; binding != nil && binding.user.Username != user.Username {
+		return authenticationv1.UserInfo{}, fmt.Errorf("unauthenticated: session %s belongs to %s, not %s", sessionID, binding.user.Username, user.Username)
+	}
+	s.prune()
+	s.bindings[sessionID] = &sessionBinding{user: user, tokenHash: tokenHash, lastUsed: s.now()}
+	return user, nil
+}
+
+// review exchanges a bearer token for the user it authenticates with a
+// TokenReview
+func (s *Sessions) review(ctx context.Context, token string) (authenticationv1.UserInfo, error) {
+	review, err := s.k8sClient.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return authenticationv1.UserInfo{}, fmt.Errorf("failed to review session token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		reason := review.Status.Error
+		if reason == "" {
+			reason = "token not accepted by the API server"
+		}
+		return authenticationv1.UserInfo{}, fmt.Errorf("unauthenticated: %s", reason)
+	}
+	return review.Status.User, nil
+}
+
+// prune drops bindings of sessions that have been idle for too long; the
+// caller must hold the lock
+func (s *Sessions) prune() {
+	for id, binding := range s.bindings {
+		if s.now().Sub(binding.lastUsed) > sessionIdleTimeout {
+			delete(s.bindings, id)
+		}
+	}
+}
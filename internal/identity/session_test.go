@@ -0,0 +1,85 @@
+package identity
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+)
+
+type testSession struct {
+	id string
+}
+
+func (s testSession) Initialize()       {}
+func (s testSession) Initialized() bool { return true }
+func (s testSession) SessionID() string { return s.id }
+func (s testSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return make(chan mcp.JSONRPCNotification)
+}
+
+func TestSessions(t *testing.T) {
+	users := map[string]string{"alice-token": "alice", "bob-token": "bob"}
+	reviews := 0
+	k8sClient := fake.NewClientset()
+	k8sClient.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		reviews++
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if user, ok := users[review.Spec.Token]; ok {
+			review.Status.Authenticated = true
+			review.Status.User = authenticationv1.UserInfo{Username: user, Groups: []string{"customer:acme"}}
+		}
+		return true, review, nil
+	})
+
+	sessions := NewSessions(k8sClient)
+	mcpSrv := mcpserver.NewMCPServer("test", "0.0.0")
+	request := func(sessionID, token string) context.Context {
+		r := httptest.NewRequest("POST", "/mcp", nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		ctx := mcpSrv.WithContext(context.Background(), testSession{id: sessionID})
+		return sessions.HTTPContextFunc(ctx, r)
+	}
+
+	if err := sessions.Authorize(request("one", ""), 1, nil); err == nil {
+		t.Error("request without a token was authorized")
+	}
+	if err := sessions.Authorize(request("one", "unknown"), 1, nil); err == nil {
+		t.Error("request with an invalid token was authorized")
+	}
+
+	for i := 0; i < 2; i++ {
+		ctx := request("one", "alice-token")
+		if err := sessions.Authorize(ctx, 1, nil); err != nil {
+			t.Fatalf("alice: %v", err)
+		}
+		if user, _ := k8s.ImpersonatedUser(ctx); user.Username != "alice" {
+			t.Errorf("impersonated %q, want alice", user.Username)
+		}
+	}
+	if reviews != 2 {
+		t.Errorf("%d token reviews, want the bound token to be reviewed once", reviews)
+	}
+
+	if err := sessions.Authorize(request("one", "bob-token"), 1, nil); err == nil {
+		t.Error("bob's token was accepted for alice's session")
+	}
+	if err := sessions.Authorize(request("two", "bob-token"), 1, nil); err != nil {
+		t.Errorf("bob's own session: %v", err)
+	}
+
+	sessions.Unregister(context.Background(), testSession{id: "one"})
+	if err := sessions.Authorize(request("one", "bob-token"), 1, nil); err != nil {
+		t.Errorf("session reused after it was closed: %v", err)
+	}
+}
@@ -5,18 +5,35 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/tracing"
 )
 
+// inClusterContext is the context name of clients using the service account
+// of the pod the server runs in
+const inClusterContext = "in-cluster"
+
 // Client wraps the Kubernetes client with Giant Swarm specific functionality
 type Client struct {
 	kubernetes.Interface
 	RestConfig *rest.Config
 	Context    string
+
+	// kubeContext is the requested context, empty for the current one
+	kubeContext string
+	// transport is swapped when the kubeconfig changes; nil in a cluster
+	transport *reloadingTransport
+
+	mu sync.RWMutex
+	// reloaded is the config of the most recently reloaded kubeconfig; nil
+	// until it changed
+	reloaded *rest.Config
 }
 
 // NewClient creates a new Kubernetes client
@@ -26,6 +43,14 @@ func NewClient(ctx context.Context, kubeContext string) (*Client, error) {
 		return nil, fmt.Errorf("failed to get kubernetes config: %w", err)
 	}
 
+	var transport *reloadingTransport
+	if currentContext != inClusterContext {
+		transport = &reloadingTransport{}
+		config.Wrap(transport.wrap)
+	}
+	config.Wrap(ImpersonateFromContext)
+	config.Wrap(tracing.WrapTransport)
+
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
@@ -38,9 +63,11 @@ func NewClient(ctx context.Context, kubeContext string) (*Client, error) {
 	}
 
 	return &Client{
-		Interface:  clientset,
-		RestConfig: config,
-		Context:    currentContext,
+		Interface:   clientset,
+		RestConfig:  config,
+		Context:     currentContext,
+		kubeContext: kubeContext,
+		transport:   transport,
 	}, nil
 }
 
@@ -48,11 +75,11 @@ func NewClient(ctx context.Context, kubeContext string) (*Client, error) {
 func getConfig(kubeContext string) (*rest.Config, string, error) {
 	// Try in-cluster config first
 	if config, err := rest.InClusterConfig(); err == nil {
-		return config, "in-cluster", nil
+		return config, inClusterContext, nil
 	}
 
 	// Fall back to kubeconfig
-	kubeconfigPath := getKubeconfigPath()
+	kubeconfigPath := KubeconfigPath()
 
 	// Build config from kubeconfig file
 	configLoadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
@@ -87,8 +114,9 @@ func getConfig(kubeContext string) (*rest.Config, string, error) {
 	return config, currentContext, nil
 }
 
-// getKubeconfigPath returns the path to the kubeconfig file
-func getKubeconfigPath() string {
+// KubeconfigPath returns the path to the kubeconfig file: $KUBECONFIG or
+// ~/.kube/config
+func KubeconfigPath() string {
 	// Check KUBECONFIG env var first
 	if kubeconfig := os.Getenv("KUBECONFIG"); kubeconfig != "" {
 		return kubeconfig
@@ -109,7 +137,7 @@ func (c *Client) GetCurrentContext() string {
 
 // ListContexts returns all available contexts from kubeconfig
 func ListContexts() ([]string, string, error) {
-	kubeconfigPath := getKubeconfigPath()
+	kubeconfigPath := KubeconfigPath()
 
 	configLoadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
 	configLoadingRules.ExplicitPath = kubeconfigPath
@@ -0,0 +1,93 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+)
+
+// UpdateOptions controls how updates handle concurrent changes
+type UpdateOptions struct {
+	// Force writes without the resourceVersion precondition, overwriting
+	// changes made since the object was read instead of retrying
+	Force bool
+}
+
+// ApplyOptions returns the server-side apply options for an update. Fields
+// owned by other field managers are only taken over when forced; otherwise
+// changing them fails with a conflict.
+func (o UpdateOptions) ApplyOptions() metav1.ApplyOptions {
+	return metav1.ApplyOptions{
+		FieldManager: FieldManager,
+		Force:        o.Force,
+	}
+}
+
+// EnsureAbsent turns the result of reading an object that is about to be
+// created with server-side apply into an error unless the object was not
+// found, as apply would update an existing object instead of failing
+func EnsureAbsent(getErr error, resource schema.GroupResource, name string) error {
+	switch {
+	case getErr == nil:
+		return apierrors.NewAlreadyExists(resource, name)
+	case apierrors.IsNotFound(getErr):
+		return nil
+	}
+	return getErr
+}
+
+// Precondition returns the resourceVersion to send with an update, which is
+// empty when forced
+func (o UpdateOptions) Precondition(resourceVersion string) string {
+	if o.Force {
+		return ""
+	}
+	return resourceVersion
+}
+
+// RetryOnConflict runs fn again when it fails because the object was modified
+// since it was read. fn is expected to re-read the object and re-apply its changes.
+// Conflicts over fields owned by another field manager are not retried, as
+// they persist until the update is forced.
+func RetryOnConflict(fn func() error) error {
+	return retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return apierrors.IsConflict(err) && !IsFieldManagerConflict(err)
+	}, fn)
+}
+
+// IsFieldManagerConflict reports whether a server-side apply failed because
+// it would change fields owned by another field manager
+func IsFieldManagerConflict(err error) bool {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) || !apierrors.IsConflict(err) {
+		return false
+	}
+	details := status.Status().Details
+	if details == nil {
+		return false
+	}
+	for _, cause := range details.Causes {
+		if cause.Type == metav1.CauseTypeFieldManagerConflict {
+			return true
+		}
+	}
+	return false
+}
+
+// ConflictError turns a conflict that persisted through all retries into an actionable message
+func ConflictError(kind, namespace, name string, err error) error {
+	if !apierrors.IsConflict(err) {
+		return err
+	}
+
+	if IsFieldManagerConflict(err) {
+		return fmt.Errorf("%s %s/%s has fields owned by another field manager, e.g. an operator or kubectl; "+
+			"re-run with force=true to take them over: %w", kind, namespace, name, err)
+	}
+	return fmt.Errorf("%s %s/%s kept changing while it was being updated, most likely by an operator "+
+		"reconciling it; try again or re-run with force=true to overwrite: %w", kind, namespace, name, err)
+}
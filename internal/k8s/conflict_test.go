@@ -0,0 +1,58 @@
+package k8s
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var appResource = schema.GroupResource{Group: "application.giantswarm.io", Resource: "apps"}
+
+func fieldManagerConflict() error {
+	err := apierrors.NewConflict(appResource, "hello", errors.New("Apply failed with 1 conflict"))
+	err.ErrStatus.Details.Causes = []metav1.StatusCause{
+		{Type: metav1.CauseTypeFieldManagerConflict, Message: `conflict with "kubectl-client-side-apply"`, Field: ".spec.version"},
+	}
+	return err
+}
+
+func TestApplyOptions(t *testing.T) {
+	if opts := (UpdateOptions{}).ApplyOptions(); opts.Force || opts.FieldManager != FieldManager {
+		t.Errorf("ApplyOptions() = %+v, want a non-forced apply as %s", opts, FieldManager)
+	}
+	if opts := (UpdateOptions{Force: true}).ApplyOptions(); !opts.Force {
+		t.Errorf("forced ApplyOptions() = %+v", opts)
+	}
+}
+
+func TestRetryOnConflict(t *testing.T) {
+	// Conflicts over the resourceVersion are retried
+	calls := 0
+	err := RetryOnConflict(func() error {
+		calls++
+		if calls < 3 {
+			return apierrors.NewConflict(appResource, "hello", errors.New("the object has been modified"))
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("RetryOnConflict() = %v after %d calls, want success after 3", err, calls)
+	}
+
+	// Conflicts over field ownership persist until forced
+	calls = 0
+	err = RetryOnConflict(func() error {
+		calls++
+		return fieldManagerConflict()
+	})
+	if calls != 1 || !IsFieldManagerConflict(err) {
+		t.Errorf("RetryOnConflict() = %v after %d calls, want the field manager conflict at once", err, calls)
+	}
+	if msg := ConflictError("app", "org-acme", "hello", err).Error(); !strings.Contains(msg, "owned by another field manager") {
+		t.Errorf("ConflictError() = %s", msg)
+	}
+}
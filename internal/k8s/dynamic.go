@@ -8,6 +8,9 @@ import (
 	"k8s.io/client-go/dynamic"
 )
 
+// FieldManager identifies changes made by this server in managedFields
+const FieldManager = "mcp-giantswarm-apps"
+
 // Giant Swarm CRD Group Version Resources
 var (
 	AppGVR = schema.GroupVersionResource{
@@ -33,6 +36,12 @@ var (
 		Version:  "v1alpha1",
 		Resource: "releases",
 	}
+
+	OrganizationGVR = schema.GroupVersionResource{
+		Group:    "security.giantswarm.io",
+		Version:  "v1alpha1",
+		Resource: "organizations",
+	}
 )
 
 // DynamicClient wraps the dynamic client for Giant Swarm resources
@@ -52,6 +61,12 @@ func NewDynamicClient(client *Client) (*DynamicClient, error) {
 	}, nil
 }
 
+// NewDynamicClientForInterface wraps an existing dynamic interface, e.g. a
+// fake client in tests
+func NewDynamicClientForInterface(client dynamic.Interface) *DynamicClient {
+	return &DynamicClient{client: client}
+}
+
 // GetInterface returns the underlying dynamic interface
 func (d *DynamicClient) GetInterface() dynamic.Interface {
 	return d.client
@@ -89,6 +104,11 @@ func (d *DynamicClient) Releases(namespace string) dynamic.ResourceInterface {
 	return d.client.Resource(ReleaseGVR).Namespace(namespace)
 }
 
+// Organizations returns the interface for working with Organization resources, which are cluster-scoped
+func (d *DynamicClient) Organizations() dynamic.ResourceInterface {
+	return d.client.Resource(OrganizationGVR)
+}
+
 // CheckCRDsExist verifies that Giant Swarm CRDs are installed
 func (d *DynamicClient) CheckCRDsExist(ctx context.Context, client *Client) error {
 	apiResourceList, err := client.Discovery().ServerResourcesForGroupVersion("application.giantswarm.io/v1alpha1")
@@ -0,0 +1,94 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Endpoint is the HTTP API of a cluster add-on such as Prometheus or
+// OpenCost. It is either reached at URL from the server, which only covers the
+// cluster the API runs in, or as a Service proxied through the API server of
+// each cluster.
+type Endpoint struct {
+	URL string
+
+	Namespace string
+	Service   string
+	Port      string
+
+	httpClient *http.Client
+}
+
+// ParseEndpoint parses an http(s) URL, or namespace/service:port of a
+// service in every cluster
+func ParseEndpoint(value string) (*Endpoint, error) {
+	endpoint := &Endpoint{httpClient: &http.Client{Timeout: 30 * time.Second}}
+
+	if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+		if _, err := url.Parse(value); err != nil {
+			return nil, fmt.Errorf("invalid URL %q: %w", value, err)
+		}
+		endpoint.URL = strings.TrimSuffix(value, "/")
+		return endpoint, nil
+	}
+
+	namespace, service, found := strings.Cut(value, "/")
+	if !found || namespace == "" || service == "" {
+		return nil, fmt.Errorf("invalid endpoint %q: expected a URL or namespace/service:port", value)
+	}
+	endpoint.Namespace = namespace
+	endpoint.Service, endpoint.Port, _ = strings.Cut(service, ":")
+	return endpoint, nil
+}
+
+// CoversWorkloadClusters reports whether the endpoint can be reached in
+// workload clusters
+func (e *Endpoint) CoversWorkloadClusters() bool {
+	return e.URL == ""
+}
+
+// String describes the endpoint for output
+func (e *Endpoint) String() string {
+	if e.URL != "" {
+		return e.URL
+	}
+	return fmt.Sprintf("service %s/%s", e.Namespace, e.Service)
+}
+
+// Get requests path with the query params. k8sClient is the client of the
+// cluster whose service is proxied; it is not used for URL endpoints.
+func (e *Endpoint) Get(ctx context.Context, k8sClient kubernetes.Interface, path string, params map[string]string) ([]byte, error) {
+	if e.URL == "" {
+		return k8sClient.CoreV1().Services(e.Namespace).ProxyGet("http", e.Service, e.Port, path, params).DoRaw(ctx)
+	}
+
+	query := url.Values{}
+	for key, value := range params {
+		query.Set(key, value)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.URL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return data, nil
+}
@@ -0,0 +1,36 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// UnknownUser is reported when the identity of the current user cannot be determined
+const UnknownUser = "unknown"
+
+// ReviewSelf returns the user information, including group claims, the API
+// server authenticates a client as, using a SelfSubjectReview
+func ReviewSelf(ctx context.Context, k8sClient kubernetes.Interface) (authenticationv1.UserInfo, error) {
+	review, err := k8sClient.AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err != nil {
+		return authenticationv1.UserInfo{}, fmt.Errorf("failed to review current user: %w", err)
+	}
+	if review.Status.UserInfo.Username == "" {
+		return authenticationv1.UserInfo{}, fmt.Errorf("API server did not return a username")
+	}
+
+	return review.Status.UserInfo, nil
+}
+
+// CurrentUser returns the username the API server authenticates this client as
+func (c *Client) CurrentUser(ctx context.Context) (string, error) {
+	user, err := ReviewSelf(ctx, c)
+	if err != nil {
+		return "", err
+	}
+	return user.Username, nil
+}
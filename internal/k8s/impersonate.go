@@ -0,0 +1,55 @@
+package k8s
+
+import (
+	"context"
+	"net/http"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/client-go/transport"
+)
+
+type impersonationKey struct{}
+
+// WithImpersonation returns a context whose Kubernetes requests are made as
+// user instead of the identity of the server
+func WithImpersonation(ctx context.Context, user authenticationv1.UserInfo) context.Context {
+	return context.WithValue(ctx, impersonationKey{}, user)
+}
+
+// ImpersonatedUser returns the user the Kubernetes requests made with ctx
+// impersonate
+func ImpersonatedUser(ctx context.Context) (authenticationv1.UserInfo, bool) {
+	user, ok := ctx.Value(impersonationKey{}).(authenticationv1.UserInfo)
+	return user, ok
+}
+
+// ImpersonateFromContext is a rest.Config transport wrapper that adds the
+// impersonation headers of the user set with WithImpersonation on the
+// request context
+func ImpersonateFromContext(rt http.RoundTripper) http.RoundTripper {
+	return impersonatingTransport{next: rt}
+}
+
+type impersonatingTransport struct {
+	next http.RoundTripper
+}
+
+func (t impersonatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	user, ok := ImpersonatedUser(req.Context())
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	config := transport.ImpersonationConfig{
+		UserName: user.Username,
+		UID:      user.UID,
+		Groups:   user.Groups,
+	}
+	if len(user.Extra) > 0 {
+		config.Extra = make(map[string][]string, len(user.Extra))
+		for key, values := range user.Extra {
+			config.Extra[key] = values
+		}
+	}
+	return transport.NewImpersonatingRoundTripper(config, t.next).RoundTrip(req)
+}
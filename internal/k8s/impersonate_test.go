@@ -0,0 +1,45 @@
+package k8s
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+func TestImpersonateFromContext(t *testing.T) {
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: ImpersonateFromContext(http.DefaultTransport)}
+	get := func(ctx context.Context) {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	get(context.Background())
+	if user := headers.Get("Impersonate-User"); user != "" {
+		t.Errorf("impersonated %q without a user in the context", user)
+	}
+
+	get(WithImpersonation(context.Background(), authenticationv1.UserInfo{
+		Username: "alice",
+		Groups:   []string{"customer:acme", "system:authenticated"},
+	}))
+	if user := headers.Get("Impersonate-User"); user != "alice" {
+		t.Errorf("Impersonate-User = %q, want alice", user)
+	}
+	if groups := headers.Values("Impersonate-Group"); !reflect.DeepEqual(groups, []string{"customer:acme", "system:authenticated"}) {
+		t.Errorf("Impersonate-Group = %v", groups)
+	}
+}
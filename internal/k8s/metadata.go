@@ -0,0 +1,22 @@
+package k8s
+
+import (
+	"encoding/json"
+)
+
+// MetadataMergePatch returns a JSON merge patch that sets labels and
+// annotations on an object while keeping the ones it already has. It is nil
+// when there is nothing to set.
+func MetadataMergePatch(labels, annotations map[string]string) ([]byte, error) {
+	metadata := make(map[string]interface{})
+	if len(labels) > 0 {
+		metadata["labels"] = labels
+	}
+	if len(annotations) > 0 {
+		metadata["annotations"] = annotations
+	}
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(map[string]interface{}{"metadata": metadata})
+}
@@ -0,0 +1,209 @@
+package k8s
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/rest"
+)
+
+// reloadingTransport sends requests through the transport built from the
+// most recently loaded kubeconfig, so that clients created once keep working
+// after their credentials were rotated
+type reloadingTransport struct {
+	mu      sync.RWMutex
+	current http.RoundTripper
+	// host replaces the scheme and host of requests once the kubeconfig
+	// points at a different server URL; nil until then
+	host *url.URL
+}
+
+// wrap is a rest.Config transport wrapper. The first transport client-go
+// builds is used until the kubeconfig is reloaded.
+func (t *reloadingTransport) wrap(rt http.RoundTripper) http.RoundTripper {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.current == nil {
+		t.current = rt
+	}
+	return t
+}
+
+func (t *reloadingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.RLock()
+	current, host := t.current, t.host
+	t.mu.RUnlock()
+
+	if host != nil && (req.URL.Host != host.Host || req.URL.Scheme != host.Scheme) {
+		// RoundTrippers must not modify the caller's request
+		req = req.Clone(req.Context())
+		req.URL.Scheme = host.Scheme
+		req.URL.Host = host.Host
+		req.Host = ""
+	}
+	return current.RoundTrip(req)
+}
+
+// swap replaces the transport and returns the previous one
+func (t *reloadingTransport) swap(rt http.RoundTripper, host *url.URL) http.RoundTripper {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	previous := t.current
+	t.current, t.host = rt, host
+	return previous
+}
+
+// WatchKubeconfig polls the kubeconfig file every interval and, when the
+// credentials or server of the client's context change, e.g. after a
+// Teleport login rotated them, rebuilds the client's transport and calls
+// onChange. Clients running in a cluster are not watched, since client-go
+// already reloads service account tokens.
+func (c *Client) WatchKubeconfig(ctx context.Context, interval time.Duration, onChange func()) {
+	if c.transport == nil || interval <= 0 {
+		return
+	}
+
+	paths := filepath.SplitList(KubeconfigPath())
+	files := fingerprintFiles(paths)
+	credentials := credentialsFingerprint(c.RestConfig)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			current := fingerprintFiles(paths)
+			if current == files {
+				continue
+			}
+
+			config, _, err := getConfig(c.kubeContext)
+			if err != nil {
+				// The file may be in the middle of being rewritten; try again
+				// on the next tick
+				log.Printf("Warning: failed to reload kubeconfig: %v", err)
+				continue
+			}
+			files = current
+
+			changed := credentialsFingerprint(config)
+			if changed == credentials {
+				continue
+			}
+
+			if err := c.reload(config); err != nil {
+				log.Printf("Warning: failed to reload kubeconfig: %v", err)
+				continue
+			}
+			credentials = changed
+
+			log.Printf("Kubeconfig credentials of context %s changed, reloaded Kubernetes clients", c.Context)
+			if onChange != nil {
+				onChange()
+			}
+		}
+	}()
+}
+
+// reload switches the client's transport to one built from config
+func (c *Client) reload(config *rest.Config) error {
+	rt, err := rest.TransportFor(config)
+	if err != nil {
+		return fmt.Errorf("failed to create transport: %w", err)
+	}
+
+	host, err := url.Parse(config.Host)
+	if err != nil || host.Host == "" {
+		return fmt.Errorf("invalid server URL %q", config.Host)
+	}
+	if host.Scheme == "" {
+		host.Scheme = "https"
+	}
+
+	previous := c.transport.swap(rt, host)
+	utilnet.CloseIdleConnectionsFor(previous)
+
+	c.mu.Lock()
+	c.reloaded = config
+	c.mu.Unlock()
+	return nil
+}
+
+// ServerConfig returns the config of the server the client currently talks
+// to. Unlike RestConfig, its host and certificate authority follow kubeconfig
+// reloads.
+func (c *Client) ServerConfig() *rest.Config {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.reloaded != nil {
+		return c.reloaded
+	}
+	return c.RestConfig
+}
+
+// fingerprintFiles returns a hash of the contents of the kubeconfig files;
+// missing files hash as empty
+func fingerprintFiles(paths []string) string {
+	hash := sha256.New()
+	for _, path := range paths {
+		data, _ := os.ReadFile(path)
+		fmt.Fprintf(hash, "%s:%d:", path, len(data))
+		hash.Write(data)
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
+// credentialsFingerprint returns a hash of the fields of config that
+// determine the server and the identity the client authenticates as
+func credentialsFingerprint(config *rest.Config) string {
+	data, _ := json.Marshal(struct {
+		Host            string
+		Username        string
+		Password        string
+		BearerToken     string
+		BearerTokenFile string
+		CertFile        string
+		KeyFile         string
+		CAFile          string
+		CertData        []byte
+		KeyData         []byte
+		CAData          []byte
+		ServerName      string
+		Insecure        bool
+		AuthProvider    any
+		ExecProvider    any
+	}{
+		Host:            config.Host,
+		Username:        config.Username,
+		Password:        config.Password,
+		BearerToken:     config.BearerToken,
+		BearerTokenFile: config.BearerTokenFile,
+		CertFile:        config.CertFile,
+		KeyFile:         config.KeyFile,
+		CAFile:          config.CAFile,
+		CertData:        config.CertData,
+		KeyData:         config.KeyData,
+		CAData:          config.CAData,
+		ServerName:      config.ServerName,
+		Insecure:        config.Insecure,
+		AuthProvider:    config.AuthProvider,
+		ExecProvider:    config.ExecProvider,
+	})
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
@@ -0,0 +1,77 @@
+package k8s
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestReloadingTransport(t *testing.T) {
+	serve := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(name))
+		}))
+	}
+	oldServer, newServer := serve("old"), serve("new")
+	defer oldServer.Close()
+	defer newServer.Close()
+
+	transport := &reloadingTransport{}
+	client := &http.Client{Transport: transport.wrap(http.DefaultTransport)}
+	get := func() string {
+		resp, err := client.Get(oldServer.URL + "/api")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		buf := make([]byte, 8)
+		n, _ := resp.Body.Read(buf)
+		return string(buf[:n])
+	}
+
+	if got := get(); got != "old" {
+		t.Errorf("before reload: got %q, want old", got)
+	}
+
+	host, _ := url.Parse(newServer.URL)
+	transport.swap(http.DefaultTransport, host)
+	if got := get(); got != "new" {
+		t.Errorf("after reload: got %q, want new", got)
+	}
+}
+
+func TestServerConfigAfterReload(t *testing.T) {
+	client := &Client{
+		RestConfig: &rest.Config{Host: "https://old.example.com", TLSClientConfig: rest.TLSClientConfig{CAData: []byte("old")}},
+		transport:  &reloadingTransport{},
+	}
+	if got := client.ServerConfig().Host; got != "https://old.example.com" {
+		t.Errorf("before reload: host = %s, want the old one", got)
+	}
+
+	reloaded := &rest.Config{Host: "https://new.example.com", TLSClientConfig: rest.TLSClientConfig{Insecure: true}}
+	if err := client.reload(reloaded); err != nil {
+		t.Fatal(err)
+	}
+	if got := client.ServerConfig(); got.Host != "https://new.example.com" || len(got.CAData) != 0 {
+		t.Errorf("after reload: host = %s, CA = %q, want the new server without the old CA", got.Host, got.CAData)
+	}
+}
+
+func TestCredentialsFingerprint(t *testing.T) {
+	config := &rest.Config{Host: "https://api.example.com", BearerToken: "one"}
+	before := credentialsFingerprint(config)
+
+	config.QPS = 50
+	if credentialsFingerprint(config) != before {
+		t.Error("fingerprint changed without a credentials change")
+	}
+
+	config.BearerToken = "two"
+	if credentialsFingerprint(config) == before {
+		t.Error("fingerprint did not change with the token")
+	}
+}
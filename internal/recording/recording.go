@@ -0,0 +1,171 @@
+// Package recording captures tool calls and their results to a file and
+// serves recorded results again, to reproduce agent sessions and to build
+// deterministic demos.
+package recording
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+)
+
+// Entry is a recorded tool call, one JSON object per line of a recording
+type Entry struct {
+	Time      time.Time              `json:"time"`
+	Tool      string                 `json:"tool"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	// Result is the CallToolResult the client received
+	Result json.RawMessage `json:"result,omitempty"`
+	// Error is set when the call failed with a protocol error
+	Error string `json:"error,omitempty"`
+}
+
+// key identifies calls that get the same recorded answers: the tool and its
+// arguments, whose keys encoding/json sorts
+func (e Entry) key() string {
+	if len(e.Arguments) == 0 {
+		return e.Tool + " {}"
+	}
+	args, _ := json.Marshal(e.Arguments)
+	return e.Tool + " " + string(args)
+}
+
+// Recorder appends every tool call to a recording
+type Recorder struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewRecorder creates a recorder appending to path
+func NewRecorder(path string) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording %s: %w", path, err)
+	}
+	return &Recorder{file: file}, nil
+}
+
+// Close closes the recording
+func (r *Recorder) Close() error {
+	return r.file.Close()
+}
+
+// Middleware records the calls and the results handed back to the client
+func (r *Recorder) Middleware() mcpserver.ToolHandlerMiddleware {
+	return func(next mcpserver.ToolHandlerFunc) mcpserver.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, req)
+
+			entry := Entry{Time: time.Now().UTC(), Tool: req.Params.Name, Arguments: req.GetArguments()}
+			if err != nil {
+				entry.Error = err.Error()
+			}
+			if result != nil {
+				if data, marshalErr := json.Marshal(result); marshalErr == nil {
+					entry.Result = data
+				}
+			}
+			r.write(entry)
+			return result, err
+		}
+	}
+}
+
+func (r *Recorder) write(entry Entry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, _ = r.file.Write(append(data, '\n'))
+}
+
+// Replayer answers tool calls with the results of a recording
+type Replayer struct {
+	mu sync.Mutex
+	// answers are the recorded entries of each call, in recording order
+	answers map[string][]Entry
+	// served counts the answers served per call
+	served map[string]int
+}
+
+// Load reads a recording written by a Recorder
+func Load(path string) (*Replayer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording %s: %w", path, err)
+	}
+	defer file.Close()
+
+	r := &Replayer{answers: make(map[string][]Entry), served: make(map[string]int)}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse line %d of recording %s: %w", line, path, err)
+		}
+		r.answers[entry.key()] = append(r.answers[entry.key()], entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recording %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Calls is the number of recorded calls
+func (r *Replayer) Calls() int {
+	n := 0
+	for _, entries := range r.answers {
+		n += len(entries)
+	}
+	return n
+}
+
+// next returns the answer to a call. Repeated calls get the recorded answers
+// in order, and the last one once they are used up.
+func (r *Replayer) next(entry Entry) (Entry, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := entry.key()
+	answers := r.answers[key]
+	if len(answers) == 0 {
+		return Entry{}, false
+	}
+	i := min(r.served[key], len(answers)-1)
+	r.served[key]++
+	return answers[i], true
+}
+
+// Middleware serves recorded results instead of calling the tools. Calls
+// that were not recorded get an error result.
+func (r *Replayer) Middleware() mcpserver.ToolHandlerMiddleware {
+	return func(next mcpserver.ToolHandlerFunc) mcpserver.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			answer, ok := r.next(Entry{Tool: req.Params.Name, Arguments: req.GetArguments()})
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("No recorded answer for %s with these arguments; only recorded calls can be replayed.", req.Params.Name)), nil
+			}
+			if answer.Error != "" {
+				return nil, fmt.Errorf("%s", answer.Error)
+			}
+			result, err := mcp.ParseCallToolResult(&answer.Result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse the recorded result of %s: %w", req.Params.Name, err)
+			}
+			return result, nil
+		}
+	}
+}
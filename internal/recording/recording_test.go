@@ -0,0 +1,87 @@
+package recording
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func callRequest(tool string, args map[string]interface{}) mcp.CallToolRequest {
+	var req mcp.CallToolRequest
+	req.Params.Name = tool
+	req.Params.Arguments = args
+	return req
+}
+
+func resultText(t *testing.T, result *mcp.CallToolResult) string {
+	t.Helper()
+	if result == nil || len(result.Content) == 0 {
+		t.Fatal("result has no content")
+	}
+	text, ok := mcp.AsTextContent(result.Content[0])
+	if !ok {
+		t.Fatalf("content is %T, want text", result.Content[0])
+	}
+	return text.Text
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	recorder, err := NewRecorder(path)
+	if err != nil {
+		t.Fatalf("NewRecorder() error = %v", err)
+	}
+
+	calls := 0
+	handler := recorder.Middleware()(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		if calls == 1 {
+			return mcp.NewToolResultText("first"), nil
+		}
+		return mcp.NewToolResultText("second"), nil
+	})
+	ctx := context.Background()
+	args := map[string]interface{}{"namespace": "org-acme", "all": true}
+	for i := 0; i < 2; i++ {
+		if _, err := handler(ctx, callRequest("app_list", args)); err != nil {
+			t.Fatalf("recorded call error = %v", err)
+		}
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	replayer, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if replayer.Calls() != 2 {
+		t.Errorf("Calls() = %d, want 2", replayer.Calls())
+	}
+	replay := replayer.Middleware()(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		t.Fatal("replay called the tool")
+		return nil, nil
+	})
+
+	// Argument order does not matter, repeated calls get the answers in order
+	reordered := map[string]interface{}{"all": true, "namespace": "org-acme"}
+	for _, want := range []string{"first", "second", "second"} {
+		result, err := replay(ctx, callRequest("app_list", reordered))
+		if err != nil {
+			t.Fatalf("replay error = %v", err)
+		}
+		if got := resultText(t, result); got != want {
+			t.Errorf("replayed %q, want %q", got, want)
+		}
+	}
+
+	result, err := replay(ctx, callRequest("app_list", map[string]interface{}{"namespace": "org-globex"}))
+	if err != nil {
+		t.Fatalf("replay error = %v", err)
+	}
+	if !result.IsError {
+		t.Error("a call that was not recorded should return an error result")
+	}
+}
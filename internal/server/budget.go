@@ -0,0 +1,85 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// OutputBudget limits the size of tool results so that they do not overflow
+// the context of the client
+type OutputBudget struct {
+	// MaxChars is the maximum size of a result in bytes, which bounds the
+	// number of characters; 0 is unlimited
+	MaxChars int
+
+	// MaxItems is the maximum number of entries a listing tool returns per
+	// call; 0 is unlimited
+	MaxItems int
+}
+
+// Fit returns how many of entries, starting at offset, fit the budget after
+// reserved bytes. At least one entry is returned so that listings always
+// make progress.
+func (b OutputBudget) Fit(entries []string, offset, reserved int) int {
+	n := 0
+	size := reserved
+	for _, entry := range entries[offset:] {
+		size += len(entry)
+		if n > 0 && ((b.MaxChars > 0 && size > b.MaxChars) || (b.MaxItems > 0 && n >= b.MaxItems)) {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// Truncate cuts text that exceeds MaxChars at the last line break within the
+// budget and appends a note. It reports whether text was truncated.
+func (b OutputBudget) Truncate(text string) (string, bool) {
+	if b.MaxChars <= 0 || len(text) <= b.MaxChars {
+		return text, false
+	}
+
+	cut := text[:b.MaxChars]
+	if i := strings.LastIndexByte(cut, '\n'); i > 0 {
+		cut = cut[:i+1]
+	} else {
+		// Do not split a multi-byte character
+		end := b.MaxChars
+		for end > 0 && !utf8.RuneStart(text[end]) {
+			end--
+		}
+		cut = text[:end] + "\n"
+	}
+
+	return cut + fmt.Sprintf("\n[output truncated: showing %d of %d bytes, narrow the request with filters to see the rest]\n", len(cut), len(text)), true
+}
+
+// Parts splits text into parts of at most MaxChars bytes, cut at the
+// last line break within the budget where there is one. Text within the
+// budget is a single part.
+func (b OutputBudget) Parts(text string) []string {
+	if b.MaxChars <= 0 || len(text) <= b.MaxChars {
+		return []string{text}
+	}
+
+	var parts []string
+	for len(text) > b.MaxChars {
+		cut := b.MaxChars
+		if i := strings.LastIndexByte(text[:cut], '\n'); i > 0 {
+			cut = i + 1
+		} else {
+			// Do not split a multi-byte character
+			for cut > 1 && !utf8.RuneStart(text[cut]) {
+				cut--
+			}
+		}
+		parts = append(parts, text[:cut])
+		text = text[cut:]
+	}
+	if text != "" {
+		parts = append(parts, text)
+	}
+	return parts
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestOutputBudgetTruncate(t *testing.T) {
+	budget := OutputBudget{MaxChars: 12}
+
+	if got, truncated := budget.Truncate("short\n"); truncated || got != "short\n" {
+		t.Errorf("Truncate(short) = %q, %v", got, truncated)
+	}
+
+	got, truncated := budget.Truncate("line one\nline two\nline three\n")
+	if !truncated || !strings.HasPrefix(got, "line one\n\n[output truncated") {
+		t.Errorf("Truncate = %q, %v; want cut after the first line", got, truncated)
+	}
+
+	// Without a line break the cut moves back to the start of a character
+	got, truncated = budget.Truncate(strings.Repeat("ü", 10))
+	if before, _, _ := strings.Cut(got, "\n"); !truncated || before != strings.Repeat("ü", 6) || !utf8.ValidString(got) {
+		t.Errorf("Truncate = %q, %v; want six whole characters", got, truncated)
+	}
+}
+
+func TestOutputBudgetParts(t *testing.T) {
+	budget := OutputBudget{MaxChars: 12}
+
+	if parts := budget.Parts("short\n"); len(parts) != 1 || parts[0] != "short\n" {
+		t.Errorf("Parts(short) = %q", parts)
+	}
+
+	text := "line one\nline two\nline three\n" + strings.Repeat("ü", 10)
+	parts := budget.Parts(text)
+	if strings.Join(parts, "") != text {
+		t.Errorf("Parts do not add up to the text: %q", parts)
+	}
+	for _, part := range parts {
+		if len(part) > budget.MaxChars || !utf8.ValidString(part) {
+			t.Errorf("part %q exceeds the budget or splits a character", part)
+		}
+	}
+	if parts[0] != "line one\n" {
+		t.Errorf("first part = %q, want cut at the line break", parts[0])
+	}
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxCachedResults bounds the results a ResultCache holds; the oldest are
+// dropped first
+const maxCachedResults = 256
+
+// ResultCache keeps the results of expensive read-only tool calls for a TTL,
+// so that an agent repeating a call within a conversation is answered from
+// memory
+type ResultCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	results map[string]cachedResult
+}
+
+type cachedResult struct {
+	result *mcp.CallToolResult
+	stored time.Time
+}
+
+// NewResultCache creates a cache whose results are fresh for ttl
+func NewResultCache(ttl time.Duration) *ResultCache {
+	return &ResultCache{
+		ttl:     ttl,
+		now:     time.Now,
+		results: make(map[string]cachedResult),
+	}
+}
+
+// Get returns a copy of the fresh result stored under key and its age
+func (c *ResultCache) Get(key string) (*mcp.CallToolResult, time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.results[key]
+	if !ok {
+		return nil, 0, false
+	}
+	age := c.now().Sub(cached.stored)
+	if age >= c.ttl {
+		delete(c.results, key)
+		return nil, 0, false
+	}
+	return copyResult(cached.result), age, true
+}
+
+// Put stores a copy of result under key
+func (c *ResultCache) Put(key string, result *mcp.CallToolResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if len(c.results) >= maxCachedResults {
+		oldest := ""
+		for k, cached := range c.results {
+			if now.Sub(cached.stored) >= c.ttl {
+				delete(c.results, k)
+			} else if oldest == "" || cached.stored.Before(c.results[oldest].stored) {
+				oldest = k
+			}
+		}
+		if len(c.results) >= maxCachedResults {
+			delete(c.results, oldest)
+		}
+	}
+	c.results[key] = cachedResult{result: copyResult(result), stored: now}
+}
+
+// Clear drops all results, e.g. after the credentials changed
+func (c *ResultCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results = make(map[string]cachedResult)
+}
+
+// copyResult copies the content list of a result, which middlewares such as
+// the output budget modify in place
+func copyResult(result *mcp.CallToolResult) *mcp.CallToolResult {
+	c := *result
+	c.Content = append([]mcp.Content(nil), result.Content...)
+	return &c
+}
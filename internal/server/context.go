@@ -1,13 +1,103 @@
 package server
 
 import (
+	"context"
+	"log"
+	"sync"
+
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/identity"
 	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/alerts"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cost"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/metrics"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/organization"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/report"
 )
 
 // Context holds shared server resources
 type Context struct {
 	K8sClient     *k8s.Client
 	DynamicClient *k8s.DynamicClient
+
+	// ConfigHistoryRevisions is the number of previous revisions kept per
+	// ConfigMap or Secret changed through the config tools; 0 disables history
+	ConfigHistoryRevisions int
+
+	// AppCatalogEntryIndex caches AppCatalogEntries for catalog lookups; nil
+	// when caching is disabled
+	AppCatalogEntryIndex *appcatalogentry.Index
+
+	// ResultCache answers repeated calls of expensive read-only tools from
+	// memory; nil when disabled
+	ResultCache *ResultCache
+
+	// UpdatesReporter regenerates the pending upgrades and drift report in
+	// the background; nil when disabled
+	UpdatesReporter *report.Reporter
+
+	// WorkloadClients builds and caches clients for workload clusters from
+	// their kubeconfig secrets
+	WorkloadClients *cluster.ClientPool
+
+	// NamespacePolicy restricts the namespaces tools may operate on
+	NamespacePolicy NamespacePolicy
+
+	// OutputBudget limits the size of tool results
+	OutputBudget OutputBudget
+
+	// Identity determines the current user and its organization; nil when
+	// not configured
+	Identity *identity.Resolver
+
+	// SystemNamespaces are the namespaces classified as system namespaces;
+	// nil is the default list
+	SystemNamespaces organization.SystemNamespaces
+
+	// Runbooks are the troubleshooting docs of well-known apps shown by
+	// app_get and the troubleshoot-app prompt; nil is app.DefaultRunbooks
+	Runbooks app.Runbooks
+
+	// DevCatalog is the OCI catalog app_dev_deploy pushes local charts to, as
+	// namespace/name; empty when not configured
+	DevCatalog string
+
+	// LocalFiles lets tools read files on the server, e.g. TLS key pairs given
+	// as paths. It is only set for the stdio transport, where the client runs
+	// on the same machine and user as the server.
+	LocalFiles bool
+
+	// ValidateRemote makes the catalog tools check that repository URLs are
+	// reachable before saving them
+	ValidateRemote bool
+
+	// CostAPI is the OpenCost or Kubecost API queried by app_cost; nil when
+	// not configured
+	CostAPI *cost.API
+
+	// Prometheus is queried by cluster_metrics instead of the metrics-server;
+	// nil when not configured
+	Prometheus *metrics.Prometheus
+
+	// Alertmanager of the installation is queried by alerts_list and the
+	// troubleshoot-app prompt; nil when not configured
+	Alertmanager *alerts.Alertmanager
+
+	mu       sync.RWMutex
+	defaults Defaults
+	sessions map[string]Defaults
+	creators map[string]string
+}
+
+// Defaults are used by tools when the caller omits the corresponding argument
+type Defaults struct {
+	Organization string
+	Cluster      string
+	Namespace    string
 }
 
 // NewContext creates a new server context
@@ -17,3 +107,84 @@ func NewContext(k8sClient *k8s.Client, dynamicClient *k8s.DynamicClient) *Contex
 		DynamicClient: dynamicClient,
 	}
 }
+
+// Defaults returns the default organization, cluster and namespace of the
+// session calling a tool, falling back to the server's defaults when the
+// session hasn't set any
+func (c *Context) Defaults(ctx context.Context) Defaults {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if session := mcpserver.ClientSessionFromContext(ctx); session != nil {
+		if defaults, ok := c.sessions[session.SessionID()]; ok {
+			return defaults
+		}
+	}
+	return c.defaults
+}
+
+// SetDefaults replaces the default organization, cluster and namespace of the
+// session calling a tool. Outside a session, e.g. at startup, it replaces the
+// server's defaults, which all sessions start with.
+func (c *Context) SetDefaults(ctx context.Context, defaults Defaults) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	session := mcpserver.ClientSessionFromContext(ctx)
+	if session == nil {
+		c.defaults = defaults
+		return
+	}
+	if c.sessions == nil {
+		c.sessions = make(map[string]Defaults)
+	}
+	c.sessions[session.SessionID()] = defaults
+}
+
+// UnregisterSession drops the defaults and creator of a session that ended.
+// It is an OnUnregisterSession hook.
+func (c *Context) UnregisterSession(ctx context.Context, session mcpserver.ClientSession) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.sessions, session.SessionID())
+	delete(c.creators, session.SessionID())
+}
+
+// Creator returns the identity recorded on resources created by the session
+// calling a tool: the username the API server authenticates its requests as.
+// It is resolved with a SelfSubjectReview once per session; when that fails,
+// the error is logged, k8s.UnknownUser returned and the review retried on the
+// next call.
+func (c *Context) Creator(ctx context.Context) string {
+	sessionID := ""
+	if session := mcpserver.ClientSessionFromContext(ctx); session != nil {
+		sessionID = session.SessionID()
+	}
+
+	c.mu.RLock()
+	creator, ok := c.creators[sessionID]
+	c.mu.RUnlock()
+	if ok {
+		return creator
+	}
+
+	creator, err := c.K8sClient.CurrentUser(ctx)
+	if err != nil {
+		log.Printf("Warning: failed to determine the creator of new resources, recording %q: %v", k8s.UnknownUser, err)
+		return k8s.UnknownUser
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.creators == nil {
+		c.creators = make(map[string]string)
+	}
+	c.creators[sessionID] = creator
+	return creator
+}
+
+// ResetCreators drops the creators resolved so far, e.g. after the
+// credentials of the server changed
+func (c *Context) ResetCreators() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.creators = nil
+}
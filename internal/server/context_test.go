@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+)
+
+type testSession struct {
+	id string
+}
+
+func (s testSession) Initialize()       {}
+func (s testSession) Initialized() bool { return true }
+func (s testSession) SessionID() string { return s.id }
+func (s testSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return make(chan mcp.JSONRPCNotification)
+}
+
+func TestSessionDefaults(t *testing.T) {
+	s := mcpserver.NewMCPServer("test", "0.0.0")
+	alice := s.WithContext(context.Background(), testSession{id: "alice"})
+	bob := s.WithContext(context.Background(), testSession{id: "bob"})
+
+	ctx := NewContext(nil, nil)
+	ctx.SetDefaults(context.Background(), Defaults{Organization: "acme"})
+	ctx.SetDefaults(alice, Defaults{Organization: "globex", Cluster: "prod"})
+
+	if got := ctx.Defaults(alice); got.Organization != "globex" || got.Cluster != "prod" {
+		t.Errorf("alice's defaults = %+v", got)
+	}
+	if got := ctx.Defaults(bob); got.Organization != "acme" || got.Cluster != "" {
+		t.Errorf("bob's defaults = %+v, want the server's", got)
+	}
+
+	ctx.UnregisterSession(context.Background(), testSession{id: "alice"})
+	if got := ctx.Defaults(alice); got.Organization != "acme" {
+		t.Errorf("defaults after unregistering = %+v, want the server's", got)
+	}
+}
+
+func TestSessionCreator(t *testing.T) {
+	s := mcpserver.NewMCPServer("test", "0.0.0")
+	alice := s.WithContext(context.Background(), testSession{id: "alice"})
+	bob := s.WithContext(context.Background(), testSession{id: "bob"})
+
+	reviews := 0
+	var reviewErr error
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("create", "selfsubjectreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		reviews++
+		if reviewErr != nil {
+			return true, nil, reviewErr
+		}
+		review := &authenticationv1.SelfSubjectReview{}
+		review.Status.UserInfo.Username = "alice@example.com"
+		return true, review, nil
+	})
+	ctx := NewContext(&k8s.Client{Interface: clientset}, nil)
+
+	// A failed review is not remembered
+	reviewErr = errors.New("the server could not find the requested resource")
+	if got := ctx.Creator(alice); got != k8s.UnknownUser {
+		t.Errorf("Creator() with a failing review = %q, want %q", got, k8s.UnknownUser)
+	}
+	reviewErr = nil
+
+	for i := 0; i < 3; i++ {
+		if got := ctx.Creator(alice); got != "alice@example.com" {
+			t.Errorf("Creator() = %q", got)
+		}
+	}
+	if reviews != 2 {
+		t.Errorf("reviewed %d times for one session, want 2", reviews)
+	}
+
+	ctx.Creator(bob)
+	if reviews != 3 {
+		t.Errorf("reviewed %d times for two sessions, want 3", reviews)
+	}
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+)
+
+// Drainer tracks running tool calls so that shutdown can wait for them to
+// finish before the transport closes the connections their results are sent
+// over
+type Drainer struct {
+	mu      sync.Mutex
+	running int
+	// draining is closed once Drain was called
+	draining chan struct{}
+	// idle is closed once draining and no tool call is running
+	idle chan struct{}
+}
+
+// NewDrainer creates a drainer without running tool calls
+func NewDrainer() *Drainer {
+	return &Drainer{draining: make(chan struct{}), idle: make(chan struct{})}
+}
+
+// Draining returns a channel that is closed once the drainer stops accepting
+// tool calls
+func (d *Drainer) Draining() <-chan struct{} {
+	return d.draining
+}
+
+// isDraining reports whether Drain was called; d.mu must be held
+func (d *Drainer) isDraining() bool {
+	select {
+	case <-d.draining:
+		return true
+	default:
+		return false
+	}
+}
+
+// Middleware counts running tool calls and rejects new ones once draining
+func (d *Drainer) Middleware() mcpserver.ToolHandlerMiddleware {
+	return func(next mcpserver.ToolHandlerFunc) mcpserver.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			d.mu.Lock()
+			if d.isDraining() {
+				d.mu.Unlock()
+				return mcp.NewToolResultError("The server is shutting down, retry the call once it is back."), nil
+			}
+			d.running++
+			d.mu.Unlock()
+
+			defer func() {
+				d.mu.Lock()
+				d.running--
+				if d.isDraining() && d.running == 0 {
+					close(d.idle)
+				}
+				d.mu.Unlock()
+			}()
+
+			return next(ctx, req)
+		}
+	}
+}
+
+// Drain stops accepting tool calls and waits until the running ones have
+// finished or ctx is done
+func (d *Drainer) Drain(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.isDraining() {
+		close(d.draining)
+		if d.running == 0 {
+			close(d.idle)
+		}
+	}
+	d.mu.Unlock()
+
+	select {
+	case <-d.idle:
+		return nil
+	case <-ctx.Done():
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if d.running == 1 {
+			return fmt.Errorf("1 tool call still running after the shutdown grace period")
+		}
+		return fmt.Errorf("%d tool calls still running after the shutdown grace period", d.running)
+	}
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestDrainer(t *testing.T) {
+	drainer := NewDrainer()
+	started, release := make(chan struct{}), make(chan struct{})
+	handler := drainer.Middleware()(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		close(started)
+		<-release
+		return mcp.NewToolResultText("done"), nil
+	})
+
+	done := make(chan *mcp.CallToolResult)
+	go func() {
+		result, _ := handler(context.Background(), mcp.CallToolRequest{})
+		done <- result
+	}()
+	<-started
+
+	// Without a grace period, drain gives up on the running call right away
+	expired, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := drainer.Drain(expired); err == nil || err.Error() != "1 tool call still running after the shutdown grace period" {
+		t.Errorf("drain with a running call: error = %v", err)
+	}
+	select {
+	case <-drainer.Draining():
+	default:
+		t.Error("drainer does not report draining")
+	}
+
+	if result, _ := handler(context.Background(), mcp.CallToolRequest{}); result == nil || !result.IsError {
+		t.Error("call accepted while draining")
+	}
+
+	close(release)
+	if result := <-done; result.IsError {
+		t.Error("running call failed")
+	}
+	if err := drainer.Drain(context.Background()); err != nil {
+		t.Errorf("drain after the call finished: %v", err)
+	}
+}
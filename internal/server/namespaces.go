@@ -0,0 +1,49 @@
+package server
+
+import (
+	"fmt"
+	"path"
+)
+
+// NamespacePolicy restricts the namespaces tools may operate on using glob
+// patterns. A namespace is allowed when it matches an allowed pattern (or no
+// allowed patterns are configured) and matches none of the denied patterns.
+type NamespacePolicy struct {
+	Allowed []string
+	Denied  []string
+}
+
+// NewNamespacePolicy creates a policy after checking the pattern syntax
+func NewNamespacePolicy(allowed, denied []string) (NamespacePolicy, error) {
+	for _, pattern := range append(append([]string{}, allowed...), denied...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return NamespacePolicy{}, fmt.Errorf("invalid namespace pattern %q: %w", pattern, err)
+		}
+	}
+
+	return NamespacePolicy{Allowed: allowed, Denied: denied}, nil
+}
+
+// Active reports whether the policy restricts any namespace
+func (p NamespacePolicy) Active() bool {
+	return len(p.Allowed) > 0 || len(p.Denied) > 0
+}
+
+// Allows reports whether tools may operate on a namespace
+func (p NamespacePolicy) Allows(namespace string) bool {
+	for _, pattern := range p.Denied {
+		if ok, _ := path.Match(pattern, namespace); ok {
+			return false
+		}
+	}
+
+	if len(p.Allowed) == 0 {
+		return true
+	}
+	for _, pattern := range p.Allowed {
+		if ok, _ := path.Match(pattern, namespace); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+)
+
+// Stage is a step of a long running operation reported to the client
+type Stage string
+
+const (
+	StageValidated   Stage = "validated"
+	StageApplied     Stage = "applied"
+	StageReconciling Stage = "reconciling"
+	StageReady       Stage = "ready"
+)
+
+// Progress sends MCP progress notifications for a tool call. Notifications are
+// only sent when the client asked for them with a progress token; otherwise
+// reporting is a no-op.
+type Progress struct {
+	ctx     context.Context
+	srv     *mcpserver.MCPServer
+	token   mcp.ProgressToken
+	total   int
+	current int
+}
+
+// NewProgress creates a progress reporter for a tool call with the given number of stages
+func NewProgress(ctx context.Context, req mcp.CallToolRequest, total int) *Progress {
+	p := &Progress{
+		ctx:   ctx,
+		srv:   mcpserver.ServerFromContext(ctx),
+		total: total,
+	}
+	if req.Params.Meta != nil {
+		p.token = req.Params.Meta.ProgressToken
+	}
+	return p
+}
+
+// Report marks a stage as reached
+func (p *Progress) Report(stage Stage, message string) {
+	p.current++
+	if p.token == nil || p.srv == nil {
+		return
+	}
+
+	text := string(stage)
+	if message != "" {
+		text += ": " + message
+	}
+
+	params := map[string]any{
+		"progressToken": p.token,
+		"progress":      p.current,
+		"message":       text,
+	}
+	if p.total > 0 {
+		params["total"] = p.total
+	}
+
+	if err := p.srv.SendNotificationToClient(p.ctx, "notifications/progress", params); err != nil {
+		log.Printf("Failed to send progress notification: %v", err)
+	}
+}
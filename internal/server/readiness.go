@@ -0,0 +1,33 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ReadinessPath is the HTTP endpoint reporting whether the server is ready
+// to serve tool calls
+const ReadinessPath = "/readyz"
+
+// Ready returns an error while caches are still warming up. Tools work
+// before that, but are slower and fall back to the API server.
+func (c *Context) Ready() error {
+	if c.AppCatalogEntryIndex != nil && !c.AppCatalogEntryIndex.Synced() {
+		return fmt.Errorf("app catalog entry index is warming up")
+	}
+	return nil
+}
+
+// ReadinessHandler serves ReadinessPath, answering 503 until the server is
+// ready
+func ReadinessHandler(c *Context) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if err := c.Ready(); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintln(w, err)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+)
+
+func TestReadinessHandler(t *testing.T) {
+	ctx := NewContext(nil, nil)
+	handler := ReadinessHandler(ctx)
+
+	get := func() int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ReadinessPath, nil))
+		return rec.Code
+	}
+
+	if code := get(); code != http.StatusOK {
+		t.Errorf("without caches: status %d, want 200", code)
+	}
+
+	ctx.AppCatalogEntryIndex = appcatalogentry.NewIndex(nil, 0)
+	if code := get(); code != http.StatusServiceUnavailable {
+		t.Errorf("while warming up: status %d, want 503", code)
+	}
+
+	ctx.AppCatalogEntryIndex.Load(nil)
+	if code := get(); code != http.StatusOK {
+		t.Errorf("after sync: status %d, want 200", code)
+	}
+}
@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestToolSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+	client := &http.Client{Transport: WrapTransport(http.DefaultTransport)}
+
+	handler := ToolMiddleware()(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		httpReq, _ := http.NewRequestWithContext(ctx, http.MethodGet, api.URL+"/api/v1/namespaces", nil)
+		resp, err := client.Do(httpReq)
+		if err != nil {
+			return nil, err
+		}
+		resp.Body.Close()
+		return nil, errors.New("app not found")
+	})
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "app_get"
+	if _, err := handler(context.Background(), req); err == nil {
+		t.Fatal("expected the tool error to be returned")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("recorded %d spans, want 2", len(spans))
+	}
+	k8sSpan, toolSpan := spans[0], spans[1]
+
+	if toolSpan.Name() != "tool app_get" || toolSpan.Status().Code != codes.Error {
+		t.Errorf("tool span = %s (%v), want failed tool app_get", toolSpan.Name(), toolSpan.Status())
+	}
+	if k8sSpan.Name() != "k8s GET" || k8sSpan.Parent().SpanID() != toolSpan.SpanContext().SpanID() {
+		t.Errorf("k8s span = %s with parent %s, want k8s GET under the tool span", k8sSpan.Name(), k8sSpan.Parent().SpanID())
+	}
+}
@@ -4,9 +4,15 @@ import (
 	"github.com/giantswarm/mcp-giantswarm-apps/cmd"
 )
 
-const version = "dev"
+// Set at build time with -ldflags "-X main.version=... -X main.commit=... -X main.date=..."
+var (
+	version = "dev"
+	commit  = ""
+	date    = ""
+)
 
 func main() {
 	cmd.SetVersion(version)
+	cmd.SetBuildInfo(commit, date)
 	cmd.Execute()
 }
@@ -0,0 +1,243 @@
+// Package alerts reads the alerts an Alertmanager currently holds and
+// filters them by the cluster, namespace and app they concern.
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+)
+
+// Labels of Prometheus alerts that identify what an alert concerns
+const (
+	AlertNameLabel = "alertname"
+	SeverityLabel  = "severity"
+	NamespaceLabel = "namespace"
+)
+
+// ClusterLabels name the cluster an alert fires for; Giant Swarm
+// installations use cluster_id
+var ClusterLabels = []string{"cluster_id", "cluster"}
+
+// AppLabels name the app an alert fires for
+var AppLabels = []string{"app", "app_name", "app_kubernetes_io_name", "app_kubernetes_io_instance", "release", "helm_release"}
+
+// WorkloadLabels name the workload an alert fires for. Their values start
+// with the Helm release name for workloads of an app.
+var WorkloadLabels = []string{"pod", "deployment", "statefulset", "daemonset", "job_name", "service", "container"}
+
+// severityOrder ranks severities, the most severe first
+var severityOrder = map[string]int{"critical": 0, "page": 0, "error": 1, "warning": 2, "notify": 3, "info": 4}
+
+// Alert is an alert held by Alertmanager
+type Alert struct {
+	Fingerprint  string            `json:"fingerprint"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Status       struct {
+		// State is active, suppressed or unprocessed
+		State       string   `json:"state"`
+		SilencedBy  []string `json:"silencedBy"`
+		InhibitedBy []string `json:"inhibitedBy"`
+	} `json:"status"`
+}
+
+// Name is the alert name
+func (a Alert) Name() string {
+	return a.Labels[AlertNameLabel]
+}
+
+// Severity is the severity label, or none
+func (a Alert) Severity() string {
+	if severity := a.Labels[SeverityLabel]; severity != "" {
+		return severity
+	}
+	return "none"
+}
+
+// Cluster is the cluster the alert fires for, if labeled
+func (a Alert) Cluster() string {
+	for _, label := range ClusterLabels {
+		if value := a.Labels[label]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Summary is the summary, description or message annotation
+func (a Alert) Summary() string {
+	for _, annotation := range []string{"summary", "description", "message"} {
+		if value := a.Annotations[annotation]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Alertmanager is a configured Alertmanager API
+type Alertmanager struct {
+	*k8s.Endpoint
+}
+
+// ParseAlertmanager parses the --alertmanager flag: an http(s) URL, or
+// namespace/service:port of the Alertmanager service in the management cluster
+func ParseAlertmanager(value string) (*Alertmanager, error) {
+	endpoint, err := k8s.ParseEndpoint(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Alertmanager: %w", err)
+	}
+	return &Alertmanager{Endpoint: endpoint}, nil
+}
+
+// List returns the active alerts. Silenced and inhibited alerts are only
+// included with includeSuppressed. k8sClient is the client of the cluster
+// whose Alertmanager service is proxied.
+func (am *Alertmanager) List(ctx context.Context, k8sClient kubernetes.Interface, includeSuppressed bool) ([]Alert, error) {
+	params := map[string]string{
+		"active":    "true",
+		"silenced":  strconv.FormatBool(includeSuppressed),
+		"inhibited": strconv.FormatBool(includeSuppressed),
+	}
+	data, err := am.Get(ctx, k8sClient, "/api/v2/alerts", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alerts from Alertmanager %s: %w", am.Endpoint, err)
+	}
+
+	var alerts []Alert
+	if err := json.Unmarshal(data, &alerts); err != nil {
+		return nil, fmt.Errorf("failed to parse the Alertmanager response: %w", err)
+	}
+	return alerts, nil
+}
+
+// Filter selects alerts. Empty fields match every alert.
+type Filter struct {
+	Cluster string
+	// Namespace is the namespace the alert fires in. With Apps, alerts
+	// without a namespace label match as well.
+	Namespace string
+	// Apps are names an alert of the app carries in one of AppLabels, or as
+	// the prefix of a WorkloadLabels value
+	Apps     []string
+	Severity string
+	// Labels must all match exactly
+	Labels map[string]string
+}
+
+// Matches reports whether an alert passes the filter
+func (f Filter) Matches(a Alert) bool {
+	if f.Cluster != "" && a.Cluster() != f.Cluster {
+		return false
+	}
+	if namespace := a.Labels[NamespaceLabel]; f.Namespace != "" && namespace != f.Namespace && (namespace != "" || len(f.Apps) == 0) {
+		return false
+	}
+	if f.Severity != "" && !strings.EqualFold(a.Severity(), f.Severity) {
+		return false
+	}
+	for key, value := range f.Labels {
+		if a.Labels[key] != value {
+			return false
+		}
+	}
+	return len(f.Apps) == 0 || f.matchesApp(a)
+}
+
+func (f Filter) matchesApp(a Alert) bool {
+	for _, name := range f.Apps {
+		for _, label := range AppLabels {
+			if a.Labels[label] == name {
+				return true
+			}
+		}
+		for _, label := range WorkloadLabels {
+			if value := a.Labels[label]; value == name || strings.HasPrefix(value, name+"-") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Apply returns the alerts passing the filter, the most severe and then the
+// longest firing first
+func (f Filter) Apply(alerts []Alert) []Alert {
+	var matched []Alert
+	for _, a := range alerts {
+		if f.Matches(a) {
+			matched = append(matched, a)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		a, b := severityRank(matched[i]), severityRank(matched[j])
+		if a != b {
+			return a < b
+		}
+		return matched[i].StartsAt.Before(matched[j].StartsAt)
+	})
+	return matched
+}
+
+func severityRank(a Alert) int {
+	if rank, ok := severityOrder[strings.ToLower(a.Severity())]; ok {
+		return rank
+	}
+	return len(severityOrder)
+}
+
+// AppFilter selects the alerts of an app deployed to clusterName, which is
+// empty for the management cluster
+func AppFilter(a *app.App, clusterName string) Filter {
+	names := []string{a.Name}
+	if a.Spec.Name != "" && a.Spec.Name != a.Name {
+		names = append(names, a.Spec.Name)
+	}
+	return Filter{Cluster: clusterName, Namespace: a.Spec.Namespace, Apps: names}
+}
+
+// Describe renders an alert on one line: severity, name, where it fires, for
+// how long and its summary
+func (a Alert) Describe(now time.Time) string {
+	var where []string
+	if cluster := a.Cluster(); cluster != "" {
+		where = append(where, "cluster "+cluster)
+	}
+	if namespace := a.Labels[NamespaceLabel]; namespace != "" {
+		where = append(where, "namespace "+namespace)
+	}
+	for _, label := range WorkloadLabels {
+		if value := a.Labels[label]; value != "" {
+			where = append(where, label+" "+value)
+			break
+		}
+	}
+	if !a.StartsAt.IsZero() {
+		where = append(where, "for "+now.Sub(a.StartsAt).Round(time.Minute).String())
+	}
+	if len(a.Status.SilencedBy) > 0 {
+		where = append(where, "silenced")
+	} else if len(a.Status.InhibitedBy) > 0 {
+		where = append(where, "inhibited")
+	}
+
+	line := fmt.Sprintf("[%s] %s", a.Severity(), a.Name())
+	if len(where) > 0 {
+		line += " (" + strings.Join(where, ", ") + ")"
+	}
+	if summary := a.Summary(); summary != "" {
+		line += ": " + strings.Join(strings.Fields(summary), " ")
+	}
+	return line
+}
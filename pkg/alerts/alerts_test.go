@@ -0,0 +1,67 @@
+package alerts
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+)
+
+const alertsJSON = `[
+  {"fingerprint": "1", "labels": {"alertname": "DeploymentNotSatisfied", "severity": "warning", "cluster_id": "prod", "namespace": "kube-system", "deployment": "prod-ingress-controller"},
+   "annotations": {"description": "Deployment kube-system/prod-ingress-controller is not satisfied."}, "startsAt": "2026-10-16T10:00:00Z", "status": {"state": "active"}},
+  {"fingerprint": "2", "labels": {"alertname": "IngressDown", "severity": "page", "cluster_id": "prod", "app": "ingress-nginx"},
+   "startsAt": "2026-10-16T11:00:00Z", "status": {"state": "active"}},
+  {"fingerprint": "3", "labels": {"alertname": "PodCrashLooping", "severity": "page", "cluster_id": "staging", "namespace": "kube-system", "pod": "prod-ingress-abc"},
+   "startsAt": "2026-10-16T09:00:00Z", "status": {"state": "active"}},
+  {"fingerprint": "4", "labels": {"alertname": "CoreDNSDown", "severity": "page", "cluster_id": "prod", "namespace": "kube-system", "pod": "coredns-abc"},
+   "startsAt": "2026-10-16T09:00:00Z", "status": {"state": "active"}}
+]`
+
+func TestListAndAppFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/alerts" || r.URL.Query().Get("silenced") != "false" {
+			http.Error(w, "unexpected request "+r.URL.String(), http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(alertsJSON))
+	}))
+	defer server.Close()
+
+	am, err := ParseAlertmanager(server.URL)
+	if err != nil {
+		t.Fatalf("ParseAlertmanager() error = %v", err)
+	}
+	all, err := am.List(context.Background(), nil, false)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(all) != 4 {
+		t.Fatalf("got %d alerts, want 4", len(all))
+	}
+
+	a := &app.App{Name: "prod-ingress", Namespace: "org-acme", Spec: app.AppSpec{Name: "ingress-nginx", Namespace: "kube-system"}}
+	matched := AppFilter(a, "prod").Apply(all)
+	var names []string
+	for _, alert := range matched {
+		names = append(names, alert.Name())
+	}
+	// The page comes before the warning; other clusters and apps are left out
+	if got, want := strings.Join(names, ","), "IngressDown,DeploymentNotSatisfied"; got != want {
+		t.Errorf("matched %s, want %s", got, want)
+	}
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	want := "[warning] DeploymentNotSatisfied (cluster prod, namespace kube-system, deployment prod-ingress-controller, for 2h0m0s): Deployment kube-system/prod-ingress-controller is not satisfied."
+	if got := matched[1].Describe(now); got != want {
+		t.Errorf("Describe() = %q, want %q", got, want)
+	}
+
+	if got := (Filter{Severity: "PAGE", Labels: map[string]string{"cluster_id": "prod"}}).Apply(all); len(got) != 2 {
+		t.Errorf("got %d page alerts of prod, want 2", len(got))
+	}
+}
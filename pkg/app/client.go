@@ -2,10 +2,15 @@ package app
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
 	"github.com/giantswarm/mcp-giantswarm-apps/pkg/organization"
@@ -67,11 +72,18 @@ func (c *Client) Get(ctx context.Context, namespace, name string) (*App, error)
 	return NewAppFromUnstructured(obj)
 }
 
-// Create creates a new app
+// Create creates a new app with server-side apply, so that later updates own
+// its fields without forcing
 func (c *Client) Create(ctx context.Context, app *App) (*App, error) {
+	_, err := c.dynamicClient.Apps(app.Namespace).Get(ctx, app.Name, metav1.GetOptions{})
+	if err = k8s.EnsureAbsent(err, k8s.AppGVR.GroupResource(), app.Name); err != nil {
+		return nil, fmt.Errorf("failed to create app %s/%s: %w", app.Namespace, app.Name, err)
+	}
+
 	unstructuredApp := app.ToUnstructured()
+	unstructuredApp.SetResourceVersion("")
 
-	created, err := c.dynamicClient.Apps(app.Namespace).Create(ctx, unstructuredApp, metav1.CreateOptions{})
+	created, err := c.dynamicClient.Apps(app.Namespace).Apply(ctx, app.Name, unstructuredApp, k8s.UpdateOptions{}.ApplyOptions())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create app %s/%s: %w", app.Namespace, app.Name, err)
 	}
@@ -79,27 +91,39 @@ func (c *Client) Create(ctx context.Context, app *App) (*App, error) {
 	return NewAppFromUnstructured(created)
 }
 
-// Update updates an existing app
-func (c *Client) Update(ctx context.Context, app *App) (*App, error) {
-	// Get current app to preserve metadata
-	current, err := c.Get(ctx, app.Namespace, app.Name)
-	if err != nil {
-		return nil, err
-	}
+// Update re-reads an app, applies mutate to it and sends the managed fields
+// with server-side apply. The apply is conditional on the resourceVersion that
+// was read, so concurrent changes (e.g. by app-operator) cause a re-read and
+// retry instead of being overwritten, unless opts.Force is set. Metadata and
+// spec fields this server does not manage are kept.
+func (c *Client) Update(ctx context.Context, namespace, name string, opts k8s.UpdateOptions, mutate func(*App) error) (*App, error) {
+	var updated *App
+	err := k8s.RetryOnConflict(func() error {
+		current, err := c.Get(ctx, namespace, name)
+		if err != nil {
+			return err
+		}
 
-	// Convert to unstructured
-	unstructuredApp := app.ToUnstructured()
+		if err := mutate(current); err != nil {
+			return err
+		}
+
+		applyConfig := current.ToApplyConfiguration()
+		applyConfig.SetResourceVersion(opts.Precondition(current.ResourceVersion))
 
-	// Preserve resource version for update
-	currentUnstructured := current.ToUnstructured()
-	unstructuredApp.SetResourceVersion(currentUnstructured.GetResourceVersion())
+		obj, err := c.dynamicClient.Apps(namespace).Apply(ctx, name, applyConfig, opts.ApplyOptions())
+		if err != nil {
+			return err
+		}
 
-	updated, err := c.dynamicClient.Apps(app.Namespace).Update(ctx, unstructuredApp, metav1.UpdateOptions{})
+		updated, err = NewAppFromUnstructured(obj)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update app %s/%s: %w", app.Namespace, app.Name, err)
+		return nil, fmt.Errorf("failed to update app %s/%s: %w", namespace, name, k8s.ConflictError("app", namespace, name, err))
 	}
 
-	return NewAppFromUnstructured(updated)
+	return updated, nil
 }
 
 // Delete deletes an app
@@ -114,13 +138,111 @@ func (c *Client) Delete(ctx context.Context, namespace, name string) error {
 
 // UpdateVersion updates the version of an app
 func (c *Client) UpdateVersion(ctx context.Context, namespace, name, version string) (*App, error) {
-	app, err := c.Get(ctx, namespace, name)
+	return c.Update(ctx, namespace, name, k8s.UpdateOptions{}, func(app *App) error {
+		app.Spec.Version = version
+		return nil
+	})
+}
+
+// WaitForDeployed polls an app until app-operator has deployed its desired version.
+// It fails early when the release of that version failed and otherwise waits
+// until ctx is done.
+func (c *Client) WaitForDeployed(ctx context.Context, namespace, name string, interval time.Duration) (*App, error) {
+	var current *App
+	err := wait.PollUntilContextCancel(ctx, interval, true, func(ctx context.Context) (bool, error) {
+		var err error
+		current, err = c.Get(ctx, namespace, name)
+		if err != nil {
+			return false, err
+		}
+
+		if current.Status.Version != current.Spec.Version {
+			return false, nil
+		}
+
+		switch current.Status.Release.Status {
+		case "deployed":
+			return true, nil
+		case "failed":
+			return false, fmt.Errorf("release of app %s/%s version %s failed", namespace, name, current.Spec.Version)
+		default:
+			return false, nil
+		}
+	})
 	if err != nil {
-		return nil, err
+		if ctx.Err() != nil && current != nil {
+			return current, fmt.Errorf("timed out waiting for app %s/%s to be deployed (release status: %q)",
+				namespace, name, current.Status.Release.Status)
+		}
+		return current, err
+	}
+
+	return current, nil
+}
+
+// SetLabels adds or overwrites labels on an app and removes the given keys
+func (c *Client) SetLabels(ctx context.Context, namespace, name string, set map[string]string, remove []string) (*App, error) {
+	return c.patchMetadata(ctx, namespace, name, "labels", set, remove)
+}
+
+// SetAnnotations adds or overwrites annotations on an app and removes the given keys
+func (c *Client) SetAnnotations(ctx context.Context, namespace, name string, set map[string]string, remove []string) (*App, error) {
+	return c.patchMetadata(ctx, namespace, name, "annotations", set, remove)
+}
+
+// Pause stops app-operator from reconciling an app
+func (c *Client) Pause(ctx context.Context, namespace, name string) (*App, error) {
+	return c.SetAnnotations(ctx, namespace, name, map[string]string{PausedAnnotation: "true"}, nil)
+}
+
+// Resume lets app-operator reconcile a paused app again
+func (c *Client) Resume(ctx context.Context, namespace, name string) (*App, error) {
+	return c.SetAnnotations(ctx, namespace, name, nil, []string{PausedAnnotation})
+}
+
+// Protect protects an app from deletion, recording reason when set
+func (c *Client) Protect(ctx context.Context, namespace, name, reason string) (*App, error) {
+	set := map[string]string{DeletionProtectionAnnotation: "true"}
+	var remove []string
+	if reason != "" {
+		set[DeletionProtectionReasonAnnotation] = reason
+	} else {
+		remove = append(remove, DeletionProtectionReasonAnnotation)
 	}
+	return c.SetAnnotations(ctx, namespace, name, set, remove)
+}
 
-	app.Spec.Version = version
-	return c.Update(ctx, app)
+// Unprotect lets an app be deleted again
+func (c *Client) Unprotect(ctx context.Context, namespace, name string) (*App, error) {
+	return c.SetAnnotations(ctx, namespace, name, nil, []string{DeletionProtectionAnnotation, DeletionProtectionReasonAnnotation})
+}
+
+// patchMetadata changes labels or annotations with a merge patch, leaving all
+// other keys untouched
+func (c *Client) patchMetadata(ctx context.Context, namespace, name, field string, set map[string]string, remove []string) (*App, error) {
+	values := make(map[string]interface{}, len(set)+len(remove))
+	for _, key := range remove {
+		values[key] = nil
+	}
+	for key, value := range set {
+		values[key] = value
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			field: values,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build %s patch: %w", field, err)
+	}
+
+	patched, err := c.dynamicClient.Apps(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update %s of app %s/%s: %w", field, namespace, name, err)
+	}
+
+	return NewAppFromUnstructured(patched)
 }
 
 // FilterByStatus filters apps by release status
@@ -153,6 +275,57 @@ func FilterByCatalog(apps []*App, catalog string) []*App {
 	return filtered
 }
 
+// FilterByCatalogRef filters apps to those installed from the catalog
+// namespace/name. Apps without a catalog namespace match by name, since
+// app-operator resolves their catalog by name alone.
+func FilterByCatalogRef(apps []*App, namespace, name string) []*App {
+	filtered := make([]*App, 0)
+	for _, app := range apps {
+		if app.Spec.Catalog != name {
+			continue
+		}
+		if app.Spec.CatalogNamespace == "" || app.Spec.CatalogNamespace == namespace {
+			filtered = append(filtered, app)
+		}
+	}
+	return filtered
+}
+
+// FilterByLabels filters apps by a label selector
+func FilterByLabels(apps []*App, selector string) ([]*App, error) {
+	if selector == "" {
+		return apps, nil
+	}
+
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %w", selector, err)
+	}
+
+	filtered := make([]*App, 0)
+	for _, app := range apps {
+		if parsed.Matches(labels.Set(app.Labels)) {
+			filtered = append(filtered, app)
+		}
+	}
+	return filtered, nil
+}
+
+// FilterByManaged filters apps to those created through this server
+func FilterByManaged(apps []*App, managedOnly bool) []*App {
+	if !managedOnly {
+		return apps
+	}
+
+	filtered := make([]*App, 0)
+	for _, app := range apps {
+		if app.IsMCPManaged() {
+			filtered = append(filtered, app)
+		}
+	}
+	return filtered
+}
+
 // GetOrganizationNamespaces returns all organization namespaces (org-*)
 func (c *Client) GetOrganizationNamespaces(ctx context.Context, k8sClient *k8s.Client) ([]string, error) {
 	return organization.ListOrganizationNamespaces(ctx, k8sClient)
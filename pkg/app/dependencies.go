@@ -0,0 +1,102 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+)
+
+// Dependency check results
+const (
+	DependencySatisfied    = "satisfied"
+	DependencyMissing      = "missing"
+	DependencyIncompatible = "incompatible"
+	DependencyBundled      = "bundled"
+)
+
+// DependencyCheck is the result of checking a single dependency against installed apps
+type DependencyCheck struct {
+	Dependency       appcatalogentry.Dependency `json:"dependency"`
+	Status           string                     `json:"status"`
+	InstalledApp     string                     `json:"installedApp,omitempty"`
+	InstalledVersion string                     `json:"installedVersion,omitempty"`
+}
+
+// CheckDependencies compares dependencies with the apps installed on the same cluster
+func CheckDependencies(deps []appcatalogentry.Dependency, installed []*App) []DependencyCheck {
+	checks := make([]DependencyCheck, 0, len(deps))
+	for _, dep := range deps {
+		check := DependencyCheck{Dependency: dep}
+
+		if dep.Bundled {
+			check.Status = DependencyBundled
+			checks = append(checks, check)
+			continue
+		}
+
+		found := findDependencyApp(dep.Name, installed)
+		if found == nil {
+			check.Status = DependencyMissing
+			checks = append(checks, check)
+			continue
+		}
+
+		check.InstalledApp = found.Namespace + "/" + found.Name
+		check.InstalledVersion = found.Spec.Version
+		check.Status = DependencySatisfied
+		if !versionSatisfies(found.Spec.Version, dep.Constraint) {
+			check.Status = DependencyIncompatible
+		}
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+// findDependencyApp finds an installed app by chart name. Giant Swarm charts are
+// often published with an "-app" suffix, so both spellings are accepted.
+func findDependencyApp(name string, installed []*App) *App {
+	base := strings.TrimSuffix(name, "-app")
+	for _, a := range installed {
+		if strings.TrimSuffix(a.Spec.Name, "-app") == base {
+			return a
+		}
+	}
+	return nil
+}
+
+// versionSatisfies reports whether a version matches a semver constraint.
+// An empty constraint is always satisfied; unparsable versions are not.
+func versionSatisfies(version, constraint string) bool {
+	if constraint == "" {
+		return true
+	}
+
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return false
+	}
+
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false
+	}
+
+	return c.Check(v)
+}
+
+// SameTargetCluster reports whether two apps are deployed to the same cluster
+func SameTargetCluster(a, b *App) bool {
+	if a.Spec.KubeConfig.InCluster || b.Spec.KubeConfig.InCluster {
+		return a.Spec.KubeConfig.InCluster == b.Spec.KubeConfig.InCluster
+	}
+
+	if a.Spec.KubeConfig.Secret == nil || b.Spec.KubeConfig.Secret == nil {
+		return a.Namespace == b.Namespace
+	}
+
+	return a.Spec.KubeConfig.Secret.Name == b.Spec.KubeConfig.Secret.Name &&
+		a.Spec.KubeConfig.Secret.Namespace == b.Spec.KubeConfig.Secret.Namespace
+}
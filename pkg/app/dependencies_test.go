@@ -0,0 +1,38 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+)
+
+func TestCheckDependencies(t *testing.T) {
+	deps := []appcatalogentry.Dependency{
+		{Name: "cert-manager-app", Constraint: ">=3.0.0"},
+		{Name: "external-dns-app"},
+		{Name: "prometheus-operator-crd", Constraint: "~1.2"},
+		{Name: "common", Bundled: true},
+	}
+
+	installed := []*App{
+		{Name: "cert-manager", Namespace: "org-acme", Spec: AppSpec{Name: "cert-manager", Version: "2.25.0"}},
+		{Name: "crds", Namespace: "org-acme", Spec: AppSpec{Name: "prometheus-operator-crd", Version: "1.2.3"}},
+	}
+
+	want := map[string]string{
+		"cert-manager-app":        DependencyIncompatible,
+		"external-dns-app":        DependencyMissing,
+		"prometheus-operator-crd": DependencySatisfied,
+		"common":                  DependencyBundled,
+	}
+
+	checks := CheckDependencies(deps, installed)
+	if len(checks) != len(want) {
+		t.Fatalf("expected %d checks, got %d", len(want), len(checks))
+	}
+	for _, check := range checks {
+		if check.Status != want[check.Dependency.Name] {
+			t.Errorf("%s: status = %s, want %s", check.Dependency.Name, check.Status, want[check.Dependency.Name])
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// AppOperatorFinalizer is added by app-operator, which removes it once the
+// Chart of the app was deleted in the target cluster
+const AppOperatorFinalizer = "operatorkit.giantswarm.io/app-operator-app"
+
+// finalizerExplanations describe why a known finalizer blocks deletion
+var finalizerExplanations = map[string]string{
+	AppOperatorFinalizer: "app-operator removes this finalizer after deleting the app's Chart in the target cluster. " +
+		"It stays when app-operator is not running, or when the workload cluster is unreachable or already gone, " +
+		"so the Chart can never be confirmed as deleted.",
+}
+
+// ExplainFinalizer returns why a finalizer blocks the deletion of an app
+func ExplainFinalizer(finalizer string) string {
+	if explanation, ok := finalizerExplanations[finalizer]; ok {
+		return explanation
+	}
+	return "Unknown finalizer; the controller that added it must remove it. Check that it is still running."
+}
+
+// IsTerminating reports whether the app is being deleted but is held by finalizers
+func (a *App) IsTerminating() bool {
+	return a.DeletionTimestamp != nil && len(a.Finalizers) > 0
+}
+
+// IsStuck reports whether the app has been terminating for at least stuckAfter
+func (a *App) IsStuck(now time.Time, stuckAfter time.Duration) bool {
+	return a.IsTerminating() && now.Sub(*a.DeletionTimestamp) >= stuckAfter
+}
+
+// FindStuck returns the apps that have been terminating for longer than
+// stuckAfter, longest first
+func FindStuck(apps []*App, now time.Time, stuckAfter time.Duration) []*App {
+	stuck := make([]*App, 0)
+	for _, a := range apps {
+		if a.IsStuck(now, stuckAfter) {
+			stuck = append(stuck, a)
+		}
+	}
+	sort.Slice(stuck, func(i, j int) bool { return stuck[i].DeletionTimestamp.Before(*stuck[j].DeletionTimestamp) })
+	return stuck
+}
+
+// RemoveFinalizer removes a finalizer from a terminating app so that its
+// deletion completes. The patch carries the resourceVersion the app was read
+// at, so it fails if the app changed in the meantime.
+func (c *Client) RemoveFinalizer(ctx context.Context, namespace, name, finalizer string) error {
+	current, err := c.Get(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+	if current.DeletionTimestamp == nil {
+		return fmt.Errorf("app %s/%s is not being deleted; finalizers are only removed from terminating apps", namespace, name)
+	}
+	if !slices.Contains(current.Finalizers, finalizer) {
+		return fmt.Errorf("app %s/%s does not have finalizer %s", namespace, name, finalizer)
+	}
+
+	remaining := make([]string, 0, len(current.Finalizers)-1)
+	for _, f := range current.Finalizers {
+		if f != finalizer {
+			remaining = append(remaining, f)
+		}
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"finalizers":      remaining,
+			"resourceVersion": current.ResourceVersion,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build finalizer patch: %w", err)
+	}
+
+	_, err = c.dynamicClient.Apps(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to remove finalizer %s from app %s/%s: %w", finalizer, namespace, name, err)
+	}
+	return nil
+}
+
+// appOperatorSelector selects app-operator deployments
+const appOperatorSelector = "app.kubernetes.io/name=app-operator"
+
+// ReadyAppOperators counts the ready app-operator replicas in namespaces. A
+// count of zero suggests that nobody will remove AppOperatorFinalizer.
+func ReadyAppOperators(ctx context.Context, k8sClient kubernetes.Interface, namespaces ...string) (int, error) {
+	ready := 0
+	for _, ns := range namespaces {
+		deployments, err := k8sClient.AppsV1().Deployments(ns).List(ctx, metav1.ListOptions{LabelSelector: appOperatorSelector})
+		if err != nil {
+			return 0, fmt.Errorf("failed to list app-operator deployments in namespace %s: %w", ns, err)
+		}
+		for _, d := range deployments.Items {
+			ready += int(d.Status.ReadyReplicas)
+		}
+	}
+	return ready, nil
+}
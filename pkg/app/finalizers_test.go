@@ -0,0 +1,31 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFindStuck(t *testing.T) {
+	now := time.Now()
+	at := func(ago time.Duration) *time.Time {
+		ts := now.Add(-ago)
+		return &ts
+	}
+
+	apps := []*App{
+		{Name: "running", Finalizers: []string{AppOperatorFinalizer}},
+		{Name: "recent", DeletionTimestamp: at(time.Minute), Finalizers: []string{AppOperatorFinalizer}},
+		{Name: "old", DeletionTimestamp: at(3 * time.Hour), Finalizers: []string{AppOperatorFinalizer}},
+		{Name: "older", DeletionTimestamp: at(48 * time.Hour), Finalizers: []string{"example.com/other"}},
+		{Name: "released", DeletionTimestamp: at(48 * time.Hour)},
+	}
+
+	stuck := FindStuck(apps, now, 10*time.Minute)
+	if len(stuck) != 2 || stuck[0].Name != "older" || stuck[1].Name != "old" {
+		names := make([]string, 0, len(stuck))
+		for _, a := range stuck {
+			names = append(names, a.Name)
+		}
+		t.Errorf("stuck apps = %v, want [older old]", names)
+	}
+}
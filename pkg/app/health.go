@@ -0,0 +1,139 @@
+package app
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Health annotations are the conventions app owners use to describe how an
+// App is operated: where it is monitored, its runbook and its availability
+// objective in percent (e.g. "99.9")
+const (
+	MonitoringURLAnnotation = "giantswarm.io/monitoring-url"
+	RunbookURLAnnotation    = "giantswarm.io/runbook-url"
+	SLOAnnotation           = "giantswarm.io/slo"
+)
+
+// Health states an App is rolled up into
+const (
+	HealthDeployed = "deployed"
+	HealthFailed   = "failed"
+	HealthPending  = "pending"
+	HealthUnknown  = "unknown"
+)
+
+// HealthInfo is what the health annotations of an App declare
+type HealthInfo struct {
+	MonitoringURL string
+	RunbookURL    string
+	SLO           string
+}
+
+// IsEmpty reports whether no health annotation is set
+func (h HealthInfo) IsEmpty() bool {
+	return h == HealthInfo{}
+}
+
+// HealthInfo returns the health annotations of the App
+func (a *App) HealthInfo() HealthInfo {
+	return HealthInfo{
+		MonitoringURL: a.Annotations[MonitoringURLAnnotation],
+		RunbookURL:    a.Annotations[RunbookURLAnnotation],
+		SLO:           a.Annotations[SLOAnnotation],
+	}
+}
+
+// HealthState classifies the release status of the App. A deployed release
+// of another version than the desired one is pending, as app-operator has not
+// rolled out the change yet.
+func (a *App) HealthState() string {
+	status := a.Status.Release.Status
+	switch {
+	case status == "deployed" && a.Status.Version != "" && a.Status.Version != a.Spec.Version:
+		return HealthPending
+	case status == "deployed":
+		return HealthDeployed
+	case strings.Contains(status, "failed"), status == "not-installed":
+		return HealthFailed
+	case status == "", strings.HasPrefix(status, "pending"), status == "uninstalling":
+		return HealthPending
+	}
+	return HealthUnknown
+}
+
+// ValidateHealthAnnotations checks the health annotations among
+// annotations: URLs must be absolute http(s) URLs and the SLO a percentage
+func ValidateHealthAnnotations(annotations map[string]string) error {
+	for _, key := range []string{MonitoringURLAnnotation, RunbookURLAnnotation} {
+		value, ok := annotations[key]
+		if !ok {
+			continue
+		}
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid %s %q: must be an http(s) URL", key, value)
+		}
+	}
+	if value, ok := annotations[SLOAnnotation]; ok {
+		slo, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		if err != nil || slo <= 0 || slo > 100 {
+			return fmt.Errorf("invalid %s %q: must be a percentage like 99.9", SLOAnnotation, value)
+		}
+	}
+	return nil
+}
+
+// healthRank orders health states from the worst
+var healthRank = map[string]int{HealthFailed: 0, HealthUnknown: 1, HealthPending: 2, HealthDeployed: 3}
+
+// HealthRollup counts Apps per health state and keeps the worst offenders
+type HealthRollup struct {
+	Total  int
+	Counts map[string]int
+	// Offenders are the Apps that are not deployed, failed first, then
+	// unknown and pending, the longest undeployed first
+	Offenders []*App
+}
+
+// NewHealthRollup rolls up the health states of apps
+func NewHealthRollup(apps []*App) *HealthRollup {
+	rollup := &HealthRollup{Total: len(apps), Counts: map[string]int{}}
+	for _, a := range apps {
+		state := a.HealthState()
+		rollup.Counts[state]++
+		if state != HealthDeployed {
+			rollup.Offenders = append(rollup.Offenders, a)
+		}
+	}
+	SortByHealth(rollup.Offenders)
+	return rollup
+}
+
+// DeployedPercent is the share of apps that are deployed
+func (r *HealthRollup) DeployedPercent() float64 {
+	if r.Total == 0 {
+		return 100
+	}
+	return float64(r.Counts[HealthDeployed]) / float64(r.Total) * 100
+}
+
+// SortByHealth orders apps from the worst health state, then by the time
+// they were last deployed and by namespace and name
+func SortByHealth(apps []*App) {
+	sort.SliceStable(apps, func(i, j int) bool {
+		a, b := apps[i], apps[j]
+		if ra, rb := healthRank[a.HealthState()], healthRank[b.HealthState()]; ra != rb {
+			return ra < rb
+		}
+		if a.Status.Release.LastDeployed != b.Status.Release.LastDeployed {
+			return a.Status.Release.LastDeployed < b.Status.Release.LastDeployed
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
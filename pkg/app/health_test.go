@@ -0,0 +1,73 @@
+package app
+
+import "testing"
+
+func newHealthTestApp(name, status, desired, deployed, lastDeployed string) *App {
+	return &App{
+		Name:      name,
+		Namespace: "org-acme",
+		Spec:      AppSpec{Version: desired},
+		Status:    AppStatus{Version: deployed, Release: ReleaseStatus{Status: status, LastDeployed: lastDeployed}},
+	}
+}
+
+func TestHealthState(t *testing.T) {
+	tests := []struct {
+		status, desired, deployed string
+		want                      string
+	}{
+		{"deployed", "1.0.0", "1.0.0", HealthDeployed},
+		{"deployed", "1.1.0", "1.0.0", HealthPending},
+		{"failed", "1.0.0", "1.0.0", HealthFailed},
+		{"not-installed", "1.0.0", "", HealthFailed},
+		{"pending-upgrade", "1.0.0", "1.0.0", HealthPending},
+		{"", "1.0.0", "", HealthPending},
+		{"superseded", "1.0.0", "1.0.0", HealthUnknown},
+	}
+	for _, tt := range tests {
+		if got := newHealthTestApp("a", tt.status, tt.desired, tt.deployed, "").HealthState(); got != tt.want {
+			t.Errorf("HealthState() of %q %s/%s = %s, want %s", tt.status, tt.desired, tt.deployed, got, tt.want)
+		}
+	}
+}
+
+func TestNewHealthRollup(t *testing.T) {
+	rollup := NewHealthRollup([]*App{
+		newHealthTestApp("ok", "deployed", "1.0.0", "1.0.0", "2026-10-01T00:00:00Z"),
+		newHealthTestApp("upgrading", "pending-upgrade", "1.0.0", "1.0.0", "2026-10-02T00:00:00Z"),
+		newHealthTestApp("broken-new", "failed", "1.0.0", "1.0.0", "2026-10-03T00:00:00Z"),
+		newHealthTestApp("broken-old", "failed", "1.0.0", "1.0.0", "2026-09-01T00:00:00Z"),
+	})
+
+	if rollup.Total != 4 || rollup.Counts[HealthFailed] != 2 || rollup.Counts[HealthPending] != 1 || rollup.DeployedPercent() != 25 {
+		t.Errorf("unexpected rollup %+v", rollup)
+	}
+	var names []string
+	for _, a := range rollup.Offenders {
+		names = append(names, a.Name)
+	}
+	if len(names) != 3 || names[0] != "broken-old" || names[1] != "broken-new" || names[2] != "upgrading" {
+		t.Errorf("offenders = %v, want [broken-old broken-new upgrading]", names)
+	}
+}
+
+func TestValidateHealthAnnotations(t *testing.T) {
+	valid := map[string]string{
+		MonitoringURLAnnotation: "https://grafana.example.com/d/ingress",
+		SLOAnnotation:           "99.9%",
+		"unrelated":             "not a url",
+	}
+	if err := ValidateHealthAnnotations(valid); err != nil {
+		t.Errorf("ValidateHealthAnnotations() error = %v", err)
+	}
+
+	for _, invalid := range []map[string]string{
+		{RunbookURLAnnotation: "wiki/ingress"},
+		{SLOAnnotation: "high"},
+		{SLOAnnotation: "120"},
+	} {
+		if err := ValidateHealthAnnotations(invalid); err == nil {
+			t.Errorf("expected an error for %v", invalid)
+		}
+	}
+}
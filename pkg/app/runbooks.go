@@ -0,0 +1,118 @@
+package app
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Runbook is a troubleshooting document for an app
+type Runbook struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// Runbooks maps app names to their troubleshooting documents. Names are
+// matched without a trailing "-app", so "cert-manager" covers the
+// cert-manager-app chart. A nil Runbooks is DefaultRunbooks.
+type Runbooks map[string][]Runbook
+
+// DefaultRunbooks are the troubleshooting guides of well-known apps
+var DefaultRunbooks = Runbooks{
+	"cert-manager": {
+		{Title: "cert-manager troubleshooting", URL: "https://cert-manager.io/docs/troubleshooting/"},
+		{Title: "Troubleshooting ACME certificates", URL: "https://cert-manager.io/docs/troubleshooting/acme/"},
+	},
+	"ingress-nginx": {
+		{Title: "ingress-nginx troubleshooting", URL: "https://kubernetes.github.io/ingress-nginx/troubleshooting/"},
+	},
+	"nginx-ingress-controller": {
+		{Title: "ingress-nginx troubleshooting", URL: "https://kubernetes.github.io/ingress-nginx/troubleshooting/"},
+	},
+	"external-dns": {
+		{Title: "external-dns FAQ", URL: "https://github.com/kubernetes-sigs/external-dns/blob/master/docs/faq.md"},
+	},
+	"coredns": {
+		{Title: "Debugging DNS resolution", URL: "https://kubernetes.io/docs/tasks/administer-cluster/dns-debugging-resolution/"},
+	},
+	"cilium": {
+		{Title: "Cilium troubleshooting", URL: "https://docs.cilium.io/en/stable/operations/troubleshooting/"},
+	},
+	"cluster-autoscaler": {
+		{Title: "cluster-autoscaler FAQ", URL: "https://github.com/kubernetes/autoscaler/blob/master/cluster-autoscaler/FAQ.md"},
+	},
+	"kyverno": {
+		{Title: "Kyverno troubleshooting", URL: "https://kyverno.io/docs/troubleshooting/"},
+	},
+	"velero": {
+		{Title: "Velero troubleshooting", URL: "https://velero.io/docs/main/troubleshooting/"},
+	},
+	"loki": {
+		{Title: "Loki troubleshooting", URL: "https://grafana.com/docs/loki/latest/operations/troubleshooting/"},
+	},
+}
+
+// LoadRunbooks reads a runbooks file and merges it over DefaultRunbooks, e.g.
+//
+//	cert-manager:
+//	  - title: Platform team certificate runbook
+//	    url: https://wiki.example.com/cert-manager
+//	kyverno: []
+//
+// An app listed in the file replaces its default runbooks; an empty list
+// removes them.
+func LoadRunbooks(path string) (Runbooks, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read runbooks: %w", err)
+	}
+
+	var configured Runbooks
+	if err := yaml.UnmarshalStrict(data, &configured); err != nil {
+		return nil, fmt.Errorf("failed to parse runbooks %s: %w", path, err)
+	}
+
+	runbooks := make(Runbooks, len(DefaultRunbooks)+len(configured))
+	for name, links := range DefaultRunbooks {
+		runbooks[name] = links
+	}
+	for name, links := range configured {
+		for _, link := range links {
+			u, err := url.Parse(link.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return nil, fmt.Errorf("invalid runbook URL %q of app %s in %s: must be an http(s) URL", link.URL, name, path)
+			}
+		}
+		runbooks[runbookKey(name)] = links
+	}
+	return runbooks, nil
+}
+
+// For returns the runbooks of an app's chart or, failing that, of its name
+func (r Runbooks) For(a *App) []Runbook {
+	for _, name := range []string{a.Spec.Name, a.Name} {
+		if links := r.Lookup(name); len(links) > 0 {
+			return links
+		}
+	}
+	return nil
+}
+
+// Lookup returns the runbooks configured for an app name
+func (r Runbooks) Lookup(name string) []Runbook {
+	if r == nil {
+		r = DefaultRunbooks
+	}
+	if name == "" {
+		return nil
+	}
+	return r[runbookKey(name)]
+}
+
+// runbookKey normalizes an app name for matching
+func runbookKey(name string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), "-app")
+}
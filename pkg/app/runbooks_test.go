@@ -0,0 +1,81 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunbooksFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		app      *App
+		runbooks Runbooks
+		want     string
+	}{
+		{name: "chart name with -app suffix", app: &App{Name: "prod-certs", Spec: AppSpec{Name: "cert-manager-app"}}, want: "https://cert-manager.io/docs/troubleshooting/"},
+		{name: "app name when chart is unknown", app: &App{Name: "Kyverno", Spec: AppSpec{Name: "kyverno-policies-custom"}}, want: "https://kyverno.io/docs/troubleshooting/"},
+		{name: "unknown app", app: &App{Name: "hello-world", Spec: AppSpec{Name: "hello-world"}}},
+		{name: "configured runbooks", app: &App{Name: "hello-world"}, runbooks: Runbooks{"hello-world": {{Title: "Hello", URL: "https://wiki.example.com/hello"}}}, want: "https://wiki.example.com/hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.runbooks.For(tt.app)
+			if tt.want == "" {
+				if len(got) != 0 {
+					t.Errorf("For() = %v, want none", got)
+				}
+				return
+			}
+			if len(got) == 0 || got[0].URL != tt.want {
+				t.Errorf("For() = %v, want first %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadRunbooks(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "runbooks.yaml")
+	content := `Cert-Manager-App:
+  - title: Platform certificate runbook
+    url: https://wiki.example.com/cert-manager
+kyverno: []
+hello-world:
+  - title: Hello runbook
+    url: https://wiki.example.com/hello
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	runbooks, err := LoadRunbooks(path)
+	if err != nil {
+		t.Fatalf("LoadRunbooks() error = %v", err)
+	}
+	if got := runbooks.Lookup("cert-manager"); len(got) != 1 || got[0].URL != "https://wiki.example.com/cert-manager" {
+		t.Errorf("cert-manager runbooks = %v, want the configured one only", got)
+	}
+	if got := runbooks.Lookup("kyverno"); len(got) != 0 {
+		t.Errorf("kyverno runbooks = %v, want none", got)
+	}
+	if got := runbooks.Lookup("hello-world-app"); len(got) != 1 {
+		t.Errorf("hello-world runbooks = %v", got)
+	}
+	if got := runbooks.Lookup("cilium"); len(got) == 0 {
+		t.Error("defaults of unlisted apps were dropped")
+	}
+	if got := DefaultRunbooks.Lookup("cert-manager"); len(got) != 2 {
+		t.Error("loading runbooks changed the defaults")
+	}
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte("hello:\n  - title: Hello\n    url: wiki/hello\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadRunbooks(invalid); err == nil || !strings.Contains(err.Error(), "http(s) URL") {
+		t.Errorf("LoadRunbooks() error = %v, want an invalid URL error", err)
+	}
+}
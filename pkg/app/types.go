@@ -1,31 +1,61 @@
 package app
 
 import (
+	"time"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
+// PausedAnnotation makes app-operator skip reconciliation of an App while set to "true"
+const PausedAnnotation = "app-operator.giantswarm.io/paused"
+
+// DeletionProtectionAnnotation makes app_delete refuse to delete an App while
+// set to "true"; DeletionProtectionReasonAnnotation says why it is protected
+const (
+	DeletionProtectionAnnotation       = "mcp.giantswarm.io/deletion-protection"
+	DeletionProtectionReasonAnnotation = "mcp.giantswarm.io/deletion-protection-reason"
+)
+
+// Annotations recorded on Apps created through this server
+const (
+	CreatedByAnnotation = "mcp.giantswarm.io/created-by"
+	CreatedAtAnnotation = "mcp.giantswarm.io/created-at"
+	TicketAnnotation    = "mcp.giantswarm.io/ticket"
+)
+
 // App represents a Giant Swarm App resource
 type App struct {
-	Name      string
-	Namespace string
-	Spec      AppSpec
-	Status    AppStatus
+	Name            string
+	Namespace       string
+	Labels          map[string]string
+	Annotations     map[string]string
+	Finalizers      []string
+	ResourceVersion string
+	// DeletionTimestamp is set once the app is being deleted
+	DeletionTimestamp *time.Time
+	Spec              AppSpec
+	Status            AppStatus
 }
 
 // AppSpec represents the spec of an App
 type AppSpec struct {
-	Catalog    string
-	Name       string
-	Namespace  string
-	Version    string
-	KubeConfig KubeConfig
-	Config     *AppConfig
-	UserConfig *AppConfig
+	Catalog string
+	// CatalogNamespace is the namespace of the catalog; when empty
+	// app-operator looks the catalog up by name
+	CatalogNamespace string
+	Name             string
+	Namespace        string
+	Version          string
+	KubeConfig       KubeConfig
+	Config           *AppConfig
+	UserConfig       *AppConfig
+	ExtraConfigs     []ExtraConfig
 }
 
 // KubeConfig represents the kubeconfig for the app
 type KubeConfig struct {
 	InCluster bool
+	Context   string
 	Secret    *SecretReference
 }
 
@@ -47,6 +77,30 @@ type ConfigMapReference struct {
 	Namespace string
 }
 
+// Kinds of extra configs
+const (
+	ExtraConfigKindConfigMap = "configMap"
+	ExtraConfigKindSecret    = "secret"
+)
+
+// Extra configs are merged in order of priority with the cluster config and
+// the user config, which have fixed priorities. DefaultExtraConfigPriority is
+// the priority app-operator assigns to extra configs without one.
+const (
+	DefaultExtraConfigPriority = 25
+	ConfigPriority             = 50
+	UserConfigPriority         = 100
+)
+
+// ExtraConfig references an additional ConfigMap or Secret merged into the
+// values of an app
+type ExtraConfig struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Priority  int
+}
+
 // AppStatus represents the status of an App
 type AppStatus struct {
 	AppVersion string
@@ -63,8 +117,16 @@ type ReleaseStatus struct {
 // NewAppFromUnstructured converts an unstructured object to an App
 func NewAppFromUnstructured(obj *unstructured.Unstructured) (*App, error) {
 	app := &App{
-		Name:      obj.GetName(),
-		Namespace: obj.GetNamespace(),
+		Name:            obj.GetName(),
+		Namespace:       obj.GetNamespace(),
+		Labels:          obj.GetLabels(),
+		Annotations:     obj.GetAnnotations(),
+		Finalizers:      obj.GetFinalizers(),
+		ResourceVersion: obj.GetResourceVersion(),
+	}
+	if ts := obj.GetDeletionTimestamp(); ts != nil {
+		deleted := ts.Time
+		app.DeletionTimestamp = &deleted
 	}
 
 	// Extract spec
@@ -78,6 +140,10 @@ func NewAppFromUnstructured(obj *unstructured.Unstructured) (*App, error) {
 		app.Spec.Catalog = catalog
 	}
 
+	if catalogNamespace, ok := spec["catalogNamespace"].(string); ok {
+		app.Spec.CatalogNamespace = catalogNamespace
+	}
+
 	// Name
 	if name, ok := spec["name"].(string); ok {
 		app.Spec.Name = name
@@ -98,6 +164,20 @@ func NewAppFromUnstructured(obj *unstructured.Unstructured) (*App, error) {
 		if inCluster, ok := kubeConfig["inCluster"].(bool); ok {
 			app.Spec.KubeConfig.InCluster = inCluster
 		}
+		if context, ok := kubeConfig["context"].(map[string]interface{}); ok {
+			if name, ok := context["name"].(string); ok {
+				app.Spec.KubeConfig.Context = name
+			}
+		}
+		if secret, ok := kubeConfig["secret"].(map[string]interface{}); ok {
+			app.Spec.KubeConfig.Secret = &SecretReference{}
+			if name, ok := secret["name"].(string); ok {
+				app.Spec.KubeConfig.Secret.Name = name
+			}
+			if namespace, ok := secret["namespace"].(string); ok {
+				app.Spec.KubeConfig.Secret.Namespace = namespace
+			}
+		}
 	}
 
 	// Config
@@ -110,6 +190,15 @@ func NewAppFromUnstructured(obj *unstructured.Unstructured) (*App, error) {
 		app.Spec.UserConfig = parseAppConfig(userConfig)
 	}
 
+	// ExtraConfigs
+	if extraConfigs, ok := spec["extraConfigs"].([]interface{}); ok {
+		for _, item := range extraConfigs {
+			if extraConfig, ok := item.(map[string]interface{}); ok {
+				app.Spec.ExtraConfigs = append(app.Spec.ExtraConfigs, parseExtraConfig(extraConfig))
+			}
+		}
+	}
+
 	// Extract status
 	status, found, err := unstructured.NestedMap(obj.Object, "status")
 	if err == nil && found {
@@ -164,8 +253,137 @@ func parseAppConfig(config map[string]interface{}) *AppConfig {
 	return ac
 }
 
-// ToUnstructured converts an App to an unstructured object
+// parseExtraConfig parses an extra config from unstructured data
+func parseExtraConfig(extraConfig map[string]interface{}) ExtraConfig {
+	ec := ExtraConfig{
+		Kind:     ExtraConfigKindConfigMap,
+		Priority: DefaultExtraConfigPriority,
+	}
+
+	if kind, ok := extraConfig["kind"].(string); ok && kind != "" {
+		ec.Kind = kind
+	}
+	if name, ok := extraConfig["name"].(string); ok {
+		ec.Name = name
+	}
+	if namespace, ok := extraConfig["namespace"].(string); ok {
+		ec.Namespace = namespace
+	}
+	switch priority := extraConfig["priority"].(type) {
+	case int64:
+		ec.Priority = int(priority)
+	case float64:
+		ec.Priority = int(priority)
+	}
+
+	return ec
+}
+
+// HasExtraConfig reports whether the app references the ConfigMap or Secret
+// as an extra config
+func (a *App) HasExtraConfig(kind, namespace, name string) bool {
+	for _, ec := range a.Spec.ExtraConfigs {
+		if ec.Kind == kind && ec.Namespace == namespace && ec.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveExtraConfig removes the references to the ConfigMap or Secret from
+// the extra configs of the app and reports whether there were any
+func (a *App) RemoveExtraConfig(kind, namespace, name string) bool {
+	kept := make([]ExtraConfig, 0, len(a.Spec.ExtraConfigs))
+	for _, ec := range a.Spec.ExtraConfigs {
+		if ec.Kind != kind || ec.Namespace != namespace || ec.Name != name {
+			kept = append(kept, ec)
+		}
+	}
+	removed := len(kept) != len(a.Spec.ExtraConfigs)
+	a.Spec.ExtraConfigs = kept
+	return removed
+}
+
+// IsPaused reports whether reconciliation of the app is paused
+func (a *App) IsPaused() bool {
+	return a.Annotations[PausedAnnotation] == "true"
+}
+
+// IsDeletionProtected reports whether the app is protected from deletion
+func (a *App) IsDeletionProtected() bool {
+	return a.Annotations[DeletionProtectionAnnotation] == "true"
+}
+
+// MarkCreated records who created the app through this server and when, and
+// optionally the ticket or pull request the change belongs to
+func (a *App) MarkCreated(creator, ticket string, at time.Time) {
+	if a.Annotations == nil {
+		a.Annotations = make(map[string]string)
+	}
+	a.Annotations[CreatedByAnnotation] = creator
+	a.Annotations[CreatedAtAnnotation] = at.UTC().Format(time.RFC3339)
+	if ticket != "" {
+		a.Annotations[TicketAnnotation] = ticket
+	}
+}
+
+// IsMCPManaged reports whether the app was created through this server
+func (a *App) IsMCPManaged() bool {
+	_, ok := a.Annotations[CreatedByAnnotation]
+	return ok
+}
+
+// ToUnstructured converts an App to an unstructured object, including
+// the metadata that is owned by other controllers
 func (a *App) ToUnstructured() *unstructured.Unstructured {
+	obj := a.ToApplyConfiguration()
+
+	if len(a.Labels) > 0 {
+		obj.SetLabels(a.Labels)
+	}
+	if len(a.Annotations) > 0 {
+		obj.SetAnnotations(a.Annotations)
+	}
+	if len(a.Finalizers) > 0 {
+		obj.SetFinalizers(a.Finalizers)
+	}
+	if a.ResourceVersion != "" {
+		obj.SetResourceVersion(a.ResourceVersion)
+	}
+
+	return obj
+}
+
+// Manifest returns the App as a plain manifest for review or version control:
+// its identity, labels and spec, without server-populated fields
+func (a *App) Manifest() map[string]interface{} {
+	obj := a.ToApplyConfiguration()
+	if len(a.Labels) > 0 {
+		obj.SetLabels(a.Labels)
+	}
+	return obj.Object
+}
+
+// ToApplyConfiguration returns the fields of an App that this server manages.
+// It only contains the identity and the spec, so applying it with server-side
+// apply leaves labels, annotations, finalizers and any spec fields not known
+// to this type untouched.
+func (a *App) ToApplyConfiguration() *unstructured.Unstructured {
+	kubeConfig := map[string]interface{}{
+		"inCluster": a.Spec.KubeConfig.InCluster,
+	}
+	if a.Spec.KubeConfig.Context != "" {
+		kubeConfig["context"] = map[string]interface{}{
+			"name": a.Spec.KubeConfig.Context,
+		}
+	}
+	if a.Spec.KubeConfig.Secret != nil {
+		kubeConfig["secret"] = map[string]interface{}{
+			"name":      a.Spec.KubeConfig.Secret.Name,
+			"namespace": a.Spec.KubeConfig.Secret.Namespace,
+		}
+	}
+
 	obj := &unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"apiVersion": "application.giantswarm.io/v1alpha1",
@@ -175,17 +393,19 @@ func (a *App) ToUnstructured() *unstructured.Unstructured {
 				"namespace": a.Namespace,
 			},
 			"spec": map[string]interface{}{
-				"catalog":   a.Spec.Catalog,
-				"name":      a.Spec.Name,
-				"namespace": a.Spec.Namespace,
-				"version":   a.Spec.Version,
-				"kubeConfig": map[string]interface{}{
-					"inCluster": a.Spec.KubeConfig.InCluster,
-				},
+				"catalog":    a.Spec.Catalog,
+				"name":       a.Spec.Name,
+				"namespace":  a.Spec.Namespace,
+				"version":    a.Spec.Version,
+				"kubeConfig": kubeConfig,
 			},
 		},
 	}
 
+	if a.Spec.CatalogNamespace != "" {
+		obj.Object["spec"].(map[string]interface{})["catalogNamespace"] = a.Spec.CatalogNamespace
+	}
+
 	// Add config if present
 	if a.Spec.Config != nil {
 		spec := obj.Object["spec"].(map[string]interface{})
@@ -230,5 +450,19 @@ func (a *App) ToUnstructured() *unstructured.Unstructured {
 		spec["userConfig"] = userConfig
 	}
 
+	// Add extraConfigs if present; an empty list removes them with apply
+	if a.Spec.ExtraConfigs != nil {
+		extraConfigs := make([]interface{}, 0, len(a.Spec.ExtraConfigs))
+		for _, ec := range a.Spec.ExtraConfigs {
+			extraConfigs = append(extraConfigs, map[string]interface{}{
+				"kind":      ec.Kind,
+				"name":      ec.Name,
+				"namespace": ec.Namespace,
+				"priority":  int64(ec.Priority),
+			})
+		}
+		obj.Object["spec"].(map[string]interface{})["extraConfigs"] = extraConfigs
+	}
+
 	return obj
 }
@@ -0,0 +1,146 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestAppRoundTrip(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "application.giantswarm.io/v1alpha1",
+		"kind":       "App",
+		"metadata": map[string]interface{}{
+			"name":            "hello-world",
+			"namespace":       "org-acme",
+			"resourceVersion": "42",
+			"labels":          map[string]interface{}{"app-operator.giantswarm.io/version": "0.0.0"},
+			"annotations":     map[string]interface{}{"owner": "team-a"},
+			"finalizers":      []interface{}{"operatorkit.giantswarm.io/app-operator-app"},
+		},
+		"spec": map[string]interface{}{
+			"catalog":   "giantswarm",
+			"name":      "hello-world",
+			"namespace": "hello",
+			"version":   "1.0.0",
+			"kubeConfig": map[string]interface{}{
+				"inCluster": false,
+				"context":   map[string]interface{}{"name": "prod-admin@prod"},
+				"secret":    map[string]interface{}{"name": "prod-kubeconfig", "namespace": "org-acme"},
+			},
+			"extraConfigs": []interface{}{
+				map[string]interface{}{"kind": "configMap", "name": "hello-world-org-defaults", "namespace": "org-acme"},
+				map[string]interface{}{"kind": "secret", "name": "hello-world-overrides", "namespace": "org-acme", "priority": int64(150)},
+			},
+		},
+	}}
+
+	a, err := NewAppFromUnstructured(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := a.ToUnstructured()
+	if !reflect.DeepEqual(out.GetLabels(), obj.GetLabels()) {
+		t.Errorf("labels = %v", out.GetLabels())
+	}
+	if !reflect.DeepEqual(out.GetAnnotations(), obj.GetAnnotations()) {
+		t.Errorf("annotations = %v", out.GetAnnotations())
+	}
+	if !reflect.DeepEqual(out.GetFinalizers(), obj.GetFinalizers()) {
+		t.Errorf("finalizers = %v", out.GetFinalizers())
+	}
+	if out.GetResourceVersion() != "42" {
+		t.Errorf("resourceVersion = %q", out.GetResourceVersion())
+	}
+	secretName, _, _ := unstructured.NestedString(out.Object, "spec", "kubeConfig", "secret", "name")
+	if secretName != "prod-kubeconfig" {
+		t.Errorf("kubeConfig secret = %q", secretName)
+	}
+	wantExtraConfigs := []ExtraConfig{
+		{Kind: ExtraConfigKindConfigMap, Name: "hello-world-org-defaults", Namespace: "org-acme", Priority: DefaultExtraConfigPriority},
+		{Kind: ExtraConfigKindSecret, Name: "hello-world-overrides", Namespace: "org-acme", Priority: 150},
+	}
+	if !reflect.DeepEqual(a.Spec.ExtraConfigs, wantExtraConfigs) {
+		t.Errorf("extraConfigs = %+v", a.Spec.ExtraConfigs)
+	}
+	if extraConfigs, _, _ := unstructured.NestedSlice(out.Object, "spec", "extraConfigs"); len(extraConfigs) != 2 {
+		t.Errorf("extraConfigs not written back: %v", extraConfigs)
+	}
+
+	// The apply configuration must not claim metadata owned by other managers
+	applyConfig := a.ToApplyConfiguration()
+	if applyConfig.GetLabels() != nil || applyConfig.GetAnnotations() != nil || applyConfig.GetFinalizers() != nil {
+		t.Error("apply configuration should only contain name, namespace and spec")
+	}
+	if applyConfig.GetResourceVersion() != "" {
+		t.Error("apply configuration should not set a resourceVersion")
+	}
+}
+
+func TestMarkCreated(t *testing.T) {
+	a := &App{Name: "hello-world", Namespace: "org-acme"}
+	if a.IsMCPManaged() {
+		t.Fatal("new app reported as managed")
+	}
+
+	a.MarkCreated("jane@example.com", "OPS-123", time.Date(2024, 5, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*3600)))
+
+	want := map[string]string{
+		CreatedByAnnotation: "jane@example.com",
+		CreatedAtAnnotation: "2024-05-01T10:00:00Z",
+		TicketAnnotation:    "OPS-123",
+	}
+	if !reflect.DeepEqual(a.Annotations, want) {
+		t.Errorf("annotations = %v, want %v", a.Annotations, want)
+	}
+	if !a.IsMCPManaged() {
+		t.Error("annotated app not reported as managed")
+	}
+
+	apps := FilterByManaged([]*App{a, {Name: "other"}}, true)
+	if len(apps) != 1 || apps[0] != a {
+		t.Errorf("FilterByManaged = %v, want only the annotated app", apps)
+	}
+}
+
+func TestFilterByLabels(t *testing.T) {
+	apps := []*App{
+		{Name: "ingress", Labels: map[string]string{"team": "platform"}},
+		{Name: "dns", Labels: map[string]string{"team": "network"}},
+	}
+
+	filtered, err := FilterByLabels(apps, "team=platform")
+	if err != nil || len(filtered) != 1 || filtered[0].Name != "ingress" {
+		t.Errorf("FilterByLabels(team=platform) = %v, %v; want ingress", filtered, err)
+	}
+	if _, err := FilterByLabels(apps, "team in (platform"); err == nil {
+		t.Error("invalid selector was accepted")
+	}
+}
+
+func TestFilterByCatalogRef(t *testing.T) {
+	apps := []*App{
+		{Name: "by-name", Spec: AppSpec{Catalog: "acme"}},
+		{Name: "same-namespace", Spec: AppSpec{Catalog: "acme", CatalogNamespace: "org-acme"}},
+		{Name: "other-namespace", Spec: AppSpec{Catalog: "acme", CatalogNamespace: "org-other"}},
+		{Name: "other-catalog", Spec: AppSpec{Catalog: "giantswarm"}},
+	}
+
+	filtered := FilterByCatalogRef(apps, "org-acme", "acme")
+	if len(filtered) != 2 || filtered[0].Name != "by-name" || filtered[1].Name != "same-namespace" {
+		t.Errorf("FilterByCatalogRef = %v, want by-name and same-namespace", filtered)
+	}
+}
+
+func TestIsDeletionProtected(t *testing.T) {
+	a := &App{Annotations: map[string]string{DeletionProtectionAnnotation: "true"}}
+	if !a.IsDeletionProtected() {
+		t.Error("annotated app is not protected")
+	}
+	if (&App{Annotations: map[string]string{DeletionProtectionAnnotation: "false"}}).IsDeletionProtected() {
+		t.Error("app with protection false is protected")
+	}
+}
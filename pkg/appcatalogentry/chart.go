@@ -0,0 +1,140 @@
+package appcatalogentry
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	// maxChartSize limits the size of a downloaded chart package
+	maxChartSize = 20 << 20
+
+	// maxChartFileSize limits the size of a single file extracted from a chart package
+	maxChartFileSize = 5 << 20
+)
+
+// ChartFiles holds the files of a chart package keyed by their path relative to the chart root
+type ChartFiles map[string][]byte
+
+// chartHTTPClient is used to download chart packages
+var chartHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// FetchChart downloads the chart package of an entry and returns its files
+func FetchChart(ctx context.Context, entry *AppCatalogEntry) (ChartFiles, error) {
+	if len(entry.Spec.Chart.URLs) == 0 {
+		return nil, fmt.Errorf("app catalog entry %s has no chart URLs", entry.Name)
+	}
+
+	var lastErr error
+	for _, url := range entry.Spec.Chart.URLs {
+		files, err := fetchChartFromURL(ctx, url)
+		if err == nil {
+			return files, nil
+		}
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+// fetchChartFromURL downloads and extracts a single chart package
+func fetchChartFromURL(ctx context.Context, url string) (ChartFiles, error) {
+	if strings.HasPrefix(url, "oci://") {
+		return nil, fmt.Errorf("fetching OCI charts is not supported: %s", url)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid chart URL %s: %w", url, err)
+	}
+
+	resp, err := chartHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download chart %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download chart %s: %s", url, resp.Status)
+	}
+
+	files, err := ExtractChart(io.LimitReader(resp.Body, maxChartSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract chart %s: %w", url, err)
+	}
+
+	return files, nil
+}
+
+// ExtractChart reads a gzipped chart archive. The leading chart directory is
+// stripped from all paths, so "nginx/README.md" becomes "README.md".
+func ExtractChart(r io.Reader) (ChartFiles, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	files := make(ChartFiles)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// Skip entries escaping the archive root
+		name := path.Clean(strings.TrimPrefix(header.Name, "/"))
+		if name == ".." || strings.HasPrefix(name, "../") {
+			continue
+		}
+		idx := strings.Index(name, "/")
+		if idx < 0 {
+			continue
+		}
+		name = name[idx+1:]
+
+		data, err := io.ReadAll(io.LimitReader(tr, maxChartFileSize))
+		if err != nil {
+			return nil, err
+		}
+		files[name] = data
+	}
+
+	return files, nil
+}
+
+// Get returns a file by its path relative to the chart root, matching the file name case-insensitively
+func (f ChartFiles) Get(name string) ([]byte, bool) {
+	if data, ok := f[name]; ok {
+		return data, true
+	}
+	for fileName, data := range f {
+		if strings.EqualFold(fileName, name) {
+			return data, true
+		}
+	}
+	return nil, false
+}
+
+// Readme returns the README.md of the chart
+func (f ChartFiles) Readme() (string, error) {
+	data, ok := f.Get("README.md")
+	if !ok {
+		return "", fmt.Errorf("chart has no README.md")
+	}
+	return string(data), nil
+}
@@ -0,0 +1,103 @@
+package appcatalogentry
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func buildChartArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestExtractChart(t *testing.T) {
+	archive := buildChartArchive(t, map[string]string{
+		"nginx/Chart.yaml":         "name: nginx\n",
+		"nginx/readme.md":          "# nginx\n",
+		"nginx/templates/svc.yaml": "kind: Service\n",
+		"nginx/../../etc/passwd":   "root\n",
+	})
+
+	files, err := ExtractChart(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("ExtractChart() error = %v", err)
+	}
+
+	if _, ok := files["Chart.yaml"]; !ok {
+		t.Error("expected Chart.yaml at the chart root")
+	}
+	if _, ok := files["templates/svc.yaml"]; !ok {
+		t.Error("expected templates/svc.yaml to keep its directory")
+	}
+	if len(files) != 3 {
+		t.Errorf("expected 3 files, got %d", len(files))
+	}
+
+	readme, err := files.Readme()
+	if err != nil {
+		t.Fatalf("Readme() error = %v", err)
+	}
+	if readme != "# nginx\n" {
+		t.Errorf("Readme() = %q", readme)
+	}
+}
+
+func TestFetchChart(t *testing.T) {
+	archive := buildChartArchive(t, map[string]string{
+		"hello/README.md": "hello world",
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/hello-1.0.0.tgz" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(archive)
+	}))
+	defer srv.Close()
+
+	entry := &AppCatalogEntry{}
+	entry.Name = "default-hello-1.0.0"
+	entry.Spec.Chart.URLs = []string{srv.URL + "/missing.tgz", srv.URL + "/hello-1.0.0.tgz"}
+
+	files, err := FetchChart(context.Background(), entry)
+	if err != nil {
+		t.Fatalf("FetchChart() error = %v", err)
+	}
+	if readme, _ := files.Readme(); readme != "hello world" {
+		t.Errorf("Readme() = %q", readme)
+	}
+
+	entry.Spec.Chart.URLs = nil
+	if _, err := FetchChart(context.Background(), entry); err == nil {
+		t.Error("expected an error for an entry without chart URLs")
+	}
+}
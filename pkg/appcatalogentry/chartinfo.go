@@ -0,0 +1,88 @@
+package appcatalogentry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Chart annotations describing the license and maintainers of a chart, as
+// used by Artifact Hub. The maintainers annotation is a YAML list like the
+// maintainers field of Chart.yaml.
+const (
+	LicenseAnnotation     = "artifacthub.io/license"
+	MaintainersAnnotation = "artifacthub.io/maintainers"
+
+	// licensesAnnotation is the older, unprefixed license annotation
+	licensesAnnotation = "licenses"
+)
+
+// Maintainer is a maintainer of a chart
+type Maintainer struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+	URL   string `json:"url,omitempty"`
+}
+
+// ChartInfo describes where a chart comes from and under which license, to
+// assess an app before deploying it
+type ChartInfo struct {
+	// UpstreamChartURL and UpstreamChartVersion are set by Giant Swarm charts
+	// that wrap a community chart
+	UpstreamChartURL     string       `json:"upstreamChartURL,omitempty"`
+	UpstreamChartVersion string       `json:"upstreamChartVersion,omitempty"`
+	License              string       `json:"license,omitempty"`
+	Maintainers          []Maintainer `json:"maintainers,omitempty"`
+}
+
+// chartInfoMetadata is the subset of Chart.yaml holding the chart info
+type chartInfoMetadata struct {
+	UpstreamChartURL     string            `json:"upstreamChartURL"`
+	UpstreamChartVersion string            `json:"upstreamChartVersion"`
+	Maintainers          []Maintainer      `json:"maintainers"`
+	Annotations          map[string]string `json:"annotations"`
+}
+
+// ParseChartInfo reads the upstream, license and maintainer information of a
+// Chart.yaml. Maintainers listed in Chart.yaml take precedence over the
+// maintainers annotation.
+func ParseChartInfo(data []byte) (*ChartInfo, error) {
+	var metadata chartInfoMetadata
+	if err := yaml.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("invalid Chart.yaml: %w", err)
+	}
+
+	info := &ChartInfo{
+		UpstreamChartURL:     metadata.UpstreamChartURL,
+		UpstreamChartVersion: metadata.UpstreamChartVersion,
+		License:              strings.TrimSpace(metadata.Annotations[LicenseAnnotation]),
+		Maintainers:          metadata.Maintainers,
+	}
+	if info.License == "" {
+		info.License = strings.TrimSpace(metadata.Annotations[licensesAnnotation])
+	}
+	if len(info.Maintainers) == 0 && metadata.Annotations[MaintainersAnnotation] != "" {
+		if err := yaml.Unmarshal([]byte(metadata.Annotations[MaintainersAnnotation]), &info.Maintainers); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %w", MaintainersAnnotation, err)
+		}
+	}
+
+	return info, nil
+}
+
+// GetChartInfo downloads the chart package of an entry and returns the
+// upstream, license and maintainer information of its Chart.yaml
+func (c *Client) GetChartInfo(ctx context.Context, entry *AppCatalogEntry) (*ChartInfo, error) {
+	files, err := FetchChart(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := files.Get("Chart.yaml")
+	if !ok {
+		return nil, fmt.Errorf("chart has no Chart.yaml")
+	}
+	return ParseChartInfo(data)
+}
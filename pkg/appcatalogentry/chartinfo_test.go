@@ -0,0 +1,50 @@
+package appcatalogentry
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseChartInfo(t *testing.T) {
+	chart := `apiVersion: v2
+name: kyverno
+version: 0.17.0
+upstreamChartURL: https://github.com/kyverno/kyverno/tree/main/charts/kyverno
+upstreamChartVersion: 3.1.4
+maintainers:
+  - name: team-shield
+    email: shield@example.com
+annotations:
+  artifacthub.io/license: Apache-2.0
+  artifacthub.io/maintainers: |
+    - name: ignored
+`
+	info, err := ParseChartInfo([]byte(chart))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &ChartInfo{
+		UpstreamChartURL:     "https://github.com/kyverno/kyverno/tree/main/charts/kyverno",
+		UpstreamChartVersion: "3.1.4",
+		License:              "Apache-2.0",
+		Maintainers:          []Maintainer{{Name: "team-shield", Email: "shield@example.com"}},
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("got %+v, want %+v", info, want)
+	}
+
+	// Community charts without maintainers in Chart.yaml
+	chart = `name: redis
+annotations:
+  licenses: BSD-3-Clause
+  artifacthub.io/maintainers: |
+    - name: Redis Maintainers
+      url: https://redis.io
+`
+	if info, err = ParseChartInfo([]byte(chart)); err != nil {
+		t.Fatal(err)
+	}
+	if info.License != "BSD-3-Clause" || len(info.Maintainers) != 1 || info.Maintainers[0].URL != "https://redis.io" {
+		t.Errorf("got %+v", info)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
@@ -15,6 +16,7 @@ import (
 // Client provides operations for AppCatalogEntry resources
 type Client struct {
 	dynamicClient *k8s.DynamicClient
+	index         *Index
 }
 
 // NewClient creates a new AppCatalogEntry client
@@ -24,8 +26,34 @@ func NewClient(dynamicClient *k8s.DynamicClient) *Client {
 	}
 }
 
+// WithIndex makes the client serve lists and lookups from index once it is
+// synced, falling back to the API server until then
+func (c *Client) WithIndex(index *Index) *Client {
+	c.index = index
+	return c
+}
+
+// indexed reports whether lookups can be served from the index
+func (c *Client) indexed() bool {
+	return c.index.Synced()
+}
+
 // List lists AppCatalogEntries in a namespace or across all namespaces
 func (c *Client) List(ctx context.Context, namespace string) ([]*AppCatalogEntry, error) {
+	if c.indexed() {
+		entries := c.index.All()
+		if namespace == "" {
+			return entries, nil
+		}
+		filtered := make([]*AppCatalogEntry, 0)
+		for _, entry := range entries {
+			if entry.Namespace == namespace {
+				filtered = append(filtered, entry)
+			}
+		}
+		return filtered, nil
+	}
+
 	listOptions := metav1.ListOptions{}
 
 	var list *unstructured.UnstructuredList
@@ -57,10 +85,16 @@ func (c *Client) List(ctx context.Context, namespace string) ([]*AppCatalogEntry
 
 // ListByCatalog lists AppCatalogEntries for a specific catalog
 func (c *Client) ListByCatalog(ctx context.Context, catalogName, catalogNamespace string) ([]*AppCatalogEntry, error) {
-	// List all entries and filter by catalog
-	entries, err := c.List(ctx, "")
-	if err != nil {
-		return nil, err
+	var entries []*AppCatalogEntry
+	if c.indexed() {
+		entries = c.index.ByCatalog(catalogName)
+	} else {
+		// List all entries and filter by catalog
+		var err error
+		entries, err = c.List(ctx, "")
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	filtered := make([]*AppCatalogEntry, 0)
@@ -74,6 +108,24 @@ func (c *Client) ListByCatalog(ctx context.Context, catalogName, catalogNamespac
 	return filtered, nil
 }
 
+// CountByCatalog returns the number of apps and app versions of a catalog,
+// from the index counts when it is synced and the catalog namespace is known
+func (c *Client) CountByCatalog(ctx context.Context, catalogName, catalogNamespace string) (CatalogCount, error) {
+	if c.indexed() && catalogNamespace != "" {
+		return c.index.CountByCatalog(catalogNamespace, catalogName), nil
+	}
+
+	entries, err := c.ListByCatalog(ctx, catalogName, catalogNamespace)
+	if err != nil {
+		return CatalogCount{}, err
+	}
+
+	return CatalogCount{
+		Apps:    len(GroupByApp(entries)),
+		Entries: len(entries),
+	}, nil
+}
+
 // Get retrieves a specific AppCatalogEntry
 func (c *Client) Get(ctx context.Context, namespace, name string) (*AppCatalogEntry, error) {
 	obj, err := c.dynamicClient.AppCatalogEntries(namespace).Get(ctx, name, metav1.GetOptions{})
@@ -84,62 +136,75 @@ func (c *Client) Get(ctx context.Context, namespace, name string) (*AppCatalogEn
 	return NewAppCatalogEntryFromUnstructured(obj)
 }
 
-// Search searches for AppCatalogEntries by app name or keywords
+// Search searches for AppCatalogEntries by app name, keywords and
+// description, returning the best matches first
 func (c *Client) Search(ctx context.Context, query string) ([]*AppCatalogEntry, error) {
-	entries, err := c.List(ctx, "")
+	ranked, err := c.SearchRanked(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	query = strings.ToLower(query)
-	results := make([]*AppCatalogEntry, 0)
+	results := make([]*AppCatalogEntry, 0, len(ranked))
+	for _, result := range ranked {
+		results = append(results, result.Entry)
+	}
 
-	for _, entry := range entries {
-		// Search in app name
-		if strings.Contains(strings.ToLower(entry.Spec.AppName), query) {
-			results = append(results, entry)
-			continue
-		}
+	return results, nil
+}
 
-		// Search in chart name
-		if strings.Contains(strings.ToLower(entry.Spec.Chart.Name), query) {
-			results = append(results, entry)
-			continue
-		}
+// SearchRanked searches for AppCatalogEntries and returns them with their
+// score, see Rank
+func (c *Client) SearchRanked(ctx context.Context, query string) ([]SearchResult, error) {
+	entries, err := c.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
 
-		// Search in description
-		if strings.Contains(strings.ToLower(entry.Spec.Chart.Description), query) {
-			results = append(results, entry)
-			continue
+	return Rank(entries, query), nil
+}
+
+// GetVersions gets all available versions for an app
+func (c *Client) GetVersions(ctx context.Context, appName string) ([]*AppCatalogEntry, error) {
+	var entries []*AppCatalogEntry
+	if c.indexed() {
+		// The index matches case-insensitively, the filter below keeps exact matches
+		entries = c.index.ByApp(appName)
+	} else {
+		var err error
+		entries, err = c.List(ctx, "")
+		if err != nil {
+			return nil, err
 		}
+	}
 
-		// Search in keywords
-		for _, keyword := range entry.Spec.Chart.Keywords {
-			if strings.Contains(strings.ToLower(keyword), query) {
-				results = append(results, entry)
-				break
-			}
+	versions := make([]*AppCatalogEntry, 0)
+	for _, entry := range entries {
+		if entry.MatchesApp(appName) {
+			versions = append(versions, entry)
 		}
 	}
 
-	return results, nil
+	return versions, nil
 }
 
-// GetVersions gets all available versions for an app
-func (c *Client) GetVersions(ctx context.Context, appName string) ([]*AppCatalogEntry, error) {
-	entries, err := c.List(ctx, "")
+// FindVersion finds the entry for a specific version of an app in a catalog
+func (c *Client) FindVersion(ctx context.Context, catalogName, appName, version string) (*AppCatalogEntry, error) {
+	entries, err := c.ListByCatalog(ctx, catalogName, "")
 	if err != nil {
 		return nil, err
 	}
 
-	versions := make([]*AppCatalogEntry, 0)
+	version = strings.TrimPrefix(version, "v")
 	for _, entry := range entries {
-		if entry.Spec.AppName == appName || entry.Spec.Chart.Name == appName {
-			versions = append(versions, entry)
+		if !entry.MatchesApp(appName) {
+			continue
+		}
+		if strings.TrimPrefix(entry.GetLatestVersion(), "v") == version {
+			return entry, nil
 		}
 	}
 
-	return versions, nil
+	return nil, fmt.Errorf("app catalog entry not found for %s/%s@%s", catalogName, appName, version)
 }
 
 // FilterByLabels filters entries by label selector
@@ -201,15 +266,86 @@ func SortByDate(entries []*AppCatalogEntry) []*AppCatalogEntry {
 	return sorted
 }
 
+// NewestVersion returns the highest stable semver version of the entries.
+// Pre-releases are only considered when there is no stable version, and the
+// most recently updated version is used when no version parses.
+func NewestVersion(entries []*AppCatalogEntry) string {
+	var newest, newestPre *semver.Version
+	for _, entry := range entries {
+		v, err := semver.NewVersion(entry.GetLatestVersion())
+		if err != nil {
+			continue
+		}
+		if v.Prerelease() != "" {
+			if newestPre == nil || v.GreaterThan(newestPre) {
+				newestPre = v
+			}
+		} else if newest == nil || v.GreaterThan(newest) {
+			newest = v
+		}
+	}
+
+	switch {
+	case newest != nil:
+		return newest.Original()
+	case newestPre != nil:
+		return newestPre.Original()
+	case len(entries) > 0:
+		return SortByDate(entries)[0].GetLatestVersion()
+	}
+	return ""
+}
+
+// NewestStableVersion returns the highest semver version of the entries that
+// is not a pre-release, or "" if there is none
+func NewestStableVersion(entries []*AppCatalogEntry) string {
+	var newest *semver.Version
+	for _, entry := range entries {
+		v, err := semver.NewVersion(entry.GetLatestVersion())
+		if err != nil || v.Prerelease() != "" {
+			continue
+		}
+		if newest == nil || v.GreaterThan(newest) {
+			newest = v
+		}
+	}
+	if newest == nil {
+		return ""
+	}
+	return newest.Original()
+}
+
+// ResolveLatest returns the newest stable version of an app in a catalog
+func (c *Client) ResolveLatest(ctx context.Context, catalogName, appName string) (string, error) {
+	entries, err := c.ListByCatalog(ctx, catalogName, "")
+	if err != nil {
+		return "", err
+	}
+
+	versions := make([]*AppCatalogEntry, 0)
+	for _, entry := range entries {
+		if entry.MatchesApp(appName) {
+			versions = append(versions, entry)
+		}
+	}
+	if len(versions) == 0 {
+		return "", fmt.Errorf("app %s not found in catalog %s", appName, catalogName)
+	}
+
+	version := NewestStableVersion(versions)
+	if version == "" {
+		return "", fmt.Errorf("catalog %s has no stable version of %s (newest: %s); specify the version explicitly",
+			catalogName, appName, NewestVersion(versions))
+	}
+	return version, nil
+}
+
 // GroupByApp groups entries by app name
 func GroupByApp(entries []*AppCatalogEntry) map[string][]*AppCatalogEntry {
 	grouped := make(map[string][]*AppCatalogEntry)
 
 	for _, entry := range entries {
-		appName := entry.Spec.AppName
-		if appName == "" {
-			appName = entry.Spec.Chart.Name
-		}
+		appName := entry.GetAppName()
 
 		if _, exists := grouped[appName]; !exists {
 			grouped[appName] = make([]*AppCatalogEntry, 0)
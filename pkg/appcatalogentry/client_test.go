@@ -0,0 +1,36 @@
+package appcatalogentry
+
+import (
+	"context"
+	"testing"
+)
+
+func TestResolveLatest(t *testing.T) {
+	entry := func(app, version string) *AppCatalogEntry {
+		return &AppCatalogEntry{Name: "giantswarm-" + app + "-" + version, Namespace: "default", Spec: AppCatalogEntrySpec{
+			AppName: app,
+			Catalog: CatalogReference{Name: "giantswarm"},
+			Chart:   ChartSpec{Name: app, Version: version},
+		}}
+	}
+
+	index := NewIndex(nil, 0)
+	index.Load([]*AppCatalogEntry{
+		entry("ingress-nginx", "1.9.0"),
+		entry("ingress-nginx", "1.10.0"),
+		entry("ingress-nginx", "2.0.0-rc.1"),
+		entry("preview", "0.1.0-alpha"),
+	})
+	client := NewClient(nil).WithIndex(index)
+	ctx := context.Background()
+
+	if got, err := client.ResolveLatest(ctx, "giantswarm", "ingress-nginx"); err != nil || got != "1.10.0" {
+		t.Errorf("ResolveLatest(ingress-nginx) = %q, %v; want 1.10.0", got, err)
+	}
+	if _, err := client.ResolveLatest(ctx, "giantswarm", "preview"); err == nil {
+		t.Error("ResolveLatest(preview) succeeded without a stable version")
+	}
+	if _, err := client.ResolveLatest(ctx, "giantswarm", "missing"); err == nil {
+		t.Error("ResolveLatest(missing) succeeded")
+	}
+}
@@ -0,0 +1,107 @@
+package appcatalogentry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"sigs.k8s.io/yaml"
+)
+
+// DependsOnAnnotation lists the apps an app needs to be installed alongside it.
+// The value is a comma separated list of app names, each optionally followed by
+// a version constraint, e.g. "cert-manager-app >=3.0.0, external-dns-app".
+const DependsOnAnnotation = "app-operator.giantswarm.io/depends-on"
+
+// Dependency describes an app that has to be present for another app to work
+type Dependency struct {
+	Name       string `json:"name"`
+	Constraint string `json:"constraint,omitempty"`
+	// Bundled dependencies are Helm subcharts shipped inside the chart package
+	// and do not need to be installed separately
+	Bundled bool `json:"bundled,omitempty"`
+}
+
+// chartMetadata is the subset of Chart.yaml used for dependency analysis
+type chartMetadata struct {
+	Annotations  map[string]string `json:"annotations"`
+	Dependencies []struct {
+		Name       string `json:"name"`
+		Version    string `json:"version"`
+		Repository string `json:"repository"`
+	} `json:"dependencies"`
+}
+
+// ParseDependsOn parses the value of the depends-on annotation
+func ParseDependsOn(value string) ([]Dependency, error) {
+	deps := make([]Dependency, 0)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name := item
+		constraint := ""
+		if idx := strings.IndexAny(item, " <>=!~^@"); idx >= 0 {
+			name = item[:idx]
+			constraint = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(item[idx:]), "@"))
+		}
+
+		if constraint != "" {
+			if _, err := semver.NewConstraint(constraint); err != nil {
+				return nil, fmt.Errorf("invalid version constraint for dependency %s: %w", name, err)
+			}
+		}
+
+		deps = append(deps, Dependency{Name: name, Constraint: constraint})
+	}
+
+	return deps, nil
+}
+
+// ChartDependencies reads the dependencies declared in a chart's Chart.yaml,
+// both the depends-on annotation and the bundled Helm dependency list
+func ChartDependencies(files ChartFiles) ([]Dependency, error) {
+	data, ok := files.Get("Chart.yaml")
+	if !ok {
+		return nil, fmt.Errorf("chart has no Chart.yaml")
+	}
+
+	var metadata chartMetadata
+	if err := yaml.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("invalid Chart.yaml: %w", err)
+	}
+
+	deps, err := ParseDependsOn(metadata.Annotations[DependsOnAnnotation])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range metadata.Dependencies {
+		deps = append(deps, Dependency{
+			Name:       d.Name,
+			Constraint: d.Version,
+			Bundled:    true,
+		})
+	}
+
+	return deps, nil
+}
+
+// GetDependencies returns the dependencies of an entry. The depends-on
+// annotation on the entry takes precedence; otherwise the chart package is
+// downloaded and its Chart.yaml inspected.
+func (c *Client) GetDependencies(ctx context.Context, entry *AppCatalogEntry) ([]Dependency, error) {
+	if value, ok := entry.Annotations[DependsOnAnnotation]; ok {
+		return ParseDependsOn(value)
+	}
+
+	files, err := FetchChart(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return ChartDependencies(files)
+}
@@ -0,0 +1,67 @@
+package appcatalogentry
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDependsOn(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []Dependency
+		wantErr bool
+	}{
+		{name: "empty", value: "", want: []Dependency{}},
+		{
+			name:  "names and constraints",
+			value: "cert-manager-app >=3.0.0, external-dns-app, prometheus-operator-crd@~1.2",
+			want: []Dependency{
+				{Name: "cert-manager-app", Constraint: ">=3.0.0"},
+				{Name: "external-dns-app"},
+				{Name: "prometheus-operator-crd", Constraint: "~1.2"},
+			},
+		},
+		{name: "blank items are skipped", value: " , kyverno ,", want: []Dependency{{Name: "kyverno"}}},
+		{name: "invalid constraint", value: "cert-manager-app >=not-a-version", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDependsOn(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDependsOn() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseDependsOn() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChartDependencies(t *testing.T) {
+	files := ChartFiles{"Chart.yaml": []byte(`name: hello
+annotations:
+  app-operator.giantswarm.io/depends-on: cert-manager-app >=3.0.0
+dependencies:
+  - name: common
+    version: 1.x.x
+    repository: https://charts.example.com
+`)}
+
+	got, err := ChartDependencies(files)
+	if err != nil {
+		t.Fatalf("ChartDependencies() error = %v", err)
+	}
+	want := []Dependency{
+		{Name: "cert-manager-app", Constraint: ">=3.0.0"},
+		{Name: "common", Constraint: "1.x.x", Bundled: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ChartDependencies() = %+v, want %+v", got, want)
+	}
+
+	if _, err := ChartDependencies(ChartFiles{}); err == nil {
+		t.Error("chart without Chart.yaml accepted")
+	}
+}
@@ -0,0 +1,203 @@
+package appcatalogentry
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+)
+
+// Index is an in-memory copy of all AppCatalogEntries with lookups by app
+// name and catalog. It is refreshed in the background so that tools
+// do not list thousands of entries from the API server on every call.
+type Index struct {
+	dynamicClient *k8s.DynamicClient
+	interval      time.Duration
+
+	// ready is closed once the index has been loaded
+	ready chan struct{}
+
+	mu          sync.RWMutex
+	synced      bool
+	started     time.Time
+	syncedAfter time.Duration
+	lastRefresh time.Time
+	lastErr     error
+	entries     []*AppCatalogEntry
+	byApp       map[string][]*AppCatalogEntry
+	byCatalog   map[string][]*AppCatalogEntry
+	counts      map[string]CatalogCount
+}
+
+// CatalogCount is the number of apps and app versions of a catalog
+type CatalogCount struct {
+	Apps    int
+	Entries int
+}
+
+// NewIndex creates an index that is refreshed every interval once started
+func NewIndex(dynamicClient *k8s.DynamicClient, interval time.Duration) *Index {
+	return &Index{
+		dynamicClient: dynamicClient,
+		interval:      interval,
+		ready:         make(chan struct{}),
+	}
+}
+
+// Start loads the index and keeps refreshing it until ctx is done. Failed
+// refreshes are logged and keep the previous entries.
+func (i *Index) Start(ctx context.Context) {
+	i.mu.Lock()
+	i.started = time.Now()
+	i.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(i.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := i.Refresh(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Warning: failed to refresh app catalog entry index: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Refresh lists all AppCatalogEntries and rebuilds the index
+func (i *Index) Refresh(ctx context.Context) error {
+	entries, err := NewClient(i.dynamicClient).List(ctx, "")
+	if err != nil {
+		i.mu.Lock()
+		i.lastErr = err
+		i.mu.Unlock()
+		return err
+	}
+
+	i.Load(entries)
+	return nil
+}
+
+// Load replaces the indexed entries
+func (i *Index) Load(entries []*AppCatalogEntry) {
+	byApp := make(map[string][]*AppCatalogEntry)
+	byCatalog := make(map[string][]*AppCatalogEntry)
+	counts := make(map[string]CatalogCount)
+	apps := make(map[string]bool)
+
+	for _, entry := range entries {
+		key := catalogKey(entry.Spec.Catalog.Namespace, entry.Spec.Catalog.Name)
+		count := counts[key]
+		count.Entries++
+		if appKey := key + "/" + entry.GetAppName(); !apps[appKey] {
+			apps[appKey] = true
+			count.Apps++
+		}
+		counts[key] = count
+
+		names := []string{strings.ToLower(entry.Spec.AppName)}
+		if chart := strings.ToLower(entry.Spec.Chart.Name); chart != names[0] {
+			names = append(names, chart)
+		}
+		for _, name := range names {
+			if name != "" {
+				byApp[name] = append(byApp[name], entry)
+			}
+		}
+
+		byCatalog[entry.Spec.Catalog.Name] = append(byCatalog[entry.Spec.Catalog.Name], entry)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.entries = entries
+	i.byApp = byApp
+	i.byCatalog = byCatalog
+	i.counts = counts
+	i.lastRefresh = time.Now()
+	i.lastErr = nil
+	if !i.synced {
+		i.synced = true
+		if !i.started.IsZero() {
+			i.syncedAfter = i.lastRefresh.Sub(i.started)
+		}
+		close(i.ready)
+	}
+}
+
+// WaitForSync blocks until the index has been loaded or ctx is done
+func (i *Index) WaitForSync(ctx context.Context) error {
+	select {
+	case <-i.ready:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("app catalog entry index not synced: %w", ctx.Err())
+	}
+}
+
+// WarmUp returns when the index was started and how long the initial load
+// took, which is 0 while it is still warming up
+func (i *Index) WarmUp() (time.Time, time.Duration) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.started, i.syncedAfter
+}
+
+// Synced reports whether the index has been loaded at least once
+func (i *Index) Synced() bool {
+	if i == nil {
+		return false
+	}
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.synced
+}
+
+// Status returns the time of the last successful refresh and the error of the
+// last failed one, if it failed after that
+func (i *Index) Status() (time.Time, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.lastRefresh, i.lastErr
+}
+
+// All returns all indexed entries
+func (i *Index) All() []*AppCatalogEntry {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.entries
+}
+
+// ByApp returns the entries whose app or chart name is name, case-insensitively
+func (i *Index) ByApp(name string) []*AppCatalogEntry {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.byApp[strings.ToLower(name)]
+}
+
+// ByCatalog returns the entries of a catalog
+func (i *Index) ByCatalog(catalogName string) []*AppCatalogEntry {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.byCatalog[catalogName]
+}
+
+// CountByCatalog returns the number of apps and app versions of a catalog
+func (i *Index) CountByCatalog(catalogNamespace, catalogName string) CatalogCount {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.counts[catalogKey(catalogNamespace, catalogName)]
+}
+
+func catalogKey(namespace, name string) string {
+	return namespace + "/" + name
+}
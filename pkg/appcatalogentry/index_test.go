@@ -0,0 +1,81 @@
+package appcatalogentry
+
+import (
+	"context"
+	"testing"
+)
+
+func TestIndexLookups(t *testing.T) {
+	entries := []*AppCatalogEntry{
+		{Name: "giantswarm-ingress-nginx-1.0.0", Namespace: "default", Spec: AppCatalogEntrySpec{
+			AppName: "ingress-nginx",
+			Catalog: CatalogReference{Name: "giantswarm"},
+			Chart:   ChartSpec{Name: "ingress-nginx", Version: "1.0.0", Keywords: []string{"Ingress", "ingress"}},
+		}},
+		{Name: "giantswarm-ingress-nginx-1.1.0", Namespace: "default", Spec: AppCatalogEntrySpec{
+			AppName: "ingress-nginx",
+			Catalog: CatalogReference{Name: "giantswarm"},
+			Chart:   ChartSpec{Name: "ingress-nginx", Version: "1.1.0"},
+		}},
+		{Name: "community-external-dns-2.0.0", Namespace: "community", Spec: AppCatalogEntrySpec{
+			AppName: "external-dns",
+			Catalog: CatalogReference{Name: "community"},
+			Chart:   ChartSpec{Name: "external-dns-chart", Version: "2.0.0", Keywords: []string{"dns"}},
+		}},
+	}
+
+	index := NewIndex(nil, 0)
+	if index.Synced() {
+		t.Fatal("index is synced before it was loaded")
+	}
+	index.Load(entries)
+
+	if got := len(index.ByApp("Ingress-Nginx")); got != 2 {
+		t.Errorf("ByApp(Ingress-Nginx) = %d entries, want 2", got)
+	}
+	if got := len(index.ByApp("external-dns-chart")); got != 1 {
+		t.Errorf("ByApp(external-dns-chart) = %d entries, want 1", got)
+	}
+	if got := len(index.ByCatalog("giantswarm")); got != 2 {
+		t.Errorf("ByCatalog(giantswarm) = %d entries, want 2", got)
+	}
+
+	if got := index.CountByCatalog("", "giantswarm"); got != (CatalogCount{Apps: 1, Entries: 2}) {
+		t.Errorf("CountByCatalog(giantswarm) = %+v, want 1 app with 2 entries", got)
+	}
+
+	// A client with a synced index never calls the API server
+	client := NewClient(nil).WithIndex(index)
+	ctx := context.Background()
+
+	listed, err := client.List(ctx, "community")
+	if err != nil || len(listed) != 1 {
+		t.Errorf("List(community) = %d entries, %v; want 1", len(listed), err)
+	}
+
+	entry, err := client.FindVersion(ctx, "giantswarm", "ingress-nginx", "v1.1.0")
+	if err != nil || entry.Name != "giantswarm-ingress-nginx-1.1.0" {
+		t.Errorf("FindVersion = %v, %v", entry, err)
+	}
+
+	versions, err := client.GetVersions(ctx, "ingress-nginx")
+	if err != nil || len(versions) != 2 {
+		t.Errorf("GetVersions = %d entries, %v; want 2", len(versions), err)
+	}
+}
+
+func TestIndexWaitForSync(t *testing.T) {
+	index := NewIndex(nil, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := index.WaitForSync(ctx); err == nil {
+		t.Error("WaitForSync returned before the index was loaded")
+	}
+
+	index.Load(nil)
+	index.Load(nil) // reloading must not close the ready channel twice
+	if err := index.WaitForSync(context.Background()); err != nil {
+		t.Errorf("WaitForSync after Load = %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package appcatalogentry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// WorkloadRequests are the resource requests a block of chart values sets
+// for one workload, e.g. the controller of an ingress chart
+type WorkloadRequests struct {
+	// Path is the dotted path of the values block holding resources; it is
+	// empty for the top level
+	Path     string
+	Replicas int
+
+	// Requests are per replica
+	Requests corev1.ResourceList
+}
+
+// Total returns the requests of all replicas
+func (w WorkloadRequests) Total() corev1.ResourceList {
+	total := corev1.ResourceList{}
+	for name, quantity := range w.Requests {
+		sum := quantity.DeepCopy()
+		sum.Mul(int64(w.Replicas))
+		total[name] = sum
+	}
+	return total
+}
+
+// EstimateRequests finds the resources.requests blocks in chart values. The
+// replicas of a block are read from its replicas or replicaCount sibling, or
+// autoscaling.minReplicas when autoscaling is enabled, and default to 1.
+// Blocks below a disabled component (enabled: false) are skipped. Without
+// rendering the chart this is an estimate: charts that compute requests in
+// templates or use other keys are not covered. Values that are not valid
+// quantities are returned as problems.
+func EstimateRequests(values map[string]interface{}) ([]WorkloadRequests, []string) {
+	var workloads []WorkloadRequests
+	var problems []string
+	estimateRequests(values, "", &workloads, &problems)
+	sort.Slice(workloads, func(i, j int) bool { return workloads[i].Path < workloads[j].Path })
+	return workloads, problems
+}
+
+func estimateRequests(values map[string]interface{}, path string, workloads *[]WorkloadRequests, problems *[]string) {
+	if enabled, ok := values["enabled"].(bool); ok && !enabled {
+		return
+	}
+
+	if resources, ok := values["resources"].(map[string]interface{}); ok {
+		if requests, ok := resources["requests"].(map[string]interface{}); ok {
+			w := WorkloadRequests{Path: path, Replicas: replicasOf(values), Requests: corev1.ResourceList{}}
+			for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+				value, ok := requests[string(name)]
+				if !ok || value == nil {
+					continue
+				}
+				quantity, err := resource.ParseQuantity(strings.TrimSpace(fmt.Sprint(value)))
+				if err != nil {
+					*problems = append(*problems, fmt.Sprintf("%s: invalid %s request %v", joinValuesPath(path, "resources.requests"), name, value))
+					continue
+				}
+				w.Requests[name] = quantity
+			}
+			if len(w.Requests) > 0 {
+				*workloads = append(*workloads, w)
+			}
+		}
+	}
+
+	for key, value := range values {
+		if key == "resources" {
+			continue
+		}
+		if child, ok := value.(map[string]interface{}); ok {
+			estimateRequests(child, joinValuesPath(path, key), workloads, problems)
+		}
+	}
+}
+
+// replicasOf reads the replica count of a values block
+func replicasOf(values map[string]interface{}) int {
+	if autoscaling, ok := values["autoscaling"].(map[string]interface{}); ok {
+		if enabled, _ := autoscaling["enabled"].(bool); enabled {
+			if n, ok := asInt(autoscaling["minReplicas"]); ok {
+				return n
+			}
+		}
+	}
+	for _, key := range []string{"replicas", "replicaCount"} {
+		if n, ok := asInt(values[key]); ok {
+			return n
+		}
+	}
+	return 1
+}
+
+func asInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, v >= 0
+	case int64:
+		return int(v), v >= 0
+	case float64:
+		return int(v), v >= 0 && v == float64(int(v))
+	}
+	return 0, false
+}
+
+func joinValuesPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
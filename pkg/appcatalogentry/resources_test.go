@@ -0,0 +1,55 @@
+package appcatalogentry
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+)
+
+func TestEstimateRequests(t *testing.T) {
+	defaults := `
+replicaCount: 2
+resources:
+  requests:
+    cpu: 100m
+    memory: 128Mi
+worker:
+  autoscaling:
+    enabled: true
+    minReplicas: 3
+  resources:
+    requests:
+      cpu: 0.5
+metrics:
+  enabled: false
+  resources:
+    requests:
+      cpu: 1
+sidecar:
+  resources:
+    requests:
+      memory: lots
+`
+	var values map[string]interface{}
+	if err := yaml.Unmarshal([]byte(defaults), &values); err != nil {
+		t.Fatal(err)
+	}
+	values["replicaCount"] = 1
+
+	workloads, problems := EstimateRequests(values)
+	if len(workloads) != 2 {
+		t.Fatalf("got %d workloads, want the top level and worker: %+v", len(workloads), workloads)
+	}
+	if workloads[0].Path != "" || workloads[0].Replicas != 1 {
+		t.Errorf("top level = %+v, want 1 replica after the override", workloads[0])
+	}
+	worker := workloads[1].Total()[corev1.ResourceCPU]
+	if workloads[1].Path != "worker" || worker.Cmp(resource.MustParse("1500m")) != 0 {
+		t.Errorf("worker = %+v, want 3 replicas of 500m", workloads[1])
+	}
+	if len(problems) != 1 {
+		t.Errorf("problems = %v, want the invalid sidecar memory", problems)
+	}
+}
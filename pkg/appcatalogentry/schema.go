@@ -0,0 +1,124 @@
+package appcatalogentry
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// ValuesSchemaFile is the JSON schema Helm validates chart values against
+const ValuesSchemaFile = "values.schema.json"
+
+// ValuesFile holds the default values of a chart
+const ValuesFile = "values.yaml"
+
+// RequiredPlaceholder is filled in for required string values without a default
+const RequiredPlaceholder = "REPLACE_ME"
+
+// jsonSchema is the subset of a JSON schema used to derive default values
+type jsonSchema struct {
+	Type       interface{}            `json:"type"`
+	Default    interface{}            `json:"default"`
+	Properties map[string]*jsonSchema `json:"properties"`
+	Required   []string               `json:"required"`
+	Enum       []interface{}          `json:"enum"`
+}
+
+// ValuesSchema returns the values schema of a chart, if it has one
+func (f ChartFiles) ValuesSchema() ([]byte, bool) {
+	return f.Get(ValuesSchemaFile)
+}
+
+// DefaultValues returns the default values.yaml of a chart, if it has one
+func (f ChartFiles) DefaultValues() ([]byte, bool) {
+	return f.Get(ValuesFile)
+}
+
+// DefaultValuesFromSchema derives a values document from a chart values schema.
+// Properties with a default get that default, required properties without one
+// get a placeholder of their type, optional properties without a default are
+// left out. The dotted paths of the placeholders are returned so callers can
+// point out what has to be filled in.
+func DefaultValuesFromSchema(data []byte) (map[string]interface{}, []string, error) {
+	var schema jsonSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse values schema: %w", err)
+	}
+
+	var placeholders []string
+	values := defaultObject(&schema, "", &placeholders)
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	sort.Strings(placeholders)
+
+	return values, placeholders, nil
+}
+
+// defaultObject returns the defaults of the properties of an object schema, or
+// nil when none of them has a value
+func defaultObject(schema *jsonSchema, path string, placeholders *[]string) map[string]interface{} {
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	values := make(map[string]interface{})
+	for name, prop := range schema.Properties {
+		if prop == nil {
+			continue
+		}
+		propPath := name
+		if path != "" {
+			propPath = path + "." + name
+		}
+
+		if prop.Default != nil {
+			values[name] = prop.Default
+			continue
+		}
+
+		if len(prop.Properties) > 0 {
+			if nested := defaultObject(prop, propPath, placeholders); nested != nil {
+				values[name] = nested
+				continue
+			}
+		}
+
+		if required[name] {
+			values[name] = placeholderValue(prop)
+			*placeholders = append(*placeholders, propPath)
+		}
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+// placeholderValue returns a value of the schema's type for a required
+// property without a default
+func placeholderValue(schema *jsonSchema) interface{} {
+	if len(schema.Enum) > 0 {
+		return schema.Enum[0]
+	}
+
+	schemaType, _ := schema.Type.(string)
+	if types, ok := schema.Type.([]interface{}); ok && len(types) > 0 {
+		schemaType, _ = types[0].(string)
+	}
+
+	switch schemaType {
+	case "object":
+		return map[string]interface{}{}
+	case "array":
+		return []interface{}{}
+	case "boolean":
+		return false
+	case "integer", "number":
+		return 0
+	default:
+		return RequiredPlaceholder
+	}
+}
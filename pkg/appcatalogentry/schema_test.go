@@ -0,0 +1,60 @@
+package appcatalogentry
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDefaultValuesFromSchema(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"required": ["ingress", "domain"],
+		"properties": {
+			"replicas": {"type": "integer", "default": 2},
+			"domain": {"type": "string"},
+			"debug": {"type": "boolean"},
+			"ingress": {
+				"type": "object",
+				"required": ["className", "tls"],
+				"properties": {
+					"enabled": {"type": "boolean", "default": true},
+					"className": {"type": "string", "enum": ["nginx", "traefik"]},
+					"tls": {"type": "boolean"}
+				}
+			},
+			"resources": {
+				"type": "object",
+				"properties": {
+					"limits": {"type": "object"}
+				}
+			}
+		}
+	}`
+
+	values, placeholders, err := DefaultValuesFromSchema([]byte(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"replicas": float64(2),
+		"domain":   RequiredPlaceholder,
+		"ingress": map[string]interface{}{
+			"enabled":   true,
+			"className": "nginx",
+			"tls":       false,
+		},
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("values = %#v, want %#v", values, want)
+	}
+
+	wantPlaceholders := []string{"domain", "ingress.className", "ingress.tls"}
+	if !reflect.DeepEqual(placeholders, wantPlaceholders) {
+		t.Errorf("placeholders = %v, want %v", placeholders, wantPlaceholders)
+	}
+
+	if _, _, err := DefaultValuesFromSchema([]byte("not json")); err == nil {
+		t.Error("expected an error for an invalid schema")
+	}
+}
@@ -0,0 +1,185 @@
+package appcatalogentry
+
+import (
+	"sort"
+	"strings"
+)
+
+// Scores of the ways a query term can match an entry, best first
+const (
+	scoreNameExact       = 100
+	scoreNamePrefix      = 80
+	scoreNamePartPrefix  = 75
+	scoreNameContains    = 60
+	scoreNameFuzzy       = 50
+	scoreKeywordExact    = 45
+	scoreKeywordPrefix   = 40
+	scoreKeywordFuzzy    = 30
+	scoreDescriptionWord = 20
+	scoreDescription     = 10
+)
+
+// SearchResult is an entry matching a search query
+type SearchResult struct {
+	Entry *AppCatalogEntry
+	Score int
+}
+
+// Rank scores entries against a query and returns the matching ones, best
+// first. Every term of the query has to match the app or chart name, a
+// keyword or the description; names rank above keywords and keywords above
+// descriptions. Name and keyword matches tolerate small typos.
+func Rank(entries []*AppCatalogEntry, query string) []SearchResult {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return nil
+	}
+
+	results := make([]SearchResult, 0)
+	for _, entry := range entries {
+		total := 0
+		for _, term := range terms {
+			score := scoreTerm(entry, term)
+			if score == 0 {
+				total = 0
+				break
+			}
+			total += score
+		}
+		if total > 0 {
+			results = append(results, SearchResult{Entry: entry, Score: total})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Entry.Spec.AppName < results[j].Entry.Spec.AppName
+	})
+
+	return results
+}
+
+// scoreTerm returns the best score of a single query term for an entry
+func scoreTerm(entry *AppCatalogEntry, term string) int {
+	best := 0
+	for _, name := range []string{entry.Spec.AppName, entry.Spec.Chart.Name} {
+		best = max(best, scoreName(strings.ToLower(name), term))
+	}
+	if best >= scoreNameFuzzy {
+		return best
+	}
+
+	for _, keyword := range entry.Spec.Chart.Keywords {
+		keyword = strings.ToLower(keyword)
+		switch {
+		case keyword == term:
+			best = max(best, scoreKeywordExact)
+		case strings.HasPrefix(keyword, term):
+			best = max(best, scoreKeywordPrefix)
+		case isTypo(keyword, term):
+			best = max(best, scoreKeywordFuzzy)
+		}
+	}
+	if best > 0 {
+		return best
+	}
+
+	description := strings.ToLower(entry.Spec.Chart.Description)
+	for _, word := range strings.FieldsFunc(description, isWordSeparator) {
+		if strings.HasPrefix(word, term) {
+			return scoreDescriptionWord
+		}
+	}
+	if strings.Contains(description, term) {
+		return scoreDescription
+	}
+
+	return 0
+}
+
+// scoreName matches a term against a name and the hyphen-separated parts of
+// it, so that "nginx" finds "ingress-nginx" before apps that merely mention it
+func scoreName(name, term string) int {
+	if name == "" {
+		return 0
+	}
+
+	switch {
+	case name == term:
+		return scoreNameExact
+	case strings.HasPrefix(name, term):
+		return scoreNamePrefix
+	}
+
+	for _, part := range strings.FieldsFunc(name, isWordSeparator) {
+		if strings.HasPrefix(part, term) {
+			return scoreNamePartPrefix
+		}
+	}
+
+	if strings.Contains(name, term) {
+		return scoreNameContains
+	}
+
+	if isTypo(name, term) {
+		return scoreNameFuzzy
+	}
+	for _, part := range strings.FieldsFunc(name, isWordSeparator) {
+		if isTypo(part, term) {
+			return scoreNameFuzzy
+		}
+	}
+
+	return 0
+}
+
+// isTypo reports whether term is a misspelling of word. Short terms allow one
+// edit, longer ones two.
+func isTypo(word, term string) bool {
+	if len(term) < 4 {
+		return false
+	}
+	allowed := 1
+	if len(term) > 7 {
+		allowed = 2
+	}
+	if diff := len(word) - len(term); diff > allowed || -diff > allowed {
+		return false
+	}
+	return editDistance(word, term) <= allowed
+}
+
+// editDistance returns the Damerau-Levenshtein distance (with adjacent
+// transpositions) between two strings
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return rows[len(ra)][len(rb)]
+}
+
+func isWordSeparator(r rune) bool {
+	return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+}
@@ -0,0 +1,92 @@
+package appcatalogentry
+
+import (
+	"testing"
+)
+
+func searchEntry(appName, description string, keywords ...string) *AppCatalogEntry {
+	return &AppCatalogEntry{
+		Name: "giantswarm-" + appName,
+		Spec: AppCatalogEntrySpec{
+			AppName: appName,
+			Chart:   ChartSpec{Name: appName, Description: description, Keywords: keywords},
+		},
+	}
+}
+
+func TestRank(t *testing.T) {
+	entries := []*AppCatalogEntry{
+		searchEntry("cert-manager", "Automatically provisions TLS certificates, e.g. for ingress resources"),
+		searchEntry("traefik", "Cloud native edge router", "ingress", "proxy"),
+		searchEntry("ingress-nginx", "Ingress controller using NGINX"),
+		searchEntry("nginx-ingress-controller", "Legacy NGINX ingress controller"),
+		searchEntry("external-dns", "Configures DNS records", "dns"),
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name:  "names before keywords before descriptions",
+			query: "ingress",
+			want:  []string{"ingress-nginx", "nginx-ingress-controller", "traefik", "cert-manager"},
+		},
+		{
+			name:  "prefix",
+			query: "cert",
+			want:  []string{"cert-manager"},
+		},
+		{
+			name:  "typo",
+			query: "trafeik",
+			want:  []string{"traefik"},
+		},
+		{
+			name:  "all terms must match",
+			query: "nginx legacy",
+			want:  []string{"nginx-ingress-controller"},
+		},
+		{
+			name:  "no match",
+			query: "postgres",
+			want:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := Rank(entries, tt.query)
+			got := make([]string, 0, len(results))
+			for _, r := range results {
+				got = append(got, r.Entry.Spec.AppName)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Rank(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Rank(%q) = %v, want %v", tt.query, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"traefik", "trafeik", 1},
+		{"nginx", "ngnx", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -1,11 +1,21 @@
 package appcatalogentry
 
 import (
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
+// DeprecatedAnnotation marks an entry as deprecated. Its value is "true" or
+// a note, e.g. naming the app that replaces it; "false" overrides the
+// DEPRECATED description prefix charts use by Helm convention.
+const DeprecatedAnnotation = "application.giantswarm.io/deprecated"
+
+// LatestLabel is set to "true" by app-operator on the entry of the newest
+// version of each app in a catalog
+const LatestLabel = "latest"
+
 // AppCatalogEntry represents a Giant Swarm AppCatalogEntry resource
 type AppCatalogEntry struct {
 	Name        string
@@ -54,6 +64,77 @@ type Restrictions struct {
 	GpuInstances       bool
 }
 
+// Summary is the compact form of an entry for clients that render catalog
+// browsers, e.g. with the chart icon
+type Summary struct {
+	Name            string   `json:"name"`
+	Namespace       string   `json:"namespace"`
+	App             string   `json:"app"`
+	Version         string   `json:"version"`
+	UpstreamVersion string   `json:"upstreamVersion,omitempty"`
+	Catalog         string   `json:"catalog"`
+	Description     string   `json:"description,omitempty"`
+	Icon            string   `json:"icon,omitempty"`
+	Home            string   `json:"home,omitempty"`
+	Keywords        []string `json:"keywords,omitempty"`
+	Sources         []string `json:"sources,omitempty"`
+	ClusterApp      bool     `json:"clusterApp,omitempty"`
+	Updated         string   `json:"updated,omitempty"`
+	Deprecated      bool     `json:"deprecated,omitempty"`
+	DeprecationNote string   `json:"deprecationNote,omitempty"`
+}
+
+// Summary returns the compact form of the entry
+func (e *AppCatalogEntry) Summary() Summary {
+	summary := Summary{
+		Name:            e.Name,
+		Namespace:       e.Namespace,
+		App:             e.GetAppName(),
+		Version:         e.GetLatestVersion(),
+		UpstreamVersion: e.GetAppVersion(),
+		Catalog:         e.Spec.Catalog.Name,
+		Description:     e.Spec.Chart.Description,
+		Icon:            e.Spec.Chart.Icon,
+		Home:            e.Spec.Chart.Home,
+		Keywords:        e.Spec.Chart.Keywords,
+		Sources:         e.Spec.Chart.Sources,
+		ClusterApp:      e.IsClusterApp(),
+	}
+
+	if updated := e.LastUpdated(); updated != nil {
+		summary.Updated = updated.Format(time.RFC3339)
+	}
+	summary.Deprecated, summary.DeprecationNote = e.Deprecation()
+
+	return summary
+}
+
+// LastUpdated returns when the entry was last updated, falling back to its
+// creation date; nil when the catalog recorded neither
+func (e *AppCatalogEntry) LastUpdated() *time.Time {
+	if e.Spec.DateUpdated != nil {
+		return e.Spec.DateUpdated
+	}
+	return e.Spec.DateCreated
+}
+
+// Deprecation reports whether the entry is deprecated, by its
+// DeprecatedAnnotation or a chart description starting with DEPRECATED, and
+// the note of the annotation
+func (e *AppCatalogEntry) Deprecation() (bool, string) {
+	if value, ok := e.Annotations[DeprecatedAnnotation]; ok {
+		switch note := strings.TrimSpace(value); strings.ToLower(note) {
+		case "false":
+			return false, ""
+		case "", "true":
+			return true, ""
+		default:
+			return true, note
+		}
+	}
+	return strings.HasPrefix(strings.ToUpper(strings.TrimSpace(e.Spec.Chart.Description)), "DEPRECATED"), ""
+}
+
 // GetLatestVersion returns the latest version from the entry
 func (e *AppCatalogEntry) GetLatestVersion() string {
 	if e.Spec.Chart.Version != "" {
@@ -62,6 +143,20 @@ func (e *AppCatalogEntry) GetLatestVersion() string {
 	return e.Spec.AppVersion
 }
 
+// GetAppName returns the name of the app, falling back to the chart name
+func (e *AppCatalogEntry) GetAppName() string {
+	if e.Spec.AppName != "" {
+		return e.Spec.AppName
+	}
+	return e.Spec.Chart.Name
+}
+
+// MatchesApp reports whether the entry is a version of the app with the given
+// app or chart name
+func (e *AppCatalogEntry) MatchesApp(name string) bool {
+	return e.Spec.AppName == name || e.Spec.Chart.Name == name
+}
+
 // GetAppVersion returns the application version
 func (e *AppCatalogEntry) GetAppVersion() string {
 	if e.Spec.Chart.AppVersion != "" {
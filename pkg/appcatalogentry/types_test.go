@@ -0,0 +1,55 @@
+package appcatalogentry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeprecation(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		description string
+		want        bool
+		wantNote    string
+	}{
+		{name: "not deprecated", description: "Ingress controller"},
+		{name: "annotation true", annotations: map[string]string{DeprecatedAnnotation: "true"}, want: true},
+		{name: "annotation note", annotations: map[string]string{DeprecatedAnnotation: " use ingress-nginx instead "}, want: true, wantNote: "use ingress-nginx instead"},
+		{name: "description prefix", description: "DEPRECATED: use ingress-nginx", want: true},
+		{name: "annotation false overrides description", annotations: map[string]string{DeprecatedAnnotation: "False"}, description: "Deprecated chart"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &AppCatalogEntry{Annotations: tt.annotations}
+			entry.Spec.Chart.Description = tt.description
+
+			got, note := entry.Deprecation()
+			if got != tt.want || note != tt.wantNote {
+				t.Errorf("Deprecation() = %v, %q, want %v, %q", got, note, tt.want, tt.wantNote)
+			}
+			if summary := entry.Summary(); summary.Deprecated != tt.want {
+				t.Errorf("Summary().Deprecated = %v, want %v", summary.Deprecated, tt.want)
+			}
+		})
+	}
+}
+
+func TestLastUpdated(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := created.Add(48 * time.Hour)
+
+	entry := &AppCatalogEntry{}
+	if entry.LastUpdated() != nil {
+		t.Error("entry without dates has a last update")
+	}
+	entry.Spec.DateCreated = &created
+	if got := entry.LastUpdated(); got == nil || !got.Equal(created) {
+		t.Errorf("LastUpdated() = %v, want the creation date", got)
+	}
+	entry.Spec.DateUpdated = &updated
+	if got := entry.LastUpdated(); got == nil || !got.Equal(updated) {
+		t.Errorf("LastUpdated() = %v, want the update date", got)
+	}
+}
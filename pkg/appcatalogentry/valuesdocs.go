@@ -0,0 +1,209 @@
+package appcatalogentry
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// ValueDoc documents a key of a chart's values.yaml
+type ValueDoc struct {
+	// Key is the dotted path of the value, e.g. image.tag
+	Key         string `json:"key"`
+	Type        string `json:"type"`
+	Default     string `json:"default"`
+	Description string `json:"description"`
+}
+
+var (
+	// typePrefix is an explicit type in front of a description, e.g. "(int)"
+	typePrefix = regexp.MustCompile(`^\(([^)]+)\)\s*`)
+	// oldStyleDoc is a comment that names the key it documents, e.g.
+	// "# image.tag -- Overrides the image tag"
+	oldStyleDoc = regexp.MustCompile(`^([\w.\-\[\]]+)\s+--\s*(.*)$`)
+)
+
+// ParseValuesDocs extracts the documentation of values from helm-docs style
+// comments in a values.yaml: a comment block starting with "# --" right above
+// a key, optionally continued by further comment lines, "# @default -- text"
+// overriding the rendered default, and a "(type)" prefix overriding the
+// inferred type. The older "# key.path -- description" form is supported too.
+// Only documented keys are returned, in the order they appear.
+func ParseValuesDocs(data []byte) ([]ValueDoc, error) {
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse values: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	docs := make([]ValueDoc, 0)
+	values := make(map[string]*yamlv3.Node)
+	var named []ValueDoc
+	walkValues(doc.Content[0], "", func(key string, keyNode, value *yamlv3.Node) {
+		values[key] = value
+		for _, comment := range []string{keyNode.HeadComment, keyNode.LineComment, keyNode.FootComment} {
+			named = append(named, parseNamedDocs(comment)...)
+		}
+		if d, ok := parseDocComment(keyNode.HeadComment); ok {
+			d.Key = key
+			docs = append(docs, completeDoc(d, value))
+		}
+	})
+
+	// Old style comments may be anywhere, so they are matched by name
+	documented := make(map[string]bool, len(docs))
+	for _, d := range docs {
+		documented[d.Key] = true
+	}
+	for _, d := range named {
+		if value, ok := values[d.Key]; ok && !documented[d.Key] {
+			documented[d.Key] = true
+			docs = append(docs, completeDoc(d, value))
+		}
+	}
+
+	return docs, nil
+}
+
+// walkValues calls fn for every key of a mapping and its nested mappings
+func walkValues(node *yamlv3.Node, path string, fn func(key string, keyNode, value *yamlv3.Node)) {
+	if node.Kind != yamlv3.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, value := node.Content[i], node.Content[i+1]
+		key := keyNode.Value
+		if path != "" {
+			key = path + "." + key
+		}
+		fn(key, keyNode, value)
+		walkValues(value, key, fn)
+	}
+}
+
+// commentLines returns the text of the lines of a comment without the #
+func commentLines(comment string) []string {
+	var lines []string
+	for _, line := range strings.Split(comment, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, strings.TrimSpace(strings.TrimLeft(line, "#")))
+	}
+	return lines
+}
+
+// parseDocComment parses the last "# --" block of the comment above a key
+func parseDocComment(comment string) (ValueDoc, bool) {
+	lines := commentLines(comment)
+	start := -1
+	for i, line := range lines {
+		if strings.HasPrefix(line, "--") {
+			start = i
+		}
+	}
+	if start < 0 {
+		return ValueDoc{}, false
+	}
+
+	d := ValueDoc{}
+	description := []string{strings.TrimSpace(strings.TrimPrefix(lines[start], "--"))}
+	for _, line := range lines[start+1:] {
+		if rest, ok := strings.CutPrefix(line, "@default --"); ok {
+			d.Default = strings.TrimSpace(rest)
+			continue
+		}
+		if strings.HasPrefix(line, "@") {
+			continue
+		}
+		description = append(description, line)
+	}
+	d.Description = strings.TrimSpace(strings.Join(description, " "))
+	return d, true
+}
+
+// parseNamedDocs parses old style "# key.path -- description" comments
+func parseNamedDocs(comment string) []ValueDoc {
+	var docs []ValueDoc
+	for _, line := range commentLines(comment) {
+		if m := oldStyleDoc.FindStringSubmatch(line); m != nil {
+			docs = append(docs, ValueDoc{Key: m[1], Description: strings.TrimSpace(m[2])})
+		}
+	}
+	return docs
+}
+
+// completeDoc fills in the type and default of a value's documentation
+func completeDoc(d ValueDoc, value *yamlv3.Node) ValueDoc {
+	if m := typePrefix.FindStringSubmatch(d.Description); m != nil {
+		d.Type = m[1]
+		d.Description = d.Description[len(m[0]):]
+	}
+	if d.Type == "" {
+		d.Type = valueType(value)
+	}
+	if d.Default == "" {
+		d.Default = renderDefault(value)
+	}
+	return d
+}
+
+// valueType returns the helm-docs type name of a value
+func valueType(node *yamlv3.Node) string {
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		return "object"
+	case yamlv3.SequenceNode:
+		return "list"
+	case yamlv3.AliasNode:
+		return valueType(node.Alias)
+	}
+	switch node.Tag {
+	case "!!int":
+		return "int"
+	case "!!float":
+		return "float"
+	case "!!bool":
+		return "bool"
+	case "!!null":
+		return "string"
+	}
+	return "string"
+}
+
+// renderDefault renders a value compactly, as JSON for collections
+func renderDefault(node *yamlv3.Node) string {
+	if node.Kind == yamlv3.ScalarNode {
+		if node.Tag == "!!str" {
+			data, _ := json.Marshal(node.Value)
+			return string(data)
+		}
+		return node.Value
+	}
+
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return ""
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// ValuesDocs returns the documented keys of a chart's values.yaml, or none
+// when the chart has no values.yaml
+func (f ChartFiles) ValuesDocs() ([]ValueDoc, error) {
+	data, ok := f.DefaultValues()
+	if !ok {
+		return nil, nil
+	}
+	return ParseValuesDocs(data)
+}
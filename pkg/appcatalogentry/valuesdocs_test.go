@@ -0,0 +1,48 @@
+package appcatalogentry
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseValuesDocs(t *testing.T) {
+	values := []byte(`# Not documentation
+# -- Number of replicas
+replicaCount: 1
+
+image:
+  # -- Image repository
+  repository: quay.io/giantswarm/nginx
+  # -- (string) Overrides the image tag,
+  # which defaults to the chart appVersion
+  # @default -- chart appVersion
+  tag: ""
+
+# -- Extra labels for all resources
+labels:
+  team: honeybadger
+
+tolerations: []
+
+# resources.limits -- Resource limits
+resources:
+  limits: {}
+  enabled: true
+`)
+
+	docs, err := ParseValuesDocs(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ValueDoc{
+		{Key: "replicaCount", Type: "int", Default: "1", Description: "Number of replicas"},
+		{Key: "image.repository", Type: "string", Default: `"quay.io/giantswarm/nginx"`, Description: "Image repository"},
+		{Key: "image.tag", Type: "string", Default: "chart appVersion", Description: "Overrides the image tag, which defaults to the chart appVersion"},
+		{Key: "labels", Type: "object", Default: `{"team":"honeybadger"}`, Description: "Extra labels for all resources"},
+		{Key: "resources.limits", Type: "object", Default: "{}", Description: "Resource limits"},
+	}
+	if !reflect.DeepEqual(docs, want) {
+		t.Errorf("ParseValuesDocs() =\n%+v\nwant\n%+v", docs, want)
+	}
+}
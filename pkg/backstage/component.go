@@ -0,0 +1,185 @@
+// Package backstage renders deployed apps as Backstage catalog entities, so
+// that a service catalog can be populated from platform data.
+package backstage
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+)
+
+// Backstage entity kinds and annotations
+const (
+	APIVersion = "backstage.io/v1alpha1"
+
+	KubernetesIDAnnotation        = "backstage.io/kubernetes-id"
+	KubernetesNamespaceAnnotation = "backstage.io/kubernetes-namespace"
+
+	// AppAnnotation and CatalogEntryAnnotation point back at the App and
+	// AppCatalogEntry a component was generated from, as namespace/name
+	AppAnnotation          = "giantswarm.io/app"
+	CatalogEntryAnnotation = "giantswarm.io/appcatalogentry"
+)
+
+// maxNameLength is the Backstage limit for names and tags
+const maxNameLength = 63
+
+// ComponentOptions are the catalog fields not derived from the App
+type ComponentOptions struct {
+	// Owner is the owning entity, e.g. group:acme
+	Owner string
+	// System groups the components, e.g. the cluster they run on
+	System string
+	// Entry is the catalog entry of the deployed version, if found
+	Entry *appcatalogentry.AppCatalogEntry
+}
+
+// Link is a link of a Backstage entity
+type Link struct {
+	URL   string `json:"url"`
+	Title string `json:"title,omitempty"`
+	Icon  string `json:"icon,omitempty"`
+}
+
+// Component is a Backstage Component entity
+type Component struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Metadata   ComponentMetadata `json:"metadata"`
+	Spec       ComponentSpec     `json:"spec"`
+}
+
+// ComponentMetadata is the metadata of a Backstage entity
+type ComponentMetadata struct {
+	Name        string            `json:"name"`
+	Title       string            `json:"title,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Links       []Link            `json:"links,omitempty"`
+}
+
+// ComponentSpec is the spec of a Backstage Component
+type ComponentSpec struct {
+	Type      string `json:"type"`
+	Lifecycle string `json:"lifecycle"`
+	Owner     string `json:"owner"`
+	System    string `json:"system,omitempty"`
+}
+
+// NewComponent describes a deployed app as a Backstage Component. Apps of
+// pre-release versions are experimental, all others in production.
+func NewComponent(a *app.App, opts ComponentOptions) *Component {
+	component := &Component{
+		APIVersion: APIVersion,
+		Kind:       "Component",
+		Metadata: ComponentMetadata{
+			Name:  entityName(a.Name),
+			Title: fmt.Sprintf("%s (%s %s)", a.Name, a.Spec.Name, a.Spec.Version),
+			Annotations: map[string]string{
+				KubernetesIDAnnotation: a.Name,
+				AppAnnotation:          a.Namespace + "/" + a.Name,
+			},
+		},
+		Spec: ComponentSpec{
+			Type:      "service",
+			Lifecycle: "production",
+			Owner:     opts.Owner,
+			System:    entityName(opts.System),
+		},
+	}
+	if a.Spec.Namespace != "" {
+		component.Metadata.Annotations[KubernetesNamespaceAnnotation] = a.Spec.Namespace
+	}
+	if clusterName := a.Labels[cluster.ClusterLabel]; clusterName != "" {
+		component.Metadata.Labels = map[string]string{cluster.ClusterLabel: clusterName}
+	}
+	if v, err := semver.NewVersion(a.Spec.Version); err == nil && v.Prerelease() != "" {
+		component.Spec.Lifecycle = "experimental"
+	}
+
+	if entry := opts.Entry; entry != nil {
+		component.Metadata.Description = entry.Spec.Chart.Description
+		component.Metadata.Annotations[CatalogEntryAnnotation] = entry.Namespace + "/" + entry.Name
+		for _, keyword := range entry.Spec.Chart.Keywords {
+			if tag := entityTag(keyword); tag != "" {
+				component.Metadata.Tags = append(component.Metadata.Tags, tag)
+			}
+		}
+		if entry.Spec.Chart.Home != "" {
+			component.Metadata.Links = append(component.Metadata.Links, Link{URL: entry.Spec.Chart.Home, Title: "Home", Icon: "web"})
+		}
+		for _, source := range entry.Spec.Chart.Sources {
+			component.Metadata.Links = append(component.Metadata.Links, Link{URL: source, Title: "Source", Icon: "github"})
+		}
+	}
+	if runbook := a.Annotations[app.RunbookURLAnnotation]; runbook != "" {
+		component.Metadata.Links = append(component.Metadata.Links, Link{URL: runbook, Title: "Runbook", Icon: "docs"})
+	}
+
+	return component
+}
+
+// Marshal renders components as a multi-document catalog-info.yaml
+func Marshal(components []*Component) (string, error) {
+	var out bytes.Buffer
+	for i, component := range components {
+		data, err := yaml.Marshal(component)
+		if err != nil {
+			return "", fmt.Errorf("failed to render component %s: %w", component.Metadata.Name, err)
+		}
+		if i > 0 {
+			out.WriteString("---\n")
+		}
+		out.Write(data)
+	}
+	return out.String(), nil
+}
+
+// entityName makes a string a valid Backstage name: letters, digits and
+// the separators -, _ and ., at most 63 characters
+func entityName(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '-' || r == '_' || r == '.':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	name := b.String()
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+	return strings.Trim(name, "-_.")
+}
+
+// entityTag makes a chart keyword a valid Backstage tag: lowercase letters,
+// digits, +, # and single dashes
+func entityTag(keyword string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(keyword) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '+', r == '#':
+			b.WriteRune(r)
+		case !strings.HasSuffix(b.String(), "-"):
+			b.WriteRune('-')
+		}
+	}
+	tag := b.String()
+	if len(tag) > maxNameLength {
+		tag = tag[:maxNameLength]
+	}
+	return strings.Trim(tag, "-")
+}
@@ -0,0 +1,55 @@
+package backstage
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+)
+
+func TestNewComponent(t *testing.T) {
+	a := &app.App{
+		Name:        "prod-ingress",
+		Namespace:   "org-acme",
+		Labels:      map[string]string{"giantswarm.io/cluster": "prod"},
+		Annotations: map[string]string{app.RunbookURLAnnotation: "https://runbooks.example.com/ingress"},
+		Spec:        app.AppSpec{Name: "ingress-nginx", Namespace: "kube-system", Version: "3.1.0-rc.1"},
+	}
+	entry := &appcatalogentry.AppCatalogEntry{Name: "giantswarm-ingress-nginx-3.1.0-rc.1", Namespace: "giantswarm"}
+	entry.Spec.Chart.Description = "Ingress controller"
+	entry.Spec.Chart.Home = "https://github.com/giantswarm/ingress-nginx-app"
+	entry.Spec.Chart.Keywords = []string{"Ingress", "load balancer", "--"}
+
+	component := NewComponent(a, ComponentOptions{Owner: "group:acme", System: "prod", Entry: entry})
+
+	if component.Spec.Owner != "group:acme" || component.Spec.System != "prod" || component.Spec.Lifecycle != "experimental" {
+		t.Errorf("spec = %+v", component.Spec)
+	}
+	if got := component.Metadata.Annotations[KubernetesNamespaceAnnotation]; got != "kube-system" {
+		t.Errorf("kubernetes namespace = %s", got)
+	}
+	if tags := component.Metadata.Tags; len(tags) != 2 || tags[0] != "ingress" || tags[1] != "load-balancer" {
+		t.Errorf("tags = %v", tags)
+	}
+	if links := component.Metadata.Links; len(links) != 2 || links[1].Title != "Runbook" {
+		t.Errorf("links = %+v", links)
+	}
+
+	out, err := Marshal([]*Component{component, NewComponent(&app.App{Name: "dex"}, ComponentOptions{Owner: "group:acme"})})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if strings.Count(out, "kind: Component") != 2 || !strings.Contains(out, "lifecycle: production") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestEntityName(t *testing.T) {
+	if got := entityName("my app/v2"); got != "my-app-v2" {
+		t.Errorf("entityName() = %s", got)
+	}
+	if got := entityName(strings.Repeat("a", 70)); len(got) != maxNameLength {
+		t.Errorf("entityName() has %d characters", len(got))
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
 )
@@ -63,11 +64,20 @@ func (c *Client) Get(ctx context.Context, namespace, name string) (*Catalog, err
 	return NewCatalogFromUnstructured(obj)
 }
 
-// Create creates a new catalog
+// Create creates a new catalog with server-side apply, so that later updates
+// own its fields without forcing
 func (c *Client) Create(ctx context.Context, catalog *Catalog) (*Catalog, error) {
+	_, err := c.dynamicClient.Catalogs(catalog.Namespace).Get(ctx, catalog.Name, metav1.GetOptions{})
+	if err = k8s.EnsureAbsent(err, k8s.CatalogGVR.GroupResource(), catalog.Name); err != nil {
+		return nil, fmt.Errorf("failed to create catalog %s/%s: %w", catalog.Namespace, catalog.Name, err)
+	}
+
 	unstructuredCatalog := catalog.ToUnstructured()
+	if len(catalog.Labels) == 0 {
+		unstructured.RemoveNestedField(unstructuredCatalog.Object, "metadata", "labels")
+	}
 
-	created, err := c.dynamicClient.Catalogs(catalog.Namespace).Create(ctx, unstructuredCatalog, metav1.CreateOptions{})
+	created, err := c.dynamicClient.Catalogs(catalog.Namespace).Apply(ctx, catalog.Name, unstructuredCatalog, k8s.UpdateOptions{}.ApplyOptions())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create catalog %s/%s: %w", catalog.Namespace, catalog.Name, err)
 	}
@@ -75,27 +85,60 @@ func (c *Client) Create(ctx context.Context, catalog *Catalog) (*Catalog, error)
 	return NewCatalogFromUnstructured(created)
 }
 
-// Update updates an existing catalog
-func (c *Client) Update(ctx context.Context, catalog *Catalog) (*Catalog, error) {
-	// Get current catalog to preserve metadata
-	current, err := c.Get(ctx, catalog.Namespace, catalog.Name)
+// Update re-reads a catalog, applies mutate to it and sends it with
+// server-side apply, retrying when the catalog changed in between
+func (c *Client) Update(ctx context.Context, namespace, name string, opts k8s.UpdateOptions, mutate func(*Catalog) error) (*Catalog, error) {
+	var updated *Catalog
+	err := k8s.RetryOnConflict(func() error {
+		obj, err := c.dynamicClient.Catalogs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		current, err := NewCatalogFromUnstructured(obj)
+		if err != nil {
+			return err
+		}
+
+		if err := mutate(current); err != nil {
+			return err
+		}
+
+		applyConfig := current.ToUnstructured()
+		if len(current.Labels) == 0 {
+			unstructured.RemoveNestedField(applyConfig.Object, "metadata", "labels")
+		}
+		applyConfig.SetResourceVersion(opts.Precondition(obj.GetResourceVersion()))
+
+		applied, err := c.dynamicClient.Catalogs(namespace).Apply(ctx, name, applyConfig, opts.ApplyOptions())
+		if err != nil {
+			return err
+		}
+
+		updated, err = NewCatalogFromUnstructured(applied)
+		return err
+	})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to update catalog %s/%s: %w", namespace, name, k8s.ConflictError("catalog", namespace, name, err))
 	}
 
-	// Convert to unstructured
-	unstructuredCatalog := catalog.ToUnstructured()
+	return updated, nil
+}
 
-	// Preserve resource version for update
-	currentUnstructured := current.ToUnstructured()
-	unstructuredCatalog.SetResourceVersion(currentUnstructured.GetResourceVersion())
+// MergeMetadata sets labels and annotations on a catalog, keeping the ones it
+// already has
+func (c *Client) MergeMetadata(ctx context.Context, namespace, name string, labels, annotations map[string]string) error {
+	patch, err := k8s.MetadataMergePatch(labels, annotations)
+	if err != nil || patch == nil {
+		return err
+	}
 
-	updated, err := c.dynamicClient.Catalogs(catalog.Namespace).Update(ctx, unstructuredCatalog, metav1.UpdateOptions{})
+	_, err = c.dynamicClient.Catalogs(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: k8s.FieldManager})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update catalog %s/%s: %w", catalog.Namespace, catalog.Name, err)
+		return fmt.Errorf("failed to update metadata of catalog %s/%s: %w", namespace, name, err)
 	}
 
-	return NewCatalogFromUnstructured(updated)
+	return nil
 }
 
 // Delete deletes a catalog
@@ -137,13 +180,3 @@ func FilterByVisibility(catalogs []*Catalog, visibility string) []*Catalog {
 	}
 	return filtered
 }
-
-// ValidateRepositoryURL validates that a repository URL is accessible
-func ValidateRepositoryURL(url string) error {
-	// Basic URL validation - in a real implementation, this could make HTTP requests
-	// to verify the repository is accessible
-	if url == "" {
-		return fmt.Errorf("repository URL cannot be empty")
-	}
-	return nil
-}
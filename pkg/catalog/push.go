@@ -0,0 +1,475 @@
+package catalog
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Media types of Helm charts stored in OCI registries
+	helmConfigMediaType  = "application/vnd.cncf.helm.config.v1+json"
+	helmChartMediaType   = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+	ociManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+
+	// maxPushChartSize limits the size of a chart package pushed to a registry
+	maxPushChartSize = 20 << 20
+)
+
+// registryClient is used to push charts to OCI registries
+var registryClient = &http.Client{Timeout: 60 * time.Second}
+
+// chartVersionLine matches the version field of a Chart.yaml
+var chartVersionLine = regexp.MustCompile(`(?m)^version:.*$`)
+
+// ChartPackage is a packaged Helm chart (.tgz)
+type ChartPackage struct {
+	Name    string
+	Version string
+	// Metadata is the Chart.yaml of the chart
+	Metadata []byte
+	Data     []byte
+}
+
+// ReadChartPackage reads the name and version of a packaged chart from its
+// Chart.yaml
+func ReadChartPackage(data []byte) (*ChartPackage, error) {
+	if len(data) > maxPushChartSize {
+		return nil, fmt.Errorf("chart package is larger than %d MiB", maxPushChartSize>>20)
+	}
+
+	var metadata []byte
+	err := walkChartPackage(data, func(header *tar.Header, r io.Reader) error {
+		if metadata == nil && isChartMetadata(header.Name) {
+			var err error
+			metadata, err = io.ReadAll(r)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chart package: %w", err)
+	}
+	if metadata == nil {
+		return nil, fmt.Errorf("chart package has no Chart.yaml")
+	}
+
+	var chart struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	if err := yaml.Unmarshal(metadata, &chart); err != nil {
+		return nil, fmt.Errorf("failed to parse Chart.yaml: %w", err)
+	}
+	if chart.Name == "" || chart.Version == "" {
+		return nil, fmt.Errorf("chart package has no name or version in its Chart.yaml")
+	}
+
+	return &ChartPackage{Name: chart.Name, Version: chart.Version, Metadata: metadata, Data: data}, nil
+}
+
+// ReadChartFile reads a packaged chart from a file
+func ReadChartFile(file string) (*ChartPackage, error) {
+	if !strings.HasSuffix(file, ".tgz") && !strings.HasSuffix(file, ".tar.gz") {
+		return nil, fmt.Errorf("%s is not a chart package (.tgz); package the chart with helm package first", file)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chart package: %w", err)
+	}
+	return ReadChartPackage(data)
+}
+
+// WithVersion returns a copy of the chart package whose Chart.yaml declares
+// version, so that each push of a changing chart gets a version of its own
+func (c *ChartPackage) WithVersion(version string) (*ChartPackage, error) {
+	if version == c.Version {
+		return c, nil
+	}
+	metadata := chartVersionLine.ReplaceAll(c.Metadata, []byte("version: "+version))
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	err := walkChartPackage(c.Data, func(header *tar.Header, r io.Reader) error {
+		content := r
+		if isChartMetadata(header.Name) {
+			content = bytes.NewReader(metadata)
+			header.Size = int64(len(metadata))
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := io.Copy(tw, content)
+		return err
+	})
+	if err == nil {
+		err = tw.Close()
+	}
+	if err == nil {
+		err = gz.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to repackage chart: %w", err)
+	}
+
+	return &ChartPackage{Name: c.Name, Version: version, Metadata: metadata, Data: buf.Bytes()}, nil
+}
+
+// isChartMetadata reports whether an archive path is the Chart.yaml of the
+// top-level chart, e.g. "nginx/Chart.yaml"
+func isChartMetadata(name string) bool {
+	dir, file := path.Split(path.Clean(strings.TrimPrefix(name, "/")))
+	return file == "Chart.yaml" && dir != "" && !strings.Contains(strings.TrimSuffix(dir, "/"), "/")
+}
+
+// walkChartPackage calls fn with each entry of a gzipped chart archive
+func walkChartPackage(data []byte, fn func(*tar.Header, io.Reader) error) error {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(header, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// OCIRepositoryURL returns the oci:// URL of the catalog's OCI repository
+func (c *Catalog) OCIRepositoryURL() (string, bool) {
+	for _, repository := range c.Spec.Repositories {
+		if strings.HasPrefix(repository.URL, "oci://") {
+			return repository.URL, true
+		}
+	}
+	if strings.HasPrefix(c.Spec.Storage.URL, "oci://") {
+		return c.Spec.Storage.URL, true
+	}
+	return "", false
+}
+
+// RegistryCredentials are the username and password (or token) used to push
+// to a registry
+type RegistryCredentials struct {
+	Username string
+	Password string
+}
+
+// DockerCredentials returns the credentials stored for a registry host in the
+// Docker config ($DOCKER_CONFIG/config.json or ~/.docker/config.json), as
+// written by docker login and helm registry login. Credential helpers are not
+// supported; nil means no credentials are stored.
+func DockerCredentials(host string) (*RegistryCredentials, error) {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, nil
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Docker config: %w", err)
+	}
+
+	var config struct {
+		Auths map[string]struct {
+			Auth string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse Docker config: %w", err)
+	}
+	for _, key := range []string{host, "https://" + host, "https://" + host + "/v2/"} {
+		entry, ok := config.Auths[key]
+		if !ok || entry.Auth == "" {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Docker config credentials for %s: %w", host, err)
+		}
+		username, password, _ := strings.Cut(string(decoded), ":")
+		return &RegistryCredentials{Username: username, Password: password}, nil
+	}
+	return nil, nil
+}
+
+// PushedChart is a chart pushed to an OCI registry
+type PushedChart struct {
+	// Reference is the chart's oci:// reference including the tag
+	Reference string
+	Digest    string
+}
+
+// PushChart pushes a chart package to the OCI repository at repositoryURL
+// (e.g. oci://registry.example.com/charts/dev/) as <name>:<version>, the
+// layout helm push uses. Blobs the registry already has are not uploaded
+// again.
+func PushChart(ctx context.Context, repositoryURL string, chart *ChartPackage, credentials *RegistryCredentials) (*PushedChart, error) {
+	u, err := url.Parse(repositoryURL)
+	if err != nil || u.Scheme != "oci" || u.Host == "" {
+		return nil, fmt.Errorf("invalid OCI repository URL %q", repositoryURL)
+	}
+
+	config, err := yaml.YAMLToJSON(chart.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert Chart.yaml: %w", err)
+	}
+
+	r := &registry{
+		host:        u.Host,
+		repository:  strings.Trim(path.Join(u.Path, chart.Name), "/"),
+		credentials: credentials,
+	}
+	configDescriptor, err := r.pushBlob(ctx, helmConfigMediaType, config)
+	if err != nil {
+		return nil, err
+	}
+	chartDescriptor, err := r.pushBlob(ctx, helmChartMediaType, chart.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := json.Marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"mediaType":     ociManifestMediaType,
+		"config":        configDescriptor,
+		"layers":        []descriptor{chartDescriptor},
+		"annotations": map[string]string{
+			"org.opencontainers.image.title":   chart.Name,
+			"org.opencontainers.image.version": chart.Version,
+			"org.opencontainers.image.created": time.Now().UTC().Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// OCI tags cannot contain "+", helm push replaces it with "_"
+	tag := strings.ReplaceAll(chart.Version, "+", "_")
+	resp, err := r.do(ctx, http.MethodPut, "/manifests/"+tag, ociManifestMediaType, manifest)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("failed to push manifest %s:%s: %s", r.repository, tag, resp.Status)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		digest = sha256Digest(manifest)
+	}
+	return &PushedChart{
+		Reference: fmt.Sprintf("oci://%s/%s:%s", r.host, r.repository, tag),
+		Digest:    digest,
+	}, nil
+}
+
+// descriptor references a blob in an OCI manifest
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int    `json:"size"`
+}
+
+func sha256Digest(data []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+}
+
+// registry is a repository of an OCI registry, spoken to with the
+// distribution API
+type registry struct {
+	host        string
+	repository  string
+	credentials *RegistryCredentials
+	// token is the bearer token obtained after the first challenge
+	token string
+}
+
+// pushBlob uploads a blob with a monolithic upload unless the registry
+// already has it
+func (r *registry) pushBlob(ctx context.Context, mediaType string, data []byte) (descriptor, error) {
+	desc := descriptor{MediaType: mediaType, Digest: sha256Digest(data), Size: len(data)}
+
+	resp, err := r.do(ctx, http.MethodHead, "/blobs/"+desc.Digest, "", nil)
+	if err != nil {
+		return desc, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return desc, nil
+	}
+
+	resp, err = r.do(ctx, http.MethodPost, "/blobs/uploads/", "", nil)
+	if err != nil {
+		return desc, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return desc, fmt.Errorf("failed to start upload to %s: %s", r.repository, resp.Status)
+	}
+	location, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil || resp.Header.Get("Location") == "" {
+		return desc, fmt.Errorf("registry returned no upload location for %s", r.repository)
+	}
+	query := location.Query()
+	query.Set("digest", desc.Digest)
+	location.RawQuery = query.Encode()
+
+	resp, err = r.doURL(ctx, http.MethodPut, location.String(), "application/octet-stream", data)
+	if err != nil {
+		return desc, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return desc, fmt.Errorf("failed to upload blob %s to %s: %s", desc.Digest, r.repository, resp.Status)
+	}
+	return desc, nil
+}
+
+// do sends a request to a path below /v2/<repository>
+func (r *registry) do(ctx context.Context, method, apiPath, contentType string, body []byte) (*http.Response, error) {
+	return r.doURL(ctx, method, fmt.Sprintf("https://%s/v2/%s%s", r.host, r.repository, apiPath), contentType, body)
+}
+
+// doURL sends a request, answering an authentication challenge once
+func (r *registry) doURL(ctx context.Context, method, target, contentType string, body []byte) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request for %s: %w", target, err)
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		if r.token != "" {
+			req.Header.Set("Authorization", "Bearer "+r.token)
+		} else if r.credentials != nil {
+			req.SetBasicAuth(r.credentials.Username, r.credentials.Password)
+		}
+
+		resp, err := registryClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reach registry %s: %w", r.host, err)
+		}
+		if resp.StatusCode != http.StatusUnauthorized || attempt > 0 {
+			return resp, nil
+		}
+
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+			return nil, fmt.Errorf("registry %s refused the credentials; log in with helm registry login %s", r.host, r.host)
+		}
+		if r.token, err = r.fetchToken(ctx, challenge); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// fetchToken answers a bearer challenge by requesting a push token from the
+// challenge's realm
+func (r *registry) fetchToken(ctx context.Context, challenge string) (string, error) {
+	params := parseChallenge(challenge)
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", fmt.Errorf("registry %s sent an invalid authentication challenge", r.host)
+	}
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull,push", r.repository))
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	if r.credentials != nil {
+		req.SetBasicAuth(r.credentials.Username, r.credentials.Password)
+	}
+	resp, err := registryClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get registry token from %s: %w", realm.Host, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get registry token from %s: %s; log in with helm registry login %s", realm.Host, resp.Status, r.host)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to parse registry token: %w", err)
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	if token.AccessToken != "" {
+		return token.AccessToken, nil
+	}
+	return "", fmt.Errorf("registry %s returned an empty token", realm.Host)
+}
+
+// parseChallenge parses the parameters of a WWW-Authenticate challenge, e.g.
+// Bearer realm="https://auth.example.com/token",scope="repository:a:pull,push"
+func parseChallenge(challenge string) map[string]string {
+	params := make(map[string]string)
+	_, rest, _ := strings.Cut(challenge, " ")
+	for rest != "" {
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		key = strings.ToLower(strings.Trim(key, " ,"))
+		if strings.HasPrefix(value, `"`) {
+			// Quoted values may contain commas
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				params[key] = value[1:]
+				break
+			}
+			params[key], rest = value[1:end+1], value[end+2:]
+		} else {
+			params[key], rest, _ = strings.Cut(value, ",")
+		}
+	}
+	return params
+}
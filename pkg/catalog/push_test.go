@@ -0,0 +1,190 @@
+package catalog
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// chartArchive builds a chart package with the given files
+func chartArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestChartPackageWithVersion(t *testing.T) {
+	data := chartArchive(t, map[string]string{
+		"hello/Chart.yaml":             "# comment\napiVersion: v2\nname: hello\nversion: 0.1.0\n",
+		"hello/charts/sub/Chart.yaml":  "apiVersion: v2\nname: sub\nversion: 9.9.9\n",
+		"hello/templates/service.yaml": "kind: Service\n",
+	})
+
+	chart, err := ReadChartPackage(data)
+	if err != nil {
+		t.Fatalf("ReadChartPackage() error = %v", err)
+	}
+	if chart.Name != "hello" || chart.Version != "0.1.0" {
+		t.Errorf("chart = %s %s, want hello 0.1.0", chart.Name, chart.Version)
+	}
+
+	dev, err := chart.WithVersion("0.1.0-dev.1")
+	if err != nil {
+		t.Fatalf("WithVersion() error = %v", err)
+	}
+	reread, err := ReadChartPackage(dev.Data)
+	if err != nil {
+		t.Fatalf("repackaged chart: %v", err)
+	}
+	if reread.Version != "0.1.0-dev.1" || !strings.HasPrefix(string(reread.Metadata), "# comment\n") {
+		t.Errorf("repackaged Chart.yaml = %q", reread.Metadata)
+	}
+
+	// The subchart keeps its version
+	err = walkChartPackage(dev.Data, func(header *tar.Header, r io.Reader) error {
+		if header.Name == "hello/charts/sub/Chart.yaml" {
+			content, _ := io.ReadAll(r)
+			if !strings.Contains(string(content), "version: 9.9.9") {
+				t.Errorf("subchart Chart.yaml changed: %q", content)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadChartPackage(chartArchive(t, map[string]string{"hello/values.yaml": ""})); err == nil {
+		t.Error("package without Chart.yaml accepted")
+	}
+}
+
+func TestPushChart(t *testing.T) {
+	var mu sync.Mutex
+	blobs := make(map[string][]byte)
+	manifests := make(map[string][]byte)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); user != "dev" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("scope") != "repository:charts/dev/hello:pull,push" {
+			t.Errorf("token scope = %q", r.URL.Query().Get("scope"))
+		}
+		_, _ = w.Write([]byte(`{"token":"push-token"}`))
+	})
+	var srv *httptest.Server
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer push-token" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",service="registry",scope="repository:charts/dev/hello:pull,push"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		path := strings.TrimPrefix(r.URL.Path, "/v2/charts/dev/hello")
+		switch {
+		case r.Method == http.MethodHead && strings.HasPrefix(path, "/blobs/"):
+			if _, ok := blobs[strings.TrimPrefix(path, "/blobs/")]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case r.Method == http.MethodPost && path == "/blobs/uploads/":
+			w.Header().Set("Location", "/v2/charts/dev/hello/blobs/uploads/1?state=x")
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodPut && strings.HasPrefix(path, "/blobs/uploads/"):
+			data, _ := io.ReadAll(r.Body)
+			if r.URL.Query().Get("state") != "x" {
+				t.Error("upload location query was dropped")
+			}
+			blobs[r.URL.Query().Get("digest")] = data
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPut && strings.HasPrefix(path, "/manifests/"):
+			data, _ := io.ReadAll(r.Body)
+			manifests[strings.TrimPrefix(path, "/manifests/")] = data
+			w.Header().Set("Docker-Content-Digest", "sha256:manifest")
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	srv = httptest.NewTLSServer(mux)
+	defer srv.Close()
+
+	previous := registryClient
+	registryClient = srv.Client()
+	defer func() { registryClient = previous }()
+
+	chart, err := ReadChartPackage(chartArchive(t, map[string]string{"hello/Chart.yaml": "name: hello\nversion: 0.1.0+build.1\n"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	host := strings.TrimPrefix(srv.URL, "https://")
+	pushed, err := PushChart(context.Background(), "oci://"+host+"/charts/dev/", chart, &RegistryCredentials{Username: "dev", Password: "secret"})
+	if err != nil {
+		t.Fatalf("PushChart() error = %v", err)
+	}
+
+	if pushed.Reference != "oci://"+host+"/charts/dev/hello:0.1.0_build.1" || pushed.Digest != "sha256:manifest" {
+		t.Errorf("pushed = %+v", pushed)
+	}
+	if len(blobs) != 2 || !bytes.Equal(blobs[sha256Digest(chart.Data)], chart.Data) {
+		t.Errorf("uploaded %d blobs, chart content missing", len(blobs))
+	}
+	manifest := string(manifests["0.1.0_build.1"])
+	if !strings.Contains(manifest, helmChartMediaType) || !strings.Contains(manifest, helmConfigMediaType) {
+		t.Errorf("manifest = %s", manifest)
+	}
+
+	// Pushing again skips the blobs the registry has
+	uploads := len(blobs)
+	if _, err := PushChart(context.Background(), "oci://"+host+"/charts/dev", chart, &RegistryCredentials{Username: "dev", Password: "secret"}); err != nil {
+		t.Fatalf("second PushChart() error = %v", err)
+	}
+	if len(blobs) != uploads {
+		t.Errorf("blobs uploaded again")
+	}
+
+	if _, err := PushChart(context.Background(), "oci://"+host+"/charts/dev", chart, nil); err == nil {
+		t.Error("push without credentials succeeded")
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	params := parseChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:a/b:pull,push"`)
+	want := map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry.example.com",
+		"scope":   "repository:a/b:pull,push",
+	}
+	for key, value := range want {
+		if params[key] != value {
+			t.Errorf("%s = %q, want %q", key, params[key], value)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// probeClient is used for the remote repository checks
+var probeClient = &http.Client{Timeout: 10 * time.Second}
+
+// ValidateRepositoryURL validates a Helm (http, https) or OCI (oci) repository
+// URL. With remote it also checks that the repository is reachable: the
+// index.yaml of a Helm repository must be served, and an OCI registry must
+// answer on its /v2/ API.
+func ValidateRepositoryURL(ctx context.Context, repositoryURL string, remote bool) error {
+	if repositoryURL == "" {
+		return fmt.Errorf("repository URL cannot be empty")
+	}
+
+	u, err := url.Parse(repositoryURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse repository URL: %w", err)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("repository URL %q has no host", repositoryURL)
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		if remote {
+			return probeHelmRepository(ctx, u)
+		}
+	case "oci":
+		if remote {
+			return probeOCIRegistry(ctx, u)
+		}
+	default:
+		return fmt.Errorf("unsupported repository URL scheme %q (supported: http, https, oci)", u.Scheme)
+	}
+	return nil
+}
+
+// probeHelmRepository checks that the repository serves a Helm index
+func probeHelmRepository(ctx context.Context, u *url.URL) error {
+	indexURL := strings.TrimSuffix(u.String(), "/") + "/index.yaml"
+
+	resp, err := probe(ctx, indexURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("repository index %s returned %s", indexURL, resp.Status)
+	}
+
+	// The index can be large; its header is enough to recognize it
+	head, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return fmt.Errorf("failed to read repository index %s: %w", indexURL, err)
+	}
+	if !bytes.Contains(head, []byte("apiVersion:")) && !bytes.Contains(head, []byte("entries:")) {
+		return fmt.Errorf("%s is not a Helm repository index", indexURL)
+	}
+	return nil
+}
+
+// probeOCIRegistry checks that the host runs an OCI registry. Catalog URLs
+// are repository prefixes rather than repositories, so there is no tag list
+// to fetch; an unauthorized answer still proves the registry exists.
+func probeOCIRegistry(ctx context.Context, u *url.URL) error {
+	apiURL := (&url.URL{Scheme: "https", Host: u.Host, Path: "/v2/"}).String()
+
+	resp, err := probe(ctx, apiURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusUnauthorized {
+		return fmt.Errorf("OCI registry %s returned %s", apiURL, resp.Status)
+	}
+	return nil
+}
+
+func probe(ctx context.Context, target string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", target, err)
+	}
+
+	resp, err := probeClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s: %w", target, err)
+	}
+	return resp, nil
+}
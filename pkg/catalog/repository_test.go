@@ -0,0 +1,61 @@
+package catalog
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidateRepositoryURL(t *testing.T) {
+	ctx := context.Background()
+
+	if err := ValidateRepositoryURL(ctx, "", false); err == nil {
+		t.Error("empty URL accepted")
+	}
+	if err := ValidateRepositoryURL(ctx, "ftp://example.com/charts", false); err == nil {
+		t.Error("ftp URL accepted")
+	}
+	if err := ValidateRepositoryURL(ctx, "https://unreachable.invalid/charts/", false); err != nil {
+		t.Errorf("URL without remote check: %v", err)
+	}
+}
+
+func TestValidateRepositoryURLRemote(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/charts/index.yaml":
+			_, _ = w.Write([]byte("apiVersion: v1\nentries: {}\n"))
+		case "/html/index.yaml":
+			_, _ = w.Write([]byte("<html></html>"))
+		case "/v2/":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	defaultClient := probeClient
+	probeClient = srv.Client()
+	defer func() { probeClient = defaultClient }()
+
+	host := strings.TrimPrefix(srv.URL, "https://")
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{url: srv.URL + "/charts/"},
+		{url: srv.URL + "/html/", wantErr: true},
+		{url: srv.URL + "/typo/", wantErr: true},
+		{url: "oci://" + host + "/giantswarm/"},
+	}
+	for _, tt := range tests {
+		err := ValidateRepositoryURL(ctx, tt.url, true)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateRepositoryURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
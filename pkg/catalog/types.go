@@ -1,9 +1,47 @@
 package catalog
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
+const (
+	// TypeLabel holds the type of a catalog
+	TypeLabel = "application.giantswarm.io/catalog-type"
+
+	// VisibilityLabel holds the visibility of a catalog
+	VisibilityLabel = "application.giantswarm.io/catalog-visibility"
+)
+
+// Types are the valid catalog types
+var Types = []string{"stable", "testing", "community"}
+
+// Visibilities are the valid catalog visibilities
+var Visibilities = []string{"public", "internal", "private"}
+
+// NormalizeType returns a catalog type in its canonical lower-case form, or
+// an error listing the valid types
+func NormalizeType(catalogType string) (string, error) {
+	return normalizeLabelValue("type", catalogType, Types)
+}
+
+// NormalizeVisibility returns a catalog visibility in its canonical
+// lower-case form, or an error listing the valid visibilities
+func NormalizeVisibility(visibility string) (string, error) {
+	return normalizeLabelValue("visibility", visibility, Visibilities)
+}
+
+func normalizeLabelValue(kind, value string, valid []string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	if !slices.Contains(valid, normalized) {
+		return "", fmt.Errorf("invalid catalog %s %q (valid: %s)", kind, value, strings.Join(valid, ", "))
+	}
+	return normalized, nil
+}
+
 // Catalog represents a Giant Swarm Catalog resource
 type Catalog struct {
 	Name      string
@@ -54,7 +92,7 @@ type SecretReference struct {
 
 // CatalogType represents the type of catalog (stable, testing, community)
 func (c *Catalog) CatalogType() string {
-	if catalogType, ok := c.Labels["application.giantswarm.io/catalog-type"]; ok {
+	if catalogType, ok := c.Labels[TypeLabel]; ok {
 		return catalogType
 	}
 	return "unknown"
@@ -62,12 +100,20 @@ func (c *Catalog) CatalogType() string {
 
 // CatalogVisibility represents the visibility of catalog (public, private)
 func (c *Catalog) CatalogVisibility() string {
-	if visibility, ok := c.Labels["application.giantswarm.io/catalog-visibility"]; ok {
+	if visibility, ok := c.Labels[VisibilityLabel]; ok {
 		return visibility
 	}
 	return "unknown"
 }
 
+// SetLabel sets a label of the catalog
+func (c *Catalog) SetLabel(key, value string) {
+	if c.Labels == nil {
+		c.Labels = make(map[string]string)
+	}
+	c.Labels[key] = value
+}
+
 // NewCatalogFromUnstructured converts an unstructured object to a Catalog
 func NewCatalogFromUnstructured(obj *unstructured.Unstructured) (*Catalog, error) {
 	catalog := &Catalog{
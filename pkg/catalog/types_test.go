@@ -0,0 +1,27 @@
+package catalog
+
+import "testing"
+
+func TestNormalizeType(t *testing.T) {
+	got, err := NormalizeType(" Stable ")
+	if err != nil || got != "stable" {
+		t.Errorf("NormalizeType(\" Stable \") = %q, %v; want stable", got, err)
+	}
+
+	_, err = NormalizeType("beta")
+	want := `invalid catalog type "beta" (valid: stable, testing, community)`
+	if err == nil || err.Error() != want {
+		t.Errorf("NormalizeType(\"beta\") error = %v, want %q", err, want)
+	}
+}
+
+func TestNormalizeVisibility(t *testing.T) {
+	got, err := NormalizeVisibility("PRIVATE")
+	if err != nil || got != "private" {
+		t.Errorf("NormalizeVisibility(\"PRIVATE\") = %q, %v; want private", got, err)
+	}
+
+	if _, err := NormalizeVisibility("secret"); err == nil {
+		t.Error("NormalizeVisibility(\"secret\") succeeded, want error")
+	}
+}
@@ -0,0 +1,148 @@
+package cluster
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// oidcIssuerArg is the API server flag naming the trusted OIDC issuer
+const oidcIssuerArg = "oidc-issuer-url"
+
+// APIServerAccess is what external tooling needs to talk to the API server
+// of a workload cluster. Fields that could not be determined are empty.
+type APIServerAccess struct {
+	// Server is the API server URL
+	Server string
+
+	// CAFingerprint is the SHA-256 fingerprint of the cluster CA certificate,
+	// as colon-separated hex bytes
+	CAFingerprint string
+	CASubject     string
+	CANotAfter    time.Time
+
+	// OIDCIssuer is the issuer URL of the OIDC provider the API server
+	// trusts; empty when none is configured
+	OIDCIssuer string
+}
+
+// KubeconfigAccess reads the API server URL and cluster CA of the current
+// context of a kubeconfig
+func KubeconfigAccess(kubeconfig []byte) (*APIServerAccess, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+
+	clusterName := ""
+	if kubeContext := config.Contexts[config.CurrentContext]; kubeContext != nil {
+		clusterName = kubeContext.Cluster
+	} else if len(config.Clusters) == 1 {
+		for name := range config.Clusters {
+			clusterName = name
+		}
+	}
+	kubeCluster := config.Clusters[clusterName]
+	if kubeCluster == nil {
+		return nil, fmt.Errorf("kubeconfig has no cluster for its current context")
+	}
+
+	access := &APIServerAccess{Server: kubeCluster.Server}
+	if len(kubeCluster.CertificateAuthorityData) > 0 {
+		cert, err := parseCertificate(kubeCluster.CertificateAuthorityData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate authority of cluster %s: %w", clusterName, err)
+		}
+		access.CAFingerprint = Fingerprint(cert.Raw)
+		access.CASubject = cert.Subject.String()
+		access.CANotAfter = cert.NotAfter
+	}
+	return access, nil
+}
+
+// Fingerprint returns the SHA-256 fingerprint of a DER certificate in the
+// colon-separated form openssl x509 -fingerprint -sha256 prints
+func Fingerprint(der []byte) string {
+	sum := sha256.Sum256(der)
+	hex := make([]string, len(sum))
+	for i, b := range sum {
+		hex[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(hex, ":")
+}
+
+// ControlPlaneOIDCIssuer returns the OIDC issuer configured on a control
+// plane object: the oidc-issuer-url API server argument of a
+// KubeadmControlPlane, or the OIDC identity provider of an EKS control plane
+func ControlPlaneOIDCIssuer(controlPlane *unstructured.Unstructured) string {
+	if issuer, _, _ := unstructured.NestedString(controlPlane.Object, "spec", "oidcIdentityProviderConfig", "issuerUrl"); issuer != "" {
+		return issuer
+	}
+
+	path := []string{"spec", "kubeadmConfigSpec", "clusterConfiguration", "apiServer", "extraArgs"}
+	if args, found, _ := unstructured.NestedStringMap(controlPlane.Object, path...); found {
+		return args[oidcIssuerArg]
+	}
+	// CAPI v1beta2 lists the arguments as name/value pairs
+	if args, found, _ := unstructured.NestedSlice(controlPlane.Object, path...); found {
+		for _, arg := range args {
+			if arg, ok := arg.(map[string]interface{}); ok && arg["name"] == oidcIssuerArg {
+				value, _ := arg["value"].(string)
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// GetAPIServerAccess returns the API server URL, CA fingerprint and OIDC
+// issuer of a workload cluster. The URL and CA come from the cluster's
+// kubeconfig secret, the URL falls back to the control plane endpoint of the
+// cluster spec, and the issuer comes from its control plane object. The
+// error reports the first part that could not be read.
+func (c *Client) GetAPIServerAccess(ctx context.Context, cl *Cluster) (*APIServerAccess, error) {
+	var firstErr error
+	access := &APIServerAccess{}
+
+	if kubeconfig, err := c.GetKubeconfig(ctx, cl); err != nil {
+		firstErr = err
+	} else if fromKubeconfig, err := KubeconfigAccess(kubeconfig); err != nil {
+		firstErr = err
+	} else {
+		access = fromKubeconfig
+	}
+	if endpoint := cl.Spec.ControlPlaneEndpoint; access.Server == "" && endpoint != nil {
+		access.Server = "https://" + endpoint.Host
+		if endpoint.Port != 0 {
+			access.Server += fmt.Sprintf(":%d", endpoint.Port)
+		}
+	}
+
+	if ref := cl.Spec.ControlPlaneRef; ref != nil {
+		gvr, err := infrastructureGVR(ref)
+		if err == nil {
+			namespace := ref.Namespace
+			if namespace == "" {
+				namespace = cl.Namespace
+			}
+			var controlPlane *unstructured.Unstructured
+			controlPlane, err = c.dynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+			if err == nil {
+				access.OIDCIssuer = ControlPlaneOIDCIssuer(controlPlane)
+			} else {
+				err = fmt.Errorf("failed to get %s %s/%s: %w", ref.Kind, namespace, ref.Name, err)
+			}
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return access, firstErr
+}
@@ -0,0 +1,113 @@
+package cluster
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestKubeconfigAccess(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kubernetes"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: other
+  cluster:
+    server: https://other.example.com
+- name: acme-prod
+  cluster:
+    server: https://api.acme-prod.example.com:6443
+    certificate-authority-data: %s
+contexts:
+- name: acme-prod-admin@acme-prod
+  context:
+    cluster: acme-prod
+    user: admin
+current-context: acme-prod-admin@acme-prod
+`, base64.StdEncoding.EncodeToString(caPEM))
+
+	access, err := KubeconfigAccess([]byte(kubeconfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if access.Server != "https://api.acme-prod.example.com:6443" {
+		t.Errorf("Server = %s", access.Server)
+	}
+	sum := sha256.Sum256(der)
+	if got := strings.ReplaceAll(access.CAFingerprint, ":", ""); got != fmt.Sprintf("%X", sum) {
+		t.Errorf("CAFingerprint = %s", access.CAFingerprint)
+	}
+	if access.CASubject != "CN=kubernetes" {
+		t.Errorf("CASubject = %s", access.CASubject)
+	}
+}
+
+func TestControlPlaneOIDCIssuer(t *testing.T) {
+	tests := []struct {
+		name string
+		spec map[string]interface{}
+		want string
+	}{
+		{
+			name: "kubeadm extra args",
+			spec: map[string]interface{}{"kubeadmConfigSpec": map[string]interface{}{"clusterConfiguration": map[string]interface{}{"apiServer": map[string]interface{}{
+				"extraArgs": map[string]interface{}{"oidc-issuer-url": "https://dex.acme.example.com", "oidc-client-id": "dex-k8s-authenticator"},
+			}}}},
+			want: "https://dex.acme.example.com",
+		},
+		{
+			name: "kubeadm v1beta2 extra args",
+			spec: map[string]interface{}{"kubeadmConfigSpec": map[string]interface{}{"clusterConfiguration": map[string]interface{}{"apiServer": map[string]interface{}{
+				"extraArgs": []interface{}{map[string]interface{}{"name": "oidc-issuer-url", "value": "https://dex.acme.example.com"}},
+			}}}},
+			want: "https://dex.acme.example.com",
+		},
+		{
+			name: "eks",
+			spec: map[string]interface{}{"oidcIdentityProviderConfig": map[string]interface{}{"issuerUrl": "https://issuer.example.com"}},
+			want: "https://issuer.example.com",
+		},
+		{
+			name: "none",
+			spec: map[string]interface{}{"replicas": int64(3)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controlPlane := &unstructured.Unstructured{Object: map[string]interface{}{"spec": tt.spec}}
+			if got := ControlPlaneOIDCIssuer(controlPlane); got != tt.want {
+				t.Errorf("ControlPlaneOIDCIssuer() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Capacity is the schedulable capacity of a cluster
+type Capacity struct {
+	// Nodes is the number of ready, schedulable nodes
+	Nodes int
+
+	// Allocatable is the total allocatable of the schedulable nodes, and
+	// LargestNode the highest allocatable of a single node per resource
+	Allocatable corev1.ResourceList
+	LargestNode corev1.ResourceList
+
+	// Requested is the sum of the requests of the pods that are not finished
+	Requested corev1.ResourceList
+}
+
+// Free returns the allocatable resources that are not requested yet
+func (c *Capacity) Free() corev1.ResourceList {
+	free := corev1.ResourceList{}
+	for name, quantity := range c.Allocatable {
+		left := quantity.DeepCopy()
+		if requested, ok := c.Requested[name]; ok {
+			left.Sub(requested)
+		}
+		free[name] = left
+	}
+	return free
+}
+
+// GetCapacity sums the allocatable resources of the ready, schedulable nodes
+// of a cluster and the requests of the pods running on them
+func GetCapacity(ctx context.Context, k8sClient kubernetes.Interface) (*Capacity, error) {
+	nodes, err := k8sClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	capacity := &Capacity{LargestNode: corev1.ResourceList{}}
+	schedulable := make([]Node, 0, len(nodes.Items))
+	for i := range nodes.Items {
+		n := NewNode(&nodes.Items[i])
+		if !n.Ready || n.Unschedulable {
+			continue
+		}
+		schedulable = append(schedulable, n)
+		for name, quantity := range n.Allocatable {
+			if largest, ok := capacity.LargestNode[name]; !ok || quantity.Cmp(largest) > 0 {
+				capacity.LargestNode[name] = quantity
+			}
+		}
+	}
+	capacity.Nodes = len(schedulable)
+	capacity.Allocatable = TotalAllocatable(schedulable)
+
+	pods, err := k8sClient.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: "status.phase!=Succeeded,status.phase!=Failed",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	capacity.Requested = corev1.ResourceList{}
+	for i := range pods.Items {
+		if pods.Items[i].Spec.NodeName == "" {
+			continue
+		}
+		addResources(capacity.Requested, PodRequests(&pods.Items[i]))
+	}
+
+	return capacity, nil
+}
+
+// PodRequests returns the effective requests of a pod: the sum of its
+// containers, or the highest init container request when that is larger
+func PodRequests(pod *corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		addResources(requests, container.Resources.Requests)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	return requests
+}
+
+func addResources(total, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		sum := total[name]
+		sum.Add(quantity)
+		total[name] = sum
+	}
+}
+
+// QuotaLimit is the room a ResourceQuota leaves for one resource
+type QuotaLimit struct {
+	Quota    string
+	Resource corev1.ResourceName
+	Hard     resource.Quantity
+	Used     resource.Quantity
+}
+
+// NamespaceQuotas returns the CPU and memory request limits the
+// ResourceQuotas of a namespace set. A namespace that does not exist has no
+// quotas.
+func NamespaceQuotas(ctx context.Context, k8sClient kubernetes.Interface, namespace string) ([]QuotaLimit, error) {
+	quotas, err := k8sClient.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource quotas in namespace %s: %w", namespace, err)
+	}
+
+	// A quota on cpu or memory limits the requests as well
+	limited := map[corev1.ResourceName]corev1.ResourceName{
+		corev1.ResourceRequestsCPU:    corev1.ResourceCPU,
+		corev1.ResourceCPU:            corev1.ResourceCPU,
+		corev1.ResourceRequestsMemory: corev1.ResourceMemory,
+		corev1.ResourceMemory:         corev1.ResourceMemory,
+	}
+
+	var limits []QuotaLimit
+	for _, quota := range quotas.Items {
+		for name, hard := range quota.Spec.Hard {
+			resourceName, ok := limited[name]
+			if !ok {
+				continue
+			}
+			limits = append(limits, QuotaLimit{
+				Quota:    quota.Name,
+				Resource: resourceName,
+				Hard:     hard,
+				Used:     quota.Status.Used[name],
+			})
+		}
+	}
+	sort.Slice(limits, func(i, j int) bool {
+		if limits[i].Quota != limits[j].Quota {
+			return limits[i].Quota < limits[j].Quota
+		}
+		return limits[i].Resource < limits[j].Resource
+	})
+	return limits, nil
+}
+
+// Remaining returns how much of the quota is left
+func (l QuotaLimit) Remaining() resource.Quantity {
+	left := l.Hard.DeepCopy()
+	left.Sub(l.Used)
+	return left
+}
@@ -0,0 +1,103 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func capacityNode(name, cpu, memory string, ready bool) *corev1.Node {
+	status := corev1.ConditionTrue
+	if !ready {
+		status = corev1.ConditionFalse
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+		},
+	}
+}
+
+func TestGetCapacity(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName: "a",
+			InitContainers: []corev1.Container{{Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("1"),
+			}}}},
+			Containers: []corev1.Container{
+				{Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("250m"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				}}},
+				{Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("250m"),
+				}}},
+			},
+		},
+	}
+	client := fake.NewClientset(
+		capacityNode("a", "2", "8Gi", true),
+		capacityNode("b", "4", "8Gi", true),
+		capacityNode("c", "16", "64Gi", false),
+		pod,
+	)
+
+	capacity, err := GetCapacity(context.Background(), client)
+	if err != nil {
+		t.Fatalf("GetCapacity: %v", err)
+	}
+	if capacity.Nodes != 2 {
+		t.Errorf("Nodes = %d, want 2 (the not ready node excluded)", capacity.Nodes)
+	}
+	largest := capacity.LargestNode[corev1.ResourceCPU]
+	if largest.Cmp(resource.MustParse("4")) != 0 {
+		t.Errorf("LargestNode cpu = %s, want 4", largest.String())
+	}
+
+	// The init container request is larger than the sum of the containers
+	free := capacity.Free()
+	freeCPU, freeMemory := free[corev1.ResourceCPU], free[corev1.ResourceMemory]
+	if freeCPU.Cmp(resource.MustParse("5")) != 0 || freeMemory.Cmp(resource.MustParse("15Gi")) != 0 {
+		t.Errorf("Free = cpu %s, memory %s, want cpu 5, memory 15Gi", freeCPU.String(), freeMemory.String())
+	}
+}
+
+func TestNamespaceQuotas(t *testing.T) {
+	client := fake.NewClientset(&corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "team"},
+		Spec: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{
+			corev1.ResourceRequestsCPU: resource.MustParse("4"),
+			corev1.ResourceMemory:      resource.MustParse("8Gi"),
+			corev1.ResourcePods:        resource.MustParse("20"),
+		}},
+		Status: corev1.ResourceQuotaStatus{Used: corev1.ResourceList{
+			corev1.ResourceRequestsCPU: resource.MustParse("3"),
+			corev1.ResourceMemory:      resource.MustParse("2Gi"),
+		}},
+	})
+
+	limits, err := NamespaceQuotas(context.Background(), client, "team")
+	if err != nil {
+		t.Fatalf("NamespaceQuotas: %v", err)
+	}
+	if len(limits) != 2 {
+		t.Fatalf("got %d limits, want cpu and memory: %+v", len(limits), limits)
+	}
+	if limits[0].Resource != corev1.ResourceCPU {
+		t.Fatalf("first limit is %s, want cpu", limits[0].Resource)
+	}
+	if remaining := limits[0].Remaining(); remaining.Cmp(resource.MustParse("1")) != 0 {
+		t.Errorf("remaining cpu = %s, want 1", remaining.String())
+	}
+}
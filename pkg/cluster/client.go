@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -27,6 +28,7 @@ type Client struct {
 	dynamicClient dynamic.Interface
 	k8sClient     kubernetes.Interface
 	appClient     *app.Client
+	pool          *ClientPool
 }
 
 // NewClient creates a new cluster client
@@ -82,6 +84,12 @@ func (c *Client) Get(ctx context.Context, namespace, name string) (*Cluster, err
 	return NewClusterFromUnstructured(obj)
 }
 
+// WithClientPool makes the client reuse workload cluster clients from pool
+func (c *Client) WithClientPool(pool *ClientPool) *Client {
+	c.pool = pool
+	return c
+}
+
 // ListByOrganization lists all clusters belonging to an organization
 func (c *Client) ListByOrganization(ctx context.Context, org string) ([]*Cluster, error) {
 	// First, get all namespaces for the organization
@@ -112,15 +120,24 @@ func (c *Client) ListByOrganization(ctx context.Context, org string) ([]*Cluster
 
 // GetKubeconfig retrieves the kubeconfig for a workload cluster
 func (c *Client) GetKubeconfig(ctx context.Context, cluster *Cluster) ([]byte, error) {
-	// Look for kubeconfig secret in the same namespace as the cluster
-	// The secret name follows the pattern: {cluster-name}-kubeconfig
-	secretName := fmt.Sprintf("%s-kubeconfig", cluster.Name)
+	secret, err := getKubeconfigSecret(ctx, c.k8sClient, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return kubeconfigFromSecret(secret)
+}
 
-	secret, err := c.k8sClient.CoreV1().Secrets(cluster.Namespace).Get(ctx, secretName, metav1.GetOptions{})
+// getKubeconfigSecret gets the kubeconfig secret of a workload cluster, which
+// lives in the cluster's namespace and is named {cluster-name}-kubeconfig
+func getKubeconfigSecret(ctx context.Context, k8sClient kubernetes.Interface, cluster *Cluster) (*corev1.Secret, error) {
+	secret, err := k8sClient.CoreV1().Secrets(cluster.Namespace).Get(ctx, KubeconfigSecretName(cluster), metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get kubeconfig secret: %w", err)
 	}
+	return secret, nil
+}
 
+func kubeconfigFromSecret(secret *corev1.Secret) ([]byte, error) {
 	// The kubeconfig is usually stored in the "value" key
 	if kubeconfig, ok := secret.Data["value"]; ok {
 		return kubeconfig, nil
@@ -145,18 +162,17 @@ func (c *Client) ListApps(ctx context.Context, cluster *Cluster) ([]*app.App, er
 		return nil, fmt.Errorf("failed to list apps in cluster %s: %w", cluster.Name, err)
 	}
 
-	// Also check for apps that target this cluster via kubeconfig
-	// This requires checking apps in the organization namespace
+	// Also check for apps that target this cluster via kubeconfig, which live
+	// in the namespace of the cluster (its organization namespace)
+	orgNamespace := cluster.Namespace
 	if cluster.GetOrganization() != "" {
-		orgNamespace := organization.GetOrganizationNamespace(cluster.GetOrganization())
+		orgNamespace = organization.GetOrganizationNamespace(cluster.GetOrganization())
+	}
+	if orgNamespace != workloadNamespace {
 		orgApps, err := c.appClient.List(ctx, orgNamespace, "")
 		if err == nil {
-			// Filter apps that target this cluster
 			for _, app := range orgApps {
-				// Check if app targets this cluster
-				// This would need to be implemented based on how apps reference target clusters
-				if !app.Spec.KubeConfig.InCluster {
-					// TODO: Check if app references this cluster's kubeconfig
+				if AppTargetsCluster(app, cluster) {
 					apps = append(apps, app)
 				}
 			}
@@ -166,6 +182,57 @@ func (c *Client) ListApps(ctx context.Context, cluster *Cluster) ([]*app.App, er
 	return apps, nil
 }
 
+// KubeconfigSecretName returns the name of the kubeconfig secret CAPI creates for a cluster
+func KubeconfigSecretName(cluster *Cluster) string {
+	return fmt.Sprintf("%s-kubeconfig", cluster.Name)
+}
+
+// AppTargetsCluster reports whether an app is deployed to a workload cluster.
+// The kubeconfig secret an app references is authoritative; apps without one
+// are matched by their kubeconfig context and then by the cluster label.
+func AppTargetsCluster(a *app.App, cluster *Cluster) bool {
+	if a.Spec.KubeConfig.InCluster {
+		return false
+	}
+
+	if secret := a.Spec.KubeConfig.Secret; secret != nil && secret.Name != "" {
+		namespace := secret.Namespace
+		if namespace == "" {
+			namespace = a.Namespace
+		}
+		return secret.Name == KubeconfigSecretName(cluster) && namespace == cluster.Namespace
+	}
+
+	if context := a.Spec.KubeConfig.Context; context != "" {
+		return context == cluster.Name || context == fmt.Sprintf("%s-admin@%s", cluster.Name, cluster.Name)
+	}
+
+	return a.Labels[ClusterLabel] == cluster.Name
+}
+
+// FindAppCluster returns the workload cluster an app is deployed to, looking
+// in the app's namespace first. It returns nil for apps deployed to the
+// management cluster.
+func (c *Client) FindAppCluster(ctx context.Context, a *app.App) (*Cluster, error) {
+	if a.Spec.KubeConfig.InCluster {
+		return nil, nil
+	}
+
+	for _, namespace := range []string{a.Namespace, ""} {
+		clusters, err := c.List(ctx, namespace, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, cl := range clusters {
+			if AppTargetsCluster(a, cl) {
+				return cl, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no workload cluster found for app %s/%s", a.Namespace, a.Name)
+}
+
 // IsWorkloadCluster checks if this is a workload cluster (not the management cluster)
 func (c *Client) IsWorkloadCluster(cluster *Cluster) bool {
 	// Management clusters typically have specific labels or are in specific namespaces
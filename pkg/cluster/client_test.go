@@ -0,0 +1,74 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+)
+
+func TestAppTargetsCluster(t *testing.T) {
+	cl := &Cluster{Name: "prod", Namespace: "org-acme"}
+
+	tests := []struct {
+		name string
+		app  *app.App
+		want bool
+	}{
+		{
+			name: "in cluster",
+			app:  &app.App{Namespace: "org-acme", Spec: app.AppSpec{KubeConfig: app.KubeConfig{InCluster: true}}},
+			want: false,
+		},
+		{
+			name: "kubeconfig secret of the cluster",
+			app: &app.App{Namespace: "org-acme", Spec: app.AppSpec{KubeConfig: app.KubeConfig{
+				Secret: &app.SecretReference{Name: "prod-kubeconfig", Namespace: "org-acme"},
+			}}},
+			want: true,
+		},
+		{
+			name: "kubeconfig secret without namespace",
+			app: &app.App{Namespace: "org-acme", Spec: app.AppSpec{KubeConfig: app.KubeConfig{
+				Secret: &app.SecretReference{Name: "prod-kubeconfig"},
+			}}},
+			want: true,
+		},
+		{
+			name: "kubeconfig secret of another cluster wins over the label",
+			app: &app.App{Namespace: "org-acme", Labels: map[string]string{ClusterLabel: "prod"}, Spec: app.AppSpec{KubeConfig: app.KubeConfig{
+				Secret: &app.SecretReference{Name: "staging-kubeconfig", Namespace: "org-acme"},
+			}}},
+			want: false,
+		},
+		{
+			name: "same secret name in another namespace",
+			app: &app.App{Namespace: "org-other", Spec: app.AppSpec{KubeConfig: app.KubeConfig{
+				Secret: &app.SecretReference{Name: "prod-kubeconfig", Namespace: "org-other"},
+			}}},
+			want: false,
+		},
+		{
+			name: "kubeconfig context",
+			app:  &app.App{Namespace: "org-acme", Spec: app.AppSpec{KubeConfig: app.KubeConfig{Context: "prod-admin@prod"}}},
+			want: true,
+		},
+		{
+			name: "cluster label",
+			app:  &app.App{Namespace: "org-acme", Labels: map[string]string{ClusterLabel: "prod"}},
+			want: true,
+		},
+		{
+			name: "no reference",
+			app:  &app.App{Namespace: "org-acme"},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AppTargetsCluster(tt.app, cl); got != tt.want {
+				t.Errorf("AppTargetsCluster() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
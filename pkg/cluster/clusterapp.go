@@ -0,0 +1,53 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+)
+
+// ClusterCharts are the cluster-$provider charts Giant Swarm clusters are
+// created from. Such a cluster is described by an App named after the cluster
+// in its namespace; the user values of the App hold its node pools, OIDC and
+// control plane settings.
+var ClusterCharts = []string{
+	"cluster-aws",
+	"cluster-azure",
+	"cluster-cloud-director",
+	"cluster-eks",
+	"cluster-gcp",
+	"cluster-openstack",
+	"cluster-vsphere",
+}
+
+// UserConfigKey is the data key of the cluster app's user values ConfigMap
+const UserConfigKey = "values"
+
+// IsClusterChart reports whether a chart is one of the cluster charts
+func IsClusterChart(name string) bool {
+	for _, chart := range ClusterCharts {
+		if name == chart {
+			return true
+		}
+	}
+	return false
+}
+
+// UserConfigName returns the conventional name of the user values ConfigMap
+// of a cluster app
+func UserConfigName(clusterName string) string {
+	return clusterName + "-userconfig"
+}
+
+// GetClusterApp returns the cluster app a cluster was created from
+func (c *Client) GetClusterApp(ctx context.Context, namespace, name string) (*app.App, error) {
+	a, err := c.appClient.Get(ctx, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster app of %s/%s: %w", namespace, name, err)
+	}
+	if !IsClusterChart(a.Spec.Name) {
+		return nil, fmt.Errorf("app %s/%s deploys %s, not a cluster chart; the cluster was not created from a cluster-$provider app", namespace, name, a.Spec.Name)
+	}
+	return a, nil
+}
@@ -0,0 +1,20 @@
+package cluster
+
+import "testing"
+
+func TestIsClusterChart(t *testing.T) {
+	for name, want := range map[string]bool{
+		"cluster-aws":         true,
+		"cluster-vsphere":     true,
+		"cluster-autoscaler":  false,
+		"default-apps-aws":    false,
+		"cluster-aws-default": false,
+	} {
+		if got := IsClusterChart(name); got != want {
+			t.Errorf("IsClusterChart(%q) = %v, want %v", name, got, want)
+		}
+	}
+	if got := UserConfigName("prod"); got != "prod-userconfig" {
+		t.Errorf("UserConfigName() = %s", got)
+	}
+}
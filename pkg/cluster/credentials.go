@@ -0,0 +1,122 @@
+package cluster
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Kinds of kubeconfig credentials
+const (
+	CredentialClientCertificate = "client certificate"
+	CredentialToken             = "token"
+)
+
+// CredentialExpiry is the expiry of a credential in a kubeconfig
+type CredentialExpiry struct {
+	// User is the kubeconfig user the credential belongs to
+	User     string
+	Kind     string
+	Subject  string
+	NotAfter time.Time
+}
+
+// KubeconfigExpiry returns the expiry of the client certificates and JWT
+// tokens in a kubeconfig, soonest first. Credentials without an expiry,
+// such as static tokens or exec plugins, are left out.
+func KubeconfigExpiry(kubeconfig []byte) ([]CredentialExpiry, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+
+	expiries := make([]CredentialExpiry, 0)
+	for name, authInfo := range config.AuthInfos {
+		if len(authInfo.ClientCertificateData) > 0 {
+			cert, err := parseCertificate(authInfo.ClientCertificateData)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse client certificate of user %s: %w", name, err)
+			}
+			expiries = append(expiries, CredentialExpiry{
+				User:     name,
+				Kind:     CredentialClientCertificate,
+				Subject:  cert.Subject.String(),
+				NotAfter: cert.NotAfter,
+			})
+		}
+		if authInfo.Token != "" {
+			if subject, exp, ok := tokenExpiry(authInfo.Token); ok {
+				expiries = append(expiries, CredentialExpiry{
+					User:     name,
+					Kind:     CredentialToken,
+					Subject:  subject,
+					NotAfter: exp,
+				})
+			}
+		}
+	}
+
+	sort.Slice(expiries, func(i, j int) bool { return expiries[i].NotAfter.Before(expiries[j].NotAfter) })
+	return expiries, nil
+}
+
+// KubeconfigExpiry returns the expiry of the credentials in the kubeconfig
+// secret of a workload cluster
+func (c *Client) KubeconfigExpiry(ctx context.Context, cl *Cluster) ([]CredentialExpiry, error) {
+	kubeconfig, err := c.GetKubeconfig(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	return KubeconfigExpiry(kubeconfig)
+}
+
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// tokenExpiry reads the subject and exp claims of a JWT without verifying
+// it. Tokens that are not JWTs or have no exp claim do not expire.
+func tokenExpiry(token string) (string, time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", time.Time{}, false
+	}
+
+	var claims struct {
+		Subject string `json:"sub"`
+		Expiry  int64  `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Expiry == 0 {
+		return "", time.Time{}, false
+	}
+	return claims.Subject, time.Unix(claims.Expiry, 0), true
+}
+
+// ExpiresWithin returns the credentials expiring before now plus d,
+// including those already expired
+func ExpiresWithin(expiries []CredentialExpiry, now time.Time, d time.Duration) []CredentialExpiry {
+	deadline := now.Add(d)
+	expiring := make([]CredentialExpiry, 0)
+	for _, expiry := range expiries {
+		if expiry.NotAfter.Before(deadline) {
+			expiring = append(expiring, expiry)
+		}
+	}
+	return expiring
+}
@@ -0,0 +1,71 @@
+package cluster
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestKubeconfigExpiry(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "admin"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(10 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	tokenExp := now.Add(2 * 24 * time.Hour)
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":"system:serviceaccount:default:ci","exp":%d}`, tokenExp.Unix())))
+	token := "e30." + payload + ".sig"
+
+	kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+users:
+- name: cert
+  user:
+    client-certificate-data: %s
+- name: jwt
+  user:
+    token: %s
+- name: static
+  user:
+    token: not-a-jwt
+`, base64.StdEncoding.EncodeToString(certPEM), token)
+
+	expiries, err := KubeconfigExpiry([]byte(kubeconfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expiries) != 2 {
+		t.Fatalf("got %d expiries, want 2: %+v", len(expiries), expiries)
+	}
+	if expiries[0].Kind != CredentialToken || !expiries[0].NotAfter.Equal(tokenExp) || expiries[0].Subject != "system:serviceaccount:default:ci" {
+		t.Errorf("first expiry = %+v, want the token", expiries[0])
+	}
+	if expiries[1].Kind != CredentialClientCertificate || !expiries[1].NotAfter.Equal(template.NotAfter) || expiries[1].Subject != "CN=admin" {
+		t.Errorf("second expiry = %+v, want the client certificate", expiries[1])
+	}
+
+	if got := ExpiresWithin(expiries, now, 7*24*time.Hour); len(got) != 1 || got[0].User != "jwt" {
+		t.Errorf("ExpiresWithin 7 days = %+v, want the token", got)
+	}
+}
@@ -0,0 +1,347 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/tracing"
+)
+
+// HealthLevel is the color-coded state of a health check
+type HealthLevel string
+
+const (
+	HealthGreen  HealthLevel = "green"
+	HealthYellow HealthLevel = "yellow"
+	HealthRed    HealthLevel = "red"
+)
+
+// HealthCheck is the health of a single cluster component
+type HealthCheck struct {
+	Component string
+	Level     HealthLevel
+	Summary   string
+	Details   []string
+}
+
+// HealthReport aggregates the health of all components of a cluster
+type HealthReport struct {
+	Cluster    string
+	Overall    HealthLevel
+	Checks     []HealthCheck
+	RootCauses []string
+}
+
+// NodeHealth is the health of a single workload cluster node
+type NodeHealth struct {
+	Name          string
+	Ready         bool
+	Unschedulable bool
+	Pressure      []string
+}
+
+// HealthInput holds everything collected for a cluster health report.
+// Errors are kept next to the data so a partial report can still be built.
+type HealthInput struct {
+	Cluster               *Cluster
+	ControlPlane          *ControlPlane
+	ControlPlaneErr       error
+	MachineDeployments    []*MachineDeployment
+	MachineDeploymentsErr error
+	Nodes                 []NodeHealth
+	NodesErr              error
+}
+
+// CheckHealth collects the CAPI and node state of a cluster and builds a health report
+func (c *Client) CheckHealth(ctx context.Context, cl *Cluster) *HealthReport {
+	input := HealthInput{Cluster: cl}
+
+	input.ControlPlane, input.ControlPlaneErr = c.GetControlPlane(ctx, cl)
+	input.MachineDeployments, input.MachineDeploymentsErr = c.ListMachineDeployments(ctx, cl)
+	input.Nodes, input.NodesErr = c.listNodeHealth(ctx, cl)
+
+	return BuildHealthReport(input)
+}
+
+// WorkloadClient creates a Kubernetes client for a workload cluster from its
+// kubeconfig secret, or takes it from the client pool if one is set
+func (c *Client) WorkloadClient(ctx context.Context, cl *Cluster) (kubernetes.Interface, error) {
+	if c.pool != nil {
+		client, err := c.pool.Get(ctx, cl)
+		if err != nil {
+			return nil, err
+		}
+		return client.Interface, nil
+	}
+
+	restConfig, err := c.WorkloadConfig(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for cluster %s: %w", cl.Name, err)
+	}
+
+	return clientset, nil
+}
+
+// WorkloadConfig returns the REST config of a workload cluster, for clients
+// other than the typed one, e.g. a dynamic client for custom resources
+func (c *Client) WorkloadConfig(ctx context.Context, cl *Cluster) (*rest.Config, error) {
+	if c.pool != nil {
+		client, err := c.pool.Get(ctx, cl)
+		if err != nil {
+			return nil, err
+		}
+		return client.RestConfig, nil
+	}
+
+	kubeconfig, err := c.GetKubeconfig(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig for cluster %s: %w", cl.Name, err)
+	}
+	restConfig.Wrap(k8s.ImpersonateFromContext)
+	restConfig.Wrap(tracing.WrapTransport)
+
+	return restConfig, nil
+}
+
+// listNodeHealth reads node conditions from the workload cluster
+func (c *Client) listNodeHealth(ctx context.Context, cl *Cluster) ([]NodeHealth, error) {
+	workloadClient, err := c.WorkloadClient(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := workloadClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes in cluster %s: %w", cl.Name, err)
+	}
+
+	result := make([]NodeHealth, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		nh := NodeHealth{
+			Name:          node.Name,
+			Unschedulable: node.Spec.Unschedulable,
+		}
+		for _, cond := range node.Status.Conditions {
+			switch cond.Type {
+			case corev1.NodeReady:
+				nh.Ready = cond.Status == corev1.ConditionTrue
+			case corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure, corev1.NodeNetworkUnavailable:
+				if cond.Status == corev1.ConditionTrue {
+					nh.Pressure = append(nh.Pressure, string(cond.Type))
+				}
+			}
+		}
+		result = append(result, nh)
+	}
+
+	return result, nil
+}
+
+// BuildHealthReport evaluates the collected cluster state
+func BuildHealthReport(input HealthInput) *HealthReport {
+	cl := input.Cluster
+	report := &HealthReport{Cluster: cl.Name}
+
+	report.add(clusterHealthCheck(cl, report))
+	report.add(controlPlaneHealthCheck(input.ControlPlane, input.ControlPlaneErr, report))
+	report.add(machineDeploymentsHealthCheck(input.MachineDeployments, input.MachineDeploymentsErr, report))
+	report.add(nodesHealthCheck(input.Nodes, input.NodesErr, report))
+
+	return report
+}
+
+// add appends a check and raises the overall level if needed
+func (r *HealthReport) add(check HealthCheck) {
+	r.Checks = append(r.Checks, check)
+	if r.Overall == "" || severity(check.Level) > severity(r.Overall) {
+		r.Overall = check.Level
+	}
+}
+
+func (r *HealthReport) addRootCause(format string, args ...interface{}) {
+	r.RootCauses = append(r.RootCauses, fmt.Sprintf(format, args...))
+}
+
+func severity(level HealthLevel) int {
+	switch level {
+	case HealthRed:
+		return 2
+	case HealthYellow:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func clusterHealthCheck(cl *Cluster, report *HealthReport) HealthCheck {
+	check := HealthCheck{Component: "Cluster", Level: HealthGreen, Summary: fmt.Sprintf("phase %s", cl.Status.Phase)}
+
+	switch cl.Status.Phase {
+	case "Failed":
+		check.Level = HealthRed
+	case "Provisioned":
+	default:
+		check.Level = HealthYellow
+	}
+
+	if !cl.Status.InfrastructureReady {
+		check.Level = HealthRed
+		check.Details = append(check.Details, "infrastructure not ready")
+		if ref := cl.Spec.InfrastructureRef; ref != nil {
+			report.addRootCause("Infrastructure is not ready; check the conditions of %s %s", ref.Kind, ref.Name)
+		} else {
+			report.addRootCause("Infrastructure is not ready")
+		}
+	}
+
+	for _, cond := range cl.Status.Conditions {
+		if cond.Status != "False" {
+			continue
+		}
+		detail := fmt.Sprintf("%s is False", cond.Type)
+		if cond.Reason != "" {
+			detail += fmt.Sprintf(" (%s)", cond.Reason)
+		}
+		if cond.Message != "" {
+			detail += ": " + cond.Message
+		}
+		check.Details = append(check.Details, detail)
+		if cond.Type == "Ready" {
+			check.Level = HealthRed
+		} else if check.Level == HealthGreen {
+			check.Level = HealthYellow
+		}
+	}
+
+	return check
+}
+
+func controlPlaneHealthCheck(cp *ControlPlane, err error, report *HealthReport) HealthCheck {
+	check := HealthCheck{Component: "Control Plane"}
+	if err != nil {
+		check.Level = HealthYellow
+		check.Summary = "unknown"
+		check.Details = []string{err.Error()}
+		return check
+	}
+
+	check.Level = HealthGreen
+	check.Summary = fmt.Sprintf("%d/%d ready, version %s", cp.Status.ReadyReplicas, cp.DesiredReplicas, cp.Version)
+
+	if !cp.Initialized {
+		check.Level = HealthRed
+		report.addRootCause("Control plane %s has not been initialized; check the bootstrap of the first control plane machine", cp.Name)
+	} else if cp.Status.ReadyReplicas == 0 {
+		check.Level = HealthRed
+		report.addRootCause("No control plane machine of %s is ready; the API server is likely unreachable", cp.Name)
+	} else if cp.Status.ReadyReplicas < cp.DesiredReplicas {
+		check.Level = HealthYellow
+		report.addRootCause("Control plane %s has %d of %d machines ready", cp.Name, cp.Status.ReadyReplicas, cp.DesiredReplicas)
+	}
+
+	if cp.Status.UpdatedReplicas < cp.Status.Replicas {
+		if check.Level == HealthGreen {
+			check.Level = HealthYellow
+		}
+		check.Details = append(check.Details, fmt.Sprintf("rollout in progress: %d/%d machines updated", cp.Status.UpdatedReplicas, cp.Status.Replicas))
+	}
+
+	for _, cond := range cp.Conditions {
+		if cond.Status == "False" {
+			check.Details = append(check.Details, fmt.Sprintf("%s is False (%s) %s", cond.Type, cond.Reason, cond.Message))
+		}
+	}
+
+	return check
+}
+
+func machineDeploymentsHealthCheck(deployments []*MachineDeployment, err error, report *HealthReport) HealthCheck {
+	check := HealthCheck{Component: "Worker Nodes"}
+	if err != nil {
+		check.Level = HealthYellow
+		check.Summary = "unknown"
+		check.Details = []string{err.Error()}
+		return check
+	}
+
+	check.Level = HealthGreen
+	var ready, desired int64
+	for _, md := range deployments {
+		ready += md.Status.ReadyReplicas
+		desired += md.DesiredReplicas
+
+		switch {
+		case md.Phase == "Failed":
+			check.Level = HealthRed
+			check.Details = append(check.Details, fmt.Sprintf("%s: phase Failed", md.Name))
+			report.addRootCause("MachineDeployment %s failed; check its machines with cluster_machines", md.Name)
+		case md.DesiredReplicas > 0 && md.Status.ReadyReplicas == 0:
+			check.Level = HealthRed
+			check.Details = append(check.Details, fmt.Sprintf("%s: no ready replicas", md.Name))
+			report.addRootCause("MachineDeployment %s has no ready machines; nodes may fail to join the cluster", md.Name)
+		case md.Status.ReadyReplicas < md.DesiredReplicas:
+			if check.Level == HealthGreen {
+				check.Level = HealthYellow
+			}
+			check.Details = append(check.Details, fmt.Sprintf("%s: %d/%d ready (phase %s)",
+				md.Name, md.Status.ReadyReplicas, md.DesiredReplicas, md.Phase))
+		}
+	}
+
+	check.Summary = fmt.Sprintf("%d MachineDeployments, %d/%d replicas ready", len(deployments), ready, desired)
+	return check
+}
+
+func nodesHealthCheck(nodes []NodeHealth, err error, report *HealthReport) HealthCheck {
+	check := HealthCheck{Component: "Nodes"}
+	if err != nil {
+		check.Level = HealthYellow
+		check.Summary = "unknown"
+		check.Details = []string{err.Error()}
+		return check
+	}
+
+	check.Level = HealthGreen
+	notReady := make([]string, 0)
+	for _, node := range nodes {
+		if !node.Ready {
+			notReady = append(notReady, node.Name)
+		}
+		if len(node.Pressure) > 0 {
+			if check.Level == HealthGreen {
+				check.Level = HealthYellow
+			}
+			check.Details = append(check.Details, fmt.Sprintf("%s: %s", node.Name, strings.Join(node.Pressure, ", ")))
+		}
+		if node.Unschedulable {
+			check.Details = append(check.Details, fmt.Sprintf("%s: cordoned", node.Name))
+		}
+	}
+
+	if len(notReady) > 0 {
+		check.Level = HealthRed
+		check.Details = append(check.Details, fmt.Sprintf("not ready: %s", strings.Join(notReady, ", ")))
+		report.addRootCause("%d of %d nodes are not ready; check kubelet and CNI on those nodes", len(notReady), len(nodes))
+	}
+
+	check.Summary = fmt.Sprintf("%d/%d ready", len(nodes)-len(notReady), len(nodes))
+	return check
+}
@@ -0,0 +1,71 @@
+package cluster
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuildHealthReport(t *testing.T) {
+	healthy := &Cluster{
+		Name: "prod",
+		Status: ClusterStatus{
+			Phase:               "Provisioned",
+			InfrastructureReady: true,
+			ControlPlaneReady:   true,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		input      HealthInput
+		overall    HealthLevel
+		rootCauses int
+	}{
+		{
+			name: "healthy cluster",
+			input: HealthInput{
+				Cluster:      healthy,
+				ControlPlane: &ControlPlane{Name: "prod", Initialized: true, DesiredReplicas: 3, Status: ReplicaStatus{Replicas: 3, ReadyReplicas: 3, UpdatedReplicas: 3}},
+				MachineDeployments: []*MachineDeployment{
+					{Name: "prod-md", DesiredReplicas: 2, Phase: "Running", Status: ReplicaStatus{ReadyReplicas: 2}},
+				},
+				Nodes: []NodeHealth{{Name: "a", Ready: true}, {Name: "b", Ready: true}},
+			},
+			overall: HealthGreen,
+		},
+		{
+			name: "unreachable workload cluster",
+			input: HealthInput{
+				Cluster:      healthy,
+				ControlPlane: &ControlPlane{Name: "prod", Initialized: true, DesiredReplicas: 1, Status: ReplicaStatus{Replicas: 1, ReadyReplicas: 1, UpdatedReplicas: 1}},
+				NodesErr:     errors.New("kubeconfig not found"),
+			},
+			overall: HealthYellow,
+		},
+		{
+			name: "not ready nodes and failed machine deployment",
+			input: HealthInput{
+				Cluster:      healthy,
+				ControlPlane: &ControlPlane{Name: "prod", Initialized: true, DesiredReplicas: 3, Status: ReplicaStatus{Replicas: 3, ReadyReplicas: 2, UpdatedReplicas: 3}},
+				MachineDeployments: []*MachineDeployment{
+					{Name: "prod-md", DesiredReplicas: 2, Phase: "Failed"},
+				},
+				Nodes: []NodeHealth{{Name: "a", Ready: true}, {Name: "b", Ready: false}},
+			},
+			overall:    HealthRed,
+			rootCauses: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := BuildHealthReport(tt.input)
+			if report.Overall != tt.overall {
+				t.Errorf("Overall = %s, want %s", report.Overall, tt.overall)
+			}
+			if len(report.RootCauses) != tt.rootCauses {
+				t.Errorf("RootCauses = %v, want %d entries", report.RootCauses, tt.rootCauses)
+			}
+		})
+	}
+}
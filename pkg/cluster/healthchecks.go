@@ -0,0 +1,278 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// MachineHealthCheckGVR is the GroupVersionResource for CAPI MachineHealthCheck resources
+var MachineHealthCheckGVR = schema.GroupVersionResource{
+	Group:    "cluster.x-k8s.io",
+	Version:  "v1beta1",
+	Resource: "machinehealthchecks",
+}
+
+// Machine conditions set by the MachineHealthCheck controller
+const (
+	// HealthCheckSucceededCondition is false on machines a health check found unhealthy
+	HealthCheckSucceededCondition = "HealthCheckSucceeded"
+	// OwnerRemediatedCondition is false on unhealthy machines waiting for
+	// their owner, e.g. a MachineSet, to replace them
+	OwnerRemediatedCondition = "OwnerRemediated"
+)
+
+// remediationEventReasons are the reasons of the events the MachineHealthCheck
+// controller records on machines it marks unhealthy
+var remediationEventReasons = map[string]bool{
+	"MachineMarkedUnhealthy": true,
+	"DetectedUnhealthy":      true,
+}
+
+// remediationRestrictedReason is the reason of the event recorded on a
+// MachineHealthCheck that refused remediation, e.g. because more machines
+// are unhealthy than maxUnhealthy allows
+const remediationRestrictedReason = "RemediationRestricted"
+
+// MachineHealthCheck represents a CAPI MachineHealthCheck resource
+type MachineHealthCheck struct {
+	Name      string
+	Namespace string
+	// Selector are the labels of the machines the check covers
+	Selector            map[string]string
+	UnhealthyConditions []UnhealthyCondition
+	MaxUnhealthy        string
+	UnhealthyRange      string
+	NodeStartupTimeout  string
+
+	ExpectedMachines    int64
+	CurrentHealthy      int64
+	RemediationsAllowed int64
+	// Targets are the names of the machines the check covers
+	Targets    []string
+	Conditions []Condition
+
+	// Unhealthy are the targets the check found unhealthy
+	Unhealthy []UnhealthyMachine
+	// Restricted is the message of the last event refusing remediation
+	Restricted *RemediationEvent
+}
+
+// UnhealthyCondition is a node condition that makes a machine unhealthy once
+// it has held for the timeout
+type UnhealthyCondition struct {
+	Type    string
+	Status  string
+	Timeout string
+}
+
+// UnhealthyMachine is a machine a health check found unhealthy
+type UnhealthyMachine struct {
+	Name     string
+	NodeName string
+	Reason   string
+	Message  string
+	Since    string
+	// WaitingForRemediation is set until the machine's owner replaces it
+	WaitingForRemediation bool
+}
+
+// RemediationEvent summarizes the events of one kind recorded on an object
+type RemediationEvent struct {
+	Object   string
+	Count    int32
+	LastSeen time.Time
+	Message  string
+}
+
+// MachineHealth is the health checking state of a cluster's machines
+type MachineHealth struct {
+	HealthChecks []*MachineHealthCheck
+	// Remediations are the machines marked unhealthy according to the
+	// events still kept by the API server, most recent first. They include
+	// machines that have since been replaced.
+	Remediations []RemediationEvent
+}
+
+// GetMachineHealth lists the MachineHealthChecks of a cluster with the
+// machines they found unhealthy, and the remediation events of its machines
+func (c *Client) GetMachineHealth(ctx context.Context, cl *Cluster) (*MachineHealth, error) {
+	list, err := c.dynamicClient.Resource(MachineHealthCheckGVR).Namespace(cl.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list machine health checks for cluster %s: %w", cl.Name, err)
+	}
+	machines, err := c.ListMachines(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*Machine, len(machines))
+	for _, m := range machines {
+		byName[m.Name] = m
+	}
+
+	health := &MachineHealth{}
+	for _, item := range list.Items {
+		mhc := NewMachineHealthCheckFromUnstructured(&item)
+		if clusterName, _, _ := unstructured.NestedString(item.Object, "spec", "clusterName"); clusterName != cl.Name {
+			continue
+		}
+		for _, target := range mhc.Targets {
+			if m, ok := byName[target]; ok {
+				if unhealthy, ok := unhealthyMachine(m); ok {
+					mhc.Unhealthy = append(mhc.Unhealthy, unhealthy)
+				}
+			}
+		}
+		health.HealthChecks = append(health.HealthChecks, mhc)
+	}
+	sort.Slice(health.HealthChecks, func(i, j int) bool { return health.HealthChecks[i].Name < health.HealthChecks[j].Name })
+
+	events, err := c.k8sClient.CoreV1().Events(cl.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events for cluster %s: %w", cl.Name, err)
+	}
+	health.Remediations = remediationEvents(events.Items, cl, byName, health.HealthChecks)
+	return health, nil
+}
+
+// unhealthyMachine reports whether a health check marked a machine unhealthy
+func unhealthyMachine(m *Machine) (UnhealthyMachine, bool) {
+	var unhealthy UnhealthyMachine
+	found := false
+	for _, cond := range m.Conditions {
+		switch {
+		case cond.Type == HealthCheckSucceededCondition && cond.Status == "False":
+			unhealthy = UnhealthyMachine{
+				Name:     m.Name,
+				NodeName: m.NodeName,
+				Reason:   cond.Reason,
+				Message:  cond.Message,
+				Since:    cond.LastTransitionTime,
+			}
+			found = true
+		case cond.Type == OwnerRemediatedCondition && cond.Status == "False":
+			unhealthy.WaitingForRemediation = true
+		}
+	}
+	if !found {
+		return UnhealthyMachine{}, false
+	}
+	return unhealthy, true
+}
+
+// remediationEvents aggregates the events marking the cluster's machines
+// unhealthy per machine, and records the last event restricting remediation
+// on each health check. Events of machines that no longer exist are matched
+// by the cluster name prefix CAPI gives machine names.
+func remediationEvents(events []corev1.Event, cl *Cluster, machines map[string]*Machine, checks []*MachineHealthCheck) []RemediationEvent {
+	byCheck := make(map[string]*MachineHealthCheck, len(checks))
+	for _, mhc := range checks {
+		byCheck[mhc.Name] = mhc
+	}
+
+	byMachine := make(map[string]*RemediationEvent)
+	for _, event := range events {
+		object := event.InvolvedObject
+		lastSeen := eventTime(event)
+
+		if object.Kind == "MachineHealthCheck" && event.Reason == remediationRestrictedReason {
+			if mhc, ok := byCheck[object.Name]; ok && (mhc.Restricted == nil || lastSeen.After(mhc.Restricted.LastSeen)) {
+				mhc.Restricted = &RemediationEvent{Object: object.Name, Count: eventCount(event), LastSeen: lastSeen, Message: event.Message}
+			}
+			continue
+		}
+		if object.Kind != "Machine" || !remediationEventReasons[event.Reason] {
+			continue
+		}
+		if _, ok := machines[object.Name]; !ok && !strings.HasPrefix(object.Name, cl.Name+"-") {
+			continue
+		}
+
+		remediation, ok := byMachine[object.Name]
+		if !ok {
+			remediation = &RemediationEvent{Object: object.Name}
+			byMachine[object.Name] = remediation
+		}
+		remediation.Count += eventCount(event)
+		if lastSeen.After(remediation.LastSeen) {
+			remediation.LastSeen = lastSeen
+			remediation.Message = event.Message
+		}
+	}
+
+	remediations := make([]RemediationEvent, 0, len(byMachine))
+	for _, remediation := range byMachine {
+		remediations = append(remediations, *remediation)
+	}
+	sort.Slice(remediations, func(i, j int) bool {
+		if !remediations[i].LastSeen.Equal(remediations[j].LastSeen) {
+			return remediations[i].LastSeen.After(remediations[j].LastSeen)
+		}
+		return remediations[i].Object < remediations[j].Object
+	})
+	return remediations
+}
+
+// eventTime returns when an event was last seen
+func eventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// eventCount returns how often an event occurred
+func eventCount(event corev1.Event) int32 {
+	if event.Series != nil && event.Series.Count > 0 {
+		return event.Series.Count
+	}
+	if event.Count > 0 {
+		return event.Count
+	}
+	return 1
+}
+
+// NewMachineHealthCheckFromUnstructured converts an unstructured object to a MachineHealthCheck
+func NewMachineHealthCheckFromUnstructured(obj *unstructured.Unstructured) *MachineHealthCheck {
+	mhc := &MachineHealthCheck{
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+	}
+
+	mhc.Selector, _, _ = unstructured.NestedStringMap(obj.Object, "spec", "selector", "matchLabels")
+	if maxUnhealthy, found, _ := unstructured.NestedFieldNoCopy(obj.Object, "spec", "maxUnhealthy"); found {
+		mhc.MaxUnhealthy = fmt.Sprint(maxUnhealthy)
+	}
+	mhc.UnhealthyRange, _, _ = unstructured.NestedString(obj.Object, "spec", "unhealthyRange")
+	mhc.NodeStartupTimeout, _, _ = unstructured.NestedString(obj.Object, "spec", "nodeStartupTimeout")
+	if conditions, found, _ := unstructured.NestedSlice(obj.Object, "spec", "unhealthyConditions"); found {
+		for _, c := range conditions {
+			if cond, ok := c.(map[string]interface{}); ok {
+				typ, _ := cond["type"].(string)
+				status, _ := cond["status"].(string)
+				timeout, _ := cond["timeout"].(string)
+				mhc.UnhealthyConditions = append(mhc.UnhealthyConditions, UnhealthyCondition{Type: typ, Status: status, Timeout: timeout})
+			}
+		}
+	}
+
+	mhc.ExpectedMachines, _, _ = unstructured.NestedInt64(obj.Object, "status", "expectedMachines")
+	mhc.CurrentHealthy, _, _ = unstructured.NestedInt64(obj.Object, "status", "currentHealthy")
+	mhc.RemediationsAllowed, _, _ = unstructured.NestedInt64(obj.Object, "status", "remediationsAllowed")
+	mhc.Targets, _, _ = unstructured.NestedStringSlice(obj.Object, "status", "targets")
+	mhc.Conditions = parseStatusConditions(obj)
+
+	return mhc
+}
@@ -0,0 +1,93 @@
+package cluster_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	gstesting "github.com/giantswarm/mcp-giantswarm-apps/pkg/testing"
+)
+
+func TestGetMachineHealth(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+
+	mhc := gstesting.Object("cluster.x-k8s.io/v1beta1", "MachineHealthCheck", "org-acme", "prod-workers", map[string]interface{}{
+		"clusterName":        "prod",
+		"maxUnhealthy":       "40%",
+		"nodeStartupTimeout": "20m0s",
+		"selector":           map[string]interface{}{"matchLabels": map[string]interface{}{"cluster.x-k8s.io/deployment-name": "prod-md"}},
+		"unhealthyConditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "Unknown", "timeout": "5m0s"},
+		},
+	})
+	mhc.Object["status"] = map[string]interface{}{
+		"expectedMachines":    int64(3),
+		"currentHealthy":      int64(2),
+		"remediationsAllowed": int64(0),
+		"targets":             []interface{}{"prod-md-a", "prod-md-b", "prod-md-c"},
+	}
+	other := gstesting.Object("cluster.x-k8s.io/v1beta1", "MachineHealthCheck", "org-acme", "staging-workers", map[string]interface{}{"clusterName": "staging"})
+
+	dynamicClient := gstesting.NewDynamicClient(mhc, other,
+		gstesting.Machine("org-acme", "prod", "prod-md-a"),
+		gstesting.Machine("org-acme", "prod", "prod-md-b",
+			cluster.Condition{Type: cluster.HealthCheckSucceededCondition, Status: "False", Reason: "UnhealthyNode", Message: "Condition Ready on node is reporting status Unknown for more than 5m0s", LastTransitionTime: "2026-01-05T09:50:00Z"},
+			cluster.Condition{Type: cluster.OwnerRemediatedCondition, Status: "False", Reason: "WaitingForRemediation"},
+		),
+		gstesting.Machine("org-acme", "prod", "prod-md-c"),
+	)
+	k8sClient := fake.NewClientset(
+		gstesting.Event("org-acme", "b1", "Machine", "prod-md-b", "MachineMarkedUnhealthy", "marked unhealthy", 1, now.Add(-10*time.Minute)),
+		// A machine that was already replaced
+		gstesting.Event("org-acme", "x1", "Machine", "prod-md-x", "MachineMarkedUnhealthy", "old message", 2, now.Add(-40*time.Minute)),
+		gstesting.Event("org-acme", "x2", "Machine", "prod-md-x", "DetectedUnhealthy", "new message", 1, now.Add(-30*time.Minute)),
+		gstesting.Event("org-acme", "s1", "Machine", "staging-md-a", "MachineMarkedUnhealthy", "other cluster", 1, now),
+		gstesting.Event("org-acme", "b2", "Machine", "prod-md-b", "Created", "unrelated", 1, now),
+		gstesting.Event("org-acme", "r1", "MachineHealthCheck", "prod-workers", "RemediationRestricted", "Remediation restricted due to exceeded number of unhealthy machines", 3, now.Add(-5*time.Minute)),
+	)
+	client := cluster.NewClient(k8s.NewDynamicClientForInterface(dynamicClient), k8sClient, nil)
+
+	health, err := client.GetMachineHealth(ctx, &cluster.Cluster{Name: "prod", Namespace: "org-acme"})
+	if err != nil {
+		t.Fatalf("GetMachineHealth() error = %v", err)
+	}
+
+	if len(health.HealthChecks) != 1 {
+		t.Fatalf("got %d health checks, want 1", len(health.HealthChecks))
+	}
+	check := health.HealthChecks[0]
+	if check.Name != "prod-workers" || check.MaxUnhealthy != "40%" || check.NodeStartupTimeout != "20m0s" || check.ExpectedMachines != 3 || check.CurrentHealthy != 2 {
+		t.Errorf("health check = %+v", check)
+	}
+	if check.Selector["cluster.x-k8s.io/deployment-name"] != "prod-md" || len(check.UnhealthyConditions) != 1 || check.UnhealthyConditions[0].Timeout != "5m0s" {
+		t.Errorf("spec = %+v", check)
+	}
+	if len(check.Unhealthy) != 1 || check.Unhealthy[0].Name != "prod-md-b" || check.Unhealthy[0].NodeName != "prod-md-b-node" || !check.Unhealthy[0].WaitingForRemediation {
+		t.Errorf("unhealthy = %+v", check.Unhealthy)
+	}
+	if check.Restricted == nil || check.Restricted.Count != 3 {
+		t.Errorf("restricted = %+v", check.Restricted)
+	}
+
+	if len(health.Remediations) != 2 {
+		t.Fatalf("remediations = %+v", health.Remediations)
+	}
+	if got := health.Remediations[0]; got.Object != "prod-md-b" || got.Count != 1 {
+		t.Errorf("first remediation = %+v", got)
+	}
+	if got := health.Remediations[1]; got.Object != "prod-md-x" || got.Count != 3 || got.Message != "new message" || !got.LastSeen.Equal(now.Add(-30*time.Minute)) {
+		t.Errorf("second remediation = %+v", got)
+	}
+}
+
+func TestMachineHealthCheckIntMaxUnhealthy(t *testing.T) {
+	obj := gstesting.Object("cluster.x-k8s.io/v1beta1", "MachineHealthCheck", "org-acme", "prod", map[string]interface{}{"maxUnhealthy": int64(2)})
+	if mhc := cluster.NewMachineHealthCheckFromUnstructured(obj); mhc.MaxUnhealthy != "2" {
+		t.Errorf("MaxUnhealthy = %q, want 2", mhc.MaxUnhealthy)
+	}
+}
@@ -0,0 +1,148 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// InfrastructureDetail is a single provider-specific property of a cluster's infrastructure
+type InfrastructureDetail struct {
+	Name  string
+	Value string
+}
+
+// Infrastructure holds the resolved infrastructure object of a cluster
+type Infrastructure struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Ready     bool
+	// Provider is the short name of the provider adapter that read the
+	// object, and Region, Network and CIDRs what it found; they are empty
+	// for kinds without an adapter
+	Provider             string
+	Region               string
+	Network              string
+	CIDRs                []string
+	ControlPlaneEndpoint string
+	FailureDomains       []string
+	Details              []InfrastructureDetail
+	Conditions           []Condition
+}
+
+// infrastructureField maps a path in the infrastructure object to a display name
+type infrastructureField struct {
+	name string
+	path []string
+}
+
+// providerFields lists the fields surfaced for infrastructure kinds that
+// have no provider adapter
+var providerFields = map[string][]infrastructureField{
+	"GCPCluster": {
+		{"Project", []string{"spec", "project"}},
+		{"Region", []string{"spec", "region"}},
+		{"Network", []string{"spec", "network", "name"}},
+	},
+	"OpenStackCluster": {
+		{"Cloud", []string{"spec", "identityRef", "cloudName"}},
+		{"External Network", []string{"spec", "externalNetwork", "id"}},
+		{"Bastion Enabled", []string{"spec", "bastion", "enabled"}},
+		{"Bastion Floating IP", []string{"status", "bastion", "floatingIP"}},
+	},
+}
+
+// GetInfrastructure follows the infrastructureRef of a cluster and returns the provider object
+func (c *Client) GetInfrastructure(ctx context.Context, cl *Cluster) (*Infrastructure, error) {
+	ref := cl.Spec.InfrastructureRef
+	if ref == nil {
+		return nil, fmt.Errorf("cluster %s has no infrastructure reference", cl.Name)
+	}
+
+	gvr, err := infrastructureGVR(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = cl.Namespace
+	}
+
+	obj, err := c.dynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s/%s: %w", ref.Kind, namespace, ref.Name, err)
+	}
+
+	return NewInfrastructureFromUnstructured(obj), nil
+}
+
+// infrastructureGVR derives the resource of an infrastructure reference,
+// following the CAPI convention of lowercase plural kinds
+func infrastructureGVR(ref *ObjectReference) (schema.GroupVersionResource, error) {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid infrastructure apiVersion %q: %w", ref.APIVersion, err)
+	}
+	if ref.Kind == "" {
+		return schema.GroupVersionResource{}, fmt.Errorf("infrastructure reference has no kind")
+	}
+
+	return gv.WithResource(strings.ToLower(ref.Kind) + "s"), nil
+}
+
+// NewInfrastructureFromUnstructured converts an infrastructure provider object
+func NewInfrastructureFromUnstructured(obj *unstructured.Unstructured) *Infrastructure {
+	infra := &Infrastructure{
+		Kind:      obj.GetKind(),
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+	}
+
+	infra.Ready, _, _ = unstructured.NestedBool(obj.Object, "status", "ready")
+	infra.Conditions = parseStatusConditions(obj)
+
+	host, _, _ := unstructured.NestedString(obj.Object, "spec", "controlPlaneEndpoint", "host")
+	port, _, _ := unstructured.NestedInt64(obj.Object, "spec", "controlPlaneEndpoint", "port")
+	if host != "" {
+		infra.ControlPlaneEndpoint = host
+		if port != 0 {
+			infra.ControlPlaneEndpoint = fmt.Sprintf("%s:%d", host, port)
+		}
+	}
+
+	if domains, found, _ := unstructured.NestedMap(obj.Object, "status", "failureDomains"); found {
+		for name := range domains {
+			infra.FailureDomains = append(infra.FailureDomains, name)
+		}
+		sort.Strings(infra.FailureDomains)
+	}
+
+	if adapter, ok := ProviderAdapterFor(infra.Kind); ok {
+		details := adapter.ClusterDetails(obj)
+		infra.Provider = adapter.Names()[0]
+		infra.Region = details.Region
+		infra.Network = details.Network
+		infra.CIDRs = details.CIDRs
+		infra.Details = details.Details
+		return infra
+	}
+
+	for _, field := range providerFields[infra.Kind] {
+		value, found, err := unstructured.NestedFieldNoCopy(obj.Object, field.path...)
+		if err != nil || !found || value == nil {
+			continue
+		}
+		if s := fmt.Sprintf("%v", value); s != "" {
+			infra.Details = append(infra.Details, InfrastructureDetail{Name: field.name, Value: s})
+		}
+	}
+
+	return infra
+}
@@ -0,0 +1,66 @@
+package cluster
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNewInfrastructureFromUnstructured(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta2",
+		"kind":       "AWSCluster",
+		"metadata":   map[string]interface{}{"name": "prod", "namespace": "org-acme"},
+		"spec": map[string]interface{}{
+			"region": "eu-west-1",
+			"network": map[string]interface{}{
+				"vpc": map[string]interface{}{"id": "vpc-123"},
+			},
+			"bastion":              map[string]interface{}{"enabled": true},
+			"controlPlaneEndpoint": map[string]interface{}{"host": "api.prod.example.com", "port": int64(443)},
+		},
+		"status": map[string]interface{}{
+			"ready": true,
+			"failureDomains": map[string]interface{}{
+				"eu-west-1b": map[string]interface{}{},
+				"eu-west-1a": map[string]interface{}{},
+			},
+		},
+	}}
+
+	infra := NewInfrastructureFromUnstructured(obj)
+
+	if !infra.Ready {
+		t.Error("expected infrastructure to be ready")
+	}
+	if infra.ControlPlaneEndpoint != "api.prod.example.com:443" {
+		t.Errorf("ControlPlaneEndpoint = %q", infra.ControlPlaneEndpoint)
+	}
+	if len(infra.FailureDomains) != 2 || infra.FailureDomains[0] != "eu-west-1a" {
+		t.Errorf("FailureDomains = %v", infra.FailureDomains)
+	}
+
+	if infra.Provider != "capa" || infra.Region != "eu-west-1" || infra.Network != "vpc-123" {
+		t.Errorf("Provider, Region, Network = %q, %q, %q", infra.Provider, infra.Region, infra.Network)
+	}
+
+	want := map[string]string{"Bastion Enabled": "true"}
+	if len(infra.Details) != len(want) {
+		t.Fatalf("Details = %v", infra.Details)
+	}
+	for _, d := range infra.Details {
+		if want[d.Name] != d.Value {
+			t.Errorf("%s = %q, want %q", d.Name, d.Value, want[d.Name])
+		}
+	}
+}
+
+func TestInfrastructureGVR(t *testing.T) {
+	gvr, err := infrastructureGVR(&ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1", Kind: "AzureCluster"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gvr.Resource != "azureclusters" || gvr.Group != "infrastructure.cluster.x-k8s.io" {
+		t.Errorf("unexpected GVR %v", gvr)
+	}
+}
@@ -0,0 +1,243 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// ClusterNameLabel is set by CAPI on all resources belonging to a cluster
+	ClusterNameLabel = "cluster.x-k8s.io/cluster-name"
+
+	// MachineDeploymentNameLabel is set by CAPI on machines created by a MachineDeployment
+	MachineDeploymentNameLabel = "cluster.x-k8s.io/deployment-name"
+
+	// ControlPlaneLabel is set by CAPI on control plane machines
+	ControlPlaneLabel = "cluster.x-k8s.io/control-plane"
+)
+
+// MachineGVR is the GroupVersionResource for CAPI Machine resources
+var MachineGVR = schema.GroupVersionResource{
+	Group:    "cluster.x-k8s.io",
+	Version:  "v1beta1",
+	Resource: "machines",
+}
+
+// MachineDeploymentGVR is the GroupVersionResource for CAPI MachineDeployment resources
+var MachineDeploymentGVR = schema.GroupVersionResource{
+	Group:    "cluster.x-k8s.io",
+	Version:  "v1beta1",
+	Resource: "machinedeployments",
+}
+
+// KubeadmControlPlaneGVR is the GroupVersionResource for KubeadmControlPlane resources
+var KubeadmControlPlaneGVR = schema.GroupVersionResource{
+	Group:    "controlplane.cluster.x-k8s.io",
+	Version:  "v1beta1",
+	Resource: "kubeadmcontrolplanes",
+}
+
+// ReplicaStatus holds the replica counters shared by CAPI scalable resources
+type ReplicaStatus struct {
+	Replicas            int64
+	ReadyReplicas       int64
+	UpdatedReplicas     int64
+	AvailableReplicas   int64
+	UnavailableReplicas int64
+}
+
+// MachineDeployment represents a CAPI MachineDeployment resource
+type MachineDeployment struct {
+	Name            string
+	Namespace       string
+	ClusterName     string
+	Version         string
+	DesiredReplicas int64
+	Phase           string
+	Status          ReplicaStatus
+	Conditions      []Condition
+}
+
+// Machine represents a CAPI Machine resource
+type Machine struct {
+	Name              string
+	Namespace         string
+	MachineDeployment string
+	ControlPlane      bool
+	Version           string
+	ProviderID        string
+	NodeName          string
+	Phase             string
+	FailureReason     string
+	FailureMessage    string
+	Conditions        []Condition
+}
+
+// ControlPlane represents a KubeadmControlPlane resource
+type ControlPlane struct {
+	Name            string
+	Namespace       string
+	Version         string
+	DesiredReplicas int64
+	Initialized     bool
+	Ready           bool
+	Status          ReplicaStatus
+	Conditions      []Condition
+}
+
+// ListMachineDeployments lists the MachineDeployments of a cluster
+func (c *Client) ListMachineDeployments(ctx context.Context, cl *Cluster) ([]*MachineDeployment, error) {
+	list, err := c.dynamicClient.Resource(MachineDeploymentGVR).Namespace(cl.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", ClusterNameLabel, cl.Name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list machine deployments for cluster %s: %w", cl.Name, err)
+	}
+
+	deployments := make([]*MachineDeployment, 0, len(list.Items))
+	for _, item := range list.Items {
+		deployments = append(deployments, NewMachineDeploymentFromUnstructured(&item))
+	}
+
+	return deployments, nil
+}
+
+// ListMachines lists the Machines of a cluster
+func (c *Client) ListMachines(ctx context.Context, cl *Cluster) ([]*Machine, error) {
+	list, err := c.dynamicClient.Resource(MachineGVR).Namespace(cl.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", ClusterNameLabel, cl.Name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list machines for cluster %s: %w", cl.Name, err)
+	}
+
+	machines := make([]*Machine, 0, len(list.Items))
+	for _, item := range list.Items {
+		machines = append(machines, NewMachineFromUnstructured(&item))
+	}
+
+	return machines, nil
+}
+
+// IsFailed reports whether a machine failed or is not running
+func (m *Machine) IsFailed() bool {
+	return m.Phase != "Running" || m.FailureReason != ""
+}
+
+// FilterMachines keeps the machines of a MachineDeployment (all when empty)
+// and, with failedOnly, the machines that failed or are not running
+func FilterMachines(machines []*Machine, machineDeployment string, failedOnly bool) []*Machine {
+	filtered := make([]*Machine, 0, len(machines))
+	for _, m := range machines {
+		if machineDeployment != "" && m.MachineDeployment != machineDeployment {
+			continue
+		}
+		if failedOnly && !m.IsFailed() {
+			continue
+		}
+		filtered = append(filtered, m)
+	}
+	return filtered
+}
+
+// GetControlPlane retrieves the KubeadmControlPlane referenced by a cluster
+func (c *Client) GetControlPlane(ctx context.Context, cl *Cluster) (*ControlPlane, error) {
+	ref := cl.Spec.ControlPlaneRef
+	if ref == nil {
+		return nil, fmt.Errorf("cluster %s has no control plane reference", cl.Name)
+	}
+	if ref.Kind != "KubeadmControlPlane" {
+		return nil, fmt.Errorf("unsupported control plane kind %s", ref.Kind)
+	}
+
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = cl.Namespace
+	}
+
+	obj, err := c.dynamicClient.Resource(KubeadmControlPlaneGVR).Namespace(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get control plane %s/%s: %w", namespace, ref.Name, err)
+	}
+
+	return NewControlPlaneFromUnstructured(obj), nil
+}
+
+// NewMachineDeploymentFromUnstructured converts an unstructured object to a MachineDeployment
+func NewMachineDeploymentFromUnstructured(obj *unstructured.Unstructured) *MachineDeployment {
+	md := &MachineDeployment{
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+	}
+
+	md.ClusterName, _, _ = unstructured.NestedString(obj.Object, "spec", "clusterName")
+	md.Version, _, _ = unstructured.NestedString(obj.Object, "spec", "template", "spec", "version")
+	md.DesiredReplicas, _, _ = unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	md.Phase, _, _ = unstructured.NestedString(obj.Object, "status", "phase")
+	md.Status = parseReplicaStatus(obj)
+	md.Conditions = parseStatusConditions(obj)
+
+	return md
+}
+
+// NewMachineFromUnstructured converts an unstructured object to a Machine
+func NewMachineFromUnstructured(obj *unstructured.Unstructured) *Machine {
+	labels := obj.GetLabels()
+	_, isControlPlane := labels[ControlPlaneLabel]
+
+	m := &Machine{
+		Name:              obj.GetName(),
+		Namespace:         obj.GetNamespace(),
+		MachineDeployment: labels[MachineDeploymentNameLabel],
+		ControlPlane:      isControlPlane,
+	}
+
+	m.Version, _, _ = unstructured.NestedString(obj.Object, "spec", "version")
+	m.ProviderID, _, _ = unstructured.NestedString(obj.Object, "spec", "providerID")
+	m.NodeName, _, _ = unstructured.NestedString(obj.Object, "status", "nodeRef", "name")
+	m.Phase, _, _ = unstructured.NestedString(obj.Object, "status", "phase")
+	m.FailureReason, _, _ = unstructured.NestedString(obj.Object, "status", "failureReason")
+	m.FailureMessage, _, _ = unstructured.NestedString(obj.Object, "status", "failureMessage")
+	m.Conditions = parseStatusConditions(obj)
+
+	return m
+}
+
+// NewControlPlaneFromUnstructured converts an unstructured object to a ControlPlane
+func NewControlPlaneFromUnstructured(obj *unstructured.Unstructured) *ControlPlane {
+	cp := &ControlPlane{
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+	}
+
+	cp.Version, _, _ = unstructured.NestedString(obj.Object, "spec", "version")
+	cp.DesiredReplicas, _, _ = unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	cp.Initialized, _, _ = unstructured.NestedBool(obj.Object, "status", "initialized")
+	cp.Ready, _, _ = unstructured.NestedBool(obj.Object, "status", "ready")
+	cp.Status = parseReplicaStatus(obj)
+	cp.Conditions = parseStatusConditions(obj)
+
+	return cp
+}
+
+func parseReplicaStatus(obj *unstructured.Unstructured) ReplicaStatus {
+	rs := ReplicaStatus{}
+	rs.Replicas, _, _ = unstructured.NestedInt64(obj.Object, "status", "replicas")
+	rs.ReadyReplicas, _, _ = unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+	rs.UpdatedReplicas, _, _ = unstructured.NestedInt64(obj.Object, "status", "updatedReplicas")
+	rs.AvailableReplicas, _, _ = unstructured.NestedInt64(obj.Object, "status", "availableReplicas")
+	rs.UnavailableReplicas, _, _ = unstructured.NestedInt64(obj.Object, "status", "unavailableReplicas")
+	return rs
+}
+
+func parseStatusConditions(obj *unstructured.Unstructured) []Condition {
+	conditions, found, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil || !found {
+		return nil
+	}
+	return parseConditions(conditions)
+}
@@ -0,0 +1,143 @@
+package cluster
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func object(apiVersion, kind, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name, "namespace": "org-acme"},
+		"spec":       spec,
+	}}
+}
+
+func TestNewMachineFromUnstructured(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		status map[string]interface{}
+		want   Machine
+	}{
+		{
+			name:   "running worker",
+			labels: map[string]string{MachineDeploymentNameLabel: "prod-md"},
+			status: map[string]interface{}{
+				"phase":   "Running",
+				"nodeRef": map[string]interface{}{"name": "ip-10-0-1-1"},
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Ready", "status": "True", "lastTransitionTime": "2026-01-05T10:00:00Z"},
+				},
+			},
+			want: Machine{Name: "prod-a", MachineDeployment: "prod-md", Phase: "Running", NodeName: "ip-10-0-1-1"},
+		},
+		{
+			name:   "failed control plane machine",
+			labels: map[string]string{ControlPlaneLabel: ""},
+			status: map[string]interface{}{
+				"phase":          "Failed",
+				"failureReason":  "CreateError",
+				"failureMessage": "instance quota exceeded",
+			},
+			want: Machine{Name: "prod-a", ControlPlane: true, Phase: "Failed", FailureReason: "CreateError", FailureMessage: "instance quota exceeded"},
+		},
+		{
+			name: "provisioning machine without status",
+			want: Machine{Name: "prod-a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := object("cluster.x-k8s.io/v1beta1", "Machine", "prod-a", map[string]interface{}{
+				"version":    "v1.29.4",
+				"providerID": "aws:///eu-west-1a/i-0123",
+			})
+			obj.SetLabels(tt.labels)
+			if tt.status != nil {
+				obj.Object["status"] = tt.status
+			}
+
+			m := NewMachineFromUnstructured(obj)
+			if m.Version != "v1.29.4" || m.ProviderID != "aws:///eu-west-1a/i-0123" || m.Namespace != "org-acme" {
+				t.Errorf("spec fields = %+v", m)
+			}
+			if m.Name != tt.want.Name || m.MachineDeployment != tt.want.MachineDeployment || m.ControlPlane != tt.want.ControlPlane ||
+				m.Phase != tt.want.Phase || m.NodeName != tt.want.NodeName ||
+				m.FailureReason != tt.want.FailureReason || m.FailureMessage != tt.want.FailureMessage {
+				t.Errorf("machine = %+v, want %+v", m, tt.want)
+			}
+			if conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions"); len(m.Conditions) != len(conditions) {
+				t.Errorf("parsed %d conditions, want %d", len(m.Conditions), len(conditions))
+			}
+		})
+	}
+}
+
+func TestNewMachineDeploymentFromUnstructured(t *testing.T) {
+	obj := object("cluster.x-k8s.io/v1beta1", "MachineDeployment", "prod-md", map[string]interface{}{
+		"clusterName": "prod",
+		"replicas":    int64(3),
+		"template":    map[string]interface{}{"spec": map[string]interface{}{"version": "v1.29.4"}},
+	})
+	obj.Object["status"] = map[string]interface{}{
+		"phase":               "ScalingUp",
+		"replicas":            int64(3),
+		"readyReplicas":       int64(2),
+		"updatedReplicas":     int64(3),
+		"unavailableReplicas": int64(1),
+	}
+
+	md := NewMachineDeploymentFromUnstructured(obj)
+	if md.ClusterName != "prod" || md.Version != "v1.29.4" || md.DesiredReplicas != 3 || md.Phase != "ScalingUp" {
+		t.Errorf("machine deployment = %+v", md)
+	}
+	want := ReplicaStatus{Replicas: 3, ReadyReplicas: 2, UpdatedReplicas: 3, UnavailableReplicas: 1}
+	if md.Status != want {
+		t.Errorf("status = %+v, want %+v", md.Status, want)
+	}
+}
+
+func TestFilterMachines(t *testing.T) {
+	machines := []*Machine{
+		{Name: "cp-1", ControlPlane: true, Phase: "Running"},
+		{Name: "md-a-1", MachineDeployment: "md-a", Phase: "Running"},
+		{Name: "md-a-2", MachineDeployment: "md-a", Phase: "Provisioning"},
+		{Name: "md-b-1", MachineDeployment: "md-b", Phase: "Running", FailureReason: "UpdateError"},
+	}
+
+	tests := []struct {
+		name              string
+		machineDeployment string
+		failedOnly        bool
+		want              []string
+	}{
+		{name: "no filter", want: []string{"cp-1", "md-a-1", "md-a-2", "md-b-1"}},
+		{name: "machine deployment", machineDeployment: "md-a", want: []string{"md-a-1", "md-a-2"}},
+		{name: "failed only", failedOnly: true, want: []string{"md-a-2", "md-b-1"}},
+		{name: "failed in machine deployment", machineDeployment: "md-b", failedOnly: true, want: []string{"md-b-1"}},
+		{name: "unknown machine deployment", machineDeployment: "md-c", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FilterMachines(machines, tt.machineDeployment, tt.failedOnly)
+			names := make([]string, 0, len(got))
+			for _, m := range got {
+				names = append(names, m.Name)
+			}
+			if len(names) != len(tt.want) {
+				t.Fatalf("FilterMachines() = %v, want %v", names, tt.want)
+			}
+			for i := range names {
+				if names[i] != tt.want[i] {
+					t.Errorf("FilterMachines() = %v, want %v", names, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
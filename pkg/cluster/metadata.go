@@ -0,0 +1,111 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+)
+
+// protectedDomain is the domain of the labels and annotations Giant Swarm
+// controllers rely on, e.g. giantswarm.io/organization or
+// release.giantswarm.io/version
+const protectedDomain = "giantswarm.io"
+
+// IsProtectedKey reports whether a label or annotation key belongs to Giant
+// Swarm, so that changing it may break the management of the cluster
+func IsProtectedKey(key string) bool {
+	prefix, _, found := strings.Cut(key, "/")
+	if !found {
+		return false
+	}
+	return prefix == protectedDomain || strings.HasSuffix(prefix, "."+protectedDomain)
+}
+
+// SetLabels adds or overwrites labels on a cluster and removes the given keys
+func (c *Client) SetLabels(ctx context.Context, namespace, name string, set map[string]string, remove []string, opts k8s.UpdateOptions) (*Cluster, error) {
+	return c.applyMetadata(ctx, namespace, name, "labels", set, remove, opts)
+}
+
+// SetAnnotations adds or overwrites annotations on a cluster and removes the
+// given keys
+func (c *Client) SetAnnotations(ctx context.Context, namespace, name string, set map[string]string, remove []string, opts k8s.UpdateOptions) (*Cluster, error) {
+	return c.applyMetadata(ctx, namespace, name, "annotations", set, remove, opts)
+}
+
+// applyMetadata changes labels or annotations with server-side apply. Apply
+// only drops keys no other field manager owns, so removed keys that are
+// still present afterwards are deleted with a merge patch.
+func (c *Client) applyMetadata(ctx context.Context, namespace, name, field string, set map[string]string, remove []string, opts k8s.UpdateOptions) (*Cluster, error) {
+	resource := c.dynamicClient.Resource(ClusterGVR).Namespace(namespace)
+
+	var applied *unstructured.Unstructured
+	err := k8s.RetryOnConflict(func() error {
+		obj, err := resource.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		values, _, err := unstructured.NestedStringMap(obj.Object, "metadata", field)
+		if err != nil {
+			return err
+		}
+		if values == nil {
+			values = make(map[string]string)
+		}
+		for key, value := range set {
+			values[key] = value
+		}
+		for _, key := range remove {
+			delete(values, key)
+		}
+
+		applyConfig := &unstructured.Unstructured{}
+		applyConfig.SetGroupVersionKind(ClusterGVK)
+		applyConfig.SetName(name)
+		applyConfig.SetNamespace(namespace)
+		applyConfig.SetResourceVersion(opts.Precondition(obj.GetResourceVersion()))
+		if len(values) > 0 {
+			if err := unstructured.SetNestedStringMap(applyConfig.Object, values, "metadata", field); err != nil {
+				return err
+			}
+		}
+
+		applied, err = resource.Apply(ctx, name, applyConfig, opts.ApplyOptions())
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update %s of cluster %s/%s: %w", field, namespace, name, k8s.ConflictError("cluster", namespace, name, err))
+	}
+
+	current, _, _ := unstructured.NestedStringMap(applied.Object, "metadata", field)
+	leftover := make(map[string]interface{})
+	for _, key := range remove {
+		if _, ok := current[key]; ok {
+			leftover[key] = nil
+		}
+	}
+	if len(leftover) > 0 {
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				field: leftover,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %s patch: %w", field, err)
+		}
+
+		applied, err = resource.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: k8s.FieldManager})
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove %s of cluster %s/%s: %w", field, namespace, name, err)
+		}
+	}
+
+	return NewClusterFromUnstructured(applied)
+}
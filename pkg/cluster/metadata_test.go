@@ -0,0 +1,20 @@
+package cluster
+
+import "testing"
+
+func TestIsProtectedKey(t *testing.T) {
+	tests := map[string]bool{
+		"giantswarm.io/organization":      true,
+		"release.giantswarm.io/version":   true,
+		"environment":                     false,
+		"example.com/cost-center":         false,
+		"notgiantswarm.io/owner":          false,
+		"cluster.x-k8s.io/cluster-name":   false,
+		"giantswarm.io.example.com/owner": false,
+	}
+	for key, want := range tests {
+		if got := IsProtectedKey(key); got != want {
+			t.Errorf("IsProtectedKey(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+
+// Node is the inventory of a single workload cluster node
+type Node struct {
+	Name             string
+	Roles            []string
+	Ready            bool
+	Unschedulable    bool
+	KubeletVersion   string
+	OSImage          string
+	ContainerRuntime string
+	InstanceType     string
+	Zone             string
+	Taints           []corev1.Taint
+	Capacity         corev1.ResourceList
+	Allocatable      corev1.ResourceList
+}
+
+// ListNodes lists the nodes of a workload cluster, sorted by name
+func (c *Client) ListNodes(ctx context.Context, cl *Cluster) ([]Node, error) {
+	workloadClient, err := c.WorkloadClient(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := workloadClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes in cluster %s: %w", cl.Name, err)
+	}
+
+	result := make([]Node, 0, len(nodes.Items))
+	for i := range nodes.Items {
+		result = append(result, NewNode(&nodes.Items[i]))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result, nil
+}
+
+// NewNode converts a Kubernetes node to a Node
+func NewNode(node *corev1.Node) Node {
+	n := Node{
+		Name:             node.Name,
+		Unschedulable:    node.Spec.Unschedulable,
+		KubeletVersion:   node.Status.NodeInfo.KubeletVersion,
+		OSImage:          node.Status.NodeInfo.OSImage,
+		ContainerRuntime: node.Status.NodeInfo.ContainerRuntimeVersion,
+		InstanceType:     node.Labels[corev1.LabelInstanceTypeStable],
+		Zone:             node.Labels[corev1.LabelTopologyZone],
+		Taints:           node.Spec.Taints,
+		Capacity:         node.Status.Capacity,
+		Allocatable:      node.Status.Allocatable,
+	}
+
+	for label := range node.Labels {
+		if role, ok := strings.CutPrefix(label, nodeRoleLabelPrefix); ok && role != "" {
+			n.Roles = append(n.Roles, role)
+		}
+	}
+	sort.Strings(n.Roles)
+	if len(n.Roles) == 0 {
+		n.Roles = []string{"worker"}
+	}
+
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			n.Ready = cond.Status == corev1.ConditionTrue
+		}
+	}
+
+	return n
+}
+
+// HasRole reports whether the node has a role
+func (n Node) HasRole(role string) bool {
+	for _, r := range n.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// KubeletVersions counts the nodes per kubelet version
+func KubeletVersions(nodes []Node) map[string]int {
+	versions := make(map[string]int)
+	for _, n := range nodes {
+		versions[n.KubeletVersion]++
+	}
+	return versions
+}
+
+// TotalAllocatable sums the allocatable resources of nodes
+func TotalAllocatable(nodes []Node) corev1.ResourceList {
+	total := corev1.ResourceList{}
+	for _, n := range nodes {
+		for name, quantity := range n.Allocatable {
+			sum := total[name]
+			sum.Add(quantity)
+			total[name] = sum
+		}
+	}
+	return total
+}
+
+// FormatResources renders the CPU, memory and pod counts of a resource list
+func FormatResources(resources corev1.ResourceList) string {
+	quantity := func(name corev1.ResourceName) string {
+		if q, ok := resources[name]; ok {
+			return q.String()
+		}
+		return "?"
+	}
+	return fmt.Sprintf("cpu %s, memory %s, pods %s", quantity(corev1.ResourceCPU), formatMemory(resources), quantity(corev1.ResourcePods))
+}
+
+// formatMemory renders memory in GiB, which is easier to read than the Ki
+// values kubelets report
+func formatMemory(resources corev1.ResourceList) string {
+	q, ok := resources[corev1.ResourceMemory]
+	if !ok {
+		return "?"
+	}
+	return fmt.Sprintf("%.1fGi", float64(q.Value())/(1<<30))
+}
@@ -0,0 +1,55 @@
+package cluster
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewNode(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ip-10-0-1-1",
+			Labels: map[string]string{
+				"node-role.kubernetes.io/control-plane": "",
+				corev1.LabelInstanceTypeStable:          "m5.xlarge",
+				corev1.LabelTopologyZone:                "eu-west-1a",
+			},
+		},
+		Spec: corev1.NodeSpec{
+			Taints: []corev1.Taint{{Key: "node-role.kubernetes.io/control-plane", Effect: corev1.TaintEffectNoSchedule}},
+		},
+		Status: corev1.NodeStatus{
+			NodeInfo:   corev1.NodeSystemInfo{KubeletVersion: "v1.29.3"},
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("3920m"),
+				corev1.ResourceMemory: resource.MustParse("15Gi"),
+				corev1.ResourcePods:   resource.MustParse("110"),
+			},
+		},
+	}
+
+	n := NewNode(node)
+	if !n.Ready || !n.HasRole("control-plane") || n.HasRole("worker") {
+		t.Errorf("NewNode() = ready %v, roles %v", n.Ready, n.Roles)
+	}
+	if n.InstanceType != "m5.xlarge" || n.Zone != "eu-west-1a" || len(n.Taints) != 1 {
+		t.Errorf("NewNode() = instance type %s, zone %s, taints %v", n.InstanceType, n.Zone, n.Taints)
+	}
+
+	worker := NewNode(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker"}, Status: node.Status})
+	if !worker.HasRole("worker") {
+		t.Errorf("node without role labels has roles %v, want worker", worker.Roles)
+	}
+
+	nodes := []Node{n, worker}
+	if got := KubeletVersions(nodes)["v1.29.3"]; got != 2 {
+		t.Errorf("KubeletVersions() = %d nodes on v1.29.3, want 2", got)
+	}
+	if got := FormatResources(TotalAllocatable(nodes)); got != "cpu 7840m, memory 30.0Gi, pods 220" {
+		t.Errorf("FormatResources(TotalAllocatable()) = %q", got)
+	}
+}
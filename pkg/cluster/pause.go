@@ -0,0 +1,126 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+)
+
+// PausedAnnotation makes CAPI controllers skip the object it is set on,
+// whatever its value
+const PausedAnnotation = "cluster.x-k8s.io/paused"
+
+// Annotations recorded on clusters paused through this server
+const (
+	PausedByAnnotation    = "mcp.giantswarm.io/paused-by"
+	PausedAtAnnotation    = "mcp.giantswarm.io/paused-at"
+	PauseReasonAnnotation = "mcp.giantswarm.io/pause-reason"
+)
+
+// PauseState describes whether and how reconciliation of a cluster is paused
+type PauseState struct {
+	Paused bool
+	// Spec and Annotation tell which of spec.paused and PausedAnnotation
+	// pause the cluster
+	Spec       bool
+	Annotation bool
+
+	// By, Since and Reason are recorded when the cluster was paused through
+	// this server
+	By     string
+	Since  *time.Time
+	Reason string
+}
+
+// PauseState returns the pause state of the cluster
+func (c *Cluster) PauseState() PauseState {
+	_, annotated := c.Annotations[PausedAnnotation]
+	state := PauseState{
+		Paused:     c.Spec.Paused || annotated,
+		Spec:       c.Spec.Paused,
+		Annotation: annotated,
+	}
+	if !state.Paused {
+		return state
+	}
+
+	state.By = c.Annotations[PausedByAnnotation]
+	state.Reason = c.Annotations[PauseReasonAnnotation]
+	if since, err := time.Parse(time.RFC3339, c.Annotations[PausedAtAnnotation]); err == nil {
+		state.Since = &since
+	}
+	return state
+}
+
+// Summary renders the pause state in one line, e.g. "paused by alice since
+// 2026-01-05T10:00:00Z (2h ago): node pool migration"
+func (p PauseState) Summary(now time.Time) string {
+	if !p.Paused {
+		return "no"
+	}
+	summary := "paused"
+	if p.By != "" {
+		summary += " by " + p.By
+	}
+	if p.Since != nil {
+		summary += fmt.Sprintf(" since %s (%s ago)", p.Since.UTC().Format(time.RFC3339), duration.HumanDuration(now.Sub(*p.Since)))
+	}
+	if p.Reason != "" {
+		summary += ": " + p.Reason
+	}
+	return summary
+}
+
+// Pause stops CAPI controllers from reconciling a cluster and its machines,
+// control plane and infrastructure, by setting spec.paused and
+// PausedAnnotation. Who paused it, when and why are recorded in annotations.
+func (c *Client) Pause(ctx context.Context, namespace, name, by, reason string, now time.Time) (*Cluster, error) {
+	annotations := map[string]interface{}{
+		PausedAnnotation:   "true",
+		PausedByAnnotation: by,
+		PausedAtAnnotation: now.UTC().Format(time.RFC3339),
+	}
+	if reason != "" {
+		annotations[PauseReasonAnnotation] = reason
+	} else {
+		annotations[PauseReasonAnnotation] = nil
+	}
+	return c.patchPause(ctx, namespace, name, true, annotations)
+}
+
+// Resume lets CAPI controllers reconcile a paused cluster again, clearing
+// spec.paused and the pause annotations
+func (c *Client) Resume(ctx context.Context, namespace, name string) (*Cluster, error) {
+	annotations := map[string]interface{}{
+		PausedAnnotation:      nil,
+		PausedByAnnotation:    nil,
+		PausedAtAnnotation:    nil,
+		PauseReasonAnnotation: nil,
+	}
+	return c.patchPause(ctx, namespace, name, nil, annotations)
+}
+
+// patchPause sets or, with nil, removes spec.paused and the given annotations
+// with a merge patch
+func (c *Client) patchPause(ctx context.Context, namespace, name string, paused interface{}, annotations map[string]interface{}) (*Cluster, error) {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": annotations},
+		"spec":     map[string]interface{}{"paused": paused},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build pause patch: %w", err)
+	}
+
+	patched, err := c.dynamicClient.Resource(ClusterGVR).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: k8s.FieldManager})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pause state of cluster %s/%s: %w", namespace, name, err)
+	}
+	return NewClusterFromUnstructured(patched)
+}
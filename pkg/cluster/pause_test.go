@@ -0,0 +1,60 @@
+package cluster_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	gstesting "github.com/giantswarm/mcp-giantswarm-apps/pkg/testing"
+)
+
+func TestPauseResume(t *testing.T) {
+	ctx := context.Background()
+	cl := &cluster.Cluster{Name: "prod", Namespace: "org-acme", Annotations: map[string]string{"owner": "team-a"}}
+	cl.Spec.ControlPlaneRef = &cluster.ObjectReference{Kind: "KubeadmControlPlane", Name: "prod"}
+	client := cluster.NewClient(k8s.NewDynamicClientForInterface(gstesting.NewDynamicClient(gstesting.Cluster(cl))), nil, nil)
+
+	now := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	paused, err := client.Pause(ctx, "org-acme", "prod", "alice", "node pool migration", now)
+	if err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	state := paused.PauseState()
+	if !state.Paused || !state.Spec || !state.Annotation || state.By != "alice" || state.Reason != "node pool migration" || state.Since == nil || !state.Since.Equal(now) {
+		t.Errorf("paused state = %+v", state)
+	}
+	if summary := state.Summary(now.Add(2 * time.Hour)); summary != "paused by alice since 2026-01-05T10:00:00Z (120m ago): node pool migration" {
+		t.Errorf("Summary() = %q", summary)
+	}
+	if paused.Annotations["owner"] != "team-a" || paused.Spec.ControlPlaneRef == nil {
+		t.Errorf("pause changed other fields: %+v", paused)
+	}
+
+	resumed, err := client.Resume(ctx, "org-acme", "prod")
+	if err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+	if state := resumed.PauseState(); state.Paused || state.Summary(now) != "no" {
+		t.Errorf("resumed state = %+v", state)
+	}
+	for key := range resumed.Annotations {
+		if strings.Contains(key, "paused") || strings.Contains(key, "pause-") {
+			t.Errorf("annotation %s left after resume", key)
+		}
+	}
+	if resumed.Annotations["owner"] != "team-a" {
+		t.Errorf("resume removed other annotations: %v", resumed.Annotations)
+	}
+}
+
+func TestPauseStateAnnotationOnly(t *testing.T) {
+	// Pausing with kubectl sets only the annotation, with any value
+	cl := &cluster.Cluster{Annotations: map[string]string{cluster.PausedAnnotation: ""}}
+	state := cl.PauseState()
+	if !state.Paused || state.Spec || state.Summary(time.Now()) != "paused" {
+		t.Errorf("state = %+v", state)
+	}
+}
@@ -0,0 +1,127 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/tracing"
+)
+
+// pingTimeout bounds each request of a ping, so that unreachable clusters
+// are reported quickly
+const pingTimeout = 10 * time.Second
+
+// PingResult is the outcome of probing a workload cluster API server
+type PingResult struct {
+	Endpoint string
+
+	// Version is the API server version from /version
+	Version        *version.Info
+	VersionLatency time.Duration
+	VersionErr     error
+
+	// Healthz is the body of /healthz, "ok" for a healthy API server
+	Healthz        string
+	HealthzLatency time.Duration
+	HealthzErr     error
+
+	// CertNotAfter is the expiry of the API server's serving certificate;
+	// zero when the connection did not use TLS or failed
+	CertNotAfter time.Time
+	CertSubject  string
+}
+
+// Reachable reports whether the API server answered both requests
+func (r *PingResult) Reachable() bool {
+	return r.VersionErr == nil && r.HealthzErr == nil
+}
+
+// Ping calls /version and /healthz on the API server of a workload cluster
+// with the credentials of its kubeconfig secret. It uses a new connection
+// rather than a pooled client so that latencies include the TLS handshake of
+// a fresh connection and the certificate is read from the live server.
+func (c *Client) Ping(ctx context.Context, cl *Cluster) (*PingResult, error) {
+	kubeconfig, err := c.GetKubeconfig(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig for cluster %s: %w", cl.Name, err)
+	}
+	config.Wrap(k8s.ImpersonateFromContext)
+	config.Wrap(tracing.WrapTransport)
+	config.Timeout = pingTimeout
+
+	httpClient, err := rest.HTTPClientFor(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client for cluster %s: %w", cl.Name, err)
+	}
+	defer httpClient.CloseIdleConnections()
+
+	result := &PingResult{Endpoint: config.Host}
+	endpoint := strings.TrimSuffix(config.Host, "/")
+
+	body, latency, err := pingGet(ctx, httpClient, endpoint+"/version", result)
+	result.VersionLatency, result.VersionErr = latency, err
+	if err == nil {
+		var info version.Info
+		if err := json.Unmarshal(body, &info); err != nil {
+			result.VersionErr = fmt.Errorf("failed to parse /version: %w", err)
+		} else {
+			result.Version = &info
+		}
+	}
+
+	body, latency, err = pingGet(ctx, httpClient, endpoint+"/healthz", result)
+	result.HealthzLatency, result.HealthzErr = latency, err
+	if err == nil {
+		result.Healthz = strings.TrimSpace(string(body))
+	}
+
+	return result, nil
+}
+
+// pingGet requests a path of the API server and records its serving
+// certificate in result
+func pingGet(ctx context.Context, httpClient *http.Client, url string, result *PingResult) ([]byte, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	start := time.Now()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, time.Since(start), err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	latency := time.Since(start)
+	if err != nil {
+		return nil, latency, err
+	}
+
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		cert := resp.TLS.PeerCertificates[0]
+		result.CertNotAfter = cert.NotAfter
+		result.CertSubject = cert.Subject.String()
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return body, latency, fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return body, latency, nil
+}
@@ -0,0 +1,69 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPing(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/version":
+			_, _ = w.Write([]byte(`{"gitVersion":"v1.31.4","platform":"linux/amd64"}`))
+		case "/healthz":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("[-]etcd failed"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: prod
+  cluster:
+    server: %s
+    insecure-skip-tls-verify: true
+contexts:
+- name: prod
+  context:
+    cluster: prod
+    user: prod
+current-context: prod
+users:
+- name: prod
+  user:
+    token: secret
+`, srv.URL)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-kubeconfig", Namespace: "org-acme"},
+		Data:       map[string][]byte{"value": []byte(kubeconfig)},
+	}
+	c := &Client{k8sClient: fake.NewClientset(secret)}
+
+	result, err := c.Ping(context.Background(), &Cluster{Name: "prod", Namespace: "org-acme"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.VersionErr != nil || result.Version.GitVersion != "v1.31.4" {
+		t.Errorf("version = %+v, %v", result.Version, result.VersionErr)
+	}
+	if result.HealthzErr == nil {
+		t.Error("failing /healthz not reported")
+	}
+	if result.Reachable() {
+		t.Error("cluster with failing /healthz reported reachable")
+	}
+	if !result.CertNotAfter.Equal(srv.Certificate().NotAfter) {
+		t.Errorf("certificate expiry = %s, want %s", result.CertNotAfter, srv.Certificate().NotAfter)
+	}
+}
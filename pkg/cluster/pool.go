@@ -0,0 +1,209 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/tracing"
+)
+
+// ClientPool builds Kubernetes clients for workload clusters from their
+// kubeconfig secrets and caches them per cluster and impersonated user. A
+// cached client is reused for the TTL; after that the secret is read again and
+// the client is rebuilt if the secret changed, e.g. because its credentials
+// were rotated. Within the TTL, a SelfSubjectAccessReview checks that an
+// impersonated user may still read the secret before its client is returned.
+// A client is dropped as soon as its cluster rejects its credentials or can't
+// be reached, and when its kubeconfig secret is changed through
+// InvalidateSecret.
+type ClientPool struct {
+	k8sClient kubernetes.Interface
+	ttl       time.Duration
+
+	newClient func(*rest.Config) (kubernetes.Interface, error)
+	now       func() time.Time
+
+	mu      sync.Mutex
+	clients map[string]*pooledClient
+}
+
+type pooledClient struct {
+	cluster       string
+	client        *k8s.Client
+	secretVersion string
+	checked       time.Time
+}
+
+// NewClientPool creates a client pool that reads kubeconfig secrets with
+// k8sClient and re-checks them after ttl
+func NewClientPool(k8sClient kubernetes.Interface, ttl time.Duration) *ClientPool {
+	return &ClientPool{
+		k8sClient: k8sClient,
+		ttl:       ttl,
+		newClient: func(config *rest.Config) (kubernetes.Interface, error) {
+			return kubernetes.NewForConfig(config)
+		},
+		now:     time.Now,
+		clients: make(map[string]*pooledClient),
+	}
+}
+
+// Get returns a client for a workload cluster. Clients are not shared
+// between the users impersonated with k8s.WithImpersonation.
+func (p *ClientPool) Get(ctx context.Context, cluster *Cluster) (*k8s.Client, error) {
+	clusterKey := cluster.Namespace + "/" + cluster.Name
+	key := clusterKey
+	user, impersonated := k8s.ImpersonatedUser(ctx)
+	if impersonated {
+		key += "/" + user.Username
+	}
+
+	p.mu.Lock()
+	cached := p.clients[key]
+	fresh := cached != nil && p.now().Sub(cached.checked) < p.ttl
+	p.mu.Unlock()
+	if fresh {
+		// Reading the secret below authorizes the user again, a cached
+		// client needs a review instead
+		if impersonated {
+			if err := p.authorize(ctx, cluster); err != nil {
+				return nil, err
+			}
+		}
+		return cached.client, nil
+	}
+
+	secret, err := getKubeconfigSecret(ctx, p.k8sClient, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached != nil && cached.secretVersion == secret.ResourceVersion {
+		p.mu.Lock()
+		cached.checked = p.now()
+		p.mu.Unlock()
+		return cached.client, nil
+	}
+
+	kubeconfig, err := kubeconfigFromSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig of cluster %s: %w", cluster.Name, err)
+	}
+	entry := &pooledClient{cluster: clusterKey, secretVersion: secret.ResourceVersion}
+	config.Wrap(k8s.ImpersonateFromContext)
+	config.Wrap(tracing.WrapTransport)
+	config.Wrap(p.invalidateOnFailure(key, entry))
+	clientset, err := p.newClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for cluster %s: %w", cluster.Name, err)
+	}
+
+	entry.client = &k8s.Client{
+		Interface:  clientset,
+		RestConfig: config,
+		Context:    cluster.Name,
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	entry.checked = p.now()
+	p.clients[key] = entry
+
+	return entry.client, nil
+}
+
+// authorize checks that the user of ctx may read the kubeconfig secret of
+// cluster
+func (p *ClientPool) authorize(ctx context.Context, cluster *Cluster) error {
+	review, err := p.k8sClient.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: cluster.Namespace,
+				Verb:      "get",
+				Resource:  "secrets",
+				Name:      KubeconfigSecretName(cluster),
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to review access to the kubeconfig secret: %w", err)
+	}
+	if !review.Status.Allowed {
+		return fmt.Errorf("not allowed to get the kubeconfig secret of cluster %s", cluster.Name)
+	}
+	return nil
+}
+
+// invalidateOnFailure wraps the transport of a pooled client to drop it when
+// a request fails to connect or its credentials are rejected, so the next Get
+// builds it again from the kubeconfig secret
+func (p *ClientPool) invalidateOnFailure(key string, entry *pooledClient) func(http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := rt.RoundTrip(req)
+			if (err != nil && !errors.Is(err, context.Canceled)) || (resp != nil && resp.StatusCode == http.StatusUnauthorized) {
+				p.mu.Lock()
+				if p.clients[key] == entry {
+					delete(p.clients, key)
+				}
+				p.mu.Unlock()
+			}
+			return resp, err
+		})
+	}
+}
+
+// roundTripperFunc turns a function into an http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Invalidate drops the cached clients of a cluster for all users, e.g. after
+// the API server rejected their credentials
+func (p *ClientPool) Invalidate(cluster *Cluster) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	clusterKey := cluster.Namespace + "/" + cluster.Name
+	for key, entry := range p.clients {
+		if entry.cluster == clusterKey {
+			delete(p.clients, key)
+		}
+	}
+}
+
+// InvalidateSecret drops the cached client built from a kubeconfig secret
+// after the secret was changed, e.g. by the config tools. Other secrets are
+// ignored.
+func (p *ClientPool) InvalidateSecret(namespace, name string) {
+	clusterName, ok := strings.CutSuffix(name, "-kubeconfig")
+	if !ok {
+		return
+	}
+	p.Invalidate(&Cluster{Namespace: namespace, Name: clusterName})
+}
+
+// InvalidateAll drops all cached clients, e.g. after the credentials used to
+// read the kubeconfig secrets changed
+func (p *ClientPool) InvalidateAll() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.clients = make(map[string]*pooledClient)
+}
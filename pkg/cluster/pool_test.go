@@ -0,0 +1,210 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: prod
+  cluster:
+    server: https://prod.example.com
+contexts:
+- name: prod-admin@prod
+  context:
+    cluster: prod
+    user: prod-admin
+current-context: prod-admin@prod
+users:
+- name: prod-admin
+  user:
+    token: secret
+`
+
+func TestClientPool(t *testing.T) {
+	ctx := context.Background()
+	cl := &Cluster{Name: "prod", Namespace: "org-acme"}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-kubeconfig", Namespace: "org-acme", ResourceVersion: "1"},
+		Data:       map[string][]byte{"value": []byte(testKubeconfig)},
+	}
+	k8sClient := fake.NewClientset(secret)
+
+	now := time.Now()
+	built := 0
+	pool := NewClientPool(k8sClient, time.Minute)
+	pool.now = func() time.Time { return now }
+	pool.newClient = func(config *rest.Config) (kubernetes.Interface, error) {
+		built++
+		return fake.NewClientset(), nil
+	}
+
+	first, err := pool.Get(ctx, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.RestConfig.Host != "https://prod.example.com" {
+		t.Errorf("host = %s", first.RestConfig.Host)
+	}
+
+	// Within the TTL the secret is not read again
+	if err := k8sClient.CoreV1().Secrets("org-acme").Delete(ctx, "prod-kubeconfig", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if again, err := pool.Get(ctx, cl); err != nil || again != first {
+		t.Errorf("Get() within TTL = %p, %v, want cached client", again, err)
+	}
+
+	// After the TTL an unchanged secret keeps the client, a rotated one rebuilds it
+	if _, err := k8sClient.CoreV1().Secrets("org-acme").Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(2 * time.Minute)
+	if again, err := pool.Get(ctx, cl); err != nil || again != first {
+		t.Errorf("Get() with unchanged secret = %p, %v, want cached client", again, err)
+	}
+
+	rotated := secret.DeepCopy()
+	rotated.ResourceVersion = "2"
+	if _, err := k8sClient.CoreV1().Secrets("org-acme").Update(ctx, rotated, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(2 * time.Minute)
+	if again, err := pool.Get(ctx, cl); err != nil || again == first {
+		t.Errorf("Get() with rotated secret = %p, %v, want new client", again, err)
+	}
+
+	pool.Invalidate(cl)
+	if _, err := pool.Get(ctx, cl); err != nil {
+		t.Fatal(err)
+	}
+
+	// Changing another secret keeps the client, changing the kubeconfig drops it
+	pool.InvalidateSecret("org-acme", "prod-values")
+	pool.InvalidateSecret("org-acme", "prod-kubeconfig")
+	if _, err := pool.Get(ctx, cl); err != nil {
+		t.Fatal(err)
+	}
+	if built != 4 {
+		t.Errorf("built %d clients, want 4", built)
+	}
+}
+
+func TestClientPoolDropsRejectedClients(t *testing.T) {
+	ctx := context.Background()
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Unauthorized","code":401}`, http.StatusUnauthorized)
+	}))
+	defer apiServer.Close()
+
+	cl := &Cluster{Name: "prod", Namespace: "org-acme"}
+	kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: prod
+  cluster:
+    server: %s
+contexts:
+- name: prod
+  context:
+    cluster: prod
+    user: prod
+current-context: prod
+users:
+- name: prod
+  user:
+    token: expired
+`, apiServer.URL)
+	pool := NewClientPool(fake.NewClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-kubeconfig", Namespace: "org-acme", ResourceVersion: "1"},
+		Data:       map[string][]byte{"value": []byte(kubeconfig)},
+	}), time.Hour)
+
+	first, err := pool.Get(ctx, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := first.CoreV1().Namespaces().List(ctx, metav1.ListOptions{}); err == nil {
+		t.Fatal("expected the request to be rejected")
+	}
+	if again, err := pool.Get(ctx, cl); err != nil || again == first {
+		t.Errorf("Get() after rejected credentials = %p, %v, want new client", again, err)
+	}
+}
+
+func TestClientPoolPerUser(t *testing.T) {
+	ctx := context.Background()
+	cl := &Cluster{Name: "prod", Namespace: "org-acme"}
+	k8sClient := fake.NewClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-kubeconfig", Namespace: "org-acme", ResourceVersion: "1"},
+		Data:       map[string][]byte{"value": []byte(testKubeconfig)},
+	})
+	allowed := true
+	var reviewed []authorizationv1.ResourceAttributes
+	k8sClient.PrependReactor("create", "selfsubjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		review := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		reviewed = append(reviewed, *review.Spec.ResourceAttributes)
+		review.Status.Allowed = allowed
+		return true, review, nil
+	})
+
+	pool := NewClientPool(k8sClient, time.Hour)
+	pool.newClient = func(config *rest.Config) (kubernetes.Interface, error) {
+		return fake.NewClientset(), nil
+	}
+	alice := k8s.WithImpersonation(ctx, authenticationv1.UserInfo{Username: "alice"})
+	bob := k8s.WithImpersonation(ctx, authenticationv1.UserInfo{Username: "bob"})
+
+	aliceClient, err := pool.Get(alice, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bobClient, err := pool.Get(bob, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aliceClient == bobClient {
+		t.Error("users share a client")
+	}
+	if len(reviewed) != 0 {
+		t.Errorf("reviewed access of new clients: %v", reviewed)
+	}
+
+	// A cached client is only returned after an access review
+	if again, err := pool.Get(alice, cl); err != nil || again != aliceClient {
+		t.Errorf("Get() of allowed user = %p, %v, want cached client", again, err)
+	}
+	want := authorizationv1.ResourceAttributes{Namespace: "org-acme", Verb: "get", Resource: "secrets", Name: "prod-kubeconfig"}
+	if len(reviewed) != 1 || reviewed[0] != want {
+		t.Errorf("reviewed %v, want %v", reviewed, want)
+	}
+	allowed = false
+	if _, err := pool.Get(alice, cl); err == nil || !strings.Contains(err.Error(), "not allowed") {
+		t.Errorf("Get() of denied user error = %v, want not allowed", err)
+	}
+
+	// Invalidating the cluster drops the clients of all users
+	pool.Invalidate(cl)
+	if len(pool.clients) != 0 {
+		t.Errorf("%d clients left after Invalidate", len(pool.clients))
+	}
+}
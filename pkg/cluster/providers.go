@@ -0,0 +1,393 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ProviderAdapter knows where a CAPI infrastructure provider keeps the
+// region, network and instance type of a cluster. Adapters are registered
+// with RegisterProviderAdapter per infrastructure cluster kind.
+type ProviderAdapter interface {
+	// Names are the provider names, the Giant Swarm short name first
+	// (e.g. capa, aws)
+	Names() []string
+
+	// ClusterKinds are the infrastructure cluster kinds the adapter reads
+	ClusterKinds() []string
+
+	// ClusterDetails reads an infrastructure cluster object
+	ClusterDetails(obj *unstructured.Unstructured) ProviderDetails
+
+	// MachineDetails reads an infrastructure machine template
+	MachineDetails(obj *unstructured.Unstructured) MachineDetails
+}
+
+// ProviderDetails are the provider-specific properties of a cluster
+type ProviderDetails struct {
+	// Region is the region, location or virtual datacenter of the cluster
+	Region string
+	// Network is the VPC, VNet or network the cluster runs in, and CIDRs
+	// its address ranges
+	Network string
+	CIDRs   []string
+	Details []InfrastructureDetail
+}
+
+// MachineDetails are the provider-specific properties of a machine template
+type MachineDetails struct {
+	// InstanceType is the instance type, VM size or sizing of the machines
+	InstanceType string
+	Details      []InfrastructureDetail
+}
+
+var providerAdapters = map[string]ProviderAdapter{}
+
+// RegisterProviderAdapter makes an adapter handle its cluster kinds,
+// replacing any adapter registered for them before
+func RegisterProviderAdapter(adapter ProviderAdapter) {
+	for _, kind := range adapter.ClusterKinds() {
+		providerAdapters[kind] = adapter
+	}
+}
+
+// ProviderAdapterFor returns the adapter of an infrastructure cluster kind
+func ProviderAdapterFor(kind string) (ProviderAdapter, bool) {
+	adapter, ok := providerAdapters[kind]
+	return adapter, ok
+}
+
+// providerAdapterForTemplate returns the adapter of a machine template kind,
+// following the CAPI convention of <Provider>MachineTemplate next to
+// <Provider>Cluster
+func providerAdapterForTemplate(kind string) (ProviderAdapter, bool) {
+	if !strings.HasSuffix(kind, "MachineTemplate") {
+		return nil, false
+	}
+	return ProviderAdapterFor(strings.TrimSuffix(kind, "MachineTemplate") + "Cluster")
+}
+
+// NodePool is the control plane or a MachineDeployment of a cluster with the
+// machine template it creates machines from
+type NodePool struct {
+	Name         string
+	ControlPlane bool
+	Replicas     int64
+	TemplateKind string
+	TemplateName string
+	InstanceType string
+	Details      []InfrastructureDetail
+	// Error is set when the machine template could not be read
+	Error string
+}
+
+// ListNodePools returns the control plane and MachineDeployments of a cluster
+// with the instance types of their machine templates
+func (c *Client) ListNodePools(ctx context.Context, cl *Cluster) ([]*NodePool, error) {
+	var pools []*NodePool
+
+	if ref := cl.Spec.ControlPlaneRef; ref != nil && ref.Kind == "KubeadmControlPlane" {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = cl.Namespace
+		}
+		obj, err := c.dynamicClient.Resource(KubeadmControlPlaneGVR).Namespace(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get control plane %s/%s: %w", namespace, ref.Name, err)
+		}
+		pool := &NodePool{Name: obj.GetName(), ControlPlane: true}
+		pool.Replicas, _, _ = unstructured.NestedInt64(obj.Object, "spec", "replicas")
+		c.readMachineTemplate(ctx, pool, obj, namespace, "spec", "machineTemplate", "infrastructureRef")
+		pools = append(pools, pool)
+	}
+
+	list, err := c.dynamicClient.Resource(MachineDeploymentGVR).Namespace(cl.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", ClusterNameLabel, cl.Name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list machine deployments for cluster %s: %w", cl.Name, err)
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].GetName() < list.Items[j].GetName() })
+	for i := range list.Items {
+		obj := &list.Items[i]
+		pool := &NodePool{Name: obj.GetName()}
+		pool.Replicas, _, _ = unstructured.NestedInt64(obj.Object, "spec", "replicas")
+		c.readMachineTemplate(ctx, pool, obj, cl.Namespace, "spec", "template", "spec", "infrastructureRef")
+		pools = append(pools, pool)
+	}
+
+	return pools, nil
+}
+
+// readMachineTemplate fills the instance type and details of pool from the
+// machine template referenced at path of obj
+func (c *Client) readMachineTemplate(ctx context.Context, pool *NodePool, obj *unstructured.Unstructured, namespace string, path ...string) {
+	refMap, found, _ := unstructured.NestedMap(obj.Object, path...)
+	if !found {
+		return
+	}
+	ref := parseObjectReference(refMap)
+	pool.TemplateKind, pool.TemplateName = ref.Kind, ref.Name
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+
+	adapter, ok := providerAdapterForTemplate(ref.Kind)
+	if !ok {
+		return
+	}
+	gvr, err := infrastructureGVR(ref)
+	if err != nil {
+		pool.Error = err.Error()
+		return
+	}
+	template, err := c.dynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		pool.Error = fmt.Sprintf("failed to get %s %s/%s: %v", ref.Kind, namespace, ref.Name, err)
+		return
+	}
+
+	details := adapter.MachineDetails(template)
+	pool.InstanceType = details.InstanceType
+	pool.Details = details.Details
+}
+
+func init() {
+	RegisterProviderAdapter(capaAdapter{})
+	RegisterProviderAdapter(capzAdapter{})
+	RegisterProviderAdapter(capvAdapter{})
+	RegisterProviderAdapter(capvcdAdapter{})
+}
+
+// fieldReader collects the fields of an object that are set
+type fieldReader struct {
+	obj     map[string]interface{}
+	details []InfrastructureDetail
+}
+
+// str returns the string value at path, or ""
+func (r *fieldReader) str(path ...string) string {
+	value, found, err := unstructured.NestedFieldNoCopy(r.obj, path...)
+	if err != nil || !found || value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// add records the value at path as a detail when it is set
+func (r *fieldReader) add(name string, path ...string) {
+	if value := r.str(path...); value != "" {
+		r.details = append(r.details, InfrastructureDetail{Name: name, Value: value})
+	}
+}
+
+// addValue records a detail when value is set
+func (r *fieldReader) addValue(name, value string) {
+	if value != "" {
+		r.details = append(r.details, InfrastructureDetail{Name: name, Value: value})
+	}
+}
+
+// slice returns the maps of the list at path
+func (r *fieldReader) slice(path ...string) []map[string]interface{} {
+	items, _, _ := unstructured.NestedSlice(r.obj, path...)
+	maps := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			maps = append(maps, m)
+		}
+	}
+	return maps
+}
+
+// strings returns the string list at path
+func (r *fieldReader) strings(path ...string) []string {
+	values, _, _ := unstructured.NestedStringSlice(r.obj, path...)
+	return values
+}
+
+// countSubnets summarizes subnets by a role, e.g. "3 private, 3 public"
+func countSubnets(roles []string) string {
+	counts := make(map[string]int)
+	for _, role := range roles {
+		counts[role]++
+	}
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[key], key))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// capaAdapter reads Cluster API Provider AWS objects
+type capaAdapter struct{}
+
+func (capaAdapter) Names() []string        { return []string{"capa", "aws"} }
+func (capaAdapter) ClusterKinds() []string { return []string{"AWSCluster"} }
+
+func (capaAdapter) ClusterDetails(obj *unstructured.Unstructured) ProviderDetails {
+	r := &fieldReader{obj: obj.Object}
+	details := ProviderDetails{
+		Region:  r.str("spec", "region"),
+		Network: r.str("spec", "network", "vpc", "id"),
+	}
+	if cidr := r.str("spec", "network", "vpc", "cidrBlock"); cidr != "" {
+		details.CIDRs = append(details.CIDRs, cidr)
+	}
+
+	var roles []string
+	for _, subnet := range r.slice("spec", "network", "subnets") {
+		if public, _ := subnet["isPublic"].(bool); public {
+			roles = append(roles, "public")
+		} else {
+			roles = append(roles, "private")
+		}
+	}
+	r.addValue("Subnets", countSubnets(roles))
+	r.add("SSH Key", "spec", "sshKeyName")
+	r.add("Identity", "spec", "identityRef", "name")
+	r.add("Bastion Enabled", "spec", "bastion", "enabled")
+	r.add("Bastion Public IP", "status", "bastion", "publicIp")
+	details.Details = r.details
+	return details
+}
+
+func (capaAdapter) MachineDetails(obj *unstructured.Unstructured) MachineDetails {
+	r := &fieldReader{obj: obj.Object}
+	details := MachineDetails{InstanceType: r.str("spec", "template", "spec", "instanceType")}
+	r.add("AMI", "spec", "template", "spec", "ami", "id")
+	if size := r.str("spec", "template", "spec", "rootVolume", "size"); size != "" {
+		r.addValue("Root Volume", size+"GiB")
+	}
+	r.add("Instance Profile", "spec", "template", "spec", "iamInstanceProfile")
+	details.Details = r.details
+	return details
+}
+
+// capzAdapter reads Cluster API Provider Azure objects
+type capzAdapter struct{}
+
+func (capzAdapter) Names() []string        { return []string{"capz", "azure"} }
+func (capzAdapter) ClusterKinds() []string { return []string{"AzureCluster"} }
+
+func (capzAdapter) ClusterDetails(obj *unstructured.Unstructured) ProviderDetails {
+	r := &fieldReader{obj: obj.Object}
+	details := ProviderDetails{
+		Region:  r.str("spec", "location"),
+		Network: r.str("spec", "networkSpec", "vnet", "name"),
+		CIDRs:   r.strings("spec", "networkSpec", "vnet", "cidrBlocks"),
+	}
+
+	var roles []string
+	for _, subnet := range r.slice("spec", "networkSpec", "subnets") {
+		role, _ := subnet["role"].(string)
+		if role == "" {
+			role = "unknown"
+		}
+		roles = append(roles, role)
+	}
+	r.addValue("Subnets", countSubnets(roles))
+	r.add("Subscription", "spec", "subscriptionID")
+	r.add("Resource Group", "spec", "resourceGroup")
+	r.add("VNet Resource Group", "spec", "networkSpec", "vnet", "resourceGroup")
+	r.add("Identity", "spec", "identityRef", "name")
+	r.add("Bastion", "spec", "bastionSpec", "azureBastion", "name")
+	details.Details = r.details
+	return details
+}
+
+func (capzAdapter) MachineDetails(obj *unstructured.Unstructured) MachineDetails {
+	r := &fieldReader{obj: obj.Object}
+	details := MachineDetails{InstanceType: r.str("spec", "template", "spec", "vmSize")}
+	if size := r.str("spec", "template", "spec", "osDisk", "diskSizeGB"); size != "" {
+		r.addValue("OS Disk", size+"GB")
+	}
+	if _, found, _ := unstructured.NestedMap(obj.Object, "spec", "template", "spec", "spotVMOptions"); found {
+		r.addValue("Spot", "true")
+	}
+	details.Details = r.details
+	return details
+}
+
+// capvAdapter reads Cluster API Provider vSphere objects. vSphere clusters
+// have no region or network of their own; both are set per machine template.
+type capvAdapter struct{}
+
+func (capvAdapter) Names() []string        { return []string{"capv", "vsphere"} }
+func (capvAdapter) ClusterKinds() []string { return []string{"VSphereCluster"} }
+
+func (capvAdapter) ClusterDetails(obj *unstructured.Unstructured) ProviderDetails {
+	r := &fieldReader{obj: obj.Object}
+	r.add("vCenter", "spec", "server")
+	r.add("Thumbprint", "spec", "thumbprint")
+	r.add("Identity", "spec", "identityRef", "name")
+	return ProviderDetails{Details: r.details}
+}
+
+func (capvAdapter) MachineDetails(obj *unstructured.Unstructured) MachineDetails {
+	r := &fieldReader{obj: obj.Object}
+	var details MachineDetails
+	cpus, memory := r.str("spec", "template", "spec", "numCPUs"), r.str("spec", "template", "spec", "memoryMiB")
+	if cpus != "" || memory != "" {
+		details.InstanceType = fmt.Sprintf("%s vCPU, %s MiB", cpus, memory)
+	}
+	r.add("Datacenter", "spec", "template", "spec", "datacenter")
+	r.add("Datastore", "spec", "template", "spec", "datastore")
+	r.add("Template", "spec", "template", "spec", "template")
+	if disk := r.str("spec", "template", "spec", "diskGiB"); disk != "" {
+		r.addValue("Disk", disk+"GiB")
+	}
+	var networks []string
+	for _, device := range r.slice("spec", "template", "spec", "network", "devices") {
+		if name, _ := device["networkName"].(string); name != "" {
+			networks = append(networks, name)
+		}
+	}
+	r.addValue("Networks", strings.Join(networks, ", "))
+	details.Details = r.details
+	return details
+}
+
+// capvcdAdapter reads Cluster API Provider VMware Cloud Director objects
+type capvcdAdapter struct{}
+
+func (capvcdAdapter) Names() []string        { return []string{"capvcd", "cloud-director"} }
+func (capvcdAdapter) ClusterKinds() []string { return []string{"VCDCluster"} }
+
+func (capvcdAdapter) ClusterDetails(obj *unstructured.Unstructured) ProviderDetails {
+	r := &fieldReader{obj: obj.Object}
+	details := ProviderDetails{
+		Region:  r.str("spec", "ovdc"),
+		Network: r.str("spec", "ovdcNetwork"),
+	}
+	if vip := r.str("spec", "loadBalancerConfigSpec", "vipSubnet"); vip != "" {
+		details.CIDRs = append(details.CIDRs, vip)
+	}
+	r.add("Site", "spec", "site")
+	r.add("Organization", "spec", "org")
+	r.add("Credentials", "spec", "userContext", "secretRef", "name")
+	details.Details = r.details
+	return details
+}
+
+func (capvcdAdapter) MachineDetails(obj *unstructured.Unstructured) MachineDetails {
+	r := &fieldReader{obj: obj.Object}
+	details := MachineDetails{InstanceType: r.str("spec", "template", "spec", "sizingPolicy")}
+	r.add("Placement Policy", "spec", "template", "spec", "placementPolicy")
+	r.add("Catalog", "spec", "template", "spec", "catalog")
+	r.add("Template", "spec", "template", "spec", "template")
+	r.add("Disk", "spec", "template", "spec", "diskSize")
+	r.add("Storage Profile", "spec", "template", "spec", "storageProfile")
+	details.Details = r.details
+	return details
+}
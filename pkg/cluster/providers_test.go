@@ -0,0 +1,128 @@
+package cluster_test
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	gstesting "github.com/giantswarm/mcp-giantswarm-apps/pkg/testing"
+)
+
+func detail(details []cluster.InfrastructureDetail, name string) string {
+	for _, d := range details {
+		if d.Name == name {
+			return d.Value
+		}
+	}
+	return ""
+}
+
+func TestProviderClusterDetails(t *testing.T) {
+	tests := []struct {
+		name     string
+		obj      *unstructured.Unstructured
+		provider string
+		region   string
+		network  string
+		cidrs    int
+		detail   [2]string
+	}{
+		{
+			name: "capz",
+			obj: gstesting.Object("infrastructure.cluster.x-k8s.io/v1beta1", "AzureCluster", "org-acme", "prod", map[string]interface{}{
+				"location":       "westeurope",
+				"subscriptionID": "sub-1",
+				"networkSpec": map[string]interface{}{
+					"vnet": map[string]interface{}{"name": "prod-vnet", "cidrBlocks": []interface{}{"10.0.0.0/16"}},
+					"subnets": []interface{}{
+						map[string]interface{}{"role": "control-plane"},
+						map[string]interface{}{"role": "node"},
+						map[string]interface{}{"role": "node"},
+					},
+				},
+			}),
+			provider: "capz", region: "westeurope", network: "prod-vnet", cidrs: 1,
+			detail: [2]string{"Subnets", "1 control-plane, 2 node"},
+		},
+		{
+			name: "capv",
+			obj: gstesting.Object("infrastructure.cluster.x-k8s.io/v1beta1", "VSphereCluster", "org-acme", "prod", map[string]interface{}{
+				"server": "vcenter.example.com",
+			}),
+			provider: "capv",
+			detail:   [2]string{"vCenter", "vcenter.example.com"},
+		},
+		{
+			name: "capvcd",
+			obj: gstesting.Object("infrastructure.cluster.x-k8s.io/v1beta2", "VCDCluster", "org-acme", "prod", map[string]interface{}{
+				"site":                   "https://vcd.example.com",
+				"ovdc":                   "vdc-1",
+				"ovdcNetwork":            "prod-net",
+				"loadBalancerConfigSpec": map[string]interface{}{"vipSubnet": "10.10.0.0/24"},
+			}),
+			provider: "capvcd", region: "vdc-1", network: "prod-net", cidrs: 1,
+			detail: [2]string{"Site", "https://vcd.example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			infra := cluster.NewInfrastructureFromUnstructured(tt.obj)
+			if infra.Provider != tt.provider || infra.Region != tt.region || infra.Network != tt.network {
+				t.Errorf("Provider, Region, Network = %q, %q, %q", infra.Provider, infra.Region, infra.Network)
+			}
+			if len(infra.CIDRs) != tt.cidrs {
+				t.Errorf("CIDRs = %v", infra.CIDRs)
+			}
+			if got := detail(infra.Details, tt.detail[0]); got != tt.detail[1] {
+				t.Errorf("%s = %q, want %q", tt.detail[0], got, tt.detail[1])
+			}
+		})
+	}
+}
+
+func TestListNodePools(t *testing.T) {
+	kcp := gstesting.Object("controlplane.cluster.x-k8s.io/v1beta1", "KubeadmControlPlane", "org-acme", "prod", map[string]interface{}{
+		"replicas": int64(3),
+		"machineTemplate": map[string]interface{}{
+			"infrastructureRef": map[string]interface{}{
+				"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta2", "kind": "AWSMachineTemplate", "name": "prod-cp",
+			},
+		},
+	})
+	md := gstesting.Object("cluster.x-k8s.io/v1beta1", "MachineDeployment", "org-acme", "prod-workers", map[string]interface{}{
+		"replicas": int64(5),
+		"template": map[string]interface{}{"spec": map[string]interface{}{
+			"infrastructureRef": map[string]interface{}{
+				"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta2", "kind": "AWSMachineTemplate", "name": "prod-workers",
+			},
+		}},
+	})
+	md.SetLabels(map[string]string{cluster.ClusterNameLabel: "prod"})
+	cpTemplate := gstesting.Object("infrastructure.cluster.x-k8s.io/v1beta2", "AWSMachineTemplate", "org-acme", "prod-cp", map[string]interface{}{
+		"template": map[string]interface{}{"spec": map[string]interface{}{"instanceType": "m6i.xlarge"}},
+	})
+
+	client := cluster.NewClient(k8s.NewDynamicClientForInterface(gstesting.NewDynamicClient(kcp, md, cpTemplate)), nil, nil)
+
+	cl := &cluster.Cluster{Name: "prod", Namespace: "org-acme"}
+	cl.Spec.ControlPlaneRef = &cluster.ObjectReference{APIVersion: "controlplane.cluster.x-k8s.io/v1beta1", Kind: "KubeadmControlPlane", Name: "prod"}
+
+	pools, err := client.ListNodePools(context.Background(), cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pools) != 2 {
+		t.Fatalf("got %d pools, want 2", len(pools))
+	}
+	if !pools[0].ControlPlane || pools[0].Replicas != 3 || pools[0].InstanceType != "m6i.xlarge" {
+		t.Errorf("control plane pool = %+v", pools[0])
+	}
+	// The workers' template is missing, which is reported on the pool
+	if pools[1].Replicas != 5 || pools[1].TemplateName != "prod-workers" || pools[1].Error == "" {
+		t.Errorf("worker pool = %+v", pools[1])
+	}
+}
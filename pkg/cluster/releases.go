@@ -0,0 +1,301 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+)
+
+// Release states of Giant Swarm Release resources
+const (
+	ReleaseStateActive     = "active"
+	ReleaseStateDeprecated = "deprecated"
+	ReleaseStateWIP        = "wip"
+	ReleaseStatePreview    = "preview"
+)
+
+// Release is a Giant Swarm release, named <provider>-<version> (e.g.
+// aws-29.1.0), pinning the components and apps of the clusters using it
+type Release struct {
+	Name       string
+	Provider   string
+	Version    string
+	State      string
+	Date       string
+	Components []ReleaseComponent
+	Apps       []ReleaseComponent
+}
+
+// ReleaseComponent is a component or app pinned by a release
+type ReleaseComponent struct {
+	Name    string
+	Version string
+}
+
+// ReleaseProvider returns the release name prefix of a cluster provider as
+// returned by GetProvider, e.g. aws for capa or cloud-director for VCD
+func ReleaseProvider(provider string) string {
+	provider = strings.ToLower(provider)
+	for kind, adapter := range providerAdapters {
+		names := adapter.Names()
+		if strings.ToLower(kind) == provider+"cluster" {
+			return names[len(names)-1]
+		}
+		for _, name := range names {
+			if name == provider {
+				return names[len(names)-1]
+			}
+		}
+	}
+	return provider
+}
+
+// SplitReleaseName splits a release name like cloud-director-25.0.0 into its
+// provider and version
+func SplitReleaseName(name string) (string, string, bool) {
+	for i := 0; i+1 < len(name); i++ {
+		if name[i] == '-' && name[i+1] >= '0' && name[i+1] <= '9' {
+			return name[:i], name[i+1:], i > 0
+		}
+	}
+	return "", "", false
+}
+
+// NewReleaseFromUnstructured converts an unstructured Release resource
+func NewReleaseFromUnstructured(obj *unstructured.Unstructured) (*Release, error) {
+	provider, version, ok := SplitReleaseName(obj.GetName())
+	if !ok {
+		return nil, fmt.Errorf("release %s is not named <provider>-<version>", obj.GetName())
+	}
+
+	release := &Release{
+		Name:     obj.GetName(),
+		Provider: provider,
+		Version:  version,
+	}
+	release.State, _, _ = unstructured.NestedString(obj.Object, "spec", "state")
+	release.Date, _, _ = unstructured.NestedString(obj.Object, "spec", "date")
+	release.Components = parseReleaseComponents(obj.Object, "components")
+	release.Apps = parseReleaseComponents(obj.Object, "apps")
+
+	return release, nil
+}
+
+func parseReleaseComponents(obj map[string]interface{}, field string) []ReleaseComponent {
+	items, _, _ := unstructured.NestedSlice(obj, "spec", field)
+	components := make([]ReleaseComponent, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := m["name"].(string)
+		version, _ := m["version"].(string)
+		if name != "" {
+			components = append(components, ReleaseComponent{Name: name, Version: version})
+		}
+	}
+	return components
+}
+
+// ListReleases lists the releases of a provider, or of all providers when
+// provider is empty
+func (c *Client) ListReleases(ctx context.Context, provider string) ([]*Release, error) {
+	list, err := c.dynamicClient.Resource(k8s.ReleaseGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list releases: %w", err)
+	}
+
+	releases := make([]*Release, 0, len(list.Items))
+	for _, item := range list.Items {
+		release, err := NewReleaseFromUnstructured(&item)
+		if err != nil {
+			continue // Skip releases not following the naming convention
+		}
+		if provider == "" || release.Provider == provider {
+			releases = append(releases, release)
+		}
+	}
+
+	return releases, nil
+}
+
+// UpgradeHop is one upgrade of a plan and the version changes it brings
+type UpgradeHop struct {
+	From    *Release
+	To      *Release
+	Major   bool
+	Changes []ComponentChange
+}
+
+// ComponentChange is a component or app added, removed or changed by a hop.
+// From is empty for added and To for removed components.
+type ComponentChange struct {
+	Name string
+	App  bool
+	From string
+	To   string
+}
+
+// UpgradePlan is the path from the current release of a cluster to a target
+type UpgradePlan struct {
+	Current *Release
+	Target  *Release
+	// Candidates are the releases the cluster may upgrade to next
+	Candidates []*Release
+	Hops       []UpgradeHop
+}
+
+// UpgradePlanOptions tune PlanUpgrade
+type UpgradePlanOptions struct {
+	// Target is the release version to upgrade to; the newest active
+	// release when empty
+	Target string
+	// AllowSkippingMajors permits upgrading across more than one major
+	// version in a single hop
+	AllowSkippingMajors bool
+	// IncludePreviews makes preview releases valid targets
+	IncludePreviews bool
+}
+
+// PlanUpgrade computes the upgrades from the current release to the target.
+// Within a major the plan jumps to the target or the newest release of the
+// major; major versions are not skipped unless allowed, and deprecated or
+// work-in-progress releases are never upgraded to.
+func PlanUpgrade(current string, releases []*Release, opts UpgradePlanOptions) (*UpgradePlan, error) {
+	currentVersion, err := semver.NewVersion(current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse current release %q: %w", current, err)
+	}
+
+	type candidate struct {
+		release *Release
+		version *semver.Version
+	}
+	plan := &UpgradePlan{Current: &Release{Version: current}}
+	var newer []candidate
+	for _, release := range releases {
+		v, err := semver.NewVersion(release.Version)
+		if err != nil {
+			continue
+		}
+		if v.Equal(currentVersion) {
+			plan.Current = release
+			continue
+		}
+		if !v.GreaterThan(currentVersion) || !upgradeTarget(release, opts) {
+			continue
+		}
+		newer = append(newer, candidate{release: release, version: v})
+	}
+	sort.Slice(newer, func(i, j int) bool {
+		return newer[i].version.LessThan(newer[j].version)
+	})
+
+	for _, c := range newer {
+		if opts.AllowSkippingMajors || c.version.Major() <= currentVersion.Major()+1 {
+			plan.Candidates = append(plan.Candidates, c.release)
+		}
+	}
+
+	if len(newer) == 0 {
+		if opts.Target != "" {
+			return nil, fmt.Errorf("release %s is not a valid upgrade target from %s", opts.Target, current)
+		}
+		return plan, nil
+	}
+
+	target := newer[len(newer)-1]
+	if opts.Target != "" {
+		found := false
+		for _, c := range newer {
+			if c.release.Version == strings.TrimPrefix(opts.Target, "v") {
+				target, found = c, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("release %s is not a valid upgrade target from %s", opts.Target, current)
+		}
+	}
+	plan.Target = target.release
+
+	from, fromVersion := plan.Current, currentVersion
+	for fromVersion.LessThan(target.version) {
+		next := target
+		if !opts.AllowSkippingMajors && target.version.Major() > fromVersion.Major()+1 {
+			// Hop to the newest release of the next major
+			found := false
+			for _, c := range newer {
+				if c.version.Major() == fromVersion.Major()+1 {
+					next, found = c, true
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("no release of major version %d to upgrade through from %s to %s", fromVersion.Major()+1, current, target.release.Version)
+			}
+		}
+
+		plan.Hops = append(plan.Hops, UpgradeHop{
+			From:    from,
+			To:      next.release,
+			Major:   next.version.Major() > fromVersion.Major(),
+			Changes: diffReleases(from, next.release),
+		})
+		from, fromVersion = next.release, next.version
+	}
+
+	return plan, nil
+}
+
+// upgradeTarget reports whether a release may be upgraded to
+func upgradeTarget(release *Release, opts UpgradePlanOptions) bool {
+	switch release.State {
+	case ReleaseStateDeprecated, ReleaseStateWIP:
+		return false
+	case ReleaseStatePreview:
+		return opts.IncludePreviews
+	}
+	return true
+}
+
+// diffReleases lists the components and apps whose version differs between
+// two releases, components first, sorted by name
+func diffReleases(from, to *Release) []ComponentChange {
+	changes := diffComponents(from.Components, to.Components, false)
+	return append(changes, diffComponents(from.Apps, to.Apps, true)...)
+}
+
+func diffComponents(from, to []ReleaseComponent, apps bool) []ComponentChange {
+	before := make(map[string]string, len(from))
+	for _, c := range from {
+		before[c.Name] = c.Version
+	}
+	after := make(map[string]string, len(to))
+	for _, c := range to {
+		after[c.Name] = c.Version
+	}
+
+	changes := make([]ComponentChange, 0)
+	for name, version := range after {
+		if before[name] != version {
+			changes = append(changes, ComponentChange{Name: name, App: apps, From: before[name], To: version})
+		}
+	}
+	for name, version := range before {
+		if _, ok := after[name]; !ok {
+			changes = append(changes, ComponentChange{Name: name, App: apps, From: version})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
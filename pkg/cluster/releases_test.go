@@ -0,0 +1,112 @@
+package cluster
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func testRelease(version, state string, components ...ReleaseComponent) *Release {
+	return &Release{Name: "aws-" + version, Provider: "aws", Version: version, State: state, Components: components}
+}
+
+func TestPlanUpgrade(t *testing.T) {
+	releases := []*Release{
+		testRelease("25.0.0", "active", ReleaseComponent{"kubernetes", "1.25.16"}),
+		testRelease("25.1.0", "active", ReleaseComponent{"kubernetes", "1.25.16"}),
+		testRelease("26.0.0", "active", ReleaseComponent{"kubernetes", "1.26.15"}),
+		testRelease("26.1.0", "active", ReleaseComponent{"kubernetes", "1.26.15"}, ReleaseComponent{"flatcar", "3815.2.0"}),
+		testRelease("27.0.0", "active", ReleaseComponent{"kubernetes", "1.27.14"}),
+		testRelease("28.0.0", "wip", ReleaseComponent{"kubernetes", "1.28.9"}),
+		testRelease("24.0.0", "deprecated"),
+	}
+
+	plan, err := PlanUpgrade("25.0.0", releases, UpgradePlanOptions{})
+	if err != nil {
+		t.Fatalf("PlanUpgrade() error = %v", err)
+	}
+	if plan.Target.Version != "27.0.0" {
+		t.Errorf("target = %s, want 27.0.0", plan.Target.Version)
+	}
+	if len(plan.Candidates) != 3 {
+		t.Errorf("candidates = %d, want 25.1.0, 26.0.0 and 26.1.0", len(plan.Candidates))
+	}
+	if len(plan.Hops) != 2 || plan.Hops[0].To.Version != "26.1.0" || plan.Hops[1].To.Version != "27.0.0" {
+		t.Fatalf("hops = %+v, want 25.0.0 -> 26.1.0 -> 27.0.0", plan.Hops)
+	}
+	if !plan.Hops[0].Major || !plan.Hops[1].Major {
+		t.Error("hops should be major upgrades")
+	}
+
+	changes := plan.Hops[0].Changes
+	if len(changes) != 2 || changes[0].Name != "flatcar" || changes[0].From != "" ||
+		changes[1].Name != "kubernetes" || changes[1].From != "1.25.16" || changes[1].To != "1.26.15" {
+		t.Errorf("changes = %+v", changes)
+	}
+	if removed := plan.Hops[1].Changes; len(removed) != 2 || removed[0].Name != "flatcar" || removed[0].To != "" {
+		t.Errorf("changes = %+v, want flatcar removed", removed)
+	}
+
+	plan, err = PlanUpgrade("25.0.0", releases, UpgradePlanOptions{Target: "25.1.0"})
+	if err != nil || len(plan.Hops) != 1 || plan.Hops[0].Major {
+		t.Errorf("plan to 25.1.0 = %+v, %v", plan, err)
+	}
+
+	plan, err = PlanUpgrade("25.0.0", releases, UpgradePlanOptions{AllowSkippingMajors: true})
+	if err != nil || len(plan.Hops) != 1 || len(plan.Candidates) != 4 {
+		t.Errorf("plan skipping majors = %+v, %v", plan, err)
+	}
+
+	if _, err := PlanUpgrade("25.0.0", releases, UpgradePlanOptions{Target: "28.0.0"}); err == nil {
+		t.Error("expected an error for a work-in-progress target")
+	}
+
+	plan, err = PlanUpgrade("27.0.0", releases, UpgradePlanOptions{})
+	if err != nil || plan.Target != nil || len(plan.Hops) != 0 {
+		t.Errorf("plan from newest = %+v, %v", plan, err)
+	}
+}
+
+func TestPlanUpgradeMissingMajor(t *testing.T) {
+	releases := []*Release{
+		testRelease("25.0.0", "active"),
+		testRelease("27.0.0", "active"),
+	}
+	if _, err := PlanUpgrade("25.0.0", releases, UpgradePlanOptions{}); err == nil {
+		t.Error("expected an error when no release of the next major exists")
+	}
+}
+
+func TestReleaseNames(t *testing.T) {
+	for name, want := range map[string][2]string{
+		"aws-29.1.0":            {"aws", "29.1.0"},
+		"cloud-director-25.0.0": {"cloud-director", "25.0.0"},
+		"azure-30.0.0-alpha.1":  {"azure", "30.0.0-alpha.1"},
+	} {
+		provider, version, ok := SplitReleaseName(name)
+		if !ok || provider != want[0] || version != want[1] {
+			t.Errorf("SplitReleaseName(%q) = %s, %s, %v", name, provider, version, ok)
+		}
+	}
+	if _, _, ok := SplitReleaseName("v29.1.0"); ok {
+		t.Error("SplitReleaseName() accepted a name without provider")
+	}
+
+	for provider, want := range map[string]string{"capa": "aws", "AWS": "aws", "VCD": "cloud-director", "capz": "azure"} {
+		if got := ReleaseProvider(provider); got != want {
+			t.Errorf("ReleaseProvider(%q) = %s, want %s", provider, got, want)
+		}
+	}
+
+	release, err := NewReleaseFromUnstructured(&unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "aws-29.1.0"},
+		"spec": map[string]interface{}{
+			"state":      "active",
+			"components": []interface{}{map[string]interface{}{"name": "kubernetes", "version": "1.29.8"}},
+			"apps":       []interface{}{map[string]interface{}{"name": "cilium", "version": "0.25.1"}},
+		},
+	}})
+	if err != nil || release.State != "active" || len(release.Components) != 1 || release.Apps[0].Name != "cilium" {
+		t.Errorf("NewReleaseFromUnstructured() = %+v, %v", release, err)
+	}
+}
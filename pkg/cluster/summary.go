@@ -0,0 +1,105 @@
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Counts counts clusters per value, e.g. per provider
+type Counts map[string]int
+
+// String renders the counts sorted by value, e.g. "aws 3, azure 1"
+func (c Counts) String() string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s %d", key, c[key]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Summary counts a set of clusters by provider, release and readiness
+type Summary struct {
+	Total     int
+	Ready     int
+	Providers Counts
+	Releases  Counts
+
+	// NotReady names the clusters that are not ready with their phase
+	NotReady []string
+	// Paused names the clusters whose reconciliation is paused
+	Paused []string
+}
+
+func (s *Summary) add(c *Cluster) {
+	s.Total++
+	s.Providers[c.GetProvider()]++
+	release := c.GetReleaseVersion()
+	if release == "" {
+		release = "unknown"
+	}
+	s.Releases[release]++
+	if c.PauseState().Paused {
+		s.Paused = append(s.Paused, c.Namespace+"/"+c.Name)
+	}
+
+	if c.IsReady() {
+		s.Ready++
+		return
+	}
+	phase := c.Status.Phase
+	if phase == "" {
+		phase = "no phase"
+	}
+	s.NotReady = append(s.NotReady, fmt.Sprintf("%s/%s (%s)", c.Namespace, c.Name, phase))
+}
+
+// FleetSummary is the summary of all clusters and of the clusters of each
+// organization
+type FleetSummary struct {
+	Summary
+	Organizations map[string]*Summary
+}
+
+// Summarize counts clusters in total and per organization
+func Summarize(clusters []*Cluster) *FleetSummary {
+	fleet := &FleetSummary{
+		Summary:       newSummary(),
+		Organizations: make(map[string]*Summary),
+	}
+	for _, c := range clusters {
+		org := c.GetOrganization()
+		if org == "" {
+			org = "unknown"
+		}
+		summary, ok := fleet.Organizations[org]
+		if !ok {
+			s := newSummary()
+			summary = &s
+			fleet.Organizations[org] = summary
+		}
+		summary.add(c)
+		fleet.add(c)
+	}
+	return fleet
+}
+
+// OrganizationNames returns the organizations of the summary, sorted
+func (f *FleetSummary) OrganizationNames() []string {
+	names := make([]string, 0, len(f.Organizations))
+	for name := range f.Organizations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func newSummary() Summary {
+	return Summary{Providers: make(Counts), Releases: make(Counts)}
+}
@@ -0,0 +1,42 @@
+package cluster
+
+import (
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	ready := ClusterStatus{Phase: "Provisioned", InfrastructureReady: true, ControlPlaneReady: true}
+	clusters := []*Cluster{
+		{Name: "prod", Namespace: "org-acme", Status: ready, Labels: map[string]string{
+			"giantswarm.io/organization": "acme", "cluster.x-k8s.io/provider": "aws", ReleaseVersionLabel: "29.1.0",
+		}},
+		{Name: "dev", Namespace: "org-acme", Status: ClusterStatus{Phase: "Provisioning"}, Labels: map[string]string{
+			"giantswarm.io/organization": "acme", "cluster.x-k8s.io/provider": "aws", ReleaseVersionLabel: "30.0.0",
+		}},
+		{Name: "edge", Namespace: "org-beta", Status: ready, Spec: ClusterSpec{Paused: true}, Labels: map[string]string{
+			"giantswarm.io/organization": "beta", "cluster.x-k8s.io/provider": "azure",
+		}},
+	}
+
+	fleet := Summarize(clusters)
+	if fleet.Total != 3 || fleet.Ready != 2 {
+		t.Errorf("fleet = %d clusters, %d ready, want 3 and 2", fleet.Total, fleet.Ready)
+	}
+	if len(fleet.Paused) != 1 || fleet.Paused[0] != "org-beta/edge" {
+		t.Errorf("paused = %v, want org-beta/edge", fleet.Paused)
+	}
+	if got := fleet.Providers.String(); got != "aws 2, azure 1" {
+		t.Errorf("providers = %q", got)
+	}
+	if got := fleet.Releases.String(); got != "29.1.0 1, 30.0.0 1, unknown 1" {
+		t.Errorf("releases = %q", got)
+	}
+
+	if names := fleet.OrganizationNames(); len(names) != 2 || names[0] != "acme" {
+		t.Fatalf("organizations = %v", names)
+	}
+	acme := fleet.Organizations["acme"]
+	if acme.Total != 2 || acme.Ready != 1 || len(acme.NotReady) != 1 || acme.NotReady[0] != "org-acme/dev (Provisioning)" {
+		t.Errorf("acme = %+v", acme)
+	}
+}
@@ -5,6 +5,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
+// ClusterLabel names the workload cluster a Giant Swarm resource, e.g. an App, belongs to
+const ClusterLabel = "giantswarm.io/cluster"
+
+// ReleaseVersionLabel holds the Giant Swarm release a cluster runs
+const ReleaseVersionLabel = "release.giantswarm.io/version"
+
 // ClusterGVK is the GroupVersionKind for CAPI Cluster resources
 var ClusterGVK = schema.GroupVersionKind{
 	Group:   "cluster.x-k8s.io",
@@ -14,11 +20,12 @@ var ClusterGVK = schema.GroupVersionKind{
 
 // Cluster represents a CAPI Cluster resource
 type Cluster struct {
-	Name      string
-	Namespace string
-	Spec      ClusterSpec
-	Status    ClusterStatus
-	Labels    map[string]string
+	Name        string
+	Namespace   string
+	Spec        ClusterSpec
+	Status      ClusterStatus
+	Labels      map[string]string
+	Annotations map[string]string
 }
 
 // ClusterSpec represents the spec of a CAPI Cluster
@@ -26,6 +33,18 @@ type ClusterSpec struct {
 	ClusterNetwork    *ClusterNetwork
 	InfrastructureRef *ObjectReference
 	ControlPlaneRef   *ObjectReference
+	// ControlPlaneEndpoint is where the API server is reached; nil until the
+	// infrastructure provider has set it
+	ControlPlaneEndpoint *APIEndpoint
+	// Paused stops CAPI controllers from reconciling the cluster and all of
+	// its objects
+	Paused bool
+}
+
+// APIEndpoint is the host and port of an API server
+type APIEndpoint struct {
+	Host string
+	Port int32
 }
 
 // ClusterNetwork represents cluster networking configuration
@@ -95,12 +114,19 @@ func (c *Cluster) GetProvider() string {
 	return "unknown"
 }
 
+// GetReleaseVersion returns the Giant Swarm release of this cluster, or ""
+// when it is not labeled with one
+func (c *Cluster) GetReleaseVersion() string {
+	return c.Labels[ReleaseVersionLabel]
+}
+
 // NewClusterFromUnstructured converts an unstructured object to a Cluster
 func NewClusterFromUnstructured(obj *unstructured.Unstructured) (*Cluster, error) {
 	cluster := &Cluster{
-		Name:      obj.GetName(),
-		Namespace: obj.GetNamespace(),
-		Labels:    obj.GetLabels(),
+		Name:        obj.GetName(),
+		Namespace:   obj.GetNamespace(),
+		Labels:      obj.GetLabels(),
+		Annotations: obj.GetAnnotations(),
 	}
 
 	// Extract spec
@@ -120,6 +146,16 @@ func NewClusterFromUnstructured(obj *unstructured.Unstructured) (*Cluster, error
 		if cpRef, ok := spec["controlPlaneRef"].(map[string]interface{}); ok {
 			cluster.Spec.ControlPlaneRef = parseObjectReference(cpRef)
 		}
+
+		// ControlPlaneEndpoint
+		if endpoint, ok := spec["controlPlaneEndpoint"].(map[string]interface{}); ok {
+			cluster.Spec.ControlPlaneEndpoint = parseAPIEndpoint(endpoint)
+		}
+
+		// Paused
+		if paused, ok := spec["paused"].(bool); ok {
+			cluster.Spec.Paused = paused
+		}
 	}
 
 	// Extract status
@@ -171,6 +207,13 @@ func (c *Cluster) ToUnstructured() *unstructured.Unstructured {
 		spec["controlPlaneRef"] = objectReferenceToMap(c.Spec.ControlPlaneRef)
 	}
 
+	if endpoint := c.Spec.ControlPlaneEndpoint; endpoint != nil {
+		spec["controlPlaneEndpoint"] = map[string]interface{}{
+			"host": endpoint.Host,
+			"port": int64(endpoint.Port),
+		}
+	}
+
 	obj.Object["spec"] = spec
 
 	return obj
@@ -230,6 +273,21 @@ func parseObjectReference(data map[string]interface{}) *ObjectReference {
 	return ref
 }
 
+func parseAPIEndpoint(data map[string]interface{}) *APIEndpoint {
+	host, _ := data["host"].(string)
+	if host == "" {
+		return nil
+	}
+	endpoint := &APIEndpoint{Host: host}
+	switch port := data["port"].(type) {
+	case int64:
+		endpoint.Port = int32(port)
+	case float64:
+		endpoint.Port = int32(port)
+	}
+	return endpoint
+}
+
 func parseConditions(data []interface{}) []Condition {
 	conditions := make([]Condition, 0)
 
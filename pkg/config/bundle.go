@@ -0,0 +1,213 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Bundle identification, so that other YAML is not imported by mistake
+const (
+	BundleAPIVersion = "mcp.giantswarm.io/v1alpha1"
+	BundleKind       = "ConfigBundle"
+)
+
+// Bundle holds the ConfigMaps and Secrets of one or more apps, and which app
+// references which of them, so that the configuration can be exported from
+// one environment and imported into another
+type Bundle struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// Source describes where the bundle was exported from
+	Source string       `json:"source,omitempty"`
+	Apps   []BundleApp  `json:"apps,omitempty"`
+	Items  []BundleItem `json:"items"`
+}
+
+// BundleApp is an app whose configuration is in the bundle
+type BundleApp struct {
+	Name       string      `json:"name"`
+	Namespace  string      `json:"namespace"`
+	References []BundleRef `json:"references"`
+}
+
+// BundleRef is a reference of an app to a bundle item. Layer is config for
+// spec.config and userConfig for spec.userConfig.
+type BundleRef struct {
+	Layer     string     `json:"layer"`
+	Type      ConfigType `json:"type"`
+	Name      string     `json:"name"`
+	Namespace string     `json:"namespace"`
+}
+
+// BundleItem is a ConfigMap or Secret in a bundle. The data of secrets is
+// left out, and Redacted set, unless the export included secret values.
+type BundleItem struct {
+	Type       ConfigType        `json:"type"`
+	Name       string            `json:"name"`
+	Namespace  string            `json:"namespace"`
+	SecretType corev1.SecretType `json:"secretType,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Data       map[string]string `json:"data"`
+	Redacted   bool              `json:"redacted,omitempty"`
+}
+
+// NewBundle creates an empty bundle
+func NewBundle(source string) *Bundle {
+	return &Bundle{APIVersion: BundleAPIVersion, Kind: BundleKind, Source: source}
+}
+
+// AddConfig adds cfg to the bundle unless it is in it already. The values of
+// secrets are only kept with secretValues.
+func (b *Bundle) AddConfig(cfg *Config, secretValues bool) {
+	for _, item := range b.Items {
+		if item.Type == cfg.Type && item.Namespace == cfg.Namespace && item.Name == cfg.Name {
+			return
+		}
+	}
+
+	item := BundleItem{
+		Type:       cfg.Type,
+		Name:       cfg.Name,
+		Namespace:  cfg.Namespace,
+		SecretType: cfg.SecretType,
+		Labels:     cfg.Labels,
+		Data:       make(map[string]string, len(cfg.Data)),
+	}
+	for k, v := range cfg.Data {
+		if cfg.IsSecret() && !secretValues {
+			v = ""
+			item.Redacted = true
+		}
+		item.Data[k] = v
+	}
+	b.Items = append(b.Items, item)
+}
+
+// Marshal returns the bundle as YAML with items in a stable order
+func (b *Bundle) Marshal() (string, error) {
+	sort.Slice(b.Items, func(i, j int) bool {
+		if b.Items[i].Namespace != b.Items[j].Namespace {
+			return b.Items[i].Namespace < b.Items[j].Namespace
+		}
+		if b.Items[i].Name != b.Items[j].Name {
+			return b.Items[i].Name < b.Items[j].Name
+		}
+		return b.Items[i].Type < b.Items[j].Type
+	})
+	sort.Slice(b.Apps, func(i, j int) bool {
+		if b.Apps[i].Namespace != b.Apps[j].Namespace {
+			return b.Apps[i].Namespace < b.Apps[j].Namespace
+		}
+		return b.Apps[i].Name < b.Apps[j].Name
+	})
+
+	data, err := yaml.Marshal(b)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal config bundle: %w", err)
+	}
+	return string(data), nil
+}
+
+// ParseBundle parses a bundle written by Marshal
+func ParseBundle(data string) (*Bundle, error) {
+	var b Bundle
+	if err := yaml.UnmarshalStrict([]byte(data), &b); err != nil {
+		return nil, fmt.Errorf("failed to parse config bundle: %w", err)
+	}
+	if b.APIVersion != BundleAPIVersion || b.Kind != BundleKind {
+		return nil, fmt.Errorf("not a config bundle: expected apiVersion %s and kind %s", BundleAPIVersion, BundleKind)
+	}
+	for i, item := range b.Items {
+		if item.Name == "" || item.Namespace == "" {
+			return nil, fmt.Errorf("item %d of the config bundle has no name or namespace", i+1)
+		}
+		if item.Type != ConfigTypeConfigMap && item.Type != ConfigTypeSecret {
+			return nil, fmt.Errorf("item %s/%s has unknown type %q", item.Namespace, item.Name, item.Type)
+		}
+	}
+	return &b, nil
+}
+
+// Rename replaces Old with New in the names and namespaces of a bundle, e.g.
+// a cluster name prefix when promoting configuration from one cluster to
+// another
+type Rename struct {
+	Old string
+	New string
+}
+
+// ParseRenames parses a comma-separated list of old=new renames
+func ParseRenames(value string) ([]Rename, error) {
+	var renames []Rename
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		old, replacement, found := strings.Cut(pair, "=")
+		if !found || old == "" {
+			return nil, fmt.Errorf("invalid rename %q, expected old=new", pair)
+		}
+		renames = append(renames, Rename{Old: old, New: replacement})
+	}
+	return renames, nil
+}
+
+// ApplyRenames replaces the Old of each rename with its New in value, in order
+func ApplyRenames(value string, renames []Rename) string {
+	for _, r := range renames {
+		value = strings.ReplaceAll(value, r.Old, r.New)
+	}
+	return value
+}
+
+// Retarget returns a copy of the bundle with renames applied to all names and
+// namespaces of items, apps and references alike, so that references keep
+// pointing at the renamed items. A non-empty namespace then moves everything
+// into that namespace.
+func (b *Bundle) Retarget(namespace string, renames []Rename) *Bundle {
+	retarget := func(ns, name string) (string, string) {
+		ns, name = ApplyRenames(ns, renames), ApplyRenames(name, renames)
+		if namespace != "" {
+			ns = namespace
+		}
+		return ns, name
+	}
+
+	out := NewBundle(b.Source)
+	for _, item := range b.Items {
+		item.Namespace, item.Name = retarget(item.Namespace, item.Name)
+		out.Items = append(out.Items, item)
+	}
+	for _, a := range b.Apps {
+		a.Namespace, a.Name = retarget(a.Namespace, a.Name)
+		refs := make([]BundleRef, 0, len(a.References))
+		for _, ref := range a.References {
+			ref.Namespace, ref.Name = retarget(ref.Namespace, ref.Name)
+			refs = append(refs, ref)
+		}
+		a.References = refs
+		out.Apps = append(out.Apps, a)
+	}
+	return out
+}
+
+// Config converts a bundle item to a Config
+func (i BundleItem) Config() *Config {
+	data := make(map[string]string, len(i.Data))
+	for k, v := range i.Data {
+		data[k] = v
+	}
+	return &Config{
+		Name:       i.Name,
+		Namespace:  i.Namespace,
+		Type:       i.Type,
+		Data:       data,
+		Labels:     i.Labels,
+		SecretType: i.SecretType,
+	}
+}
@@ -0,0 +1,58 @@
+package config
+
+import "testing"
+
+func TestBundleRoundTrip(t *testing.T) {
+	bundle := NewBundle("org-staging")
+	bundle.AddConfig(&Config{Name: "staging-ingress-user-values", Namespace: "org-staging", Type: ConfigTypeConfigMap,
+		Data: map[string]string{"values": "replicas: 2\n"}}, false)
+	bundle.AddConfig(&Config{Name: "staging-ingress-user-secrets", Namespace: "org-staging", Type: ConfigTypeSecret,
+		Data: map[string]string{"values": "password: hunter2\n"}}, false)
+	// Shared resources are added once
+	bundle.AddConfig(&Config{Name: "staging-ingress-user-values", Namespace: "org-staging", Type: ConfigTypeConfigMap}, false)
+	bundle.Apps = []BundleApp{{Name: "staging-ingress", Namespace: "org-staging", References: []BundleRef{
+		{Layer: "userConfig", Type: ConfigTypeConfigMap, Name: "staging-ingress-user-values", Namespace: "org-staging"},
+	}}}
+
+	data, err := bundle.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseBundle(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed.Items) != 2 {
+		t.Fatalf("got %d items, want 2", len(parsed.Items))
+	}
+	secret := parsed.Items[0]
+	if secret.Type != ConfigTypeSecret || !secret.Redacted || secret.Data["values"] != "" {
+		t.Errorf("secret item = %+v, want redacted", secret)
+	}
+
+	renames, err := ParseRenames("staging=prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	retargeted := parsed.Retarget("", renames)
+	if item := retargeted.Items[1]; item.Name != "prod-ingress-user-values" || item.Namespace != "org-prod" {
+		t.Errorf("retargeted item = %s/%s", item.Namespace, item.Name)
+	}
+	ref := retargeted.Apps[0].References[0]
+	if retargeted.Apps[0].Name != "prod-ingress" || ref.Name != "prod-ingress-user-values" || ref.Namespace != "org-prod" {
+		t.Errorf("retargeted app = %+v", retargeted.Apps[0])
+	}
+
+	if moved := parsed.Retarget("org-other", nil); moved.Items[0].Namespace != "org-other" || moved.Apps[0].References[0].Namespace != "org-other" {
+		t.Errorf("namespace was not applied: %+v", moved)
+	}
+}
+
+func TestParseBundleRejectsOtherYAML(t *testing.T) {
+	if _, err := ParseBundle("apiVersion: v1\nkind: ConfigMap\n"); err == nil {
+		t.Error("ConfigMap was accepted as a bundle")
+	}
+	if _, err := ParseRenames("staging"); err == nil {
+		t.Error("rename without = was accepted")
+	}
+}
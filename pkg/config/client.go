@@ -2,18 +2,28 @@ package config
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
+	"sort"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
 	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
 )
 
 // Client provides operations for ConfigMaps and Secrets
 type Client struct {
 	k8sClient kubernetes.Interface
+
+	// secretChanged is called after a Secret was created, updated or deleted
+	secretChanged func(namespace, name string)
 }
 
 // NewClient creates a new config client
@@ -23,6 +33,20 @@ func NewClient(k8sClient kubernetes.Interface) *Client {
 	}
 }
 
+// WithSecretHook makes the client call changed after it created, updated or
+// deleted a Secret, e.g. to drop clients built from a kubeconfig secret
+func (c *Client) WithSecretHook(changed func(namespace, name string)) *Client {
+	c.secretChanged = changed
+	return c
+}
+
+// notifySecretChanged calls the secret hook, if any
+func (c *Client) notifySecretChanged(namespace, name string) {
+	if c.secretChanged != nil {
+		c.secretChanged(namespace, name)
+	}
+}
+
 // GetConfigMap retrieves a ConfigMap
 func (c *Client) GetConfigMap(ctx context.Context, namespace, name string) (*Config, error) {
 	cm, err := c.k8sClient.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
@@ -55,23 +79,41 @@ func (c *Client) Get(ctx context.Context, namespace, name string, configType Con
 	}
 }
 
-// CreateConfigMap creates a new ConfigMap
+// CreateConfigMap creates a new ConfigMap with server-side apply, so that
+// later updates own its fields without forcing
 func (c *Client) CreateConfigMap(ctx context.Context, config *Config) error {
-	cm := config.ToConfigMap()
-	_, err := c.k8sClient.CoreV1().ConfigMaps(config.Namespace).Create(ctx, cm, metav1.CreateOptions{})
+	_, err := c.k8sClient.CoreV1().ConfigMaps(config.Namespace).Get(ctx, config.Name, metav1.GetOptions{})
+	if err = k8s.EnsureAbsent(err, schema.GroupResource{Resource: "configmaps"}, config.Name); err != nil {
+		return fmt.Errorf("failed to create configmap %s/%s: %w", config.Namespace, config.Name, err)
+	}
+
+	applyConfig := corev1ac.ConfigMap(config.Name, config.Namespace).
+		WithLabels(config.Labels).
+		WithData(config.Data)
+	_, err = c.k8sClient.CoreV1().ConfigMaps(config.Namespace).Apply(ctx, applyConfig, k8s.UpdateOptions{}.ApplyOptions())
 	if err != nil {
 		return fmt.Errorf("failed to create configmap %s/%s: %w", config.Namespace, config.Name, err)
 	}
 	return nil
 }
 
-// CreateSecret creates a new Secret
+// CreateSecret creates a new Secret with server-side apply, see CreateConfigMap
 func (c *Client) CreateSecret(ctx context.Context, config *Config) error {
+	_, err := c.k8sClient.CoreV1().Secrets(config.Namespace).Get(ctx, config.Name, metav1.GetOptions{})
+	if err = k8s.EnsureAbsent(err, schema.GroupResource{Resource: "secrets"}, config.Name); err != nil {
+		return fmt.Errorf("failed to create secret %s/%s: %w", config.Namespace, config.Name, err)
+	}
+
 	secret := config.ToSecret()
-	_, err := c.k8sClient.CoreV1().Secrets(config.Namespace).Create(ctx, secret, metav1.CreateOptions{})
+	applyConfig := corev1ac.Secret(config.Name, config.Namespace).
+		WithLabels(config.Labels).
+		WithType(secret.Type).
+		WithData(secret.Data)
+	_, err = c.k8sClient.CoreV1().Secrets(config.Namespace).Apply(ctx, applyConfig, k8s.UpdateOptions{}.ApplyOptions())
 	if err != nil {
 		return fmt.Errorf("failed to create secret %s/%s: %w", config.Namespace, config.Name, err)
 	}
+	c.notifySecretChanged(config.Namespace, config.Name)
 	return nil
 }
 
@@ -87,54 +129,148 @@ func (c *Client) Create(ctx context.Context, config *Config) error {
 	}
 }
 
-// UpdateConfigMap updates an existing ConfigMap
-func (c *Client) UpdateConfigMap(ctx context.Context, config *Config) error {
-	cm := config.ToConfigMap()
+// UpdateConfigMap replaces the data and labels of an existing ConfigMap using
+// server-side apply. previousKeys are the data keys the ConfigMap had when it
+// was read; keys that were dropped from config.Data are removed afterwards.
+func (c *Client) UpdateConfigMap(ctx context.Context, config *Config, previousKeys []string, opts k8s.UpdateOptions) error {
+	applyConfig := corev1ac.ConfigMap(config.Name, config.Namespace).
+		WithLabels(config.Labels).
+		WithData(config.Data)
+	if rv := opts.Precondition(config.ResourceVersion); rv != "" {
+		applyConfig.WithResourceVersion(rv)
+	}
 
-	// Get current to preserve metadata
-	current, err := c.k8sClient.CoreV1().ConfigMaps(config.Namespace).Get(ctx, config.Name, metav1.GetOptions{})
+	_, err := c.k8sClient.CoreV1().ConfigMaps(config.Namespace).Apply(ctx, applyConfig, opts.ApplyOptions())
 	if err != nil {
-		return fmt.Errorf("failed to get current configmap: %w", err)
+		return fmt.Errorf("failed to update configmap %s/%s: %w", config.Namespace, config.Name, err)
+	}
+
+	// Apply keeps keys owned by other managers, remove the ones that were dropped
+	if stale := staleKeys(previousKeys, config.Data); len(stale) > 0 {
+		return c.removeDataKeys(ctx, config.Namespace, config.Name, ConfigTypeConfigMap, stale)
 	}
 
-	cm.ResourceVersion = current.ResourceVersion
+	return nil
+}
 
-	_, err = c.k8sClient.CoreV1().ConfigMaps(config.Namespace).Update(ctx, cm, metav1.UpdateOptions{})
+// UpdateSecret replaces the data and labels of an existing Secret using
+// server-side apply, see UpdateConfigMap
+func (c *Client) UpdateSecret(ctx context.Context, config *Config, previousKeys []string, opts k8s.UpdateOptions) error {
+	data := make(map[string][]byte, len(config.Data))
+	for k, v := range config.Data {
+		data[k] = []byte(v)
+	}
+
+	applyConfig := corev1ac.Secret(config.Name, config.Namespace).
+		WithLabels(config.Labels).
+		WithData(data)
+	if rv := opts.Precondition(config.ResourceVersion); rv != "" {
+		applyConfig.WithResourceVersion(rv)
+	}
+
+	_, err := c.k8sClient.CoreV1().Secrets(config.Namespace).Apply(ctx, applyConfig, opts.ApplyOptions())
 	if err != nil {
-		return fmt.Errorf("failed to update configmap %s/%s: %w", config.Namespace, config.Name, err)
+		return fmt.Errorf("failed to update secret %s/%s: %w", config.Namespace, config.Name, err)
 	}
+	c.notifySecretChanged(config.Namespace, config.Name)
+
+	if stale := staleKeys(previousKeys, config.Data); len(stale) > 0 {
+		return c.removeDataKeys(ctx, config.Namespace, config.Name, ConfigTypeSecret, stale)
+	}
+
 	return nil
 }
 
-// UpdateSecret updates an existing Secret
-func (c *Client) UpdateSecret(ctx context.Context, config *Config) error {
-	secret := config.ToSecret()
+// Update re-reads a configuration (ConfigMap or Secret), applies mutate to it
+// and writes it back. When the object changed in between it is read again and
+// mutate re-applied, so concurrent changes to other keys are not lost.
+func (c *Client) Update(ctx context.Context, namespace, name string, configType ConfigType, opts k8s.UpdateOptions, mutate func(*Config) error) error {
+	err := k8s.RetryOnConflict(func() error {
+		current, err := c.Get(ctx, namespace, name, configType)
+		if err != nil {
+			return err
+		}
 
-	// Get current to preserve metadata
-	current, err := c.k8sClient.CoreV1().Secrets(config.Namespace).Get(ctx, config.Name, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to get current secret: %w", err)
+		previousKeys := make([]string, 0, len(current.Data))
+		for k := range current.Data {
+			previousKeys = append(previousKeys, k)
+		}
+
+		if err := mutate(current); err != nil {
+			return err
+		}
+
+		switch configType {
+		case ConfigTypeConfigMap:
+			return c.UpdateConfigMap(ctx, current, previousKeys, opts)
+		case ConfigTypeSecret:
+			return c.UpdateSecret(ctx, current, previousKeys, opts)
+		default:
+			return fmt.Errorf("unknown config type: %s", configType)
+		}
+	})
+
+	return k8s.ConflictError(string(configType), namespace, name, err)
+}
+
+// staleKeys returns the previous keys that are not present in desired
+func staleKeys(previous []string, desired map[string]string) []string {
+	stale := make([]string, 0)
+	for _, k := range previous {
+		if _, ok := desired[k]; !ok {
+			stale = append(stale, k)
+		}
 	}
+	sort.Strings(stale)
+	return stale
+}
 
-	secret.ResourceVersion = current.ResourceVersion
+// removeDataKeys deletes data keys with a merge patch
+func (c *Client) removeDataKeys(ctx context.Context, namespace, name string, configType ConfigType, keys []string) error {
+	data := make(map[string]interface{}, len(keys))
+	for _, k := range keys {
+		data[k] = nil
+	}
 
-	_, err = c.k8sClient.CoreV1().Secrets(config.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	patch, err := json.Marshal(map[string]interface{}{"data": data})
 	if err != nil {
-		return fmt.Errorf("failed to update secret %s/%s: %w", config.Namespace, config.Name, err)
+		return fmt.Errorf("failed to build patch: %w", err)
+	}
+
+	switch configType {
+	case ConfigTypeConfigMap:
+		_, err = c.k8sClient.CoreV1().ConfigMaps(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: k8s.FieldManager})
+	case ConfigTypeSecret:
+		_, err = c.k8sClient.CoreV1().Secrets(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: k8s.FieldManager})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove keys from %s %s/%s: %w", configType, namespace, name, err)
 	}
+
 	return nil
 }
 
-// Update updates an existing configuration (ConfigMap or Secret)
-func (c *Client) Update(ctx context.Context, config *Config) error {
-	switch config.Type {
+// MergeMetadata sets labels and annotations on a ConfigMap or Secret, keeping
+// the ones it already has
+func (c *Client) MergeMetadata(ctx context.Context, namespace, name string, configType ConfigType, labels, annotations map[string]string) error {
+	patch, err := k8s.MetadataMergePatch(labels, annotations)
+	if err != nil || patch == nil {
+		return err
+	}
+
+	switch configType {
 	case ConfigTypeConfigMap:
-		return c.UpdateConfigMap(ctx, config)
+		_, err = c.k8sClient.CoreV1().ConfigMaps(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: k8s.FieldManager})
 	case ConfigTypeSecret:
-		return c.UpdateSecret(ctx, config)
+		_, err = c.k8sClient.CoreV1().Secrets(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: k8s.FieldManager})
 	default:
-		return fmt.Errorf("unknown config type: %s", config.Type)
+		return fmt.Errorf("unknown config type: %s", configType)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update metadata of %s %s/%s: %w", configType, namespace, name, err)
 	}
+
+	return nil
 }
 
 // DeleteConfigMap deletes a ConfigMap
@@ -152,6 +288,7 @@ func (c *Client) DeleteSecret(ctx context.Context, namespace, name string) error
 	if err != nil && !errors.IsNotFound(err) {
 		return fmt.Errorf("failed to delete secret %s/%s: %w", namespace, name, err)
 	}
+	c.notifySecretChanged(namespace, name)
 	return nil
 }
 
@@ -167,12 +304,18 @@ func (c *Client) Delete(ctx context.Context, namespace, name string, configType
 	}
 }
 
-// ListConfigMaps lists ConfigMaps in a namespace
-func (c *Client) ListConfigMaps(ctx context.Context, namespace string, labelSelector string) ([]*Config, error) {
-	listOptions := metav1.ListOptions{}
-	if labelSelector != "" {
-		listOptions.LabelSelector = labelSelector
+// withoutRevisions extends a label selector to skip the revisions recorded by
+// History, which are not configurations of their own
+func withoutRevisions(labelSelector string) string {
+	if labelSelector == "" {
+		return "!" + HistoryOfLabel
 	}
+	return labelSelector + ",!" + HistoryOfLabel
+}
+
+// ListConfigMaps lists ConfigMaps in a namespace, except recorded revisions
+func (c *Client) ListConfigMaps(ctx context.Context, namespace string, labelSelector string) ([]*Config, error) {
+	listOptions := metav1.ListOptions{LabelSelector: withoutRevisions(labelSelector)}
 
 	cmList, err := c.k8sClient.CoreV1().ConfigMaps(namespace).List(ctx, listOptions)
 	if err != nil {
@@ -187,12 +330,9 @@ func (c *Client) ListConfigMaps(ctx context.Context, namespace string, labelSele
 	return configs, nil
 }
 
-// ListSecrets lists Secrets in a namespace
+// ListSecrets lists Secrets in a namespace, except recorded revisions
 func (c *Client) ListSecrets(ctx context.Context, namespace string, labelSelector string) ([]*Config, error) {
-	listOptions := metav1.ListOptions{}
-	if labelSelector != "" {
-		listOptions.LabelSelector = labelSelector
-	}
+	listOptions := metav1.ListOptions{LabelSelector: withoutRevisions(labelSelector)}
 
 	secretList, err := c.k8sClient.CoreV1().Secrets(namespace).List(ctx, listOptions)
 	if err != nil {
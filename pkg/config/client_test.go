@@ -0,0 +1,141 @@
+package config
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+)
+
+func newTestConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "hello-values",
+			Namespace:   "org-acme",
+			Annotations: map[string]string{"owner": "team-a"},
+		},
+		Data: map[string]string{"replicas": "1", "obsolete": "true"},
+	}
+}
+
+// newTestClient returns a client for a ConfigMap created through it, so that
+// the server owns its data
+func newTestClient(t *testing.T) (*Client, *fake.Clientset) {
+	t.Helper()
+	k8sClient := fake.NewClientset()
+	client := NewClient(k8sClient)
+	cm := newTestConfigMap()
+	if err := client.Create(context.Background(), &Config{Name: cm.Name, Namespace: cm.Namespace, Type: ConfigTypeConfigMap, Data: cm.Data}); err != nil {
+		t.Fatal(err)
+	}
+	// Annotations set by someone else
+	if _, err := k8sClient.CoreV1().ConfigMaps(cm.Namespace).Patch(context.Background(), cm.Name, types.MergePatchType,
+		[]byte(`{"metadata":{"annotations":{"owner":"team-a"}}}`), metav1.PatchOptions{FieldManager: "kubectl-annotate"}); err != nil {
+		t.Fatal(err)
+	}
+	return client, k8sClient
+}
+
+func TestUpdateReplacesData(t *testing.T) {
+	ctx := context.Background()
+	client, k8sClient := newTestClient(t)
+
+	err := client.Update(ctx, "org-acme", "hello-values", ConfigTypeConfigMap, k8s.UpdateOptions{}, func(cfg *Config) error {
+		cfg.Data = map[string]string{"replicas": "3"}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	cm, err := k8sClient.CoreV1().ConfigMaps("org-acme").Get(ctx, "hello-values", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cm.Data, map[string]string{"replicas": "3"}) {
+		t.Errorf("data = %v", cm.Data)
+	}
+	if cm.Annotations["owner"] != "team-a" {
+		t.Errorf("annotations not preserved: %v", cm.Annotations)
+	}
+}
+
+func TestUpdateFieldOwnedByOtherManager(t *testing.T) {
+	ctx := context.Background()
+	// Created with kubectl, so another field manager owns the data
+	k8sClient := fake.NewClientset(newTestConfigMap())
+	client := NewClient(k8sClient)
+
+	mutate := func(cfg *Config) error {
+		cfg.SetValue("replicas", "2")
+		return nil
+	}
+	if err := client.Update(ctx, "org-acme", "hello-values", ConfigTypeConfigMap, k8s.UpdateOptions{}, mutate); !k8s.IsFieldManagerConflict(err) {
+		t.Fatalf("Update() error = %v, want a field manager conflict", err)
+	}
+	if err := client.Update(ctx, "org-acme", "hello-values", ConfigTypeConfigMap, k8s.UpdateOptions{Force: true}, mutate); err != nil {
+		t.Fatalf("forced Update() error = %v", err)
+	}
+
+	cm, err := k8sClient.CoreV1().ConfigMaps("org-acme").Get(ctx, "hello-values", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cm.Data["replicas"] != "2" {
+		t.Errorf("data = %v", cm.Data)
+	}
+}
+
+func TestCreateRefusesExisting(t *testing.T) {
+	client, _ := newTestClient(t)
+	err := client.Create(context.Background(), &Config{Name: "hello-values", Namespace: "org-acme", Type: ConfigTypeConfigMap})
+	if !apierrors.IsAlreadyExists(err) {
+		t.Errorf("Create() error = %v, want already exists", err)
+	}
+}
+
+func TestUpdateRetriesOnConflict(t *testing.T) {
+	ctx := context.Background()
+	client, k8sClient := newTestClient(t)
+
+	// Fail the first apply as if another writer got in between
+	conflicts := 1
+	k8sClient.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.(k8stesting.PatchAction).GetPatchType() != "application/apply-patch+yaml" || conflicts == 0 {
+			return false, nil, nil
+		}
+		conflicts--
+		return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "hello-values", nil)
+	})
+
+	calls := 0
+	err := client.Update(ctx, "org-acme", "hello-values", ConfigTypeConfigMap, k8s.UpdateOptions{}, func(cfg *Config) error {
+		calls++
+		cfg.SetValue("replicas", "2")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected mutate to run twice, ran %d times", calls)
+	}
+
+	cm, err := k8sClient.CoreV1().ConfigMaps("org-acme").Get(ctx, "hello-values", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cm.Data["replicas"] != "2" || cm.Data["obsolete"] != "true" {
+		t.Errorf("data = %v", cm.Data)
+	}
+}
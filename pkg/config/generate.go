@@ -0,0 +1,90 @@
+package config
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// passwordAlphabet avoids characters that need quoting in YAML or shells
+const passwordAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// MinPasswordLength is the shortest password GeneratePassword creates
+const MinPasswordLength = 8
+
+// GeneratePassword returns a random alphanumeric password
+func GeneratePassword(length int) (string, error) {
+	if length < MinPasswordLength {
+		return "", fmt.Errorf("password length must be at least %d", MinPasswordLength)
+	}
+
+	password := make([]byte, length)
+	limit := big.NewInt(int64(len(passwordAlphabet)))
+	for i := range password {
+		n, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate password: %w", err)
+		}
+		password[i] = passwordAlphabet[n.Int64()]
+	}
+
+	return string(password), nil
+}
+
+// TLSSecretData returns the data of a kubernetes.io/tls Secret after checking
+// that the PEM encoded certificate and key belong together
+func TLSSecretData(certPEM, keyPEM []byte) (map[string]string, error) {
+	if _, err := tls.X509KeyPair(certPEM, keyPEM); err != nil {
+		return nil, fmt.Errorf("invalid TLS certificate and key: %w", err)
+	}
+
+	return map[string]string{
+		corev1.TLSCertKey:       string(certPEM),
+		corev1.TLSPrivateKeyKey: string(keyPEM),
+	}, nil
+}
+
+// DockerRegistry holds the credentials of a kubernetes.io/dockerconfigjson Secret
+type DockerRegistry struct {
+	Server   string
+	Username string
+	Password string
+	Email    string
+}
+
+// DockerConfigSecretData returns the data of a kubernetes.io/dockerconfigjson
+// Secret, in the format kubectl create secret docker-registry produces
+func DockerConfigSecretData(registry DockerRegistry) (map[string]string, error) {
+	if registry.Server == "" || registry.Username == "" || registry.Password == "" {
+		return nil, fmt.Errorf("docker registry server, username and password are required")
+	}
+
+	type auth struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Email    string `json:"email,omitempty"`
+		Auth     string `json:"auth"`
+	}
+	dockerConfig := map[string]map[string]auth{
+		"auths": {
+			registry.Server: {
+				Username: registry.Username,
+				Password: registry.Password,
+				Email:    registry.Email,
+				Auth:     base64.StdEncoding.EncodeToString([]byte(registry.Username + ":" + registry.Password)),
+			},
+		},
+	}
+
+	data, err := json.Marshal(dockerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal docker config: %w", err)
+	}
+
+	return map[string]string{corev1.DockerConfigJsonKey: string(data)}, nil
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	password, err := GeneratePassword(32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(password) != 32 {
+		t.Errorf("len(password) = %d, want 32", len(password))
+	}
+	if other, _ := GeneratePassword(32); other == password {
+		t.Error("two generated passwords are equal")
+	}
+	if _, err := GeneratePassword(4); err == nil {
+		t.Error("GeneratePassword(4) succeeded, want error")
+	}
+}
+
+func TestTLSSecretData(t *testing.T) {
+	newKeyPair := func() ([]byte, []byte) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "example.com"},
+			NotBefore:    time.Now(),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	}
+
+	cert, key := newKeyPair()
+	data, err := TLSSecretData(cert, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data[corev1.TLSCertKey] != string(cert) || data[corev1.TLSPrivateKeyKey] != string(key) {
+		t.Errorf("TLSSecretData() = %v", data)
+	}
+
+	_, otherKey := newKeyPair()
+	if _, err := TLSSecretData(cert, otherKey); err == nil {
+		t.Error("TLSSecretData() with a mismatched key succeeded, want error")
+	}
+}
+
+func TestDockerConfigSecretData(t *testing.T) {
+	data, err := DockerConfigSecretData(DockerRegistry{Server: "gsoci.azurecr.io", Username: "robot", Password: "s3cret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dockerConfig struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal([]byte(data[corev1.DockerConfigJsonKey]), &dockerConfig); err != nil {
+		t.Fatal(err)
+	}
+	if got := dockerConfig.Auths["gsoci.azurecr.io"]; got.Username != "robot" || got.Auth != "cm9ib3Q6czNjcmV0" {
+		t.Errorf("auth = %+v", got)
+	}
+
+	if _, err := DockerConfigSecretData(DockerRegistry{Server: "gsoci.azurecr.io"}); err == nil {
+		t.Error("DockerConfigSecretData() without credentials succeeded, want error")
+	}
+}
@@ -0,0 +1,281 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// HistoryOfLabel marks a snapshot with the (hashed, if too long) name of the object it was taken from
+	HistoryOfLabel = "apps.mcp.giantswarm.io/history-of"
+
+	// HistoryRevisionLabel holds the revision number of a snapshot
+	HistoryRevisionLabel = "apps.mcp.giantswarm.io/revision"
+
+	// HistorySourceAnnotation holds the full name of the object a snapshot was taken from
+	HistorySourceAnnotation = "apps.mcp.giantswarm.io/source"
+
+	// HistoryCreatedAnnotation holds the time a snapshot was taken
+	HistoryCreatedAnnotation = "apps.mcp.giantswarm.io/created-at"
+)
+
+// Revision is a snapshot of the data of a ConfigMap or Secret before it was changed
+type Revision struct {
+	Number   int
+	Snapshot string
+	Created  time.Time
+	Data     map[string]string
+}
+
+// History keeps previous revisions of ConfigMaps and Secrets as shadow objects
+// of the same type next to them. Secret revisions are stored as Secrets so their
+// data is never exposed in a ConfigMap.
+type History struct {
+	k8sClient kubernetes.Interface
+	limit     int
+}
+
+// NewHistory creates a history that keeps at most limit revisions per object.
+// A limit of 0 or less disables snapshotting.
+func NewHistory(k8sClient kubernetes.Interface, limit int) *History {
+	return &History{
+		k8sClient: k8sClient,
+		limit:     limit,
+	}
+}
+
+// Enabled reports whether revisions are recorded
+func (h *History) Enabled() bool {
+	return h.limit > 0
+}
+
+// Snapshot records the current data of a configuration as a new revision and
+// prunes revisions beyond the limit. Take it before changing the
+// configuration; data equal to the latest revision is not recorded again.
+func (h *History) Snapshot(ctx context.Context, cfg *Config) (*Revision, error) {
+	if !h.Enabled() {
+		return nil, nil
+	}
+	if cfg.Labels[HistoryOfLabel] != "" {
+		return nil, fmt.Errorf("%s %s/%s is a recorded revision, change the original or roll it back instead", cfg.Type, cfg.Namespace, cfg.Name)
+	}
+
+	revisions, err := h.List(ctx, cfg.Namespace, cfg.Name, cfg.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	number := 1
+	if len(revisions) > 0 {
+		if maps.Equal(revisions[0].Data, cfg.Data) {
+			return revisions[0], nil
+		}
+		number = revisions[0].Number + 1
+	}
+
+	rev := &Revision{
+		Number:   number,
+		Snapshot: fmt.Sprintf("%s-history-%d", cfg.Name, number),
+		Created:  time.Now().UTC(),
+		Data:     make(map[string]string, len(cfg.Data)),
+	}
+	for k, v := range cfg.Data {
+		rev.Data[k] = v
+	}
+	if errs := validation.IsDNS1123Subdomain(rev.Snapshot); len(errs) > 0 {
+		return nil, fmt.Errorf("cannot record revisions of %s %s/%s: snapshot name %s is invalid: %s", cfg.Type, cfg.Namespace, cfg.Name, rev.Snapshot, strings.Join(errs, ", "))
+	}
+	if err := h.ensureFree(ctx, cfg, rev.Snapshot); err != nil {
+		return nil, err
+	}
+
+	meta := metav1.ObjectMeta{
+		Name:      rev.Snapshot,
+		Namespace: cfg.Namespace,
+		Labels: map[string]string{
+			HistoryOfLabel:       historyLabelValue(cfg.Name),
+			HistoryRevisionLabel: strconv.Itoa(number),
+		},
+		Annotations: map[string]string{
+			HistorySourceAnnotation:  cfg.Name,
+			HistoryCreatedAnnotation: rev.Created.Format(time.RFC3339),
+		},
+	}
+
+	switch cfg.Type {
+	case ConfigTypeConfigMap:
+		_, err = h.k8sClient.CoreV1().ConfigMaps(cfg.Namespace).Create(ctx, &corev1.ConfigMap{ObjectMeta: meta, Data: rev.Data}, metav1.CreateOptions{})
+	case ConfigTypeSecret:
+		data := make(map[string][]byte, len(rev.Data))
+		for k, v := range rev.Data {
+			data[k] = []byte(v)
+		}
+		_, err = h.k8sClient.CoreV1().Secrets(cfg.Namespace).Create(ctx, &corev1.Secret{ObjectMeta: meta, Type: corev1.SecretTypeOpaque, Data: data}, metav1.CreateOptions{})
+	default:
+		return nil, fmt.Errorf("unknown config type: %s", cfg.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot %s %s/%s: %w", cfg.Type, cfg.Namespace, cfg.Name, err)
+	}
+
+	// Drop the oldest revisions, the new one is not part of the list yet
+	for i := h.limit - 1; i < len(revisions); i++ {
+		if err := h.delete(ctx, cfg.Namespace, revisions[i].Snapshot, cfg.Type); err != nil {
+			return rev, err
+		}
+	}
+
+	return rev, nil
+}
+
+// List returns the recorded revisions of a configuration, newest first
+func (h *History) List(ctx context.Context, namespace, name string, configType ConfigType) ([]*Revision, error) {
+	listOptions := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", HistoryOfLabel, historyLabelValue(name)),
+	}
+
+	revisions := make([]*Revision, 0)
+	switch configType {
+	case ConfigTypeConfigMap:
+		list, err := h.k8sClient.CoreV1().ConfigMaps(namespace).List(ctx, listOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list revisions of configmap %s/%s: %w", namespace, name, err)
+		}
+		for _, cm := range list.Items {
+			if rev := newRevision(cm.ObjectMeta, name, cm.Data); rev != nil {
+				revisions = append(revisions, rev)
+			}
+		}
+	case ConfigTypeSecret:
+		list, err := h.k8sClient.CoreV1().Secrets(namespace).List(ctx, listOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list revisions of secret %s/%s: %w", namespace, name, err)
+		}
+		for _, secret := range list.Items {
+			data := make(map[string]string, len(secret.Data))
+			for k, v := range secret.Data {
+				data[k] = string(v)
+			}
+			if rev := newRevision(secret.ObjectMeta, name, data); rev != nil {
+				revisions = append(revisions, rev)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown config type: %s", configType)
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Number > revisions[j].Number
+	})
+
+	return revisions, nil
+}
+
+// Get returns a single revision of a configuration
+func (h *History) Get(ctx context.Context, namespace, name string, configType ConfigType, number int) (*Revision, error) {
+	revisions, err := h.List(ctx, namespace, name, configType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rev := range revisions {
+		if rev.Number == number {
+			return rev, nil
+		}
+	}
+
+	return nil, fmt.Errorf("revision %d of %s %s/%s not found", number, configType, namespace, name)
+}
+
+// ensureFree refuses to snapshot into an object that is not a revision of
+// cfg, e.g. a ConfigMap a user named like a snapshot
+func (h *History) ensureFree(ctx context.Context, cfg *Config, name string) error {
+	var meta metav1.ObjectMeta
+	switch cfg.Type {
+	case ConfigTypeConfigMap:
+		cm, err := h.k8sClient.CoreV1().ConfigMaps(cfg.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return ignoreNotFound(err)
+		}
+		meta = cm.ObjectMeta
+	case ConfigTypeSecret:
+		secret, err := h.k8sClient.CoreV1().Secrets(cfg.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return ignoreNotFound(err)
+		}
+		meta = secret.ObjectMeta
+	}
+
+	if meta.Annotations[HistorySourceAnnotation] != cfg.Name {
+		return fmt.Errorf("cannot record a revision of %s %s/%s: %s already exists and is not one of its revisions", cfg.Type, cfg.Namespace, cfg.Name, name)
+	}
+	return fmt.Errorf("cannot record a revision of %s %s/%s: revision %s already exists", cfg.Type, cfg.Namespace, cfg.Name, name)
+}
+
+func ignoreNotFound(err error) error {
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (h *History) delete(ctx context.Context, namespace, name string, configType ConfigType) error {
+	var err error
+	switch configType {
+	case ConfigTypeConfigMap:
+		err = h.k8sClient.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	case ConfigTypeSecret:
+		err = h.k8sClient.CoreV1().Secrets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to prune revision %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// newRevision reads a snapshot, skipping objects of another source whose
+// name hashed to the same label value
+func newRevision(meta metav1.ObjectMeta, source string, data map[string]string) *Revision {
+	if meta.Annotations[HistorySourceAnnotation] != source {
+		return nil
+	}
+
+	number, err := strconv.Atoi(meta.Labels[HistoryRevisionLabel])
+	if err != nil {
+		return nil
+	}
+
+	rev := &Revision{
+		Number:   number,
+		Snapshot: meta.Name,
+		Data:     data,
+	}
+	if created, err := time.Parse(time.RFC3339, meta.Annotations[HistoryCreatedAnnotation]); err == nil {
+		rev.Created = created
+	}
+
+	return rev
+}
+
+// historyLabelValue returns the name itself when it is a valid label value and
+// a hash of it otherwise, since object names can be longer than label values
+func historyLabelValue(name string) string {
+	if len(validation.IsValidLabelValue(name)) == 0 {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:])[:validation.LabelValueMaxLength]
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestHistorySnapshotAndPrune(t *testing.T) {
+	ctx := context.Background()
+	history := NewHistory(fake.NewClientset(), 2)
+
+	for _, replicas := range []string{"1", "2", "3"} {
+		cfg := &Config{
+			Name:      "hello-values",
+			Namespace: "org-acme",
+			Type:      ConfigTypeConfigMap,
+			Data:      map[string]string{"replicas": replicas},
+		}
+		if _, err := history.Snapshot(ctx, cfg); err != nil {
+			t.Fatalf("Snapshot() error = %v", err)
+		}
+	}
+
+	revisions, err := history.List(ctx, "org-acme", "hello-values", ConfigTypeConfigMap)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	numbers := make([]int, 0, len(revisions))
+	for _, rev := range revisions {
+		numbers = append(numbers, rev.Number)
+	}
+	if !reflect.DeepEqual(numbers, []int{3, 2}) {
+		t.Fatalf("revisions = %v, want [3 2]", numbers)
+	}
+	if revisions[0].Data["replicas"] != "3" {
+		t.Errorf("latest revision data = %v", revisions[0].Data)
+	}
+
+	if _, err := history.Get(ctx, "org-acme", "hello-values", ConfigTypeConfigMap, 1); err == nil {
+		t.Error("expected pruned revision 1 to be gone")
+	}
+}
+
+func TestHistorySecretRevisionsStayInSecrets(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := fake.NewClientset()
+	history := NewHistory(k8sClient, 5)
+
+	cfg := &Config{
+		Name:      "hello-secrets",
+		Namespace: "org-acme",
+		Type:      ConfigTypeSecret,
+		Data:      map[string]string{"token": "s3cr3t"},
+	}
+	if _, err := history.Snapshot(ctx, cfg); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+
+	configMaps, err := k8sClient.CoreV1().ConfigMaps("org-acme").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configMaps.Items) != 0 {
+		t.Errorf("secret revision stored in a configmap")
+	}
+
+	rev, err := history.Get(ctx, "org-acme", "hello-secrets", ConfigTypeSecret, 1)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if rev.Data["token"] != "s3cr3t" {
+		t.Errorf("data = %v", rev.Data)
+	}
+}
+
+func TestHistorySnapshotGuards(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := fake.NewClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello-values-history-1", Namespace: "org-acme"},
+	})
+	history := NewHistory(k8sClient, 5)
+
+	cfg := &Config{
+		Name:      "hello-values",
+		Namespace: "org-acme",
+		Type:      ConfigTypeConfigMap,
+		Data:      map[string]string{"replicas": "1"},
+	}
+	if _, err := history.Snapshot(ctx, cfg); err == nil {
+		t.Fatal("expected a snapshot colliding with another configmap to be refused")
+	}
+
+	cfg.Name = "other-values"
+	first, err := history.Snapshot(ctx, cfg)
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	again, err := history.Snapshot(ctx, cfg)
+	if err != nil || again.Number != first.Number {
+		t.Errorf("snapshot of unchanged data = %v, %v; want revision %d again", again, err, first.Number)
+	}
+
+	configs, err := NewClient(k8sClient).ListConfigMaps(ctx, "org-acme", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 1 || configs[0].Name != "hello-values-history-1" {
+		t.Errorf("ListConfigMaps() = %v, want only the unlabeled configmap", configs)
+	}
+
+	revision, err := NewClient(k8sClient).GetConfigMap(ctx, "org-acme", first.Snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := history.Snapshot(ctx, revision); err == nil {
+		t.Error("expected snapshotting a revision to be refused")
+	}
+}
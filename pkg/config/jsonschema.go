@@ -0,0 +1,285 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// valuesSchema is the subset of a JSON schema ValidateValues checks. Other
+// keywords are ignored, so values it accepts may still be rejected by Helm.
+type valuesSchema struct {
+	Ref                  string                   `json:"$ref"`
+	Type                 interface{}              `json:"type"`
+	Enum                 []interface{}            `json:"enum"`
+	Required             []string                 `json:"required"`
+	Properties           map[string]*valuesSchema `json:"properties"`
+	AdditionalProperties json.RawMessage          `json:"additionalProperties"`
+	PatternProperties    map[string]*valuesSchema `json:"patternProperties"`
+	Items                *valuesSchema            `json:"items"`
+	AnyOf                []*valuesSchema          `json:"anyOf"`
+	OneOf                []*valuesSchema          `json:"oneOf"`
+	Pattern              string                   `json:"pattern"`
+	Minimum              *float64                 `json:"minimum"`
+	Maximum              *float64                 `json:"maximum"`
+}
+
+// ValidateValues checks Helm values against a chart's values.schema.json. It
+// supports type, enum, required, properties, additionalProperties,
+// patternProperties, items, anyOf, oneOf, pattern, minimum, maximum and local
+// $refs, and returns the violations sorted by path.
+func ValidateValues(values map[string]interface{}, schemaData []byte) ([]string, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(schemaData, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse values schema: %w", err)
+	}
+	var schema valuesSchema
+	if err := json.Unmarshal(schemaData, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse values schema: %w", err)
+	}
+
+	// Round-trip the values through JSON, so that numbers are float64
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode values: %w", err)
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to encode values: %w", err)
+	}
+
+	v := &schemaValidator{root: root, refs: make(map[string]*valuesSchema)}
+	v.validate(doc, &schema, "", 0)
+	sort.Strings(v.violations)
+	return v.violations, nil
+}
+
+// maxSchemaDepth stops recursive $refs
+const maxSchemaDepth = 64
+
+type schemaValidator struct {
+	root       map[string]interface{}
+	refs       map[string]*valuesSchema
+	violations []string
+}
+
+func (v *schemaValidator) addf(path, format string, args ...interface{}) {
+	if path == "" {
+		path = "(root)"
+	}
+	v.violations = append(v.violations, path+": "+fmt.Sprintf(format, args...))
+}
+
+// resolve follows a local $ref like #/$defs/nodePool
+func (v *schemaValidator) resolve(ref string) (*valuesSchema, error) {
+	if schema, ok := v.refs[ref]; ok {
+		return schema, nil
+	}
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("unsupported $ref %s", ref)
+	}
+
+	var node interface{} = v.root
+	for _, token := range strings.Split(strings.TrimPrefix(strings.TrimPrefix(ref, "#"), "/"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		object, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unresolvable $ref %s", ref)
+		}
+		if node, ok = object[token]; !ok {
+			return nil, fmt.Errorf("unresolvable $ref %s", ref)
+		}
+	}
+
+	data, err := json.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	schema := &valuesSchema{}
+	if err := json.Unmarshal(data, schema); err != nil {
+		return nil, fmt.Errorf("invalid schema at $ref %s: %w", ref, err)
+	}
+	v.refs[ref] = schema
+	return schema, nil
+}
+
+func (v *schemaValidator) validate(value interface{}, schema *valuesSchema, path string, depth int) {
+	if schema == nil || depth > maxSchemaDepth {
+		return
+	}
+	if schema.Ref != "" {
+		resolved, err := v.resolve(schema.Ref)
+		if err != nil {
+			v.addf(path, "%v", err)
+			return
+		}
+		v.validate(value, resolved, path, depth+1)
+	}
+
+	if types := schemaTypes(schema.Type); len(types) > 0 && !matchesType(value, types) {
+		v.addf(path, "expected %s, got %s", strings.Join(types, " or "), jsonType(value))
+		return
+	}
+
+	if len(schema.Enum) > 0 {
+		allowed := false
+		for _, e := range schema.Enum {
+			if reflect.DeepEqual(e, value) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			v.addf(path, "must be one of %v", schema.Enum)
+		}
+	}
+
+	for _, branches := range [][]*valuesSchema{schema.AnyOf, schema.OneOf} {
+		if len(branches) > 0 && !v.matchesAny(value, branches, path, depth) {
+			v.addf(path, "does not match any of the allowed schemas")
+		}
+	}
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		v.validateObject(typed, schema, path, depth)
+	case []interface{}:
+		if schema.Items != nil {
+			for i, item := range typed {
+				v.validate(item, schema.Items, fmt.Sprintf("%s[%d]", path, i), depth+1)
+			}
+		}
+	case string:
+		if schema.Pattern != "" {
+			if re, err := regexp.Compile(schema.Pattern); err == nil && !re.MatchString(typed) {
+				v.addf(path, "does not match pattern %s", schema.Pattern)
+			}
+		}
+	case float64:
+		if schema.Minimum != nil && typed < *schema.Minimum {
+			v.addf(path, "must be at least %v", *schema.Minimum)
+		}
+		if schema.Maximum != nil && typed > *schema.Maximum {
+			v.addf(path, "must be at most %v", *schema.Maximum)
+		}
+	}
+}
+
+func (v *schemaValidator) validateObject(object map[string]interface{}, schema *valuesSchema, path string, depth int) {
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			v.addf(joinPath(path, name), "is required")
+		}
+	}
+
+	additional := &valuesSchema{}
+	allowAdditional := true
+	if len(schema.AdditionalProperties) > 0 {
+		var allowed bool
+		if err := json.Unmarshal(schema.AdditionalProperties, &allowed); err == nil {
+			allowAdditional = allowed
+		} else if err := json.Unmarshal(schema.AdditionalProperties, additional); err != nil {
+			additional = &valuesSchema{}
+		}
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := object[key]
+		keyPath := joinPath(path, key)
+		if property, ok := schema.Properties[key]; ok {
+			v.validate(value, property, keyPath, depth+1)
+			continue
+		}
+
+		matched := false
+		for pattern, property := range schema.PatternProperties {
+			if re, err := regexp.Compile(pattern); err == nil && re.MatchString(key) {
+				matched = true
+				v.validate(value, property, keyPath, depth+1)
+			}
+		}
+		if matched {
+			continue
+		}
+
+		if !allowAdditional {
+			v.addf(keyPath, "is not allowed by the schema")
+			continue
+		}
+		v.validate(value, additional, keyPath, depth+1)
+	}
+}
+
+// matchesAny reports whether value is valid against one of the schemas
+func (v *schemaValidator) matchesAny(value interface{}, schemas []*valuesSchema, path string, depth int) bool {
+	for _, schema := range schemas {
+		branch := &schemaValidator{root: v.root, refs: v.refs}
+		branch.validate(value, schema, path, depth+1)
+		if len(branch.violations) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaTypes returns the types a schema allows
+func schemaTypes(typ interface{}) []string {
+	switch t := typ.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		types := make([]string, 0, len(t))
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func matchesType(value interface{}, types []string) bool {
+	actual := jsonType(value)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType returns the JSON schema type of a decoded JSON value
+func jsonType(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if typed == math.Trunc(typed) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateValues(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"global": {
+				"type": "object",
+				"properties": {
+					"nodePools": {
+						"type": "object",
+						"additionalProperties": {"$ref": "#/$defs/nodePool"}
+					},
+					"controlPlane": {
+						"type": "object",
+						"properties": {
+							"replicas": {"type": "integer", "enum": [1, 3]},
+							"instanceType": {"type": "string", "pattern": "^[a-z0-9]+\\.[a-z0-9]+$"}
+						}
+					}
+				}
+			}
+		},
+		"$defs": {
+			"nodePool": {
+				"type": "object",
+				"required": ["instanceType"],
+				"properties": {
+					"instanceType": {"type": "string"},
+					"minSize": {"type": "integer", "minimum": 0},
+					"maxSize": {"type": "integer", "minimum": 1}
+				}
+			}
+		}
+	}`
+
+	values, _, err := ParseValues(`
+global:
+  nodePools:
+    pool0:
+      instanceType: m5.xlarge
+      minSize: 2
+      maxSize: 0
+    pool1:
+      minSize: "3"
+  controlPlane:
+    replicas: 2
+    instanceType: m5.xlarge
+extra: true
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	violations, err := ValidateValues(values, []byte(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"extra: is not allowed by the schema",
+		"global.controlPlane.replicas: must be one of [1 3]",
+		"global.nodePools.pool0.maxSize: must be at least 1",
+		"global.nodePools.pool1.instanceType: is required",
+		"global.nodePools.pool1.minSize: expected integer, got string",
+	}
+	if !reflect.DeepEqual(violations, want) {
+		t.Errorf("violations = %q, want %q", violations, want)
+	}
+}
+
+func TestSetValue(t *testing.T) {
+	values := map[string]interface{}{
+		"global": map[string]interface{}{"release": map[string]interface{}{"version": "29.0.0"}},
+	}
+
+	updated, err := SetValue(values, "global.nodePools.pool0.maxSize", float64(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := LookupValue(updated, "global.nodePools.pool0.maxSize"); got != float64(5) {
+		t.Errorf("maxSize = %v", got)
+	}
+	if got, _ := LookupValue(updated, "global.release.version"); got != "29.0.0" {
+		t.Errorf("sibling value lost: %v", updated)
+	}
+	if _, ok := LookupValue(values, "global.nodePools"); ok {
+		t.Error("SetValue modified its input")
+	}
+
+	if updated, err = SetValue(updated, "global.release", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := LookupValue(updated, "global.release"); ok {
+		t.Error("nil value did not remove the key")
+	}
+
+	if _, err := SetValue(values, "global.release.version.major", "29"); err == nil {
+		t.Error("expected an error when a path element is not a map")
+	}
+}
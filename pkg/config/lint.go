@@ -0,0 +1,297 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// Severities of lint issues
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+)
+
+// LintIssue is a problem found in Helm values. Line is 1-based, 0 when the
+// issue is not tied to a line.
+type LintIssue struct {
+	Line     int
+	Severity string
+	Message  string
+}
+
+func (i LintIssue) String() string {
+	if i.Line == 0 {
+		return fmt.Sprintf("%s: %s", i.Severity, i.Message)
+	}
+	return fmt.Sprintf("line %d: %s: %s", i.Line, i.Severity, i.Message)
+}
+
+// LintOptions are the chart files values are checked against. Both are
+// optional; without them only the YAML itself is checked.
+type LintOptions struct {
+	// Schema is the chart's values.schema.json
+	Schema []byte
+	// Defaults is the chart's values.yaml, used for unknown keys when the
+	// chart has no schema
+	Defaults []byte
+}
+
+// lintSchema is the subset of a JSON schema used for linting
+type lintSchema struct {
+	Type       interface{}            `json:"type"`
+	Properties map[string]*lintSchema `json:"properties"`
+	Items      *lintSchema            `json:"items"`
+}
+
+var yamlErrorLine = regexp.MustCompile(`line (\d+):\s*(.*)`)
+
+// Lint checks Helm values for common mistakes: tabs and inconsistent
+// indentation, YAML syntax errors, top-level keys the chart does not know,
+// and numbers quoted as strings where the schema expects a number. Issues are
+// sorted by line.
+func Lint(values string, opts LintOptions) ([]LintIssue, error) {
+	var schema *lintSchema
+	if len(opts.Schema) > 0 {
+		schema = &lintSchema{}
+		if err := json.Unmarshal(opts.Schema, schema); err != nil {
+			return nil, fmt.Errorf("failed to parse values schema: %w", err)
+		}
+	}
+
+	issues := lintIndentation(values)
+
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal([]byte(values), &doc); err != nil {
+		issues = append(issues, yamlSyntaxIssue(err))
+		sortLintIssues(issues)
+		return issues, nil
+	}
+	if len(doc.Content) == 0 {
+		return issues, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yamlv3.MappingNode {
+		issues = append(issues, LintIssue{Line: root.Line, Severity: LintError, Message: "values must be a mapping of keys to values"})
+		sortLintIssues(issues)
+		return issues, nil
+	}
+
+	known, err := knownTopLevelKeys(schema, opts.Defaults)
+	if err != nil {
+		return nil, err
+	}
+	if known != nil {
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			key := root.Content[i]
+			if !known[key.Value] {
+				issues = append(issues, LintIssue{
+					Line:     key.Line,
+					Severity: LintWarning,
+					Message:  fmt.Sprintf("unknown top-level key %q%s", key.Value, suggestKey(key.Value, known)),
+				})
+			}
+		}
+	}
+
+	if schema != nil {
+		issues = append(issues, lintTypes(root, schema, "")...)
+	}
+
+	sortLintIssues(issues)
+	return issues, nil
+}
+
+// lintIndentation reports tabs in indentation and indentation that is not a
+// multiple of the indentation the document starts with
+func lintIndentation(values string) []LintIssue {
+	issues := make([]LintIssue, 0)
+	unit := 0
+	inBlockScalar := false
+	blockIndent := 0
+
+	for i, line := range strings.Split(values, "\n") {
+		lineNo := i + 1
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		leading := line[:len(line)-len(trimmed)]
+		indent := len(leading)
+
+		// Literal and folded block scalars may be indented freely
+		if inBlockScalar {
+			if indent > blockIndent {
+				continue
+			}
+			inBlockScalar = false
+		}
+
+		if strings.Contains(leading, "\t") {
+			issues = append(issues, LintIssue{Line: lineNo, Severity: LintError, Message: "tab used for indentation, YAML only allows spaces"})
+		} else if indent > 0 {
+			if unit == 0 {
+				unit = indent
+			} else if indent%unit != 0 {
+				issues = append(issues, LintIssue{
+					Line:     lineNo,
+					Severity: LintWarning,
+					Message:  fmt.Sprintf("indented by %d spaces, which is not a multiple of the %d used elsewhere", indent, unit),
+				})
+			}
+		}
+
+		if isBlockScalarStart(trimmed) {
+			inBlockScalar = true
+			blockIndent = indent
+		}
+	}
+
+	return issues
+}
+
+func isBlockScalarStart(line string) bool {
+	line = strings.TrimSpace(line)
+	for _, indicator := range []string{"|", "|-", "|+", ">", ">-", ">+"} {
+		if strings.HasSuffix(line, ": "+indicator) || strings.HasSuffix(line, "- "+indicator) || line == indicator {
+			return true
+		}
+	}
+	return false
+}
+
+// yamlSyntaxIssue turns a YAML parser error into an issue on its line
+func yamlSyntaxIssue(err error) LintIssue {
+	message := strings.TrimPrefix(err.Error(), "yaml: ")
+	if m := yamlErrorLine.FindStringSubmatch(message); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		message = m[2]
+		if strings.Contains(message, "mapping values are not allowed") {
+			message += " (often a key indented differently from its siblings or a missing space after a colon)"
+		}
+		return LintIssue{Line: line, Severity: LintError, Message: message}
+	}
+	return LintIssue{Severity: LintError, Message: message}
+}
+
+// knownTopLevelKeys returns the top-level keys of the schema, or of the
+// default values if there is no schema. It returns nil when neither is known.
+func knownTopLevelKeys(schema *lintSchema, defaults []byte) (map[string]bool, error) {
+	if schema != nil && len(schema.Properties) > 0 {
+		known := make(map[string]bool, len(schema.Properties))
+		for k := range schema.Properties {
+			known[k] = true
+		}
+		return known, nil
+	}
+
+	if len(defaults) == 0 {
+		return nil, nil
+	}
+	var values map[string]interface{}
+	if err := yamlv3.Unmarshal(defaults, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse default values: %w", err)
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	known := make(map[string]bool, len(values))
+	for k := range values {
+		known[k] = true
+	}
+	return known, nil
+}
+
+// suggestKey returns a hint with the known key closest to key, if any is close
+func suggestKey(key string, known map[string]bool) string {
+	lower := strings.ToLower(key)
+	for k := range known {
+		if strings.ToLower(k) == lower {
+			return fmt.Sprintf(", did you mean %q?", k)
+		}
+	}
+	return ""
+}
+
+// lintTypes reports scalars quoted as strings where the schema expects a
+// number, integer or boolean
+func lintTypes(node *yamlv3.Node, schema *lintSchema, path string) []LintIssue {
+	if schema == nil {
+		return nil
+	}
+
+	issues := make([]LintIssue, 0)
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			issues = append(issues, lintTypes(value, schema.Properties[key.Value], joinPath(path, key.Value))...)
+		}
+	case yamlv3.SequenceNode:
+		for i, item := range node.Content {
+			issues = append(issues, lintTypes(item, schema.Items, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case yamlv3.ScalarNode:
+		if node.Tag != "!!str" || schemaAllows(schema, "string") {
+			break
+		}
+		switch {
+		case schemaAllows(schema, "integer") && isInteger(node.Value):
+			issues = append(issues, quotedIssue(node, path, "an integer"))
+		case schemaAllows(schema, "number") && isNumber(node.Value):
+			issues = append(issues, quotedIssue(node, path, "a number"))
+		case schemaAllows(schema, "boolean") && (node.Value == "true" || node.Value == "false"):
+			issues = append(issues, quotedIssue(node, path, "a boolean"))
+		}
+	}
+	return issues
+}
+
+func quotedIssue(node *yamlv3.Node, path, expected string) LintIssue {
+	return LintIssue{
+		Line:     node.Line,
+		Severity: LintError,
+		Message:  fmt.Sprintf("%s is the string %q but the schema expects %s, remove the quotes", path, node.Value, expected),
+	}
+}
+
+func schemaAllows(schema *lintSchema, typ string) bool {
+	switch t := schema.Type.(type) {
+	case string:
+		return t == typ
+	case []interface{}:
+		for _, v := range t {
+			if v == typ {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isInteger(value string) bool {
+	_, err := strconv.ParseInt(value, 10, 64)
+	return err == nil
+}
+
+func isNumber(value string) bool {
+	_, err := strconv.ParseFloat(value, 64)
+	return err == nil
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func sortLintIssues(issues []LintIssue) {
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Line < issues[j].Line })
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"replicaCount": {"type": "integer"},
+			"image": {"type": "object", "properties": {"tag": {"type": "string"}}},
+			"ports": {"type": "array", "items": {"type": "object", "properties": {"port": {"type": ["integer", "null"]}}}},
+			"enabled": {"type": "boolean"}
+		}
+	}`)
+
+	tests := []struct {
+		name   string
+		values string
+		opts   LintOptions
+		want   []string
+	}{
+		{
+			name:   "valid",
+			values: "replicaCount: 2\nimage:\n  tag: \"1.0\"\nscript: |\n     echo hi\n",
+			opts:   LintOptions{Defaults: []byte("replicaCount: 1\nimage: {}\nscript: \"\"\n")},
+		},
+		{
+			name:   "quoted numbers and booleans",
+			values: "replicaCount: \"3\"\nports:\n  - port: '8080'\nenabled: \"true\"\n",
+			opts:   LintOptions{Schema: schema},
+			want: []string{
+				`line 1: error: replicaCount is the string "3" but the schema expects an integer`,
+				`line 3: error: ports[0].port is the string "8080"`,
+				`line 4: error: enabled is the string "true" but the schema expects a boolean`,
+			},
+		},
+		{
+			name:   "unknown top-level keys",
+			values: "ReplicaCount: 2\nextra: true\n",
+			opts:   LintOptions{Schema: schema},
+			want: []string{
+				`line 1: warning: unknown top-level key "ReplicaCount", did you mean "replicaCount"?`,
+				`line 2: warning: unknown top-level key "extra"`,
+			},
+		},
+		{
+			name:   "tabs",
+			values: "image:\n\ttag: v1\n",
+			want:   []string{"line 2: error: tab used for indentation", "line 2: error: found character that cannot start any token"},
+		},
+		{
+			name:   "inconsistent indentation",
+			values: "image:\n  repository: nginx\n   tag: v1\n",
+			want:   []string{"line 3: warning: indented by 3 spaces", "line 3: error: mapping values are not allowed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := Lint(tt.values, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(issues) != len(tt.want) {
+				t.Fatalf("Lint() = %v, want %d issues", issues, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if got := issues[i].String(); !strings.HasPrefix(got, want) {
+					t.Errorf("issue %d = %q, want prefix %q", i, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+)
+
+// Organization defaults are ConfigMaps named <app>-org-defaults in an
+// organization namespace. New apps of the organization get them attached as
+// an extra config, so they apply below the cluster and user config.
+const (
+	OrgDefaultsSuffix = "-org-defaults"
+
+	// OrgDefaultsKey is the data key holding the default values
+	OrgDefaultsKey = "values"
+
+	// OrgDefaultsLabel marks organization defaults; its value is the app name
+	OrgDefaultsLabel = "mcp.giantswarm.io/org-defaults"
+)
+
+// OrgDefaultsName returns the name of the organization defaults of an app
+func OrgDefaultsName(appName string) string {
+	return appName + OrgDefaultsSuffix
+}
+
+// OrgDefaultsApp returns the app name of an organization defaults ConfigMap,
+// or false when the name does not follow the convention
+func OrgDefaultsApp(name string) (string, bool) {
+	appName := strings.TrimSuffix(name, OrgDefaultsSuffix)
+	return appName, appName != name && appName != ""
+}
+
+// GetOrgDefaults returns the organization defaults of an app, or nil when the
+// organization has none
+func (c *Client) GetOrgDefaults(ctx context.Context, orgNamespace, appName string) (*Config, error) {
+	cfg, err := c.GetConfigMap(ctx, orgNamespace, OrgDefaultsName(appName))
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	return cfg, err
+}
+
+// ListOrgDefaults lists the organization defaults in an organization
+// namespace, sorted by name
+func (c *Client) ListOrgDefaults(ctx context.Context, orgNamespace string) ([]*Config, error) {
+	configMaps, err := c.ListConfigMaps(ctx, orgNamespace, "")
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := make([]*Config, 0)
+	for _, cm := range configMaps {
+		if _, ok := OrgDefaultsApp(cm.Name); ok {
+			defaults = append(defaults, cm)
+		}
+	}
+	sort.Slice(defaults, func(i, j int) bool {
+		return defaults[i].Name < defaults[j].Name
+	})
+
+	return defaults, nil
+}
+
+// SetOrgDefaults creates or replaces the organization defaults of an app. It
+// reports whether the ConfigMap was created.
+func (c *Client) SetOrgDefaults(ctx context.Context, orgNamespace, appName, values string, opts k8s.UpdateOptions) (bool, error) {
+	existing, err := c.GetOrgDefaults(ctx, orgNamespace, appName)
+	if err != nil {
+		return false, err
+	}
+
+	if existing == nil {
+		return true, c.CreateConfigMap(ctx, &Config{
+			Name:      OrgDefaultsName(appName),
+			Namespace: orgNamespace,
+			Type:      ConfigTypeConfigMap,
+			Data:      map[string]string{OrgDefaultsKey: values},
+			Labels:    map[string]string{OrgDefaultsLabel: appName},
+		})
+	}
+
+	return false, c.Update(ctx, orgNamespace, existing.Name, ConfigTypeConfigMap, opts, func(cfg *Config) error {
+		if cfg.Labels == nil {
+			cfg.Labels = make(map[string]string)
+		}
+		cfg.Labels[OrgDefaultsLabel] = appName
+		cfg.Data = map[string]string{OrgDefaultsKey: values}
+		return nil
+	})
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+)
+
+func TestOrgDefaults(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient(fake.NewClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello-values", Namespace: "org-acme"},
+	}))
+
+	if cfg, err := client.GetOrgDefaults(ctx, "org-acme", "hello-world"); err != nil || cfg != nil {
+		t.Fatalf("GetOrgDefaults() = %v, %v; want none", cfg, err)
+	}
+
+	created, err := client.SetOrgDefaults(ctx, "org-acme", "hello-world", "replicas: 2\n", k8s.UpdateOptions{})
+	if err != nil || !created {
+		t.Fatalf("SetOrgDefaults() = %v, %v; want created", created, err)
+	}
+	if created, err = client.SetOrgDefaults(ctx, "org-acme", "hello-world", "replicas: 3\n", k8s.UpdateOptions{}); err != nil || created {
+		t.Fatalf("second SetOrgDefaults() = %v, %v; want updated", created, err)
+	}
+
+	cfg, err := client.GetOrgDefaults(ctx, "org-acme", "hello-world")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Name != "hello-world-org-defaults" || cfg.Data[OrgDefaultsKey] != "replicas: 3\n" || cfg.Labels[OrgDefaultsLabel] != "hello-world" {
+		t.Errorf("org defaults = %+v", cfg)
+	}
+
+	defaults, err := client.ListOrgDefaults(ctx, "org-acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(defaults) != 1 || defaults[0].Name != "hello-world-org-defaults" {
+		t.Errorf("ListOrgDefaults() = %v", defaults)
+	}
+
+	if _, ok := OrgDefaultsApp("-org-defaults"); ok {
+		t.Error("a name without app should not be org defaults")
+	}
+}
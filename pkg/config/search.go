@@ -0,0 +1,79 @@
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+// SearchOptions configures Search
+type SearchOptions struct {
+	// Query is matched case-insensitively against keys and value paths, e.g.
+	// proxy.noProxy, and against values
+	Query string
+
+	// SecretValues also matches the values of secrets, which are otherwise
+	// matched on their keys only
+	SecretValues bool
+}
+
+// SearchMatch is a key or value of a ConfigMap or Secret that matches a query
+type SearchMatch struct {
+	Namespace string
+	Name      string
+	Type      ConfigType
+	// Key is the data key, and Path the dotted path of the value in the YAML
+	// it holds; Path is empty for keys that do not hold YAML values
+	Key  string
+	Path string
+	// Value is the matched value; it is empty for secrets
+	Value string
+	// InValue is set when the query matched the value rather than the key
+	InValue bool
+}
+
+// Search returns the keys and values of configs that match the query, sorted
+// by namespace, name, key and path. Values of secrets are never returned.
+func Search(configs []*Config, opts SearchOptions) []SearchMatch {
+	query := strings.ToLower(opts.Query)
+	if query == "" {
+		return nil
+	}
+
+	var matches []SearchMatch
+	for _, cfg := range configs {
+		for entry, value := range cfg.Flatten().Data {
+			key, path, _ := strings.Cut(entry, "/")
+			match := SearchMatch{Namespace: cfg.Namespace, Name: cfg.Name, Type: cfg.Type, Key: key, Path: path}
+
+			switch {
+			case strings.Contains(strings.ToLower(entry), query):
+			case (!cfg.IsSecret() || opts.SecretValues) && strings.Contains(strings.ToLower(value), query):
+				match.InValue = true
+			default:
+				continue
+			}
+			if !cfg.IsSecret() {
+				match.Value = value
+			}
+			matches = append(matches, match)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		return a.Path < b.Path
+	})
+	return matches
+}
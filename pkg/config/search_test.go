@@ -0,0 +1,36 @@
+package config
+
+import "testing"
+
+func TestSearch(t *testing.T) {
+	configs := []*Config{
+		{Name: "prod-user-values", Namespace: "org-acme", Type: ConfigTypeConfigMap, Data: map[string]string{
+			"values": "proxy:\n  noProxy: internal.example.com\n  http: http://proxy:3128\nreplicas: 2\n",
+		}},
+		{Name: "prod-secrets", Namespace: "org-acme", Type: ConfigTypeSecret, Data: map[string]string{
+			"values": "proxy:\n  password: internal.example.com\n",
+			"token":  "internal",
+		}},
+	}
+
+	matches := Search(configs, SearchOptions{Query: "proxy.noproxy"})
+	if len(matches) != 1 || matches[0].Path != "proxy.noProxy" || matches[0].Value != "internal.example.com" {
+		t.Errorf("key search = %+v", matches)
+	}
+
+	// Secrets are matched on keys only, so the value query finds the ConfigMap
+	matches = Search(configs, SearchOptions{Query: "internal"})
+	if len(matches) != 1 || matches[0].Type != ConfigTypeConfigMap || !matches[0].InValue {
+		t.Errorf("value search = %+v", matches)
+	}
+
+	matches = Search(configs, SearchOptions{Query: "internal", SecretValues: true})
+	if len(matches) != 3 {
+		t.Fatalf("value search with secret values = %+v", matches)
+	}
+	for _, m := range matches {
+		if m.Type == ConfigTypeSecret && m.Value != "" {
+			t.Errorf("secret value returned: %+v", m)
+		}
+	}
+}
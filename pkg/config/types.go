@@ -20,11 +20,14 @@ const (
 
 // Config represents a configuration (ConfigMap or Secret)
 type Config struct {
-	Name      string
-	Namespace string
-	Type      ConfigType
-	Data      map[string]string
-	Labels    map[string]string
+	Name            string
+	Namespace       string
+	Type            ConfigType
+	Data            map[string]string
+	Labels          map[string]string
+	ResourceVersion string
+	// SecretType is the type of a Secret, Opaque when empty
+	SecretType corev1.SecretType
 }
 
 // ConfigDiff represents differences between two configurations
@@ -58,6 +61,58 @@ func (c *Config) IsSecret() bool {
 	return c.Type == ConfigTypeSecret
 }
 
+// Manifest returns the ConfigMap or Secret as a plain manifest without
+// server-populated fields. The values of Secrets are left empty unless
+// secretValues is set.
+func (c *Config) Manifest(secretValues bool) map[string]interface{} {
+	metadata := map[string]interface{}{"name": c.Name, "namespace": c.Namespace}
+	if len(c.Labels) > 0 {
+		metadata["labels"] = c.Labels
+	}
+
+	if !c.IsSecret() {
+		return map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   metadata,
+			"data":       c.Data,
+		}
+	}
+
+	stringData := make(map[string]string, len(c.Data))
+	for key, value := range c.Data {
+		if !secretValues {
+			value = ""
+		}
+		stringData[key] = value
+	}
+	secretType := c.SecretType
+	if secretType == "" {
+		secretType = corev1.SecretTypeOpaque
+	}
+	return map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   metadata,
+		"type":       string(secretType),
+		"stringData": stringData,
+	}
+}
+
+// DeepCopy returns a copy of the configuration that shares no maps with it
+func (c *Config) DeepCopy() *Config {
+	out := *c
+	out.Data = make(map[string]string, len(c.Data))
+	for k, v := range c.Data {
+		out.Data[k] = v
+	}
+	out.Labels = make(map[string]string, len(c.Labels))
+	for k, v := range c.Labels {
+		out.Labels[k] = v
+	}
+	return &out
+}
+
 // GetValue retrieves a configuration value
 func (c *Config) GetValue(key string) (string, bool) {
 	value, exists := c.Data[key]
@@ -174,6 +229,40 @@ func (c *Config) Diff(other *Config) *ConfigDiff {
 	return diff
 }
 
+// Flatten returns a copy of the configuration whose data holds one entry per
+// leaf of the YAML values in each key, e.g. "values/ingress.replicas". Keys
+// that do not hold a YAML map are kept as they are. Diffing flattened configs
+// shows which values differ instead of which keys.
+func (c *Config) Flatten() *Config {
+	flat := c.DeepCopy()
+	flat.Data = make(map[string]string)
+
+	for k, v := range c.Data {
+		var values map[string]interface{}
+		if err := yaml.Unmarshal([]byte(v), &values); err != nil || len(values) == 0 {
+			flat.Data[k] = v
+			continue
+		}
+		flattenValues(flat.Data, k+"/", values)
+	}
+
+	return flat
+}
+
+func flattenValues(out map[string]string, prefix string, values map[string]interface{}) {
+	for k, v := range values {
+		if nested, ok := v.(map[string]interface{}); ok && len(nested) > 0 {
+			flattenValues(out, prefix+k+".", nested)
+			continue
+		}
+		if str, ok := v.(string); ok {
+			out[prefix+k] = str
+		} else if encoded, err := json.Marshal(v); err == nil {
+			out[prefix+k] = string(encoded)
+		}
+	}
+}
+
 // HasChanges returns true if there are any differences
 func (d *ConfigDiff) HasChanges() bool {
 	return len(d.Added) > 0 || len(d.Modified) > 0 || len(d.Removed) > 0
@@ -182,22 +271,25 @@ func (d *ConfigDiff) HasChanges() bool {
 // NewConfigFromConfigMap creates a Config from a Kubernetes ConfigMap
 func NewConfigFromConfigMap(cm *corev1.ConfigMap) *Config {
 	return &Config{
-		Name:      cm.Name,
-		Namespace: cm.Namespace,
-		Type:      ConfigTypeConfigMap,
-		Data:      cm.Data,
-		Labels:    cm.Labels,
+		Name:            cm.Name,
+		Namespace:       cm.Namespace,
+		Type:            ConfigTypeConfigMap,
+		Data:            cm.Data,
+		Labels:          cm.Labels,
+		ResourceVersion: cm.ResourceVersion,
 	}
 }
 
 // NewConfigFromSecret creates a Config from a Kubernetes Secret
 func NewConfigFromSecret(secret *corev1.Secret) *Config {
 	config := &Config{
-		Name:      secret.Name,
-		Namespace: secret.Namespace,
-		Type:      ConfigTypeSecret,
-		Data:      make(map[string]string),
-		Labels:    secret.Labels,
+		Name:            secret.Name,
+		Namespace:       secret.Namespace,
+		Type:            ConfigTypeSecret,
+		Data:            make(map[string]string),
+		Labels:          secret.Labels,
+		ResourceVersion: secret.ResourceVersion,
+		SecretType:      secret.Type,
 	}
 
 	// Decode secret data
@@ -222,13 +314,18 @@ func (c *Config) ToConfigMap() *corev1.ConfigMap {
 
 // ToSecret converts a Config to a Kubernetes Secret
 func (c *Config) ToSecret() *corev1.Secret {
+	secretType := c.SecretType
+	if secretType == "" {
+		secretType = corev1.SecretTypeOpaque
+	}
+
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      c.Name,
 			Namespace: c.Namespace,
 			Labels:    c.Labels,
 		},
-		Type: corev1.SecretTypeOpaque,
+		Type: secretType,
 		Data: make(map[string][]byte),
 	}
 
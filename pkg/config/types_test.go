@@ -0,0 +1,41 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	cfg := &Config{
+		Name: "hello-values",
+		Data: map[string]string{
+			"values":   "ingress:\n  replicas: 2\n  hosts: [a, b]\nimage:\n  tag: v1\n",
+			"plain":    "not a map",
+			"rendered": "{}",
+		},
+	}
+
+	want := map[string]string{
+		"values/ingress.replicas": "2",
+		"values/ingress.hosts":    `["a","b"]`,
+		"values/image.tag":        "v1",
+		"plain":                   "not a map",
+		"rendered":                "{}",
+	}
+	if got := cfg.Flatten().Data; !reflect.DeepEqual(got, want) {
+		t.Errorf("Flatten() = %v, want %v", got, want)
+	}
+	if len(cfg.Data) != 3 {
+		t.Errorf("Flatten() modified the original data: %v", cfg.Data)
+	}
+
+	other := cfg.DeepCopy()
+	other.Data["values"] = "ingress:\n  replicas: 3\n  hosts: [a, b]\n"
+	diff := cfg.Flatten().Diff(other.Flatten())
+	if len(diff.Modified) != 1 || diff.Modified["values/ingress.replicas"].New != "3" {
+		t.Errorf("Modified = %v, want only values/ingress.replicas", diff.Modified)
+	}
+	if _, ok := diff.Removed["values/image.tag"]; !ok || len(diff.Removed) != 1 {
+		t.Errorf("Removed = %v, want only values/image.tag", diff.Removed)
+	}
+}
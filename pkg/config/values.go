@@ -0,0 +1,124 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// ParseValues parses the YAML values in a config key. A key may hold several
+// documents separated by ---; they are merged in order, later documents
+// overriding earlier ones like Helm does for multiple values files. It returns
+// the merged values and the number of non-empty documents.
+func ParseValues(data string) (map[string]interface{}, int, error) {
+	merged := map[string]interface{}{}
+	documents := 0
+
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(strings.NewReader(data)))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, documents, fmt.Errorf("failed to read document %d: %w", documents+1, err)
+		}
+
+		var values map[string]interface{}
+		if err := yaml.Unmarshal(doc, &values); err != nil {
+			return nil, documents, fmt.Errorf("failed to parse document %d: %w", documents+1, err)
+		}
+		if len(values) == 0 {
+			continue
+		}
+		documents++
+		merged = MergeValues(merged, values)
+	}
+
+	return merged, documents, nil
+}
+
+// MergeValues returns base with override merged into it recursively, the way
+// Helm merges values: maps are merged key by key, any other value replaces
+// the one in base. Neither argument is modified.
+func MergeValues(base, override map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range override {
+		baseMap, baseIsMap := merged[key].(map[string]interface{})
+		overrideMap, overrideIsMap := value.(map[string]interface{})
+		if baseIsMap && overrideIsMap {
+			merged[key] = MergeValues(baseMap, overrideMap)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// LookupValue returns the value at a dotted path like global.nodePools, or
+// false when it is not set. An empty path returns values itself.
+func LookupValue(values map[string]interface{}, path string) (interface{}, bool) {
+	if path == "" {
+		return values, true
+	}
+	var current interface{} = values
+	for _, key := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// SetValue returns values with the value at a dotted path replaced, creating
+// the maps along the path. A nil value removes the key. values is not
+// modified.
+func SetValue(values map[string]interface{}, path string, value interface{}) (map[string]interface{}, error) {
+	keys := strings.Split(path, ".")
+	for _, key := range keys {
+		if key == "" {
+			return nil, fmt.Errorf("invalid path %q", path)
+		}
+	}
+
+	updated := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		updated[k] = v
+	}
+
+	key := keys[0]
+	if len(keys) == 1 {
+		if value == nil {
+			delete(updated, key)
+		} else {
+			updated[key] = value
+		}
+		return updated, nil
+	}
+
+	child, ok := updated[key].(map[string]interface{})
+	if !ok {
+		if _, exists := updated[key]; exists && updated[key] != nil {
+			return nil, fmt.Errorf("%s is not a map", key)
+		}
+		child = map[string]interface{}{}
+	}
+	child, err := SetValue(child, strings.Join(keys[1:], "."), value)
+	if err != nil {
+		return nil, fmt.Errorf("%s.%w", key, err)
+	}
+	updated[key] = child
+	return updated, nil
+}
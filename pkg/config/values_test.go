@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestParseValues(t *testing.T) {
+	data := `
+ingress:
+  enabled: true
+  hosts: [a.example.com]
+replicas: 1
+---
+# overrides for production
+ingress:
+  hosts: [b.example.com]
+replicas: 3
+---
+`
+	values, documents, err := ParseValues(data)
+	if err != nil {
+		t.Fatalf("ParseValues: %v", err)
+	}
+	if documents != 2 {
+		t.Errorf("documents = %d, want 2 (the empty one skipped)", documents)
+	}
+
+	ingress := values["ingress"].(map[string]interface{})
+	if ingress["enabled"] != true || values["replicas"] != float64(3) {
+		t.Errorf("values = %v, want the later document merged over the first", values)
+	}
+	if hosts := ingress["hosts"].([]interface{}); len(hosts) != 1 || hosts[0] != "b.example.com" {
+		t.Errorf("hosts = %v, want lists replaced rather than merged", hosts)
+	}
+
+	if _, _, err := ParseValues("a: 1\n---\nb: [\n"); err == nil {
+		t.Error("expected an error for an invalid second document")
+	}
+}
+
+func TestMergeValues(t *testing.T) {
+	base := map[string]interface{}{"a": map[string]interface{}{"b": 1, "c": 2}, "d": 3}
+	merged := MergeValues(base, map[string]interface{}{"a": map[string]interface{}{"c": 4}})
+
+	a := merged["a"].(map[string]interface{})
+	if a["b"] != 1 || a["c"] != 4 || merged["d"] != 3 {
+		t.Errorf("MergeValues = %v", merged)
+	}
+	if base["a"].(map[string]interface{})["c"] != 2 {
+		t.Errorf("MergeValues modified its input: %v", base)
+	}
+}
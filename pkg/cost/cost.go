@@ -0,0 +1,160 @@
+// Package cost reads cost allocations from the OpenCost or Kubecost
+// allocation API, to estimate what apps, namespaces and clusters cost.
+package cost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+)
+
+// Flavors of the allocation API, which differ in their path
+const (
+	FlavorOpenCost = "opencost"
+	FlavorKubecost = "kubecost"
+)
+
+// Keys of allocations not attributed to a workload
+const (
+	IdleKey        = "__idle__"
+	UnallocatedKey = "__unallocated__"
+)
+
+// ReleaseAggregate aggregates allocations by Helm release, which
+// app-operator names after the App
+const ReleaseAggregate = "label:app.kubernetes.io/instance"
+
+// hoursPerMonth is the average number of hours in a month
+const hoursPerMonth = 730
+
+// Allocation is the cost of a namespace, release or other aggregate over the
+// queried window, in the currency the API is configured with
+type Allocation struct {
+	Name        string  `json:"name"`
+	CPUCost     float64 `json:"cpuCost"`
+	GPUCost     float64 `json:"gpuCost"`
+	RAMCost     float64 `json:"ramCost"`
+	PVCost      float64 `json:"pvCost"`
+	NetworkCost float64 `json:"networkCost"`
+	TotalCost   float64 `json:"totalCost"`
+	// Minutes is the time covered by the allocation
+	Minutes float64 `json:"minutes"`
+}
+
+// Monthly extrapolates the cost to a month of 730 hours
+func (a Allocation) Monthly(cost float64) float64 {
+	if a.Minutes <= 0 {
+		return 0
+	}
+	return cost / a.Minutes * 60 * hoursPerMonth
+}
+
+// API is a configured allocation API
+type API struct {
+	Flavor string
+	*k8s.Endpoint
+}
+
+// ParseAPI parses the --cost-api flag: an http(s) URL, or
+// namespace/service:port of the cost analyzer service in every cluster
+func ParseAPI(value, flavor string) (*API, error) {
+	flavor = strings.ToLower(flavor)
+	if flavor != FlavorOpenCost && flavor != FlavorKubecost {
+		return nil, fmt.Errorf("unsupported cost API flavor %q (supported: %s, %s)", flavor, FlavorOpenCost, FlavorKubecost)
+	}
+	endpoint, err := k8s.ParseEndpoint(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cost API: %w", err)
+	}
+	return &API{Flavor: flavor, Endpoint: endpoint}, nil
+}
+
+// Describe names the API for output
+func (a *API) Describe() string {
+	name := "OpenCost"
+	if a.Flavor == FlavorKubecost {
+		name = "Kubecost"
+	}
+	if a.URL != "" {
+		return name + " at " + a.URL
+	}
+	return name + " " + a.Endpoint.String()
+}
+
+func (a *API) path() string {
+	if a.Flavor == FlavorKubecost {
+		return "/model/allocation"
+	}
+	return "/allocation/compute"
+}
+
+// Allocations returns the cost allocations of a cluster over window (e.g.
+// 7d), aggregated by aggregate (e.g. namespace or ReleaseAggregate) and keyed
+// by the aggregate value. k8sClient is the client of the cluster, used to
+// reach the cost analyzer service.
+func (a *API) Allocations(ctx context.Context, k8sClient kubernetes.Interface, window, aggregate string) (map[string]Allocation, error) {
+	params := map[string]string{
+		"window":     window,
+		"aggregate":  aggregate,
+		"accumulate": "true",
+	}
+
+	data, err := a.Get(ctx, k8sClient, a.path(), params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", a.Describe(), err)
+	}
+
+	allocations, err := ParseAllocations(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response of %s: %w", a.Describe(), err)
+	}
+	return allocations, nil
+}
+
+// allocationResponse is the response of both allocation APIs. With
+// accumulate=true data holds a single set of allocations.
+type allocationResponse struct {
+	Code    int                     `json:"code"`
+	Message string                  `json:"message"`
+	Data    []map[string]Allocation `json:"data"`
+}
+
+// ParseAllocations reads an allocation API response
+func ParseAllocations(data []byte) (map[string]Allocation, error) {
+	var resp allocationResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Code != 0 && resp.Code != http.StatusOK {
+		return nil, fmt.Errorf("error %d: %s", resp.Code, resp.Message)
+	}
+
+	allocations := make(map[string]Allocation)
+	for _, set := range resp.Data {
+		for key, allocation := range set {
+			if existing, ok := allocations[key]; ok {
+				allocation = existing.add(allocation)
+			}
+			allocations[key] = allocation
+		}
+	}
+	return allocations, nil
+}
+
+// add sums two allocations of the same aggregate
+func (a Allocation) add(b Allocation) Allocation {
+	a.CPUCost += b.CPUCost
+	a.GPUCost += b.GPUCost
+	a.RAMCost += b.RAMCost
+	a.PVCost += b.PVCost
+	a.NetworkCost += b.NetworkCost
+	a.TotalCost += b.TotalCost
+	a.Minutes += b.Minutes
+	return a
+}
@@ -0,0 +1,82 @@
+package cost
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const allocationResponseJSON = `{
+  "code": 200,
+  "data": [{
+    "prod-ingress": {"name": "prod-ingress", "cpuCost": 2.5, "ramCost": 1.5, "pvCost": 1, "networkCost": 0, "totalCost": 5, "minutes": 1440},
+    "__idle__": {"name": "__idle__", "cpuCost": 1, "ramCost": 1, "totalCost": 2, "minutes": 1440}
+  }]
+}`
+
+func TestParseAPI(t *testing.T) {
+	api, err := ParseAPI("opencost/opencost:9003", "")
+	if err == nil {
+		t.Fatalf("expected an error for an empty flavor, got %+v", api)
+	}
+
+	api, err = ParseAPI("opencost/opencost:9003", "OpenCost")
+	if err != nil {
+		t.Fatalf("ParseAPI() error = %v", err)
+	}
+	if api.Namespace != "opencost" || api.Service != "opencost" || api.Port != "9003" || !api.CoversWorkloadClusters() {
+		t.Errorf("unexpected service API %+v", api)
+	}
+
+	api, err = ParseAPI("https://kubecost.example.com/", FlavorKubecost)
+	if err != nil {
+		t.Fatalf("ParseAPI() error = %v", err)
+	}
+	if api.URL != "https://kubecost.example.com" || api.path() != "/model/allocation" || api.CoversWorkloadClusters() {
+		t.Errorf("unexpected URL API %+v", api)
+	}
+
+	if _, err := ParseAPI("opencost", FlavorOpenCost); err == nil {
+		t.Error("expected an error without a service")
+	}
+}
+
+func TestAllocations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/allocation/compute" || r.URL.Query().Get("aggregate") != ReleaseAggregate || r.URL.Query().Get("window") != "1d" {
+			http.Error(w, "unexpected request "+r.URL.String(), http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(allocationResponseJSON))
+	}))
+	defer server.Close()
+
+	api, err := ParseAPI(server.URL, FlavorOpenCost)
+	if err != nil {
+		t.Fatalf("ParseAPI() error = %v", err)
+	}
+	allocations, err := api.Allocations(context.Background(), nil, "1d", ReleaseAggregate)
+	if err != nil {
+		t.Fatalf("Allocations() error = %v", err)
+	}
+
+	ingress, ok := allocations["prod-ingress"]
+	if !ok || len(allocations) != 2 {
+		t.Fatalf("unexpected allocations %+v", allocations)
+	}
+	// 5 per day is 5/24 per hour over 730 hours
+	if got, want := ingress.Monthly(ingress.TotalCost), 5.0/24*730; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Monthly() = %v, want %v", got, want)
+	}
+	if got := (Allocation{TotalCost: 1}).Monthly(1); got != 0 {
+		t.Errorf("Monthly() without minutes = %v, want 0", got)
+	}
+}
+
+func TestParseAllocationsError(t *testing.T) {
+	if _, err := ParseAllocations([]byte(`{"code": 400, "message": "invalid window"}`)); err == nil {
+		t.Error("expected an error for an error response")
+	}
+}
@@ -0,0 +1,183 @@
+// Package flux reads Flux HelmReleases and Kustomizations, so that workloads
+// deployed with plain Flux can be told apart from those deployed through the
+// App Platform.
+package flux
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+)
+
+// Kinds of Flux resources
+const (
+	KindHelmRelease   = "HelmRelease"
+	KindKustomization = "Kustomization"
+)
+
+// Labels Flux sets on the resources it applies, naming the HelmRelease or
+// Kustomization that manages them
+const (
+	HelmReleaseNameLabel        = "helm.toolkit.fluxcd.io/name"
+	HelmReleaseNamespaceLabel   = "helm.toolkit.fluxcd.io/namespace"
+	KustomizationNameLabel      = "kustomize.toolkit.fluxcd.io/name"
+	KustomizationNamespaceLabel = "kustomize.toolkit.fluxcd.io/namespace"
+)
+
+// HelmReleaseGVRs are the HelmRelease API versions, newest first
+var HelmReleaseGVRs = []schema.GroupVersionResource{
+	{Group: "helm.toolkit.fluxcd.io", Version: "v2", Resource: "helmreleases"},
+	{Group: "helm.toolkit.fluxcd.io", Version: "v2beta2", Resource: "helmreleases"},
+	{Group: "helm.toolkit.fluxcd.io", Version: "v2beta1", Resource: "helmreleases"},
+}
+
+// KustomizationGVRs are the Kustomization API versions, newest first
+var KustomizationGVRs = []schema.GroupVersionResource{
+	{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Resource: "kustomizations"},
+	{Group: "kustomize.toolkit.fluxcd.io", Version: "v1beta2", Resource: "kustomizations"},
+}
+
+// Resource is a Flux HelmRelease or Kustomization
+type Resource struct {
+	Kind      string
+	Name      string
+	Namespace string
+	// Ready is the status of the Ready condition, Unknown when not reported
+	Ready     string
+	Message   string
+	Suspended bool
+	// Source is the source reference as kind/namespace/name
+	Source string
+	// Revision is the last applied source revision
+	Revision string
+
+	// Path is the path in the source of a Kustomization
+	Path string
+	// Chart and ChartVersion are the chart of a HelmRelease
+	Chart        string
+	ChartVersion string
+	// TargetNamespace is where the resources are deployed
+	TargetNamespace string
+}
+
+// Ref returns the resource as namespace/name
+func (r *Resource) Ref() string {
+	return r.Namespace + "/" + r.Name
+}
+
+// NewResourceFromUnstructured converts an unstructured HelmRelease or
+// Kustomization
+func NewResourceFromUnstructured(obj *unstructured.Unstructured) *Resource {
+	r := &Resource{
+		Kind:      obj.GetKind(),
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+		Ready:     "Unknown",
+	}
+	str := func(path ...string) string {
+		value, _, _ := unstructured.NestedString(obj.Object, path...)
+		return value
+	}
+
+	r.Suspended, _, _ = unstructured.NestedBool(obj.Object, "spec", "suspend")
+	r.TargetNamespace = str("spec", "targetNamespace")
+	r.Path = str("spec", "path")
+	r.Revision = str("status", "lastAppliedRevision")
+
+	sourceRef := []string{"spec", "sourceRef"}
+	if r.Kind == KindHelmRelease {
+		r.Chart = str("spec", "chart", "spec", "chart")
+		r.ChartVersion = str("spec", "chart", "spec", "version")
+		sourceRef = []string{"spec", "chart", "spec", "sourceRef"}
+		if r.Revision == "" {
+			r.Revision = str("status", "lastAttemptedRevision")
+		}
+		// HelmReleases may reference a chart through a HelmChart or OCIRepository
+		if chartRef := str("spec", "chartRef", "name"); chartRef != "" && r.Chart == "" {
+			sourceRef = []string{"spec", "chartRef"}
+		}
+	}
+	if kind := str(append(sourceRef, "kind")...); kind != "" {
+		namespace := str(append(sourceRef, "namespace")...)
+		if namespace == "" {
+			namespace = r.Namespace
+		}
+		r.Source = fmt.Sprintf("%s/%s/%s", kind, namespace, str(append(sourceRef, "name")...))
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+		if status, ok := condition["status"].(string); ok {
+			r.Ready = status
+		}
+		r.Message, _ = condition["message"].(string)
+	}
+
+	return r
+}
+
+// Client lists Flux resources
+type Client struct {
+	dynamicClient dynamic.Interface
+}
+
+// NewClient creates a new Flux client
+func NewClient(dynamicClient *k8s.DynamicClient) *Client {
+	return &Client{dynamicClient: dynamicClient.GetInterface()}
+}
+
+// ListHelmReleases lists the HelmReleases of a namespace. The error is a
+// NotFound or NoMatch error when helm-controller is not installed.
+func (c *Client) ListHelmReleases(ctx context.Context, namespace string) ([]*Resource, error) {
+	return c.list(ctx, HelmReleaseGVRs, KindHelmRelease, namespace)
+}
+
+// ListKustomizations lists the Kustomizations of a namespace. The error is a
+// NotFound or NoMatch error when kustomize-controller is not installed.
+func (c *Client) ListKustomizations(ctx context.Context, namespace string) ([]*Resource, error) {
+	return c.list(ctx, KustomizationGVRs, KindKustomization, namespace)
+}
+
+// list tries the API versions in order, as clusters serve different Flux
+// versions
+func (c *Client) list(ctx context.Context, gvrs []schema.GroupVersionResource, kind, namespace string) ([]*Resource, error) {
+	var notInstalled error
+	for _, gvr := range gvrs {
+		list, err := c.dynamicClient.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if IsNotInstalled(err) {
+			notInstalled = err
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %ss in namespace %s: %w", kind, namespace, err)
+		}
+
+		resources := make([]*Resource, 0, len(list.Items))
+		for i := range list.Items {
+			item := &list.Items[i]
+			if item.GetKind() == "" {
+				item.SetKind(kind)
+			}
+			resources = append(resources, NewResourceFromUnstructured(item))
+		}
+		return resources, nil
+	}
+	return nil, notInstalled
+}
+
+// IsNotInstalled reports whether an error means that a Flux API is not served
+func IsNotInstalled(err error) bool {
+	return apierrors.IsNotFound(err) || meta.IsNoMatchError(err)
+}
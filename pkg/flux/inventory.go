@@ -0,0 +1,113 @@
+package flux
+
+import (
+	"sort"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+)
+
+// Management of an App: applied by hand or another tool, by a Flux
+// Kustomization, e.g. from a GitOps repository, or rendered by a HelmRelease
+const (
+	ManagedManually        = "manual"
+	ManagedByKustomization = "kustomization"
+	ManagedByHelmRelease   = "helmrelease"
+)
+
+// ManagedApp is an App and the Flux resource managing it, if any
+type ManagedApp struct {
+	App *app.App
+	// ManagedBy is ManagedManually, ManagedByKustomization or
+	// ManagedByHelmRelease
+	ManagedBy string
+	// Manager is the managing Kustomization or HelmRelease as namespace/name
+	Manager string
+}
+
+// HelmReleaseEntry is a HelmRelease with the Apps it relates to
+type HelmReleaseEntry struct {
+	*Resource
+	// Apps are the App CRs rendered by the HelmRelease
+	Apps []string
+	// SameChartApps are App CRs deploying the same chart, which may mean the
+	// workload is deployed twice
+	SameChartApps []string
+}
+
+// KustomizationEntry is a Kustomization with the Apps it applies
+type KustomizationEntry struct {
+	*Resource
+	Apps []string
+}
+
+// Inventory correlates the Apps and Flux resources of a set of namespaces
+type Inventory struct {
+	Apps           []ManagedApp
+	HelmReleases   []HelmReleaseEntry
+	Kustomizations []KustomizationEntry
+}
+
+// ManagerOf returns how a resource with the given labels is managed and by
+// which Flux resource, from the labels Flux sets on applied resources
+func ManagerOf(labels map[string]string) (string, string) {
+	if name := labels[HelmReleaseNameLabel]; name != "" {
+		return ManagedByHelmRelease, labels[HelmReleaseNamespaceLabel] + "/" + name
+	}
+	if name := labels[KustomizationNameLabel]; name != "" {
+		return ManagedByKustomization, labels[KustomizationNamespaceLabel] + "/" + name
+	}
+	return ManagedManually, ""
+}
+
+// NewInventory correlates Apps with HelmReleases and Kustomizations
+func NewInventory(apps []*app.App, helmReleases, kustomizations []*Resource) *Inventory {
+	inventory := &Inventory{}
+	managed := make(map[string][]string)
+	byChart := make(map[string][]string)
+
+	for _, a := range apps {
+		managedBy, manager := ManagerOf(a.Labels)
+		inventory.Apps = append(inventory.Apps, ManagedApp{App: a, ManagedBy: managedBy, Manager: manager})
+		ref := a.Namespace + "/" + a.Name
+		if manager != "" {
+			managed[managedBy+":"+manager] = append(managed[managedBy+":"+manager], ref)
+		}
+		byChart[a.Spec.Name] = append(byChart[a.Spec.Name], ref)
+	}
+
+	for _, hr := range helmReleases {
+		entry := HelmReleaseEntry{Resource: hr, Apps: managed[ManagedByHelmRelease+":"+hr.Ref()]}
+		if hr.Chart != "" {
+			entry.SameChartApps = byChart[hr.Chart]
+		}
+		inventory.HelmReleases = append(inventory.HelmReleases, entry)
+	}
+	for _, ks := range kustomizations {
+		inventory.Kustomizations = append(inventory.Kustomizations, KustomizationEntry{Resource: ks, Apps: managed[ManagedByKustomization+":"+ks.Ref()]})
+	}
+
+	sort.Slice(inventory.Apps, func(i, j int) bool {
+		a, b := inventory.Apps[i].App, inventory.Apps[j].App
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	sort.Slice(inventory.HelmReleases, func(i, j int) bool {
+		return inventory.HelmReleases[i].Ref() < inventory.HelmReleases[j].Ref()
+	})
+	sort.Slice(inventory.Kustomizations, func(i, j int) bool {
+		return inventory.Kustomizations[i].Ref() < inventory.Kustomizations[j].Ref()
+	})
+
+	return inventory
+}
+
+// Counts returns the number of Apps per management
+func (i *Inventory) Counts() map[string]int {
+	counts := make(map[string]int)
+	for _, a := range i.Apps {
+		counts[a.ManagedBy]++
+	}
+	return counts
+}
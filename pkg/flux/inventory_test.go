@@ -0,0 +1,68 @@
+package flux
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+)
+
+func TestNewInventory(t *testing.T) {
+	apps := []*app.App{
+		{Name: "prod-ingress", Namespace: "org-acme", Spec: app.AppSpec{Name: "ingress-nginx"},
+			Labels: map[string]string{KustomizationNameLabel: "acme-gitops", KustomizationNamespaceLabel: "default"}},
+		{Name: "dex", Namespace: "org-acme", Spec: app.AppSpec{Name: "dex"}},
+		{Name: "podinfo", Namespace: "org-acme", Spec: app.AppSpec{Name: "podinfo"},
+			Labels: map[string]string{HelmReleaseNameLabel: "bundle", HelmReleaseNamespaceLabel: "org-acme"}},
+	}
+	helmReleases := []*Resource{
+		{Kind: KindHelmRelease, Name: "bundle", Namespace: "org-acme", Chart: "bundle"},
+		{Kind: KindHelmRelease, Name: "podinfo", Namespace: "org-acme", Chart: "podinfo"},
+	}
+	kustomizations := []*Resource{{Kind: KindKustomization, Name: "acme-gitops", Namespace: "default"}}
+
+	inventory := NewInventory(apps, helmReleases, kustomizations)
+
+	if got := inventory.Apps[0].App.Name; got != "dex" || inventory.Apps[0].ManagedBy != ManagedManually {
+		t.Errorf("first app = %s (%s), want dex managed manually", got, inventory.Apps[0].ManagedBy)
+	}
+	counts := inventory.Counts()
+	if counts[ManagedManually] != 1 || counts[ManagedByKustomization] != 1 || counts[ManagedByHelmRelease] != 1 {
+		t.Errorf("counts = %v", counts)
+	}
+	if apps := inventory.Kustomizations[0].Apps; len(apps) != 1 || apps[0] != "org-acme/prod-ingress" {
+		t.Errorf("kustomization apps = %v", apps)
+	}
+	if bundle := inventory.HelmReleases[0]; len(bundle.Apps) != 1 || len(bundle.SameChartApps) != 0 {
+		t.Errorf("bundle = %+v", bundle)
+	}
+	if podinfo := inventory.HelmReleases[1]; len(podinfo.SameChartApps) != 1 || podinfo.SameChartApps[0] != "org-acme/podinfo" {
+		t.Errorf("podinfo = %+v, want the podinfo App flagged", podinfo)
+	}
+}
+
+func TestNewResourceFromUnstructured(t *testing.T) {
+	hr := NewResourceFromUnstructured(&unstructured.Unstructured{Object: map[string]interface{}{
+		"kind":     "HelmRelease",
+		"metadata": map[string]interface{}{"name": "podinfo", "namespace": "org-acme"},
+		"spec": map[string]interface{}{
+			"suspend": true,
+			"chart": map[string]interface{}{"spec": map[string]interface{}{
+				"chart":     "podinfo",
+				"version":   "6.5.0",
+				"sourceRef": map[string]interface{}{"kind": "HelmRepository", "name": "podinfo"},
+			}},
+		},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "False", "message": "install retries exhausted"}},
+		},
+	}})
+
+	if hr.Chart != "podinfo" || hr.ChartVersion != "6.5.0" || hr.Source != "HelmRepository/org-acme/podinfo" {
+		t.Errorf("chart = %s %s from %s", hr.Chart, hr.ChartVersion, hr.Source)
+	}
+	if !hr.Suspended || hr.Ready != "False" || hr.Message != "install retries exhausted" {
+		t.Errorf("status = %+v", hr)
+	}
+}
@@ -0,0 +1,219 @@
+// Package gitops renders apps, their configuration and cluster definitions
+// in the directory structure of the Giant Swarm GitOps template
+// (https://github.com/giantswarm/gitops-template), so that resources created
+// by hand can be moved into a repository reconciled by Flux.
+package gitops
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+)
+
+// KustomizationFile is the kustomization written to every directory of an
+// export, listing its manifests and subdirectories
+const KustomizationFile = "kustomization.yaml"
+
+// droppedLabelPrefixes are dropped from exported resources, as they are
+// set by the controllers currently managing them
+var droppedLabelPrefixes = []string{
+	"kustomize.toolkit.fluxcd.io/",
+	"helm.toolkit.fluxcd.io/",
+}
+
+// Layout places resources in the GitOps template directory structure:
+//
+//	management-clusters/<mc>/organizations/<org>/
+//	  apps/<app>/                           apps of the management cluster
+//	  workload-clusters/<cluster>/cluster/  the cluster definition
+//	  workload-clusters/<cluster>/mapi/apps/<app>/
+type Layout struct {
+	ManagementCluster string
+	Organization      string
+}
+
+// OrganizationDir returns the directory of the organization
+func (l Layout) OrganizationDir() string {
+	return path.Join("management-clusters", l.ManagementCluster, "organizations", l.Organization)
+}
+
+// WorkloadClusterDir returns the directory of a workload cluster
+func (l Layout) WorkloadClusterDir(clusterName string) string {
+	return path.Join(l.OrganizationDir(), "workload-clusters", clusterName)
+}
+
+// AppDir returns the directory of an app deployed to a workload cluster, or
+// to the management cluster when clusterName is empty. Cluster apps and
+// their default apps go into the cluster definition.
+func (l Layout) AppDir(clusterName string, a *app.App) string {
+	switch {
+	case clusterName == "":
+		return path.Join(l.OrganizationDir(), "apps", a.Name)
+	case IsClusterDefinition(a):
+		return path.Join(l.WorkloadClusterDir(clusterName), "cluster", a.Name)
+	}
+	return path.Join(l.WorkloadClusterDir(clusterName), "mapi", "apps", a.Name)
+}
+
+// IsClusterDefinition reports whether an app defines a cluster, i.e. deploys
+// a cluster-$provider or default-apps-$provider chart
+func IsClusterDefinition(a *app.App) bool {
+	return cluster.IsClusterChart(a.Spec.Name) || strings.HasPrefix(a.Spec.Name, "default-apps-")
+}
+
+// Export collects the files of a GitOps repository export
+type Export struct {
+	Layout Layout
+	files  map[string]string
+}
+
+// NewExport creates an empty export
+func NewExport(layout Layout) *Export {
+	return &Export{Layout: layout, files: make(map[string]string)}
+}
+
+// AddApp adds an app and the ConfigMaps and Secrets it references. The App is
+// written to appcr.yaml, ConfigMaps to configmap.yaml and Secrets, without
+// their values, to secret.enc.yaml to be encrypted with SOPS.
+func (e *Export) AddApp(clusterName string, a *app.App, configs []*config.Config) error {
+	dir := e.Layout.AppDir(clusterName, a)
+
+	exported := *a
+	exported.Labels = exportedLabels(a.Labels)
+	if err := e.addManifest(path.Join(dir, "appcr.yaml"), "", exported.Manifest()); err != nil {
+		return err
+	}
+
+	for _, cfg := range configs {
+		cfg = cfg.DeepCopy()
+		cfg.Labels = exportedLabels(cfg.Labels)
+
+		// Never write secret values in plain text to a repository
+		file, header := "configmap.yaml", ""
+		if cfg.IsSecret() {
+			file, header = "secret.enc.yaml", "# Fill in the values and encrypt this file with SOPS before committing it\n"
+		}
+
+		// Further resources of the same kind are suffixed with their name
+		base, ext, _ := strings.Cut(file, ".")
+		file = path.Join(dir, file)
+		if _, taken := e.files[file]; taken {
+			file = path.Join(dir, fmt.Sprintf("%s-%s.%s", base, cfg.Name, ext))
+		}
+		if err := e.addManifest(file, header, cfg.Manifest(false)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *Export) addManifest(file, header string, manifest map[string]interface{}) error {
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", file, err)
+	}
+	e.files[file] = header + string(data)
+	return nil
+}
+
+func exportedLabels(labels map[string]string) map[string]string {
+	exported := make(map[string]string, len(labels))
+	for key, value := range labels {
+		dropped := false
+		for _, prefix := range droppedLabelPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				dropped = true
+				break
+			}
+		}
+		if !dropped {
+			exported[key] = value
+		}
+	}
+	return exported
+}
+
+// Files returns the exported files by path, including a kustomization.yaml
+// in every directory from the organization directory down
+func (e *Export) Files() map[string]string {
+	files := make(map[string]string, len(e.files))
+	resources := make(map[string]map[string]bool)
+	root := e.Layout.OrganizationDir()
+
+	for file, content := range e.files {
+		files[file] = content
+		// Register the file with its directory and each directory with
+		// its parent, up to the organization directory
+		for child := file; child != root && strings.HasPrefix(child, root+"/"); child = path.Dir(child) {
+			dir := path.Dir(child)
+			if resources[dir] == nil {
+				resources[dir] = make(map[string]bool)
+			}
+			resources[dir][path.Base(child)] = true
+		}
+	}
+
+	for dir, children := range resources {
+		names := make([]string, 0, len(children))
+		for name := range children {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var kustomization strings.Builder
+		kustomization.WriteString("apiVersion: kustomize.config.k8s.io/v1beta1\nkind: Kustomization\nresources:\n")
+		for _, name := range names {
+			kustomization.WriteString("- " + name + "\n")
+		}
+		files[path.Join(dir, KustomizationFile)] = kustomization.String()
+	}
+
+	return files
+}
+
+// Paths returns the sorted paths of the exported files
+func (e *Export) Paths() []string {
+	files := e.Files()
+	paths := make([]string, 0, len(files))
+	for file := range files {
+		paths = append(paths, file)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Tarball returns the exported files as a gzipped tar archive
+func (e *Export) Tarball() ([]byte, error) {
+	files := e.Files()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, file := range e.Paths() {
+		content := files[file]
+		if err := tw.WriteHeader(&tar.Header{Name: file, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			return nil, fmt.Errorf("failed to write %s to archive: %w", file, err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			return nil, fmt.Errorf("failed to write %s to archive: %w", file, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
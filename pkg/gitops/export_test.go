@@ -0,0 +1,99 @@
+package gitops
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+)
+
+func TestExport(t *testing.T) {
+	export := NewExport(Layout{ManagementCluster: "golem", Organization: "acme"})
+	org := "management-clusters/golem/organizations/acme"
+
+	clusterApp := &app.App{Name: "prod", Namespace: "org-acme", Spec: app.AppSpec{Name: "cluster-aws", Version: "2.0.0", KubeConfig: app.KubeConfig{InCluster: true}}}
+	if err := export.AddApp("prod", clusterApp, []*config.Config{
+		{Name: "prod-userconfig", Namespace: "org-acme", Type: config.ConfigTypeConfigMap, Data: map[string]string{"values": "global: {}\n"}},
+	}); err != nil {
+		t.Fatalf("AddApp() error = %v", err)
+	}
+
+	ingress := &app.App{Name: "prod-ingress", Namespace: "org-acme",
+		Labels: map[string]string{"giantswarm.io/cluster": "prod", "kustomize.toolkit.fluxcd.io/name": "old"},
+		Spec:   app.AppSpec{Name: "ingress-nginx", Version: "3.0.0"}}
+	if err := export.AddApp("prod", ingress, []*config.Config{
+		{Name: "prod-ingress-user-values", Namespace: "org-acme", Type: config.ConfigTypeConfigMap, Data: map[string]string{"values": "replicas: 2\n"}},
+		{Name: "prod-ingress-user-secrets", Namespace: "org-acme", Type: config.ConfigTypeSecret, Data: map[string]string{"values": "token: s3cr3t\n"}},
+		{Name: "prod-ingress-extra", Namespace: "org-acme", Type: config.ConfigTypeConfigMap, Data: map[string]string{"values": "a: b\n"}},
+	}); err != nil {
+		t.Fatalf("AddApp() error = %v", err)
+	}
+
+	files := export.Files()
+	appDir := org + "/workload-clusters/prod/mapi/apps/prod-ingress/"
+	for _, file := range []string{
+		org + "/kustomization.yaml",
+		org + "/workload-clusters/prod/cluster/prod/appcr.yaml",
+		org + "/workload-clusters/prod/cluster/prod/configmap.yaml",
+		appDir + "appcr.yaml",
+		appDir + "configmap.yaml",
+		appDir + "configmap-prod-ingress-extra.yaml",
+		appDir + "secret.enc.yaml",
+		appDir + "kustomization.yaml",
+	} {
+		if _, ok := files[file]; !ok {
+			t.Errorf("missing %s in %v", file, export.Paths())
+		}
+	}
+
+	if secret := files[appDir+"secret.enc.yaml"]; strings.Contains(secret, "s3cr3t") || !strings.Contains(secret, "SOPS") {
+		t.Errorf("secret values must be left out:\n%s", secret)
+	}
+	if appCR := files[appDir+"appcr.yaml"]; strings.Contains(appCR, "kustomize.toolkit.fluxcd.io") || !strings.Contains(appCR, "giantswarm.io/cluster: prod") {
+		t.Errorf("unexpected labels:\n%s", appCR)
+	}
+	if got, want := files[org+"/workload-clusters/prod/kustomization.yaml"], "resources:\n- cluster\n- mapi\n"; !strings.HasSuffix(got, want) {
+		t.Errorf("kustomization = %q, want suffix %q", got, want)
+	}
+	if _, ok := files["management-clusters/golem/kustomization.yaml"]; ok {
+		t.Error("kustomizations must not be written above the organization directory")
+	}
+
+	archive, err := export.Tarball()
+	if err != nil {
+		t.Fatalf("Tarball() error = %v", err)
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	tr := tar.NewReader(gz)
+	count := 0
+	for {
+		if _, err := tr.Next(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("tar error = %v", err)
+		}
+		count++
+	}
+	if count != len(files) {
+		t.Errorf("archive has %d files, want %d", count, len(files))
+	}
+}
+
+func TestLayoutAppDir(t *testing.T) {
+	layout := Layout{ManagementCluster: "golem", Organization: "acme"}
+	defaultApps := &app.App{Name: "prod-default-apps", Spec: app.AppSpec{Name: "default-apps-aws"}}
+	if got := layout.AppDir("prod", defaultApps); got != "management-clusters/golem/organizations/acme/workload-clusters/prod/cluster/prod-default-apps" {
+		t.Errorf("AppDir() = %s", got)
+	}
+	if got := layout.AppDir("", &app.App{Name: "dex"}); got != "management-clusters/golem/organizations/acme/apps/dex" {
+		t.Errorf("AppDir() = %s", got)
+	}
+}
@@ -0,0 +1,126 @@
+package iac
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// hclIdentifier matches object keys that need no quotes
+var hclIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// hclExpression is written to HCL verbatim, e.g. a variable reference
+type hclExpression string
+
+// writeHCLValue writes a manifest value as an HCL expression, indenting nested
+// objects and lists by two spaces per level
+func writeHCLValue(out *strings.Builder, value interface{}, indent int) {
+	pad := strings.Repeat("  ", indent)
+	switch v := value.(type) {
+	case hclExpression:
+		out.WriteString(string(v))
+	case map[string]interface{}:
+		if len(v) == 0 {
+			out.WriteString("{}")
+			return
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		out.WriteString("{\n")
+		for _, key := range keys {
+			out.WriteString(pad + "  " + hclKey(key) + " = ")
+			writeHCLValue(out, v[key], indent+1)
+			out.WriteString("\n")
+		}
+		out.WriteString(pad + "}")
+	case map[string]string:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[key] = item
+		}
+		writeHCLValue(out, converted, indent)
+	case []interface{}:
+		if len(v) == 0 {
+			out.WriteString("[]")
+			return
+		}
+		out.WriteString("[\n")
+		for _, item := range v {
+			out.WriteString(pad + "  ")
+			writeHCLValue(out, item, indent+1)
+			out.WriteString(",\n")
+		}
+		out.WriteString(pad + "]")
+	case []string:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = item
+		}
+		writeHCLValue(out, items, indent)
+	case string:
+		writeHCLString(out, v, indent)
+	case nil:
+		out.WriteString("null")
+	default:
+		// bool, int64, float64 and the like print as HCL literals
+		out.WriteString(fmt.Sprint(v))
+	}
+}
+
+// writeHCLString writes a quoted string, or a heredoc for multi-line strings
+// like Helm values. Template sequences are escaped so that HCL keeps them
+// literally.
+func writeHCLString(out *strings.Builder, s string, indent int) {
+	s = strings.ReplaceAll(s, "${", "$${")
+	s = strings.ReplaceAll(s, "%{", "%%{")
+
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	if len(lines) > 1 && strings.HasSuffix(s, "\n") && !containsHeredocEnd(lines) {
+		out.WriteString("<<-EOT\n")
+		pad := strings.Repeat("  ", indent+1)
+		for _, line := range lines {
+			out.WriteString(pad + line + "\n")
+		}
+		out.WriteString(strings.Repeat("  ", indent) + "EOT")
+		return
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	out.WriteString(`"` + replacer.Replace(s) + `"`)
+}
+
+// containsHeredocEnd reports whether a line would end a heredoc early
+func containsHeredocEnd(lines []string) bool {
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "EOT" {
+			return true
+		}
+	}
+	return false
+}
+
+// hclKey returns an object key, quoted unless it is an identifier
+func hclKey(key string) string {
+	if hclIdentifier.MatchString(key) {
+		return key
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(key) + `"`
+}
+
+// hclName turns Kubernetes names into a Terraform resource or variable name
+func hclName(parts ...string) string {
+	name := strings.Join(parts, "_")
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,158 @@
+// Package iac converts Apps and their configuration into infrastructure as
+// code: Terraform configuration for the hashicorp/kubernetes provider and
+// Crossplane provider-kubernetes Objects. Both manage the App CRs, so the App
+// Platform keeps deploying the apps once the resources are imported.
+package iac
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+)
+
+// CrossplaneObjectAPIVersion is the provider-kubernetes Object API
+const CrossplaneObjectAPIVersion = "kubernetes.crossplane.io/v1alpha2"
+
+// Definition is an App together with the ConfigMaps and Secrets it references
+type Definition struct {
+	App     *app.App
+	Configs []*config.Config
+}
+
+// sortedDefinitions orders definitions by namespace and name, so that the
+// output is stable
+func sortedDefinitions(definitions []Definition) []Definition {
+	sorted := append([]Definition(nil), definitions...)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i].App, sorted[j].App
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return sorted
+}
+
+// Terraform renders the definitions as Terraform configuration using
+// kubernetes_manifest resources. Secret values become sensitive variables,
+// which are declared at the top.
+func Terraform(definitions []Definition) (string, error) {
+	var variables, resources strings.Builder
+
+	for _, def := range sortedDefinitions(definitions) {
+		var dependencies []string
+		for _, cfg := range def.Configs {
+			kind := "configmap"
+			if cfg.IsSecret() {
+				kind = "secret"
+			}
+			name := hclName(kind, cfg.Namespace, cfg.Name)
+			manifest := cfg.Manifest(false)
+
+			if cfg.IsSecret() {
+				stringData := make(map[string]interface{}, len(cfg.Data))
+				keys := make([]string, 0, len(cfg.Data))
+				for key := range cfg.Data {
+					keys = append(keys, key)
+				}
+				sort.Strings(keys)
+				for _, key := range keys {
+					variable := hclName(name, key)
+					variables.WriteString(fmt.Sprintf("variable %q {\n  description = %q\n  type        = string\n  sensitive   = true\n}\n\n",
+						variable, fmt.Sprintf("Key %s of Secret %s/%s", key, cfg.Namespace, cfg.Name)))
+					stringData[key] = hclExpression("var." + variable)
+				}
+				manifest["stringData"] = stringData
+			}
+
+			writeTerraformResource(&resources, name, manifest, nil)
+			dependencies = append(dependencies, "kubernetes_manifest."+name)
+		}
+
+		writeTerraformResource(&resources, hclName("app", def.App.Namespace, def.App.Name), def.App.Manifest(), dependencies)
+	}
+
+	if resources.Len() == 0 {
+		return "", fmt.Errorf("no apps to export")
+	}
+
+	var out strings.Builder
+	out.WriteString("terraform {\n  required_providers {\n    kubernetes = {\n      source = \"hashicorp/kubernetes\"\n    }\n  }\n}\n\n")
+	out.WriteString(variables.String())
+	out.WriteString(strings.TrimSuffix(resources.String(), "\n"))
+	return out.String(), nil
+}
+
+func writeTerraformResource(out *strings.Builder, name string, manifest map[string]interface{}, dependsOn []string) {
+	out.WriteString(fmt.Sprintf("resource \"kubernetes_manifest\" %q {\n  manifest = ", name))
+	writeHCLValue(out, manifest, 1)
+	out.WriteString("\n")
+	if len(dependsOn) > 0 {
+		out.WriteString("\n  depends_on = [\n")
+		for _, dependency := range dependsOn {
+			out.WriteString("    " + dependency + ",\n")
+		}
+		out.WriteString("  ]\n")
+	}
+	out.WriteString("}\n\n")
+}
+
+// Crossplane renders the definitions as provider-kubernetes Objects using
+// the given ProviderConfig. Secrets are written without their values, which
+// have to be filled in or patched from a secret store.
+func Crossplane(definitions []Definition, providerConfig string) (string, error) {
+	var documents []map[string]interface{}
+	for _, def := range sortedDefinitions(definitions) {
+		for _, cfg := range def.Configs {
+			kind := "configmap"
+			if cfg.IsSecret() {
+				kind = "secret"
+			}
+			documents = append(documents, crossplaneObject(objectName(cfg.Namespace, cfg.Name, kind), cfg.Manifest(false), providerConfig))
+		}
+		documents = append(documents, crossplaneObject(objectName(def.App.Namespace, def.App.Name, "app"), def.App.Manifest(), providerConfig))
+	}
+
+	if len(documents) == 0 {
+		return "", fmt.Errorf("no apps to export")
+	}
+
+	var out strings.Builder
+	for i, doc := range documents {
+		data, err := yaml.Marshal(doc)
+		if err != nil {
+			return "", fmt.Errorf("failed to render Crossplane object: %w", err)
+		}
+		if i > 0 {
+			out.WriteString("---\n")
+		}
+		out.Write(data)
+	}
+	return out.String(), nil
+}
+
+func crossplaneObject(name string, manifest map[string]interface{}, providerConfig string) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": CrossplaneObjectAPIVersion,
+		"kind":       "Object",
+		"metadata":   map[string]interface{}{"name": name},
+		"spec": map[string]interface{}{
+			"forProvider":       map[string]interface{}{"manifest": manifest},
+			"providerConfigRef": map[string]interface{}{"name": providerConfig},
+		},
+	}
+}
+
+// objectName names a cluster-scoped Object after the resource it manages
+func objectName(namespace, name, kind string) string {
+	objName := fmt.Sprintf("%s-%s-%s", namespace, name, kind)
+	if len(objName) > 253 {
+		objName = objName[:253]
+	}
+	return objName
+}
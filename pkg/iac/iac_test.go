@@ -0,0 +1,71 @@
+package iac
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+)
+
+func testDefinition() Definition {
+	return Definition{
+		App: &app.App{Name: "prod-ingress", Namespace: "org-acme", Labels: map[string]string{"giantswarm.io/cluster": "prod"},
+			Spec: app.AppSpec{Catalog: "giantswarm", Name: "ingress-nginx", Namespace: "kube-system", Version: "3.0.0",
+				UserConfig: &app.AppConfig{ConfigMap: &app.ConfigMapReference{Name: "prod-ingress-user-values", Namespace: "org-acme"}}}},
+		Configs: []*config.Config{
+			{Name: "prod-ingress-user-values", Namespace: "org-acme", Type: config.ConfigTypeConfigMap,
+				Data: map[string]string{"values": "controller:\n  replicas: 2\n  annotation: \"${not-a-template}\"\n"}},
+			{Name: "prod-ingress-user-secrets", Namespace: "org-acme", Type: config.ConfigTypeSecret,
+				Data: map[string]string{"values": "token: s3cr3t\n"}},
+		},
+	}
+}
+
+func TestTerraform(t *testing.T) {
+	hcl, err := Terraform([]Definition{testDefinition()})
+	if err != nil {
+		t.Fatalf("Terraform() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`source = "hashicorp/kubernetes"`,
+		`variable "secret_org_acme_prod_ingress_user_secrets_values" {`,
+		`resource "kubernetes_manifest" "configmap_org_acme_prod_ingress_user_values" {`,
+		`resource "kubernetes_manifest" "app_org_acme_prod_ingress" {`,
+		"      values = <<-EOT\n        controller:\n          replicas: 2\n",
+		`$${not-a-template}`,
+		`values = var.secret_org_acme_prod_ingress_user_secrets_values`,
+		`"giantswarm.io/cluster" = "prod"`,
+		"kubernetes_manifest.secret_org_acme_prod_ingress_user_secrets,",
+	} {
+		if !strings.Contains(hcl, want) {
+			t.Errorf("missing %q in:\n%s", want, hcl)
+		}
+	}
+	if strings.Contains(hcl, "s3cr3t") {
+		t.Error("secret values must not be written")
+	}
+}
+
+func TestCrossplane(t *testing.T) {
+	out, err := Crossplane([]Definition{testDefinition()}, "in-cluster")
+	if err != nil {
+		t.Fatalf("Crossplane() error = %v", err)
+	}
+	if got := strings.Count(out, "kind: Object"); got != 3 {
+		t.Errorf("got %d objects, want 3:\n%s", got, out)
+	}
+	for _, want := range []string{"name: org-acme-prod-ingress-app", "name: in-cluster", "kind: App"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "s3cr3t") {
+		t.Error("secret values must not be written")
+	}
+
+	if _, err := Crossplane(nil, "default"); err == nil {
+		t.Error("expected an error without apps")
+	}
+}
@@ -0,0 +1,227 @@
+// Package metrics takes utilization snapshots of clusters from the
+// metrics-server or from Prometheus: the CPU and memory the nodes and pods use
+// now, compared to what the nodes can allocate.
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Paths of the metrics.k8s.io API served by the metrics-server
+const (
+	nodeMetricsPath = "/apis/metrics.k8s.io/v1beta1/nodes"
+	podMetricsPath  = "/apis/metrics.k8s.io/v1beta1/pods"
+)
+
+// NodeUsage is the utilization of a node
+type NodeUsage struct {
+	Name  string
+	Ready bool
+
+	CPUCores    float64
+	MemoryBytes float64
+
+	AllocatableCPUCores    float64
+	AllocatableMemoryBytes float64
+}
+
+// CPUPercent is the share of the allocatable CPU in use
+func (n NodeUsage) CPUPercent() float64 {
+	return percent(n.CPUCores, n.AllocatableCPUCores)
+}
+
+// MemoryPercent is the share of the allocatable memory in use
+func (n NodeUsage) MemoryPercent() float64 {
+	return percent(n.MemoryBytes, n.AllocatableMemoryBytes)
+}
+
+// PodUsage is the utilization of a pod, or of all pods of a namespace
+type PodUsage struct {
+	Namespace string
+	Name      string
+
+	CPUCores    float64
+	MemoryBytes float64
+}
+
+// Snapshot is the utilization of a cluster at one point in time
+type Snapshot struct {
+	// Source names where the usage was read from
+	Source string
+	Nodes  []NodeUsage
+	Pods   []PodUsage
+}
+
+// Total sums the usage and allocatable resources of all nodes
+func (s *Snapshot) Total() NodeUsage {
+	total := NodeUsage{Name: "total"}
+	for _, n := range s.Nodes {
+		total.CPUCores += n.CPUCores
+		total.MemoryBytes += n.MemoryBytes
+		total.AllocatableCPUCores += n.AllocatableCPUCores
+		total.AllocatableMemoryBytes += n.AllocatableMemoryBytes
+	}
+	return total
+}
+
+// TopPods returns the n pods using the most memory, or the most CPU
+func (s *Snapshot) TopPods(n int, byMemory bool) []PodUsage {
+	return top(s.Pods, n, byMemory)
+}
+
+// TopNamespaces sums the usage of the pods per namespace and returns the n
+// namespaces using the most memory, or the most CPU
+func (s *Snapshot) TopNamespaces(n int, byMemory bool) []PodUsage {
+	byNamespace := map[string]*PodUsage{}
+	var namespaces []PodUsage
+	for _, pod := range s.Pods {
+		usage, ok := byNamespace[pod.Namespace]
+		if !ok {
+			usage = &PodUsage{Namespace: pod.Namespace}
+			byNamespace[pod.Namespace] = usage
+		}
+		usage.CPUCores += pod.CPUCores
+		usage.MemoryBytes += pod.MemoryBytes
+	}
+	for _, usage := range byNamespace {
+		namespaces = append(namespaces, *usage)
+	}
+	return top(namespaces, n, byMemory)
+}
+
+func top(usages []PodUsage, n int, byMemory bool) []PodUsage {
+	sorted := append([]PodUsage(nil), usages...)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if byMemory && a.MemoryBytes != b.MemoryBytes {
+			return a.MemoryBytes > b.MemoryBytes
+		}
+		if !byMemory && a.CPUCores != b.CPUCores {
+			return a.CPUCores > b.CPUCores
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	if n > 0 && len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// metricsList is a NodeMetricsList or PodMetricsList of the metrics.k8s.io
+// API. Node metrics carry usage, pod metrics the usage of their containers.
+type metricsList struct {
+	Items []struct {
+		Metadata   metav1.ObjectMeta   `json:"metadata"`
+		Usage      corev1.ResourceList `json:"usage"`
+		Containers []struct {
+			Usage corev1.ResourceList `json:"usage"`
+		} `json:"containers"`
+	} `json:"items"`
+}
+
+// FromMetricsServer takes a snapshot from the metrics-server of the cluster
+// k8sClient connects to
+func FromMetricsServer(ctx context.Context, k8sClient kubernetes.Interface) (*Snapshot, error) {
+	snapshot, err := newSnapshot(ctx, k8sClient, "metrics-server")
+	if err != nil {
+		return nil, err
+	}
+
+	var nodeMetrics, podMetrics metricsList
+	if err := getMetrics(ctx, k8sClient, nodeMetricsPath, &nodeMetrics); err != nil {
+		return nil, err
+	}
+	if err := getMetrics(ctx, k8sClient, podMetricsPath, &podMetrics); err != nil {
+		return nil, err
+	}
+
+	usage := map[string]corev1.ResourceList{}
+	for _, item := range nodeMetrics.Items {
+		usage[item.Metadata.Name] = item.Usage
+	}
+	for i := range snapshot.Nodes {
+		n := &snapshot.Nodes[i]
+		n.CPUCores = cores(usage[n.Name])
+		n.MemoryBytes = memoryBytes(usage[n.Name])
+	}
+
+	for _, item := range podMetrics.Items {
+		pod := PodUsage{Namespace: item.Metadata.Namespace, Name: item.Metadata.Name}
+		for _, container := range item.Containers {
+			pod.CPUCores += cores(container.Usage)
+			pod.MemoryBytes += memoryBytes(container.Usage)
+		}
+		snapshot.Pods = append(snapshot.Pods, pod)
+	}
+	return snapshot, nil
+}
+
+func getMetrics(ctx context.Context, k8sClient kubernetes.Interface, path string, into *metricsList) error {
+	data, err := k8sClient.CoreV1().RESTClient().Get().AbsPath(path).DoRaw(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read %s from the metrics-server (is it installed?): %w", path, err)
+	}
+	if err := json.Unmarshal(data, into); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return nil
+}
+
+// newSnapshot starts a snapshot with the allocatable resources of the nodes
+func newSnapshot(ctx context.Context, k8sClient kubernetes.Interface, source string) (*Snapshot, error) {
+	nodes, err := k8sClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	snapshot := &Snapshot{Source: source}
+	for _, node := range nodes.Items {
+		n := NodeUsage{
+			Name:                   node.Name,
+			AllocatableCPUCores:    cores(node.Status.Allocatable),
+			AllocatableMemoryBytes: memoryBytes(node.Status.Allocatable),
+		}
+		for _, cond := range node.Status.Conditions {
+			if cond.Type == corev1.NodeReady {
+				n.Ready = cond.Status == corev1.ConditionTrue
+			}
+		}
+		snapshot.Nodes = append(snapshot.Nodes, n)
+	}
+	sort.Slice(snapshot.Nodes, func(i, j int) bool { return snapshot.Nodes[i].Name < snapshot.Nodes[j].Name })
+	return snapshot, nil
+}
+
+func cores(resources corev1.ResourceList) float64 {
+	return quantity(resources, corev1.ResourceCPU).AsApproximateFloat64()
+}
+
+func memoryBytes(resources corev1.ResourceList) float64 {
+	return quantity(resources, corev1.ResourceMemory).AsApproximateFloat64()
+}
+
+func quantity(resources corev1.ResourceList, name corev1.ResourceName) *resource.Quantity {
+	q, ok := resources[name]
+	if !ok {
+		return resource.NewQuantity(0, resource.DecimalSI)
+	}
+	return &q
+}
+
+func percent(used, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return used / total * 100
+}
@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestNode(name string, ready bool) *corev1.Node {
+	status := corev1.ConditionTrue
+	if !ready {
+		status = corev1.ConditionFalse
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("16Gi"),
+			},
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+		},
+	}
+}
+
+func TestPrometheusSnapshot(t *testing.T) {
+	responses := map[string]string{
+		nodeCPUQuery:    `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"node":"worker-a"},"value":[1700000000,"3"]}]}}`,
+		nodeMemoryQuery: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"node":"worker-a"},"value":[1700000000,"8589934592"]}]}}`,
+		podCPUQuery: `{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{"namespace":"kube-system","pod":"coredns-1"},"value":[1700000000,"0.1"]},
+			{"metric":{"namespace":"kube-system","pod":"cilium-1"},"value":[1700000000,"0.3"]},
+			{"metric":{"namespace":"ingress","pod":"nginx-1"},"value":[1700000000,"0.2"]}]}}`,
+		podMemoryQuery: `{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{"namespace":"ingress","pod":"nginx-1"},"value":[1700000000,"1073741824"]}]}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.URL.Query().Get("query")]
+		if r.URL.Path != "/api/v1/query" || !ok {
+			http.Error(w, "unexpected request "+r.URL.String(), http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	prometheus, err := ParsePrometheus(server.URL)
+	if err != nil {
+		t.Fatalf("ParsePrometheus() error = %v", err)
+	}
+	snapshot, err := prometheus.Snapshot(context.Background(), fake.NewClientset(newTestNode("worker-b", false), newTestNode("worker-a", true)))
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+
+	if len(snapshot.Nodes) != 2 || snapshot.Nodes[0].Name != "worker-a" || snapshot.Nodes[1].Ready {
+		t.Fatalf("unexpected nodes %+v", snapshot.Nodes)
+	}
+	if got := snapshot.Nodes[0].CPUPercent(); got != 75 {
+		t.Errorf("CPUPercent() = %v, want 75", got)
+	}
+	if got := snapshot.Nodes[0].MemoryPercent(); got != 50 {
+		t.Errorf("MemoryPercent() = %v, want 50", got)
+	}
+	if got := snapshot.Total().CPUPercent(); got != 37.5 {
+		t.Errorf("total CPUPercent() = %v, want 37.5", got)
+	}
+
+	namespaces := snapshot.TopNamespaces(1, false)
+	if len(namespaces) != 1 || namespaces[0].Namespace != "kube-system" || namespaces[0].CPUCores != 0.4 {
+		t.Errorf("unexpected top namespaces by CPU %+v", namespaces)
+	}
+	pods := snapshot.TopPods(2, true)
+	if len(pods) != 2 || pods[0].Name != "nginx-1" || pods[0].CPUCores != 0.2 {
+		t.Errorf("unexpected top pods by memory %+v", pods)
+	}
+}
+
+func TestPrometheusQueryError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"error","error":"parse error"}`))
+	}))
+	defer server.Close()
+
+	prometheus, err := ParsePrometheus(server.URL)
+	if err != nil {
+		t.Fatalf("ParsePrometheus() error = %v", err)
+	}
+	if _, err := prometheus.Query(context.Background(), nil, "up{"); err == nil {
+		t.Error("expected an error for a failed query")
+	}
+}
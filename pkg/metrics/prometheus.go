@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+)
+
+// PromQL queries of the cAdvisor metrics the kubelets expose. The root cgroup
+// (id="/") is the usage of the whole node.
+const (
+	nodeCPUQuery    = `sum by (node) (rate(container_cpu_usage_seconds_total{id="/"}[5m]))`
+	nodeMemoryQuery = `sum by (node) (container_memory_working_set_bytes{id="/"})`
+	podCPUQuery     = `sum by (namespace, pod) (rate(container_cpu_usage_seconds_total{container!="",container!="POD"}[5m]))`
+	podMemoryQuery  = `sum by (namespace, pod) (container_memory_working_set_bytes{container!="",container!="POD"})`
+)
+
+// Prometheus is a configured Prometheus query API
+type Prometheus struct {
+	*k8s.Endpoint
+}
+
+// ParsePrometheus parses the --prometheus flag: an http(s) URL, or
+// namespace/service:port of the Prometheus service in every cluster
+func ParsePrometheus(value string) (*Prometheus, error) {
+	endpoint, err := k8s.ParseEndpoint(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Prometheus: %w", err)
+	}
+	return &Prometheus{Endpoint: endpoint}, nil
+}
+
+// Sample is a value of an instant vector with its labels
+type Sample struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// queryResponse is the response of the instant query API
+type queryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			// Value is the [timestamp, "value"] pair
+			Value []interface{} `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// Query runs an instant PromQL query that returns a vector. k8sClient is the
+// client of the cluster whose Prometheus service is proxied.
+func (p *Prometheus) Query(ctx context.Context, k8sClient kubernetes.Interface, query string) ([]Sample, error) {
+	data, err := p.Get(ctx, k8sClient, "/api/v1/query", map[string]string{"query": query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Prometheus %s: %w", p.Endpoint, err)
+	}
+
+	var resp queryResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse the Prometheus response: %w", err)
+	}
+	if resp.Status != "success" {
+		return nil, fmt.Errorf("prometheus query %q failed: %s", query, resp.Error)
+	}
+	if resp.Data.ResultType != "vector" {
+		return nil, fmt.Errorf("prometheus query %q returned a %s, expected a vector", query, resp.Data.ResultType)
+	}
+
+	samples := make([]Sample, 0, len(resp.Data.Result))
+	for _, result := range resp.Data.Result {
+		if len(result.Value) != 2 {
+			continue
+		}
+		text, _ := result.Value[1].(string)
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			continue
+		}
+		samples = append(samples, Sample{Labels: result.Metric, Value: value})
+	}
+	return samples, nil
+}
+
+// Snapshot takes a snapshot from the cAdvisor metrics in Prometheus, with
+// the allocatable resources read from the nodes of the cluster
+func (p *Prometheus) Snapshot(ctx context.Context, k8sClient kubernetes.Interface) (*Snapshot, error) {
+	snapshot, err := newSnapshot(ctx, k8sClient, "Prometheus "+p.Endpoint.String())
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]Sample, 4)
+	for i, query := range []string{nodeCPUQuery, nodeMemoryQuery, podCPUQuery, podMemoryQuery} {
+		if results[i], err = p.Query(ctx, k8sClient, query); err != nil {
+			return nil, err
+		}
+	}
+
+	nodes := map[string]*NodeUsage{}
+	for i := range snapshot.Nodes {
+		nodes[snapshot.Nodes[i].Name] = &snapshot.Nodes[i]
+	}
+	for _, sample := range results[0] {
+		if n, ok := nodes[sample.Labels["node"]]; ok {
+			n.CPUCores = sample.Value
+		}
+	}
+	for _, sample := range results[1] {
+		if n, ok := nodes[sample.Labels["node"]]; ok {
+			n.MemoryBytes = sample.Value
+		}
+	}
+
+	pods := map[string]*PodUsage{}
+	var order []string
+	podUsage := func(sample Sample) *PodUsage {
+		key := sample.Labels["namespace"] + "/" + sample.Labels["pod"]
+		pod, ok := pods[key]
+		if !ok {
+			pod = &PodUsage{Namespace: sample.Labels["namespace"], Name: sample.Labels["pod"]}
+			pods[key] = pod
+			order = append(order, key)
+		}
+		return pod
+	}
+	for _, sample := range results[2] {
+		podUsage(sample).CPUCores = sample.Value
+	}
+	for _, sample := range results[3] {
+		podUsage(sample).MemoryBytes = sample.Value
+	}
+	for _, key := range order {
+		snapshot.Pods = append(snapshot.Pods, *pods[key])
+	}
+	return snapshot, nil
+}
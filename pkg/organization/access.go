@@ -0,0 +1,233 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// AccessResource is a resource covered by an access report
+type AccessResource struct {
+	Name     string
+	Group    string
+	Resource string
+}
+
+// AccessResources are the resources an access report covers
+var AccessResources = []AccessResource{
+	{Name: "apps", Group: "application.giantswarm.io", Resource: "apps"},
+	{Name: "catalogs", Group: "application.giantswarm.io", Resource: "catalogs"},
+	{Name: "clusters", Group: "cluster.x-k8s.io", Resource: "clusters"},
+	{Name: "secrets", Group: "", Resource: "secrets"},
+}
+
+// AccessVerbs are the verbs an access report checks, in display order
+var AccessVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+
+// NamespaceAccess lists the verbs allowed on each AccessResource in a namespace
+type NamespaceAccess struct {
+	Namespace string
+	Allowed   map[string][]string // resource name -> allowed verbs
+	// Incomplete is set when the API server could not evaluate all rules,
+	// e.g. because an authorizer does not support rule listing
+	Incomplete bool
+}
+
+// Subject is the user access is checked for with SubjectAccessReviews
+type Subject struct {
+	User   string
+	Groups []string
+}
+
+// AccessReport checks which verbs are allowed on AccessResources in each
+// namespace. Without a subject it runs a SelfSubjectRulesReview for the
+// current identity; with one, a SubjectAccessReview per resource and verb.
+func AccessReport(ctx context.Context, k8sClient kubernetes.Interface, namespaces []string, subject *Subject) ([]NamespaceAccess, error) {
+	report := make([]NamespaceAccess, 0, len(namespaces))
+	for _, ns := range namespaces {
+		var access NamespaceAccess
+		var err error
+		if subject == nil {
+			access, err = selfAccess(ctx, k8sClient, ns)
+		} else {
+			access, err = subjectAccess(ctx, k8sClient, ns, subject)
+		}
+		if err != nil {
+			return nil, err
+		}
+		report = append(report, access)
+	}
+	return report, nil
+}
+
+func selfAccess(ctx context.Context, k8sClient kubernetes.Interface, namespace string) (NamespaceAccess, error) {
+	review, err := k8sClient.AuthorizationV1().SelfSubjectRulesReviews().Create(ctx, &authorizationv1.SelfSubjectRulesReview{
+		Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: namespace},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return NamespaceAccess{}, fmt.Errorf("failed to review access rules in namespace %s: %w", namespace, err)
+	}
+
+	access := NamespaceAccess{
+		Namespace:  namespace,
+		Allowed:    make(map[string][]string),
+		Incomplete: review.Status.Incomplete,
+	}
+	for _, resource := range AccessResources {
+		access.Allowed[resource.Name] = AllowedVerbs(review.Status.ResourceRules, resource)
+	}
+	return access, nil
+}
+
+func subjectAccess(ctx context.Context, k8sClient kubernetes.Interface, namespace string, subject *Subject) (NamespaceAccess, error) {
+	access := NamespaceAccess{
+		Namespace: namespace,
+		Allowed:   make(map[string][]string),
+	}
+	for _, resource := range AccessResources {
+		allowed := make([]string, 0)
+		for _, verb := range AccessVerbs {
+			review, err := k8sClient.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+				Spec: authorizationv1.SubjectAccessReviewSpec{
+					User:   subject.User,
+					Groups: subject.Groups,
+					ResourceAttributes: &authorizationv1.ResourceAttributes{
+						Namespace: namespace,
+						Verb:      verb,
+						Group:     resource.Group,
+						Resource:  resource.Resource,
+					},
+				},
+			}, metav1.CreateOptions{})
+			if err != nil {
+				return NamespaceAccess{}, fmt.Errorf("failed to review access of %s in namespace %s: %w", subject.User, namespace, err)
+			}
+			if review.Status.Allowed {
+				allowed = append(allowed, verb)
+			}
+		}
+		access.Allowed[resource.Name] = allowed
+	}
+	return access, nil
+}
+
+// AllowedVerbs returns the AccessVerbs that rules allow on all objects of a
+// resource. Rules limited to specific resource names are ignored.
+func AllowedVerbs(rules []authorizationv1.ResourceRule, resource AccessResource) []string {
+	granted := make(map[string]bool)
+	for _, rule := range rules {
+		if len(rule.ResourceNames) > 0 ||
+			!containsOrWildcard(rule.APIGroups, resource.Group) ||
+			!containsOrWildcard(rule.Resources, resource.Resource) {
+			continue
+		}
+		for _, verb := range rule.Verbs {
+			granted[verb] = true
+		}
+	}
+
+	allowed := make([]string, 0, len(AccessVerbs))
+	for _, verb := range AccessVerbs {
+		if granted[verb] || granted["*"] {
+			allowed = append(allowed, verb)
+		}
+	}
+	return allowed
+}
+
+func containsOrWildcard(values []string, value string) bool {
+	for _, v := range values {
+		if v == value || v == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// AccessRequirement is a resource and the verbs needed to manage apps with it
+type AccessRequirement struct {
+	Resource AccessResource
+	Verbs    []string
+}
+
+// AppManagementRequirements are the permissions ValidateNamespaceAccess
+// checks: managing apps and their ConfigMap and Secret values
+var AppManagementRequirements = []AccessRequirement{
+	{Resource: AccessResource{Name: "apps", Group: "application.giantswarm.io", Resource: "apps"}, Verbs: []string{"get", "list", "create", "update", "delete"}},
+	{Resource: AccessResource{Name: "configmaps", Group: "", Resource: "configmaps"}, Verbs: []string{"get", "create", "update"}},
+	{Resource: AccessResource{Name: "secrets", Group: "", Resource: "secrets"}, Verbs: []string{"get", "create", "update"}},
+}
+
+// AccessCheck is the result of a SelfSubjectAccessReview for one verb
+type AccessCheck struct {
+	Resource string
+	Verb     string
+	Allowed  bool
+	// Reason explains the decision when the authorizer gives one
+	Reason string
+}
+
+// Access levels summarizing the checks of a namespace
+const (
+	AccessFull    = "full"
+	AccessPartial = "partial"
+	AccessNone    = "none"
+)
+
+// ValidateNamespaceAccess checks with a SelfSubjectAccessReview per verb
+// whether the current identity may manage apps in a namespace. Unlike reading
+// the namespace itself, which most users may not do, this reflects the
+// permissions inside the namespace.
+func ValidateNamespaceAccess(ctx context.Context, k8sClient kubernetes.Interface, namespace string) ([]AccessCheck, error) {
+	checks := make([]AccessCheck, 0)
+	for _, requirement := range AppManagementRequirements {
+		for _, verb := range requirement.Verbs {
+			review, err := k8sClient.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: &authorizationv1.ResourceAttributes{
+						Namespace: namespace,
+						Verb:      verb,
+						Group:     requirement.Resource.Group,
+						Resource:  requirement.Resource.Resource,
+					},
+				},
+			}, metav1.CreateOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to review access to %s in namespace %s: %w", requirement.Resource.Name, namespace, err)
+			}
+
+			reason := review.Status.Reason
+			if review.Status.EvaluationError != "" {
+				reason = review.Status.EvaluationError
+			}
+			checks = append(checks, AccessCheck{
+				Resource: requirement.Resource.Name,
+				Verb:     verb,
+				Allowed:  review.Status.Allowed,
+				Reason:   reason,
+			})
+		}
+	}
+	return checks, nil
+}
+
+// AccessLevel summarizes access checks as AccessFull, AccessPartial or AccessNone
+func AccessLevel(checks []AccessCheck) string {
+	allowed := 0
+	for _, check := range checks {
+		if check.Allowed {
+			allowed++
+		}
+	}
+	switch {
+	case allowed == 0:
+		return AccessNone
+	case allowed == len(checks):
+		return AccessFull
+	default:
+		return AccessPartial
+	}
+}
@@ -0,0 +1,85 @@
+package organization
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestAllowedVerbs(t *testing.T) {
+	rules := []authorizationv1.ResourceRule{
+		{APIGroups: []string{"application.giantswarm.io"}, Resources: []string{"apps"}, Verbs: []string{"get", "list"}},
+		{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"watch"}},
+		{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"*"}, ResourceNames: []string{"one"}},
+		{APIGroups: []string{"cluster.x-k8s.io"}, Resources: []string{"clusters"}, Verbs: []string{"*"}},
+	}
+
+	tests := map[string][]string{
+		"apps":     {"get", "list", "watch"},
+		"catalogs": {"watch"},
+		"secrets":  {"watch"},
+		"clusters": AccessVerbs,
+	}
+	for _, resource := range AccessResources {
+		if got := AllowedVerbs(rules, resource); !reflect.DeepEqual(got, tests[resource.Name]) {
+			t.Errorf("AllowedVerbs(%s) = %v, want %v", resource.Name, got, tests[resource.Name])
+		}
+	}
+}
+
+func TestAccessReportForSubject(t *testing.T) {
+	k8sClient := fake.NewClientset()
+	k8sClient.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attrs := review.Spec.ResourceAttributes
+		review.Status.Allowed = review.Spec.User == "alice" && attrs.Namespace == "org-acme" && attrs.Resource == "apps" && attrs.Verb == "get"
+		return true, review, nil
+	})
+
+	report, err := AccessReport(context.Background(), k8sClient, []string{"org-acme", "org-other"}, &Subject{User: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := report[0].Allowed["apps"]; !reflect.DeepEqual(got, []string{"get"}) {
+		t.Errorf("org-acme apps = %v, want [get]", got)
+	}
+	if got := report[1].Allowed["apps"]; len(got) != 0 {
+		t.Errorf("org-other apps = %v, want none", got)
+	}
+}
+
+func TestValidateNamespaceAccess(t *testing.T) {
+	k8sClient := fake.NewClientset()
+	k8sClient.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attrs := review.Spec.ResourceAttributes
+		review.Status.Allowed = attrs.Namespace == "org-acme" && (attrs.Verb == "get" || attrs.Verb == "list")
+		return true, review, nil
+	})
+
+	checks, err := ValidateNamespaceAccess(context.Background(), k8sClient, "org-acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := AccessLevel(checks); got != AccessPartial {
+		t.Errorf("org-acme access = %s, want %s", got, AccessPartial)
+	}
+	for _, check := range checks {
+		if want := check.Verb == "get" || check.Verb == "list"; check.Allowed != want {
+			t.Errorf("%s %s allowed = %v, want %v", check.Verb, check.Resource, check.Allowed, want)
+		}
+	}
+
+	checks, err = ValidateNamespaceAccess(context.Background(), k8sClient, "org-other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := AccessLevel(checks); got != AccessNone {
+		t.Errorf("org-other access = %s, want %s", got, AccessNone)
+	}
+}
@@ -0,0 +1,236 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+)
+
+// managedByLabel marks the objects created for an automation service account
+const managedByLabel = "app.kubernetes.io/managed-by"
+
+// DefaultCatalogNamespaces are the namespaces of the Giant Swarm catalogs and
+// their entries, which automation may read in addition to its organization's
+var DefaultCatalogNamespaces = []string{"default"}
+
+var (
+	allVerbs  = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+	readVerbs = []string{"get", "list", "watch"}
+)
+
+// AutomationRules are the permissions of an automation service account in its
+// organization namespace: managing apps and their configs, and reading the
+// catalogs they install from
+var AutomationRules = []rbacv1.PolicyRule{
+	{APIGroups: []string{"application.giantswarm.io"}, Resources: []string{"apps"}, Verbs: allVerbs},
+	{APIGroups: []string{""}, Resources: []string{"configmaps", "secrets"}, Verbs: allVerbs},
+	{APIGroups: []string{"application.giantswarm.io"}, Resources: []string{"catalogs", "appcatalogentries"}, Verbs: readVerbs},
+}
+
+// CatalogReadRules are the permissions of an automation service account in
+// the catalog namespaces
+var CatalogReadRules = []rbacv1.PolicyRule{
+	{APIGroups: []string{"application.giantswarm.io"}, Resources: []string{"catalogs", "appcatalogentries"}, Verbs: readVerbs},
+}
+
+// AutomationAccount is a service account provisioned for CI automation of an
+// organization, with a token for it
+type AutomationAccount struct {
+	Namespace string
+	Name      string
+	// Created is false when the service account already existed
+	Created bool
+	// RoleBindings are the namespace/name of the bindings granting its permissions
+	RoleBindings []string
+
+	Token string
+	// ExpiresAt is the token expiration the API server granted, which may be
+	// earlier than requested
+	ExpiresAt time.Time
+}
+
+// CreateAutomationAccount creates a service account in the organization
+// namespace, binds it to a Role with AutomationRules there and to one with
+// CatalogReadRules in each catalog namespace, and requests a token for it.
+// Existing objects of the same names are reused and their rules and subjects
+// brought up to date, so calling it again rotates the token; objects not
+// labeled as managed by this server are refused rather than taken over.
+func CreateAutomationAccount(ctx context.Context, k8sClient kubernetes.Interface, organization, name string, catalogNamespaces []string, tokenDuration time.Duration) (*AutomationAccount, error) {
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid service account name %q: %s", name, strings.Join(errs, "; "))
+	}
+	if tokenDuration < 10*time.Minute {
+		return nil, fmt.Errorf("token duration %s is shorter than the 10m minimum", tokenDuration)
+	}
+
+	namespace := GetOrganizationNamespace(organization)
+	labels := map[string]string{
+		OrganizationLabel: strings.TrimPrefix(namespace, OrganizationNamespacePrefix),
+		managedByLabel:    k8s.FieldManager,
+	}
+	account := &AutomationAccount{Namespace: namespace, Name: name}
+
+	_, err := k8sClient.CoreV1().ServiceAccounts(namespace).Create(ctx, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+	}, metav1.CreateOptions{FieldManager: k8s.FieldManager})
+	switch {
+	case err == nil:
+		account.Created = true
+	case !apierrors.IsAlreadyExists(err):
+		return nil, fmt.Errorf("failed to create service account %s/%s: %w", namespace, name, err)
+	default:
+		existing, err := k8sClient.CoreV1().ServiceAccounts(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get service account %s/%s: %w", namespace, name, err)
+		}
+		if err := checkManaged("service account", existing); err != nil {
+			return nil, err
+		}
+	}
+
+	subject := rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: name, Namespace: namespace}
+	bind := func(ns, roleName string, rules []rbacv1.PolicyRule) error {
+		if err := ensureRole(ctx, k8sClient, ns, roleName, labels, rules); err != nil {
+			return err
+		}
+		if err := ensureRoleBinding(ctx, k8sClient, ns, roleName, labels, subject); err != nil {
+			return err
+		}
+		account.RoleBindings = append(account.RoleBindings, ns+"/"+roleName)
+		return nil
+	}
+
+	if err := bind(namespace, name+"-app-management", AutomationRules); err != nil {
+		return nil, err
+	}
+	for _, ns := range catalogNamespaces {
+		if ns == "" || ns == namespace {
+			continue
+		}
+		if err := bind(ns, namespace+"-"+name+"-catalog-read", CatalogReadRules); err != nil {
+			return nil, err
+		}
+	}
+
+	seconds := int64(tokenDuration.Seconds())
+	token, err := k8sClient.CoreV1().ServiceAccounts(namespace).CreateToken(ctx, name, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: &seconds},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token for service account %s/%s: %w", namespace, name, err)
+	}
+	account.Token = token.Status.Token
+	account.ExpiresAt = token.Status.ExpirationTimestamp.Time
+
+	return account, nil
+}
+
+// ensureRole creates a Role or replaces the rules of an existing one
+func ensureRole(ctx context.Context, k8sClient kubernetes.Interface, namespace, name string, labels map[string]string, rules []rbacv1.PolicyRule) error {
+	roles := k8sClient.RbacV1().Roles(namespace)
+	role, err := roles.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = roles.Create(ctx, &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+			Rules:      rules,
+		}, metav1.CreateOptions{FieldManager: k8s.FieldManager})
+		if err != nil {
+			return fmt.Errorf("failed to create role %s/%s: %w", namespace, name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get role %s/%s: %w", namespace, name, err)
+	}
+	if err := checkManaged("role", role); err != nil {
+		return err
+	}
+
+	role.Rules = rules
+	if _, err := roles.Update(ctx, role, metav1.UpdateOptions{FieldManager: k8s.FieldManager}); err != nil {
+		return fmt.Errorf("failed to update role %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// ensureRoleBinding binds a subject to the Role of the same name, creating the
+// binding or adding the subject to an existing one
+func ensureRoleBinding(ctx context.Context, k8sClient kubernetes.Interface, namespace, name string, labels map[string]string, subject rbacv1.Subject) error {
+	bindings := k8sClient.RbacV1().RoleBindings(namespace)
+	roleRef := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name}
+
+	binding, err := bindings.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = bindings.Create(ctx, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+			Subjects:   []rbacv1.Subject{subject},
+			RoleRef:    roleRef,
+		}, metav1.CreateOptions{FieldManager: k8s.FieldManager})
+		if err != nil {
+			return fmt.Errorf("failed to create role binding %s/%s: %w", namespace, name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get role binding %s/%s: %w", namespace, name, err)
+	}
+	if err := checkManaged("role binding", binding); err != nil {
+		return err
+	}
+
+	// The role reference of a binding cannot be changed
+	if binding.RoleRef != roleRef {
+		return fmt.Errorf("role binding %s/%s already exists and refers to %s %s", namespace, name, binding.RoleRef.Kind, binding.RoleRef.Name)
+	}
+	for _, s := range binding.Subjects {
+		if s.Kind == subject.Kind && s.Name == subject.Name && s.Namespace == subject.Namespace {
+			return nil
+		}
+	}
+	binding.Subjects = append(binding.Subjects, subject)
+	if _, err := bindings.Update(ctx, binding, metav1.UpdateOptions{FieldManager: k8s.FieldManager}); err != nil {
+		return fmt.Errorf("failed to update role binding %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// checkManaged refuses an existing object that was not created by this server,
+// so that granting automation never rewrites or reuses someone else's RBAC
+func checkManaged(kind string, object metav1.Object) error {
+	if object.GetLabels()[managedByLabel] != k8s.FieldManager {
+		return fmt.Errorf("%s %s/%s already exists and is not managed by %s (label %s); choose another name or delete it",
+			kind, object.GetNamespace(), object.GetName(), k8s.FieldManager, managedByLabel)
+	}
+	return nil
+}
+
+// AutomationKubeconfig returns a kubeconfig that authenticates as the
+// account with its token against the API server at server, trusting caData.
+// Without caData the system roots are trusted.
+func AutomationKubeconfig(account *AutomationAccount, server string, caData []byte) ([]byte, error) {
+	name := account.Namespace + "-" + account.Name
+	config := clientcmdapi.NewConfig()
+	config.Clusters[name] = &clientcmdapi.Cluster{Server: server, CertificateAuthorityData: caData}
+	config.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: account.Token}
+	config.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name, Namespace: account.Namespace}
+	config.CurrentContext = name
+
+	data, err := clientcmd.Write(*config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+	return data, nil
+}
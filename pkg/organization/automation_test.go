@@ -0,0 +1,134 @@
+package organization
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+)
+
+func TestCreateAutomationAccount(t *testing.T) {
+	ctx := context.Background()
+	expiry := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := fake.NewSimpleClientset(&rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "org-acme-ci-catalog-read", Namespace: "default", Labels: map[string]string{managedByLabel: k8s.FieldManager}},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "org-acme-ci-catalog-read"},
+	})
+	client.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		return true, &authenticationv1.TokenRequest{Status: authenticationv1.TokenRequestStatus{
+			Token:               "secret-token",
+			ExpirationTimestamp: metav1.NewTime(expiry),
+		}}, nil
+	})
+
+	account, err := CreateAutomationAccount(ctx, client, "acme", "ci", DefaultCatalogNamespaces, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("CreateAutomationAccount() error = %v", err)
+	}
+	if !account.Created || account.Namespace != "org-acme" || account.Token != "secret-token" || !account.ExpiresAt.Equal(expiry) {
+		t.Errorf("account = %+v", account)
+	}
+	wantBindings := []string{"org-acme/ci-app-management", "default/org-acme-ci-catalog-read"}
+	if len(account.RoleBindings) != 2 || account.RoleBindings[0] != wantBindings[0] || account.RoleBindings[1] != wantBindings[1] {
+		t.Errorf("RoleBindings = %v, want %v", account.RoleBindings, wantBindings)
+	}
+
+	role, err := client.RbacV1().Roles("org-acme").Get(ctx, "ci-app-management", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("app management role: %v", err)
+	}
+	if len(role.Rules) != len(AutomationRules) || role.Labels[OrganizationLabel] != "acme" {
+		t.Errorf("role = %+v", role)
+	}
+
+	// The existing binding keeps its subjects and gains the service account
+	binding, err := client.RbacV1().RoleBindings("default").Get(ctx, "org-acme-ci-catalog-read", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("catalog role binding: %v", err)
+	}
+	if len(binding.Subjects) != 2 || binding.Subjects[1].Name != "ci" || binding.Subjects[1].Namespace != "org-acme" {
+		t.Errorf("binding subjects = %+v", binding.Subjects)
+	}
+
+	// Calling again reuses the objects
+	again, err := CreateAutomationAccount(ctx, client, "acme", "ci", DefaultCatalogNamespaces, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("second CreateAutomationAccount() error = %v", err)
+	}
+	if again.Created {
+		t.Error("existing service account reported as created")
+	}
+	binding, _ = client.RbacV1().RoleBindings("default").Get(ctx, "org-acme-ci-catalog-read", metav1.GetOptions{})
+	if len(binding.Subjects) != 2 {
+		t.Errorf("subject added twice: %+v", binding.Subjects)
+	}
+
+	if _, err := CreateAutomationAccount(ctx, client, "acme", "CI", nil, 24*time.Hour); err == nil {
+		t.Error("invalid name accepted")
+	}
+	if _, err := CreateAutomationAccount(ctx, client, "acme", "ci", nil, time.Minute); err == nil {
+		t.Error("token duration below the minimum accepted")
+	}
+
+	kubeconfig, err := AutomationKubeconfig(account, "https://api.example.com", []byte("ca"))
+	if err != nil {
+		t.Fatalf("AutomationKubeconfig() error = %v", err)
+	}
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		t.Fatalf("kubeconfig does not load: %v", err)
+	}
+	kubeContext := config.Contexts[config.CurrentContext]
+	if kubeContext == nil || kubeContext.Namespace != "org-acme" ||
+		config.Clusters[kubeContext.Cluster].Server != "https://api.example.com" ||
+		config.AuthInfos[kubeContext.AuthInfo].Token != "secret-token" {
+		t.Errorf("kubeconfig = %s", kubeconfig)
+	}
+}
+
+func TestCreateAutomationAccountRefusesUnmanaged(t *testing.T) {
+	meta := func(namespace, name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"app.kubernetes.io/managed-by": "helm"}}
+	}
+	tests := []struct {
+		name     string
+		existing runtime.Object
+	}{
+		{"service account", &corev1.ServiceAccount{ObjectMeta: meta("org-acme", "ci")}},
+		{"role", &rbacv1.Role{ObjectMeta: meta("org-acme", "ci-app-management")}},
+		{"role binding", &rbacv1.RoleBinding{
+			ObjectMeta: meta("default", "org-acme-ci-catalog-read"),
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "org-acme-ci-catalog-read"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(tt.existing)
+			_, err := CreateAutomationAccount(context.Background(), client, "acme", "ci", DefaultCatalogNamespaces, 24*time.Hour)
+			if err == nil || !strings.Contains(err.Error(), "is not managed by") {
+				t.Fatalf("CreateAutomationAccount() error = %v, want refusal of the unmanaged %s", err, tt.name)
+			}
+			if tt.name == "role" {
+				role, _ := client.RbacV1().Roles("org-acme").Get(context.Background(), "ci-app-management", metav1.GetOptions{})
+				if len(role.Rules) != 0 {
+					t.Errorf("unmanaged role rewritten: %+v", role.Rules)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+)
+
+// Organization represents a security.giantswarm.io Organization resource
+type Organization struct {
+	Name        string
+	Namespace   string
+	Created     time.Time
+	Terminating bool
+	Labels      map[string]string
+}
+
+// DriftType describes how Organization resources and namespaces disagree
+type DriftType string
+
+const (
+	// DriftNamespaceNotProvisioned is an Organization whose status has no namespace yet
+	DriftNamespaceNotProvisioned DriftType = "namespace-not-provisioned"
+
+	// DriftNamespaceMissing is an Organization whose namespace does not exist
+	DriftNamespaceMissing DriftType = "namespace-missing"
+
+	// DriftOrphanedNamespace is an organization namespace without an Organization
+	DriftOrphanedNamespace DriftType = "orphaned-namespace"
+)
+
+// Drift is a single mismatch between Organization resources and namespaces
+type Drift struct {
+	Type         DriftType
+	Organization string
+	Namespace    string
+}
+
+// Client provides operations for Organization resources
+type Client struct {
+	dynamicClient *k8s.DynamicClient
+	k8sClient     kubernetes.Interface
+}
+
+// NewClient creates a new organization client
+func NewClient(dynamicClient *k8s.DynamicClient, k8sClient kubernetes.Interface) *Client {
+	return &Client{
+		dynamicClient: dynamicClient,
+		k8sClient:     k8sClient,
+	}
+}
+
+// List lists all Organization resources
+func (c *Client) List(ctx context.Context) ([]*Organization, error) {
+	list, err := c.dynamicClient.Organizations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	orgs := make([]*Organization, 0, len(list.Items))
+	for _, item := range list.Items {
+		orgs = append(orgs, NewOrganizationFromUnstructured(&item))
+	}
+
+	sort.Slice(orgs, func(i, j int) bool {
+		return orgs[i].Name < orgs[j].Name
+	})
+
+	return orgs, nil
+}
+
+// Get retrieves a single Organization resource
+func (c *Client) Get(ctx context.Context, name string) (*Organization, error) {
+	obj, err := c.dynamicClient.Organizations().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization %s: %w", name, err)
+	}
+
+	return NewOrganizationFromUnstructured(obj), nil
+}
+
+// DetectDrift compares all Organization resources with the organization namespaces
+func (c *Client) DetectDrift(ctx context.Context, orgs []*Organization) ([]Drift, error) {
+	namespaces, err := c.k8sClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	names := make([]string, 0, len(namespaces.Items))
+	for _, ns := range namespaces.Items {
+		names = append(names, ns.Name)
+	}
+
+	return DetectDrift(orgs, names), nil
+}
+
+// NewOrganizationFromUnstructured converts an unstructured object to an Organization
+func NewOrganizationFromUnstructured(obj *unstructured.Unstructured) *Organization {
+	org := &Organization{
+		Name:        obj.GetName(),
+		Created:     obj.GetCreationTimestamp().Time,
+		Terminating: obj.GetDeletionTimestamp() != nil,
+		Labels:      obj.GetLabels(),
+	}
+	org.Namespace, _, _ = unstructured.NestedString(obj.Object, "status", "namespace")
+	return org
+}
+
+// DetectDrift reports Organizations without a usable namespace and organization
+// namespaces that no Organization refers to
+func DetectDrift(orgs []*Organization, namespaces []string) []Drift {
+	existing := make(map[string]bool, len(namespaces))
+	for _, ns := range namespaces {
+		existing[ns] = true
+	}
+
+	drift := make([]Drift, 0)
+	owned := make(map[string]bool, len(orgs))
+	for _, org := range orgs {
+		switch {
+		case org.Namespace == "":
+			drift = append(drift, Drift{Type: DriftNamespaceNotProvisioned, Organization: org.Name})
+		case !existing[org.Namespace]:
+			drift = append(drift, Drift{Type: DriftNamespaceMissing, Organization: org.Name, Namespace: org.Namespace})
+		}
+		owned[GetOrganizationNamespace(org.Name)] = true
+		if org.Namespace != "" {
+			owned[org.Namespace] = true
+		}
+	}
+
+	sorted := append([]string{}, namespaces...)
+	sort.Strings(sorted)
+	for _, ns := range sorted {
+		if IsOrganizationNamespace(ns) && !owned[ns] {
+			orgName, _ := GetOrganizationFromNamespace(ns)
+			drift = append(drift, Drift{Type: DriftOrphanedNamespace, Organization: orgName, Namespace: ns})
+		}
+	}
+
+	return drift
+}
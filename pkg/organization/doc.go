@@ -37,10 +37,15 @@
 //
 // The package recognizes four types of namespaces:
 //   - Organization: Primary namespace for an organization (org-*)
-//   - WorkloadCluster: Namespace for a workload cluster (workload-*)
-//   - System: Core Kubernetes namespaces (kube-system, default, etc.)
+//   - WorkloadCluster: Namespace for a workload cluster (workload-*, or any
+//     namespace with a cluster label, such as legacy namespaces named after
+//     the cluster ID)
+//   - System: Platform namespaces (kube-system, default, etc.), configurable with SystemNamespaces
 //   - Other: Any other namespace
 //
+// ClassifyNamespace decides by the labels below first and falls back to the
+// name prefixes for namespaces without them.
+//
 // # Labels
 //
 // The package uses these standard Giant Swarm labels:
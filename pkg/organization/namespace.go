@@ -0,0 +1,80 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// OwnerLabel identifies the organization that owns a resource
+	OwnerLabel = "giantswarm.io/owner"
+
+	// ClusterLabel identifies the workload cluster a namespace belongs to
+	ClusterLabel = "giantswarm.io/cluster"
+)
+
+// NamespaceLabels returns the multi-tenancy labels of a namespace owned by an
+// organization. Workload cluster namespaces (workload-*) also get the cluster
+// label, which defaults to the name without the prefix. System namespaces
+// are refused.
+func NamespaceLabels(name, organization, cluster string, system SystemNamespaces) (map[string]string, error) {
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid namespace name %q: %s", name, strings.Join(errs, "; "))
+	}
+	if IsOrganizationNamespace(name) {
+		return nil, fmt.Errorf("namespace %s is an organization namespace, these are created with the Organization resource", name)
+	}
+	if system.Contains(name) {
+		return nil, fmt.Errorf("namespace %s is a system namespace", name)
+	}
+	if organization == "" {
+		return nil, fmt.Errorf("organization is required")
+	}
+
+	org := strings.TrimPrefix(organization, OrganizationNamespacePrefix)
+	labels := map[string]string{
+		OrganizationLabel: org,
+		OwnerLabel:        org,
+	}
+
+	if cluster == "" && IsWorkloadClusterNamespace(name) {
+		cluster = strings.TrimPrefix(name, WorkloadClusterNamespacePrefix)
+	}
+	if cluster != "" {
+		labels[ClusterLabel] = cluster
+	}
+
+	return labels, nil
+}
+
+// CreateNamespace creates a namespace owned by an organization with the
+// labels of NamespaceLabels and any extra labels
+func CreateNamespace(ctx context.Context, k8sClient kubernetes.Interface, name, organization, cluster string, system SystemNamespaces, extraLabels map[string]string) (*corev1.Namespace, error) {
+	labels, err := NamespaceLabels(name, organization, cluster, system)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range extraLabels {
+		if _, ok := labels[k]; !ok {
+			labels[k] = v
+		}
+	}
+
+	ns, err := k8sClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create namespace %s: %w", name, err)
+	}
+
+	return ns, nil
+}
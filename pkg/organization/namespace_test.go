@@ -0,0 +1,72 @@
+package organization
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNamespaceLabels(t *testing.T) {
+	tests := []struct {
+		name         string
+		namespace    string
+		organization string
+		cluster      string
+		want         map[string]string
+		wantErr      bool
+	}{
+		{
+			name:         "app namespace",
+			namespace:    "team-a-apps",
+			organization: "acme",
+			want:         map[string]string{OrganizationLabel: "acme", OwnerLabel: "acme"},
+		},
+		{
+			name:         "workload cluster namespace",
+			namespace:    "workload-prod",
+			organization: "org-acme",
+			want:         map[string]string{OrganizationLabel: "acme", OwnerLabel: "acme", ClusterLabel: "prod"},
+		},
+		{
+			name:         "explicit cluster",
+			namespace:    "prod-monitoring",
+			organization: "acme",
+			cluster:      "prod",
+			want:         map[string]string{OrganizationLabel: "acme", OwnerLabel: "acme", ClusterLabel: "prod"},
+		},
+		{
+			name:         "organization namespace",
+			namespace:    "org-acme",
+			organization: "acme",
+			wantErr:      true,
+		},
+		{
+			name:         "system namespace",
+			namespace:    "kube-system",
+			organization: "acme",
+			wantErr:      true,
+		},
+		{
+			name:         "invalid name",
+			namespace:    "Team_A",
+			organization: "acme",
+			wantErr:      true,
+		},
+		{
+			name:      "missing organization",
+			namespace: "team-a-apps",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NamespaceLabels(tt.namespace, tt.organization, tt.cluster, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NamespaceLabels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NamespaceLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
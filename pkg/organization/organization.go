@@ -6,8 +6,8 @@ import (
 	"fmt"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -50,71 +50,38 @@ func GetOrganizationNamespace(organization string) string {
 	return OrganizationNamespacePrefix + organization
 }
 
-// ListOrganizationNamespaces returns all organization namespaces in the cluster
+// ListOrganizationNamespaces returns all organization namespaces in the cluster,
+// classified with ClassifyNamespace
 func ListOrganizationNamespaces(ctx context.Context, k8sClient kubernetes.Interface) ([]string, error) {
-	// First try to list by label
-	namespaceList, err := k8sClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{
-		LabelSelector: labels.Set{
-			OrganizationLabel: "true",
-		}.String(),
-	})
-
-	if err == nil && len(namespaceList.Items) > 0 {
-		// Found namespaces with the organization label
-		namespaces := make([]string, 0, len(namespaceList.Items))
-		for _, ns := range namespaceList.Items {
-			namespaces = append(namespaces, ns.Name)
-		}
-		return namespaces, nil
-	}
-
-	// Fallback to listing all namespaces and filtering by prefix
-	// This handles both error cases and when no labeled namespaces are found
-	allNamespaces, err := k8sClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	namespaceList, err := k8sClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list namespaces: %w", err)
 	}
 
 	orgNamespaces := make([]string, 0)
-	for _, ns := range allNamespaces.Items {
-		if IsOrganizationNamespace(ns.Name) {
-			orgNamespaces = append(orgNamespaces, ns.Name)
+	for i := range namespaceList.Items {
+		if ClassifyNamespace(&namespaceList.Items[i], nil).Type == NamespaceTypeOrganization {
+			orgNamespaces = append(orgNamespaces, namespaceList.Items[i].Name)
 		}
 	}
 	return orgNamespaces, nil
 }
 
-// GetNamespacesByOrganization returns all namespaces belonging to an organization
-// This includes the organization namespace and any workload cluster namespaces
+// GetNamespacesByOrganization returns all namespaces belonging to an organization:
+// its organization namespace, the namespaces of its workload clusters, and any
+// other namespace labeled with the organization
 func GetNamespacesByOrganization(ctx context.Context, k8sClient kubernetes.Interface, organization string) ([]string, error) {
-	orgNamespace := GetOrganizationNamespace(organization)
+	organization = strings.TrimPrefix(organization, OrganizationNamespacePrefix)
 
-	// List all namespaces
 	namespaceList, err := k8sClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list namespaces: %w", err)
 	}
 
 	namespaces := make([]string, 0)
-	for _, ns := range namespaceList.Items {
-		// Include the organization namespace itself
-		if ns.Name == orgNamespace {
-			namespaces = append(namespaces, ns.Name)
-			continue
-		}
-
-		// Check if namespace belongs to this organization via labels
-		if orgLabel, exists := ns.Labels[OrganizationLabel]; exists && orgLabel == organization {
-			namespaces = append(namespaces, ns.Name)
-			continue
-		}
-
-		// Check for workload cluster namespaces that belong to this organization
-		if IsWorkloadClusterNamespace(ns.Name) {
-			// Check if the workload cluster belongs to this organization
-			if owner, exists := ns.Labels["giantswarm.io/owner"]; exists && owner == organization {
-				namespaces = append(namespaces, ns.Name)
-			}
+	for i := range namespaceList.Items {
+		if ClassifyNamespace(&namespaceList.Items[i], nil).Organization == organization {
+			namespaces = append(namespaces, namespaceList.Items[i].Name)
 		}
 	}
 
@@ -147,84 +114,66 @@ const (
 	NamespaceTypeOther NamespaceType = "other"
 )
 
-// GetNamespaceInfo returns detailed information about a namespace
-func GetNamespaceInfo(ctx context.Context, k8sClient kubernetes.Interface, namespace string) (*NamespaceInfo, error) {
+// GetNamespaceInfo returns detailed information about a namespace, using
+// system to recognize system namespaces
+func GetNamespaceInfo(ctx context.Context, k8sClient kubernetes.Interface, namespace string, system SystemNamespaces) (*NamespaceInfo, error) {
 	ns, err := k8sClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
 	}
 
+	return ClassifyNamespace(ns, system), nil
+}
+
+// ClassifyNamespace determines the type, organization and cluster of a
+// namespace. The giantswarm.io labels decide first:
+//   - a cluster label marks the namespace of a workload cluster, whatever its
+//     name, as on legacy installations where cluster namespaces are named
+//     after the cluster ID
+//   - an organization label naming the organization of an org-* namespace
+//     (or "true") marks an organization namespace
+//
+// Namespaces without these labels are classified by system, then by the
+// org- and workload- name prefixes.
+func ClassifyNamespace(ns *corev1.Namespace, system SystemNamespaces) *NamespaceInfo {
 	info := &NamespaceInfo{
-		Name:   namespace,
+		Name:   ns.Name,
 		Labels: ns.Labels,
 	}
 
-	// Determine namespace type
-	if IsOrganizationNamespace(namespace) {
-		info.Type = NamespaceTypeOrganization
-		info.Organization, _ = GetOrganizationFromNamespace(namespace)
-	} else if IsWorkloadClusterNamespace(namespace) {
-		info.Type = NamespaceTypeWorkloadCluster
-		if clusterID, exists := ns.Labels["giantswarm.io/cluster"]; exists {
-			info.ClusterID = clusterID
-		}
-		if owner, exists := ns.Labels["giantswarm.io/owner"]; exists {
-			info.Organization = owner
-		}
-	} else if isSystemNamespace(namespace) {
-		info.Type = NamespaceTypeSystem
-	} else {
-		info.Type = NamespaceTypeOther
-		// Check if it has an organization label
-		if org, exists := ns.Labels[OrganizationLabel]; exists {
-			info.Organization = org
-		}
-	}
-
-	return info, nil
-}
-
-// isSystemNamespace checks if a namespace is a system namespace
-func isSystemNamespace(namespace string) bool {
-	systemNamespaces := []string{
-		"kube-system",
-		"kube-public",
-		"kube-node-lease",
-		"default",
-		"giantswarm",
-		"flux-system",
-		"monitoring",
+	org := ns.Labels[OrganizationLabel]
+	if org == "true" {
+		org = ""
 	}
-
-	for _, sysNs := range systemNamespaces {
-		if namespace == sysNs {
-			return true
-		}
+	owner := org
+	if owner == "" {
+		owner = ns.Labels[OwnerLabel]
 	}
 
-	return false
-}
-
-// ValidateNamespaceAccess validates if the current context has access to a namespace
-// This is a placeholder for RBAC validation
-func ValidateNamespaceAccess(ctx context.Context, k8sClient kubernetes.Interface, namespace string) error {
-	// Try to get the namespace - if we can't, we don't have access
-	_, err := k8sClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("no access to namespace %s: %w", namespace, err)
+	switch _, labeled := ns.Labels[OrganizationLabel]; {
+	case ns.Labels[ClusterLabel] != "":
+		info.Type = NamespaceTypeWorkloadCluster
+		info.ClusterID = ns.Labels[ClusterLabel]
+		info.Organization = owner
+	case labeled && IsOrganizationNamespace(ns.Name) && (org == "" || GetOrganizationNamespace(org) == ns.Name):
+		info.Type = NamespaceTypeOrganization
+		info.Organization, _ = GetOrganizationFromNamespace(ns.Name)
+	case labeled:
+		info.Type = NamespaceTypeOther
+		info.Organization = owner
+	case system.Contains(ns.Name):
+		info.Type = NamespaceTypeSystem
+	case IsOrganizationNamespace(ns.Name):
+		info.Type = NamespaceTypeOrganization
+		info.Organization, _ = GetOrganizationFromNamespace(ns.Name)
+	case IsWorkloadClusterNamespace(ns.Name):
+		info.Type = NamespaceTypeWorkloadCluster
+		info.ClusterID = strings.TrimPrefix(ns.Name, WorkloadClusterNamespacePrefix)
+		info.Organization = owner
+	default:
+		info.Type = NamespaceTypeOther
+		info.Organization = owner
 	}
-	return nil
-}
 
-// GetOrganizationFromCurrentContext attempts to determine the organization from the current context
-// This is a placeholder that would need to be implemented based on your authentication setup
-func GetOrganizationFromCurrentContext(ctx context.Context, k8sClient kubernetes.Interface) (string, error) {
-	// This would typically check:
-	// 1. Service account namespace if running in-cluster
-	// 2. RBAC permissions to determine organization
-	// 3. Context name patterns
-	// 4. Default to a configured organization
-
-	// For now, return empty to indicate no default organization
-	return "", nil
+	return info
 }
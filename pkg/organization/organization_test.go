@@ -437,7 +437,7 @@ func TestGetNamespaceInfo(t *testing.T) {
 			client := fake.NewSimpleClientset(tt.ns)
 			ctx := context.Background()
 
-			got, err := GetNamespaceInfo(ctx, client, tt.namespace)
+			got, err := GetNamespaceInfo(ctx, client, tt.namespace, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetNamespaceInfo() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -458,3 +458,130 @@ func TestGetNamespaceInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectDrift(t *testing.T) {
+	orgs := []*Organization{
+		{Name: "acme", Namespace: "org-acme"},
+		{Name: "pending"},
+		{Name: "gone", Namespace: "org-gone"},
+	}
+	namespaces := []string{"default", "org-acme", "org-orphan"}
+
+	got := DetectDrift(orgs, namespaces)
+	want := []Drift{
+		{Type: DriftNamespaceNotProvisioned, Organization: "pending"},
+		{Type: DriftNamespaceMissing, Organization: "gone", Namespace: "org-gone"},
+		{Type: DriftOrphanedNamespace, Organization: "orphan", Namespace: "org-orphan"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("DetectDrift() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("DetectDrift()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestClassifyNamespace(t *testing.T) {
+	tests := []struct {
+		name        string
+		namespace   string
+		labels      map[string]string
+		wantType    NamespaceType
+		wantOrg     string
+		wantCluster string
+	}{
+		{
+			name:      "organization namespace labeled with its organization",
+			namespace: "org-acme",
+			labels:    map[string]string{OrganizationLabel: "acme"},
+			wantType:  NamespaceTypeOrganization,
+			wantOrg:   "acme",
+		},
+		{
+			name:      "org- namespace labeled with another organization",
+			namespace: "org-legacy",
+			labels:    map[string]string{OrganizationLabel: "acme"},
+			wantType:  NamespaceTypeOther,
+			wantOrg:   "acme",
+		},
+		{
+			name:      "unlabeled org- namespace",
+			namespace: "org-acme",
+			wantType:  NamespaceTypeOrganization,
+			wantOrg:   "acme",
+		},
+		{
+			name:        "legacy cluster namespace named after the cluster ID",
+			namespace:   "x7k2p",
+			labels:      map[string]string{ClusterLabel: "x7k2p", OrganizationLabel: "acme"},
+			wantType:    NamespaceTypeWorkloadCluster,
+			wantOrg:     "acme",
+			wantCluster: "x7k2p",
+		},
+		{
+			name:        "legacy cluster namespace with only an owner",
+			namespace:   "x7k2p",
+			labels:      map[string]string{ClusterLabel: "x7k2p", OwnerLabel: "acme"},
+			wantType:    NamespaceTypeWorkloadCluster,
+			wantOrg:     "acme",
+			wantCluster: "x7k2p",
+		},
+		{
+			name:        "unlabeled workload- namespace",
+			namespace:   "workload-prod",
+			wantType:    NamespaceTypeWorkloadCluster,
+			wantCluster: "prod",
+		},
+		{
+			name:      "app namespace of an organization",
+			namespace: "team-apps",
+			labels:    map[string]string{OrganizationLabel: "acme", OwnerLabel: "acme"},
+			wantType:  NamespaceTypeOther,
+			wantOrg:   "acme",
+		},
+		{
+			name:      "system namespace",
+			namespace: "flux-system",
+			wantType:  NamespaceTypeSystem,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := ClassifyNamespace(&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.namespace, Labels: tt.labels},
+			}, nil)
+			if info.Type != tt.wantType || info.Organization != tt.wantOrg || info.ClusterID != tt.wantCluster {
+				t.Errorf("ClassifyNamespace() = type %s, organization %q, cluster %q; want %s, %q, %q",
+					info.Type, info.Organization, info.ClusterID, tt.wantType, tt.wantOrg, tt.wantCluster)
+			}
+		})
+	}
+}
+
+func TestGetNamespacesByOrganizationLegacyClusters(t *testing.T) {
+	client := fake.NewClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-acme", Labels: map[string]string{OrganizationLabel: "acme"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "x7k2p", Labels: map[string]string{ClusterLabel: "x7k2p", OrganizationLabel: "acme"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "b9q4z", Labels: map[string]string{ClusterLabel: "b9q4z", OrganizationLabel: "other"}}},
+	)
+
+	got, err := GetNamespacesByOrganization(context.Background(), client, "org-acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != "org-acme" || got[1] != "x7k2p" {
+		t.Errorf("GetNamespacesByOrganization() = %v, want [org-acme x7k2p]", got)
+	}
+
+	orgs, err := ListOrganizationNamespaces(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orgs) != 1 || orgs[0] != "org-acme" {
+		t.Errorf("ListOrganizationNamespaces() = %v, want [org-acme]", orgs)
+	}
+}
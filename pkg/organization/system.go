@@ -0,0 +1,97 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// SystemNamespacesKey is the ConfigMap key holding system namespace patterns
+const SystemNamespacesKey = "namespaces"
+
+// DefaultSystemNamespaces are the namespaces classified as system namespaces
+// unless an installation configures its own
+var DefaultSystemNamespaces = SystemNamespaces{
+	"kube-system",
+	"kube-public",
+	"kube-node-lease",
+	"default",
+	"giantswarm",
+	"flux-system",
+	"monitoring",
+}
+
+// SystemNamespaces are the names or glob patterns (e.g. "kube-*") of the
+// namespaces that belong to the platform rather than an organization. A nil
+// set is DefaultSystemNamespaces.
+type SystemNamespaces []string
+
+// NewSystemNamespaces creates a set after checking the pattern syntax
+func NewSystemNamespaces(patterns []string) (SystemNamespaces, error) {
+	set := make(SystemNamespaces, 0, len(patterns))
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid system namespace pattern %q: %w", pattern, err)
+		}
+		set = append(set, pattern)
+	}
+	return set, nil
+}
+
+// Match returns the pattern a namespace matches, if any
+func (s SystemNamespaces) Match(namespace string) (string, bool) {
+	if s == nil {
+		s = DefaultSystemNamespaces
+	}
+	for _, pattern := range s {
+		if matched, _ := path.Match(pattern, namespace); matched {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// Contains reports whether a namespace is a system namespace
+func (s SystemNamespaces) Contains(namespace string) bool {
+	_, ok := s.Match(namespace)
+	return ok
+}
+
+// String lists the patterns of the set
+func (s SystemNamespaces) String() string {
+	if s == nil {
+		s = DefaultSystemNamespaces
+	}
+	return strings.Join(s, ", ")
+}
+
+// LoadSystemNamespacesFromConfigMap reads system namespace patterns from the
+// "namespaces" key of a ConfigMap, one per line or separated by commas.
+// Lines starting with # are comments.
+func LoadSystemNamespacesFromConfigMap(ctx context.Context, k8sClient kubernetes.Interface, namespace, name string) (SystemNamespaces, error) {
+	cm, err := k8sClient.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system namespaces configmap %s/%s: %w", namespace, name, err)
+	}
+	data, ok := cm.Data[SystemNamespacesKey]
+	if !ok {
+		return nil, fmt.Errorf("configmap %s/%s has no %q key", namespace, name, SystemNamespacesKey)
+	}
+
+	var patterns []string
+	for _, line := range strings.Split(data, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		patterns = append(patterns, strings.Split(line, ",")...)
+	}
+	return NewSystemNamespaces(patterns)
+}
@@ -0,0 +1,57 @@
+package organization
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSystemNamespaces(t *testing.T) {
+	var defaults SystemNamespaces
+	if !defaults.Contains("flux-system") || defaults.Contains("org-acme") {
+		t.Error("nil set does not use the default system namespaces")
+	}
+
+	system, err := NewSystemNamespaces([]string{"kube-*", " platform ", ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pattern, ok := system.Match("kube-system"); !ok || pattern != "kube-*" {
+		t.Errorf("Match(kube-system) = %q, %v", pattern, ok)
+	}
+	if !system.Contains("platform") || system.Contains("monitoring") {
+		t.Errorf("configured set %v does not replace the defaults", system)
+	}
+
+	if _, err := NewSystemNamespaces([]string{"kube-["}); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
+
+func TestLoadSystemNamespacesFromConfigMap(t *testing.T) {
+	client := fake.NewClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "system-namespaces", Namespace: "giantswarm"},
+		Data:       map[string]string{SystemNamespacesKey: "# observability\nloki, mimir\n\ncert-*\n"},
+	})
+
+	system, err := LoadSystemNamespacesFromConfigMap(context.Background(), client, "giantswarm", "system-namespaces")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if system.String() != "loki, mimir, cert-*" {
+		t.Errorf("loaded %q", system.String())
+	}
+
+	info, err := GetNamespaceInfo(context.Background(), fake.NewClientset(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "cert-manager"},
+	}), "cert-manager", system)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Type != NamespaceTypeSystem {
+		t.Errorf("cert-manager type = %s, want system", info.Type)
+	}
+}
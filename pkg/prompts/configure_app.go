@@ -41,7 +41,7 @@ func registerConfigureAppPrompt(s *mcpserver.MCPServer, ctx *server.Context) err
 				"First, identify which app you want to configure:")
 
 			if catalog == "" {
-				pb.addCodeBlock("List Available Catalogs", "bash", "catalog.list")
+				pb.addCodeBlock("List Available Catalogs", "bash", "catalog_list")
 				pb.addSection("Popular Catalogs",
 					"- **giantswarm**: Official Giant Swarm apps\n"+
 						"- **giantswarm-playground**: Experimental apps\n"+
@@ -50,7 +50,7 @@ func registerConfigureAppPrompt(s *mcpserver.MCPServer, ctx *server.Context) err
 
 			if appName == "" && catalog != "" {
 				pb.addCodeBlock("Browse Apps in Catalog", "bash",
-					fmt.Sprintf("appcatalogentry.list --catalog %s", catalog))
+					fmt.Sprintf("appcatalogentry_list --catalog %s", catalog))
 			}
 
 			pb.addSection("Action Required",
@@ -74,7 +74,7 @@ func registerConfigureAppPrompt(s *mcpserver.MCPServer, ctx *server.Context) err
 			pb.addSection("Step 2: Select Version",
 				"Check available versions and their configuration requirements:")
 			pb.addCodeBlock("Get App Versions", "bash",
-				fmt.Sprintf("appcatalogentry.get --catalog %s --name %s", catalog, appName))
+				fmt.Sprintf("appcatalogentry_get --catalog %s --name %s", catalog, appName))
 			pb.addSection("Version Selection Tips",
 				"- Use the latest stable version for new deployments\n"+
 					"- Check version changelog for configuration changes\n"+
@@ -217,7 +217,7 @@ resources:
 		pb.addSection("Step 5: Reference Configuration in App",
 			"When creating or updating the app, reference your configuration:")
 		pb.addCodeBlock("Deploy with Configuration", "bash",
-			fmt.Sprintf(`app.create \
+			fmt.Sprintf(`app_create \
   --name %s \
   --namespace %s \
   --catalog %s \
@@ -72,7 +72,7 @@ func registerCreateCatalogPrompt(s *mcpserver.MCPServer, ctx *server.Context) er
 		if organization == "" {
 			pb.addSection("Select Organization",
 				"Catalogs must be created within an organization namespace:")
-			pb.addCodeBlock("List Organizations", "bash", "organization.list")
+			pb.addCodeBlock("List Organizations", "bash", "organization_list")
 			pb.addSection("Action Required",
 				"Please specify the 'organization' argument.")
 			return &mcp.GetPromptResult{
@@ -157,7 +157,7 @@ helm push my-app-1.0.0.tgz oci://myregistry.io/helm-charts/`)
 		namespace := fmt.Sprintf("org-%s", organization)
 		repoURL := "<YOUR_REPOSITORY_URL>"
 
-		createCmd := fmt.Sprintf(`catalog.create \
+		createCmd := fmt.Sprintf(`catalog_create \
   --name %s \
   --namespace %s \
   --title "%s Apps" \
@@ -208,10 +208,10 @@ helm push my-app-1.0.0.tgz oci://myregistry.io/helm-charts/`)
 			"After creation, verify your catalog is working:")
 
 		pb.addCodeBlock("Check Catalog", "bash",
-			fmt.Sprintf("catalog.get --name %s --namespace %s", catalogName, namespace))
+			fmt.Sprintf("catalog_get --name %s --namespace %s", catalogName, namespace))
 
 		pb.addCodeBlock("List Apps", "bash",
-			fmt.Sprintf("appcatalogentry.list --catalog %s", catalogName))
+			fmt.Sprintf("appcatalogentry_list --catalog %s", catalogName))
 
 		// Step 6: Adding apps
 		pb.addSection("Step 6: Adding Apps to Your Catalog",
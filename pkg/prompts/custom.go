@@ -0,0 +1,238 @@
+package prompts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+// CustomPrompt is an operator-defined prompt loaded at startup
+type CustomPrompt struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Arguments   []CustomArgument `json:"arguments,omitempty"`
+	Template    string           `json:"template"`
+	Source      string           `json:"-"`
+}
+
+// CustomArgument declares an argument accepted by a custom prompt
+type CustomArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+}
+
+// ParseCustomPrompt parses a prompt definition.
+//
+// Two formats are supported:
+//   - Markdown (.md) with a YAML front matter block holding name, description
+//     and arguments; the body is the template
+//   - YAML (.yaml/.yml) with the same fields plus a 'template' field
+//
+// Templates use Go text/template syntax with the arguments available by name,
+// e.g. {{ .organization }}.
+func ParseCustomPrompt(source string, data []byte) (*CustomPrompt, error) {
+	prompt := &CustomPrompt{}
+
+	switch strings.ToLower(filepath.Ext(source)) {
+	case ".md", ".markdown":
+		frontMatter, body, err := splitFrontMatter(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid prompt %s: %w", source, err)
+		}
+		if err := yaml.Unmarshal([]byte(frontMatter), prompt); err != nil {
+			return nil, fmt.Errorf("invalid front matter in %s: %w", source, err)
+		}
+		prompt.Template = body
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, prompt); err != nil {
+			return nil, fmt.Errorf("invalid prompt %s: %w", source, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported prompt file %s (expected .md, .yaml or .yml)", source)
+	}
+
+	if prompt.Name == "" {
+		prompt.Name = strings.TrimSuffix(filepath.Base(source), filepath.Ext(source))
+	}
+	if !isValidKubernetesName(prompt.Name) {
+		return nil, fmt.Errorf("invalid prompt name %q in %s (lowercase alphanumeric and hyphens)", prompt.Name, source)
+	}
+	if strings.TrimSpace(prompt.Template) == "" {
+		return nil, fmt.Errorf("prompt %s has an empty template", source)
+	}
+	for _, arg := range prompt.Arguments {
+		if arg.Name == "" {
+			return nil, fmt.Errorf("prompt %s declares an argument without a name", source)
+		}
+	}
+
+	// Fail early on template syntax errors instead of on first use
+	if _, err := template.New(prompt.Name).Option("missingkey=zero").Parse(prompt.Template); err != nil {
+		return nil, fmt.Errorf("invalid template in %s: %w", source, err)
+	}
+
+	prompt.Source = source
+	return prompt, nil
+}
+
+// splitFrontMatter separates a leading '---' delimited YAML block from the document body
+func splitFrontMatter(content string) (string, string, error) {
+	content = strings.TrimPrefix(content, "\ufeff")
+	if !strings.HasPrefix(content, "---") {
+		return "", "", fmt.Errorf("missing front matter")
+	}
+
+	rest := strings.TrimPrefix(content, "---")
+	end := strings.Index(rest, "\n---")
+	if end < 0 {
+		return "", "", fmt.Errorf("unterminated front matter")
+	}
+
+	body := rest[end+len("\n---"):]
+	body = strings.TrimPrefix(strings.TrimPrefix(body, "\r"), "\n")
+	return rest[:end], body, nil
+}
+
+// LoadCustomPromptsFromDir loads all prompt definitions from a directory
+func LoadCustomPromptsFromDir(dir string) ([]*CustomPrompt, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompts directory %s: %w", dir, err)
+	}
+
+	files := make(map[string][]byte)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prompt file %s: %w", path, err)
+		}
+		files[entry.Name()] = data
+	}
+
+	return parseCustomPrompts(files)
+}
+
+// LoadCustomPromptsFromConfigMap loads prompt definitions from a ConfigMap,
+// where every data key is treated as a file name
+func LoadCustomPromptsFromConfigMap(ctx context.Context, k8sClient kubernetes.Interface, namespace, name string) ([]*CustomPrompt, error) {
+	cm, err := k8sClient.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prompts configmap %s/%s: %w", namespace, name, err)
+	}
+
+	files := make(map[string][]byte, len(cm.Data))
+	for key, value := range cm.Data {
+		files[key] = []byte(value)
+	}
+
+	return parseCustomPrompts(files)
+}
+
+// parseCustomPrompts parses a set of prompt files in a stable order and rejects duplicate names
+func parseCustomPrompts(files map[string][]byte) ([]*CustomPrompt, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		switch strings.ToLower(filepath.Ext(name)) {
+		case ".md", ".markdown", ".yaml", ".yml":
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	prompts := make([]*CustomPrompt, 0, len(names))
+	seen := make(map[string]string)
+	for _, name := range names {
+		prompt, err := ParseCustomPrompt(name, files[name])
+		if err != nil {
+			return nil, err
+		}
+		if previous, exists := seen[prompt.Name]; exists {
+			return nil, fmt.Errorf("duplicate prompt name %q in %s and %s", prompt.Name, previous, name)
+		}
+		seen[prompt.Name] = name
+		prompts = append(prompts, prompt)
+	}
+
+	return prompts, nil
+}
+
+// Render executes the prompt template with the given arguments
+func (p *CustomPrompt) Render(args map[string]string) (string, error) {
+	for _, arg := range p.Arguments {
+		if arg.Required && args[arg.Name] == "" {
+			return "", fmt.Errorf("%s is required", arg.Name)
+		}
+	}
+
+	tmpl, err := template.New(p.Name).Option("missingkey=zero").Parse(p.Template)
+	if err != nil {
+		return "", fmt.Errorf("invalid template for prompt %s: %w", p.Name, err)
+	}
+
+	data := make(map[string]string, len(p.Arguments))
+	for _, arg := range p.Arguments {
+		data[arg.Name] = ""
+	}
+	for k, v := range args {
+		data[k] = v
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt %s: %w", p.Name, err)
+	}
+
+	return out.String(), nil
+}
+
+// RegisterCustomPrompts registers operator-defined prompts with the MCP server
+func RegisterCustomPrompts(s *mcpserver.MCPServer, customPrompts []*CustomPrompt) error {
+	for _, p := range customPrompts {
+		options := []mcp.PromptOption{
+			mcp.WithPromptDescription(p.Description),
+		}
+		for _, arg := range p.Arguments {
+			argOptions := []mcp.ArgumentOption{mcp.ArgumentDescription(arg.Description)}
+			if arg.Required {
+				argOptions = append(argOptions, mcp.RequiredArgument())
+			}
+			options = append(options, mcp.WithArgument(arg.Name, argOptions...))
+		}
+
+		customPrompt := p
+		s.AddPrompt(mcp.NewPrompt(p.Name, options...), func(promptCtx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			text, err := customPrompt.Render(req.Params.Arguments)
+			if err != nil {
+				return nil, err
+			}
+
+			return &mcp.GetPromptResult{
+				Description: customPrompt.Description,
+				Messages: []mcp.PromptMessage{
+					{
+						Role:    mcp.RoleUser,
+						Content: mcp.TextContent{Text: text},
+					},
+				},
+			}, nil
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseCustomPrompt(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		data     string
+		wantErr  bool
+		wantName string
+		wantArgs int
+	}{
+		{
+			name:   "markdown with front matter",
+			source: "rotate-certs.md",
+			data: `---
+name: rotate-certs
+description: Rotate ingress certificates
+arguments:
+  - name: cluster
+    description: Target cluster
+    required: true
+---
+# Rotate certificates on {{ .cluster }}
+`,
+			wantName: "rotate-certs",
+			wantArgs: 1,
+		},
+		{
+			name:     "markdown name defaults to file name",
+			source:   "oncall-handover.md",
+			data:     "---\ndescription: Handover\n---\nBody",
+			wantName: "oncall-handover",
+		},
+		{
+			name:   "yaml definition",
+			source: "backup.yaml",
+			data: `name: backup-app
+description: Back up an app
+template: "Back up {{ .app }}"
+arguments:
+  - name: app
+`,
+			wantName: "backup-app",
+			wantArgs: 1,
+		},
+		{
+			name:    "missing front matter",
+			source:  "broken.md",
+			data:    "# No front matter",
+			wantErr: true,
+		},
+		{
+			name:    "invalid template",
+			source:  "broken.yaml",
+			data:    "name: broken\ntemplate: \"{{ .app \"",
+			wantErr: true,
+		},
+		{
+			name:    "empty template",
+			source:  "empty.yaml",
+			data:    "name: empty",
+			wantErr: true,
+		},
+		{
+			name:    "invalid name",
+			source:  "bad.yaml",
+			data:    "name: Bad_Name\ntemplate: body",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported extension",
+			source:  "prompt.txt",
+			data:    "body",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompt, err := ParseCustomPrompt(tt.source, []byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCustomPrompt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if prompt.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", prompt.Name, tt.wantName)
+			}
+			if len(prompt.Arguments) != tt.wantArgs {
+				t.Errorf("len(Arguments) = %d, want %d", len(prompt.Arguments), tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestCustomPromptRender(t *testing.T) {
+	prompt := &CustomPrompt{
+		Name: "rotate-certs",
+		Arguments: []CustomArgument{
+			{Name: "cluster", Required: true},
+			{Name: "issuer"},
+		},
+		Template: "Rotate on {{ .cluster }}{{ if .issuer }} using {{ .issuer }}{{ end }}.",
+	}
+
+	got, err := prompt.Render(map[string]string{"cluster": "prod"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got != "Rotate on prod." {
+		t.Errorf("Render() = %q", got)
+	}
+
+	if _, err := prompt.Render(map[string]string{}); err == nil {
+		t.Error("Render() expected error for missing required argument")
+	}
+}
+
+func TestLoadCustomPromptsFromDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.md":      "---\nname: runbook\n---\nFirst",
+		"b.yaml":    "name: runbook\ntemplate: Second",
+		"notes.txt": "ignored",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := LoadCustomPromptsFromDir(dir)
+	if err == nil || !strings.Contains(err.Error(), "duplicate prompt name") {
+		t.Fatalf("expected duplicate prompt name error, got %v", err)
+	}
+
+	if err := os.Remove(filepath.Join(dir, "b.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	prompts, err := LoadCustomPromptsFromDir(dir)
+	if err != nil {
+		t.Fatalf("LoadCustomPromptsFromDir() error = %v", err)
+	}
+	if len(prompts) != 1 || prompts[0].Name != "runbook" {
+		t.Errorf("unexpected prompts: %+v", prompts)
+	}
+}
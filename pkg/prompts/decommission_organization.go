@@ -0,0 +1,249 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/catalog"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/organization"
+)
+
+// organizationInventory holds the resources found for an organization
+// that have to be removed before the organization namespace can go away
+type organizationInventory struct {
+	Namespaces []string
+	Clusters   []*cluster.Cluster
+	Apps       []*app.App
+	Catalogs   []*catalog.Catalog
+	Configs    []*config.Config
+	Errors     []string
+}
+
+func registerDecommissionOrganizationPrompt(s *mcpserver.MCPServer, ctx *server.Context) error {
+	prompt := mcp.NewPrompt(
+		"decommission-organization",
+		mcp.WithPromptDescription("Guided teardown of an organization and all of its resources"),
+		mcp.WithArgument("organization", mcp.ArgumentDescription("Organization to decommission (e.g., 'acme')")),
+	)
+
+	s.AddPrompt(prompt, func(promptCtx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		orgName := req.Params.Arguments["organization"]
+
+		if orgName == "" {
+			pb := newPromptBuilder()
+			pb.addSection("Decommission Organization",
+				"This guide walks you through the safe removal of an organization, "+
+					"including its clusters, apps, catalogs and configuration.")
+			pb.addSection("Step 1: Select Organization",
+				"List the organizations to find the one you want to remove:")
+			pb.addCodeBlock("List Organizations", "bash", "organization_list")
+			pb.addSection("Action Required",
+				"Please specify the organization using the 'organization' argument.")
+			return &mcp.GetPromptResult{
+				Description: "Decommission organization guide - organization selection needed",
+				Messages: []mcp.PromptMessage{
+					{
+						Role:    mcp.RoleUser,
+						Content: mcp.TextContent{Text: pb.build()},
+					},
+				},
+			}, nil
+		}
+
+		inventory := collectOrganizationInventory(promptCtx, ctx, orgName)
+
+		return &mcp.GetPromptResult{
+			Description: fmt.Sprintf("Decommission plan for organization %s", orgName),
+			Messages: []mcp.PromptMessage{
+				{
+					Role:    mcp.RoleUser,
+					Content: mcp.TextContent{Text: buildDecommissionPlan(orgName, inventory)},
+				},
+			},
+		}, nil
+	})
+
+	return nil
+}
+
+// collectOrganizationInventory enumerates everything owned by an organization.
+// Failures are recorded on the inventory instead of aborting, so the user still
+// gets a plan for the parts that could be listed.
+func collectOrganizationInventory(ctx context.Context, serverCtx *server.Context, orgName string) *organizationInventory {
+	inventory := &organizationInventory{}
+
+	namespaces, err := organization.GetNamespacesByOrganization(ctx, serverCtx.K8sClient, orgName)
+	if err != nil {
+		inventory.Errors = append(inventory.Errors, fmt.Sprintf("namespaces: %v", err))
+		namespaces = []string{organization.GetOrganizationNamespace(orgName)}
+	}
+	inventory.Namespaces = namespaces
+
+	appClient := app.NewClient(serverCtx.DynamicClient)
+	clusterClient := cluster.NewClient(serverCtx.DynamicClient, serverCtx.K8sClient, appClient)
+	catalogClient := catalog.NewClient(serverCtx.DynamicClient)
+	configClient := config.NewClient(serverCtx.K8sClient)
+
+	clusters, err := clusterClient.ListByOrganization(ctx, orgName)
+	if err != nil {
+		inventory.Errors = append(inventory.Errors, fmt.Sprintf("clusters: %v", err))
+	}
+	inventory.Clusters = clusters
+
+	for _, ns := range namespaces {
+		apps, err := appClient.List(ctx, ns, "")
+		if err != nil {
+			inventory.Errors = append(inventory.Errors, fmt.Sprintf("apps in %s: %v", ns, err))
+		}
+		inventory.Apps = append(inventory.Apps, apps...)
+
+		catalogs, err := catalogClient.List(ctx, ns)
+		if err != nil {
+			inventory.Errors = append(inventory.Errors, fmt.Sprintf("catalogs in %s: %v", ns, err))
+		}
+		inventory.Catalogs = append(inventory.Catalogs, catalogs...)
+
+		configMaps, err := configClient.ListConfigMaps(ctx, ns, "")
+		if err != nil {
+			inventory.Errors = append(inventory.Errors, fmt.Sprintf("configmaps in %s: %v", ns, err))
+		}
+		inventory.Configs = append(inventory.Configs, configMaps...)
+
+		secrets, err := configClient.ListSecrets(ctx, ns, "")
+		if err != nil {
+			inventory.Errors = append(inventory.Errors, fmt.Sprintf("secrets in %s: %v", ns, err))
+		}
+		inventory.Configs = append(inventory.Configs, secrets...)
+	}
+
+	return inventory
+}
+
+// buildDecommissionPlan renders the teardown guide for an organization inventory
+func buildDecommissionPlan(orgName string, inventory *organizationInventory) string {
+	orgNamespace := organization.GetOrganizationNamespace(orgName)
+	pb := newPromptBuilder()
+
+	pb.addSection("Decommission Organization",
+		fmt.Sprintf("This guide sequences the removal of organization **%s**. "+
+			"Resources are deleted from the outside in: apps first, then clusters, "+
+			"catalogs and configuration, and finally the organization namespace **%s**.",
+			orgName, orgNamespace))
+
+	// Step 1: Inventory
+	pb.addList("Step 1: Inventory", []string{
+		fmt.Sprintf("Namespaces: %d", len(inventory.Namespaces)),
+		fmt.Sprintf("Clusters: %d", len(inventory.Clusters)),
+		fmt.Sprintf("Apps: %d", len(inventory.Apps)),
+		fmt.Sprintf("Catalogs: %d", len(inventory.Catalogs)),
+		fmt.Sprintf("ConfigMaps and Secrets: %d", len(inventory.Configs)),
+	})
+
+	if len(inventory.Errors) > 0 {
+		pb.addList("Inventory Incomplete", inventory.Errors)
+	}
+
+	// Blocking resources
+	blockers := decommissionBlockers(inventory)
+	if len(blockers) > 0 {
+		pb.addList("Blocking Resources", blockers)
+	} else {
+		pb.addSection("Blocking Resources", "No blocking resources were found.")
+	}
+
+	// Step 2: Apps
+	if len(inventory.Apps) > 0 {
+		items := make([]string, 0, len(inventory.Apps))
+		for _, a := range inventory.Apps {
+			items = append(items, fmt.Sprintf("`app_delete --name %s --namespace %s`", a.Name, a.Namespace))
+		}
+		pb.addList("Step 2: Delete Apps", items)
+	} else {
+		pb.addSection("Step 2: Delete Apps", "No apps to delete.")
+	}
+
+	// Step 3: Clusters
+	if len(inventory.Clusters) > 0 {
+		items := make([]string, 0, len(inventory.Clusters))
+		for _, c := range inventory.Clusters {
+			items = append(items, fmt.Sprintf("%s in namespace %s (phase: %s)", c.Name, c.Namespace, c.Status.Phase))
+		}
+		pb.addList("Step 3: Delete Clusters", items)
+		pb.addSection("Cluster Deletion",
+			"Delete each workload cluster through its cluster app or the Cluster CR and wait until "+
+				"the Cluster resource is gone. Cluster deletion removes the cloud infrastructure "+
+				"and can take several minutes per cluster.")
+		pb.addCodeBlock("Verify Clusters Are Gone", "bash",
+			fmt.Sprintf("cluster_list --organization %s", orgName))
+	} else {
+		pb.addSection("Step 3: Delete Clusters", "No clusters to delete.")
+	}
+
+	// Step 4: Catalogs
+	if len(inventory.Catalogs) > 0 {
+		items := make([]string, 0, len(inventory.Catalogs))
+		for _, c := range inventory.Catalogs {
+			items = append(items, fmt.Sprintf("`catalog_delete --name %s --namespace %s`", c.Name, c.Namespace))
+		}
+		pb.addList("Step 4: Delete Catalogs", items)
+	} else {
+		pb.addSection("Step 4: Delete Catalogs", "No catalogs to delete.")
+	}
+
+	// Step 5: Configuration
+	if len(inventory.Configs) > 0 {
+		items := make([]string, 0, len(inventory.Configs))
+		for _, c := range inventory.Configs {
+			items = append(items, fmt.Sprintf("%s %s/%s", c.Type, c.Namespace, c.Name))
+		}
+		pb.addList("Step 5: Review Configuration", items)
+		pb.addSection("Configuration Cleanup",
+			"ConfigMaps and Secrets are removed together with their namespace. "+
+				"Export anything you need to keep before continuing.")
+	} else {
+		pb.addSection("Step 5: Review Configuration", "No configuration objects found.")
+	}
+
+	// Step 6: Namespace removal
+	pb.addSection("Step 6: Remove the Organization",
+		"Once all steps above are complete and no blocking resources remain, "+
+			"delete the Organization resource. The organization operator removes the "+
+			"organization namespace afterwards.")
+	pb.addCodeBlock("Delete Organization", "bash",
+		fmt.Sprintf("kubectl delete organization %s", orgName))
+
+	pb.addList("Safety Checklist", []string{
+		"Confirm with the organization owners that the teardown is expected",
+		"Export app configuration that should be kept",
+		"Check that no other organization consumes this organization's catalogs",
+		"Re-run this prompt to confirm the inventory is empty before deleting the namespace",
+	})
+
+	return pb.build()
+}
+
+// decommissionBlockers lists resources that prevent the organization namespace from being removed
+func decommissionBlockers(inventory *organizationInventory) []string {
+	blockers := make([]string, 0)
+
+	for _, c := range inventory.Clusters {
+		blockers = append(blockers, fmt.Sprintf("Cluster %s/%s still exists", c.Namespace, c.Name))
+	}
+
+	for _, a := range inventory.Apps {
+		switch a.Status.Release.Status {
+		case "failed", "pending-install", "pending-upgrade", "pending-rollback", "uninstalling":
+			blockers = append(blockers, fmt.Sprintf("App %s/%s is in state '%s' and may not uninstall cleanly",
+				a.Namespace, a.Name, a.Status.Release.Status))
+		}
+	}
+
+	return blockers
+}
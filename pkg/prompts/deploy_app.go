@@ -41,7 +41,7 @@ func registerDeployAppPrompt(s *mcpserver.MCPServer, ctx *server.Context) error
 			pb.addSection("Step 1: Select Organization",
 				"First, you need to select which organization to deploy the app in. "+
 					"Use the following command to list available organizations:")
-			pb.addCodeBlock("List Organizations", "bash", "organization.list")
+			pb.addCodeBlock("List Organizations", "bash", "organization_list")
 			pb.addSection("Action Required",
 				"Please specify the organization using the 'organization' argument.")
 			return &mcp.GetPromptResult{
@@ -69,7 +69,7 @@ func registerDeployAppPrompt(s *mcpserver.MCPServer, ctx *server.Context) error
 				"Choose a catalog that contains the app you want to deploy. "+
 					"Available catalogs can be listed with:")
 			pb.addCodeBlock("List Catalogs", "bash",
-				fmt.Sprintf("catalog.list --organization %s", orgName))
+				fmt.Sprintf("catalog_list --organization %s", orgName))
 			pb.addList("Common Catalogs", []string{
 				"giantswarm - Official Giant Swarm apps",
 				"giantswarm-playground - Experimental apps",
@@ -95,7 +95,7 @@ func registerDeployAppPrompt(s *mcpserver.MCPServer, ctx *server.Context) error
 			pb.addSection("Step 3: Select App",
 				"Browse available apps in the catalog to find the one you want to deploy:")
 			pb.addCodeBlock("Browse Apps", "bash",
-				fmt.Sprintf("appcatalogentry.list --catalog %s", catalogName))
+				fmt.Sprintf("appcatalogentry_list --catalog %s", catalogName))
 			pb.addSection("Popular Apps",
 				"Some commonly deployed apps:\n"+
 					"- nginx-ingress-controller - Ingress controller\n"+
@@ -121,7 +121,7 @@ func registerDeployAppPrompt(s *mcpserver.MCPServer, ctx *server.Context) error
 		pb.addSection("Step 4: Select Version",
 			"Check available versions for the app:")
 		pb.addCodeBlock("Get App Details", "bash",
-			fmt.Sprintf("appcatalogentry.get --catalog %s --name %s", catalogName, appName))
+			fmt.Sprintf("appcatalogentry_get --catalog %s --name %s", catalogName, appName))
 
 		// Step 5: Configuration
 		pb.addSection("Step 5: Configuration (Optional)",
@@ -140,7 +140,7 @@ func registerDeployAppPrompt(s *mcpserver.MCPServer, ctx *server.Context) error
 		pb.addSection("Step 6: Deploy the App",
 			"Use the following command to deploy the app:")
 
-		deployCmd := fmt.Sprintf(`app.create \
+		deployCmd := fmt.Sprintf(`app_create \
   --name %s \
   --namespace %s \
   --catalog %s \
@@ -159,7 +159,7 @@ func registerDeployAppPrompt(s *mcpserver.MCPServer, ctx *server.Context) error
 		pb.addSection("Step 7: Verify Deployment",
 			"After deployment, verify the app status:")
 		pb.addCodeBlock("Check Status", "bash",
-			fmt.Sprintf("app.get --namespace %s --name %s", namespace, appName))
+			fmt.Sprintf("app_get --namespace %s --name %s", namespace, appName))
 
 		// Best practices
 		pb.addList("Best Practices", []string{
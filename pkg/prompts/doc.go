@@ -14,13 +14,18 @@
 //
 // # Available Prompts
 //
-// The package includes five main prompts:
+// The package includes six main prompts:
 //
 //   - deploy-app: Guides through deploying a Giant Swarm app
 //   - upgrade-app: Helps safely upgrade an app to a new version
 //   - troubleshoot-app: Comprehensive troubleshooting guide
 //   - create-catalog: Guide to create custom app catalogs
 //   - configure-app: Interactive configuration wizard
+//   - decommission-organization: Guided teardown of an organization
+//
+// A prompt is only registered when the tools it walks through are, so
+// RegisterPrompts must be called after the tools; prompts of disabled tool
+// groups or of tools left out by the tool profile are skipped.
 //
 // # Usage
 //
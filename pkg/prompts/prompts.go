@@ -2,6 +2,7 @@ package prompts
 
 import (
 	"fmt"
+	"log"
 	"strings"
 
 	mcpserver "github.com/mark3labs/mcp-go/server"
@@ -9,34 +10,51 @@ import (
 	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
 )
 
-// RegisterPrompts registers all available prompts with the MCP server
-func RegisterPrompts(s *mcpserver.MCPServer, ctx *server.Context) error {
-	// Register deploy-app prompt
-	if err := registerDeployAppPrompt(s, ctx); err != nil {
-		return fmt.Errorf("failed to register deploy-app prompt: %w", err)
-	}
+// builtinPrompt is a prompt shipped with the server and the tools it walks
+// the user through
+type builtinPrompt struct {
+	name     string
+	tools    []string
+	register func(*mcpserver.MCPServer, *server.Context) error
+}
 
-	// Register upgrade-app prompt
-	if err := registerUpgradeAppPrompt(s, ctx); err != nil {
-		return fmt.Errorf("failed to register upgrade-app prompt: %w", err)
-	}
+// builtinPrompts lists the built-in prompts in registration order
+var builtinPrompts = []builtinPrompt{
+	{"deploy-app", []string{"app_create"}, registerDeployAppPrompt},
+	{"upgrade-app", []string{"app_update"}, registerUpgradeAppPrompt},
+	{"troubleshoot-app", []string{"app_get"}, registerTroubleshootAppPrompt},
+	{"create-catalog", []string{"catalog_create"}, registerCreateCatalogPrompt},
+	{"configure-app", []string{"app_create"}, registerConfigureAppPrompt},
+	{"decommission-organization", []string{"app_delete", "catalog_delete"}, registerDecommissionOrganizationPrompt},
+}
 
-	// Register troubleshoot-app prompt
-	if err := registerTroubleshootAppPrompt(s, ctx); err != nil {
-		return fmt.Errorf("failed to register troubleshoot-app prompt: %w", err)
+// RegisterPrompts registers the built-in prompts whose tools are registered
+// with the MCP server, so it must be called after the tools. Prompts of tool
+// groups that are disabled, or of tools the tool profile leaves out, are
+// skipped.
+func RegisterPrompts(s *mcpserver.MCPServer, ctx *server.Context) error {
+	for _, prompt := range builtinPrompts {
+		if missing := missingTools(s, prompt.tools); len(missing) > 0 {
+			log.Printf("Skipping the %s prompt, its tools are not registered: %s", prompt.name, strings.Join(missing, ", "))
+			continue
+		}
+		if err := prompt.register(s, ctx); err != nil {
+			return fmt.Errorf("failed to register %s prompt: %w", prompt.name, err)
+		}
 	}
 
-	// Register create-catalog prompt
-	if err := registerCreateCatalogPrompt(s, ctx); err != nil {
-		return fmt.Errorf("failed to register create-catalog prompt: %w", err)
-	}
+	return nil
+}
 
-	// Register configure-app prompt
-	if err := registerConfigureAppPrompt(s, ctx); err != nil {
-		return fmt.Errorf("failed to register configure-app prompt: %w", err)
+// missingTools returns the tools that are not registered with s
+func missingTools(s *mcpserver.MCPServer, tools []string) []string {
+	var missing []string
+	for _, name := range tools {
+		if s.GetTool(name) == nil {
+			missing = append(missing, name)
+		}
 	}
-
-	return nil
+	return missing
 }
 
 // promptBuilder helps build formatted prompts with sections
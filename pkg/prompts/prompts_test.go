@@ -5,6 +5,9 @@ import (
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
 )
 
 func TestPromptBuilder(t *testing.T) {
@@ -324,3 +327,37 @@ func TestPromptRegistration(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildDecommissionPlan(t *testing.T) {
+	inventory := &organizationInventory{
+		Namespaces: []string{"org-acme"},
+		Clusters: []*cluster.Cluster{
+			{Name: "prod", Namespace: "org-acme"},
+		},
+		Apps: []*app.App{
+			{Name: "ingress", Namespace: "org-acme", Status: app.AppStatus{Release: app.ReleaseStatus{Status: "failed"}}},
+			{Name: "dns", Namespace: "org-acme", Status: app.AppStatus{Release: app.ReleaseStatus{Status: "deployed"}}},
+		},
+	}
+
+	plan := buildDecommissionPlan("acme", inventory)
+
+	for _, expected := range []string{
+		"## Step 1: Inventory",
+		"- Clusters: 1",
+		"- Apps: 2",
+		"Cluster org-acme/prod still exists",
+		"App org-acme/ingress is in state 'failed'",
+		"`app_delete --name dns --namespace org-acme`",
+		"No catalogs to delete.",
+		"kubectl delete organization acme",
+	} {
+		if !strings.Contains(plan, expected) {
+			t.Errorf("Expected plan to contain %q, but it didn't.\nGot:\n%s", expected, plan)
+		}
+	}
+
+	if strings.Contains(plan, "App org-acme/dns is in state") {
+		t.Error("Deployed app should not be reported as blocking")
+	}
+}
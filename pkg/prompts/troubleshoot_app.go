@@ -3,11 +3,15 @@ package prompts
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	mcpserver "github.com/mark3labs/mcp-go/server"
 
 	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/alerts"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
 )
 
 func registerTroubleshootAppPrompt(s *mcpserver.MCPServer, ctx *server.Context) error {
@@ -38,7 +42,7 @@ func registerTroubleshootAppPrompt(s *mcpserver.MCPServer, ctx *server.Context)
 			pb.addSection("Identify the App",
 				"To troubleshoot effectively, we need to identify the specific app:")
 			pb.addCodeBlock("List Apps with Status", "bash",
-				"app.list --all-orgs")
+				"app_list --all-orgs")
 			pb.addSection("Look For",
 				"Apps with status other than 'deployed', such as:\n"+
 					"- failed\n"+
@@ -60,11 +64,30 @@ func registerTroubleshootAppPrompt(s *mcpserver.MCPServer, ctx *server.Context)
 		pb.addSection("App Details",
 			fmt.Sprintf("Troubleshooting: **%s** in namespace: **%s**", appName, namespace))
 
+		// Docs targeted at the app, from its runbook annotation and the
+		// runbooks of well-known apps
+		if docs := appRunbooks(promptCtx, ctx, namespace, appName); len(docs) > 0 {
+			pb.addList("Troubleshooting Docs", docs)
+		}
+
+		// Alerts firing for the app right now
+		if ctx.Alertmanager != nil {
+			firing, err := firingAppAlerts(promptCtx, ctx, namespace, appName)
+			switch {
+			case err != nil:
+				pb.addSection("Firing Alerts", fmt.Sprintf("Could not read the alerts of the app: %v", err))
+			case len(firing) == 0:
+				pb.addSection("Firing Alerts", "No alerts are firing for the app.")
+			default:
+				pb.addList("Firing Alerts", firing)
+			}
+		}
+
 		// Step 1: Current status
 		pb.addSection("Step 1: Check App Status",
 			"First, get detailed information about the app:")
 		pb.addCodeBlock("Get App Details", "bash",
-			fmt.Sprintf("app.get --name %s --namespace %s", appName, namespace))
+			fmt.Sprintf("app_get --name %s --namespace %s", appName, namespace))
 		pb.addList("Key Information to Note", []string{
 			"Release status",
 			"Current version",
@@ -90,7 +113,7 @@ func registerTroubleshootAppPrompt(s *mcpserver.MCPServer, ctx *server.Context)
 			pb.addSection("Check 2: Configuration Issues",
 				"Verify configuration is correct:")
 			pb.addCodeBlock("Check Config", "bash",
-				fmt.Sprintf("config.get --namespace %s --app %s", namespace, appName))
+				fmt.Sprintf("config_get --namespace %s --app %s", namespace, appName))
 			pb.addList("Common Config Issues", []string{
 				"Missing required configuration values",
 				"Invalid YAML syntax",
@@ -101,12 +124,12 @@ func registerTroubleshootAppPrompt(s *mcpserver.MCPServer, ctx *server.Context)
 			pb.addSection("Check 3: Catalog and Version",
 				"Ensure the app version exists in the catalog:")
 			pb.addCodeBlock("Verify App in Catalog", "bash",
-				"appcatalogentry.get --catalog <CATALOG> --name <APP_NAME>")
+				"appcatalogentry_get --catalog <CATALOG> --name <APP_NAME>")
 
 			pb.addSection("Check 4: Namespace Permissions",
 				"Verify you have permissions in the namespace:")
 			pb.addCodeBlock("Check Access", "bash",
-				fmt.Sprintf("organization.validate-access --namespace %s", namespace))
+				fmt.Sprintf("organization_validate_access --namespace %s", namespace))
 		}
 
 		if issueType == "configuration" || issueType == "" {
@@ -140,6 +163,11 @@ func registerTroubleshootAppPrompt(s *mcpserver.MCPServer, ctx *server.Context)
 				"Review app-specific metrics and logs",
 			})
 
+			pb.addSection("Cluster Utilization",
+				"See whether the nodes of the target cluster are saturated and which pods use the most:")
+			pb.addCodeBlock("Metrics Snapshot", "bash",
+				"cluster_metrics --cluster <CLUSTER>")
+
 			pb.addSection("Resource Configuration",
 				"Adjust resources if needed:")
 			pb.addCodeBlock("Example Resource Config", "yaml",
@@ -180,23 +208,23 @@ func registerTroubleshootAppPrompt(s *mcpserver.MCPServer, ctx *server.Context)
 		pb.addSection("Option 1: Reapply Configuration",
 			"If configuration was the issue:")
 		pb.addCodeBlock("Update Config", "bash",
-			fmt.Sprintf("app.update --name %s --namespace %s --config-name <NEW_CONFIG>",
+			fmt.Sprintf("app_update --name %s --namespace %s --config-name <NEW_CONFIG>",
 				appName, namespace))
 
 		pb.addSection("Option 2: Force Redeploy",
 			"Trigger a fresh deployment:")
 		pb.addCodeBlock("Delete and Recreate", "bash",
 			fmt.Sprintf(`# Delete the app
-app.delete --name %s --namespace %s
+app_delete --name %s --namespace %s
 
 # Recreate with same or updated configuration
-app.create --name %s --namespace %s --catalog <CATALOG> --app <APP> --version <VERSION>`,
+app_create --name %s --namespace %s --catalog <CATALOG> --app <APP> --version <VERSION>`,
 				appName, namespace, appName, namespace))
 
 		pb.addSection("Option 3: Rollback Version",
 			"If a recent upgrade caused issues:")
 		pb.addCodeBlock("Rollback", "bash",
-			fmt.Sprintf("app.update --name %s --namespace %s --version <PREVIOUS_VERSION>",
+			fmt.Sprintf("app_update --name %s --namespace %s --version <PREVIOUS_VERSION>",
 				appName, namespace))
 
 		// Getting help
@@ -211,7 +239,7 @@ app.create --name %s --namespace %s --catalog <CATALOG> --app <APP> --version <V
 		pb.addSection("Information to Provide to Support", "")
 		pb.addList("Gather This Information", []string{
 			"App name, namespace, and version",
-			"Error messages from 'app.get' command",
+			"Error messages from 'app_get' command",
 			"Kubernetes events",
 			"Pod logs if available",
 			"Configuration being used",
@@ -231,3 +259,54 @@ app.create --name %s --namespace %s --catalog <CATALOG> --app <APP> --version <V
 
 	return nil
 }
+
+// appRunbooks lists the troubleshooting docs of an app as markdown links. An
+// app that cannot be read is matched by its name only.
+func appRunbooks(ctx context.Context, serverCtx *server.Context, namespace, name string) []string {
+	a := &app.App{Name: name}
+	if serverCtx.DynamicClient != nil {
+		if found, err := app.NewClient(serverCtx.DynamicClient).Get(ctx, namespace, name); err == nil {
+			a = found
+		}
+	}
+
+	var docs []string
+	if runbook := a.HealthInfo().RunbookURL; runbook != "" {
+		docs = append(docs, fmt.Sprintf("[App runbook](%s)", runbook))
+	}
+	for _, runbook := range serverCtx.Runbooks.For(a) {
+		docs = append(docs, fmt.Sprintf("[%s](%s)", runbook.Title, runbook.URL))
+	}
+	return docs
+}
+
+// firingAppAlerts describes the unsilenced alerts firing for an app in its
+// cluster, the most severe first
+func firingAppAlerts(ctx context.Context, serverCtx *server.Context, namespace, name string) ([]string, error) {
+	appClient := app.NewClient(serverCtx.DynamicClient)
+	clusterClient := cluster.NewClient(serverCtx.DynamicClient, serverCtx.K8sClient, appClient).WithClientPool(serverCtx.WorkloadClients)
+
+	a, err := appClient.Get(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	cl, err := clusterClient.FindAppCluster(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	clusterName := ""
+	if cl != nil {
+		clusterName = cl.Name
+	}
+
+	all, err := serverCtx.Alertmanager.List(ctx, serverCtx.K8sClient, false)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var firing []string
+	for _, alert := range alerts.AppFilter(a, clusterName).Apply(all) {
+		firing = append(firing, alert.Describe(now))
+	}
+	return firing, nil
+}
@@ -37,7 +37,7 @@ func registerUpgradeAppPrompt(s *mcpserver.MCPServer, ctx *server.Context) error
 		if appName == "" || namespace == "" {
 			pb.addSection("Step 1: Identify the App",
 				"First, identify the app you want to upgrade. List all apps to find the correct one:")
-			pb.addCodeBlock("List Apps", "bash", "app.list")
+			pb.addCodeBlock("List Apps", "bash", "app_list")
 			pb.addSection("Action Required",
 				"Please specify both 'name' and 'namespace' arguments for the app you want to upgrade.")
 			return &mcp.GetPromptResult{
@@ -58,7 +58,7 @@ func registerUpgradeAppPrompt(s *mcpserver.MCPServer, ctx *server.Context) error
 		pb.addSection("Step 2: Check Current Status",
 			"Before upgrading, check the current status and version of your app:")
 		pb.addCodeBlock("Get App Details", "bash",
-			fmt.Sprintf("app.get --name %s --namespace %s", appName, namespace))
+			fmt.Sprintf("app_get --name %s --namespace %s", appName, namespace))
 		pb.addList("What to Check", []string{
 			"Current version",
 			"Release status (should be 'deployed')",
@@ -73,7 +73,7 @@ func registerUpgradeAppPrompt(s *mcpserver.MCPServer, ctx *server.Context) error
 			pb.addSection("Find Available Versions",
 				"First, identify the catalog and app name from the current app details, then:")
 			pb.addCodeBlock("List Versions", "bash",
-				"appcatalogentry.get --catalog <CATALOG> --name <APP_NAME>")
+				"appcatalogentry_get --catalog <CATALOG> --name <APP_NAME>")
 			pb.addList("Version Selection Guidelines", []string{
 				"Check the changelog for breaking changes",
 				"Prefer incremental upgrades over major jumps",
@@ -111,7 +111,7 @@ func registerUpgradeAppPrompt(s *mcpserver.MCPServer, ctx *server.Context) error
 		pb.addSection("Step 5: Review Configuration",
 			"Check if configuration changes are needed for the new version:")
 		pb.addCodeBlock("View Current Config", "bash",
-			fmt.Sprintf("config.get --namespace %s --app %s", namespace, appName))
+			fmt.Sprintf("config_get --namespace %s --app %s", namespace, appName))
 		pb.addSection("Configuration Compatibility",
 			"Compare your current configuration with the new version's schema:")
 		pb.addCodeBlock("Check New Schema", "bash",
@@ -121,14 +121,14 @@ func registerUpgradeAppPrompt(s *mcpserver.MCPServer, ctx *server.Context) error
 		pb.addSection("Step 6: Perform the Upgrade",
 			"Execute the upgrade command:")
 		pb.addCodeBlock("Upgrade Command", "bash",
-			fmt.Sprintf("app.update --name %s --namespace %s --version %s",
+			fmt.Sprintf("app_update --name %s --namespace %s --version %s",
 				appName, namespace, targetVersion))
 
 		// Step 7: Monitor upgrade
 		pb.addSection("Step 7: Monitor the Upgrade",
 			"After initiating the upgrade, monitor its progress:")
 		pb.addCodeBlock("Check Status", "bash",
-			fmt.Sprintf("app.get --name %s --namespace %s", appName, namespace))
+			fmt.Sprintf("app_get --name %s --namespace %s", appName, namespace))
 		pb.addList("Monitor These Aspects", []string{
 			"Release status transitions",
 			"Pod rollout status",
@@ -151,7 +151,7 @@ func registerUpgradeAppPrompt(s *mcpserver.MCPServer, ctx *server.Context) error
 		pb.addSection("Rollback (If Needed)",
 			"If issues occur, you can rollback to the previous version:")
 		pb.addCodeBlock("Rollback Command", "bash",
-			fmt.Sprintf("app.update --name %s --namespace %s --version <PREVIOUS_VERSION>",
+			fmt.Sprintf("app_update --name %s --namespace %s --version <PREVIOUS_VERSION>",
 				appName, namespace))
 
 		// Best practices
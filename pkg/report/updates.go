@@ -0,0 +1,453 @@
+// Package report generates fleet reports in the background so that agents can
+// read a fresh report without waiting for it to be computed.
+package report
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/organization"
+)
+
+// UpdatesURI is the MCP resource serving the latest updates report
+const UpdatesURI = "report://updates"
+
+// Kinds of drift
+const (
+	// DriftNotReconciled is an app whose deployed version or release status
+	// does not match its spec
+	DriftNotReconciled = "not-reconciled"
+	// DriftVersionSkew is an app deployed with different versions to the
+	// clusters of an organization
+	DriftVersionSkew = "version-skew"
+	// DriftValues is an app whose user values differ between the clusters of
+	// an organization
+	DriftValues = "values"
+)
+
+// maxDriftKeys limits the differing value keys listed per app
+const maxDriftKeys = 10
+
+// UpdatesReport lists the pending upgrades and configuration drift of all
+// apps on the management cluster
+type UpdatesReport struct {
+	GeneratedAt     time.Time        `json:"generatedAt"`
+	Duration        string           `json:"duration"`
+	Apps            int              `json:"apps"`
+	PendingUpgrades []PendingUpgrade `json:"pendingUpgrades"`
+	Drift           []Drift          `json:"drift"`
+	Errors          []string         `json:"errors,omitempty"`
+
+	// appNamespaces counts the apps per namespace and errorNamespaces holds
+	// the namespace of each error, for Filter
+	appNamespaces   map[string]int
+	errorNamespaces []string
+}
+
+// Filter returns the part of the report about the namespaces allows accepts,
+// e.g. those a server is restricted to
+func (r *UpdatesReport) Filter(allows func(namespace string) bool) *UpdatesReport {
+	filtered := &UpdatesReport{
+		GeneratedAt:     r.GeneratedAt,
+		Duration:        r.Duration,
+		PendingUpgrades: []PendingUpgrade{},
+		Drift:           []Drift{},
+		appNamespaces:   make(map[string]int),
+	}
+	for namespace, count := range r.appNamespaces {
+		if allows(namespace) {
+			filtered.Apps += count
+			filtered.appNamespaces[namespace] = count
+		}
+	}
+	for _, upgrade := range r.PendingUpgrades {
+		if allows(upgrade.Namespace) {
+			filtered.PendingUpgrades = append(filtered.PendingUpgrades, upgrade)
+		}
+	}
+	for _, drift := range r.Drift {
+		if allows(drift.namespace) {
+			filtered.Drift = append(filtered.Drift, drift)
+		}
+	}
+	for i, err := range r.Errors {
+		if i < len(r.errorNamespaces) && allows(r.errorNamespaces[i]) {
+			filtered.Errors = append(filtered.Errors, err)
+			filtered.errorNamespaces = append(filtered.errorNamespaces, r.errorNamespaces[i])
+		}
+	}
+	return filtered
+}
+
+// PendingUpgrade is an app with a newer stable version in its catalog
+type PendingUpgrade struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Cluster   string `json:"cluster,omitempty"`
+	Catalog   string `json:"catalog"`
+	App       string `json:"app"`
+	Deployed  string `json:"deployed"`
+	Latest    string `json:"latest"`
+}
+
+// Drift is an inconsistency of an app, see the Drift* kinds. Namespace and
+// Name are set for a single app; drift across clusters names the
+// organization and the chart instead.
+type Drift struct {
+	Kind         string `json:"kind"`
+	Organization string `json:"organization,omitempty"`
+	Namespace    string `json:"namespace,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Catalog      string `json:"catalog"`
+	App          string `json:"app"`
+	Details      string `json:"details"`
+
+	// namespace holds the apps of the drift, also for drift across clusters
+	namespace string
+}
+
+// Reporter regenerates the updates report periodically
+type Reporter struct {
+	appClient    *app.Client
+	configClient *config.Client
+	entryClient  *appcatalogentry.Client
+	interval     time.Duration
+
+	mu       sync.RWMutex
+	latest   *UpdatesReport
+	onUpdate func(*UpdatesReport)
+}
+
+// NewReporter creates a reporter generating a report every interval once
+// started. Catalog entries are read from index when it is set.
+func NewReporter(k8sClient *k8s.Client, dynamicClient *k8s.DynamicClient, index *appcatalogentry.Index, interval time.Duration) *Reporter {
+	entryClient := appcatalogentry.NewClient(dynamicClient)
+	if index != nil {
+		entryClient.WithIndex(index)
+	}
+	return &Reporter{
+		appClient:    app.NewClient(dynamicClient),
+		configClient: config.NewClient(k8sClient),
+		entryClient:  entryClient,
+		interval:     interval,
+	}
+}
+
+// OnUpdate sets a function called with every new report, e.g. to notify
+// subscribers of the resource
+func (r *Reporter) OnUpdate(fn func(*UpdatesReport)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onUpdate = fn
+}
+
+// Latest returns the most recent report, or nil before the first one
+func (r *Reporter) Latest() *UpdatesReport {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.latest
+}
+
+// Start generates a report and keeps regenerating it until ctx is done.
+// Failed runs are logged and keep the previous report.
+func (r *Reporter) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := r.Refresh(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Warning: failed to generate updates report: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Refresh generates a new report and makes it the latest
+func (r *Reporter) Refresh(ctx context.Context) error {
+	report, err := r.Generate(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.latest = report
+	onUpdate := r.onUpdate
+	r.mu.Unlock()
+
+	if onUpdate != nil {
+		onUpdate(report)
+	}
+	return nil
+}
+
+// Generate computes a report from all apps and catalog entries. User values
+// are only read for apps deployed to more than one cluster of an
+// organization; values that cannot be read are listed as errors.
+func (r *Reporter) Generate(ctx context.Context) (*UpdatesReport, error) {
+	start := time.Now()
+
+	apps, err := r.appClient.List(ctx, "", "")
+	if err != nil {
+		return nil, err
+	}
+	entries, err := r.entryClient.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]*config.Config)
+	failures := []string{}
+	var errorNamespaces []string
+	for _, group := range groupByOrganization(apps) {
+		if len(group.apps) < 2 {
+			continue
+		}
+		for _, a := range group.apps {
+			if a.Spec.UserConfig == nil || a.Spec.UserConfig.ConfigMap == nil {
+				continue
+			}
+			ref := a.Spec.UserConfig.ConfigMap
+			namespace := ref.Namespace
+			if namespace == "" {
+				namespace = a.Namespace
+			}
+			cfg, err := r.configClient.GetConfigMap(ctx, namespace, ref.Name)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("user values of app %s/%s: %v", a.Namespace, a.Name, err))
+				errorNamespaces = append(errorNamespaces, a.Namespace)
+				continue
+			}
+			values[a.Namespace+"/"+a.Name] = cfg.Flatten()
+		}
+	}
+
+	report := BuildUpdatesReport(apps, entries, values)
+	report.GeneratedAt = start
+	report.Duration = time.Since(start).Round(time.Millisecond).String()
+	report.Errors = failures
+	report.errorNamespaces = errorNamespaces
+	return report, nil
+}
+
+// BuildUpdatesReport computes the pending upgrades and drift of apps. values
+// holds the flattened user values of apps by namespace/name.
+func BuildUpdatesReport(apps []*app.App, entries []*appcatalogentry.AppCatalogEntry, values map[string]*config.Config) *UpdatesReport {
+	report := &UpdatesReport{Apps: len(apps), PendingUpgrades: []PendingUpgrade{}, Drift: []Drift{}, appNamespaces: make(map[string]int)}
+	for _, a := range apps {
+		report.appNamespaces[a.Namespace]++
+	}
+
+	newest := make(map[string]string)
+	for _, a := range apps {
+		key := a.Spec.Catalog + "/" + a.Spec.Name
+		latest, ok := newest[key]
+		if !ok {
+			var versions []*appcatalogentry.AppCatalogEntry
+			for _, entry := range entries {
+				if entry.Spec.Catalog.Name == a.Spec.Catalog && entry.MatchesApp(a.Spec.Name) {
+					versions = append(versions, entry)
+				}
+			}
+			latest = appcatalogentry.NewestStableVersion(versions)
+			newest[key] = latest
+		}
+
+		deployed := deployedVersion(a)
+		if isNewer(latest, deployed) {
+			report.PendingUpgrades = append(report.PendingUpgrades, PendingUpgrade{
+				Namespace: a.Namespace,
+				Name:      a.Name,
+				Cluster:   clusterName(a),
+				Catalog:   a.Spec.Catalog,
+				App:       a.Spec.Name,
+				Deployed:  deployed,
+				Latest:    strings.TrimPrefix(latest, "v"),
+			})
+		}
+
+		var problems []string
+		if a.Status.Version != "" && a.Status.Version != a.Spec.Version {
+			problems = append(problems, fmt.Sprintf("spec version %s, deployed %s", a.Spec.Version, a.Status.Version))
+		}
+		if status := a.Status.Release.Status; status != "" && status != "deployed" {
+			problems = append(problems, "release status "+status)
+		}
+		if len(problems) > 0 {
+			report.Drift = append(report.Drift, Drift{
+				Kind: DriftNotReconciled, Namespace: a.Namespace, Name: a.Name,
+				Catalog: a.Spec.Catalog, App: a.Spec.Name, Details: strings.Join(problems, ", "),
+				namespace: a.Namespace,
+			})
+		}
+	}
+
+	for _, group := range groupByOrganization(apps) {
+		if len(group.apps) < 2 {
+			continue
+		}
+
+		versions := make(map[string]bool)
+		var deployments []string
+		for _, a := range group.apps {
+			versions[deployedVersion(a)] = true
+			deployments = append(deployments, fmt.Sprintf("%s=%s", clusterName(a), deployedVersion(a)))
+		}
+		if len(versions) > 1 {
+			report.Drift = append(report.Drift, Drift{
+				Kind: DriftVersionSkew, Organization: group.organization,
+				Catalog: group.catalog, App: group.app, Details: strings.Join(deployments, ", "),
+				namespace: group.namespace,
+			})
+		}
+
+		if keys := differingKeys(group.apps, values); len(keys) > 0 {
+			details := strings.Join(keys, ", ")
+			if len(keys) > maxDriftKeys {
+				details = fmt.Sprintf("%s and %d more", strings.Join(keys[:maxDriftKeys], ", "), len(keys)-maxDriftKeys)
+			}
+			report.Drift = append(report.Drift, Drift{
+				Kind: DriftValues, Organization: group.organization,
+				Catalog: group.catalog, App: group.app, Details: "differing keys: " + details,
+				namespace: group.namespace,
+			})
+		}
+	}
+
+	sort.SliceStable(report.PendingUpgrades, func(i, j int) bool {
+		a, b := report.PendingUpgrades[i], report.PendingUpgrades[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return report
+}
+
+// appGroup is the deployments of one chart to the clusters of an organization
+type appGroup struct {
+	organization string
+	namespace    string
+	catalog      string
+	app          string
+	apps         []*app.App
+}
+
+// groupByOrganization groups workload cluster apps by organization and chart,
+// sorted by cluster within each group
+func groupByOrganization(apps []*app.App) []*appGroup {
+	groups := make(map[string]*appGroup)
+	var keys []string
+	for _, a := range apps {
+		if a.Spec.KubeConfig.InCluster || clusterName(a) == "" {
+			continue
+		}
+		org, err := organization.GetOrganizationFromNamespace(a.Namespace)
+		if err != nil {
+			org = a.Namespace
+		}
+		key := org + "/" + a.Spec.Catalog + "/" + a.Spec.Name
+		group, ok := groups[key]
+		if !ok {
+			group = &appGroup{organization: org, namespace: a.Namespace, catalog: a.Spec.Catalog, app: a.Spec.Name}
+			groups[key] = group
+			keys = append(keys, key)
+		}
+		group.apps = append(group.apps, a)
+	}
+
+	sort.Strings(keys)
+	result := make([]*appGroup, 0, len(keys))
+	for _, key := range keys {
+		group := groups[key]
+		sort.Slice(group.apps, func(i, j int) bool { return clusterName(group.apps[i]) < clusterName(group.apps[j]) })
+		result = append(result, group)
+	}
+	return result
+}
+
+// differingKeys returns the flattened value keys that differ between the
+// user values of apps, sorted
+func differingKeys(apps []*app.App, values map[string]*config.Config) []string {
+	var configs []*config.Config
+	for _, a := range apps {
+		cfg, ok := values[a.Namespace+"/"+a.Name]
+		if !ok {
+			cfg = &config.Config{}
+		}
+		configs = append(configs, cfg)
+	}
+
+	differing := make(map[string]bool)
+	for _, cfg := range configs[1:] {
+		diff := configs[0].Diff(cfg)
+		for k := range diff.Added {
+			differing[k] = true
+		}
+		for k := range diff.Modified {
+			differing[k] = true
+		}
+		for k := range diff.Removed {
+			differing[k] = true
+		}
+	}
+
+	keys := make([]string, 0, len(differing))
+	for k := range differing {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// clusterName returns the workload cluster an app is deployed to, from its
+// kubeconfig secret or cluster label, or "" for the management cluster
+func clusterName(a *app.App) string {
+	if a.Spec.KubeConfig.InCluster {
+		return ""
+	}
+	if secret := a.Spec.KubeConfig.Secret; secret != nil && strings.HasSuffix(secret.Name, "-kubeconfig") {
+		return strings.TrimSuffix(secret.Name, "-kubeconfig")
+	}
+	return a.Labels[cluster.ClusterLabel]
+}
+
+// deployedVersion returns the version app-operator reports as deployed,
+// falling back to the desired version
+func deployedVersion(a *app.App) string {
+	if a.Status.Version != "" {
+		return a.Status.Version
+	}
+	return a.Spec.Version
+}
+
+// isNewer reports whether latest is a higher semver version than deployed
+func isNewer(latest, deployed string) bool {
+	l, err := semver.NewVersion(latest)
+	if err != nil {
+		return false
+	}
+	d, err := semver.NewVersion(deployed)
+	if err != nil {
+		return false
+	}
+	return l.GreaterThan(d)
+}
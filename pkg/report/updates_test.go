@@ -0,0 +1,121 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+)
+
+func workloadApp(name, cluster, version string) *app.App {
+	return &app.App{
+		Name:      cluster + "-" + name,
+		Namespace: "org-acme",
+		Spec: app.AppSpec{
+			Catalog:    "giantswarm",
+			Name:       name,
+			Version:    version,
+			KubeConfig: app.KubeConfig{Secret: &app.SecretReference{Name: cluster + "-kubeconfig"}},
+		},
+		Status: app.AppStatus{Version: version, Release: app.ReleaseStatus{Status: "deployed"}},
+	}
+}
+
+func entry(name, version string) *appcatalogentry.AppCatalogEntry {
+	return &appcatalogentry.AppCatalogEntry{
+		Name: "giantswarm-" + name + "-" + version,
+		Spec: appcatalogentry.AppCatalogEntrySpec{
+			AppName: name,
+			Catalog: appcatalogentry.CatalogReference{Name: "giantswarm"},
+			Chart:   appcatalogentry.ChartSpec{Name: name, Version: version},
+		},
+	}
+}
+
+func TestBuildUpdatesReport(t *testing.T) {
+	failing := workloadApp("ingress", "beta", "2.0.0")
+	failing.Status.Release.Status = "failed"
+
+	apps := []*app.App{
+		workloadApp("ingress", "alpha", "1.0.0"),
+		failing,
+		workloadApp("dns", "alpha", "1.0.0"),
+		workloadApp("dns", "beta", "1.0.0"),
+	}
+	entries := []*appcatalogentry.AppCatalogEntry{
+		entry("ingress", "1.0.0"),
+		entry("ingress", "2.0.0"),
+		entry("ingress", "3.0.0-rc.1"),
+		entry("dns", "1.0.0"),
+	}
+	values := map[string]*config.Config{
+		"org-acme/alpha-dns": {Data: map[string]string{"replicas": "2", "zone": "a"}},
+		"org-acme/beta-dns":  {Data: map[string]string{"replicas": "3", "zone": "a"}},
+	}
+
+	report := BuildUpdatesReport(apps, entries, values)
+
+	if report.Apps != 4 {
+		t.Errorf("apps = %d, want 4", report.Apps)
+	}
+	if len(report.PendingUpgrades) != 1 {
+		t.Fatalf("pending upgrades = %+v, want 1", report.PendingUpgrades)
+	}
+	if u := report.PendingUpgrades[0]; u.Name != "alpha-ingress" || u.Cluster != "alpha" || u.Deployed != "1.0.0" || u.Latest != "2.0.0" {
+		t.Errorf("unexpected pending upgrade %+v", u)
+	}
+
+	drift := make(map[string]Drift)
+	for _, d := range report.Drift {
+		drift[d.Kind+"/"+d.App] = d
+	}
+	if len(drift) != 3 {
+		t.Errorf("drift = %+v, want 3 entries", report.Drift)
+	}
+	if d, ok := drift[DriftNotReconciled+"/ingress"]; !ok || d.Name != "beta-ingress" {
+		t.Errorf("missing not-reconciled drift of beta-ingress: %+v", report.Drift)
+	}
+	if d, ok := drift[DriftVersionSkew+"/ingress"]; !ok || d.Organization != "acme" || d.Details != "alpha=1.0.0, beta=2.0.0" {
+		t.Errorf("unexpected version skew %+v", d)
+	}
+	if d, ok := drift[DriftValues+"/dns"]; !ok || d.Details != "differing keys: replicas" {
+		t.Errorf("unexpected values drift %+v", d)
+	}
+}
+
+func TestFilterUpdatesReport(t *testing.T) {
+	other := workloadApp("ingress", "gamma", "1.0.0")
+	other.Namespace = "org-globex"
+	other.Status.Release.Status = "failed"
+	apps := []*app.App{
+		workloadApp("ingress", "alpha", "1.0.0"),
+		workloadApp("ingress", "beta", "2.0.0"),
+		other,
+	}
+	entries := []*appcatalogentry.AppCatalogEntry{entry("ingress", "1.0.0"), entry("ingress", "2.0.0")}
+
+	report := BuildUpdatesReport(apps, entries, nil)
+	report.Errors = []string{"user values of app org-globex/gamma-ingress: forbidden"}
+	report.errorNamespaces = []string{"org-globex"}
+
+	filtered := report.Filter(func(namespace string) bool { return namespace == "org-acme" })
+	if filtered.Apps != 2 {
+		t.Errorf("apps = %d, want 2", filtered.Apps)
+	}
+	for _, u := range filtered.PendingUpgrades {
+		if u.Namespace != "org-acme" {
+			t.Errorf("pending upgrade of another namespace: %+v", u)
+		}
+	}
+	if len(filtered.PendingUpgrades) != 1 {
+		t.Errorf("pending upgrades = %+v, want the alpha upgrade", filtered.PendingUpgrades)
+	}
+	// The version skew of org-acme stays, the failed release of org-globex goes
+	if len(filtered.Drift) != 1 || filtered.Drift[0].Kind != DriftVersionSkew || filtered.Drift[0].Organization != "acme" {
+		t.Errorf("drift = %+v, want the version skew of acme", filtered.Drift)
+	}
+	if len(filtered.Errors) != 0 {
+		t.Errorf("errors = %v, want none", filtered.Errors)
+	}
+}
@@ -0,0 +1,107 @@
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+)
+
+// buildChangelog turns the entries of an app into changelog entries, newest
+// version first. Versions are ordered by semver; entries whose version does
+// not parse are ordered by date after the parsable ones. Each entry carries a
+// hint for upgrading from the version before it.
+func buildChangelog(entries []*appcatalogentry.AppCatalogEntry) []ChangelogEntry {
+	type item struct {
+		version string
+		parsed  *semver.Version
+		date    time.Time
+		entry   *appcatalogentry.AppCatalogEntry
+	}
+
+	// The same version can be listed by more than one catalog namespace
+	seen := make(map[string]bool, len(entries))
+	items := make([]item, 0, len(entries))
+	for _, entry := range entries {
+		version := entry.GetLatestVersion()
+		if version == "" || seen[version] {
+			continue
+		}
+		seen[version] = true
+
+		it := item{version: version, entry: entry}
+		if parsed, err := semver.NewVersion(version); err == nil {
+			it.parsed = parsed
+		}
+		if entry.Spec.DateCreated != nil {
+			it.date = *entry.Spec.DateCreated
+		} else if entry.Spec.DateUpdated != nil {
+			it.date = *entry.Spec.DateUpdated
+		}
+		items = append(items, it)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		switch {
+		case a.parsed != nil && b.parsed != nil:
+			if !a.parsed.Equal(b.parsed) {
+				return a.parsed.GreaterThan(b.parsed)
+			}
+		case a.parsed != nil:
+			return true
+		case b.parsed != nil:
+			return false
+		}
+		if !a.date.Equal(b.date) {
+			return a.date.After(b.date)
+		}
+		return a.version > b.version
+	})
+
+	changelog := make([]ChangelogEntry, 0, len(items))
+	for i, it := range items {
+		entry := ChangelogEntry{
+			Version:     it.version,
+			Description: it.entry.Spec.Chart.Description,
+		}
+		if !it.date.IsZero() {
+			entry.Date = it.date.Format("2006-01-02")
+		}
+
+		if i < len(items)-1 {
+			previous := items[i+1]
+			entry.UpgradeFrom = previous.version
+			entry.Breaking, entry.UpgradeHint = upgradeHint(previous.parsed, it.parsed)
+		}
+
+		changelog = append(changelog, entry)
+	}
+
+	return changelog
+}
+
+// upgradeHint describes upgrading between two consecutive versions and
+// reports whether the step may contain breaking changes. Following semver,
+// minor bumps before 1.0.0 are treated as breaking.
+func upgradeHint(from, to *semver.Version) (bool, string) {
+	if from == nil || to == nil {
+		return false, "versions are not semver, review the release notes before upgrading"
+	}
+
+	switch {
+	case to.Major() != from.Major():
+		return true, fmt.Sprintf("major upgrade from %d.x to %d.x, review breaking changes and migrate the configuration before upgrading", from.Major(), to.Major())
+	case to.Major() == 0 && to.Minor() != from.Minor():
+		return true, "minor upgrade of a 0.x version, which may contain breaking changes"
+	case to.Minor() != from.Minor():
+		return false, "minor upgrade, new features and backwards compatible"
+	case to.Prerelease() != "":
+		return false, fmt.Sprintf("pre-release %s, not recommended for production", to.Prerelease())
+	default:
+		return false, "patch upgrade, safe to apply directly"
+	}
+}
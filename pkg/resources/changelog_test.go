@@ -0,0 +1,59 @@
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+)
+
+func changelogEntry(version string, created time.Time) *appcatalogentry.AppCatalogEntry {
+	return &appcatalogentry.AppCatalogEntry{
+		Spec: appcatalogentry.AppCatalogEntrySpec{
+			AppName:     "ingress-nginx",
+			Chart:       appcatalogentry.ChartSpec{Version: version},
+			DateCreated: &created,
+		},
+	}
+}
+
+func TestBuildChangelog(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	// 1.10.0 sorts after 1.9.0 by semver but before it as a string, and the
+	// 1.9.1 backport was released after 2.0.0
+	changelog := buildChangelog([]*appcatalogentry.AppCatalogEntry{
+		changelogEntry("1.9.0", day(1)),
+		changelogEntry("2.0.0", day(3)),
+		changelogEntry("1.10.0", day(2)),
+		changelogEntry("1.9.1", day(4)),
+		changelogEntry("1.9.1", day(4)),
+		changelogEntry("nightly", day(5)),
+	})
+
+	want := []struct {
+		version     string
+		upgradeFrom string
+		breaking    bool
+	}{
+		{"2.0.0", "1.10.0", true},
+		{"1.10.0", "1.9.1", false},
+		{"1.9.1", "1.9.0", false},
+		{"1.9.0", "nightly", false},
+		{"nightly", "", false},
+	}
+
+	if len(changelog) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(changelog), len(want), changelog)
+	}
+	for i, w := range want {
+		got := changelog[i]
+		if got.Version != w.version || got.UpgradeFrom != w.upgradeFrom || got.Breaking != w.breaking {
+			t.Errorf("entry %d = %s from %q (breaking %v), want %s from %q (breaking %v)",
+				i, got.Version, got.UpgradeFrom, got.Breaking, w.version, w.upgradeFrom, w.breaking)
+		}
+		if w.upgradeFrom != "" && got.UpgradeHint == "" {
+			t.Errorf("entry %d has no upgrade hint", i)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package resources
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+)
+
+// DefaultListLimit is the page size of ListResourcesPage when none is given
+const DefaultListLimit = 100
+
+// ListableTypes are the resource types that can be enumerated, in list order.
+// READMEs and default values are only served through their URI templates.
+var ListableTypes = []ResourceType{
+	ResourceTypeApp,
+	ResourceTypeConfig,
+	ResourceTypeCatalog,
+	ResourceTypeCluster,
+	ResourceTypeSchema,
+	ResourceTypeChangelog,
+}
+
+// ListOptions selects a page of resources
+type ListOptions struct {
+	// Types restricts the listed resource types; all listable types when empty
+	Types []ResourceType
+
+	// Cursor continues a previous listing
+	Cursor string
+
+	// Limit is the maximum number of resources per page, DefaultListLimit when 0
+	Limit int
+}
+
+// ResourcePage is a page of resources and the cursor of the next one, which
+// is empty on the last page
+type ResourcePage struct {
+	Resources  []ResourceMetadata
+	NextCursor string
+}
+
+// ListResources returns all available resources
+func (p *Provider) ListResources(ctx context.Context) ([]ResourceMetadata, error) {
+	var resources []ResourceMetadata
+	opts := ListOptions{}
+	for {
+		page, err := p.ListResourcesPage(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, page.Resources...)
+		if page.NextCursor == "" {
+			return resources, nil
+		}
+		opts.Cursor = page.NextCursor
+	}
+}
+
+// ListResourcesPage returns a page of resources. Types are listed one at a
+// time and only when the page reaches them, and the Kubernetes objects behind
+// them are listed in chunks of the page size, so a page only reads the
+// objects it needs.
+func (p *Provider) ListResourcesPage(ctx context.Context, opts ListOptions) (*ResourcePage, error) {
+	return paginate(ctx, opts, p.listChunk)
+}
+
+// ParseResourceTypes parses a list of resource type names, as used in URI schemes
+func ParseResourceTypes(names []string) ([]ResourceType, error) {
+	types := make([]ResourceType, 0, len(names))
+	for _, name := range names {
+		t := ResourceType(strings.TrimSpace(name))
+		if !isListable(t) {
+			return nil, fmt.Errorf("unknown or unlistable resource type %q", name)
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
+
+// listChunkFunc lists at most limit Kubernetes objects of a resource type,
+// starting at a continue token, and returns the resources they provide and
+// the continue token of the next chunk, which is empty after the last one
+type listChunkFunc func(ctx context.Context, t ResourceType, continueToken string, limit int) ([]ResourceMetadata, string, error)
+
+func paginate(ctx context.Context, opts ListOptions, listChunk listChunkFunc) (*ResourcePage, error) {
+	types := opts.Types
+	if len(types) == 0 {
+		types = ListableTypes
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+
+	startType, continueToken, err := decodeCursor(opts.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resume at the type of the cursor
+	start := 0
+	if startType != "" {
+		start = slices.Index(types, startType)
+		if start < 0 {
+			return nil, fmt.Errorf("invalid cursor: resource type %s is not listed", startType)
+		}
+	}
+
+	page := &ResourcePage{Resources: make([]ResourceMetadata, 0)}
+	for i := start; i < len(types); i++ {
+		if i != start {
+			continueToken = ""
+		}
+
+		// Objects may provide no resource, keep listing until the page is full
+		for {
+			resources, next, err := listChunk(ctx, types[i], continueToken, limit-len(page.Resources))
+			if err != nil {
+				return nil, err
+			}
+			page.Resources = append(page.Resources, resources...)
+			continueToken = next
+			if continueToken == "" || len(page.Resources) >= limit {
+				break
+			}
+		}
+
+		if len(page.Resources) >= limit {
+			switch {
+			case continueToken != "":
+				page.NextCursor = encodeCursor(types[i], continueToken)
+			case i < len(types)-1:
+				page.NextCursor = encodeCursor(types[i+1], "")
+			}
+			break
+		}
+	}
+
+	return page, nil
+}
+
+// Cursors are base64 encoded "type:continue" pairs of the resource type to
+// list next and the Kubernetes continue token to list it from
+func encodeCursor(t ResourceType, continueToken string) string {
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", t, continueToken)))
+}
+
+func decodeCursor(cursor string) (ResourceType, string, error) {
+	if cursor == "" {
+		return "", "", nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid cursor: %w", err)
+	}
+	name, continueToken, found := strings.Cut(string(data), ":")
+	if !found || !isListable(ResourceType(name)) {
+		return "", "", fmt.Errorf("invalid cursor %q", cursor)
+	}
+
+	return ResourceType(name), continueToken, nil
+}
+
+func isListable(t ResourceType) bool {
+	return slices.Contains(ListableTypes, t)
+}
+
+// listChunk lists a chunk of the Kubernetes objects behind a resource type,
+// skipping objects of namespaces the provider hides. Changelogs are listed
+// from the entries app-operator labels as the latest version of their app,
+// so there is one per app.
+func (p *Provider) listChunk(ctx context.Context, t ResourceType, continueToken string, limit int) ([]ResourceMetadata, string, error) {
+	opts := metav1.ListOptions{Limit: int64(limit), Continue: continueToken}
+
+	var resource dynamic.ResourceInterface
+	switch t {
+	case ResourceTypeApp, ResourceTypeConfig:
+		resource = p.dynamicClient.Apps("")
+	case ResourceTypeCatalog:
+		resource = p.dynamicClient.Catalogs("")
+	case ResourceTypeCluster:
+		resource = p.dynamicClient.GetInterface().Resource(cluster.ClusterGVR)
+	case ResourceTypeSchema:
+		resource = p.dynamicClient.AppCatalogEntries("")
+	case ResourceTypeChangelog:
+		resource = p.dynamicClient.AppCatalogEntries("")
+		opts.LabelSelector = appcatalogentry.LatestLabel + "=true"
+	default:
+		return nil, "", fmt.Errorf("resource type %s cannot be listed", t)
+	}
+
+	list, err := resource.List(ctx, opts)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list %s resources: %w", t, err)
+	}
+
+	resources := make([]ResourceMetadata, 0, len(list.Items))
+	for i := range list.Items {
+		item := &list.Items[i]
+		if p.checkNamespace(item.GetNamespace()) != nil {
+			continue
+		}
+		r, ok, err := describe(t, item)
+		if err != nil {
+			return nil, "", err
+		}
+		if ok {
+			resources = append(resources, r)
+		}
+	}
+
+	return resources, list.GetContinue(), nil
+}
+
+// describe returns the resource of type t an object provides, if any
+func describe(t ResourceType, obj *unstructured.Unstructured) (ResourceMetadata, bool, error) {
+	switch t {
+	case ResourceTypeApp, ResourceTypeConfig:
+		a, err := app.NewAppFromUnstructured(obj)
+		if err != nil {
+			return ResourceMetadata{}, false, err
+		}
+		if t == ResourceTypeApp {
+			return ResourceMetadata{
+				URI:         fmt.Sprintf("app://%s/%s", a.Namespace, a.Name),
+				Name:        fmt.Sprintf("App: %s/%s", a.Namespace, a.Name),
+				Description: fmt.Sprintf("Giant Swarm app %s in namespace %s", a.Name, a.Namespace),
+				MimeType:    "application/json",
+			}, true, nil
+		}
+
+		// Only apps with configuration have a config resource
+		if a.Spec.Config == nil && a.Spec.UserConfig == nil {
+			return ResourceMetadata{}, false, nil
+		}
+		return ResourceMetadata{
+			URI:         fmt.Sprintf("config://%s/%s/values", a.Namespace, a.Name),
+			Name:        fmt.Sprintf("Config: %s/%s", a.Namespace, a.Name),
+			Description: fmt.Sprintf("Configuration values for app %s", a.Name),
+			MimeType:    "application/json",
+		}, true, nil
+
+	case ResourceTypeCatalog:
+		return ResourceMetadata{
+			URI:         fmt.Sprintf("catalog://%s", obj.GetName()),
+			Name:        fmt.Sprintf("Catalog: %s", obj.GetName()),
+			Description: fmt.Sprintf("Giant Swarm app catalog %s", obj.GetName()),
+			MimeType:    "application/json",
+		}, true, nil
+
+	case ResourceTypeCluster:
+		return ResourceMetadata{
+			URI:         fmt.Sprintf("cluster://%s/%s", obj.GetNamespace(), obj.GetName()),
+			Name:        fmt.Sprintf("Cluster: %s/%s", obj.GetNamespace(), obj.GetName()),
+			Description: fmt.Sprintf("Workload cluster %s in namespace %s", obj.GetName(), obj.GetNamespace()),
+			MimeType:    "application/json",
+		}, true, nil
+	}
+
+	entry, err := appcatalogentry.NewAppCatalogEntryFromUnstructured(obj)
+	if err != nil {
+		return ResourceMetadata{}, false, err
+	}
+	catalogName := entry.Spec.Catalog.Name
+	appName := entry.GetAppName()
+	if catalogName == "" || appName == "" {
+		return ResourceMetadata{}, false, nil
+	}
+
+	if t == ResourceTypeChangelog {
+		return ResourceMetadata{
+			URI:         fmt.Sprintf("changelog://%s/%s", catalogName, appName),
+			Name:        fmt.Sprintf("Changelog: %s/%s", catalogName, appName),
+			Description: fmt.Sprintf("Version history for %s", appName),
+			MimeType:    "application/json",
+		}, true, nil
+	}
+
+	// One schema per version
+	version := entry.Spec.Chart.Version
+	if version == "" {
+		return ResourceMetadata{}, false, nil
+	}
+	return ResourceMetadata{
+		URI:         fmt.Sprintf("schema://%s/%s/%s", catalogName, appName, version),
+		Name:        fmt.Sprintf("Schema: %s/%s@%s", catalogName, appName, version),
+		Description: fmt.Sprintf("Configuration schema for %s version %s", appName, version),
+		MimeType:    "application/json",
+	}, true, nil
+}
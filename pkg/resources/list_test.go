@@ -0,0 +1,121 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+	gstesting "github.com/giantswarm/mcp-giantswarm-apps/pkg/testing"
+)
+
+func TestPaginate(t *testing.T) {
+	counts := map[ResourceType]int{
+		ResourceTypeApp:     3,
+		ResourceTypeCatalog: 0,
+		ResourceTypeCluster: 2,
+	}
+	listed := make(map[ResourceType]int)
+	listChunk := func(_ context.Context, rt ResourceType, continueToken string, limit int) ([]ResourceMetadata, string, error) {
+		if limit <= 0 {
+			t.Fatalf("listed %d %s objects", limit, rt)
+		}
+		start, _ := strconv.Atoi(continueToken)
+		end := min(start+limit, counts[rt])
+		listed[rt] += end - start
+
+		resources := make([]ResourceMetadata, 0, end-start)
+		for i := start; i < end; i++ {
+			resources = append(resources, ResourceMetadata{URI: fmt.Sprintf("%s://%d", rt, i)})
+		}
+		if end == counts[rt] {
+			return resources, "", nil
+		}
+		return resources, strconv.Itoa(end), nil
+	}
+
+	opts := ListOptions{
+		Types: []ResourceType{ResourceTypeApp, ResourceTypeCatalog, ResourceTypeCluster},
+		Limit: 2,
+	}
+
+	// The first page must not enumerate types it does not reach
+	page, err := paginate(context.Background(), opts, listChunk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if listed[ResourceTypeApp] != 2 || listed[ResourceTypeCluster] != 0 {
+		t.Errorf("first page listed %v, want 2 apps only", listed)
+	}
+
+	var uris []string
+	for {
+		for _, r := range page.Resources {
+			uris = append(uris, r.URI)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		opts.Cursor = page.NextCursor
+		if page, err = paginate(context.Background(), opts, listChunk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if listed[ResourceTypeApp] != 3 || listed[ResourceTypeCluster] != 2 {
+		t.Errorf("listed %v, want every object once", listed)
+	}
+
+	want := []string{"app://0", "app://1", "app://2", "cluster://0", "cluster://1"}
+	if fmt.Sprint(uris) != fmt.Sprint(want) {
+		t.Errorf("listed %v, want %v", uris, want)
+	}
+
+	if _, err := paginate(context.Background(), ListOptions{Cursor: "not a cursor"}, listChunk); err == nil {
+		t.Error("expected an error for an invalid cursor")
+	}
+}
+
+func TestListResourcesRestrictedNamespaces(t *testing.T) {
+	hello := gstesting.CatalogEntry("giantswarm", "hello-world", "2.3.0")
+	hello.SetLabels(map[string]string{appcatalogentry.LatestLabel: "true"})
+	internal := gstesting.CatalogEntry("acme-internal", "billing-api", "1.4.0")
+	internal.SetNamespace("org-globex")
+	internal.SetLabels(map[string]string{appcatalogentry.LatestLabel: "true"})
+
+	k8sClient, dynamicClient := gstesting.NewClients(
+		gstesting.DeployedApp("org-acme", "hello-world", "giantswarm", "2.3.0"),
+		gstesting.DeployedApp("org-globex", "hello-world", "giantswarm", "2.3.0"),
+		gstesting.ProvisionedCluster("globex", "prod", "30.0.0"),
+		hello,
+		gstesting.CatalogEntry("giantswarm", "hello-world", "2.2.0"),
+		internal,
+	)
+	provider := NewProvider(k8sClient, dynamicClient)
+	provider.RestrictNamespaces(func(namespace string) bool { return namespace != "org-globex" })
+
+	resources, err := provider.ListResources(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var uris []string
+	for _, r := range resources {
+		uris = append(uris, r.URI)
+	}
+	want := []string{
+		"app://org-acme/hello-world",
+		"schema://giantswarm/hello-world/2.2.0",
+		"schema://giantswarm/hello-world/2.3.0",
+		"changelog://giantswarm/hello-world",
+	}
+	if fmt.Sprint(uris) != fmt.Sprint(want) {
+		t.Errorf("listed %v, want %v", uris, want)
+	}
+
+	if _, err := provider.GetResource(context.Background(), "schema://acme-internal/billing-api/1.4.0"); err == nil || !strings.Contains(err.Error(), "not allowed") {
+		t.Errorf("reading the schema of a hidden catalog: error = %v, want not allowed", err)
+	}
+}
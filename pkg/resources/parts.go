@@ -0,0 +1,42 @@
+package resources
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PartParameter is the query parameter of a resource URI selecting one part
+// of a resource that is too large to read at once, e.g.
+// readme://giantswarm/nginx-ingress-controller-app/3.0.0?part=2
+const PartParameter = "part"
+
+// SplitPart splits the part query off a resource URI. part is 0 when the URI
+// selects the whole resource.
+func SplitPart(uri string) (string, int, error) {
+	base, query, found := strings.Cut(uri, "?")
+	if !found {
+		return uri, 0, nil
+	}
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid query of resource URI %s: %w", uri, err)
+	}
+	for name := range values {
+		if name != PartParameter {
+			return "", 0, fmt.Errorf("unknown query parameter %q of resource URI %s", name, uri)
+		}
+	}
+
+	value := values.Get(PartParameter)
+	if value == "" {
+		return base, 0, nil
+	}
+	part, err := strconv.Atoi(value)
+	if err != nil || part < 1 {
+		return "", 0, fmt.Errorf("invalid part %q of resource URI %s: must be a positive number", value, uri)
+	}
+	return base, part, nil
+}
@@ -0,0 +1,30 @@
+package resources
+
+import "testing"
+
+func TestSplitPart(t *testing.T) {
+	tests := []struct {
+		uri      string
+		wantBase string
+		wantPart int
+		wantErr  bool
+	}{
+		{uri: "readme://giantswarm/nginx/1.0.0", wantBase: "readme://giantswarm/nginx/1.0.0"},
+		{uri: "readme://giantswarm/nginx/1.0.0?part=2", wantBase: "readme://giantswarm/nginx/1.0.0", wantPart: 2},
+		{uri: "config://org-acme/nginx/values?part=", wantBase: "config://org-acme/nginx/values"},
+		{uri: "readme://giantswarm/nginx/1.0.0?part=0", wantErr: true},
+		{uri: "readme://giantswarm/nginx/1.0.0?part=x", wantErr: true},
+		{uri: "readme://giantswarm/nginx/1.0.0?offset=10", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		base, part, err := SplitPart(tt.uri)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("SplitPart(%s) error = %v, wantErr %v", tt.uri, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && (base != tt.wantBase || part != tt.wantPart) {
+			t.Errorf("SplitPart(%s) = %s, %d; want %s, %d", tt.uri, base, part, tt.wantBase, tt.wantPart)
+		}
+	}
+}
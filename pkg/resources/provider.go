@@ -2,17 +2,17 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 
-	"k8s.io/apimachinery/pkg/labels"
-	"sigs.k8s.io/yaml"
-
 	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
 	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
 	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
 	"github.com/giantswarm/mcp-giantswarm-apps/pkg/catalog"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
 	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
 )
 
@@ -24,105 +24,45 @@ type Provider struct {
 	catalogClient         *catalog.Client
 	appCatalogEntryClient *appcatalogentry.Client
 	configClient          *config.Client
+	clusterClient         *cluster.Client
+
+	// allows reports whether resources of a namespace may be read and
+	// listed; nil allows all namespaces
+	allows func(namespace string) bool
 }
 
 // NewProvider creates a new resource provider
 func NewProvider(k8sClient *k8s.Client, dynamicClient *k8s.DynamicClient) *Provider {
+	appClient := app.NewClient(dynamicClient)
 	return &Provider{
 		k8sClient:             k8sClient,
 		dynamicClient:         dynamicClient,
-		appClient:             app.NewClient(dynamicClient),
+		appClient:             appClient,
 		catalogClient:         catalog.NewClient(dynamicClient),
 		appCatalogEntryClient: appcatalogentry.NewClient(dynamicClient),
 		configClient:          config.NewClient(k8sClient),
+		clusterClient:         cluster.NewClient(dynamicClient, k8sClient, appClient),
 	}
 }
 
-// ListResources returns a list of available resources
-func (p *Provider) ListResources(ctx context.Context) ([]ResourceMetadata, error) {
-	var resources []ResourceMetadata
-
-	// List all apps across namespaces
-	apps, err := p.appClient.List(ctx, "", labels.Everything().String())
-	if err != nil {
-		return nil, fmt.Errorf("failed to list apps: %w", err)
-	}
-
-	for _, app := range apps {
-		// Add app resource
-		resources = append(resources, ResourceMetadata{
-			URI:         fmt.Sprintf("app://%s/%s", app.Namespace, app.Name),
-			Name:        fmt.Sprintf("App: %s/%s", app.Namespace, app.Name),
-			Description: fmt.Sprintf("Giant Swarm app %s in namespace %s", app.Name, app.Namespace),
-			MimeType:    "application/json",
-		})
-
-		// Add config resource if app has configuration
-		if app.Spec.Config != nil || app.Spec.UserConfig != nil {
-			resources = append(resources, ResourceMetadata{
-				URI:         fmt.Sprintf("config://%s/%s/values", app.Namespace, app.Name),
-				Name:        fmt.Sprintf("Config: %s/%s", app.Namespace, app.Name),
-				Description: fmt.Sprintf("Configuration values for app %s", app.Name),
-				MimeType:    "application/json",
-			})
-		}
-	}
-
-	// List all catalogs
-	catalogs, err := p.catalogClient.List(ctx, "")
-	if err != nil {
-		return nil, fmt.Errorf("failed to list catalogs: %w", err)
-	}
-
-	for _, catalog := range catalogs {
-		resources = append(resources, ResourceMetadata{
-			URI:         fmt.Sprintf("catalog://%s", catalog.Name),
-			Name:        fmt.Sprintf("Catalog: %s", catalog.Name),
-			Description: fmt.Sprintf("Giant Swarm app catalog %s", catalog.Name),
-			MimeType:    "application/json",
-		})
-	}
-
-	// List app catalog entries for schema and changelog resources
-	entries, err := p.appCatalogEntryClient.List(ctx, labels.Everything().String())
-	if err != nil {
-		return nil, fmt.Errorf("failed to list app catalog entries: %w", err)
-	}
+// UseAppCatalogEntryIndex serves catalog entry lookups from index
+func (p *Provider) UseAppCatalogEntryIndex(index *appcatalogentry.Index) {
+	p.appCatalogEntryClient.WithIndex(index)
+}
 
-	// Group entries by app name and catalog
-	appMap := make(map[string]*appcatalogentry.AppCatalogEntry)
-	for _, entry := range entries {
-		// Parse the name to get catalog and app name
-		parts := strings.Split(entry.Name, "-")
-		if len(parts) >= 2 {
-			catalogName := parts[0]
-			appName := strings.Join(parts[1:len(parts)-1], "-")
-
-			// Add schema resource for each version
-			if entry.Spec.Chart.Version != "" {
-				resources = append(resources, ResourceMetadata{
-					URI:         fmt.Sprintf("schema://%s/%s/%s", catalogName, appName, entry.Spec.Chart.Version),
-					Name:        fmt.Sprintf("Schema: %s/%s@%s", catalogName, appName, entry.Spec.Chart.Version),
-					Description: fmt.Sprintf("Configuration schema for %s version %s", appName, entry.Spec.Chart.Version),
-					MimeType:    "application/json",
-				})
-			}
+// RestrictNamespaces hides the resources of namespaces allows rejects.
+// Catalogs, schemas, changelogs, READMEs and default values belong to the
+// namespace of their catalog.
+func (p *Provider) RestrictNamespaces(allows func(namespace string) bool) {
+	p.allows = allows
+}
 
-			// Keep track of unique apps for changelog
-			key := fmt.Sprintf("%s/%s", catalogName, appName)
-			if _, exists := appMap[key]; !exists {
-				appMap[key] = entry
-				resources = append(resources, ResourceMetadata{
-					URI:         fmt.Sprintf("changelog://%s/%s", catalogName, appName),
-					Name:        fmt.Sprintf("Changelog: %s/%s", catalogName, appName),
-					Description: fmt.Sprintf("Version history for %s", appName),
-					MimeType:    "application/json",
-				})
-			}
-		}
+// checkNamespace fails for namespaces whose resources are hidden
+func (p *Provider) checkNamespace(namespace string) error {
+	if p.allows != nil && !p.allows(namespace) {
+		return fmt.Errorf("namespace %s is not allowed on this server", namespace)
 	}
-
-	return resources, nil
+	return nil
 }
 
 // GetResource fetches the content of a specific resource
@@ -132,6 +72,13 @@ func (p *Provider) GetResource(ctx context.Context, uri string) (interface{}, er
 		return nil, err
 	}
 
+	switch resourceURI.Type {
+	case ResourceTypeApp, ResourceTypeConfig, ResourceTypeCluster:
+		if err := p.checkNamespace(resourceURI.Namespace); err != nil {
+			return nil, err
+		}
+	}
+
 	switch resourceURI.Type {
 	case ResourceTypeApp:
 		return p.getAppResource(ctx, resourceURI)
@@ -143,6 +90,14 @@ func (p *Provider) GetResource(ctx context.Context, uri string) (interface{}, er
 		return p.getSchemaResource(ctx, resourceURI)
 	case ResourceTypeChangelog:
 		return p.getChangelogResource(ctx, resourceURI)
+	case ResourceTypeReadme:
+		return p.getReadmeResource(ctx, resourceURI)
+	case ResourceTypeValues:
+		return p.getValuesResource(ctx, resourceURI)
+	case ResourceTypeCluster:
+		return p.getClusterResource(ctx, resourceURI)
+	case ResourceTypeReleaseNotes:
+		return p.getReleaseNotesResource(ctx, resourceURI)
 	default:
 		return nil, fmt.Errorf("unknown resource type: %s", resourceURI.Type)
 	}
@@ -199,6 +154,9 @@ func (p *Provider) getCatalogResource(ctx context.Context, uri *ResourceURI) (*C
 	if err != nil {
 		return nil, fmt.Errorf("failed to get catalog: %w", err)
 	}
+	if err := p.checkNamespace(catalog.Namespace); err != nil {
+		return nil, err
+	}
 
 	content := &CatalogResourceContent{
 		Name:        uri.Name,
@@ -214,15 +172,9 @@ func (p *Provider) getCatalogResource(ctx context.Context, uri *ResourceURI) (*C
 	}
 
 	// Count apps in this catalog
-	entries, err := p.appCatalogEntryClient.List(ctx, labels.Everything().String())
-	if err == nil {
-		count := 0
-		for _, entry := range entries {
-			if strings.HasPrefix(entry.Name, uri.Name+"-") {
-				count++
-			}
-		}
-		content.AppCount = count
+	if count, err := p.appCatalogEntryClient.CountByCatalog(ctx, catalog.Name, catalog.Namespace); err == nil {
+		content.AppCount = count.Apps
+		content.EntryCount = count.Entries
 	}
 
 	// Get timestamp
@@ -237,7 +189,7 @@ func (p *Provider) getCatalogResource(ctx context.Context, uri *ResourceURI) (*C
 
 func (p *Provider) getConfigResource(ctx context.Context, uri *ResourceURI) (*ConfigResourceContent, error) {
 	// Get the app to find its config references
-	app, err := p.appClient.Get(ctx, uri.Namespace, uri.Name)
+	a, err := p.appClient.Get(ctx, uri.Namespace, uri.Name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get app: %w", err)
 	}
@@ -246,44 +198,64 @@ func (p *Provider) getConfigResource(ctx context.Context, uri *ResourceURI) (*Co
 		AppName:   uri.Name,
 		Namespace: uri.Namespace,
 		Values:    make(map[string]interface{}),
+		Merged:    make(map[string]interface{}),
+		Sources:   []ConfigSource{},
 	}
 
-	// Get user config
-	if app.Spec.UserConfig != nil {
-		if app.Spec.UserConfig.ConfigMap != nil {
-			cm, err := p.configClient.GetConfigMap(ctx, uri.Namespace, app.Spec.UserConfig.ConfigMap.Name)
-			if err == nil && cm.Data != nil {
-				for k, v := range cm.Data {
-					// Try to parse as YAML/JSON
-					var parsed interface{}
-					if err := yaml.Unmarshal([]byte(v), &parsed); err == nil {
-						content.Values[k] = parsed
-					} else {
-						content.Values[k] = v
-					}
-				}
+	layers := []struct {
+		name     string
+		config   *app.AppConfig
+		priority int
+	}{
+		{"config", a.Spec.Config, app.ConfigPriority},
+		{"userConfig", a.Spec.UserConfig, app.UserConfigPriority},
+	}
+
+	// Extra configs are merged before the layers of higher priority
+	extraConfigs := make([]app.ExtraConfig, len(a.Spec.ExtraConfigs))
+	copy(extraConfigs, a.Spec.ExtraConfigs)
+	sort.SliceStable(extraConfigs, func(i, j int) bool {
+		return extraConfigs[i].Priority < extraConfigs[j].Priority
+	})
+	addExtraConfigs := func(below int) {
+		for len(extraConfigs) > 0 && extraConfigs[0].Priority < below {
+			ec := extraConfigs[0]
+			extraConfigs = extraConfigs[1:]
+			namespace := refNamespace(ec.Namespace, uri.Namespace)
+			if ec.Kind == app.ExtraConfigKindSecret {
+				secret, err := p.configClient.GetSecret(ctx, namespace, ec.Name)
+				content.addSources("extraConfig", "Secret", namespace, ec.Name, secret, err)
+			} else {
+				cm, err := p.configClient.GetConfigMap(ctx, namespace, ec.Name)
+				content.addSources("extraConfig", "ConfigMap", namespace, ec.Name, cm, err)
+			}
+		}
+	}
+
+	for _, layer := range layers {
+		addExtraConfigs(layer.priority)
+		if layer.config == nil {
+			continue
+		}
+		if ref := layer.config.ConfigMap; ref != nil {
+			cm, err := p.configClient.GetConfigMap(ctx, refNamespace(ref.Namespace, uri.Namespace), ref.Name)
+			content.addSources(layer.name, "ConfigMap", refNamespace(ref.Namespace, uri.Namespace), ref.Name, cm, err)
+			if err == nil && layer.name == "userConfig" {
 				content.Source = "configmap"
 			}
 		}
-		if app.Spec.UserConfig.Secret != nil {
-			secret, err := p.configClient.GetSecret(ctx, uri.Namespace, app.Spec.UserConfig.Secret.Name)
-			if err == nil && secret.Data != nil {
-				for k, v := range secret.Data {
-					// Try to parse as YAML/JSON
-					var parsed interface{}
-					if err := yaml.Unmarshal([]byte(v), &parsed); err == nil {
-						content.Values[k] = parsed
-					} else {
-						content.Values[k] = v
-					}
-				}
+		if ref := layer.config.Secret; ref != nil {
+			secret, err := p.configClient.GetSecret(ctx, refNamespace(ref.Namespace, uri.Namespace), ref.Name)
+			content.addSources(layer.name, "Secret", refNamespace(ref.Namespace, uri.Namespace), ref.Name, secret, err)
+			if err == nil && layer.name == "userConfig" {
 				content.Source = "secret"
 			}
 		}
 	}
+	addExtraConfigs(math.MaxInt)
 
 	// Get last update time
-	unstructuredApp := app.ToUnstructured()
+	unstructuredApp := a.ToUnstructured()
 	metadata := unstructuredApp.Object["metadata"].(map[string]interface{})
 	if metadata["creationTimestamp"] != nil {
 		content.LastUpdate = metadata["creationTimestamp"].(string)
@@ -292,67 +264,128 @@ func (p *Provider) getConfigResource(ctx context.Context, uri *ResourceURI) (*Co
 	return content, nil
 }
 
-func (p *Provider) getSchemaResource(ctx context.Context, uri *ResourceURI) (*SchemaResourceContent, error) {
-	// Find the app catalog entry for the specific version
-	entries, err := p.appCatalogEntryClient.List(ctx, labels.Everything().String())
+// addSources adds a source per key of a config object and merges their
+// values. The values of the user config are also kept per key.
+func (c *ConfigResourceContent) addSources(layer, kind, namespace, name string, cfg *config.Config, err error) {
 	if err != nil {
-		return nil, fmt.Errorf("failed to list app catalog entries: %w", err)
+		c.Sources = append(c.Sources, ConfigSource{Layer: layer, Kind: kind, Namespace: namespace, Name: name, Error: err.Error()})
+		return
+	}
+
+	keys := make([]string, 0, len(cfg.Data))
+	for key := range cfg.Data {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
 
-	var targetEntry *appcatalogentry.AppCatalogEntry
-	searchName := fmt.Sprintf("%s-%s-%s", uri.Catalog, uri.Name, uri.Version)
+	for _, key := range keys {
+		raw := cfg.Data[key]
+		source := ConfigSource{Layer: layer, Kind: kind, Namespace: namespace, Name: name, Key: key, Raw: raw}
 
-	for _, entry := range entries {
-		if entry.Name == searchName {
-			targetEntry = entry
-			break
+		values, documents, err := config.ParseValues(raw)
+		source.Documents = documents
+		if err != nil {
+			source.Error = err.Error()
+		} else {
+			source.Parsed = values
+			c.Merged = config.MergeValues(c.Merged, values)
+		}
+		c.Sources = append(c.Sources, source)
+
+		if layer == "userConfig" {
+			if err == nil {
+				c.Values[key] = values
+			} else {
+				c.Values[key] = raw
+			}
 		}
 	}
+}
 
-	if targetEntry == nil {
-		return nil, fmt.Errorf("app catalog entry not found for %s/%s@%s", uri.Catalog, uri.Name, uri.Version)
+// refNamespace returns the namespace of a config reference, which defaults
+// to the namespace of the app
+func refNamespace(namespace, appNamespace string) string {
+	if namespace == "" {
+		return appNamespace
+	}
+	return namespace
+}
+
+// findVersion returns the catalog entry of the app version of uri
+func (p *Provider) findVersion(ctx context.Context, uri *ResourceURI) (*appcatalogentry.AppCatalogEntry, error) {
+	entry, err := p.appCatalogEntryClient.FindVersion(ctx, uri.Catalog, uri.Name, uri.Version)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkNamespace(entry.Namespace); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (p *Provider) getSchemaResource(ctx context.Context, uri *ResourceURI) (*SchemaResourceContent, error) {
+	entry, err := p.findVersion(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := appcatalogentry.FetchChart(ctx, entry)
+	if err != nil {
+		return nil, err
 	}
 
 	content := &SchemaResourceContent{
 		AppName: uri.Name,
 		Version: uri.Version,
-		Schema:  make(map[string]interface{}),
-	}
-
-	// For now, generate a basic schema from the chart metadata
-	// In a real implementation, this would fetch the actual values schema
-	content.Schema = map[string]interface{}{
-		"type":        "object",
-		"title":       targetEntry.Spec.Chart.Name,
-		"description": targetEntry.Spec.Chart.Description,
-		"properties": map[string]interface{}{
-			"replicaCount": map[string]interface{}{
-				"type":        "integer",
-				"description": "Number of replicas",
-				"default":     1,
-			},
-			"image": map[string]interface{}{
-				"type": "object",
-				"properties": map[string]interface{}{
-					"repository": map[string]interface{}{
-						"type":        "string",
-						"description": "Image repository",
-					},
-					"tag": map[string]interface{}{
-						"type":        "string",
-						"description": "Image tag",
-					},
-				},
-			},
-		},
 	}
 
+	if data, ok := files.ValuesSchema(); ok {
+		if err := json.Unmarshal(data, &content.Schema); err != nil {
+			return nil, fmt.Errorf("failed to parse values schema of %s/%s@%s: %w", uri.Catalog, uri.Name, uri.Version, err)
+		}
+		if required, ok := content.Schema["required"].([]interface{}); ok {
+			for _, r := range required {
+				if name, ok := r.(string); ok {
+					content.Required = append(content.Required, name)
+				}
+			}
+		}
+	}
+
+	docs, err := files.ValuesDocs()
+	if err != nil {
+		return nil, fmt.Errorf("%s/%s@%s: %w", uri.Catalog, uri.Name, uri.Version, err)
+	}
+	content.Documentation = docs
+	describeSchema(content.Schema, docs)
+
 	return content, nil
 }
 
+// describeSchema adds the descriptions of documented values to the schema
+// properties that have none
+func describeSchema(schema map[string]interface{}, docs []appcatalogentry.ValueDoc) {
+	for _, doc := range docs {
+		property := schema
+		for _, name := range strings.Split(doc.Key, ".") {
+			properties, _ := property["properties"].(map[string]interface{})
+			property, _ = properties[name].(map[string]interface{})
+			if property == nil {
+				break
+			}
+		}
+		if property == nil || doc.Description == "" {
+			continue
+		}
+		if _, ok := property["description"]; !ok {
+			property["description"] = doc.Description
+		}
+	}
+}
+
 func (p *Provider) getChangelogResource(ctx context.Context, uri *ResourceURI) (*ChangelogResourceContent, error) {
 	// List all versions of this app
-	entries, err := p.appCatalogEntryClient.List(ctx, labels.Everything().String())
+	entries, err := p.appCatalogEntryClient.ListByCatalog(ctx, uri.Catalog, "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to list app catalog entries: %w", err)
 	}
@@ -364,78 +397,84 @@ func (p *Provider) getChangelogResource(ctx context.Context, uri *ResourceURI) (
 	}
 
 	// Filter entries for this app
-	appPrefix := fmt.Sprintf("%s-%s-", uri.Catalog, uri.Name)
-	var versions []struct {
-		version     string
-		date        string
-		description string
-		entry       *appcatalogentry.AppCatalogEntry
+	versions := make([]*appcatalogentry.AppCatalogEntry, 0)
+	for _, entry := range entries {
+		if entry.MatchesApp(uri.Name) && p.checkNamespace(entry.Namespace) == nil {
+			versions = append(versions, entry)
+		}
 	}
+	content.Entries = buildChangelog(versions)
 
-	for _, entry := range entries {
-		if strings.HasPrefix(entry.Name, appPrefix) {
-			item := struct {
-				version     string
-				date        string
-				description string
-				entry       *appcatalogentry.AppCatalogEntry
-			}{
-				version: entry.Spec.Chart.Version,
-				entry:   entry,
-			}
+	return content, nil
+}
 
-			// Extract date
-			if entry.Spec.DateCreated != nil {
-				item.date = entry.Spec.DateCreated.Format("2006-01-02")
-			}
+func (p *Provider) getReadmeResource(ctx context.Context, uri *ResourceURI) (*ReadmeResourceContent, error) {
+	entry, err := p.findVersion(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
 
-			// Extract description
-			if entry.Spec.Chart.Description != "" {
-				item.description = entry.Spec.Chart.Description
-			}
+	files, err := appcatalogentry.FetchChart(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
 
-			versions = append(versions, item)
-		}
+	readme, err := files.Readme()
+	if err != nil {
+		return nil, fmt.Errorf("%s/%s@%s: %w", uri.Catalog, uri.Name, uri.Version, err)
 	}
 
-	// Sort versions by date (newest first)
-	sort.Slice(versions, func(i, j int) bool {
-		return versions[i].date > versions[j].date
-	})
+	return &ReadmeResourceContent{
+		AppName: uri.Name,
+		Catalog: uri.Catalog,
+		Version: uri.Version,
+		Content: readme,
+	}, nil
+}
 
-	// Convert to changelog entries
-	for i, v := range versions {
-		entry := ChangelogEntry{
-			Version:     v.version,
-			Date:        v.date,
-			Description: v.description,
-		}
+func (p *Provider) getValuesResource(ctx context.Context, uri *ResourceURI) (*ValuesResourceContent, error) {
+	entry, err := p.findVersion(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
 
-		// Check if it's a breaking change (major version bump)
-		if i < len(versions)-1 {
-			nextVersion := versions[i+1].version
-			if isBreakingChange(v.version, nextVersion) {
-				entry.Breaking = true
-			}
-		}
+	files, err := appcatalogentry.FetchChart(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
 
-		content.Entries = append(content.Entries, entry)
+	content := &ValuesResourceContent{
+		AppName: uri.Name,
+		Catalog: uri.Catalog,
+		Version: uri.Version,
+		Values:  map[string]interface{}{},
+	}
+	if data, ok := files.DefaultValues(); ok {
+		if content.Values, _, err = config.ParseValues(string(data)); err != nil {
+			return nil, fmt.Errorf("failed to parse values.yaml of %s/%s@%s: %w", uri.Catalog, uri.Name, uri.Version, err)
+		}
 	}
 
 	return content, nil
 }
 
-// isBreakingChange checks if version change is breaking (major version bump)
-func isBreakingChange(newVersion, oldVersion string) bool {
-	// Simple check: if major version changed
-	newParts := strings.Split(newVersion, ".")
-	oldParts := strings.Split(oldVersion, ".")
-
-	if len(newParts) > 0 && len(oldParts) > 0 {
-		newMajor := strings.TrimPrefix(newParts[0], "v")
-		oldMajor := strings.TrimPrefix(oldParts[0], "v")
-		return newMajor != oldMajor
-	}
-
-	return false
+// getClusterResource returns the status summary of a workload cluster, the
+// resource linked by cluster_get
+func (p *Provider) getClusterResource(ctx context.Context, uri *ResourceURI) (*ClusterResourceContent, error) {
+	cl, err := p.clusterClient.Get(ctx, uri.Namespace, uri.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	return &ClusterResourceContent{
+		Name:                cl.Name,
+		Namespace:           cl.Namespace,
+		Organization:        cl.GetOrganization(),
+		Provider:            cl.GetProvider(),
+		Phase:               cl.Status.Phase,
+		Ready:               cl.IsReady(),
+		InfrastructureReady: cl.Status.InfrastructureReady,
+		ControlPlaneReady:   cl.Status.ControlPlaneReady,
+		Labels:              cl.Labels,
+	}, nil
 }
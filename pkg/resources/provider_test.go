@@ -0,0 +1,75 @@
+package resources
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+)
+
+func TestConfigSources(t *testing.T) {
+	content := &ConfigResourceContent{
+		Values: make(map[string]interface{}),
+		Merged: make(map[string]interface{}),
+	}
+
+	content.addSources("config", "ConfigMap", "org-acme", "cluster-values", &config.Config{Data: map[string]string{
+		"values": "baseDomain: example.com\nreplicas: 1\n",
+	}}, nil)
+	content.addSources("userConfig", "ConfigMap", "org-acme", "nginx-user-values", &config.Config{Data: map[string]string{
+		"values":   "replicas: 2\n---\nreplicas: 3\n",
+		"extra":    "debug: true\n",
+		"template": "replicas: [\n",
+	}}, nil)
+	content.addSources("userConfig", "Secret", "org-acme", "nginx-secrets", nil, errors.New("not found"))
+
+	if len(content.Sources) != 5 {
+		t.Fatalf("got %d sources, want 5: %+v", len(content.Sources), content.Sources)
+	}
+
+	// Keys are merged in sorted order after the cluster config
+	if content.Sources[1].Key != "extra" || content.Sources[3].Key != "values" || content.Sources[3].Documents != 2 {
+		t.Errorf("user config sources = %+v", content.Sources[1:4])
+	}
+	if content.Merged["baseDomain"] != "example.com" || content.Merged["replicas"] != float64(3) || content.Merged["debug"] != true {
+		t.Errorf("merged = %v", content.Merged)
+	}
+
+	if content.Sources[2].Error == "" || content.Values["template"] != "replicas: [\n" {
+		t.Errorf("invalid key: source %+v, value %v", content.Sources[2], content.Values["template"])
+	}
+	if content.Sources[4].Error != "not found" || content.Sources[4].Name != "nginx-secrets" {
+		t.Errorf("missing secret source = %+v", content.Sources[4])
+	}
+	if _, ok := content.Values["values"].(map[string]interface{}); !ok {
+		t.Errorf("user config values are not parsed: %v", content.Values)
+	}
+}
+
+func TestDescribeSchema(t *testing.T) {
+	schema := map[string]interface{}{
+		"properties": map[string]interface{}{
+			"replicaCount": map[string]interface{}{"type": "integer"},
+			"image": map[string]interface{}{
+				"properties": map[string]interface{}{
+					"tag": map[string]interface{}{"type": "string", "description": "From the schema"},
+				},
+			},
+		},
+	}
+	describeSchema(schema, []appcatalogentry.ValueDoc{
+		{Key: "replicaCount", Description: "Number of replicas"},
+		{Key: "image.tag", Description: "Image tag"},
+		{Key: "missing.key", Description: "Not in the schema"},
+	})
+
+	properties := schema["properties"].(map[string]interface{})
+	if got := properties["replicaCount"].(map[string]interface{})["description"]; got != "Number of replicas" {
+		t.Errorf("replicaCount description = %v", got)
+	}
+	tag := properties["image"].(map[string]interface{})["properties"].(map[string]interface{})["tag"].(map[string]interface{})
+	if got := tag["description"]; got != "From the schema" {
+		t.Errorf("image.tag description = %v, want the schema's own", got)
+	}
+}
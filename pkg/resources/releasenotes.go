@@ -0,0 +1,112 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// maxReleaseFileSize limits the size of a downloaded release file
+const maxReleaseFileSize = 1 << 20
+
+// releasesBaseURL serves the files of the giantswarm/releases repository,
+// which has a {provider}/v{version} directory per platform release
+var releasesBaseURL = "https://raw.githubusercontent.com/giantswarm/releases/master"
+
+// releasesWebURL is the browsable location of the giantswarm/releases repository
+const releasesWebURL = "https://github.com/giantswarm/releases/tree/master"
+
+// releaseHTTPClient is used to download release notes
+var releaseHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// releaseProviderAliases maps infrastructure provider names to the directory
+// of their releases
+var releaseProviderAliases = map[string]string{
+	"aws":   "capa",
+	"azure": "capz",
+	"vcd":   "cloud-director",
+}
+
+// ReleaseProviderDir returns the releases repository directory of a provider
+func ReleaseProviderDir(provider string) string {
+	provider = strings.ToLower(provider)
+	if dir, ok := releaseProviderAliases[provider]; ok {
+		return dir
+	}
+	return provider
+}
+
+// releaseManifest is the subset of a Release resource listed in release notes
+type releaseManifest struct {
+	Spec struct {
+		Date       string             `json:"date"`
+		State      string             `json:"state"`
+		Components []ReleaseComponent `json:"components"`
+		Apps       []ReleaseComponent `json:"apps"`
+	} `json:"spec"`
+}
+
+func (p *Provider) getReleaseNotesResource(ctx context.Context, uri *ResourceURI) (*ReleaseNotesResourceContent, error) {
+	dir := fmt.Sprintf("%s/v%s", ReleaseProviderDir(uri.Name), strings.TrimPrefix(uri.Version, "v"))
+
+	notes, err := fetchReleaseFile(ctx, dir+"/README.md")
+	if err != nil {
+		return nil, err
+	}
+
+	content := &ReleaseNotesResourceContent{
+		Provider: uri.Name,
+		Version:  strings.TrimPrefix(uri.Version, "v"),
+		URL:      releasesWebURL + "/" + dir,
+		Notes:    string(notes),
+	}
+
+	// The Release manifest is optional, older releases do not have one
+	if data, err := fetchReleaseFile(ctx, dir+"/release.yaml"); err == nil {
+		var manifest releaseManifest
+		if err := yaml.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse release %s: %w", dir, err)
+		}
+		content.Date = manifest.Spec.Date
+		content.State = manifest.Spec.State
+		content.Components = manifest.Spec.Components
+		content.Apps = manifest.Spec.Apps
+	}
+
+	return content, nil
+}
+
+// fetchReleaseFile downloads a file from the releases repository
+func fetchReleaseFile(ctx context.Context, path string) ([]byte, error) {
+	url := releasesBaseURL + "/" + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid release URL %s: %w", url, err)
+	}
+
+	resp, err := releaseHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("release notes not found: %s", path)
+	default:
+		return nil, fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxReleaseFileSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+
+	return data, nil
+}
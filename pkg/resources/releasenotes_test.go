@@ -0,0 +1,48 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetReleaseNotesResource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/capa/v25.1.0/README.md":
+			_, _ = w.Write([]byte("# :zap: Giant Swarm Release v25.1.0 for CAPA :zap:\n"))
+		case "/capa/v25.1.0/release.yaml":
+			_, _ = w.Write([]byte("spec:\n  date: \"2024-06-01T12:00:00Z\"\n  state: active\n  components:\n  - name: kubernetes\n    version: 1.25.16\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	previous := releasesBaseURL
+	releasesBaseURL = srv.URL
+	defer func() { releasesBaseURL = previous }()
+
+	p := &Provider{}
+	uri, err := ParseResourceURI("releasenotes://aws/v25.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := p.getReleaseNotesResource(context.Background(), uri)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content.Version != "25.1.0" || content.State != "active" || content.URL != releasesWebURL+"/capa/v25.1.0" {
+		t.Errorf("content = %+v", content)
+	}
+	if len(content.Components) != 1 || content.Components[0].Version != "1.25.16" {
+		t.Errorf("components = %v", content.Components)
+	}
+
+	uri.Version = "1.0.0"
+	if _, err := p.getReleaseNotesResource(context.Background(), uri); err == nil {
+		t.Error("missing release succeeded, want error")
+	}
+}
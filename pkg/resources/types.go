@@ -3,17 +3,23 @@ package resources
 import (
 	"fmt"
 	"strings"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
 )
 
 // ResourceType represents the type of resource
 type ResourceType string
 
 const (
-	ResourceTypeApp       ResourceType = "app"
-	ResourceTypeCatalog   ResourceType = "catalog"
-	ResourceTypeConfig    ResourceType = "config"
-	ResourceTypeSchema    ResourceType = "schema"
-	ResourceTypeChangelog ResourceType = "changelog"
+	ResourceTypeApp          ResourceType = "app"
+	ResourceTypeCatalog      ResourceType = "catalog"
+	ResourceTypeConfig       ResourceType = "config"
+	ResourceTypeSchema       ResourceType = "schema"
+	ResourceTypeChangelog    ResourceType = "changelog"
+	ResourceTypeReadme       ResourceType = "readme"
+	ResourceTypeCluster      ResourceType = "cluster"
+	ResourceTypeReleaseNotes ResourceType = "releasenotes"
+	ResourceTypeValues       ResourceType = "values"
 )
 
 // ResourceURI represents a parsed resource URI
@@ -49,6 +55,14 @@ func ParseResourceURI(uri string) (*ResourceURI, error) {
 		resourceType = ResourceTypeSchema
 	case "changelog":
 		resourceType = ResourceTypeChangelog
+	case "readme":
+		resourceType = ResourceTypeReadme
+	case "cluster":
+		resourceType = ResourceTypeCluster
+	case "releasenotes":
+		resourceType = ResourceTypeReleaseNotes
+	case "values":
+		resourceType = ResourceTypeValues
 	default:
 		return nil, fmt.Errorf("unknown resource type: %s", scheme)
 	}
@@ -103,6 +117,40 @@ func ParseResourceURI(uri string) (*ResourceURI, error) {
 		}
 		result.Catalog = pathParts[0]
 		result.Name = pathParts[1]
+
+	case ResourceTypeReadme:
+		// readme://{catalog}/{app}/{version}
+		if len(pathParts) != 3 {
+			return nil, fmt.Errorf("invalid readme resource path: expected catalog/app/version")
+		}
+		result.Catalog = pathParts[0]
+		result.Name = pathParts[1]
+		result.Version = pathParts[2]
+
+	case ResourceTypeValues:
+		// values://{catalog}/{app}/{version}
+		if len(pathParts) != 3 {
+			return nil, fmt.Errorf("invalid values resource path: expected catalog/app/version")
+		}
+		result.Catalog = pathParts[0]
+		result.Name = pathParts[1]
+		result.Version = pathParts[2]
+
+	case ResourceTypeCluster:
+		// cluster://{namespace}/{name}
+		if len(pathParts) != 2 {
+			return nil, fmt.Errorf("invalid cluster resource path: expected namespace/name")
+		}
+		result.Namespace = pathParts[0]
+		result.Name = pathParts[1]
+
+	case ResourceTypeReleaseNotes:
+		// releasenotes://{provider}/{version}
+		if len(pathParts) != 2 {
+			return nil, fmt.Errorf("invalid release notes resource path: expected provider/version")
+		}
+		result.Name = pathParts[0]
+		result.Version = pathParts[1]
 	}
 
 	return result, nil
@@ -124,6 +172,14 @@ func (r *ResourceURI) String() string {
 		return fmt.Sprintf("schema://%s/%s/%s", r.Catalog, r.Name, r.Version)
 	case ResourceTypeChangelog:
 		return fmt.Sprintf("changelog://%s/%s", r.Catalog, r.Name)
+	case ResourceTypeReadme:
+		return fmt.Sprintf("readme://%s/%s/%s", r.Catalog, r.Name, r.Version)
+	case ResourceTypeValues:
+		return fmt.Sprintf("values://%s/%s/%s", r.Catalog, r.Name, r.Version)
+	case ResourceTypeCluster:
+		return fmt.Sprintf("cluster://%s/%s", r.Namespace, r.Name)
+	case ResourceTypeReleaseNotes:
+		return fmt.Sprintf("releasenotes://%s/%s", r.Name, r.Version)
 	default:
 		return ""
 	}
@@ -158,25 +214,58 @@ type CatalogResourceContent struct {
 	Visibility  string `json:"visibility"`
 	URL         string `json:"url"`
 	AppCount    int    `json:"appCount"`
+	EntryCount  int    `json:"entryCount"`
 	LastUpdated string `json:"lastUpdated,omitempty"`
 }
 
 // ConfigResourceContent represents the content of a config resource
 type ConfigResourceContent struct {
-	AppName    string                 `json:"appName"`
-	Namespace  string                 `json:"namespace"`
-	Values     map[string]interface{} `json:"values"`
-	Source     string                 `json:"source"` // configmap or secret
+	AppName   string                 `json:"appName"`
+	Namespace string                 `json:"namespace"`
+	Values    map[string]interface{} `json:"values"`
+	Source    string                 `json:"source"` // configmap or secret
+	// Merged is the result of merging all sources in order
+	Merged     map[string]interface{} `json:"merged"`
+	Sources    []ConfigSource         `json:"sources"`
 	LastUpdate string                 `json:"lastUpdate,omitempty"`
 }
 
-// SchemaResourceContent represents the content of a schema resource
+// ConfigSource is one key of a ConfigMap or Secret referenced by an app, in
+// the order app-operator merges them: config before userConfig, ConfigMap
+// before Secret, keys sorted by name. Extra configs are merged in order of
+// their priority before the first of these layers with a higher one.
+type ConfigSource struct {
+	Layer     string `json:"layer"` // config, userConfig or extraConfig
+	Kind      string `json:"kind"`  // ConfigMap or Secret
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Key       string `json:"key,omitempty"`
+	// Documents is the number of YAML documents in the key
+	Documents int                    `json:"documents"`
+	Raw       string                 `json:"raw,omitempty"`
+	Parsed    map[string]interface{} `json:"parsed,omitempty"`
+	Error     string                 `json:"error,omitempty"`
+}
+
+// SchemaResourceContent represents the content of a schema resource: the
+// chart's values.schema.json, null when it has none, and the helm-docs
+// documentation of its values.yaml
 type SchemaResourceContent struct {
-	AppName     string                 `json:"appName"`
-	Version     string                 `json:"version"`
-	Schema      map[string]interface{} `json:"schema"`
-	Required    []string               `json:"required,omitempty"`
-	Definitions map[string]interface{} `json:"definitions,omitempty"`
+	AppName       string                     `json:"appName"`
+	Version       string                     `json:"version"`
+	Schema        map[string]interface{}     `json:"schema"`
+	Required      []string                   `json:"required,omitempty"`
+	Definitions   map[string]interface{}     `json:"definitions,omitempty"`
+	Documentation []appcatalogentry.ValueDoc `json:"documentation,omitempty"`
+}
+
+// ValuesResourceContent represents the default values.yaml of an app
+// version, parsed to JSON
+type ValuesResourceContent struct {
+	AppName string                 `json:"appName"`
+	Catalog string                 `json:"catalog"`
+	Version string                 `json:"version"`
+	Values  map[string]interface{} `json:"values"`
 }
 
 // ChangelogEntry represents a single changelog entry
@@ -186,6 +275,8 @@ type ChangelogEntry struct {
 	Description string   `json:"description"`
 	Changes     []string `json:"changes,omitempty"`
 	Breaking    bool     `json:"breaking,omitempty"`
+	UpgradeFrom string   `json:"upgradeFrom,omitempty"`
+	UpgradeHint string   `json:"upgradeHint,omitempty"`
 }
 
 // ChangelogResourceContent represents the content of a changelog resource
@@ -194,3 +285,42 @@ type ChangelogResourceContent struct {
 	Catalog string           `json:"catalog"`
 	Entries []ChangelogEntry `json:"entries"`
 }
+
+// ReadmeResourceContent represents the content of a readme resource
+type ReadmeResourceContent struct {
+	AppName string `json:"appName"`
+	Catalog string `json:"catalog"`
+	Version string `json:"version"`
+	Content string `json:"content"`
+}
+
+// ClusterResourceContent represents the content of a cluster resource
+type ClusterResourceContent struct {
+	Name                string            `json:"name"`
+	Namespace           string            `json:"namespace"`
+	Organization        string            `json:"organization,omitempty"`
+	Provider            string            `json:"provider,omitempty"`
+	Phase               string            `json:"phase"`
+	Ready               bool              `json:"ready"`
+	InfrastructureReady bool              `json:"infrastructureReady"`
+	ControlPlaneReady   bool              `json:"controlPlaneReady"`
+	Labels              map[string]string `json:"labels,omitempty"`
+}
+
+// ReleaseComponent is a component or app of a platform release
+type ReleaseComponent struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// ReleaseNotesResourceContent represents the release notes of a platform release
+type ReleaseNotesResourceContent struct {
+	Provider   string             `json:"provider"`
+	Version    string             `json:"version"`
+	URL        string             `json:"url"`
+	Date       string             `json:"date,omitempty"`
+	State      string             `json:"state,omitempty"`
+	Notes      string             `json:"notes"`
+	Components []ReleaseComponent `json:"components,omitempty"`
+	Apps       []ReleaseComponent `json:"apps,omitempty"`
+}
@@ -0,0 +1,20 @@
+package resources
+
+import "testing"
+
+func TestParseResourceURIValues(t *testing.T) {
+	uri, err := ParseResourceURI("values://giantswarm/nginx-ingress-controller/3.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uri.Type != ResourceTypeValues || uri.Catalog != "giantswarm" || uri.Name != "nginx-ingress-controller" || uri.Version != "3.0.0" {
+		t.Errorf("parsed %+v", uri)
+	}
+	if got := uri.String(); got != "values://giantswarm/nginx-ingress-controller/3.0.0" {
+		t.Errorf("String() = %s", got)
+	}
+
+	if _, err := ParseResourceURI("values://giantswarm/nginx-ingress-controller"); err == nil {
+		t.Error("expected an error for a values URI without version")
+	}
+}
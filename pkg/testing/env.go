@@ -0,0 +1,167 @@
+package testing
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+)
+
+// AssetsEnv names the directory holding the etcd and kube-apiserver
+// binaries, as installed by setup-envtest
+const AssetsEnv = "KUBEBUILDER_ASSETS"
+
+// envStartTimeout bounds starting the API server and installing the CRDs
+const envStartTimeout = 2 * time.Minute
+
+//go:embed crds/*.yaml
+var crds embed.FS
+
+// Env is a local etcd and kube-apiserver started with envtest, with the App,
+// Catalog, AppCatalogEntry and Cluster CRDs installed, for integration tests
+// of clients and tools. It needs the binaries in $KUBEBUILDER_ASSETS.
+type Env struct {
+	Client  *k8s.Client
+	Dynamic *k8s.DynamicClient
+
+	env *envtest.Environment
+}
+
+// StartEnv starts an Env. Stop it when done.
+func StartEnv(ctx context.Context) (*Env, error) {
+	if os.Getenv(AssetsEnv) == "" {
+		return nil, fmt.Errorf("%s is not set: install the binaries with setup-envtest", AssetsEnv)
+	}
+	definitions, err := loadCRDs()
+	if err != nil {
+		return nil, err
+	}
+	env := &envtest.Environment{
+		CRDInstallOptions:        envtest.CRDInstallOptions{CRDs: definitions},
+		ControlPlaneStartTimeout: envStartTimeout,
+		ControlPlaneStopTimeout:  envStartTimeout,
+	}
+	config, err := env.Start()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start the env: %w", err)
+	}
+	config.QPS = 100
+	config.Burst = 200
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		_ = env.Stop()
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		_ = env.Stop()
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	return &Env{
+		Client:  &k8s.Client{Interface: clientset, RestConfig: config, Context: "env"},
+		Dynamic: k8s.NewDynamicClientForInterface(dynamicClient),
+		env:     env,
+	}, nil
+}
+
+// loadCRDs parses the embedded CRDs
+func loadCRDs() ([]*apiextensionsv1.CustomResourceDefinition, error) {
+	files, err := crds.ReadDir("crds")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CRDs: %w", err)
+	}
+	var definitions []*apiextensionsv1.CustomResourceDefinition
+	for _, file := range files {
+		data, err := crds.ReadFile("crds/" + file.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CRD %s: %w", file.Name(), err)
+		}
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := yaml.Unmarshal(data, crd); err != nil {
+			return nil, fmt.Errorf("failed to parse CRD %s: %w", file.Name(), err)
+		}
+		definitions = append(definitions, crd)
+	}
+	return definitions, nil
+}
+
+// Create creates objects: unstructured ones, e.g. built by App or Cluster,
+// through the dynamic client including their status, the others through the
+// clientset. Namespaces must come before the objects they hold.
+func (e *Env) Create(ctx context.Context, objects ...runtime.Object) error {
+	for _, obj := range objects {
+		var err error
+		switch o := obj.(type) {
+		case *unstructured.Unstructured:
+			err = e.createUnstructured(ctx, o)
+		case *corev1.Namespace:
+			_, err = e.Client.CoreV1().Namespaces().Create(ctx, o, metav1.CreateOptions{})
+		case *corev1.ConfigMap:
+			_, err = e.Client.CoreV1().ConfigMaps(o.Namespace).Create(ctx, o, metav1.CreateOptions{})
+		case *corev1.Secret:
+			_, err = e.Client.CoreV1().Secrets(o.Namespace).Create(ctx, o, metav1.CreateOptions{})
+		default:
+			err = fmt.Errorf("unsupported object type %T", obj)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create object: %w", err)
+		}
+	}
+	return nil
+}
+
+func (e *Env) createUnstructured(ctx context.Context, obj *unstructured.Unstructured) error {
+	gvr, ok := resourceFor(obj.GroupVersionKind())
+	if !ok {
+		return fmt.Errorf("no resource known for kind %s", obj.GroupVersionKind())
+	}
+	client := e.Dynamic.GetInterface().Resource(gvr).Namespace(obj.GetNamespace())
+	created, err := client.Create(ctx, obj, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	// The status subresource drops the status on create
+	if status, ok := obj.Object["status"]; ok {
+		created.Object["status"] = status
+		if _, err := client.UpdateStatus(ctx, created, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to set the status of %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// resourceFor maps a kind to its resource through the list kinds
+func resourceFor(gvk schema.GroupVersionKind) (schema.GroupVersionResource, bool) {
+	for gvr, listKind := range ListKinds() {
+		if gvr.Group == gvk.Group && gvr.Version == gvk.Version && listKind == gvk.Kind+"List" {
+			return gvr, true
+		}
+	}
+	return schema.GroupVersionResource{}, false
+}
+
+// ServerContext returns a server context with the clients of the env
+func (e *Env) ServerContext() *server.Context {
+	return server.NewContext(e.Client, e.Dynamic)
+}
+
+// Stop stops the API server and etcd and removes their data
+func (e *Env) Stop() error {
+	return e.env.Stop()
+}
@@ -0,0 +1,50 @@
+package testing_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	gstesting "github.com/giantswarm/mcp-giantswarm-apps/pkg/testing"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/tools"
+)
+
+func TestEnv(t *testing.T) {
+	if os.Getenv(gstesting.AssetsEnv) == "" {
+		t.Skipf("%s is not set", gstesting.AssetsEnv)
+	}
+	ctx := context.Background()
+	env, err := gstesting.StartEnv(ctx)
+	if err != nil {
+		t.Fatalf("StartEnv() error = %v", err)
+	}
+	defer func() {
+		if err := env.Stop(); err != nil {
+			t.Errorf("Stop() error = %v", err)
+		}
+	}()
+
+	if err := env.Create(ctx,
+		gstesting.OrganizationNamespace("acme"),
+		gstesting.DeployedApp("org-acme", "hello-world", "giantswarm", "2.3.0"),
+		gstesting.ProvisionedCluster("acme", "prod", "30.0.0"),
+	); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	s := mcpserver.NewMCPServer("test", "0.0.0")
+	if err := tools.RegisterAppTools(s, env.ServerContext()); err != nil {
+		t.Fatalf("RegisterAppTools() error = %v", err)
+	}
+	text, err := gstesting.CallTool(ctx, s, "app_get", map[string]interface{}{"namespace": "org-acme", "name": "hello-world"})
+	if err != nil {
+		t.Fatalf("app_get error = %v", err)
+	}
+	// The status is only set through the status subresource
+	if !strings.Contains(text, "deployed") {
+		t.Errorf("app_get output misses the release status:\n%s", text)
+	}
+}
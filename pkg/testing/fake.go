@@ -0,0 +1,171 @@
+// Package testing helps test clients and tools without a management
+// cluster: it builds Giant Swarm resources, fake clients and server contexts
+// holding them, and calls registered tools. Env runs the same tests against a
+// real API server with the App, Catalog, AppCatalogEntry and Cluster CRDs.
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/flux"
+)
+
+// ListKinds maps the resources the clients of this module list to their list
+// kinds, which the fake dynamic client needs to know
+func ListKinds() map[schema.GroupVersionResource]string {
+	kinds := map[schema.GroupVersionResource]string{
+		k8s.AppGVR:                     "AppList",
+		k8s.CatalogGVR:                 "CatalogList",
+		k8s.AppCatalogEntryGVR:         "AppCatalogEntryList",
+		k8s.ReleaseGVR:                 "ReleaseList",
+		k8s.OrganizationGVR:            "OrganizationList",
+		cluster.ClusterGVR:             "ClusterList",
+		cluster.MachineGVR:             "MachineList",
+		cluster.MachineDeploymentGVR:   "MachineDeploymentList",
+		cluster.KubeadmControlPlaneGVR: "KubeadmControlPlaneList",
+		cluster.MachineHealthCheckGVR:  "MachineHealthCheckList",
+	}
+	for _, gvr := range flux.HelmReleaseGVRs {
+		kinds[gvr] = "HelmReleaseList"
+	}
+	for _, gvr := range flux.KustomizationGVRs {
+		kinds[gvr] = "KustomizationList"
+	}
+	return kinds
+}
+
+// NewDynamicClient returns a fake dynamic client holding objects, e.g. the
+// resources built by App, Catalog, AppCatalogEntry and Cluster. Server-side
+// applies create missing objects and merge into existing ones.
+func NewDynamicClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), ListKinds(), objects...)
+	client.PrependReactor("patch", "*", applyReaction(client.Tracker()))
+	return client
+}
+
+// applyReaction handles server-side applies, which the fake object tracker
+// only supports for typed objects. Without field management, the applied
+// configuration is merged into the object like a JSON merge patch.
+func applyReaction(tracker clienttesting.ObjectTracker) clienttesting.ReactionFunc {
+	return func(action clienttesting.Action) (bool, runtime.Object, error) {
+		patch, ok := action.(clienttesting.PatchAction)
+		if !ok || patch.GetPatchType() != types.ApplyPatchType || patch.GetSubresource() != "" {
+			return false, nil, nil
+		}
+		gvr, namespace, name := patch.GetResource(), patch.GetNamespace(), patch.GetName()
+
+		applied := map[string]interface{}{}
+		if err := json.Unmarshal(patch.GetPatch(), &applied); err != nil {
+			return true, nil, err
+		}
+
+		current, err := tracker.Get(gvr, namespace, name)
+		switch {
+		case apierrors.IsNotFound(err):
+			obj := &unstructured.Unstructured{Object: applied}
+			obj.SetNamespace(namespace)
+			err = tracker.Create(gvr, obj, namespace)
+		case err == nil:
+			obj := current.(*unstructured.Unstructured).DeepCopy()
+			if rv := obj.GetResourceVersion(); rv != "" {
+				if want, _, _ := unstructured.NestedString(applied, "metadata", "resourceVersion"); want != "" && want != rv {
+					return true, nil, apierrors.NewConflict(gvr.GroupResource(), name, fmt.Errorf("the object has been modified"))
+				}
+			}
+			mergeInto(obj.Object, applied)
+			err = tracker.Update(gvr, obj, namespace)
+		}
+		if err != nil {
+			return true, nil, err
+		}
+
+		obj, err := tracker.Get(gvr, namespace, name)
+		return true, obj, err
+	}
+}
+
+// mergeInto merges src into dst, recursing into objects and replacing all
+// other values
+func mergeInto(dst, src map[string]interface{}) {
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeInto(dstMap, srcMap)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+// NewKubernetesClient returns a client backed by a fake clientset holding
+// objects, e.g. namespaces, ConfigMaps and Secrets
+func NewKubernetesClient(objects ...runtime.Object) *k8s.Client {
+	return &k8s.Client{Interface: fake.NewClientset(objects...), Context: "fake"}
+}
+
+// NewClients returns fake clients holding objects. Unstructured objects go to
+// the dynamic client, typed ones to the clientset.
+func NewClients(objects ...runtime.Object) (*k8s.Client, *k8s.DynamicClient) {
+	var custom, core []runtime.Object
+	for _, obj := range objects {
+		if _, ok := obj.(*unstructured.Unstructured); ok {
+			custom = append(custom, obj)
+		} else {
+			core = append(core, obj)
+		}
+	}
+	return NewKubernetesClient(core...), k8s.NewDynamicClientForInterface(NewDynamicClient(custom...))
+}
+
+// NewServerContext returns a server context with the fake clients of
+// NewClients
+func NewServerContext(objects ...runtime.Object) *server.Context {
+	return server.NewContext(NewClients(objects...))
+}
+
+// CallTool calls a tool registered on s with args and returns the text of
+// its result. Tool errors and error results are returned as errors.
+func CallTool(ctx context.Context, s *mcpserver.MCPServer, name string, args map[string]interface{}) (string, error) {
+	tool := s.GetTool(name)
+	if tool == nil {
+		return "", fmt.Errorf("tool %s is not registered", name)
+	}
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = name
+	req.Params.Arguments = args
+	result, err := tool.Handler(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	var texts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	text := strings.Join(texts, "\n")
+	if result.IsError {
+		return "", fmt.Errorf("tool %s failed: %s", name, text)
+	}
+	return text, nil
+}
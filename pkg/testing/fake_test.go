@@ -0,0 +1,72 @@
+package testing_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	gstesting "github.com/giantswarm/mcp-giantswarm-apps/pkg/testing"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/tools"
+)
+
+func TestCallToolWithFakeClients(t *testing.T) {
+	ctx := gstesting.NewServerContext(
+		gstesting.OrganizationNamespace("acme"),
+		gstesting.DeployedApp("org-acme", "hello-world", "giantswarm", "2.3.0"),
+		gstesting.CatalogEntry("giantswarm", "hello-world", "2.3.0"),
+		gstesting.ProvisionedCluster("acme", "prod", "30.0.0"),
+	)
+	s := mcpserver.NewMCPServer("test", "0.0.0")
+	if err := tools.RegisterAppTools(s, ctx); err != nil {
+		t.Fatalf("RegisterAppTools() error = %v", err)
+	}
+
+	text, err := gstesting.CallTool(context.Background(), s, "app_get", map[string]interface{}{"namespace": "org-acme", "name": "hello-world"})
+	if err != nil {
+		t.Fatalf("app_get error = %v", err)
+	}
+	if !strings.Contains(text, "hello-world") || !strings.Contains(text, "2.3.0") {
+		t.Errorf("app_get output misses the app:\n%s", text)
+	}
+
+	text, err = gstesting.CallTool(context.Background(), s, "app_list", map[string]interface{}{"organization": "acme"})
+	if err != nil {
+		t.Fatalf("app_list error = %v", err)
+	}
+	if !strings.Contains(text, "hello-world") {
+		t.Errorf("app_list output misses the app:\n%s", text)
+	}
+
+	if _, err := gstesting.CallTool(context.Background(), s, "app_get", map[string]interface{}{"namespace": "org-acme", "name": "missing"}); err == nil {
+		t.Error("app_get of a missing app succeeded")
+	}
+	if _, err := gstesting.CallTool(context.Background(), s, "no_such_tool", nil); err == nil {
+		t.Error("calling an unregistered tool succeeded")
+	}
+}
+
+func TestApplyCreatesMissingObjects(t *testing.T) {
+	ctx := context.Background()
+	client := app.NewClient(k8s.NewDynamicClientForInterface(gstesting.NewDynamicClient()))
+	hello := &app.App{Name: "hello-world", Namespace: "org-acme", Spec: app.AppSpec{Name: "hello-world", Catalog: "giantswarm", Version: "2.3.0"}}
+
+	if _, err := client.Create(ctx, hello); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	got, err := client.Get(ctx, "org-acme", "hello-world")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Spec.Version != "2.3.0" {
+		t.Errorf("version = %q, want 2.3.0", got.Spec.Version)
+	}
+
+	if _, err := client.Create(ctx, hello); !apierrors.IsAlreadyExists(err) {
+		t.Errorf("second Create() error = %v, want already exists", err)
+	}
+}
@@ -0,0 +1,207 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/catalog"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/organization"
+)
+
+// Normalize round-trips an object through JSON, so that nested values only
+// hold the types unstructured objects can be deep-copied with, e.g. no
+// map[string]string. The fake dynamic client panics on anything else.
+func Normalize(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		panic(fmt.Sprintf("failed to marshal %s %s: %v", obj.GetKind(), obj.GetName(), err))
+	}
+	normalized := &unstructured.Unstructured{}
+	if err := normalized.UnmarshalJSON(data); err != nil {
+		panic(fmt.Sprintf("failed to unmarshal %s %s: %v", obj.GetKind(), obj.GetName(), err))
+	}
+	return normalized
+}
+
+// App returns the App resource of a, including its status
+func App(a *app.App) *unstructured.Unstructured {
+	obj := a.ToUnstructured()
+	status := map[string]interface{}{
+		"release": map[string]interface{}{
+			"status": a.Status.Release.Status,
+		},
+	}
+	if a.Status.Version != "" {
+		status["version"] = a.Status.Version
+	}
+	if a.Status.AppVersion != "" {
+		status["appVersion"] = a.Status.AppVersion
+	}
+	if a.Status.Release.LastDeployed != "" {
+		status["release"].(map[string]interface{})["lastDeployed"] = a.Status.Release.LastDeployed
+	}
+	obj.Object["status"] = status
+	return Normalize(obj)
+}
+
+// DeployedApp returns an App deployed from catalog in version, as
+// app-operator reports it once the Helm release is installed
+func DeployedApp(namespace, name, catalogName, version string) *unstructured.Unstructured {
+	return App(&app.App{
+		Name:      name,
+		Namespace: namespace,
+		Spec: app.AppSpec{
+			Catalog:    catalogName,
+			Name:       name,
+			Namespace:  namespace,
+			Version:    version,
+			KubeConfig: app.KubeConfig{InCluster: true},
+		},
+		Status: app.AppStatus{
+			Version: version,
+			Release: app.ReleaseStatus{Status: "deployed", LastDeployed: "2026-01-01T00:00:00Z"},
+		},
+	})
+}
+
+// Catalog returns the Catalog resource of c
+func Catalog(c *catalog.Catalog) *unstructured.Unstructured {
+	return Normalize(c.ToUnstructured())
+}
+
+// AppCatalogEntry returns the AppCatalogEntry resource of e
+func AppCatalogEntry(e *appcatalogentry.AppCatalogEntry) *unstructured.Unstructured {
+	return Normalize(e.ToUnstructured())
+}
+
+// CatalogEntry returns the AppCatalogEntry app-operator creates for version
+// of a chart in a catalog
+func CatalogEntry(catalogName, appName, version string) *unstructured.Unstructured {
+	return AppCatalogEntry(&appcatalogentry.AppCatalogEntry{
+		Name:      fmt.Sprintf("%s-%s-%s", catalogName, appName, version),
+		Namespace: metav1.NamespaceDefault,
+		Labels: map[string]string{
+			"app.kubernetes.io/name":            appName,
+			"application.giantswarm.io/catalog": catalogName,
+		},
+		Spec: appcatalogentry.AppCatalogEntrySpec{
+			AppName:    appName,
+			AppVersion: version,
+			Catalog:    appcatalogentry.CatalogReference{Name: catalogName, Namespace: metav1.NamespaceDefault},
+			Chart:      appcatalogentry.ChartSpec{Name: appName, Version: version, AppVersion: version},
+		},
+	})
+}
+
+// Cluster returns the CAPI Cluster resource of c, including its status
+func Cluster(c *cluster.Cluster) *unstructured.Unstructured {
+	obj := c.ToUnstructured()
+	if len(c.Annotations) > 0 {
+		obj.SetAnnotations(c.Annotations)
+	}
+	status := map[string]interface{}{
+		"infrastructureReady": c.Status.InfrastructureReady,
+		"controlPlaneReady":   c.Status.ControlPlaneReady,
+	}
+	if c.Status.Phase != "" {
+		status["phase"] = c.Status.Phase
+	}
+	if len(c.Status.Conditions) > 0 {
+		status["conditions"] = conditionList(c.Status.Conditions)
+	}
+	obj.Object["status"] = status
+	return Normalize(obj)
+}
+
+func conditionList(conditions []cluster.Condition) []interface{} {
+	var list []interface{}
+	for _, condition := range conditions {
+		list = append(list, map[string]interface{}{
+			"type":               condition.Type,
+			"status":             condition.Status,
+			"lastTransitionTime": condition.LastTransitionTime,
+			"reason":             condition.Reason,
+			"message":            condition.Message,
+		})
+	}
+	return list
+}
+
+// ProvisionedCluster returns a ready workload cluster of an organization,
+// living in its organization namespace
+func ProvisionedCluster(org, name, releaseVersion string) *unstructured.Unstructured {
+	return Cluster(&cluster.Cluster{
+		Name:      name,
+		Namespace: organization.GetOrganizationNamespace(org),
+		Labels: map[string]string{
+			organization.OrganizationLabel: org,
+			cluster.ReleaseVersionLabel:    releaseVersion,
+		},
+		Status: cluster.ClusterStatus{
+			Phase:               "Provisioned",
+			InfrastructureReady: true,
+			ControlPlaneReady:   true,
+			Conditions:          []cluster.Condition{{Type: "Ready", Status: "True"}},
+		},
+	})
+}
+
+// Object returns a resource of any kind with spec, e.g. a CAPI or provider
+// resource without a dedicated builder
+func Object(apiVersion, kind, namespace, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	return Normalize(&unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+		"spec":       spec,
+	}})
+}
+
+// Machine returns a CAPI Machine of a workload cluster whose node is named
+// after it, with conditions in its status
+func Machine(namespace, clusterName, name string, conditions ...cluster.Condition) *unstructured.Unstructured {
+	obj := Object("cluster.x-k8s.io/v1beta1", "Machine", namespace, name, map[string]interface{}{"clusterName": clusterName})
+	obj.SetLabels(map[string]string{cluster.ClusterNameLabel: clusterName})
+	obj.Object["status"] = map[string]interface{}{
+		"nodeRef":    map[string]interface{}{"name": name + "-node"},
+		"conditions": conditionList(conditions),
+	}
+	return Normalize(obj)
+}
+
+// Event returns an event about an object, last seen at lastSeen
+func Event(namespace, name, kind, object, reason, message string, count int32, lastSeen time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: namespace},
+		InvolvedObject: corev1.ObjectReference{Kind: kind, Name: object, Namespace: namespace},
+		Reason:         reason,
+		Message:        message,
+		Count:          count,
+		LastTimestamp:  metav1.NewTime(lastSeen),
+	}
+}
+
+// OrganizationNamespace returns the namespace of an organization
+func OrganizationNamespace(org string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   organization.GetOrganizationNamespace(org),
+		Labels: map[string]string{organization.OrganizationLabel: org},
+	}}
+}
+
+// ConfigMap returns a ConfigMap holding values.yaml, as referenced by the
+// user config of an app
+func ConfigMap(namespace, name, values string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Data:       map[string]string{"values": values},
+	}
+}
@@ -4,18 +4,32 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	mcpserver "github.com/mark3labs/mcp-go/server"
 
 	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
 	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
 	"github.com/giantswarm/mcp-giantswarm-apps/pkg/organization"
 )
 
+const (
+	// defaultWaitTimeout is how long app_create and app_update wait for a deployment by default
+	defaultWaitTimeout = 5 * time.Minute
+
+	// waitInterval is how often the app status is polled while waiting
+	waitInterval = 5 * time.Second
+)
+
 // RegisterAppTools registers all app management tools
 func RegisterAppTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 	appClient := app.NewClient(ctx.DynamicClient)
+	clusterClient := cluster.NewClient(ctx.DynamicClient, ctx.K8sClient, appClient).WithClientPool(ctx.WorkloadClients)
+	configClient := newConfigClient(ctx)
+	configHistory := config.NewHistory(ctx.K8sClient, ctx.ConfigHistoryRevisions)
 
 	// app_list tool
 	listTool := mcp.NewTool(
@@ -28,6 +42,9 @@ func RegisterAppTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 		mcp.WithString("catalog", mcp.Description("Filter by catalog name")),
 		mcp.WithBoolean("all-orgs", mcp.Description("List apps from all organization namespaces")),
 		mcp.WithBoolean("include-workload-clusters", mcp.Description("Include apps from workload cluster namespaces")),
+		mcp.WithString("cluster", mcp.Description("Only list apps deployed to this workload cluster, from its workload namespace and apps referencing its kubeconfig secret")),
+		mcp.WithBoolean("managed-only", mcp.Description("Only list apps created through this server")),
+		withContinue(),
 	)
 
 	s.AddTool(listTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -45,7 +62,20 @@ func RegisterAppTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 		var err error
 
 		// Determine which namespaces to query
-		if org != "" {
+		if clusterName := getStringArg(args, "cluster"); clusterName != "" {
+			// Apps of a workload cluster, found by the namespace and kubeconfig conventions
+			targetCluster, err := findCluster(toolCtx, clusterClient, clusterName, "", org)
+			if err != nil {
+				return nil, err
+			}
+			apps, err = clusterClient.ListApps(toolCtx, targetCluster)
+			if err != nil {
+				return nil, err
+			}
+			if apps, err = app.FilterByLabels(apps, labelSelector); err != nil {
+				return nil, err
+			}
+		} else if org != "" {
 			// List apps from specific organization
 			if includeWorkloadClusters {
 				apps, err = appClient.ListByOrganization(toolCtx, ctx.K8sClient, org, labelSelector)
@@ -83,29 +113,43 @@ func RegisterAppTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 		// Apply filters
 		apps = app.FilterByStatus(apps, status)
 		apps = app.FilterByCatalog(apps, catalog)
+		apps = app.FilterByManaged(apps, getBoolArg(args, "managed-only"))
 
 		// Format output
 		if len(apps) == 0 {
 			return mcp.NewToolResultText("No apps found"), nil
 		}
 
-		var output strings.Builder
-		output.WriteString(fmt.Sprintf("Found %d apps:\n\n", len(apps)))
-
+		blocks := make([]string, 0, len(apps))
 		for _, a := range apps {
+			var output strings.Builder
 			output.WriteString(fmt.Sprintf("Name: %s\n", a.Name))
 			output.WriteString(fmt.Sprintf("Namespace: %s\n", a.Namespace))
 			output.WriteString(fmt.Sprintf("App: %s (v%s)\n", a.Spec.Name, a.Spec.Version))
 			output.WriteString(fmt.Sprintf("Catalog: %s\n", a.Spec.Catalog))
 			output.WriteString(fmt.Sprintf("Target Namespace: %s\n", a.Spec.Namespace))
 			output.WriteString(fmt.Sprintf("Status: %s\n", a.Status.Release.Status))
+			if a.IsPaused() {
+				output.WriteString("Paused: true\n")
+			}
+			if a.IsMCPManaged() {
+				output.WriteString(fmt.Sprintf("Created By: %s (%s)\n", a.Annotations[app.CreatedByAnnotation], a.Annotations[app.CreatedAtAnnotation]))
+				if ticket := a.Annotations[app.TicketAnnotation]; ticket != "" {
+					output.WriteString(fmt.Sprintf("Ticket: %s\n", ticket))
+				}
+			}
 			if a.Status.Release.LastDeployed != "" {
 				output.WriteString(fmt.Sprintf("Last Deployed: %s\n", a.Status.Release.LastDeployed))
 			}
 			output.WriteString("---\n")
+			blocks = append(blocks, output.String())
 		}
 
-		return mcp.NewToolResultText(output.String()), nil
+		result, err := budgetedList(ctx.OutputBudget, args, fmt.Sprintf("Found %d apps:\n\n", len(apps)), blocks)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText(result), nil
 	})
 
 	// app_get tool
@@ -135,6 +179,20 @@ func RegisterAppTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 		output.WriteString(fmt.Sprintf("  Version: %s\n", app.Spec.Version))
 		output.WriteString(fmt.Sprintf("  Target Namespace: %s\n", app.Spec.Namespace))
 		output.WriteString(fmt.Sprintf("  In-Cluster: %v\n", app.Spec.KubeConfig.InCluster))
+		if app.Spec.KubeConfig.Secret != nil {
+			output.WriteString(fmt.Sprintf("  KubeConfig Secret: %s/%s\n",
+				app.Spec.KubeConfig.Secret.Namespace, app.Spec.KubeConfig.Secret.Name))
+		}
+		if len(app.Finalizers) > 0 {
+			output.WriteString(fmt.Sprintf("  Finalizers: %s\n", strings.Join(app.Finalizers, ", ")))
+		}
+
+		if len(app.Labels) > 0 {
+			output.WriteString("\n" + formatMetadata("Labels:", app.Labels))
+		}
+		if len(app.Annotations) > 0 {
+			output.WriteString("\n" + formatMetadata("Annotations:", app.Annotations))
+		}
 
 		if app.Spec.Config != nil {
 			output.WriteString("\nConfiguration:\n")
@@ -160,198 +218,249 @@ func RegisterAppTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 			}
 		}
 
+		if len(app.Spec.ExtraConfigs) > 0 {
+			output.WriteString("\nExtra Configuration:\n")
+			for _, ec := range app.Spec.ExtraConfigs {
+				output.WriteString(fmt.Sprintf("  %s: %s/%s (priority %d)\n", ec.Kind, ec.Namespace, ec.Name, ec.Priority))
+			}
+		}
+
 		output.WriteString("\nStatus:\n")
 		output.WriteString(fmt.Sprintf("  App Version: %s\n", app.Status.AppVersion))
 		output.WriteString(fmt.Sprintf("  Chart Version: %s\n", app.Status.Version))
 		output.WriteString(fmt.Sprintf("  Release Status: %s\n", app.Status.Release.Status))
+		output.WriteString(fmt.Sprintf("  Paused: %v\n", app.IsPaused()))
 		if app.Status.Release.LastDeployed != "" {
 			output.WriteString(fmt.Sprintf("  Last Deployed: %s\n", app.Status.Release.LastDeployed))
 		}
 
-		return mcp.NewToolResultText(output.String()), nil
+		output.WriteString("\nHealth:\n")
+		output.WriteString(fmt.Sprintf("  State: %s\n", app.HealthState()))
+		health := app.HealthInfo()
+		if health.SLO != "" {
+			output.WriteString(fmt.Sprintf("  SLO: %s\n", health.SLO))
+		}
+		if health.MonitoringURL != "" {
+			output.WriteString(fmt.Sprintf("  Monitoring: %s\n", health.MonitoringURL))
+		}
+		if health.RunbookURL != "" {
+			output.WriteString(fmt.Sprintf("  Runbook: %s\n", health.RunbookURL))
+		}
+		if runbooks := ctx.Runbooks.For(app); len(runbooks) > 0 {
+			output.WriteString("  Troubleshooting docs:\n")
+			for _, runbook := range runbooks {
+				output.WriteString(fmt.Sprintf("    - %s: %s\n", runbook.Title, runbook.URL))
+			}
+		}
+
+		return textWithLinks(output.String(), appLinks(app)...), nil
 	})
 
-	// app_create tool
-	createTool := mcp.NewTool(
-		"app_create",
-		mcp.WithDescription("Create a new Giant Swarm app"),
-		mcp.WithString("name", mcp.Required(), mcp.Description("Name for the app resource")),
-		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace to create the app in")),
-		mcp.WithString("catalog", mcp.Required(), mcp.Description("Catalog name (e.g., giantswarm)")),
-		mcp.WithString("app", mcp.Required(), mcp.Description("App name from catalog (e.g., nginx-ingress-controller)")),
-		mcp.WithString("version", mcp.Required(), mcp.Description("App version")),
-		mcp.WithString("target-namespace", mcp.Description("Target namespace for the app (defaults to app name)")),
-		mcp.WithBoolean("in-cluster", mcp.Description("Deploy to management cluster (default: true)")),
-		mcp.WithString("cluster", mcp.Description("Target workload cluster name (overrides in-cluster)")),
-		mcp.WithString("config-name", mcp.Description("Name of the ConfigMap for configuration")),
-		mcp.WithString("user-config-name", mcp.Description("Name of the ConfigMap for user configuration")),
+	// app_delete tool
+	deleteTool := mcp.NewTool(
+		"app_delete",
+		mcp.WithDescription("Delete a Giant Swarm app. Apps protected with app_protect are only deleted with override-protection."),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the app")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace of the app")),
+		mcp.WithBoolean("override-protection", mcp.Description("Delete the app even though it is protected from deletion (default: false)")),
 	)
 
-	s.AddTool(createTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.AddTool(deleteTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := req.Params.Arguments.(map[string]interface{})
-
 		name := args["name"].(string)
 		namespace := args["namespace"].(string)
-		catalog := args["catalog"].(string)
-		appName := args["app"].(string)
-		version := args["version"].(string)
-		targetNamespace := getStringArg(args, "target-namespace")
-		if targetNamespace == "" {
-			targetNamespace = appName
-		}
-
-		inCluster := true
-		if val, ok := args["in-cluster"].(bool); ok {
-			inCluster = val
-		}
 
-		// Check if a target cluster is specified
-		targetCluster := getStringArg(args, "cluster")
-		if targetCluster != "" {
-			// Override in-cluster setting when targeting a specific cluster
-			inCluster = false
-		}
-
-		newApp := &app.App{
-			Name:      name,
-			Namespace: namespace,
-			Spec: app.AppSpec{
-				Catalog:   catalog,
-				Name:      appName,
-				Namespace: targetNamespace,
-				Version:   version,
-				KubeConfig: app.KubeConfig{
-					InCluster: inCluster,
-				},
-			},
-		}
-
-		// If targeting a workload cluster, set up the kubeconfig reference
-		if targetCluster != "" {
-			// The app operator would need to handle the kubeconfig setup based on the target cluster
-			// For now, we'll add a label to indicate the target cluster
-			// This would require extending the App type or handling it at creation time
-		}
-
-		// Add config references if provided
-		configName := getStringArg(args, "config-name")
-		if configName != "" {
-			newApp.Spec.Config = &app.AppConfig{
-				ConfigMap: &app.ConfigMapReference{
-					Name:      configName,
-					Namespace: namespace,
-				},
-			}
+		existing, err := appClient.Get(toolCtx, namespace, name)
+		if err != nil {
+			return nil, err
 		}
-
-		userConfigName := getStringArg(args, "user-config-name")
-		if userConfigName != "" {
-			newApp.Spec.UserConfig = &app.AppConfig{
-				ConfigMap: &app.ConfigMapReference{
-					Name:      userConfigName,
-					Namespace: namespace,
-				},
+		if existing.IsDeletionProtected() && !getBoolArg(args, "override-protection") {
+			message := fmt.Sprintf("App %s/%s is protected from deletion", namespace, name)
+			if reason := existing.Annotations[app.DeletionProtectionReasonAnnotation]; reason != "" {
+				message += ": " + reason
 			}
+			return mcp.NewToolResultError(message + ". Remove the protection with app_protect (protect: false), or re-run with override-protection=true to delete it anyway."), nil
 		}
 
-		created, err := appClient.Create(toolCtx, newApp)
+		err = appClient.Delete(toolCtx, namespace, name)
 		if err != nil {
 			return nil, err
 		}
 
-		// If we're targeting a workload cluster, provide additional info
-		result := fmt.Sprintf("Successfully created app %s/%s", created.Namespace, created.Name)
-		if targetCluster != "" {
-			result += fmt.Sprintf("\nTarget cluster: %s", targetCluster)
-			result += "\nNote: Ensure the app operator has access to the workload cluster's kubeconfig"
-		}
-
-		return mcp.NewToolResultText(result), nil
+		return mcp.NewToolResultText(fmt.Sprintf("Successfully deleted app %s/%s", namespace, name)), nil
 	})
 
-	// app_update tool
-	updateTool := mcp.NewTool(
-		"app_update",
-		mcp.WithDescription("Update an existing Giant Swarm app"),
-		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the app")),
-		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace of the app")),
-		mcp.WithString("version", mcp.Description("New version to update to")),
-		mcp.WithString("config-name", mcp.Description("Update ConfigMap name")),
-		mcp.WithString("user-config-name", mcp.Description("Update user ConfigMap name")),
+	// app_force_cleanup tool
+	cleanupTool := mcp.NewTool(
+		"app_force_cleanup",
+		mcp.WithDescription("Find apps stuck in deletion because a finalizer is never removed, explain what each finalizer waits for, "+
+			"and optionally remove one. Removing a finalizer skips the cleanup it guards, so the app's resources in the target cluster may be left behind."),
+		mcp.WithString("name", mcp.Description("Name of a single app to inspect (requires namespace)")),
+		mcp.WithString("namespace", mcp.Description("Namespace to look for stuck apps in, or of the named app")),
+		mcp.WithString("organization", mcp.Description("Organization to look for stuck apps in")),
+		mcp.WithString("stuck-after", mcp.Description("How long an app must have been terminating to count as stuck and have a finalizer removed (default: 10m)")),
+		mcp.WithString("remove-finalizer", mcp.Description("Finalizer to remove from the named app")),
+		mcp.WithString("confirm", mcp.Description("Must be <namespace>/<name> of the app to remove the finalizer")),
 	)
 
-	s.AddTool(updateTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.AddTool(cleanupTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := req.Params.Arguments.(map[string]interface{})
-		name := args["name"].(string)
-		namespace := args["namespace"].(string)
+		name := getStringArg(args, "name")
+		namespace := getStringArg(args, "namespace")
+		org := getStringArg(args, "organization")
+		finalizer := getStringArg(args, "remove-finalizer")
 
-		// Get current app
-		currentApp, err := appClient.Get(toolCtx, namespace, name)
-		if err != nil {
-			return nil, err
+		stuckAfter := 10 * time.Minute
+		if value := getStringArg(args, "stuck-after"); value != "" {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid stuck-after %q: %w", value, err)
+			}
+			stuckAfter = d
 		}
 
-		// Update version if provided
-		if version := getStringArg(args, "version"); version != "" {
-			currentApp.Spec.Version = version
-		}
+		if name == "" {
+			if finalizer != "" {
+				return nil, fmt.Errorf("remove-finalizer requires the name and namespace of the app")
+			}
 
-		// Update config if provided
-		if configName := getStringArg(args, "config-name"); configName != "" {
-			if currentApp.Spec.Config == nil {
-				currentApp.Spec.Config = &app.AppConfig{}
+			var apps []*app.App
+			var err error
+			if org != "" {
+				apps, err = appClient.ListByOrganization(toolCtx, ctx.K8sClient, org, "")
+			} else {
+				apps, err = appClient.List(toolCtx, namespace, "")
 			}
-			if currentApp.Spec.Config.ConfigMap == nil {
-				currentApp.Spec.Config.ConfigMap = &app.ConfigMapReference{}
+			if err != nil {
+				return nil, err
 			}
-			currentApp.Spec.Config.ConfigMap.Name = configName
-			currentApp.Spec.Config.ConfigMap.Namespace = namespace
-		}
 
-		// Update user config if provided
-		if userConfigName := getStringArg(args, "user-config-name"); userConfigName != "" {
-			if currentApp.Spec.UserConfig == nil {
-				currentApp.Spec.UserConfig = &app.AppConfig{}
+			stuck := app.FindStuck(apps, time.Now(), stuckAfter)
+			if len(stuck) == 0 {
+				return mcp.NewToolResultText(fmt.Sprintf("No apps have been terminating for more than %s", stuckAfter)), nil
 			}
-			if currentApp.Spec.UserConfig.ConfigMap == nil {
-				currentApp.Spec.UserConfig.ConfigMap = &app.ConfigMapReference{}
+
+			var output strings.Builder
+			output.WriteString(fmt.Sprintf("Found %d apps stuck in deletion:\n", len(stuck)))
+			for _, a := range stuck {
+				output.WriteString("\n" + explainStuckApp(toolCtx, ctx, a))
 			}
-			currentApp.Spec.UserConfig.ConfigMap.Name = userConfigName
-			currentApp.Spec.UserConfig.ConfigMap.Namespace = namespace
+			output.WriteString("\nTo remove a finalizer, call again with name, namespace, remove-finalizer and confirm: <namespace>/<name>.\n")
+			return mcp.NewToolResultText(output.String()), nil
 		}
 
-		updated, err := appClient.Update(toolCtx, currentApp)
+		if namespace == "" {
+			return nil, fmt.Errorf("namespace is required together with name")
+		}
+		target, err := appClient.Get(toolCtx, namespace, name)
 		if err != nil {
 			return nil, err
 		}
+		if !target.IsTerminating() {
+			return mcp.NewToolResultText(fmt.Sprintf("App %s/%s is not stuck in deletion", namespace, name)), nil
+		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully updated app %s/%s", updated.Namespace, updated.Name)), nil
-	})
-
-	// app_delete tool
-	deleteTool := mcp.NewTool(
-		"app_delete",
-		mcp.WithDescription("Delete a Giant Swarm app"),
-		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the app")),
-		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace of the app")),
-	)
+		explanation := explainStuckApp(toolCtx, ctx, target)
+		if finalizer == "" {
+			return mcp.NewToolResultText(explanation), nil
+		}
+		// The controller may still be cleaning up an app deleted only recently
+		if now := time.Now(); !target.IsStuck(now, stuckAfter) {
+			return nil, fmt.Errorf("app %s/%s has only been terminating for %s, wait until it has been for %s before removing a finalizer",
+				namespace, name, now.Sub(*target.DeletionTimestamp).Round(time.Second), stuckAfter)
+		}
 
-	s.AddTool(deleteTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args := req.Params.Arguments.(map[string]interface{})
-		name := args["name"].(string)
-		namespace := args["namespace"].(string)
+		if want := namespace + "/" + name; getStringArg(args, "confirm") != want {
+			return mcp.NewToolResultText(explanation + fmt.Sprintf("\nRemoving %s skips the cleanup it guards. "+
+				"To remove it anyway, call again with confirm: %q.\n", finalizer, want)), nil
+		}
 
-		err := appClient.Delete(toolCtx, namespace, name)
-		if err != nil {
+		if err := appClient.RemoveFinalizer(toolCtx, namespace, name, finalizer); err != nil {
 			return nil, err
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully deleted app %s/%s", namespace, name)), nil
+		return mcp.NewToolResultText(fmt.Sprintf("Removed finalizer %s from app %s/%s. "+
+			"Check the target cluster for resources of the app that were not cleaned up.", finalizer, namespace, name)), nil
 	})
 
+	registerAppDeployTools(s, ctx, appClient, configClient)
+	registerAppCheckTools(s, ctx, appClient, clusterClient)
+	registerAppCostTools(s, ctx, appClient, clusterClient)
+	registerAppMetadataTools(s, ctx, appClient)
+	registerAppPromoteTools(s, ctx, appClient, clusterClient, configClient, configHistory)
+	registerAppScaffoldTools(s, ctx)
+
 	return nil
 }
 
+// newAppProgress creates the progress reporter of app_create and app_update,
+// which have two more stages when waiting for the deployment
+func newAppProgress(ctx context.Context, req mcp.CallToolRequest, waitForDeploy bool) *server.Progress {
+	if waitForDeploy {
+		return server.NewProgress(ctx, req, 4)
+	}
+	return server.NewProgress(ctx, req, 2)
+}
+
+// parseWaitTimeout reads the timeout argument of the tools that can wait for a deployment
+func parseWaitTimeout(args map[string]interface{}) (time.Duration, error) {
+	value := getStringArg(args, "timeout")
+	if value == "" {
+		return defaultWaitTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q: use a positive duration like 30s or 5m", value)
+	}
+	return timeout, nil
+}
+
+// waitForApp waits for app-operator to deploy an app and describes the outcome.
+// A timeout is reported in the result rather than as an error, since the
+// change itself has been applied.
+func waitForApp(ctx context.Context, appClient *app.Client, progress *server.Progress, namespace, name string, timeout time.Duration) string {
+	progress.Report(server.StageReconciling, "waiting for app-operator")
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	deployed, err := appClient.WaitForDeployed(waitCtx, namespace, name, waitInterval)
+	if err != nil {
+		return fmt.Sprintf("Deployment not confirmed: %v", err)
+	}
+
+	progress.Report(server.StageReady, fmt.Sprintf("version %s deployed", deployed.Status.Version))
+	return fmt.Sprintf("Deployed version %s (release status: %s)", deployed.Status.Version, deployed.Status.Release.Status)
+}
+
+// explainStuckApp describes how long an app has been terminating and what
+// each of its finalizers waits for
+func explainStuckApp(toolCtx context.Context, ctx *server.Context, a *app.App) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("%s/%s: terminating since %s (%s)\n", a.Namespace, a.Name,
+		a.DeletionTimestamp.UTC().Format(time.RFC3339), time.Since(*a.DeletionTimestamp).Round(time.Minute)))
+
+	for _, finalizer := range a.Finalizers {
+		output.WriteString(fmt.Sprintf("  Finalizer: %s\n", finalizer))
+		output.WriteString(fmt.Sprintf("    %s\n", app.ExplainFinalizer(finalizer)))
+
+		if finalizer != app.AppOperatorFinalizer {
+			continue
+		}
+		ready, err := app.ReadyAppOperators(toolCtx, ctx.K8sClient, a.Namespace, "giantswarm")
+		switch {
+		case err != nil:
+			output.WriteString(fmt.Sprintf("    app-operator status unknown: %v\n", err))
+		case ready == 0:
+			output.WriteString(fmt.Sprintf("    No ready app-operator found in namespaces %s and giantswarm.\n", a.Namespace))
+		default:
+			output.WriteString(fmt.Sprintf("    %d ready app-operator replicas found; check their logs and whether the target cluster is reachable.\n", ready))
+		}
+	}
+	return output.String()
+}
+
 // Helper functions
 func getStringArg(args map[string]interface{}, key string) string {
 	if val, ok := args[key].(string); ok {
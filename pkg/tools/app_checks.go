@@ -0,0 +1,388 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/vulnerability"
+)
+
+// registerAppCheckTools registers the tools checking the dependencies,
+// vulnerabilities and capacity needs of apps
+func registerAppCheckTools(s *mcpserver.MCPServer, ctx *server.Context, appClient *app.Client, clusterClient *cluster.Client) {
+	// app_dependencies tool
+	dependenciesTool := mcp.NewTool(
+		"app_dependencies",
+		mcp.WithDescription("Check whether the apps an app depends on are installed on the same cluster in a compatible version"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the app")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace of the app")),
+	)
+
+	s.AddTool(dependenciesTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		name := args["name"].(string)
+		namespace := args["namespace"].(string)
+
+		target, err := appClient.Get(toolCtx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+
+		entryClient := appcatalogentry.NewClient(ctx.DynamicClient).WithIndex(ctx.AppCatalogEntryIndex)
+		entry, err := entryClient.FindVersion(toolCtx, target.Spec.Catalog, target.Spec.Name, target.Spec.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		deps, err := entryClient.GetDependencies(toolCtx, entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read dependencies of %s: %w", entry.Name, err)
+		}
+
+		if len(deps) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("App %s/%s (%s@%s) declares no dependencies",
+				namespace, name, target.Spec.Name, target.Spec.Version)), nil
+		}
+
+		// Apps deployed to the same cluster live in the same namespace
+		apps, err := appClient.List(toolCtx, namespace, "")
+		if err != nil {
+			return nil, err
+		}
+		peers := make([]*app.App, 0, len(apps))
+		for _, a := range apps {
+			if a.Name != target.Name && app.SameTargetCluster(a, target) {
+				peers = append(peers, a)
+			}
+		}
+
+		checks := app.CheckDependencies(deps, peers)
+
+		var output strings.Builder
+		output.WriteString(fmt.Sprintf("Dependencies of %s/%s (%s@%s):\n\n", namespace, name, target.Spec.Name, target.Spec.Version))
+
+		problems := 0
+		for _, check := range checks {
+			constraint := check.Dependency.Constraint
+			if constraint == "" {
+				constraint = "any"
+			}
+			output.WriteString(fmt.Sprintf("- %s (%s): %s", check.Dependency.Name, constraint, check.Status))
+			switch check.Status {
+			case app.DependencySatisfied:
+				output.WriteString(fmt.Sprintf(" by %s@%s", check.InstalledApp, check.InstalledVersion))
+			case app.DependencyIncompatible:
+				output.WriteString(fmt.Sprintf(" - installed %s@%s does not match", check.InstalledApp, check.InstalledVersion))
+				problems++
+			case app.DependencyMissing:
+				problems++
+			}
+			output.WriteString("\n")
+		}
+
+		if problems > 0 {
+			output.WriteString(fmt.Sprintf("\n%d dependencies need attention before %s can work correctly\n", problems, name))
+		} else {
+			output.WriteString("\nAll dependencies are satisfied\n")
+		}
+
+		return mcp.NewToolResultText(output.String()), nil
+	})
+
+	// app_vulnerabilities tool
+	vulnerabilitiesTool := mcp.NewTool(
+		"app_vulnerabilities",
+		mcp.WithDescription("Summarize the known CVEs of the container images an app runs, from the Trivy Operator "+
+			"VulnerabilityReports in its target cluster. Images are taken from the running pods of the app's release."),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the app")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace of the app")),
+		mcp.WithString("top", mcp.Description("Number of critical and high vulnerabilities to list per image (default: 5)")),
+	)
+
+	s.AddTool(vulnerabilitiesTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		name := getStringArg(args, "name")
+		namespace := getStringArg(args, "namespace")
+
+		top := 5
+		if value := getStringArg(args, "top"); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid top %q: must be a non-negative number", value)
+			}
+			top = n
+		}
+
+		a, err := appClient.Get(toolCtx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+
+		k8sClient, dynamicClient, target, err := appTargetClients(toolCtx, ctx, clusterClient, a)
+		if err != nil {
+			return nil, err
+		}
+
+		images, err := vulnerability.ReleaseImages(toolCtx, k8sClient, a.Spec.Namespace, a.Name)
+		if err != nil {
+			return nil, err
+		}
+		if len(images) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("No running pods of release %s found in namespace %s of %s",
+				a.Name, a.Spec.Namespace, target)), nil
+		}
+
+		reports, err := vulnerability.NewTrivyOperatorScanner(dynamicClient).Scan(toolCtx, a.Spec.Namespace, images)
+		if err != nil {
+			return nil, err
+		}
+
+		return mcp.NewToolResultText(formatVulnerabilityReports(fmt.Sprintf("Vulnerabilities of app %s/%s (%s in %s)",
+			namespace, name, target, a.Spec.Namespace), reports, top)), nil
+	})
+
+	// app_capacity_check tool
+	capacityTool := mcp.NewTool(
+		"app_capacity_check",
+		mcp.WithDescription("Estimate the CPU and memory an app requests from its chart values and compare it to the free "+
+			"allocatable capacity of the target cluster and the quotas of the target namespace, warning before a deploy that cannot schedule"),
+		mcp.WithString("catalog", mcp.Required(), mcp.Description("Catalog name (e.g., giantswarm)")),
+		mcp.WithString("app", mcp.Required(), mcp.Description("App name from catalog")),
+		mcp.WithString("version", mcp.Description("App version (default: newest version in the catalog)")),
+		mcp.WithString("values", mcp.Description("YAML values to merge over the chart defaults, as they would be set in the user config")),
+		mcp.WithString("target-namespace", mcp.Description("Namespace the app would be installed in (defaults to app name)")),
+		mcp.WithString("cluster", mcp.Description("Target workload cluster name (default: the management cluster)")),
+		mcp.WithString("namespace", mcp.Description("Namespace where the cluster is located")),
+		mcp.WithString("organization", mcp.Description("Organization that owns the cluster")),
+	)
+
+	s.AddTool(capacityTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		catalogName := getStringArg(args, "catalog")
+		appName := getStringArg(args, "app")
+		targetNamespace := getStringArg(args, "target-namespace")
+		if targetNamespace == "" {
+			targetNamespace = appName
+		}
+
+		files, err := fetchAppChart(toolCtx, ctx, catalogName, appName, getStringArg(args, "version"))
+		if err != nil {
+			return nil, err
+		}
+		values := map[string]interface{}{}
+		if defaults, ok := files.DefaultValues(); ok {
+			if err := yaml.Unmarshal(defaults, &values); err != nil {
+				return nil, fmt.Errorf("failed to parse the chart's %s: %w", appcatalogentry.ValuesFile, err)
+			}
+		}
+		if overrides := getStringArg(args, "values"); overrides != "" {
+			var userValues map[string]interface{}
+			if err := yaml.Unmarshal([]byte(overrides), &userValues); err != nil {
+				return nil, fmt.Errorf("invalid values: %w", err)
+			}
+			values = config.MergeValues(values, userValues)
+		}
+		workloads, problems := appcatalogentry.EstimateRequests(values)
+
+		var k8sClient kubernetes.Interface = ctx.K8sClient
+		target := "the management cluster"
+		if clusterName := getStringArg(args, "cluster"); clusterName != "" {
+			cl, err := findCluster(toolCtx, clusterClient, clusterName, getStringArg(args, "namespace"), getStringArg(args, "organization"))
+			if err != nil {
+				return nil, err
+			}
+			if k8sClient, err = clusterClient.WorkloadClient(toolCtx, cl); err != nil {
+				return nil, err
+			}
+			target = "cluster " + cl.Name
+		}
+
+		capacity, err := cluster.GetCapacity(toolCtx, k8sClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the capacity of %s: %w", target, err)
+		}
+		quotas, err := cluster.NamespaceQuotas(toolCtx, k8sClient, targetNamespace)
+		if err != nil {
+			return nil, err
+		}
+
+		return mcp.NewToolResultText(formatCapacityCheck(fmt.Sprintf("Capacity check for %s from catalog %s in namespace %s of %s",
+			appName, catalogName, targetNamespace, target), workloads, problems, capacity, quotas)), nil
+	})
+}
+
+// appTargetClients returns clients for the cluster an app is installed in
+// and a description of that cluster
+func appTargetClients(toolCtx context.Context, ctx *server.Context, clusterClient *cluster.Client, a *app.App) (kubernetes.Interface, dynamic.Interface, string, error) {
+	cl, err := clusterClient.FindAppCluster(toolCtx, a)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if cl == nil {
+		return ctx.K8sClient, ctx.DynamicClient.GetInterface(), "the management cluster", nil
+	}
+
+	k8sClient, err := clusterClient.WorkloadClient(toolCtx, cl)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	config, err := clusterClient.WorkloadConfig(toolCtx, cl)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed to create dynamic client for cluster %s: %w", cl.Name, err)
+	}
+	return k8sClient, dynamicClient, fmt.Sprintf("cluster %s/%s", cl.Namespace, cl.Name), nil
+}
+
+// formatVulnerabilityReports renders the severity counts of each image, its
+// top critical and high vulnerabilities and the totals
+func formatVulnerabilityReports(title string, reports []vulnerability.ImageReport, top int) string {
+	var output strings.Builder
+	output.WriteString(title + ":\n")
+
+	totals := make(map[string]int)
+	unscanned := 0
+	for _, report := range reports {
+		output.WriteString(fmt.Sprintf("\n%s\n", report.Image))
+		if !report.Scanned {
+			unscanned++
+			output.WriteString("  No VulnerabilityReport found (not scanned yet, or the Trivy Operator does not scan this namespace)\n")
+			continue
+		}
+
+		counts := make([]string, 0, len(vulnerability.Severities))
+		for _, severity := range vulnerability.Severities {
+			totals[severity] += report.Counts[severity]
+			counts = append(counts, fmt.Sprintf("%s %d", strings.ToLower(severity), report.Counts[severity]))
+		}
+		output.WriteString(fmt.Sprintf("  %s (scanned %s)\n", strings.Join(counts, ", "), report.UpdatedAt.UTC().Format(time.RFC3339)))
+
+		listed := 0
+		for _, v := range report.Vulnerabilities {
+			if listed >= top || (v.Severity != "CRITICAL" && v.Severity != "HIGH") {
+				break
+			}
+			fixed := "no fix available"
+			if v.FixedVersion != "" {
+				fixed = "fixed in " + v.FixedVersion
+			}
+			output.WriteString(fmt.Sprintf("  - %s [%s] %s %s, %s\n", v.ID, v.Severity, v.Package, v.InstalledVersion, fixed))
+			listed++
+		}
+	}
+
+	counts := make([]string, 0, len(vulnerability.Severities))
+	for _, severity := range vulnerability.Severities {
+		counts = append(counts, fmt.Sprintf("%s %d", strings.ToLower(severity), totals[severity]))
+	}
+	output.WriteString(fmt.Sprintf("\nTotal over %d images: %s\n", len(reports)-unscanned, strings.Join(counts, ", ")))
+	if unscanned > 0 {
+		output.WriteString(fmt.Sprintf("%d images have no scan result\n", unscanned))
+	}
+	return output.String()
+}
+
+// formatCapacityCheck renders the estimated requests of an app next to the
+// free capacity of the target cluster and namespace quotas, with a warning
+// for each limit the requests exceed
+func formatCapacityCheck(title string, workloads []appcatalogentry.WorkloadRequests, problems []string, capacity *cluster.Capacity, quotas []cluster.QuotaLimit) string {
+	var output strings.Builder
+	output.WriteString(title + ":\n\n")
+
+	resourceNames := []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+	total := corev1.ResourceList{}
+	output.WriteString("Estimated requests from the chart values:\n")
+	if len(workloads) == 0 {
+		output.WriteString("  No resources.requests found in the values; the chart may set them in its templates\n")
+	}
+	for _, w := range workloads {
+		path := w.Path
+		if path == "" {
+			path = "(top level)"
+		}
+		output.WriteString(fmt.Sprintf("  %s: %d x %s\n", path, w.Replicas, formatRequests(w.Requests)))
+		for name, quantity := range w.Total() {
+			sum := total[name]
+			sum.Add(quantity)
+			total[name] = sum
+		}
+	}
+	output.WriteString(fmt.Sprintf("  Total: %s\n", formatRequests(total)))
+	for _, problem := range problems {
+		output.WriteString(fmt.Sprintf("  Skipped %s\n", problem))
+	}
+
+	free := capacity.Free()
+	output.WriteString(fmt.Sprintf("\nCluster: %d schedulable nodes\n", capacity.Nodes))
+	output.WriteString(fmt.Sprintf("  Allocatable: %s\n", formatRequests(capacity.Allocatable)))
+	output.WriteString(fmt.Sprintf("  Requested: %s\n", formatRequests(capacity.Requested)))
+	output.WriteString(fmt.Sprintf("  Free: %s\n", formatRequests(free)))
+
+	var warnings []string
+	if capacity.Nodes == 0 {
+		warnings = append(warnings, "the cluster has no ready, schedulable nodes")
+	}
+	for _, name := range resourceNames {
+		if needed, ok := total[name]; ok && needed.Cmp(free[name]) > 0 {
+			warnings = append(warnings, fmt.Sprintf("the app requests %s %s but only %s is free in the cluster", name, needed.String(), quantityOf(free, name)))
+		}
+		for _, w := range workloads {
+			if needed, ok := w.Requests[name]; ok && needed.Cmp(capacity.LargestNode[name]) > 0 {
+				warnings = append(warnings, fmt.Sprintf("a replica of %s requests %s %s, more than the largest node can allocate (%s)",
+					w.Path, name, needed.String(), quantityOf(capacity.LargestNode, name)))
+			}
+		}
+	}
+
+	if len(quotas) > 0 {
+		output.WriteString("\nNamespace quotas:\n")
+	}
+	for _, quota := range quotas {
+		remaining := quota.Remaining()
+		output.WriteString(fmt.Sprintf("  %s %s: hard %s, used %s, remaining %s\n", quota.Quota, quota.Resource, quota.Hard.String(), quota.Used.String(), remaining.String()))
+		if needed, ok := total[quota.Resource]; ok && needed.Cmp(remaining) > 0 {
+			warnings = append(warnings, fmt.Sprintf("the app requests %s %s but quota %s leaves only %s", quota.Resource, needed.String(), quota.Quota, remaining.String()))
+		}
+	}
+
+	if len(warnings) == 0 {
+		output.WriteString("\nThe estimated requests fit the free capacity and quotas.\n")
+		return output.String()
+	}
+	output.WriteString("\nWarnings:\n")
+	for _, warning := range warnings {
+		output.WriteString(fmt.Sprintf("  - %s\n", warning))
+	}
+	return output.String()
+}
+
+// formatRequests renders the CPU and memory of a resource list
+func formatRequests(resources corev1.ResourceList) string {
+	return fmt.Sprintf("cpu %s, memory %s", quantityOf(resources, corev1.ResourceCPU), quantityOf(resources, corev1.ResourceMemory))
+}
+
+func quantityOf(resources corev1.ResourceList, name corev1.ResourceName) string {
+	quantity, ok := resources[name]
+	if !ok {
+		return "0"
+	}
+	return quantity.String()
+}
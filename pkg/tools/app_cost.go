@@ -0,0 +1,200 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cost"
+)
+
+// registerAppCostTools registers the tool reporting the cost of apps
+func registerAppCostTools(s *mcpserver.MCPServer, ctx *server.Context, appClient *app.Client, clusterClient *cluster.Client) {
+	// app_cost tool
+	costTool := mcp.NewTool(
+		"app_cost",
+		mcp.WithDescription("Estimate the monthly cost of apps, their target namespaces and their clusters from the OpenCost or Kubecost "+
+			"allocation API configured with --cost-api. Costs are extrapolated from the given window, in the currency the API is configured with."),
+		mcp.WithString("name", mcp.Description("Name of a single app (requires namespace)")),
+		mcp.WithString("namespace", mcp.Description("Namespace of the apps")),
+		mcp.WithString("organization", mcp.Description("Organization whose apps to report")),
+		mcp.WithString("window", mcp.Description("Window the costs are taken from, e.g. 7d or 24h (default: 30d)")),
+	)
+
+	s.AddTool(costTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		if ctx.CostAPI == nil {
+			return nil, fmt.Errorf("no cost API configured: start the server with --cost-api")
+		}
+		window := getStringArg(args, "window")
+		if window == "" {
+			window = "30d"
+		}
+
+		apps, source, err := selectExportApps(toolCtx, ctx, appClient, args)
+		if err != nil {
+			return nil, err
+		}
+		if len(apps) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("No apps found in %s", source)), nil
+		}
+
+		targets, err := appCostTargets(toolCtx, ctx, clusterClient, apps)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText(formatAppCost(fmt.Sprintf("Estimated monthly cost of %s (from the last %s, %s)",
+			source, window, ctx.CostAPI.Describe()), queryAppCosts(toolCtx, ctx.CostAPI, targets, window))), nil
+	})
+}
+
+// appCostTarget is a cluster and the apps deployed to it, with the cost
+// allocations read for them
+type appCostTarget struct {
+	name   string
+	client kubernetes.Interface
+	apps   []*app.App
+	err    error
+
+	releases   map[string]cost.Allocation
+	namespaces map[string]cost.Allocation
+}
+
+// appCostTargets groups apps by the cluster they are deployed to, listing
+// the clusters once. Targets the cost API cannot be queried for carry an
+// error instead of a client.
+func appCostTargets(toolCtx context.Context, ctx *server.Context, clusterClient *cluster.Client, apps []*app.App) ([]*appCostTarget, error) {
+	clusters, err := clusterClient.List(toolCtx, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []*appCostTarget
+	byName := map[string]*appCostTarget{}
+	for _, a := range apps {
+		var cl *cluster.Cluster
+		if !a.Spec.KubeConfig.InCluster {
+			for _, candidate := range clusters {
+				if cluster.AppTargetsCluster(a, candidate) {
+					cl = candidate
+					break
+				}
+			}
+		}
+
+		name := "the management cluster"
+		switch {
+		case cl != nil:
+			name = fmt.Sprintf("cluster %s/%s", cl.Namespace, cl.Name)
+		case !a.Spec.KubeConfig.InCluster:
+			name = "an unknown cluster"
+		}
+
+		target, ok := byName[name]
+		if !ok {
+			target = &appCostTarget{name: name}
+			switch {
+			case cl != nil && !ctx.CostAPI.CoversWorkloadClusters():
+				target.err = fmt.Errorf("%s only covers the management cluster", ctx.CostAPI.Describe())
+			case cl != nil:
+				target.client, target.err = clusterClient.WorkloadClient(toolCtx, cl)
+			case !a.Spec.KubeConfig.InCluster:
+				target.err = fmt.Errorf("no workload cluster found for these apps")
+			default:
+				target.client = ctx.K8sClient
+			}
+			byName[name] = target
+			targets = append(targets, target)
+		}
+		target.apps = append(target.apps, a)
+	}
+
+	sort.SliceStable(targets, func(i, j int) bool { return targets[i].name < targets[j].name })
+	return targets, nil
+}
+
+// queryAppCosts reads the release and namespace allocations of each target
+func queryAppCosts(toolCtx context.Context, api *cost.API, targets []*appCostTarget, window string) []*appCostTarget {
+	for _, target := range targets {
+		if target.err != nil {
+			continue
+		}
+		if target.releases, target.err = api.Allocations(toolCtx, target.client, window, cost.ReleaseAggregate); target.err != nil {
+			continue
+		}
+		target.namespaces, target.err = api.Allocations(toolCtx, target.client, window, "namespace")
+	}
+	return targets
+}
+
+// formatAppCost renders the monthly estimates of the apps, their target
+// namespaces and the clusters, with the apps without cost data
+func formatAppCost(title string, targets []*appCostTarget) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("## %s\n\n", title))
+
+	var appsTotal float64
+	for _, target := range targets {
+		output.WriteString(fmt.Sprintf("### %s\n\n", target.name))
+		if target.err != nil {
+			output.WriteString(fmt.Sprintf("No cost data for %d apps: %v\n\n", len(target.apps), target.err))
+			continue
+		}
+
+		var clusterTotal, idle float64
+		for key, allocation := range target.namespaces {
+			monthly := allocation.Monthly(allocation.TotalCost)
+			clusterTotal += monthly
+			if key == cost.IdleKey {
+				idle = monthly
+			}
+		}
+		output.WriteString(fmt.Sprintf("Cluster: %.2f/month (idle: %.2f)\n\n", clusterTotal, idle))
+
+		var missing []string
+		namespaces := map[string]bool{}
+		output.WriteString("Apps:\n")
+		for _, a := range target.apps {
+			namespaces[a.Spec.Namespace] = true
+			allocation, ok := target.releases[a.Name]
+			if !ok {
+				missing = append(missing, a.Namespace+"/"+a.Name)
+				continue
+			}
+			monthly := allocation.Monthly(allocation.TotalCost)
+			appsTotal += monthly
+			output.WriteString(fmt.Sprintf("- %s/%s in %s: %.2f/month (CPU %.2f, RAM %.2f, storage %.2f, network %.2f)\n",
+				a.Namespace, a.Name, a.Spec.Namespace, monthly, allocation.Monthly(allocation.CPUCost)+allocation.Monthly(allocation.GPUCost),
+				allocation.Monthly(allocation.RAMCost), allocation.Monthly(allocation.PVCost), allocation.Monthly(allocation.NetworkCost)))
+		}
+		if len(missing) > 0 {
+			output.WriteString(fmt.Sprintf("- No cost data (not running, or pods without the app.kubernetes.io/instance label): %s\n", strings.Join(missing, ", ")))
+		}
+
+		names := make([]string, 0, len(namespaces))
+		for namespace := range namespaces {
+			names = append(names, namespace)
+		}
+		sort.Strings(names)
+		output.WriteString("\nTarget namespaces:\n")
+		for _, namespace := range names {
+			if allocation, ok := target.namespaces[namespace]; ok {
+				output.WriteString(fmt.Sprintf("- %s: %.2f/month\n", namespace, allocation.Monthly(allocation.TotalCost)))
+			} else {
+				output.WriteString(fmt.Sprintf("- %s: no cost data\n", namespace))
+			}
+		}
+		output.WriteString("\n")
+	}
+
+	output.WriteString(fmt.Sprintf("**Total of the apps: %.2f/month**\n", appsTotal))
+	return output.String()
+}
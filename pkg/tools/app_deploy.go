@@ -0,0 +1,403 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/catalog"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/organization"
+)
+
+// registerAppDeployTools registers the tools creating, updating and deploying
+// development versions of apps
+func registerAppDeployTools(s *mcpserver.MCPServer, ctx *server.Context, appClient *app.Client, configClient *config.Client) {
+	// app_create tool
+	createTool := mcp.NewTool(
+		"app_create",
+		mcp.WithDescription("Create a new Giant Swarm app"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name for the app resource")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace to create the app in")),
+		mcp.WithString("catalog", mcp.Required(), mcp.Description("Catalog name (e.g., giantswarm)")),
+		mcp.WithString("app", mcp.Required(), mcp.Description("App name from catalog (e.g., nginx-ingress-controller)")),
+		mcp.WithString("version", mcp.Description("App version; 'latest' or omitted resolves to the newest stable version in the catalog")),
+		mcp.WithString("target-namespace", mcp.Description("Target namespace for the app (defaults to app name)")),
+		mcp.WithBoolean("in-cluster", mcp.Description("Deploy to management cluster (default: true)")),
+		mcp.WithString("cluster", mcp.Description("Target workload cluster name (overrides in-cluster)")),
+		mcp.WithString("config-name", mcp.Description("Name of the ConfigMap for configuration")),
+		mcp.WithString("user-config-name", mcp.Description("Name of the ConfigMap for user configuration")),
+		mcp.WithBoolean("org-defaults", mcp.Description("Attach the organization defaults of the app ({app}-org-defaults in the organization namespace) as an extra config when they exist (default: true)")),
+		mcp.WithBoolean("wait", mcp.Description("Wait until app-operator has deployed the app, reporting progress (default: false)")),
+		mcp.WithString("timeout", mcp.Description("How long to wait for the deployment (default: 5m)")),
+		mcp.WithString("ticket", mcp.Description("Ticket or pull request reference recorded on the app")),
+	)
+
+	s.AddTool(createTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+
+		name := args["name"].(string)
+		namespace := args["namespace"].(string)
+		catalog := args["catalog"].(string)
+		appName := args["app"].(string)
+		targetNamespace := getStringArg(args, "target-namespace")
+		if targetNamespace == "" {
+			targetNamespace = appName
+		}
+
+		// Pin "latest" to the version it resolves to now, so that the app
+		// is not upgraded implicitly
+		version := getStringArg(args, "version")
+		resolvedLatest := version == "" || strings.EqualFold(version, "latest")
+		if resolvedLatest {
+			entryClient := appcatalogentry.NewClient(ctx.DynamicClient).WithIndex(ctx.AppCatalogEntryIndex)
+			var err error
+			if version, err = entryClient.ResolveLatest(toolCtx, catalog, appName); err != nil {
+				return nil, fmt.Errorf("failed to resolve the latest version: %w", err)
+			}
+		}
+
+		inCluster := true
+		if val, ok := args["in-cluster"].(bool); ok {
+			inCluster = val
+		}
+
+		// Check if a target cluster is specified
+		targetCluster := getStringArg(args, "cluster")
+		if targetCluster != "" {
+			// Override in-cluster setting when targeting a specific cluster
+			inCluster = false
+		}
+
+		newApp := &app.App{
+			Name:      name,
+			Namespace: namespace,
+			Spec: app.AppSpec{
+				Catalog:   catalog,
+				Name:      appName,
+				Namespace: targetNamespace,
+				Version:   version,
+				KubeConfig: app.KubeConfig{
+					InCluster: inCluster,
+				},
+			},
+		}
+
+		// If targeting a workload cluster, set up the kubeconfig reference
+		if targetCluster != "" {
+			// The app operator would need to handle the kubeconfig setup based on the target cluster
+			// For now, we'll add a label to indicate the target cluster
+			// This would require extending the App type or handling it at creation time
+		}
+
+		// Add config references if provided
+		configName := getStringArg(args, "config-name")
+		if configName != "" {
+			newApp.Spec.Config = &app.AppConfig{
+				ConfigMap: &app.ConfigMapReference{
+					Name:      configName,
+					Namespace: namespace,
+				},
+			}
+		}
+
+		userConfigName := getStringArg(args, "user-config-name")
+		if userConfigName != "" {
+			newApp.Spec.UserConfig = &app.AppConfig{
+				ConfigMap: &app.ConfigMapReference{
+					Name:      userConfigName,
+					Namespace: namespace,
+				},
+			}
+		}
+
+		// Organization defaults apply below the cluster and user config
+		orgDefaults := ""
+		if useOrgDefaults, ok := args["org-defaults"].(bool); (!ok || useOrgDefaults) && organization.IsOrganizationNamespace(namespace) {
+			defaults, err := configClient.GetOrgDefaults(toolCtx, namespace, appName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to look up organization defaults: %w", err)
+			}
+			if defaults != nil {
+				newApp.Spec.ExtraConfigs = append(newApp.Spec.ExtraConfigs, app.ExtraConfig{
+					Kind:      app.ExtraConfigKindConfigMap,
+					Name:      defaults.Name,
+					Namespace: namespace,
+					Priority:  app.DefaultExtraConfigPriority,
+				})
+				orgDefaults = defaults.Name
+			}
+		}
+
+		waitForDeploy := getBoolArg(args, "wait")
+		timeout, err := parseWaitTimeout(args)
+		if err != nil {
+			return nil, err
+		}
+		newApp.MarkCreated(ctx.Creator(toolCtx), getStringArg(args, "ticket"), time.Now())
+
+		progress := newAppProgress(toolCtx, req, waitForDeploy)
+		progress.Report(server.StageValidated, fmt.Sprintf("app %s/%s", namespace, name))
+
+		created, err := appClient.Create(toolCtx, newApp)
+		if err != nil {
+			return nil, err
+		}
+		progress.Report(server.StageApplied, fmt.Sprintf("created app %s/%s", created.Namespace, created.Name))
+
+		// If we're targeting a workload cluster, provide additional info
+		result := fmt.Sprintf("Successfully created app %s/%s", created.Namespace, created.Name)
+		if resolvedLatest {
+			result += fmt.Sprintf("\nVersion: %s (newest stable version in catalog %s, pinned)", version, catalog)
+		}
+		if orgDefaults != "" {
+			result += fmt.Sprintf("\nOrganization defaults: %s/%s (extra config, priority %d)", namespace, orgDefaults, app.DefaultExtraConfigPriority)
+		}
+		if targetCluster != "" {
+			result += fmt.Sprintf("\nTarget cluster: %s", targetCluster)
+			result += "\nNote: Ensure the app operator has access to the workload cluster's kubeconfig"
+		}
+
+		if waitForDeploy {
+			result += "\n" + waitForApp(toolCtx, appClient, progress, created.Namespace, created.Name, timeout)
+		}
+
+		return mcp.NewToolResultText(result), nil
+	})
+
+	// app_update tool
+	updateTool := mcp.NewTool(
+		"app_update",
+		mcp.WithDescription("Update an existing Giant Swarm app"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the app")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace of the app")),
+		mcp.WithString("version", mcp.Description("New version to update to")),
+		mcp.WithString("config-name", mcp.Description("Update ConfigMap name")),
+		mcp.WithString("user-config-name", mcp.Description("Update user ConfigMap name")),
+		mcp.WithBoolean("force", mcp.Description("Overwrite concurrent changes instead of retrying (default: false)")),
+		mcp.WithBoolean("wait", mcp.Description("Wait until app-operator has deployed the update, reporting progress (default: false)")),
+		mcp.WithString("timeout", mcp.Description("How long to wait for the deployment (default: 5m)")),
+	)
+
+	s.AddTool(updateTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		name := args["name"].(string)
+		namespace := args["namespace"].(string)
+
+		version := getStringArg(args, "version")
+		configName := getStringArg(args, "config-name")
+		userConfigName := getStringArg(args, "user-config-name")
+		opts := k8s.UpdateOptions{Force: getBoolArg(args, "force")}
+
+		waitForDeploy := getBoolArg(args, "wait")
+		timeout, err := parseWaitTimeout(args)
+		if err != nil {
+			return nil, err
+		}
+		progress := newAppProgress(toolCtx, req, waitForDeploy)
+		progress.Report(server.StageValidated, fmt.Sprintf("app %s/%s", namespace, name))
+
+		updated, err := appClient.Update(toolCtx, namespace, name, opts, func(currentApp *app.App) error {
+			// Update version if provided
+			if version != "" {
+				currentApp.Spec.Version = version
+			}
+
+			// Update config if provided
+			if configName != "" {
+				if currentApp.Spec.Config == nil {
+					currentApp.Spec.Config = &app.AppConfig{}
+				}
+				if currentApp.Spec.Config.ConfigMap == nil {
+					currentApp.Spec.Config.ConfigMap = &app.ConfigMapReference{}
+				}
+				currentApp.Spec.Config.ConfigMap.Name = configName
+				currentApp.Spec.Config.ConfigMap.Namespace = namespace
+			}
+
+			// Update user config if provided
+			if userConfigName != "" {
+				if currentApp.Spec.UserConfig == nil {
+					currentApp.Spec.UserConfig = &app.AppConfig{}
+				}
+				if currentApp.Spec.UserConfig.ConfigMap == nil {
+					currentApp.Spec.UserConfig.ConfigMap = &app.ConfigMapReference{}
+				}
+				currentApp.Spec.UserConfig.ConfigMap.Name = userConfigName
+				currentApp.Spec.UserConfig.ConfigMap.Namespace = namespace
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		progress.Report(server.StageApplied, fmt.Sprintf("updated app %s/%s", updated.Namespace, updated.Name))
+
+		result := fmt.Sprintf("Successfully updated app %s/%s", updated.Namespace, updated.Name)
+		if waitForDeploy {
+			result += "\n" + waitForApp(toolCtx, appClient, progress, updated.Namespace, updated.Name, timeout)
+		}
+
+		return mcp.NewToolResultText(result), nil
+	})
+
+	// app_dev_deploy tool
+	devDeployTool := mcp.NewTool(
+		"app_dev_deploy",
+		mcp.WithDescription("Push a local chart package to the dev OCI catalog configured with --dev-catalog and create or update an App installing it. "+
+			"Each push gets its own version (the chart version with a -dev.<timestamp> suffix), so app-operator upgrades the app every time. "+
+			"Only available over the stdio transport, as it reads the chart from the server's disk."),
+		mcp.WithString("chart", mcp.Required(), mcp.Description("Path of the chart package (.tgz from helm package) on the machine running the server")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace of the App")),
+		mcp.WithString("name", mcp.Description("Name of the App (default: the chart name)")),
+		mcp.WithString("version", mcp.Description("Version to push the chart as (default: the chart version with a -dev.<timestamp> suffix)")),
+		mcp.WithString("target-namespace", mcp.Description("Target namespace for a new app (defaults to the chart name)")),
+		mcp.WithString("user-config-name", mcp.Description("Name of the ConfigMap for user configuration")),
+		mcp.WithBoolean("wait", mcp.Description("Wait until app-operator has deployed the app, reporting progress (default: false)")),
+		mcp.WithString("timeout", mcp.Description("How long to wait for the deployment (default: 5m)")),
+		mcp.WithString("confirm", mcp.Description("Must be <namespace>/<name> of the app to replace an existing app installed from another catalog")),
+	)
+
+	s.AddTool(devDeployTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		if !ctx.LocalFiles {
+			return nil, fmt.Errorf("app_dev_deploy reads the chart from the server's disk and is only available over the stdio transport")
+		}
+		if ctx.DevCatalog == "" {
+			return nil, fmt.Errorf("no dev catalog configured: start the server with --dev-catalog")
+		}
+		catalogNamespace, catalogName, _ := strings.Cut(ctx.DevCatalog, "/")
+		devCatalog, err := catalog.NewClient(ctx.DynamicClient).Get(toolCtx, catalogNamespace, catalogName)
+		if err != nil {
+			return nil, err
+		}
+		repositoryURL, ok := devCatalog.OCIRepositoryURL()
+		if !ok {
+			return nil, fmt.Errorf("dev catalog %s has no OCI repository", ctx.DevCatalog)
+		}
+
+		chart, err := catalog.ReadChartFile(args["chart"].(string))
+		if err != nil {
+			return nil, err
+		}
+		version := getStringArg(args, "version")
+		if version == "" {
+			version = devChartVersion(chart.Version, time.Now())
+		}
+		if chart, err = chart.WithVersion(version); err != nil {
+			return nil, err
+		}
+
+		namespace := args["namespace"].(string)
+		name := getStringArg(args, "name")
+		if name == "" {
+			name = chart.Name
+		}
+		waitForDeploy := getBoolArg(args, "wait")
+		timeout, err := parseWaitTimeout(args)
+		if err != nil {
+			return nil, err
+		}
+		progress := newAppProgress(toolCtx, req, waitForDeploy)
+
+		// Only apps already installed from the dev catalog are replaced
+		// without confirmation, before anything is pushed
+		existing, err := appClient.Get(toolCtx, namespace, name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil && (existing.Spec.Catalog != devCatalog.Name || existing.Spec.CatalogNamespace != devCatalog.Namespace) {
+			if want := namespace + "/" + name; getStringArg(args, "confirm") != want {
+				return nil, fmt.Errorf("app %s is installed from catalog %s, not the dev catalog %s: call again with confirm: %s to replace it",
+					want, existing.Spec.Catalog, ctx.DevCatalog, want)
+			}
+		}
+
+		registryURL, err := url.Parse(repositoryURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid repository URL %q of dev catalog %s: %w", repositoryURL, ctx.DevCatalog, err)
+		}
+		credentials, err := catalog.DockerCredentials(registryURL.Host)
+		if err != nil {
+			return nil, err
+		}
+		pushed, err := catalog.PushChart(toolCtx, repositoryURL, chart, credentials)
+		if err != nil {
+			return nil, err
+		}
+		progress.Report(server.StageValidated, fmt.Sprintf("pushed %s", pushed.Reference))
+
+		var output strings.Builder
+		output.WriteString(fmt.Sprintf("Pushed chart %s %s to %s (%s)\n", chart.Name, chart.Version, pushed.Reference, pushed.Digest))
+
+		userConfigName := getStringArg(args, "user-config-name")
+		switch {
+		case existing == nil:
+			targetNamespace := getStringArg(args, "target-namespace")
+			if targetNamespace == "" {
+				targetNamespace = chart.Name
+			}
+			newApp := &app.App{
+				Name:      name,
+				Namespace: namespace,
+				Spec: app.AppSpec{
+					Catalog:          devCatalog.Name,
+					CatalogNamespace: devCatalog.Namespace,
+					Name:             chart.Name,
+					Namespace:        targetNamespace,
+					Version:          chart.Version,
+					KubeConfig:       app.KubeConfig{InCluster: true},
+				},
+			}
+			if userConfigName != "" {
+				newApp.Spec.UserConfig = &app.AppConfig{ConfigMap: &app.ConfigMapReference{Name: userConfigName, Namespace: namespace}}
+			}
+			newApp.MarkCreated(ctx.Creator(toolCtx), "", time.Now())
+			if _, err := appClient.Create(toolCtx, newApp); err != nil {
+				return nil, err
+			}
+			output.WriteString(fmt.Sprintf("Created app %s/%s installing it into namespace %s\n", namespace, name, targetNamespace))
+		default:
+			_, err := appClient.Update(toolCtx, namespace, name, k8s.UpdateOptions{}, func(currentApp *app.App) error {
+				currentApp.Spec.Catalog = devCatalog.Name
+				currentApp.Spec.CatalogNamespace = devCatalog.Namespace
+				currentApp.Spec.Name = chart.Name
+				currentApp.Spec.Version = chart.Version
+				if userConfigName != "" {
+					currentApp.Spec.UserConfig = &app.AppConfig{ConfigMap: &app.ConfigMapReference{Name: userConfigName, Namespace: namespace}}
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			output.WriteString(fmt.Sprintf("Updated app %s/%s from %s %s to %s %s\n", namespace, name,
+				existing.Spec.Catalog, existing.Spec.Version, devCatalog.Name, chart.Version))
+		}
+		progress.Report(server.StageApplied, fmt.Sprintf("app %s/%s at version %s", namespace, name, chart.Version))
+
+		if waitForDeploy {
+			output.WriteString(waitForApp(toolCtx, appClient, progress, namespace, name, timeout) + "\n")
+		}
+		return mcp.NewToolResultText(output.String()), nil
+	})
+}
+
+// devChartVersion is the version app_dev_deploy pushes a chart as: its own
+// version with a -dev.<timestamp> pre-release suffix, e.g.
+// 1.2.0-dev.20260101120000
+func devChartVersion(version string, now time.Time) string {
+	return strings.SplitN(version, "+", 2)[0] + "-dev." + now.UTC().Format("20060102150405")
+}
@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/catalog"
+	gstesting "github.com/giantswarm/mcp-giantswarm-apps/pkg/testing"
+)
+
+// writeChartPackage writes a chart package holding only a Chart.yaml
+func writeChartPackage(t *testing.T, name, version string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), name+"-"+version+".tgz")
+	f, err := os.Create(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	metadata := "apiVersion: v2\nname: " + name + "\nversion: " + version + "\n"
+	if err := tw.WriteHeader(&tar.Header{Name: name + "/Chart.yaml", Mode: 0o644, Size: int64(len(metadata)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte(metadata)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestAppDevDeployRefusals(t *testing.T) {
+	ctx := gstesting.NewServerContext(
+		gstesting.Catalog(&catalog.Catalog{Name: "dev", Namespace: "default", Spec: catalog.CatalogSpec{
+			Repositories: []catalog.Repository{{Type: "oci", URL: "oci://registry.invalid/dev"}},
+		}}),
+		gstesting.DeployedApp("org-acme", "hello", "giantswarm", "1.0.0"),
+	)
+	ctx.DevCatalog = "default/dev"
+	s := mcpserver.NewMCPServer("test", "0.0.0")
+	if err := RegisterAppTools(s, ctx); err != nil {
+		t.Fatal(err)
+	}
+	args := map[string]interface{}{"chart": writeChartPackage(t, "hello", "1.1.0"), "namespace": "org-acme"}
+
+	// The chart is read from the server's disk, which only stdio clients share
+	_, err := gstesting.CallTool(context.Background(), s, "app_dev_deploy", args)
+	if err == nil || !strings.Contains(err.Error(), "only available over the stdio transport") {
+		t.Errorf("app_dev_deploy without local files: error = %v, want refused", err)
+	}
+
+	// An app installed from another catalog is only replaced with confirm
+	ctx.LocalFiles = true
+	_, err = gstesting.CallTool(context.Background(), s, "app_dev_deploy", args)
+	if err == nil || !strings.Contains(err.Error(), "confirm: org-acme/hello") {
+		t.Errorf("app_dev_deploy of an app from another catalog: error = %v, want confirm", err)
+	}
+	existing, err := app.NewClient(ctx.DynamicClient).Get(context.Background(), "org-acme", "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if existing.Spec.Catalog != "giantswarm" || existing.Spec.Version != "1.0.0" {
+		t.Errorf("app was changed: %+v", existing.Spec)
+	}
+}
@@ -0,0 +1,235 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+)
+
+// registerAppMetadataTools registers the tools labeling, annotating, pausing,
+// resuming and protecting apps
+func registerAppMetadataTools(s *mcpserver.MCPServer, ctx *server.Context, appClient *app.Client) {
+	// app_label tool
+	labelTool := mcp.NewTool(
+		"app_label",
+		mcp.WithDescription("Add, change or remove labels on an app (e.g., ownership, environment, cost center)"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the app")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace of the app")),
+		mcp.WithString("set", mcp.Description("Labels to set in key=value format (comma-separated)")),
+		mcp.WithString("remove", mcp.Description("Label keys to remove (comma-separated)")),
+	)
+
+	s.AddTool(labelTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		name := args["name"].(string)
+		namespace := args["namespace"].(string)
+
+		set, remove, err := parseMetadataChanges(getStringArg(args, "set"), getStringArg(args, "remove"), true)
+		if err != nil {
+			return nil, err
+		}
+
+		updated, err := appClient.SetLabels(toolCtx, namespace, name, set, remove)
+		if err != nil {
+			return nil, err
+		}
+
+		return mcp.NewToolResultText(formatMetadata(fmt.Sprintf("Updated labels of app %s/%s", namespace, name), updated.Labels)), nil
+	})
+
+	// app_annotate tool
+	annotateTool := mcp.NewTool(
+		"app_annotate",
+		mcp.WithDescription("Add, change or remove annotations on an app, including the health annotations "+
+			app.MonitoringURLAnnotation+", "+app.RunbookURLAnnotation+" and "+app.SLOAnnotation+" (an availability objective in percent)"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the app")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace of the app")),
+		mcp.WithString("set", mcp.Description("Annotations to set in key=value format (comma-separated)")),
+		mcp.WithString("remove", mcp.Description("Annotation keys to remove (comma-separated)")),
+	)
+
+	s.AddTool(annotateTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		name := args["name"].(string)
+		namespace := args["namespace"].(string)
+
+		set, remove, err := parseMetadataChanges(getStringArg(args, "set"), getStringArg(args, "remove"), false)
+		if err != nil {
+			return nil, err
+		}
+		if err := app.ValidateHealthAnnotations(set); err != nil {
+			return nil, err
+		}
+
+		updated, err := appClient.SetAnnotations(toolCtx, namespace, name, set, remove)
+		if err != nil {
+			return nil, err
+		}
+
+		return mcp.NewToolResultText(formatMetadata(fmt.Sprintf("Updated annotations of app %s/%s", namespace, name), updated.Annotations)), nil
+	})
+
+	// app_pause tool
+	pauseTool := mcp.NewTool(
+		"app_pause",
+		mcp.WithDescription("Pause reconciliation of an app by app-operator, e.g. during manual interventions"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the app")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace of the app")),
+	)
+
+	s.AddTool(pauseTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		name := args["name"].(string)
+		namespace := args["namespace"].(string)
+
+		existing, err := appClient.Get(toolCtx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		if existing.IsPaused() {
+			return mcp.NewToolResultText(fmt.Sprintf("App %s/%s is already paused", namespace, name)), nil
+		}
+
+		if _, err := appClient.Pause(toolCtx, namespace, name); err != nil {
+			return nil, err
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Paused reconciliation of app %s/%s. "+
+			"Changes to the app will not be applied until it is resumed with app_resume.", namespace, name)), nil
+	})
+
+	// app_resume tool
+	resumeTool := mcp.NewTool(
+		"app_resume",
+		mcp.WithDescription("Resume reconciliation of a paused app by app-operator"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the app")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace of the app")),
+	)
+
+	s.AddTool(resumeTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		name := args["name"].(string)
+		namespace := args["namespace"].(string)
+
+		existing, err := appClient.Get(toolCtx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		if !existing.IsPaused() {
+			return mcp.NewToolResultText(fmt.Sprintf("App %s/%s is not paused", namespace, name)), nil
+		}
+
+		if _, err := appClient.Resume(toolCtx, namespace, name); err != nil {
+			return nil, err
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Resumed reconciliation of app %s/%s", namespace, name)), nil
+	})
+
+	// app_protect tool
+	protectTool := mcp.NewTool(
+		"app_protect",
+		mcp.WithDescription("Protect a critical app, such as an ingress controller or the CNI, from deletion with app_delete, or remove the protection"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the app")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace of the app")),
+		mcp.WithBoolean("protect", mcp.Description("Set to false to remove the protection (default: true)")),
+		mcp.WithString("reason", mcp.Description("Why the app is protected, shown when its deletion is refused")),
+	)
+
+	s.AddTool(protectTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		name := args["name"].(string)
+		namespace := args["namespace"].(string)
+		protect := true
+		if value, ok := args["protect"].(bool); ok {
+			protect = value
+		}
+
+		existing, err := appClient.Get(toolCtx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !protect {
+			if !existing.IsDeletionProtected() {
+				return mcp.NewToolResultText(fmt.Sprintf("App %s/%s is not protected from deletion", namespace, name)), nil
+			}
+			if _, err := appClient.Unprotect(toolCtx, namespace, name); err != nil {
+				return nil, err
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("Removed the deletion protection of app %s/%s", namespace, name)), nil
+		}
+
+		if _, err := appClient.Protect(toolCtx, namespace, name, getStringArg(args, "reason")); err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Protected app %s/%s from deletion. "+
+			"app_delete refuses to delete it unless called with override-protection=true.", namespace, name)), nil
+	})
+}
+
+// parseMetadataChanges parses the set/remove arguments of the labeling tools.
+// Label values are validated as well when isLabel is true.
+func parseMetadataChanges(setStr, removeStr string, isLabel bool) (map[string]string, []string, error) {
+	set := make(map[string]string)
+	if setStr != "" {
+		for _, kv := range strings.Split(setStr, ",") {
+			parts := strings.SplitN(kv, "=", 2)
+			if len(parts) != 2 {
+				return nil, nil, fmt.Errorf("invalid format: %s (expected key=value)", kv)
+			}
+			key := strings.TrimSpace(parts[0])
+			value := strings.TrimSpace(parts[1])
+			if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+				return nil, nil, fmt.Errorf("invalid key %q: %s", key, strings.Join(errs, "; "))
+			}
+			if isLabel {
+				if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+					return nil, nil, fmt.Errorf("invalid label value %q: %s", value, strings.Join(errs, "; "))
+				}
+			}
+			set[key] = value
+		}
+	}
+
+	remove := make([]string, 0)
+	if removeStr != "" {
+		for _, key := range strings.Split(removeStr, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				remove = append(remove, key)
+			}
+		}
+	}
+
+	if len(set) == 0 && len(remove) == 0 {
+		return nil, nil, fmt.Errorf("nothing to change: specify 'set' and/or 'remove'")
+	}
+
+	return set, remove, nil
+}
+
+// formatMetadata renders labels or annotations sorted by key
+func formatMetadata(title string, values map[string]string) string {
+	var output strings.Builder
+	output.WriteString(title + "\n")
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		output.WriteString(fmt.Sprintf("  %s: %s\n", k, values[k]))
+	}
+
+	return output.String()
+}
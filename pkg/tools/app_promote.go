@@ -0,0 +1,198 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+)
+
+// registerAppPromoteTools registers the tool promoting apps between clusters
+func registerAppPromoteTools(s *mcpserver.MCPServer, ctx *server.Context, appClient *app.Client, clusterClient *cluster.Client, configClient *config.Client, configHistory *config.History) {
+	// app_promote tool
+	promoteTool := mcp.NewTool(
+		"app_promote",
+		mcp.WithDescription("Promote an app from one cluster or namespace to another, e.g. staging to production: copies the App with its user configuration, "+
+			"pinned to the version the source runs. An existing target app is diffed first and only replaced when confirmed."),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the source app")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace of the source app")),
+		mcp.WithString("target-cluster", mcp.Description("Workload cluster to promote to; the source cluster's name is replaced with it in names and references")),
+		mcp.WithString("target-namespace", mcp.Description("Namespace to create the app and its configuration in (default: the source namespace after renames)")),
+		mcp.WithString("rename", mcp.Description("Further comma-separated old=new replacements for names and namespaces")),
+		mcp.WithBoolean("secret-values", mcp.Description("Also copy the user secrets (default: false)")),
+		mcp.WithBoolean("dry-run", mcp.Description("Only show the diff and what would be created (default: false)")),
+		mcp.WithString("confirm", mcp.Description("Must be <namespace>/<name> of the target app to replace an existing one")),
+		mcp.WithString("ticket", mcp.Description("Ticket or pull request reference recorded on a created app")),
+	)
+
+	s.AddTool(promoteTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		name := args["name"].(string)
+		namespace := args["namespace"].(string)
+		targetNamespace := getStringArg(args, "target-namespace")
+		dryRun := getBoolArg(args, "dry-run")
+
+		source, err := appClient.Get(toolCtx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+
+		var renames []config.Rename
+		if clusterName := getStringArg(args, "target-cluster"); clusterName != "" {
+			sourceCluster, err := clusterClient.FindAppCluster(toolCtx, source)
+			if err != nil {
+				return nil, err
+			}
+			if sourceCluster == nil {
+				return nil, fmt.Errorf("app %s/%s is deployed to the management cluster, target-cluster does not apply", namespace, name)
+			}
+			targetCluster, err := findCluster(toolCtx, clusterClient, clusterName, "", "")
+			if err != nil {
+				return nil, err
+			}
+			renames = append(renames, config.Rename{Old: sourceCluster.Name, New: targetCluster.Name})
+			if targetNamespace == "" && sourceCluster.Namespace == source.Namespace {
+				targetNamespace = targetCluster.Namespace
+			}
+		}
+		extra, err := config.ParseRenames(getStringArg(args, "rename"))
+		if err != nil {
+			return nil, err
+		}
+		renames = append(renames, extra...)
+
+		promoted := promotedApp(source, targetNamespace, renames)
+		if promoted.Namespace == source.Namespace && promoted.Name == source.Name {
+			return nil, fmt.Errorf("the target app is the source app; set target-cluster, target-namespace or rename")
+		}
+		if !ctx.NamespacePolicy.Allows(promoted.Namespace) {
+			return nil, fmt.Errorf("namespace %s is not allowed on this server", promoted.Namespace)
+		}
+
+		sourceBundle, warnings := exportConfigBundle(toolCtx, configClient, []*app.App{source}, namespace+"/"+name, false, getBoolArg(args, "secret-values"))
+		bundle := sourceBundle.Retarget(targetNamespace, renames)
+		// Renames may move the configuration to other namespaces than the app
+		for _, item := range bundle.Items {
+			if ns := item.Config().Namespace; !ctx.NamespacePolicy.Allows(ns) {
+				return nil, fmt.Errorf("namespace %s of %s %s is not allowed on this server", ns, item.Type, item.Name)
+			}
+		}
+		for _, a := range bundle.Apps {
+			for _, ref := range a.References {
+				setAppConfigRef(promoted, ref)
+			}
+		}
+
+		target := fmt.Sprintf("%s/%s", promoted.Namespace, promoted.Name)
+		var output strings.Builder
+		output.WriteString(fmt.Sprintf("Promoting app %s/%s (%s %s) to %s\n", namespace, name, source.Spec.Name, promoted.Spec.Version, target))
+		for _, warning := range warnings {
+			output.WriteString(fmt.Sprintf("Warning: %s\n", warning))
+		}
+
+		existing, err := appClient.Get(toolCtx, promoted.Namespace, promoted.Name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if existing != nil {
+			output.WriteString("\nChanges to the existing app:\n")
+			diff := appSpecDiff(existing, promoted)
+			if len(diff) == 0 {
+				output.WriteString("  none\n")
+			}
+			for _, line := range diff {
+				output.WriteString("  " + line + "\n")
+			}
+		} else {
+			output.WriteString("\nThe target app does not exist and will be created.\n")
+		}
+
+		for _, item := range bundle.Items {
+			cfg := item.Config()
+			output.WriteString(fmt.Sprintf("\n%s %s/%s:\n", cfg.Type, cfg.Namespace, cfg.Name))
+			if item.Redacted {
+				output.WriteString("  not copied, set secret-values to copy secrets or create it with secret_create\n")
+				continue
+			}
+			current, err := configClient.Get(toolCtx, cfg.Namespace, cfg.Name, cfg.Type)
+			switch {
+			case apierrors.IsNotFound(err):
+				output.WriteString(fmt.Sprintf("  will be created with %d keys\n", len(cfg.Data)))
+			case err != nil:
+				return nil, err
+			default:
+				diff := configDataDiff(current, cfg)
+				if len(diff) == 0 {
+					output.WriteString("  unchanged\n")
+				}
+				for _, line := range diff {
+					output.WriteString("  " + line + "\n")
+				}
+			}
+		}
+
+		if dryRun {
+			output.WriteString("\nDry run: nothing was changed.\n")
+			return mcp.NewToolResultText(output.String()), nil
+		}
+		if existing != nil && getStringArg(args, "confirm") != target {
+			output.WriteString(fmt.Sprintf("\nThe target app exists. Call again with confirm: %s to apply these changes.\n", target))
+			return mcp.NewToolResultText(output.String()), nil
+		}
+
+		output.WriteString("\nApplied:\n")
+		opts := k8s.UpdateOptions{}
+		for _, item := range bundle.Items {
+			if item.Redacted {
+				continue
+			}
+			if !ctx.NamespacePolicy.Allows(item.Namespace) {
+				return nil, fmt.Errorf("namespace %s is not allowed on this server", item.Namespace)
+			}
+			m := &manifest{Kind: "ConfigMap", Namespace: item.Namespace, Name: item.Name, Config: item.Config()}
+			if item.Type == config.ConfigTypeSecret {
+				m.Kind = "Secret"
+			}
+			result, err := applyManifest(toolCtx, m, appClient, nil, configClient, configHistory, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to apply %s: %w", m, err)
+			}
+			output.WriteString(fmt.Sprintf("- %s: %s\n", m, result))
+		}
+
+		if existing == nil {
+			promoted.MarkCreated(ctx.Creator(toolCtx), getStringArg(args, "ticket"), time.Now())
+			if _, err := appClient.Create(toolCtx, promoted); err != nil {
+				return nil, err
+			}
+			output.WriteString(fmt.Sprintf("- App %s: created\n", target))
+		} else {
+			_, err := appClient.Update(toolCtx, promoted.Namespace, promoted.Name, opts, func(current *app.App) error {
+				current.Spec = promoted.Spec
+				for k, v := range promoted.Labels {
+					if current.Labels == nil {
+						current.Labels = make(map[string]string)
+					}
+					current.Labels[k] = v
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			output.WriteString(fmt.Sprintf("- App %s: updated\n", target))
+		}
+
+		return textWithLinks(output.String(), appLinks(promoted)...), nil
+	})
+}
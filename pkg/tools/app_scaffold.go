@@ -0,0 +1,122 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/organization"
+)
+
+// registerAppScaffoldTools registers the tool scaffolding app manifests
+func registerAppScaffoldTools(s *mcpserver.MCPServer, ctx *server.Context) {
+	// app_scaffold tool
+	scaffoldTool := mcp.NewTool(
+		"app_scaffold",
+		mcp.WithDescription("Generate the manifests to install a catalog app (App, user values ConfigMap and optional Secret) as YAML for review, with values defaulted from the chart's values schema"),
+		mcp.WithString("catalog", mcp.Required(), mcp.Description("Catalog name (e.g., giantswarm)")),
+		mcp.WithString("app", mcp.Required(), mcp.Description("App name from catalog (e.g., nginx-ingress-controller)")),
+		mcp.WithString("version", mcp.Description("App version (defaults to the newest version in the catalog)")),
+		mcp.WithString("name", mcp.Description("Name for the app resource (defaults to the app name, prefixed with the cluster name for workload clusters)")),
+		mcp.WithString("namespace", mcp.Description("Namespace to create the app in")),
+		mcp.WithString("organization", mcp.Description("Organization whose namespace the app is created in (alternative to namespace)")),
+		mcp.WithString("cluster", mcp.Description("Target workload cluster name (defaults to the management cluster)")),
+		mcp.WithString("target-namespace", mcp.Description("Target namespace for the app (defaults to the app name)")),
+		mcp.WithBoolean("with-secret", mcp.Description("Also generate a Secret for sensitive user values")),
+	)
+
+	s.AddTool(scaffoldTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		catalog := args["catalog"].(string)
+		appName := args["app"].(string)
+		version := getStringArg(args, "version")
+		cluster := getStringArg(args, "cluster")
+
+		namespace := getStringArg(args, "namespace")
+		if org := getStringArg(args, "organization"); namespace == "" && org != "" {
+			namespace = organization.GetOrganizationNamespace(org)
+		}
+		if namespace == "" {
+			return nil, fmt.Errorf("either namespace or organization must be specified")
+		}
+
+		name := getStringArg(args, "name")
+		if name == "" {
+			name = appName
+			if cluster != "" {
+				name = cluster + "-" + appName
+			}
+		}
+		targetNamespace := getStringArg(args, "target-namespace")
+		if targetNamespace == "" {
+			targetNamespace = appName
+		}
+
+		entryClient := appcatalogentry.NewClient(ctx.DynamicClient).WithIndex(ctx.AppCatalogEntryIndex)
+		var entry *appcatalogentry.AppCatalogEntry
+		if version != "" {
+			var err error
+			entry, err = entryClient.FindVersion(toolCtx, catalog, appName, version)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			entries, err := entryClient.ListByCatalog(toolCtx, catalog, "")
+			if err != nil {
+				return nil, err
+			}
+			for _, e := range appcatalogentry.SortByDate(entries) {
+				if e.MatchesApp(appName) {
+					entry = e
+					break
+				}
+			}
+			if entry == nil {
+				return nil, fmt.Errorf("app %s not found in catalog %s", appName, catalog)
+			}
+		}
+
+		var output strings.Builder
+		values := map[string]interface{}{}
+		var placeholders []string
+
+		files, err := appcatalogentry.FetchChart(toolCtx, entry)
+		if err != nil {
+			output.WriteString(fmt.Sprintf("# Could not read the chart, values are left empty: %v\n", err))
+		} else if schema, ok := files.ValuesSchema(); ok {
+			values, placeholders, err = appcatalogentry.DefaultValuesFromSchema(schema)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			output.WriteString("# The chart has no values schema, values are left empty\n")
+		}
+
+		manifests, err := scaffoldManifests(scaffoldOptions{
+			Name:            name,
+			Namespace:       namespace,
+			Catalog:         catalog,
+			App:             appName,
+			Version:         strings.TrimPrefix(entry.GetLatestVersion(), "v"),
+			TargetNamespace: targetNamespace,
+			Cluster:         cluster,
+			Values:          values,
+			WithSecret:      getBoolArg(args, "with-secret"),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(placeholders) > 0 {
+			output.WriteString(fmt.Sprintf("# Replace the placeholders of these required values before applying: %s\n", strings.Join(placeholders, ", ")))
+		}
+		output.WriteString(manifests)
+
+		return mcp.NewToolResultText(output.String()), nil
+	})
+}
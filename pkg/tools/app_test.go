@@ -0,0 +1,46 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	gstesting "github.com/giantswarm/mcp-giantswarm-apps/pkg/testing"
+)
+
+func TestForceCleanupWaitsForStuckAfter(t *testing.T) {
+	terminating := gstesting.DeployedApp("org-acme", "hello", "giantswarm", "1.0.0")
+	terminating.SetFinalizers([]string{app.AppOperatorFinalizer})
+	terminating.SetDeletionTimestamp(&metav1.Time{Time: time.Now().Add(-time.Minute)})
+	ctx := gstesting.NewServerContext(terminating)
+	s := mcpserver.NewMCPServer("test", "0.0.0")
+	if err := RegisterAppTools(s, ctx); err != nil {
+		t.Fatal(err)
+	}
+	args := map[string]interface{}{
+		"name": "hello", "namespace": "org-acme", "remove-finalizer": app.AppOperatorFinalizer, "confirm": "org-acme/hello",
+	}
+
+	// Deleted a minute ago, the app is not stuck by the default of 10m yet
+	_, err := gstesting.CallTool(context.Background(), s, "app_force_cleanup", args)
+	if err == nil || !strings.Contains(err.Error(), "only been terminating for") {
+		t.Errorf("app_force_cleanup of a recently deleted app: error = %v, want refused", err)
+	}
+	current, err := app.NewClient(ctx.DynamicClient).Get(context.Background(), "org-acme", "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(current.Finalizers) != 1 {
+		t.Errorf("finalizers = %v, want the finalizer kept", current.Finalizers)
+	}
+
+	args["stuck-after"] = "30s"
+	if _, err := gstesting.CallTool(context.Background(), s, "app_force_cleanup", args); err != nil {
+		t.Errorf("app_force_cleanup with stuck-after 30s: %v", err)
+	}
+}
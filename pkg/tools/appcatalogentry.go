@@ -2,19 +2,37 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	mcpserver "github.com/mark3labs/mcp-go/server"
+	"k8s.io/apimachinery/pkg/util/duration"
 
 	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
 	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/catalog"
 )
 
+// entryList is the JSON output of appcatalogentry_list. Count is the number
+// of all entries, of which Entries holds those that fit the output budget.
+type entryList struct {
+	Count    int                       `json:"count"`
+	Entries  []appcatalogentry.Summary `json:"entries"`
+	Note     string                    `json:"note,omitempty"`
+	Continue string                    `json:"continue,omitempty"`
+}
+
+// defaultSearchLimit is the number of apps appcatalogentry_search shows by default
+const defaultSearchLimit = 10
+
 // RegisterAppCatalogEntryTools registers all AppCatalogEntry management tools
 func RegisterAppCatalogEntryTools(s *mcpserver.MCPServer, ctx *server.Context) error {
-	client := appcatalogentry.NewClient(ctx.DynamicClient)
+	client := appcatalogentry.NewClient(ctx.DynamicClient).WithIndex(ctx.AppCatalogEntryIndex)
 
 	// appcatalogentry_list tool
 	listTool := mcp.NewTool(
@@ -25,6 +43,8 @@ func RegisterAppCatalogEntryTools(s *mcpserver.MCPServer, ctx *server.Context) e
 		mcp.WithString("catalog-namespace", mcp.Description("Catalog namespace (used with catalog filter)")),
 		mcp.WithBoolean("cluster-apps", mcp.Description("Show only cluster-wide apps")),
 		mcp.WithBoolean("latest-only", mcp.Description("Show only latest version of each app")),
+		mcp.WithString("format", mcp.Description("Output format: text or json (default: text); json includes icon, home, keywords and upstream version, and the continuation token in continue")),
+		withContinue(),
 	)
 
 	s.AddTool(listTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -35,6 +55,10 @@ func RegisterAppCatalogEntryTools(s *mcpserver.MCPServer, ctx *server.Context) e
 		catalogNamespace := getStringArg(args, "catalog-namespace")
 		clusterApps := getBoolArg(args, "cluster-apps")
 		latestOnly := getBoolArg(args, "latest-only")
+		format := getStringArg(args, "format")
+		if format != "" && format != "text" && format != "json" {
+			return nil, fmt.Errorf("invalid format: %s (must be text or json)", format)
+		}
 
 		var entries []*appcatalogentry.AppCatalogEntry
 		var err error
@@ -67,15 +91,47 @@ func RegisterAppCatalogEntryTools(s *mcpserver.MCPServer, ctx *server.Context) e
 			}
 		}
 
+		sort.SliceStable(entries, func(i, j int) bool {
+			if entries[i].Spec.Catalog.Name != entries[j].Spec.Catalog.Name {
+				return entries[i].Spec.Catalog.Name < entries[j].Spec.Catalog.Name
+			}
+			return entries[i].GetAppName() < entries[j].GetAppName()
+		})
+
+		if format == "json" {
+			summaries := make([]appcatalogentry.Summary, 0, len(entries))
+			encoded := make([]string, 0, len(entries))
+			for _, entry := range entries {
+				summary := entry.Summary()
+				data, err := json.Marshal(summary)
+				if err != nil {
+					return nil, fmt.Errorf("failed to encode entry %s/%s: %w", entry.Namespace, entry.Name, err)
+				}
+				summaries = append(summaries, summary)
+				encoded = append(encoded, string(data)+",")
+			}
+
+			// Reserve room for the count, note and continuation token
+			const envelopeSize = 200
+			offset, n, note, err := budgetedPage(ctx.OutputBudget, args, encoded, envelopeSize)
+			if err != nil {
+				return nil, err
+			}
+			list := entryList{Count: len(summaries), Entries: summaries[offset : offset+n], Note: note}
+			if next := offset + n; next < len(summaries) {
+				list.Continue = encodeContinueToken(next, len(summaries))
+			}
+			return mcp.NewToolResultJSON(list)
+		}
+
 		// Format output
 		if len(entries) == 0 {
 			return mcp.NewToolResultText("No app catalog entries found"), nil
 		}
 
-		var output strings.Builder
-		output.WriteString(fmt.Sprintf("Found %d app catalog entries:\n\n", len(entries)))
-
+		blocks := make([]string, 0, len(entries))
 		for _, entry := range entries {
+			var output strings.Builder
 			output.WriteString(fmt.Sprintf("Name: %s\n", entry.Name))
 			output.WriteString(fmt.Sprintf("App: %s\n", entry.Spec.AppName))
 			output.WriteString(fmt.Sprintf("Version: %s (App: %s)\n", entry.GetLatestVersion(), entry.GetAppVersion()))
@@ -86,16 +142,27 @@ func RegisterAppCatalogEntryTools(s *mcpserver.MCPServer, ctx *server.Context) e
 			if entry.IsClusterApp() {
 				output.WriteString("Type: Cluster App\n")
 			}
+			if updated := entry.LastUpdated(); updated != nil {
+				output.WriteString(fmt.Sprintf("Updated: %s\n", entryAge(*updated, time.Now())))
+			}
+			if deprecated, note := entry.Deprecation(); deprecated {
+				output.WriteString(fmt.Sprintf("Deprecated: %s\n", deprecationText(note)))
+			}
 			output.WriteString("---\n")
+			blocks = append(blocks, output.String())
 		}
 
-		return mcp.NewToolResultText(output.String()), nil
+		result, err := budgetedList(ctx.OutputBudget, args, fmt.Sprintf("Found %d app catalog entries:\n\n", len(entries)), blocks)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText(result), nil
 	})
 
 	// appcatalogentry_get tool
 	getTool := mcp.NewTool(
 		"appcatalogentry_get",
-		mcp.WithDescription("Get detailed information about a specific app catalog entry"),
+		mcp.WithDescription("Get detailed information about a specific app catalog entry, including its upstream chart, license and maintainers"),
 		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the app catalog entry")),
 		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace of the app catalog entry")),
 	)
@@ -113,6 +180,9 @@ func RegisterAppCatalogEntryTools(s *mcpserver.MCPServer, ctx *server.Context) e
 		var output strings.Builder
 		output.WriteString(fmt.Sprintf("App Catalog Entry: %s\n", entry.Name))
 		output.WriteString(fmt.Sprintf("Namespace: %s\n", entry.Namespace))
+		if deprecated, note := entry.Deprecation(); deprecated {
+			output.WriteString(fmt.Sprintf("Deprecated: %s\n", deprecationText(note)))
+		}
 
 		output.WriteString("\nApp Information:\n")
 		output.WriteString(fmt.Sprintf("  App Name: %s\n", entry.Spec.AppName))
@@ -154,6 +224,36 @@ func RegisterAppCatalogEntryTools(s *mcpserver.MCPServer, ctx *server.Context) e
 			}
 		}
 
+		output.WriteString("\nUpstream and License:\n")
+		if info, err := client.GetChartInfo(toolCtx, entry); err != nil {
+			output.WriteString(fmt.Sprintf("  Unavailable: %v\n", err))
+		} else {
+			if info.UpstreamChartURL != "" {
+				output.WriteString(fmt.Sprintf("  Upstream Chart: %s\n", info.UpstreamChartURL))
+			}
+			if info.UpstreamChartVersion != "" {
+				output.WriteString(fmt.Sprintf("  Upstream Chart Version: %s\n", info.UpstreamChartVersion))
+			}
+			license := info.License
+			if license == "" {
+				license = "not declared"
+			}
+			output.WriteString(fmt.Sprintf("  License: %s\n", license))
+			if len(info.Maintainers) > 0 {
+				output.WriteString("  Maintainers:\n")
+				for _, m := range info.Maintainers {
+					maintainer := m.Name
+					if m.Email != "" {
+						maintainer += fmt.Sprintf(" <%s>", m.Email)
+					}
+					if m.URL != "" {
+						maintainer += " " + m.URL
+					}
+					output.WriteString(fmt.Sprintf("    - %s\n", maintainer))
+				}
+			}
+		}
+
 		if entry.Spec.Restrictions != nil {
 			output.WriteString("\nRestrictions:\n")
 			output.WriteString(fmt.Sprintf("  Cluster Singleton: %v\n", entry.Spec.Restrictions.ClusterSingleton))
@@ -170,6 +270,9 @@ func RegisterAppCatalogEntryTools(s *mcpserver.MCPServer, ctx *server.Context) e
 		if entry.Spec.DateUpdated != nil {
 			output.WriteString(fmt.Sprintf("Updated: %s\n", entry.Spec.DateUpdated.Format("2006-01-02 15:04:05")))
 		}
+		if updated := entry.LastUpdated(); updated != nil {
+			output.WriteString(fmt.Sprintf("Age: %s since the last update\n", duration.HumanDuration(time.Since(*updated))))
+		}
 
 		return mcp.NewToolResultText(output.String()), nil
 	})
@@ -177,63 +280,156 @@ func RegisterAppCatalogEntryTools(s *mcpserver.MCPServer, ctx *server.Context) e
 	// appcatalogentry_search tool
 	searchTool := mcp.NewTool(
 		"appcatalogentry_search",
-		mcp.WithDescription("Search for apps in the catalog"),
-		mcp.WithString("query", mcp.Required(), mcp.Description("Search query (searches in name, description, keywords)")),
+		mcp.WithDescription("Search for apps in the catalogs, best matches first (name matches rank above keywords and descriptions, small typos are tolerated)"),
+		mcp.WithString("query", mcp.Required(), mcp.Description("Search query (searches in name, keywords, description)")),
 		mcp.WithBoolean("cluster-apps", mcp.Description("Show only cluster-wide apps")),
+		mcp.WithString("catalog", mcp.Description("Only search this catalog")),
+		mcp.WithString("catalog-type", mcp.Description("Only search catalogs of this type"), mcp.Enum(catalog.Types...)),
+		mcp.WithString("catalog-visibility", mcp.Description("Only search catalogs with this visibility"), mcp.Enum(catalog.Visibilities...)),
+		mcp.WithString("limit", mcp.Description("Maximum number of apps to show (default: 10)")),
+		mcp.WithBoolean("include-deprecated", mcp.Description("Include apps whose latest version is deprecated (default: false)")),
+		withRefresh(),
 	)
 
 	s.AddTool(searchTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := req.Params.Arguments.(map[string]interface{})
 		query := args["query"].(string)
 		clusterApps := getBoolArg(args, "cluster-apps")
+		includeDeprecated := getBoolArg(args, "include-deprecated")
+		catalogName := getStringArg(args, "catalog")
+		catalogType := getStringArg(args, "catalog-type")
+		if catalogType != "" {
+			var err error
+			if catalogType, err = catalog.NormalizeType(catalogType); err != nil {
+				return nil, err
+			}
+		}
+		catalogVisibility := getStringArg(args, "catalog-visibility")
+		if catalogVisibility != "" {
+			var err error
+			if catalogVisibility, err = catalog.NormalizeVisibility(catalogVisibility); err != nil {
+				return nil, err
+			}
+		}
 
-		results, err := client.Search(toolCtx, query)
+		limit := defaultSearchLimit
+		if limitStr := getStringArg(args, "limit"); limitStr != "" {
+			n, err := strconv.Atoi(limitStr)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid limit %q: must be a positive number", limitStr)
+			}
+			limit = n
+		}
+
+		ranked, err := client.SearchRanked(toolCtx, query)
 		if err != nil {
 			return nil, err
 		}
 
-		// Apply filters
-		if clusterApps {
-			results = appcatalogentry.FilterByRestrictions(results, true)
+		// Catalog type and visibility are labels of the Catalog, not of its entries
+		var allowedCatalogs map[string]bool
+		if catalogType != "" || catalogVisibility != "" {
+			catalogs, err := catalog.NewClient(ctx.DynamicClient).List(toolCtx, "")
+			if err != nil {
+				return nil, err
+			}
+			allowedCatalogs = make(map[string]bool)
+			for _, c := range catalogs {
+				if (catalogType == "" || c.CatalogType() == catalogType) &&
+					(catalogVisibility == "" || c.CatalogVisibility() == catalogVisibility) {
+					allowedCatalogs[c.Namespace+"/"+c.Name] = true
+				}
+			}
 		}
 
-		if len(results) == 0 {
-			return mcp.NewToolResultText(fmt.Sprintf("No apps found matching '%s'", query)), nil
+		// Group by app, keeping the order of each app's best match
+		type appResult struct {
+			key      string
+			versions []*appcatalogentry.AppCatalogEntry
 		}
+		var apps []*appResult
+		byKey := make(map[string]*appResult)
+		for _, result := range ranked {
+			entry := result.Entry
+			if clusterApps && !entry.IsClusterApp() {
+				continue
+			}
+			if catalogName != "" && entry.Spec.Catalog.Name != catalogName {
+				continue
+			}
+			if allowedCatalogs != nil && !allowedCatalogs[entry.Spec.Catalog.Namespace+"/"+entry.Spec.Catalog.Name] {
+				continue
+			}
 
-		var output strings.Builder
-		output.WriteString(fmt.Sprintf("Found %d apps matching '%s':\n\n", len(results), query))
+			key := entry.Spec.Catalog.Name + "/" + entry.GetAppName()
+			group, ok := byKey[key]
+			if !ok {
+				group = &appResult{key: key}
+				byKey[key] = group
+				apps = append(apps, group)
+			}
+			group.versions = append(group.versions, entry)
+		}
+
+		// An app is deprecated when its latest version is; the group keeps
+		// its versions sorted by date from here on
+		hiddenDeprecated := 0
+		visible := apps[:0]
+		for _, group := range apps {
+			group.versions = appcatalogentry.SortByDate(group.versions)
+			if deprecated, _ := group.versions[0].Deprecation(); deprecated && !includeDeprecated {
+				hiddenDeprecated++
+				continue
+			}
+			visible = append(visible, group)
+		}
+		apps = visible
+		deprecatedNote := ""
+		if hiddenDeprecated > 0 {
+			deprecatedNote = fmt.Sprintf("%d deprecated apps hidden, pass include-deprecated: true to show them\n", hiddenDeprecated)
+		}
 
-		// Group by app to show all versions together
-		grouped := appcatalogentry.GroupByApp(results)
+		if len(apps) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("No apps found matching '%s'\n%s", query, deprecatedNote)), nil
+		}
 
-		for appName, versions := range grouped {
-			output.WriteString(fmt.Sprintf("App: %s\n", appName))
+		var output strings.Builder
+		if len(apps) > limit {
+			output.WriteString(fmt.Sprintf("Found %d apps matching '%s', showing the best %d:\n\n", len(apps), query, limit))
+			apps = apps[:limit]
+		} else {
+			output.WriteString(fmt.Sprintf("Found %d apps matching '%s':\n\n", len(apps), query))
+		}
 
-			// Sort versions by date
-			sorted := appcatalogentry.SortByDate(versions)
+		for _, group := range apps {
+			output.WriteString(fmt.Sprintf("App: %s\n", group.key))
 
-			for i, entry := range sorted {
-				if i == 0 {
-					output.WriteString(fmt.Sprintf("  Latest: %s (App: %s)\n", entry.GetLatestVersion(), entry.GetAppVersion()))
-					if entry.Spec.Chart.Description != "" {
-						output.WriteString(fmt.Sprintf("  Description: %s\n", entry.Spec.Chart.Description))
-					}
-					output.WriteString(fmt.Sprintf("  Catalog: %s/%s\n", entry.Spec.Catalog.Namespace, entry.Spec.Catalog.Name))
-					if entry.IsClusterApp() {
-						output.WriteString("  Type: Cluster App\n")
-					}
-				} else {
-					output.WriteString(fmt.Sprintf("  Other versions: %s", entry.GetLatestVersion()))
-					if i < len(sorted)-1 {
-						output.WriteString(", ")
-					} else {
-						output.WriteString("\n")
-					}
+			sorted := group.versions
+			latest := sorted[0]
+			output.WriteString(fmt.Sprintf("  Latest: %s (App: %s)\n", latest.GetLatestVersion(), latest.GetAppVersion()))
+			if latest.Spec.Chart.Description != "" {
+				output.WriteString(fmt.Sprintf("  Description: %s\n", latest.Spec.Chart.Description))
+			}
+			output.WriteString(fmt.Sprintf("  Catalog: %s/%s\n", latest.Spec.Catalog.Namespace, latest.Spec.Catalog.Name))
+			if latest.IsClusterApp() {
+				output.WriteString("  Type: Cluster App\n")
+			}
+			if updated := latest.LastUpdated(); updated != nil {
+				output.WriteString(fmt.Sprintf("  Updated: %s\n", entryAge(*updated, time.Now())))
+			}
+			if deprecated, note := latest.Deprecation(); deprecated {
+				output.WriteString(fmt.Sprintf("  Deprecated: %s\n", deprecationText(note)))
+			}
+			if len(sorted) > 1 {
+				others := make([]string, 0, len(sorted)-1)
+				for _, entry := range sorted[1:] {
+					others = append(others, entry.GetLatestVersion())
 				}
+				output.WriteString(fmt.Sprintf("  Other versions: %s\n", strings.Join(others, ", ")))
 			}
 			output.WriteString("---\n")
 		}
+		output.WriteString(deprecatedNote)
 
 		return mcp.NewToolResultText(output.String()), nil
 	})
@@ -243,6 +439,7 @@ func RegisterAppCatalogEntryTools(s *mcpserver.MCPServer, ctx *server.Context) e
 		"appcatalogentry_versions",
 		mcp.WithDescription("List all available versions of an app"),
 		mcp.WithString("app", mcp.Required(), mcp.Description("App name to get versions for")),
+		withRefresh(),
 	)
 
 	s.AddTool(versionsTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -282,5 +479,54 @@ func RegisterAppCatalogEntryTools(s *mcpserver.MCPServer, ctx *server.Context) e
 		return mcp.NewToolResultText(output.String()), nil
 	})
 
+	// appcatalogentry_readme tool
+	readmeTool := mcp.NewTool(
+		"appcatalogentry_readme",
+		mcp.WithDescription("Show the README of an app version from its chart package"),
+		mcp.WithString("catalog", mcp.Required(), mcp.Description("Catalog name")),
+		mcp.WithString("app", mcp.Required(), mcp.Description("App name")),
+		mcp.WithString("version", mcp.Required(), mcp.Description("App version")),
+		withRefresh(),
+		withPart(),
+	)
+
+	s.AddTool(readmeTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		catalogName := args["catalog"].(string)
+		appName := args["app"].(string)
+		version := args["version"].(string)
+
+		entry, err := client.FindVersion(toolCtx, catalogName, appName, version)
+		if err != nil {
+			return nil, err
+		}
+
+		files, err := appcatalogentry.FetchChart(toolCtx, entry)
+		if err != nil {
+			return nil, err
+		}
+
+		readme, err := files.Readme()
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("No README available for %s/%s@%s", catalogName, appName, version)), nil
+		}
+
+		return mcp.NewToolResultText(readme), nil
+	})
+
 	return nil
 }
+
+// entryAge renders when a catalog entry was last updated with its age, e.g.
+// "2026-01-05 (45d ago)"
+func entryAge(updated, now time.Time) string {
+	return fmt.Sprintf("%s (%s ago)", updated.Format("2006-01-02"), duration.HumanDuration(now.Sub(updated)))
+}
+
+// deprecationText renders the note of a deprecated entry
+func deprecationText(note string) string {
+	if note == "" {
+		return "yes"
+	}
+	return "yes, " + note
+}
@@ -0,0 +1,165 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+)
+
+// continueArgument resumes a listing that was cut at the output budget
+const continueArgument = "continue"
+
+// partArgument selects the part of a large result split at the output budget
+const partArgument = "part"
+
+// withContinue adds the continuation argument to a listing tool
+func withContinue() mcp.ToolOption {
+	return mcp.WithString(continueArgument, mcp.Description("Continuation token from a previous result that was cut at the output budget"))
+}
+
+// withPart adds the part argument to a tool returning a large document, whose
+// result is split into parts instead of being cut at the output budget
+func withPart() mcp.ToolOption {
+	return mcp.WithString(partArgument, mcp.Description("Part of a result that exceeds the output budget to return, starting at 1 (default: 1)"))
+}
+
+// encodeContinueToken returns an opaque token for the entry at offset of a
+// list of total entries
+func encodeContinueToken(offset, total int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d/%d", offset, total)))
+}
+
+func decodeContinueToken(token string) (offset, total int, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		_, err = fmt.Sscanf(string(data), "%d/%d", &offset, &total)
+	}
+	if err != nil || offset < 0 || total < 0 {
+		return 0, 0, fmt.Errorf("invalid continuation token %q", token)
+	}
+	return offset, total, nil
+}
+
+// budgetedPage returns the offset and number of the entries that fit the
+// output budget after reserved bytes, starting at the continuation token in
+// args, and a note when the list changed since the token was issued
+func budgetedPage(budget server.OutputBudget, args map[string]interface{}, entries []string, reserved int) (offset, n int, note string, err error) {
+	if token := getStringArg(args, continueArgument); token != "" {
+		var total int
+		if offset, total, err = decodeContinueToken(token); err != nil {
+			return 0, 0, "", err
+		}
+		if offset >= len(entries) {
+			return 0, 0, "", fmt.Errorf("continuation token is past the end of the %d entries, the list changed since it was issued", len(entries))
+		}
+		if total != len(entries) {
+			note = fmt.Sprintf("the list changed since the previous page (%d entries, now %d)", total, len(entries))
+		}
+	}
+	return offset, budget.Fit(entries, offset, reserved+len(note)), note, nil
+}
+
+// budgetedList renders title and the entries of a listing that fit the output
+// budget, starting at the continuation token in args. When entries are left
+// out it ends with the token that returns the next ones.
+func budgetedList(budget server.OutputBudget, args map[string]interface{}, title string, entries []string) (string, error) {
+	// Reserve room for the continuation note
+	const noteSize = 160
+	offset, n, note, err := budgetedPage(budget, args, entries, len(title)+noteSize)
+	if err != nil {
+		return "", err
+	}
+
+	var output strings.Builder
+	output.WriteString(title)
+	if note != "" {
+		output.WriteString(fmt.Sprintf("Note: %s\n\n", note))
+	}
+	for _, entry := range entries[offset : offset+n] {
+		output.WriteString(entry)
+	}
+
+	if next := offset + n; next < len(entries) {
+		output.WriteString(fmt.Sprintf("\nShowing entries %d-%d of %d. Call again with %s: %q for more.\n",
+			offset+1, next, len(entries), continueArgument, encodeContinueToken(next, len(entries))))
+	}
+
+	return output.String(), nil
+}
+
+// budgetedPart returns the part of text selected by the part argument in
+// args. Text exceeding the budget is split into parts, each ending with a note
+// on how to get the next one.
+func budgetedPart(budget server.OutputBudget, args map[string]interface{}, text string) (string, error) {
+	part := 1
+	if value := getStringArg(args, partArgument); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return "", fmt.Errorf("invalid part %q: must be a positive number", value)
+		}
+		part = n
+	}
+
+	// Reserve room for the part note
+	const noteSize = 160
+	if budget.MaxChars > 0 {
+		budget.MaxChars = max(budget.MaxChars-noteSize, noteSize)
+	}
+	parts := budget.Parts(text)
+	if part > len(parts) {
+		return "", fmt.Errorf("part %d is past the end of the result, which has %d parts", part, len(parts))
+	}
+	if len(parts) == 1 {
+		return text, nil
+	}
+
+	note := fmt.Sprintf("\n[part %d of %d", part, len(parts))
+	if part < len(parts) {
+		note += fmt.Sprintf(", call again with %s: %q for the next part", partArgument, strconv.Itoa(part+1))
+	}
+	return parts[part-1] + note + "]\n", nil
+}
+
+// OutputBudgetMiddleware cuts text results that exceed the output budget,
+// so that tools without pagination cannot overflow the client's context.
+// Tools with a part argument return the selected part instead. Structured
+// results are left alone since cutting them would break the JSON.
+func OutputBudgetMiddleware(ctx *server.Context) mcpserver.ToolHandlerMiddleware {
+	return func(next mcpserver.ToolHandlerFunc) mcpserver.ToolHandlerFunc {
+		return func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(toolCtx, req)
+			if err != nil || result == nil || result.StructuredContent != nil {
+				return result, err
+			}
+
+			split := false
+			if srv := mcpserver.ServerFromContext(toolCtx); srv != nil {
+				if tool := srv.GetTool(req.Params.Name); tool != nil {
+					_, split = tool.Tool.InputSchema.Properties[partArgument]
+				}
+			}
+			args, _ := req.Params.Arguments.(map[string]interface{})
+
+			for i, content := range result.Content {
+				if text, ok := content.(mcp.TextContent); ok {
+					if split {
+						if text.Text, err = budgetedPart(ctx.OutputBudget, args, text.Text); err != nil {
+							return nil, err
+						}
+					} else {
+						text.Text, _ = ctx.OutputBudget.Truncate(text.Text)
+					}
+					result.Content[i] = text
+				}
+			}
+			return result, nil
+		}
+	}
+}
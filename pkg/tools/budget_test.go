@@ -0,0 +1,131 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	gstesting "github.com/giantswarm/mcp-giantswarm-apps/pkg/testing"
+)
+
+var continueToken = regexp.MustCompile(`continue: "([^"]+)"`)
+
+func TestBudgetedList(t *testing.T) {
+	blocks := make([]string, 0, 5)
+	for i := 1; i <= 5; i++ {
+		blocks = append(blocks, fmt.Sprintf("Name: app-%d\n---\n", i))
+	}
+	budget := server.OutputBudget{MaxItems: 2}
+
+	var seen []string
+	args := map[string]interface{}{}
+	for page := 0; page < 5; page++ {
+		output, err := budgetedList(budget, args, "Found 5 apps:\n\n", blocks)
+		if err != nil {
+			t.Fatalf("page %d: %v", page, err)
+		}
+		for _, line := range strings.Split(output, "\n") {
+			if strings.HasPrefix(line, "Name: ") {
+				seen = append(seen, strings.TrimPrefix(line, "Name: "))
+			}
+		}
+
+		m := continueToken.FindStringSubmatch(output)
+		if m == nil {
+			break
+		}
+		args[continueArgument] = m[1]
+	}
+
+	if got := strings.Join(seen, ","); got != "app-1,app-2,app-3,app-4,app-5" {
+		t.Errorf("pages returned %s, want every app once", got)
+	}
+
+	if _, err := budgetedList(budget, map[string]interface{}{continueArgument: encodeContinueToken(9, 10)}, "", blocks); err == nil {
+		t.Error("token past the end of the list was accepted")
+	}
+	if _, err := budgetedList(budget, map[string]interface{}{continueArgument: "not a token"}, "", blocks); err == nil {
+		t.Error("invalid token was accepted")
+	}
+}
+
+func TestAppCatalogEntryListJSONPages(t *testing.T) {
+	ctx := gstesting.NewServerContext(
+		gstesting.CatalogEntry("giantswarm", "cert-manager", "1.0.0"),
+		gstesting.CatalogEntry("giantswarm", "external-dns", "1.0.0"),
+		gstesting.CatalogEntry("giantswarm", "ingress-nginx", "1.0.0"),
+	)
+	ctx.OutputBudget = server.OutputBudget{MaxItems: 2}
+	s := mcpserver.NewMCPServer("test", "0.0.0")
+	if err := RegisterAppCatalogEntryTools(s, ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var seen []string
+	args := map[string]interface{}{"format": "json"}
+	for page := 0; page < 3; page++ {
+		output, err := gstesting.CallTool(context.Background(), s, "appcatalogentry_list", args)
+		if err != nil {
+			t.Fatalf("page %d: %v", page, err)
+		}
+		var list entryList
+		if err := json.Unmarshal([]byte(output), &list); err != nil {
+			t.Fatalf("page %d is no entry list: %v", page, err)
+		}
+		if list.Count != 3 || len(list.Entries) > 2 {
+			t.Errorf("page %d has count %d and %d entries, want 3 and at most 2", page, list.Count, len(list.Entries))
+		}
+		for _, entry := range list.Entries {
+			seen = append(seen, entry.App)
+		}
+		if list.Continue == "" {
+			break
+		}
+		args[continueArgument] = list.Continue
+	}
+
+	if got := strings.Join(seen, ","); got != "cert-manager,external-dns,ingress-nginx" {
+		t.Errorf("pages returned %s, want every entry once", got)
+	}
+}
+
+var partNote = regexp.MustCompile(`\n\[part \d+ of \d+[^\]]*\]\n$`)
+
+func TestBudgetedPart(t *testing.T) {
+	budget := server.OutputBudget{MaxChars: 200}
+	var text strings.Builder
+	for i := 1; i <= 20; i++ {
+		text.WriteString(fmt.Sprintf("key-%02d: value\n", i))
+	}
+
+	var joined strings.Builder
+	args := map[string]interface{}{}
+	for part := 1; ; part++ {
+		args[partArgument] = fmt.Sprint(part)
+		output, err := budgetedPart(budget, args, text.String())
+		if err != nil {
+			t.Fatalf("part %d: %v", part, err)
+		}
+		joined.WriteString(partNote.ReplaceAllString(output, ""))
+		if !strings.Contains(output, "for the next part") {
+			break
+		}
+	}
+	if joined.String() != text.String() {
+		t.Errorf("parts joined = %q, want the whole text", joined.String())
+	}
+
+	args[partArgument] = "99"
+	if _, err := budgetedPart(budget, args, text.String()); err == nil {
+		t.Error("a part past the end should fail")
+	}
+	if output, err := budgetedPart(server.OutputBudget{}, map[string]interface{}{}, text.String()); err != nil || output != text.String() {
+		t.Errorf("unlimited budget returned %q, %v", output, err)
+	}
+}
@@ -0,0 +1,82 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+)
+
+// refreshArgument bypasses the result cache
+const refreshArgument = "refresh"
+
+// cachedTools are the read-only tools whose results are cached: they download
+// charts or scan all catalog entries, and agents tend to repeat them
+var cachedTools = map[string]bool{
+	"config_schema":            true,
+	"appcatalogentry_search":   true,
+	"appcatalogentry_versions": true,
+	"appcatalogentry_readme":   true,
+}
+
+// withRefresh adds the cache bypass argument to a cached tool
+func withRefresh() mcp.ToolOption {
+	return mcp.WithBoolean(refreshArgument, mcp.Description("Fetch a fresh result instead of one cached by a previous identical call"))
+}
+
+// ResultCacheMiddleware answers repeated identical calls of the cached tools,
+// also through their aliases, from the server's result cache. Cached results
+// end with a note of their age; refresh: true fetches a fresh one and replaces
+// it.
+func ResultCacheMiddleware(ctx *server.Context, aliases *ToolAliases) mcpserver.ToolHandlerMiddleware {
+	return func(next mcpserver.ToolHandlerFunc) mcpserver.ToolHandlerFunc {
+		return func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if ctx.ResultCache == nil || !cachedTools[aliases.Resolve(req.Params.Name)] {
+				return next(toolCtx, req)
+			}
+
+			args, _ := req.Params.Arguments.(map[string]interface{})
+			key := resultCacheKey(toolCtx, req.Params.Name, args, ctx.Defaults(toolCtx))
+			if !getBoolArg(args, refreshArgument) {
+				if result, age, ok := ctx.ResultCache.Get(key); ok {
+					result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf(
+						"\n[cached result from %s ago, call again with %s: true for a fresh one]", age.Round(time.Second), refreshArgument)))
+					return result, nil
+				}
+			}
+
+			result, err := next(toolCtx, req)
+			if err == nil && result != nil && !result.IsError {
+				ctx.ResultCache.Put(key, result)
+			}
+			return result, err
+		}
+	}
+}
+
+// resultCacheKey identifies calls with the same result: the tool as called,
+// since results through an alias carry its deprecation warning, its
+// arguments other than refresh, the session defaults filling in omitted ones
+// and the impersonated user of the session
+func resultCacheKey(toolCtx context.Context, tool string, args map[string]interface{}, defaults server.Defaults) string {
+	keyArgs := make(map[string]interface{}, len(args))
+	for name, value := range args {
+		if name != refreshArgument {
+			keyArgs[name] = value
+		}
+	}
+	// encoding/json sorts map keys
+	data, _ := json.Marshal(keyArgs)
+
+	user := ""
+	if info, ok := k8s.ImpersonatedUser(toolCtx); ok {
+		user = info.Username
+	}
+	return user + "\x00" + tool + "\x00" + string(data) + "\x00" + defaults.Organization + "/" + defaults.Cluster + "/" + defaults.Namespace
+}
@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+)
+
+func TestResultCacheMiddleware(t *testing.T) {
+	ctx := &server.Context{ResultCache: server.NewResultCache(time.Minute)}
+	calls := 0
+	aliases := NewToolAliases()
+	aliases.aliases["appcatalogentry.search"] = "appcatalogentry_search"
+	handler := ResultCacheMiddleware(ctx, aliases)(func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		return mcp.NewToolResultText("result"), nil
+	})
+
+	call := func(tool string, args map[string]interface{}) *mcp.CallToolResult {
+		t.Helper()
+		var req mcp.CallToolRequest
+		req.Params.Name = tool
+		req.Params.Arguments = args
+		result, err := handler(context.Background(), req)
+		if err != nil {
+			t.Fatalf("%s error = %v", tool, err)
+		}
+		return result
+	}
+
+	call("appcatalogentry_search", map[string]interface{}{"query": "nginx"})
+	cached := call("appcatalogentry_search", map[string]interface{}{"query": "nginx"})
+	if calls != 1 {
+		t.Errorf("identical call ran the tool again, %d calls", calls)
+	}
+	if len(cached.Content) != 2 || !strings.Contains(cached.Content[1].(mcp.TextContent).Text, "cached result") {
+		t.Errorf("cached result has no freshness note: %+v", cached.Content)
+	}
+
+	call("appcatalogentry_search", map[string]interface{}{"query": "nginx", "refresh": true})
+	if calls != 2 {
+		t.Errorf("refresh did not run the tool, %d calls", calls)
+	}
+	call("appcatalogentry_search", map[string]interface{}{"query": "kyverno"})
+	if calls != 3 {
+		t.Errorf("call with other arguments was cached, %d calls", calls)
+	}
+
+	// Notes are not added to the cached result itself
+	if result := call("appcatalogentry_search", map[string]interface{}{"query": "nginx"}); len(result.Content) != 2 {
+		t.Errorf("cached result has %d contents, want 2", len(result.Content))
+	}
+
+	call("app_list", nil)
+	call("app_list", nil)
+	if calls != 5 {
+		t.Errorf("uncached tool was answered from the cache, %d calls", calls)
+	}
+
+	// Calls through an alias are cached like those of the tool
+	call("appcatalogentry.search", map[string]interface{}{"query": "nginx"})
+	cached = call("appcatalogentry.search", map[string]interface{}{"query": "nginx"})
+	if calls != 6 || len(cached.Content) != 2 {
+		t.Errorf("identical call through an alias ran the tool again, %d calls", calls)
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	mcpserver "github.com/mark3labs/mcp-go/server"
 
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
 	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
 	"github.com/giantswarm/mcp-giantswarm-apps/pkg/catalog"
 	"github.com/giantswarm/mcp-giantswarm-apps/pkg/organization"
 )
@@ -16,6 +18,7 @@ import (
 // RegisterCatalogTools registers all catalog management tools
 func RegisterCatalogTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 	catalogClient := catalog.NewClient(ctx.DynamicClient)
+	appClient := app.NewClient(ctx.DynamicClient)
 
 	// catalog_list tool
 	listTool := mcp.NewTool(
@@ -23,9 +26,10 @@ func RegisterCatalogTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 		mcp.WithDescription("List Giant Swarm catalogs"),
 		mcp.WithString("namespace", mcp.Description("Namespace to list catalogs from (empty for all namespaces)")),
 		mcp.WithString("organization", mcp.Description("Organization to list catalogs from (e.g., 'giantswarm')")),
-		mcp.WithString("type", mcp.Description("Filter by catalog type (stable, testing, community)")),
-		mcp.WithString("visibility", mcp.Description("Filter by visibility (public, private)")),
+		mcp.WithString("type", mcp.Description("Filter by catalog type"), mcp.Enum(catalog.Types...)),
+		mcp.WithString("visibility", mcp.Description("Filter by visibility"), mcp.Enum(catalog.Visibilities...)),
 		mcp.WithBoolean("all-orgs", mcp.Description("List catalogs from all organization namespaces")),
+		withContinue(),
 	)
 
 	s.AddTool(listTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -33,12 +37,13 @@ func RegisterCatalogTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 
 		namespace := getStringArg(args, "namespace")
 		org := getStringArg(args, "organization")
-		catalogType := getStringArg(args, "type")
-		visibility := getStringArg(args, "visibility")
+		catalogType, visibility, err := catalogLabelArgs(args)
+		if err != nil {
+			return nil, err
+		}
 		allOrgs := getBoolArg(args, "all-orgs")
 
 		var catalogs []*catalog.Catalog
-		var err error
 
 		// Determine which namespaces to query
 		if org != "" {
@@ -80,10 +85,9 @@ func RegisterCatalogTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 			return mcp.NewToolResultText("No catalogs found"), nil
 		}
 
-		var output strings.Builder
-		output.WriteString(fmt.Sprintf("Found %d catalogs:\n\n", len(catalogs)))
-
+		blocks := make([]string, 0, len(catalogs))
 		for _, c := range catalogs {
+			var output strings.Builder
 			output.WriteString(fmt.Sprintf("Name: %s\n", c.Name))
 			output.WriteString(fmt.Sprintf("Namespace: %s\n", c.Namespace))
 			output.WriteString(fmt.Sprintf("Title: %s\n", c.Spec.Title))
@@ -98,9 +102,14 @@ func RegisterCatalogTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 				}
 			}
 			output.WriteString("---\n")
+			blocks = append(blocks, output.String())
 		}
 
-		return mcp.NewToolResultText(output.String()), nil
+		result, err := budgetedList(ctx.OutputBudget, args, fmt.Sprintf("Found %d catalogs:\n\n", len(catalogs)), blocks)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText(result), nil
 	})
 
 	// catalog_get tool
@@ -173,8 +182,8 @@ func RegisterCatalogTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 		mcp.WithString("storage-url", mcp.Required(), mcp.Description("URL for the Helm repository")),
 		mcp.WithString("storage-type", mcp.Description("Storage type (helm or oci, default: helm)")),
 		mcp.WithString("logo-url", mcp.Description("URL for the catalog logo")),
-		mcp.WithString("type", mcp.Description("Catalog type (stable, testing, community)")),
-		mcp.WithString("visibility", mcp.Description("Catalog visibility (public, private)")),
+		mcp.WithString("type", mcp.Description("Catalog type"), mcp.Enum(catalog.Types...)),
+		mcp.WithString("visibility", mcp.Description("Catalog visibility"), mcp.Enum(catalog.Visibilities...)),
 		mcp.WithString("oci-url", mcp.Description("Additional OCI registry URL")),
 	)
 
@@ -192,10 +201,21 @@ func RegisterCatalogTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 			storageType = "helm"
 		}
 
-		// Validate storage URL
-		if err := catalog.ValidateRepositoryURL(storageURL); err != nil {
+		catalogType, visibility, err := catalogLabelArgs(args)
+		if err != nil {
+			return nil, err
+		}
+
+		// Validate repository URLs
+		if err := catalog.ValidateRepositoryURL(toolCtx, storageURL, ctx.ValidateRemote); err != nil {
 			return nil, fmt.Errorf("invalid storage URL: %w", err)
 		}
+		ociURL := getStringArg(args, "oci-url")
+		if ociURL != "" {
+			if err := catalog.ValidateRepositoryURL(toolCtx, ociURL, ctx.ValidateRemote); err != nil {
+				return nil, fmt.Errorf("invalid OCI URL: %w", err)
+			}
+		}
 
 		newCatalog := &catalog.Catalog{
 			Name:      name,
@@ -219,7 +239,7 @@ func RegisterCatalogTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 		}
 
 		// Add OCI repository if provided
-		if ociURL := getStringArg(args, "oci-url"); ociURL != "" {
+		if ociURL != "" {
 			newCatalog.Spec.Repositories = append(newCatalog.Spec.Repositories, catalog.Repository{
 				Type: "oci",
 				URL:  ociURL,
@@ -227,11 +247,11 @@ func RegisterCatalogTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 		}
 
 		// Set labels
-		if catalogType := getStringArg(args, "type"); catalogType != "" {
-			newCatalog.Labels["application.giantswarm.io/catalog-type"] = catalogType
+		if catalogType != "" {
+			newCatalog.SetLabel(catalog.TypeLabel, catalogType)
 		}
-		if visibility := getStringArg(args, "visibility"); visibility != "" {
-			newCatalog.Labels["application.giantswarm.io/catalog-visibility"] = visibility
+		if visibility != "" {
+			newCatalog.SetLabel(catalog.VisibilityLabel, visibility)
 		}
 
 		created, err := catalogClient.Create(toolCtx, newCatalog)
@@ -252,8 +272,9 @@ func RegisterCatalogTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 		mcp.WithString("description", mcp.Description("Update description")),
 		mcp.WithString("storage-url", mcp.Description("Update storage URL")),
 		mcp.WithString("logo-url", mcp.Description("Update logo URL")),
-		mcp.WithString("type", mcp.Description("Update catalog type")),
-		mcp.WithString("visibility", mcp.Description("Update visibility")),
+		mcp.WithString("type", mcp.Description("Update catalog type"), mcp.Enum(catalog.Types...)),
+		mcp.WithString("visibility", mcp.Description("Update visibility"), mcp.Enum(catalog.Visibilities...)),
+		mcp.WithBoolean("force", mcp.Description("Overwrite concurrent changes instead of retrying (default: false)")),
 	)
 
 	s.AddTool(updateTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -261,48 +282,51 @@ func RegisterCatalogTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 		name := args["name"].(string)
 		namespace := args["namespace"].(string)
 
-		// Get current catalog
-		currentCatalog, err := catalogClient.Get(toolCtx, namespace, name)
+		title := getStringArg(args, "title")
+		description := getStringArg(args, "description")
+		storageURL := getStringArg(args, "storage-url")
+		logoURL := getStringArg(args, "logo-url")
+		catalogType, visibility, err := catalogLabelArgs(args)
 		if err != nil {
 			return nil, err
 		}
+		opts := k8s.UpdateOptions{Force: getBoolArg(args, "force")}
 
-		// Update fields if provided
-		if title := getStringArg(args, "title"); title != "" {
-			currentCatalog.Spec.Title = title
-		}
-		if description := getStringArg(args, "description"); description != "" {
-			currentCatalog.Spec.Description = description
-		}
-		if storageURL := getStringArg(args, "storage-url"); storageURL != "" {
-			if err := catalog.ValidateRepositoryURL(storageURL); err != nil {
+		if storageURL != "" {
+			if err := catalog.ValidateRepositoryURL(toolCtx, storageURL, ctx.ValidateRemote); err != nil {
 				return nil, fmt.Errorf("invalid storage URL: %w", err)
 			}
-			currentCatalog.Spec.Storage.URL = storageURL
-			// Update first repository URL as well
-			if len(currentCatalog.Spec.Repositories) > 0 {
-				currentCatalog.Spec.Repositories[0].URL = storageURL
-			}
-		}
-		if logoURL := getStringArg(args, "logo-url"); logoURL != "" {
-			currentCatalog.Spec.LogoURL = logoURL
 		}
 
-		// Update labels
-		if catalogType := getStringArg(args, "type"); catalogType != "" {
-			if currentCatalog.Labels == nil {
-				currentCatalog.Labels = make(map[string]string)
+		updated, err := catalogClient.Update(toolCtx, namespace, name, opts, func(currentCatalog *catalog.Catalog) error {
+			// Update fields if provided
+			if title != "" {
+				currentCatalog.Spec.Title = title
 			}
-			currentCatalog.Labels["application.giantswarm.io/catalog-type"] = catalogType
-		}
-		if visibility := getStringArg(args, "visibility"); visibility != "" {
-			if currentCatalog.Labels == nil {
-				currentCatalog.Labels = make(map[string]string)
+			if description != "" {
+				currentCatalog.Spec.Description = description
+			}
+			if storageURL != "" {
+				currentCatalog.Spec.Storage.URL = storageURL
+				// Update first repository URL as well
+				if len(currentCatalog.Spec.Repositories) > 0 {
+					currentCatalog.Spec.Repositories[0].URL = storageURL
+				}
+			}
+			if logoURL != "" {
+				currentCatalog.Spec.LogoURL = logoURL
 			}
-			currentCatalog.Labels["application.giantswarm.io/catalog-visibility"] = visibility
-		}
 
-		updated, err := catalogClient.Update(toolCtx, currentCatalog)
+			// Update labels
+			if catalogType != "" {
+				currentCatalog.SetLabel(catalog.TypeLabel, catalogType)
+			}
+			if visibility != "" {
+				currentCatalog.SetLabel(catalog.VisibilityLabel, visibility)
+			}
+
+			return nil
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -313,23 +337,66 @@ func RegisterCatalogTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 	// catalog_delete tool
 	deleteTool := mcp.NewTool(
 		"catalog_delete",
-		mcp.WithDescription("Delete a Giant Swarm catalog"),
+		mcp.WithDescription("Delete a Giant Swarm catalog. Refuses while apps are installed from it, unless forced."),
 		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the catalog")),
 		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace of the catalog")),
+		mcp.WithBoolean("force", mcp.Description("Delete the catalog even though apps are installed from it (default: false)")),
 	)
 
 	s.AddTool(deleteTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := req.Params.Arguments.(map[string]interface{})
 		name := args["name"].(string)
 		namespace := args["namespace"].(string)
+		force := getBoolArg(args, "force")
 
-		err := catalogClient.Delete(toolCtx, namespace, name)
+		apps, err := appClient.List(toolCtx, "", "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to check apps installed from catalog %s/%s: %w", namespace, name, err)
+		}
+		dependents := app.FilterByCatalogRef(apps, namespace, name)
+		if len(dependents) > 0 && !force {
+			return mcp.NewToolResultError(formatCatalogDependents(namespace, name, dependents)), nil
+		}
+
+		err = catalogClient.Delete(toolCtx, namespace, name)
 		if err != nil {
 			return nil, err
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully deleted catalog %s/%s", namespace, name)), nil
+		result := fmt.Sprintf("Successfully deleted catalog %s/%s", namespace, name)
+		if len(dependents) > 0 {
+			result += fmt.Sprintf("\n\nWarning: %d apps were installed from it and can no longer be upgraded or reinstalled.", len(dependents))
+		}
+		return mcp.NewToolResultText(result), nil
 	})
 
 	return nil
 }
+
+// catalogLabelArgs returns the normalized type and visibility arguments of
+// the catalog tools, which are empty when omitted
+func catalogLabelArgs(args map[string]interface{}) (catalogType, visibility string, err error) {
+	if value := getStringArg(args, "type"); value != "" {
+		if catalogType, err = catalog.NormalizeType(value); err != nil {
+			return "", "", err
+		}
+	}
+	if value := getStringArg(args, "visibility"); value != "" {
+		if visibility, err = catalog.NormalizeVisibility(value); err != nil {
+			return "", "", err
+		}
+	}
+	return catalogType, visibility, nil
+}
+
+// formatCatalogDependents explains why a catalog with installed apps is not
+// deleted
+func formatCatalogDependents(namespace, name string, dependents []*app.App) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Catalog %s/%s was not deleted: %d apps are installed from it:\n\n", namespace, name, len(dependents)))
+	for _, a := range dependents {
+		output.WriteString(fmt.Sprintf("- %s/%s (%s %s)\n", a.Namespace, a.Name, a.Spec.Name, a.Spec.Version))
+	}
+	output.WriteString("\nDelete or move these apps to another catalog first, or re-run with force=true to delete the catalog anyway.\n")
+	return output.String()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	mcpserver "github.com/mark3labs/mcp-go/server"
@@ -11,12 +12,14 @@ import (
 	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
 	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
 	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
 )
 
 // RegisterClusterTools registers all cluster management tools
 func RegisterClusterTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 	appClient := app.NewClient(ctx.DynamicClient)
-	clusterClient := cluster.NewClient(ctx.DynamicClient, ctx.K8sClient, appClient)
+	clusterClient := cluster.NewClient(ctx.DynamicClient, ctx.K8sClient, appClient).WithClientPool(ctx.WorkloadClients)
+	configClient := config.NewClient(ctx.K8sClient)
 
 	// cluster_list tool
 	listTool := mcp.NewTool(
@@ -27,6 +30,9 @@ func RegisterClusterTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 		mcp.WithString("labels", mcp.Description("Label selector (e.g., 'provider=aws,env=prod')")),
 		mcp.WithString("provider", mcp.Description("Filter by infrastructure provider (aws, azure, etc.)")),
 		mcp.WithBoolean("ready-only", mcp.Description("Show only ready clusters")),
+		mcp.WithBoolean("paused-only", mcp.Description("Show only clusters whose reconciliation is paused")),
+		mcp.WithBoolean("summary", mcp.Description("Return counts by provider, release and readiness per organization instead of the clusters")),
+		withContinue(),
 	)
 
 	s.AddTool(listTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -60,17 +66,32 @@ func RegisterClusterTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 		if readyOnly {
 			clusters = cluster.FilterByStatus(clusters, true)
 		}
+		if getBoolArg(args, "paused-only") {
+			paused := make([]*cluster.Cluster, 0)
+			for _, c := range clusters {
+				if c.PauseState().Paused {
+					paused = append(paused, c)
+				}
+			}
+			clusters = paused
+		}
 
 		// Format output
 		if len(clusters) == 0 {
 			return mcp.NewToolResultText("No clusters found"), nil
 		}
 
-		var output strings.Builder
-		output.WriteString(fmt.Sprintf("Found %d clusters:\n\n", len(clusters)))
+		if getBoolArg(args, "summary") {
+			return mcp.NewToolResultText(formatFleetSummary(cluster.Summarize(clusters))), nil
+		}
 
+		blocks := make([]string, 0, len(clusters))
 		for _, c := range clusters {
+			var output strings.Builder
 			output.WriteString(fmt.Sprintf("Name: %s\n", c.Name))
+			if pause := c.PauseState(); pause.Paused {
+				output.WriteString(fmt.Sprintf("RECONCILIATION PAUSED: %s\n", pause.Summary(time.Now())))
+			}
 			output.WriteString(fmt.Sprintf("Namespace: %s\n", c.Namespace))
 			output.WriteString(fmt.Sprintf("Organization: %s\n", c.GetOrganization()))
 			output.WriteString(fmt.Sprintf("Provider: %s\n", c.GetProvider()))
@@ -102,84 +123,14 @@ func RegisterClusterTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 			}
 
 			output.WriteString("---\n")
+			blocks = append(blocks, output.String())
 		}
 
-		return mcp.NewToolResultText(output.String()), nil
-	})
-
-	// cluster_apps tool
-	appsTool := mcp.NewTool(
-		"cluster_apps",
-		mcp.WithDescription("List apps in a specific cluster"),
-		mcp.WithString("cluster", mcp.Required(), mcp.Description("Cluster name")),
-		mcp.WithString("namespace", mcp.Description("Namespace where the cluster is located")),
-		mcp.WithString("organization", mcp.Description("Organization that owns the cluster")),
-	)
-
-	s.AddTool(appsTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args := req.Params.Arguments.(map[string]interface{})
-		clusterName := args["cluster"].(string)
-		namespace := getStringArg(args, "namespace")
-		org := getStringArg(args, "organization")
-
-		// Find the cluster
-		var targetCluster *cluster.Cluster
-		var err error
-
-		if namespace != "" {
-			targetCluster, err = clusterClient.Get(toolCtx, namespace, clusterName)
-		} else if org != "" {
-			// Search in organization namespaces
-			clusters, err := clusterClient.ListByOrganization(toolCtx, org)
-			if err == nil {
-				for _, c := range clusters {
-					if c.Name == clusterName {
-						targetCluster = c
-						break
-					}
-				}
-			}
-		} else {
-			// Search across all namespaces
-			clusters, err := clusterClient.List(toolCtx, "", "")
-			if err == nil {
-				for _, c := range clusters {
-					if c.Name == clusterName {
-						targetCluster = c
-						break
-					}
-				}
-			}
-		}
-
-		if targetCluster == nil {
-			return nil, fmt.Errorf("cluster %s not found", clusterName)
-		}
-
-		// List apps in the cluster
-		apps, err := clusterClient.ListApps(toolCtx, targetCluster)
+		result, err := budgetedList(ctx.OutputBudget, args, fmt.Sprintf("Found %d clusters:\n\n", len(clusters)), blocks)
 		if err != nil {
-			return nil, fmt.Errorf("failed to list apps in cluster %s: %w", clusterName, err)
-		}
-
-		// Format output
-		if len(apps) == 0 {
-			return mcp.NewToolResultText(fmt.Sprintf("No apps found in cluster %s", clusterName)), nil
-		}
-
-		var output strings.Builder
-		output.WriteString(fmt.Sprintf("Apps in cluster %s:\n\n", clusterName))
-
-		for _, a := range apps {
-			output.WriteString(fmt.Sprintf("Name: %s\n", a.Name))
-			output.WriteString(fmt.Sprintf("Namespace: %s\n", a.Namespace))
-			output.WriteString(fmt.Sprintf("App: %s (v%s)\n", a.Spec.Name, a.Spec.Version))
-			output.WriteString(fmt.Sprintf("Catalog: %s\n", a.Spec.Catalog))
-			output.WriteString(fmt.Sprintf("Status: %s\n", a.Status.Release.Status))
-			output.WriteString("---\n")
+			return nil, err
 		}
-
-		return mcp.NewToolResultText(output.String()), nil
+		return mcp.NewToolResultText(result), nil
 	})
 
 	// cluster_get tool
@@ -238,6 +189,10 @@ func RegisterClusterTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 		// Format detailed output
 		var output strings.Builder
 		output.WriteString(fmt.Sprintf("Cluster: %s\n", targetCluster.Name))
+		if pause := targetCluster.PauseState(); pause.Paused {
+			output.WriteString(fmt.Sprintf("RECONCILIATION PAUSED: %s\n", pause.Summary(time.Now())))
+			output.WriteString("  CAPI controllers do not act on changes to the cluster until it is resumed with cluster_resume\n")
+		}
 		output.WriteString(fmt.Sprintf("Namespace: %s\n", targetCluster.Namespace))
 		output.WriteString(fmt.Sprintf("Organization: %s\n", targetCluster.GetOrganization()))
 		output.WriteString(fmt.Sprintf("Provider: %s\n", targetCluster.GetProvider()))
@@ -290,15 +245,53 @@ func RegisterClusterTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 			}
 		}
 
+		// Provider details; clusters without infrastructure access still get the rest
+		if targetCluster.Spec.InfrastructureRef != nil {
+			output.WriteString("\nInfrastructure:\n")
+			if infra, err := clusterClient.GetInfrastructure(toolCtx, targetCluster); err != nil {
+				output.WriteString(fmt.Sprintf("  Error: %v\n", err))
+			} else {
+				writeProviderDetails(&output, infra, "  ")
+			}
+		}
+		if pools, err := clusterClient.ListNodePools(toolCtx, targetCluster); err != nil {
+			output.WriteString(fmt.Sprintf("\nNode Pools: %v\n", err))
+		} else if len(pools) > 0 {
+			output.WriteString("\nNode Pools:\n")
+			writeNodePools(&output, pools)
+		}
+
 		// Check for kubeconfig availability
-		_, kubeconfigErr := clusterClient.GetKubeconfig(toolCtx, targetCluster)
+		expiries, kubeconfigErr := clusterClient.KubeconfigExpiry(toolCtx, targetCluster)
 		if kubeconfigErr == nil {
 			output.WriteString("\nKubeconfig: Available\n")
 			output.WriteString(fmt.Sprintf("  Secret: %s-kubeconfig\n", targetCluster.Name))
+			for _, expiry := range expiries {
+				output.WriteString(fmt.Sprintf("  %s\n", formatCredentialExpiry(expiry, time.Now())))
+			}
 		} else {
 			output.WriteString("\nKubeconfig: Not Available\n")
 		}
 
+		// API server details for wiring up external tooling
+		access, accessErr := clusterClient.GetAPIServerAccess(toolCtx, targetCluster)
+		output.WriteString("\nAPI Server:\n")
+		if access.Server != "" {
+			output.WriteString(fmt.Sprintf("  Endpoint: %s\n", access.Server))
+		}
+		if access.CAFingerprint != "" {
+			output.WriteString(fmt.Sprintf("  CA Fingerprint (SHA-256): %s\n", access.CAFingerprint))
+			output.WriteString(fmt.Sprintf("  CA Subject: %s (expires %s)\n", access.CASubject, access.CANotAfter.Format("2006-01-02")))
+		}
+		if access.OIDCIssuer != "" {
+			output.WriteString(fmt.Sprintf("  OIDC Issuer: %s\n", access.OIDCIssuer))
+		} else if targetCluster.Spec.ControlPlaneRef != nil && accessErr == nil {
+			output.WriteString("  OIDC Issuer: none configured\n")
+		}
+		if accessErr != nil {
+			output.WriteString(fmt.Sprintf("  Incomplete: %v\n", accessErr))
+		}
+
 		// Show workload namespace
 		workloadNs := cluster.GetClusterNamespace(targetCluster.Name)
 		output.WriteString(fmt.Sprintf("\nWorkload Namespace: %s\n", workloadNs))
@@ -311,8 +304,133 @@ func RegisterClusterTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 			}
 		}
 
+		return textWithLinks(output.String(), clusterLink(targetCluster)), nil
+	})
+
+	// cluster_health tool
+	healthTool := mcp.NewTool(
+		"cluster_health",
+		mcp.WithDescription("Color-coded health report of a cluster combining CAPI conditions, control plane, machine deployments and nodes"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Cluster name")),
+		mcp.WithString("namespace", mcp.Description("Namespace where the cluster is located")),
+		mcp.WithString("organization", mcp.Description("Organization that owns the cluster")),
+	)
+
+	s.AddTool(healthTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		clusterName := args["name"].(string)
+
+		targetCluster, err := findCluster(toolCtx, clusterClient, clusterName, getStringArg(args, "namespace"), getStringArg(args, "organization"))
+		if err != nil {
+			return nil, err
+		}
+
+		report := clusterClient.CheckHealth(toolCtx, targetCluster)
+
+		var output strings.Builder
+		output.WriteString(fmt.Sprintf("Cluster %s: %s\n\n", report.Cluster, healthLabel(report.Overall)))
+		for _, check := range report.Checks {
+			output.WriteString(fmt.Sprintf("%s %s: %s\n", healthLabel(check.Level), check.Component, check.Summary))
+			for _, detail := range check.Details {
+				output.WriteString(fmt.Sprintf("    - %s\n", detail))
+			}
+		}
+
+		if len(report.RootCauses) > 0 {
+			output.WriteString("\nLikely root causes:\n")
+			for _, cause := range report.RootCauses {
+				output.WriteString(fmt.Sprintf("  - %s\n", cause))
+			}
+		}
+
 		return mcp.NewToolResultText(output.String()), nil
 	})
 
+	registerClusterAppTools(s, ctx, clusterClient, configClient)
+	registerClusterNodeTools(s, ctx, appClient, clusterClient)
+	registerClusterHealthCheckTools(s, ctx, clusterClient)
+	registerClusterPauseTools(s, ctx, clusterClient)
+	registerClusterValuesTools(s, ctx, appClient, clusterClient, configClient)
+	registerClusterInfrastructureTools(s, ctx, clusterClient)
+
 	return nil
 }
+
+// certExpiryWarning is how long before expiry a certificate is flagged
+const certExpiryWarning = 30 * 24 * time.Hour
+
+// metricsWarningPercent is the share of allocatable CPU or memory above which
+// cluster_metrics flags a node
+const metricsWarningPercent = 85
+
+// findCluster looks up a cluster by name in a namespace, an organization or across all namespaces
+func findCluster(ctx context.Context, clusterClient *cluster.Client, name, namespace, org string) (*cluster.Cluster, error) {
+	if namespace != "" {
+		return clusterClient.Get(ctx, namespace, name)
+	}
+
+	var clusters []*cluster.Cluster
+	var err error
+	if org != "" {
+		clusters, err = clusterClient.ListByOrganization(ctx, org)
+	} else {
+		clusters, err = clusterClient.List(ctx, "", "")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range clusters {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+
+	return nil, fmt.Errorf("cluster %s not found", name)
+}
+
+// refNamespace returns the namespace of a config reference, which defaults to
+// the namespace of the app
+func refNamespace(namespace string, a *app.App) string {
+	if namespace != "" {
+		return namespace
+	}
+	return a.Namespace
+}
+
+// healthLabel renders a health level as a fixed-width marker
+func healthLabel(level cluster.HealthLevel) string {
+	switch level {
+	case cluster.HealthRed:
+		return "[RED]"
+	case cluster.HealthYellow:
+		return "[YELLOW]"
+	default:
+		return "[GREEN]"
+	}
+}
+
+// formatFleetSummary renders cluster counts in total and per organization
+func formatFleetSummary(fleet *cluster.FleetSummary) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("%d clusters in %d organizations, %d ready\n", fleet.Total, len(fleet.Organizations), fleet.Ready))
+	if len(fleet.Paused) > 0 {
+		output.WriteString(fmt.Sprintf("Reconciliation paused: %d clusters\n", len(fleet.Paused)))
+	}
+	output.WriteString(fmt.Sprintf("Providers: %s\n", fleet.Providers))
+	output.WriteString(fmt.Sprintf("Releases: %s\n", fleet.Releases))
+
+	for _, org := range fleet.OrganizationNames() {
+		summary := fleet.Organizations[org]
+		output.WriteString(fmt.Sprintf("\n%s: %d clusters, %d ready\n", org, summary.Total, summary.Ready))
+		output.WriteString(fmt.Sprintf("  Providers: %s\n", summary.Providers))
+		output.WriteString(fmt.Sprintf("  Releases: %s\n", summary.Releases))
+		if len(summary.NotReady) > 0 {
+			output.WriteString(fmt.Sprintf("  Not ready: %s\n", strings.Join(summary.NotReady, ", ")))
+		}
+		if len(summary.Paused) > 0 {
+			output.WriteString(fmt.Sprintf("  Paused: %s\n", strings.Join(summary.Paused, ", ")))
+		}
+	}
+	return output.String()
+}
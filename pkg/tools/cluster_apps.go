@@ -0,0 +1,229 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+)
+
+// registerClusterAppTools registers the tools comparing the apps of clusters
+func registerClusterAppTools(s *mcpserver.MCPServer, ctx *server.Context, clusterClient *cluster.Client, configClient *config.Client) {
+	// cluster_apps tool
+	appsTool := mcp.NewTool(
+		"cluster_apps",
+		mcp.WithDescription("List apps in a specific cluster"),
+		mcp.WithString("cluster", mcp.Required(), mcp.Description("Cluster name")),
+		mcp.WithString("namespace", mcp.Description("Namespace where the cluster is located")),
+		mcp.WithString("organization", mcp.Description("Organization that owns the cluster")),
+		withContinue(),
+	)
+
+	s.AddTool(appsTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		clusterName := args["cluster"].(string)
+		namespace := getStringArg(args, "namespace")
+		org := getStringArg(args, "organization")
+
+		targetCluster, err := findCluster(toolCtx, clusterClient, clusterName, namespace, org)
+		if err != nil {
+			return nil, err
+		}
+
+		// List apps in the cluster
+		apps, err := clusterClient.ListApps(toolCtx, targetCluster)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list apps in cluster %s: %w", clusterName, err)
+		}
+
+		// Format output
+		if len(apps) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("No apps found in cluster %s", clusterName)), nil
+		}
+
+		blocks := make([]string, 0, len(apps))
+		for _, a := range apps {
+			var output strings.Builder
+			output.WriteString(fmt.Sprintf("Name: %s\n", a.Name))
+			output.WriteString(fmt.Sprintf("Namespace: %s\n", a.Namespace))
+			output.WriteString(fmt.Sprintf("App: %s (v%s)\n", a.Spec.Name, a.Spec.Version))
+			output.WriteString(fmt.Sprintf("Catalog: %s\n", a.Spec.Catalog))
+			output.WriteString(fmt.Sprintf("Status: %s\n", a.Status.Release.Status))
+			output.WriteString("---\n")
+			blocks = append(blocks, output.String())
+		}
+
+		result, err := budgetedList(ctx.OutputBudget, args, fmt.Sprintf("Apps in cluster %s:\n\n", clusterName), blocks)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText(result), nil
+	})
+
+	// app_matrix tool
+	matrixTool := mcp.NewTool(
+		"app_matrix",
+		mcp.WithDescription("Show a table of the apps by workload cluster of an organization, with the version deployed in each cluster and the drift against the newest version"),
+		mcp.WithString("organization", mcp.Required(), mcp.Description("Organization whose clusters to compare")),
+		mcp.WithString("apps", mcp.Description("Comma-separated app names to include (default: all)")),
+		mcp.WithString("catalog", mcp.Description("Only include apps from this catalog")),
+		mcp.WithBoolean("drift-only", mcp.Description("Only show apps with mixed or outdated versions")),
+	)
+
+	s.AddTool(matrixTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		org := args["organization"].(string)
+		catalogName := getStringArg(args, "catalog")
+		driftOnly := getBoolArg(args, "drift-only")
+
+		wanted := make(map[string]bool)
+		for _, name := range strings.Split(getStringArg(args, "apps"), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				wanted[name] = true
+			}
+		}
+
+		clusters, err := clusterClient.ListByOrganization(toolCtx, org)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list clusters: %w", err)
+		}
+		if len(clusters) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("No clusters found for organization %s", org)), nil
+		}
+
+		clusterNames := make([]string, 0, len(clusters))
+		appsByCluster := make(map[string][]*app.App, len(clusters))
+		appNames := make(map[string]bool)
+		for _, c := range clusters {
+			apps, err := clusterClient.ListApps(toolCtx, c)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list apps in cluster %s: %w", c.Name, err)
+			}
+
+			filtered := make([]*app.App, 0, len(apps))
+			for _, a := range apps {
+				if (len(wanted) == 0 || wanted[a.Spec.Name]) && (catalogName == "" || a.Spec.Catalog == catalogName) {
+					filtered = append(filtered, a)
+					appNames[a.Spec.Name] = true
+				}
+			}
+
+			clusterNames = append(clusterNames, c.Name)
+			appsByCluster[c.Name] = filtered
+		}
+		sort.Strings(clusterNames)
+
+		entryClient := appcatalogentry.NewClient(ctx.DynamicClient).WithIndex(ctx.AppCatalogEntryIndex)
+		entries := make([]*appcatalogentry.AppCatalogEntry, 0)
+		for name := range appNames {
+			versions, err := entryClient.GetVersions(toolCtx, name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get versions of app %s: %w", name, err)
+			}
+			entries = append(entries, versions...)
+		}
+
+		matrix := buildAppsMatrix(clusterNames, appsByCluster, entries)
+		if driftOnly {
+			rows := make([]appsMatrixRow, 0, len(matrix.Rows))
+			for _, row := range matrix.Rows {
+				if row.Drift() != "ok" {
+					rows = append(rows, row)
+				}
+			}
+			matrix.Rows = rows
+		}
+
+		if len(matrix.Rows) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("No matching apps found in the clusters of organization %s", org)), nil
+		}
+
+		var output strings.Builder
+		output.WriteString(fmt.Sprintf("Apps matrix for organization %s (%d clusters, %d apps):\n\n", org, len(clusterNames), len(matrix.Rows)))
+		output.WriteString(matrix.String())
+		output.WriteString("\n* behind the newest version, - not deployed\n")
+
+		return mcp.NewToolResultText(output.String()), nil
+	})
+
+	// app_drift tool
+	driftTool := mcp.NewTool(
+		"app_drift",
+		mcp.WithDescription("Compare the same app across two or more clusters and report differences in version, catalog, target namespace and user values"),
+		mcp.WithString("app", mcp.Required(), mcp.Description("App name (spec.name) or App resource name")),
+		mcp.WithString("clusters", mcp.Required(), mcp.Description("Comma-separated cluster names; the first one is the baseline")),
+		mcp.WithString("namespace", mcp.Description("Namespace where the clusters are located")),
+		mcp.WithString("organization", mcp.Description("Organization that owns the clusters")),
+		mcp.WithBoolean("include-secrets", mcp.Description("Also compare user secrets (only the differing keys are shown)")),
+	)
+
+	s.AddTool(driftTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		appName := args["app"].(string)
+		namespace := getStringArg(args, "namespace")
+		org := getStringArg(args, "organization")
+		includeSecrets := getBoolArg(args, "include-secrets")
+
+		clusterNames := make([]string, 0)
+		for _, name := range strings.Split(args["clusters"].(string), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				clusterNames = append(clusterNames, name)
+			}
+		}
+		if len(clusterNames) < 2 {
+			return nil, fmt.Errorf("at least two clusters are required")
+		}
+
+		deployments := make([]appDeployment, 0, len(clusterNames))
+		for _, clusterName := range clusterNames {
+			targetCluster, err := findCluster(toolCtx, clusterClient, clusterName, namespace, org)
+			if err != nil {
+				return nil, err
+			}
+
+			apps, err := clusterClient.ListApps(toolCtx, targetCluster)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list apps in cluster %s: %w", clusterName, err)
+			}
+
+			deployment := appDeployment{Cluster: clusterName}
+			for _, a := range apps {
+				if a.Spec.Name == appName || a.Name == appName {
+					deployment.App = a
+					break
+				}
+			}
+
+			if deployment.App != nil && deployment.App.Spec.UserConfig != nil {
+				userConfig := deployment.App.Spec.UserConfig
+				if ref := userConfig.ConfigMap; ref != nil {
+					cfg, err := configClient.GetConfigMap(toolCtx, refNamespace(ref.Namespace, deployment.App), ref.Name)
+					if err != nil {
+						return nil, fmt.Errorf("failed to get user values of %s in cluster %s: %w", deployment.App.Name, clusterName, err)
+					}
+					deployment.Values = cfg.Flatten()
+				}
+				if ref := userConfig.Secret; ref != nil && includeSecrets {
+					cfg, err := configClient.GetSecret(toolCtx, refNamespace(ref.Namespace, deployment.App), ref.Name)
+					if err != nil {
+						return nil, fmt.Errorf("failed to get user secrets of %s in cluster %s: %w", deployment.App.Name, clusterName, err)
+					}
+					deployment.Secrets = cfg.Flatten()
+				}
+			}
+
+			deployments = append(deployments, deployment)
+		}
+
+		return mcp.NewToolResultText(driftReport(appName, deployments)), nil
+	})
+}
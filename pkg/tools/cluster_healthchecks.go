@@ -0,0 +1,194 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+)
+
+// registerClusterHealthCheckTools registers the tools checking the health
+// checks, reachability and credentials of clusters
+func registerClusterHealthCheckTools(s *mcpserver.MCPServer, ctx *server.Context, clusterClient *cluster.Client) {
+	// cluster_healthchecks tool
+	healthChecksTool := mcp.NewTool(
+		"cluster_healthchecks",
+		mcp.WithDescription("List the MachineHealthChecks of a cluster with their unhealthy machines and recent remediations, to explain node churn"),
+		mcp.WithString("cluster", mcp.Required(), mcp.Description("Cluster name")),
+		mcp.WithString("namespace", mcp.Description("Namespace where the cluster is located")),
+		mcp.WithString("organization", mcp.Description("Organization that owns the cluster")),
+	)
+
+	s.AddTool(healthChecksTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		clusterName := args["cluster"].(string)
+
+		targetCluster, err := findCluster(toolCtx, clusterClient, clusterName, getStringArg(args, "namespace"), getStringArg(args, "organization"))
+		if err != nil {
+			return nil, err
+		}
+
+		health, err := clusterClient.GetMachineHealth(toolCtx, targetCluster)
+		if err != nil {
+			return nil, err
+		}
+
+		return mcp.NewToolResultText(formatMachineHealth(clusterName, health, time.Now())), nil
+	})
+
+	// cluster_ping tool
+	pingTool := mcp.NewTool(
+		"cluster_ping",
+		mcp.WithDescription("Check that the API server of a workload cluster is reachable with its kubeconfig secret: calls /version and /healthz and reports latency, Kubernetes version and serving certificate expiry"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Cluster name")),
+		mcp.WithString("namespace", mcp.Description("Namespace where the cluster is located")),
+		mcp.WithString("organization", mcp.Description("Organization that owns the cluster")),
+	)
+
+	s.AddTool(pingTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		clusterName := args["name"].(string)
+
+		targetCluster, err := findCluster(toolCtx, clusterClient, clusterName, getStringArg(args, "namespace"), getStringArg(args, "organization"))
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := clusterClient.Ping(toolCtx, targetCluster)
+		if err != nil {
+			return nil, err
+		}
+
+		return mcp.NewToolResultText(formatPing(targetCluster.Name, result, time.Now())), nil
+	})
+
+	// kubeconfigs_expiring tool
+	expiringTool := mcp.NewTool(
+		"kubeconfigs_expiring",
+		mcp.WithDescription("List clusters whose kubeconfig secret holds a client certificate or token that expires within the given number of days, or has expired"),
+		mcp.WithString("namespace", mcp.Description("Namespace to check clusters in (empty for all namespaces)")),
+		mcp.WithString("organization", mcp.Description("Organization whose clusters to check")),
+		mcp.WithString("days", mcp.Description("Report credentials expiring within this many days (default: 30)")),
+		withContinue(),
+	)
+
+	s.AddTool(expiringTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		namespace := getStringArg(args, "namespace")
+		org := getStringArg(args, "organization")
+
+		days := 30
+		if daysStr := getStringArg(args, "days"); daysStr != "" {
+			n, err := strconv.Atoi(daysStr)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid days %q: must be a non-negative number", daysStr)
+			}
+			days = n
+		}
+
+		var clusters []*cluster.Cluster
+		var err error
+		if org != "" {
+			clusters, err = clusterClient.ListByOrganization(toolCtx, org)
+		} else {
+			clusters, err = clusterClient.List(toolCtx, namespace, "")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		blocks := make([]string, 0)
+		unreadable := make([]string, 0)
+		for _, c := range clusters {
+			expiries, err := clusterClient.KubeconfigExpiry(toolCtx, c)
+			if err != nil {
+				unreadable = append(unreadable, fmt.Sprintf("  - %s/%s: %v\n", c.Namespace, c.Name, err))
+				continue
+			}
+			expiring := cluster.ExpiresWithin(expiries, now, time.Duration(days)*24*time.Hour)
+			if len(expiring) == 0 {
+				continue
+			}
+
+			var output strings.Builder
+			output.WriteString(fmt.Sprintf("%s/%s:\n", c.Namespace, c.Name))
+			for _, expiry := range expiring {
+				output.WriteString(fmt.Sprintf("  %s\n", formatCredentialExpiry(expiry, now)))
+			}
+			blocks = append(blocks, output.String())
+		}
+
+		title := fmt.Sprintf("%d of %d clusters have kubeconfig credentials expiring within %d days:\n\n", len(blocks), len(clusters), days)
+		result, err := budgetedList(ctx.OutputBudget, args, title, blocks)
+		if err != nil {
+			return nil, err
+		}
+		if len(unreadable) > 0 {
+			result += "\nKubeconfigs that could not be checked:\n" + strings.Join(unreadable, "")
+		}
+		return mcp.NewToolResultText(result), nil
+	})
+}
+
+// formatPing renders the result of cluster_ping
+func formatPing(clusterName string, result *cluster.PingResult, now time.Time) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Ping of cluster %s (%s):\n\n", clusterName, result.Endpoint))
+
+	if result.VersionErr != nil {
+		output.WriteString(fmt.Sprintf("/version: failed after %s: %v\n", result.VersionLatency.Round(time.Millisecond), result.VersionErr))
+	} else {
+		output.WriteString(fmt.Sprintf("/version: %s (%s) in %s\n", result.Version.GitVersion, result.Version.Platform, result.VersionLatency.Round(time.Millisecond)))
+	}
+	if result.HealthzErr != nil {
+		output.WriteString(fmt.Sprintf("/healthz: failed after %s: %v\n", result.HealthzLatency.Round(time.Millisecond), result.HealthzErr))
+	} else {
+		output.WriteString(fmt.Sprintf("/healthz: %s in %s\n", result.Healthz, result.HealthzLatency.Round(time.Millisecond)))
+	}
+
+	if !result.CertNotAfter.IsZero() {
+		remaining := result.CertNotAfter.Sub(now)
+		output.WriteString(fmt.Sprintf("Serving certificate: %s, expires %s (%s)\n",
+			result.CertSubject, result.CertNotAfter.UTC().Format(time.RFC3339), formatRemaining(remaining)))
+		if remaining < certExpiryWarning {
+			output.WriteString("WARNING: the serving certificate expires within 30 days\n")
+		}
+	}
+
+	if result.Reachable() {
+		output.WriteString("\nReachable: yes\n")
+	} else {
+		output.WriteString("\nReachable: no\n")
+	}
+	return output.String()
+}
+
+// formatCredentialExpiry renders the expiry of a kubeconfig credential
+func formatCredentialExpiry(expiry cluster.CredentialExpiry, now time.Time) string {
+	subject := ""
+	if expiry.Subject != "" {
+		subject = fmt.Sprintf(" (%s)", expiry.Subject)
+	}
+	return fmt.Sprintf("%s of user %s%s: expires %s (%s)", expiry.Kind, expiry.User, subject,
+		expiry.NotAfter.UTC().Format(time.RFC3339), formatRemaining(expiry.NotAfter.Sub(now)))
+}
+
+// formatRemaining renders the time left until an expiry in days
+func formatRemaining(remaining time.Duration) string {
+	if remaining <= 0 {
+		return "expired"
+	}
+	days := int(remaining.Hours() / 24)
+	if days == 0 {
+		return fmt.Sprintf("in %s", remaining.Round(time.Minute))
+	}
+	return fmt.Sprintf("in %d days", days)
+}
@@ -0,0 +1,131 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+)
+
+// registerClusterInfrastructureTools registers the tool describing the
+// infrastructure of clusters
+func registerClusterInfrastructureTools(s *mcpserver.MCPServer, ctx *server.Context, clusterClient *cluster.Client) {
+	// cluster_infrastructure tool
+	infrastructureTool := mcp.NewTool(
+		"cluster_infrastructure",
+		mcp.WithDescription("Show provider-specific infrastructure details of a cluster (region, VPC, resource group, bastion)"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Cluster name")),
+		mcp.WithString("namespace", mcp.Description("Namespace where the cluster is located")),
+		mcp.WithString("organization", mcp.Description("Organization that owns the cluster")),
+	)
+
+	s.AddTool(infrastructureTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		clusterName := args["name"].(string)
+
+		targetCluster, err := findCluster(toolCtx, clusterClient, clusterName, getStringArg(args, "namespace"), getStringArg(args, "organization"))
+		if err != nil {
+			return nil, err
+		}
+
+		infra, err := clusterClient.GetInfrastructure(toolCtx, targetCluster)
+		if err != nil {
+			return nil, err
+		}
+
+		var output strings.Builder
+		output.WriteString(fmt.Sprintf("Infrastructure of cluster %s:\n\n", clusterName))
+		output.WriteString(fmt.Sprintf("Kind: %s\n", infra.Kind))
+		output.WriteString(fmt.Sprintf("Name: %s\n", infra.Name))
+		output.WriteString(fmt.Sprintf("Namespace: %s\n", infra.Namespace))
+		output.WriteString(fmt.Sprintf("Ready: %v\n", infra.Ready))
+		if infra.ControlPlaneEndpoint != "" {
+			output.WriteString(fmt.Sprintf("Control Plane Endpoint: %s\n", infra.ControlPlaneEndpoint))
+		}
+		if len(infra.FailureDomains) > 0 {
+			output.WriteString(fmt.Sprintf("Failure Domains: %s\n", strings.Join(infra.FailureDomains, ", ")))
+		}
+		if infra.Region != "" {
+			output.WriteString(fmt.Sprintf("Region: %s\n", infra.Region))
+		}
+		if infra.Network != "" {
+			output.WriteString(fmt.Sprintf("Network: %s\n", infra.Network))
+		}
+		if len(infra.CIDRs) > 0 {
+			output.WriteString(fmt.Sprintf("CIDRs: %s\n", strings.Join(infra.CIDRs, ", ")))
+		}
+
+		if len(infra.Details) > 0 {
+			output.WriteString("\nProvider Details:\n")
+			for _, detail := range infra.Details {
+				output.WriteString(fmt.Sprintf("  %s: %s\n", detail.Name, detail.Value))
+			}
+		}
+
+		if len(infra.Conditions) > 0 {
+			output.WriteString("\nConditions:\n")
+			for _, cond := range infra.Conditions {
+				output.WriteString(fmt.Sprintf("  - %s: %s", cond.Type, cond.Status))
+				if cond.Reason != "" {
+					output.WriteString(fmt.Sprintf(" (%s)", cond.Reason))
+				}
+				output.WriteString("\n")
+			}
+		}
+
+		return mcp.NewToolResultText(output.String()), nil
+	})
+}
+
+// writeProviderDetails writes the region, network and provider-specific
+// details of a cluster's infrastructure
+func writeProviderDetails(output *strings.Builder, infra *cluster.Infrastructure, indent string) {
+	output.WriteString(fmt.Sprintf("%sKind: %s\n", indent, infra.Kind))
+	if infra.Provider != "" {
+		output.WriteString(fmt.Sprintf("%sProvider: %s\n", indent, infra.Provider))
+	}
+	if infra.Region != "" {
+		output.WriteString(fmt.Sprintf("%sRegion: %s\n", indent, infra.Region))
+	}
+	if infra.Network != "" {
+		output.WriteString(fmt.Sprintf("%sNetwork: %s\n", indent, infra.Network))
+	}
+	if len(infra.CIDRs) > 0 {
+		output.WriteString(fmt.Sprintf("%sCIDRs: %s\n", indent, strings.Join(infra.CIDRs, ", ")))
+	}
+	if len(infra.FailureDomains) > 0 {
+		output.WriteString(fmt.Sprintf("%sFailure Domains: %s\n", indent, strings.Join(infra.FailureDomains, ", ")))
+	}
+	for _, detail := range infra.Details {
+		output.WriteString(fmt.Sprintf("%s%s: %s\n", indent, detail.Name, detail.Value))
+	}
+}
+
+// writeNodePools writes one line per node pool with its instance type,
+// followed by the details of its machine template
+func writeNodePools(output *strings.Builder, pools []*cluster.NodePool) {
+	for _, pool := range pools {
+		role := "workers"
+		if pool.ControlPlane {
+			role = "control plane"
+		}
+		line := fmt.Sprintf("  - %s (%s): %d replicas", pool.Name, role, pool.Replicas)
+		if pool.InstanceType != "" {
+			line += ", " + pool.InstanceType
+		} else if pool.TemplateKind != "" {
+			line += fmt.Sprintf(", %s/%s", pool.TemplateKind, pool.TemplateName)
+		}
+		output.WriteString(line + "\n")
+		for _, detail := range pool.Details {
+			output.WriteString(fmt.Sprintf("      %s: %s\n", detail.Name, detail.Value))
+		}
+		if pool.Error != "" {
+			output.WriteString(fmt.Sprintf("      Error: %s\n", pool.Error))
+		}
+	}
+}
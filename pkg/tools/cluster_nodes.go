@@ -0,0 +1,448 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/alerts"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/metrics"
+)
+
+// registerClusterNodeTools registers the tools inspecting the machines, nodes
+// and alerts of clusters
+func registerClusterNodeTools(s *mcpserver.MCPServer, ctx *server.Context, appClient *app.Client, clusterClient *cluster.Client) {
+	// cluster_machines tool
+	machinesTool := mcp.NewTool(
+		"cluster_machines",
+		mcp.WithDescription("List the MachineDeployments and Machines of a cluster with provider IDs, versions, phases and failures"),
+		mcp.WithString("cluster", mcp.Required(), mcp.Description("Cluster name")),
+		mcp.WithString("namespace", mcp.Description("Namespace where the cluster is located")),
+		mcp.WithString("organization", mcp.Description("Organization that owns the cluster")),
+		mcp.WithString("machine-deployment", mcp.Description("Show only machines of this MachineDeployment")),
+		mcp.WithBoolean("failed-only", mcp.Description("Show only machines that are failed or not running")),
+	)
+
+	s.AddTool(machinesTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		clusterName := args["cluster"].(string)
+		mdFilter := getStringArg(args, "machine-deployment")
+		failedOnly := getBoolArg(args, "failed-only")
+
+		targetCluster, err := findCluster(toolCtx, clusterClient, clusterName, getStringArg(args, "namespace"), getStringArg(args, "organization"))
+		if err != nil {
+			return nil, err
+		}
+
+		deployments, err := clusterClient.ListMachineDeployments(toolCtx, targetCluster)
+		if err != nil {
+			return nil, err
+		}
+
+		machines, err := clusterClient.ListMachines(toolCtx, targetCluster)
+		if err != nil {
+			return nil, err
+		}
+
+		var output strings.Builder
+		output.WriteString(fmt.Sprintf("MachineDeployments in cluster %s:\n\n", clusterName))
+		if len(deployments) == 0 {
+			output.WriteString("No MachineDeployments found\n")
+		}
+		for _, md := range deployments {
+			if mdFilter != "" && md.Name != mdFilter {
+				continue
+			}
+			output.WriteString(fmt.Sprintf("Name: %s\n", md.Name))
+			output.WriteString(fmt.Sprintf("Version: %s\n", md.Version))
+			output.WriteString(fmt.Sprintf("Phase: %s\n", md.Phase))
+			output.WriteString(fmt.Sprintf("Replicas: %d desired, %d ready, %d updated, %d unavailable\n",
+				md.DesiredReplicas, md.Status.ReadyReplicas, md.Status.UpdatedReplicas, md.Status.UnavailableReplicas))
+			output.WriteString("---\n")
+		}
+
+		output.WriteString("\nMachines:\n\n")
+		shown := cluster.FilterMachines(machines, mdFilter, failedOnly)
+		for _, m := range shown {
+			role := "worker"
+			if m.ControlPlane {
+				role = "control-plane"
+			}
+			output.WriteString(fmt.Sprintf("Name: %s\n", m.Name))
+			output.WriteString(fmt.Sprintf("Role: %s\n", role))
+			if m.MachineDeployment != "" {
+				output.WriteString(fmt.Sprintf("MachineDeployment: %s\n", m.MachineDeployment))
+			}
+			output.WriteString(fmt.Sprintf("Version: %s\n", m.Version))
+			output.WriteString(fmt.Sprintf("Phase: %s\n", m.Phase))
+			if m.ProviderID != "" {
+				output.WriteString(fmt.Sprintf("Provider ID: %s\n", m.ProviderID))
+			}
+			if m.NodeName != "" {
+				output.WriteString(fmt.Sprintf("Node: %s\n", m.NodeName))
+			}
+			if m.FailureReason != "" || m.FailureMessage != "" {
+				output.WriteString(fmt.Sprintf("Failure: %s %s\n", m.FailureReason, m.FailureMessage))
+			}
+			for _, cond := range m.Conditions {
+				if cond.Status == "False" {
+					output.WriteString(fmt.Sprintf("Condition %s: False (%s) %s\n", cond.Type, cond.Reason, cond.Message))
+				}
+			}
+			output.WriteString("---\n")
+		}
+		if len(shown) == 0 {
+			output.WriteString("No machines found\n")
+		}
+
+		return mcp.NewToolResultText(output.String()), nil
+	})
+
+	// cluster_nodes tool
+	nodesTool := mcp.NewTool(
+		"cluster_nodes",
+		mcp.WithDescription("List the nodes of a workload cluster with kubelet versions, taints and capacity"),
+		mcp.WithString("cluster", mcp.Required(), mcp.Description("Cluster name")),
+		mcp.WithString("namespace", mcp.Description("Namespace where the cluster is located")),
+		mcp.WithString("organization", mcp.Description("Organization that owns the cluster")),
+		mcp.WithString("role", mcp.Description("Show only nodes with this role (e.g. control-plane, worker)")),
+	)
+
+	s.AddTool(nodesTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		clusterName := args["cluster"].(string)
+		role := getStringArg(args, "role")
+
+		targetCluster, err := findCluster(toolCtx, clusterClient, clusterName, getStringArg(args, "namespace"), getStringArg(args, "organization"))
+		if err != nil {
+			return nil, err
+		}
+
+		nodes, err := clusterClient.ListNodes(toolCtx, targetCluster)
+		if err != nil {
+			return nil, err
+		}
+		if role != "" {
+			filtered := make([]cluster.Node, 0, len(nodes))
+			for _, n := range nodes {
+				if n.HasRole(role) {
+					filtered = append(filtered, n)
+				}
+			}
+			nodes = filtered
+		}
+
+		if len(nodes) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("No nodes found in cluster %s", clusterName)), nil
+		}
+
+		var output strings.Builder
+		output.WriteString(fmt.Sprintf("Nodes in cluster %s (%d):\n\n", clusterName, len(nodes)))
+
+		for _, n := range nodes {
+			status := "Ready"
+			if !n.Ready {
+				status = "NotReady"
+			}
+			if n.Unschedulable {
+				status += ",SchedulingDisabled"
+			}
+			output.WriteString(fmt.Sprintf("Name: %s\n", n.Name))
+			output.WriteString(fmt.Sprintf("Roles: %s\n", strings.Join(n.Roles, ", ")))
+			output.WriteString(fmt.Sprintf("Status: %s\n", status))
+			output.WriteString(fmt.Sprintf("Kubelet: %s\n", n.KubeletVersion))
+			if n.InstanceType != "" || n.Zone != "" {
+				output.WriteString(fmt.Sprintf("Instance: %s in %s\n", n.InstanceType, n.Zone))
+			}
+			output.WriteString(fmt.Sprintf("OS: %s (%s)\n", n.OSImage, n.ContainerRuntime))
+			output.WriteString(fmt.Sprintf("Capacity: %s\n", cluster.FormatResources(n.Capacity)))
+			output.WriteString(fmt.Sprintf("Allocatable: %s\n", cluster.FormatResources(n.Allocatable)))
+			if len(n.Taints) > 0 {
+				taints := make([]string, 0, len(n.Taints))
+				for _, taint := range n.Taints {
+					taints = append(taints, taint.ToString())
+				}
+				output.WriteString(fmt.Sprintf("Taints: %s\n", strings.Join(taints, ", ")))
+			}
+			output.WriteString("---\n")
+		}
+
+		versions := cluster.KubeletVersions(nodes)
+		summary := make([]string, 0, len(versions))
+		for _, version := range sortedKeys(versions) {
+			summary = append(summary, fmt.Sprintf("%s (%d)", version, versions[version]))
+		}
+		output.WriteString(fmt.Sprintf("\nKubelet versions: %s\n", strings.Join(summary, ", ")))
+		if len(versions) > 1 {
+			output.WriteString("Warning: nodes run different kubelet versions, an upgrade may be in progress or stuck\n")
+		}
+		output.WriteString(fmt.Sprintf("Total allocatable: %s\n", cluster.FormatResources(cluster.TotalAllocatable(nodes))))
+
+		return mcp.NewToolResultText(output.String()), nil
+	})
+
+	// cluster_metrics tool
+	metricsTool := mcp.NewTool(
+		"cluster_metrics",
+		mcp.WithDescription("Snapshot of the current CPU and memory utilization of a cluster: per node against its allocatable "+
+			"resources, and the namespaces and pods using the most. Read from Prometheus when configured with --prometheus, otherwise from the metrics-server"),
+		mcp.WithString("cluster", mcp.Description("Workload cluster name (default: the management cluster)")),
+		mcp.WithString("namespace", mcp.Description("Namespace where the cluster is located")),
+		mcp.WithString("organization", mcp.Description("Organization that owns the cluster")),
+		mcp.WithString("top", mcp.Description("Number of namespaces and pods to list (default: 10)")),
+	)
+
+	s.AddTool(metricsTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		clusterName := getStringArg(args, "cluster")
+
+		top := 10
+		if value := getStringArg(args, "top"); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid top %q: must be a positive number", value)
+			}
+			top = n
+		}
+
+		var k8sClient kubernetes.Interface = ctx.K8sClient
+		target := "the management cluster"
+		if clusterName != "" {
+			targetCluster, err := findCluster(toolCtx, clusterClient, clusterName, getStringArg(args, "namespace"), getStringArg(args, "organization"))
+			if err != nil {
+				return nil, err
+			}
+			if k8sClient, err = clusterClient.WorkloadClient(toolCtx, targetCluster); err != nil {
+				return nil, err
+			}
+			target = "cluster " + clusterName
+		}
+
+		var err error
+		var notes []string
+		var snapshot *metrics.Snapshot
+		if prometheus := ctx.Prometheus; prometheus != nil && (clusterName == "" || prometheus.CoversWorkloadClusters()) {
+			if snapshot, err = prometheus.Snapshot(toolCtx, k8sClient); err != nil {
+				notes = append(notes, fmt.Sprintf("Prometheus unavailable, fell back to the metrics-server: %v", err))
+			}
+		}
+		if snapshot == nil {
+			if snapshot, err = metrics.FromMetricsServer(toolCtx, k8sClient); err != nil {
+				return nil, err
+			}
+		}
+
+		return mcp.NewToolResultText(formatMetricsSnapshot(target, snapshot, top, notes)), nil
+	})
+
+	// alerts_list tool
+	alertsTool := mcp.NewTool(
+		"alerts_list",
+		mcp.WithDescription("List the alerts currently firing in the installation's Alertmanager (configured with --alertmanager), "+
+			"the most severe first, filtered by cluster, app, namespace, severity or labels"),
+		mcp.WithString("cluster", mcp.Description("Cluster the alerts fire for (cluster_id or cluster label)")),
+		mcp.WithString("app", mcp.Description("Name of an App CR whose alerts to list (requires namespace); also selects its cluster and target namespace")),
+		mcp.WithString("namespace", mcp.Description("Namespace of the App CR")),
+		mcp.WithString("target-namespace", mcp.Description("Namespace the alerts fire in (namespace label)")),
+		mcp.WithString("severity", mcp.Description("Only alerts of this severity (e.g. critical, page, warning)")),
+		mcp.WithString("labels", mcp.Description("Further label matchers in key=value format (comma-separated)")),
+		mcp.WithBoolean("include-silenced", mcp.Description("Include silenced and inhibited alerts (default: false)")),
+		withContinue(),
+	)
+
+	s.AddTool(alertsTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		if ctx.Alertmanager == nil {
+			return nil, fmt.Errorf("no Alertmanager configured: start the server with --alertmanager")
+		}
+
+		var filter alerts.Filter
+		if appName := getStringArg(args, "app"); appName != "" {
+			namespace := getStringArg(args, "namespace")
+			if namespace == "" {
+				return nil, fmt.Errorf("namespace is required together with app")
+			}
+			a, err := appClient.Get(toolCtx, namespace, appName)
+			if err != nil {
+				return nil, err
+			}
+			cl, err := clusterClient.FindAppCluster(toolCtx, a)
+			if err != nil {
+				return nil, err
+			}
+			clusterName := ""
+			if cl != nil {
+				clusterName = cl.Name
+			}
+			filter = alerts.AppFilter(a, clusterName)
+		}
+		if clusterName := getStringArg(args, "cluster"); clusterName != "" {
+			filter.Cluster = clusterName
+		}
+		if namespace := getStringArg(args, "target-namespace"); namespace != "" {
+			filter.Namespace = namespace
+		}
+		filter.Severity = getStringArg(args, "severity")
+		if labels := getStringArg(args, "labels"); labels != "" {
+			matchers, _, err := parseMetadataChanges(labels, "", false)
+			if err != nil {
+				return nil, fmt.Errorf("invalid labels: %w", err)
+			}
+			filter.Labels = matchers
+		}
+
+		all, err := ctx.Alertmanager.List(toolCtx, ctx.K8sClient, getBoolArg(args, "include-silenced"))
+		if err != nil {
+			return nil, err
+		}
+		matched := filter.Apply(all)
+		if len(matched) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("No matching alerts firing (%d alerts in total)", len(all))), nil
+		}
+
+		now := time.Now()
+		entries := make([]string, 0, len(matched))
+		for _, a := range matched {
+			entries = append(entries, "- "+a.Describe(now)+"\n")
+		}
+		result, err := budgetedList(ctx.OutputBudget, args, fmt.Sprintf("%d of %d alerts firing:\n\n", len(matched), len(all)), entries)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText(result), nil
+	})
+}
+
+// formatMetricsSnapshot renders the result of cluster_metrics. Nodes above
+// metricsWarningPercent of their allocatable CPU or memory are flagged.
+func formatMetricsSnapshot(target string, snapshot *metrics.Snapshot, top int, notes []string) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Utilization of %s (from %s):\n\n", target, snapshot.Source))
+	for _, note := range notes {
+		output.WriteString(fmt.Sprintf("Note: %s\n\n", note))
+	}
+
+	total := snapshot.Total()
+	output.WriteString(fmt.Sprintf("Cluster: CPU %.2f/%.2f cores (%.0f%%), memory %.1f/%.1fGi (%.0f%%)\n\n",
+		total.CPUCores, total.AllocatableCPUCores, total.CPUPercent(),
+		total.MemoryBytes/(1<<30), total.AllocatableMemoryBytes/(1<<30), total.MemoryPercent()))
+
+	var warnings []string
+	output.WriteString(fmt.Sprintf("Nodes (%d):\n", len(snapshot.Nodes)))
+	for _, n := range snapshot.Nodes {
+		status := ""
+		if !n.Ready {
+			status = " [NotReady]"
+			warnings = append(warnings, fmt.Sprintf("node %s is not ready", n.Name))
+		}
+		output.WriteString(fmt.Sprintf("- %s: CPU %.2f/%.2f cores (%.0f%%), memory %.1f/%.1fGi (%.0f%%)%s\n",
+			n.Name, n.CPUCores, n.AllocatableCPUCores, n.CPUPercent(),
+			n.MemoryBytes/(1<<30), n.AllocatableMemoryBytes/(1<<30), n.MemoryPercent(), status))
+		if n.CPUPercent() >= metricsWarningPercent {
+			warnings = append(warnings, fmt.Sprintf("node %s uses %.0f%% of its CPU", n.Name, n.CPUPercent()))
+		}
+		if n.MemoryPercent() >= metricsWarningPercent {
+			warnings = append(warnings, fmt.Sprintf("node %s uses %.0f%% of its memory", n.Name, n.MemoryPercent()))
+		}
+	}
+
+	writeUsages := func(title string, usages []metrics.PodUsage) {
+		output.WriteString(fmt.Sprintf("\n%s:\n", title))
+		for _, usage := range usages {
+			name := usage.Namespace
+			if usage.Name != "" {
+				name += "/" + usage.Name
+			}
+			output.WriteString(fmt.Sprintf("- %s: CPU %.3f cores, memory %.0fMi\n", name, usage.CPUCores, usage.MemoryBytes/(1<<20)))
+		}
+	}
+	writeUsages("Namespaces by CPU", snapshot.TopNamespaces(top, false))
+	writeUsages("Namespaces by memory", snapshot.TopNamespaces(top, true))
+	writeUsages("Pods by CPU", snapshot.TopPods(top, false))
+	writeUsages("Pods by memory", snapshot.TopPods(top, true))
+
+	if len(warnings) > 0 {
+		output.WriteString("\nWarnings:\n")
+		for _, warning := range warnings {
+			output.WriteString(fmt.Sprintf("- %s\n", warning))
+		}
+	}
+	return output.String()
+}
+
+// formatMachineHealth renders the result of cluster_healthchecks
+func formatMachineHealth(clusterName string, health *cluster.MachineHealth, now time.Time) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("MachineHealthChecks in cluster %s:\n\n", clusterName))
+	if len(health.HealthChecks) == 0 {
+		output.WriteString("No MachineHealthChecks found: unhealthy machines are not replaced automatically\n")
+	}
+	for _, mhc := range health.HealthChecks {
+		output.WriteString(fmt.Sprintf("Name: %s\n", mhc.Name))
+		if len(mhc.Selector) > 0 {
+			selector := make([]string, 0, len(mhc.Selector))
+			for key, value := range mhc.Selector {
+				selector = append(selector, key+"="+value)
+			}
+			sort.Strings(selector)
+			output.WriteString(fmt.Sprintf("Selector: %s\n", strings.Join(selector, ",")))
+		}
+		for _, cond := range mhc.UnhealthyConditions {
+			output.WriteString(fmt.Sprintf("Unhealthy when: node %s=%s for %s\n", cond.Type, cond.Status, cond.Timeout))
+		}
+		if mhc.NodeStartupTimeout != "" {
+			output.WriteString(fmt.Sprintf("Node startup timeout: %s\n", mhc.NodeStartupTimeout))
+		}
+		if mhc.MaxUnhealthy != "" {
+			output.WriteString(fmt.Sprintf("Max unhealthy: %s\n", mhc.MaxUnhealthy))
+		}
+		if mhc.UnhealthyRange != "" {
+			output.WriteString(fmt.Sprintf("Unhealthy range: %s\n", mhc.UnhealthyRange))
+		}
+		output.WriteString(fmt.Sprintf("Machines: %d expected, %d healthy, %d remediations allowed\n",
+			mhc.ExpectedMachines, mhc.CurrentHealthy, mhc.RemediationsAllowed))
+		for _, cond := range mhc.Conditions {
+			if cond.Status == "False" {
+				output.WriteString(fmt.Sprintf("Condition %s: False (%s) %s\n", cond.Type, cond.Reason, cond.Message))
+			}
+		}
+		if mhc.Restricted != nil {
+			output.WriteString(fmt.Sprintf("REMEDIATION RESTRICTED %s ago: %s\n", duration.HumanDuration(now.Sub(mhc.Restricted.LastSeen)), mhc.Restricted.Message))
+		}
+		if len(mhc.Unhealthy) > 0 {
+			output.WriteString("Unhealthy machines:\n")
+			for _, m := range mhc.Unhealthy {
+				node := ""
+				if m.NodeName != "" {
+					node = fmt.Sprintf(" (node %s)", m.NodeName)
+				}
+				output.WriteString(fmt.Sprintf("  - %s%s since %s: %s %s\n", m.Name, node, m.Since, m.Reason, m.Message))
+				if m.WaitingForRemediation {
+					output.WriteString("    waiting for its owner to replace it\n")
+				}
+			}
+		}
+		output.WriteString("---\n")
+	}
+
+	output.WriteString("\nRecent remediations:\n\n")
+	if len(health.Remediations) == 0 {
+		output.WriteString("No machines marked unhealthy (events are kept for about an hour)\n")
+	}
+	for _, remediation := range health.Remediations {
+		output.WriteString(fmt.Sprintf("  - %s: marked unhealthy %dx, last %s ago: %s\n", remediation.Object,
+			remediation.Count, duration.HumanDuration(now.Sub(remediation.LastSeen)), remediation.Message))
+	}
+	return output.String()
+}
@@ -0,0 +1,165 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+)
+
+// registerClusterPauseTools registers the tools labeling, annotating, pausing
+// and resuming clusters
+func registerClusterPauseTools(s *mcpserver.MCPServer, ctx *server.Context, clusterClient *cluster.Client) {
+	// cluster_label tool
+	labelTool := mcp.NewTool(
+		"cluster_label",
+		mcp.WithDescription("Add, change or remove labels on a cluster (e.g., environment, cost center)"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Cluster name")),
+		mcp.WithString("namespace", mcp.Description("Namespace where the cluster is located")),
+		mcp.WithString("organization", mcp.Description("Organization that owns the cluster")),
+		mcp.WithString("set", mcp.Description("Labels to set in key=value format (comma-separated)")),
+		mcp.WithString("remove", mcp.Description("Label keys to remove (comma-separated)")),
+		mcp.WithBoolean("force", mcp.Description("Allow changing giantswarm.io labels, which Giant Swarm controllers rely on, and take over labels owned by other field managers (default: false)")),
+	)
+
+	s.AddTool(labelTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		clusterName := args["name"].(string)
+
+		set, remove, err := parseClusterMetadataChanges(args, true)
+		if err != nil {
+			return nil, err
+		}
+
+		targetCluster, err := findCluster(toolCtx, clusterClient, clusterName, getStringArg(args, "namespace"), getStringArg(args, "organization"))
+		if err != nil {
+			return nil, err
+		}
+
+		updated, err := clusterClient.SetLabels(toolCtx, targetCluster.Namespace, targetCluster.Name, set, remove, k8s.UpdateOptions{Force: getBoolArg(args, "force")})
+		if err != nil {
+			return nil, err
+		}
+
+		return mcp.NewToolResultText(formatMetadata(fmt.Sprintf("Updated labels of cluster %s/%s", updated.Namespace, updated.Name), updated.Labels)), nil
+	})
+
+	// cluster_annotate tool
+	annotateTool := mcp.NewTool(
+		"cluster_annotate",
+		mcp.WithDescription("Add, change or remove annotations on a cluster"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Cluster name")),
+		mcp.WithString("namespace", mcp.Description("Namespace where the cluster is located")),
+		mcp.WithString("organization", mcp.Description("Organization that owns the cluster")),
+		mcp.WithString("set", mcp.Description("Annotations to set in key=value format (comma-separated)")),
+		mcp.WithString("remove", mcp.Description("Annotation keys to remove (comma-separated)")),
+		mcp.WithBoolean("force", mcp.Description("Allow changing giantswarm.io annotations, which Giant Swarm controllers rely on, and take over annotations owned by other field managers (default: false)")),
+	)
+
+	s.AddTool(annotateTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		clusterName := args["name"].(string)
+
+		set, remove, err := parseClusterMetadataChanges(args, false)
+		if err != nil {
+			return nil, err
+		}
+
+		targetCluster, err := findCluster(toolCtx, clusterClient, clusterName, getStringArg(args, "namespace"), getStringArg(args, "organization"))
+		if err != nil {
+			return nil, err
+		}
+
+		updated, err := clusterClient.SetAnnotations(toolCtx, targetCluster.Namespace, targetCluster.Name, set, remove, k8s.UpdateOptions{Force: getBoolArg(args, "force")})
+		if err != nil {
+			return nil, err
+		}
+
+		return mcp.NewToolResultText(formatMetadata(fmt.Sprintf("Updated annotations of cluster %s/%s", updated.Namespace, updated.Name), updated.Annotations)), nil
+	})
+
+	// cluster_pause tool
+	pauseTool := mcp.NewTool(
+		"cluster_pause",
+		mcp.WithDescription("Pause CAPI reconciliation of a cluster and its machines, control plane and infrastructure (spec.paused and the cluster.x-k8s.io/paused annotation), e.g. for a maintenance window. Who paused it, when and why are recorded and shown by cluster_get and cluster_list."),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Cluster name")),
+		mcp.WithString("namespace", mcp.Description("Namespace where the cluster is located")),
+		mcp.WithString("organization", mcp.Description("Organization that owns the cluster")),
+		mcp.WithString("reason", mcp.Description("Why reconciliation is paused, e.g. a maintenance ticket")),
+	)
+
+	s.AddTool(pauseTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+
+		targetCluster, err := findCluster(toolCtx, clusterClient, args["name"].(string), getStringArg(args, "namespace"), getStringArg(args, "organization"))
+		if err != nil {
+			return nil, err
+		}
+		if pause := targetCluster.PauseState(); pause.Paused {
+			return mcp.NewToolResultText(fmt.Sprintf("Cluster %s/%s is already %s", targetCluster.Namespace, targetCluster.Name, pause.Summary(time.Now()))), nil
+		}
+
+		paused, err := clusterClient.Pause(toolCtx, targetCluster.Namespace, targetCluster.Name, ctx.Creator(toolCtx), getStringArg(args, "reason"), time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Paused reconciliation of cluster %s/%s: %s. "+
+			"CAPI controllers will not act on changes to the cluster, its machines or infrastructure until it is resumed with cluster_resume.",
+			paused.Namespace, paused.Name, paused.PauseState().Summary(time.Now()))), nil
+	})
+
+	// cluster_resume tool
+	resumeTool := mcp.NewTool(
+		"cluster_resume",
+		mcp.WithDescription("Resume CAPI reconciliation of a paused cluster"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Cluster name")),
+		mcp.WithString("namespace", mcp.Description("Namespace where the cluster is located")),
+		mcp.WithString("organization", mcp.Description("Organization that owns the cluster")),
+	)
+
+	s.AddTool(resumeTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+
+		targetCluster, err := findCluster(toolCtx, clusterClient, args["name"].(string), getStringArg(args, "namespace"), getStringArg(args, "organization"))
+		if err != nil {
+			return nil, err
+		}
+		pause := targetCluster.PauseState()
+		if !pause.Paused {
+			return mcp.NewToolResultText(fmt.Sprintf("Cluster %s/%s is not paused", targetCluster.Namespace, targetCluster.Name)), nil
+		}
+
+		if _, err := clusterClient.Resume(toolCtx, targetCluster.Namespace, targetCluster.Name); err != nil {
+			return nil, err
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Resumed reconciliation of cluster %s/%s, which was %s", targetCluster.Namespace, targetCluster.Name, pause.Summary(time.Now()))), nil
+	})
+}
+
+// parseClusterMetadataChanges parses the set/remove arguments of the cluster
+// labeling tools and rejects changes to protected keys unless forced
+func parseClusterMetadataChanges(args map[string]interface{}, isLabel bool) (map[string]string, []string, error) {
+	set, remove, err := parseMetadataChanges(getStringArg(args, "set"), getStringArg(args, "remove"), isLabel)
+	if err != nil {
+		return nil, nil, err
+	}
+	if getBoolArg(args, "force") {
+		return set, remove, nil
+	}
+
+	keys := append(sortedKeys(set), remove...)
+	for _, key := range keys {
+		if cluster.IsProtectedKey(key) {
+			return nil, nil, fmt.Errorf("%s is managed by Giant Swarm and changing it may break the cluster; re-run with force=true to change it anyway", key)
+		}
+	}
+	return set, remove, nil
+}
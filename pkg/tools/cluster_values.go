@@ -0,0 +1,349 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+)
+
+// registerClusterValuesTools registers the tools reading and changing the
+// values of clusters and planning their upgrades
+func registerClusterValuesTools(s *mcpserver.MCPServer, ctx *server.Context, appClient *app.Client, clusterClient *cluster.Client, configClient *config.Client) {
+	// cluster_values_get tool
+	valuesGetTool := mcp.NewTool(
+		"cluster_values_get",
+		mcp.WithDescription("Show the user values of the cluster-$provider app a cluster was created from, e.g. its node pools, OIDC and control plane settings"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Cluster name")),
+		mcp.WithString("namespace", mcp.Description("Namespace where the cluster is located")),
+		mcp.WithString("organization", mcp.Description("Organization that owns the cluster")),
+		mcp.WithString("key", mcp.Description("Only show the values under this dotted path, e.g. global.nodePools")),
+	)
+
+	s.AddTool(valuesGetTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		clusterName := args["name"].(string)
+		key := getStringArg(args, "key")
+
+		targetCluster, err := findCluster(toolCtx, clusterClient, clusterName, getStringArg(args, "namespace"), getStringArg(args, "organization"))
+		if err != nil {
+			return nil, err
+		}
+		clusterApp, err := clusterClient.GetClusterApp(toolCtx, targetCluster.Namespace, targetCluster.Name)
+		if err != nil {
+			return nil, err
+		}
+		values, source, err := readClusterValues(toolCtx, configClient, clusterApp)
+		if err != nil {
+			return nil, err
+		}
+
+		var output strings.Builder
+		output.WriteString(fmt.Sprintf("Cluster app: %s/%s (%s %s, catalog %s)\n", clusterApp.Namespace, clusterApp.Name, clusterApp.Spec.Name, clusterApp.Spec.Version, clusterApp.Spec.Catalog))
+		if source == nil {
+			output.WriteString("User values: none\n")
+			return mcp.NewToolResultText(output.String()), nil
+		}
+		output.WriteString(fmt.Sprintf("User values: %s\n\n", source))
+
+		value, ok := config.LookupValue(values, key)
+		if !ok {
+			output.WriteString(fmt.Sprintf("%s is not set\n", key))
+			return mcp.NewToolResultText(output.String()), nil
+		}
+		rendered, err := yaml.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render values: %w", err)
+		}
+		if key != "" {
+			output.WriteString(key + ":\n")
+		}
+		output.Write(rendered)
+
+		return mcp.NewToolResultText(output.String()), nil
+	})
+
+	// cluster_values_set tool
+	valuesSetTool := mcp.NewTool(
+		"cluster_values_set",
+		mcp.WithDescription("Change the user values of the cluster-$provider app a cluster was created from. The result is validated against the chart's values schema before it is written."),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Cluster name")),
+		mcp.WithString("namespace", mcp.Description("Namespace where the cluster is located")),
+		mcp.WithString("organization", mcp.Description("Organization that owns the cluster")),
+		mcp.WithString("key", mcp.Description("Dotted path of the value to replace, e.g. global.nodePools.pool0.maxSize; without it value is merged into the values")),
+		mcp.WithString("value", mcp.Required(), mcp.Description("New value as YAML; null removes the key")),
+		mcp.WithBoolean("dry-run", mcp.Description("Only validate and show the change (default: false)")),
+		mcp.WithBoolean("skip-validation", mcp.Description("Write the values even when the chart schema cannot be fetched (default: false)")),
+	)
+
+	s.AddTool(valuesSetTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		clusterName := args["name"].(string)
+		key := getStringArg(args, "key")
+		dryRun := getBoolArg(args, "dry-run")
+
+		var value interface{}
+		if err := yaml.Unmarshal([]byte(args["value"].(string)), &value); err != nil {
+			return nil, fmt.Errorf("invalid value: %w", err)
+		}
+
+		targetCluster, err := findCluster(toolCtx, clusterClient, clusterName, getStringArg(args, "namespace"), getStringArg(args, "organization"))
+		if err != nil {
+			return nil, err
+		}
+		clusterApp, err := clusterClient.GetClusterApp(toolCtx, targetCluster.Namespace, targetCluster.Name)
+		if err != nil {
+			return nil, err
+		}
+		values, source, err := readClusterValues(toolCtx, configClient, clusterApp)
+		if err != nil {
+			return nil, err
+		}
+		if source != nil && source.Type == config.ConfigTypeSecret {
+			return nil, fmt.Errorf("the user values of %s/%s are in %s; only ConfigMaps can be changed", clusterApp.Namespace, clusterApp.Name, source)
+		}
+
+		var updated map[string]interface{}
+		if key != "" {
+			if updated, err = config.SetValue(values, key, value); err != nil {
+				return nil, err
+			}
+		} else {
+			override, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("value must be a map when no key is given")
+			}
+			updated = config.MergeValues(values, override)
+		}
+
+		var output strings.Builder
+		files, err := fetchAppChart(toolCtx, ctx, clusterApp.Spec.Catalog, clusterApp.Spec.Name, clusterApp.Spec.Version)
+		switch {
+		case err != nil && !getBoolArg(args, "skip-validation"):
+			return nil, fmt.Errorf("failed to fetch chart %s %s to validate the values, set skip-validation to write them anyway: %w", clusterApp.Spec.Name, clusterApp.Spec.Version, err)
+		case err != nil:
+			output.WriteString(fmt.Sprintf("Warning: values not validated: %v\n", err))
+		default:
+			if schema, ok := files.ValuesSchema(); ok {
+				violations, err := config.ValidateValues(updated, schema)
+				if err != nil {
+					return nil, err
+				}
+				if len(violations) > 0 {
+					return nil, fmt.Errorf("the values do not match the schema of %s %s:\n  %s", clusterApp.Spec.Name, clusterApp.Spec.Version, strings.Join(violations, "\n  "))
+				}
+				output.WriteString(fmt.Sprintf("✓ Values match the schema of %s %s\n", clusterApp.Spec.Name, clusterApp.Spec.Version))
+			} else {
+				output.WriteString(fmt.Sprintf("Warning: %s %s has no values schema, values not validated\n", clusterApp.Spec.Name, clusterApp.Spec.Version))
+			}
+		}
+
+		rendered, err := yaml.Marshal(updated)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render values: %w", err)
+		}
+
+		if dryRun {
+			output.WriteString("\nDry run, new user values:\n")
+			output.Write(rendered)
+			return mcp.NewToolResultText(output.String()), nil
+		}
+
+		if source == nil {
+			source = &clusterValuesSource{
+				BundleRef: config.BundleRef{Layer: "userConfig", Type: config.ConfigTypeConfigMap, Namespace: clusterApp.Namespace, Name: cluster.UserConfigName(clusterApp.Name)},
+				Key:       cluster.UserConfigKey,
+			}
+			err := configClient.CreateConfigMap(toolCtx, &config.Config{
+				Name:      source.Name,
+				Namespace: source.Namespace,
+				Type:      config.ConfigTypeConfigMap,
+				Data:      map[string]string{source.Key: string(rendered)},
+			})
+			if err != nil {
+				return nil, err
+			}
+			if _, err := appClient.Update(toolCtx, clusterApp.Namespace, clusterApp.Name, k8s.UpdateOptions{}, func(current *app.App) error {
+				setAppConfigRef(current, source.BundleRef)
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+			output.WriteString(fmt.Sprintf("Created %s and referenced it as the user config of %s/%s\n", source, clusterApp.Namespace, clusterApp.Name))
+		} else {
+			err := configClient.Update(toolCtx, source.Namespace, source.Name, config.ConfigTypeConfigMap, k8s.UpdateOptions{}, func(cfg *config.Config) error {
+				cfg.Data[source.Key] = string(rendered)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			output.WriteString(fmt.Sprintf("Updated %s\n", source))
+		}
+		output.WriteString("Note: the values were re-serialized, YAML comments are not preserved\n")
+
+		return mcp.NewToolResultText(output.String()), nil
+	})
+
+	// cluster_upgrade_plan tool
+	upgradePlanTool := mcp.NewTool(
+		"cluster_upgrade_plan",
+		mcp.WithDescription("Plan the release upgrade of a cluster: lists the releases it may upgrade to next and the upgrade path to a target release, one major version at a time, with the component and app version changes of each hop"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Cluster name")),
+		mcp.WithString("namespace", mcp.Description("Namespace where the cluster is located")),
+		mcp.WithString("organization", mcp.Description("Organization that owns the cluster")),
+		mcp.WithString("target", mcp.Description("Release version to upgrade to (default: newest active release)")),
+		mcp.WithBoolean("allow-skip-majors", mcp.Description("Allow upgrading across several major versions in one hop (default: false)")),
+		mcp.WithBoolean("include-previews", mcp.Description("Consider preview releases as targets (default: false)")),
+	)
+
+	s.AddTool(upgradePlanTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		clusterName := args["name"].(string)
+
+		targetCluster, err := findCluster(toolCtx, clusterClient, clusterName, getStringArg(args, "namespace"), getStringArg(args, "organization"))
+		if err != nil {
+			return nil, err
+		}
+		current := targetCluster.GetReleaseVersion()
+		if current == "" {
+			return nil, fmt.Errorf("cluster %s has no %s label; it is not managed by Giant Swarm releases", targetCluster.Name, cluster.ReleaseVersionLabel)
+		}
+
+		provider := cluster.ReleaseProvider(targetCluster.GetProvider())
+		releases, err := clusterClient.ListReleases(toolCtx, provider)
+		if err != nil {
+			return nil, err
+		}
+		if len(releases) == 0 {
+			return nil, fmt.Errorf("no %s releases found on the management cluster", provider)
+		}
+
+		plan, err := cluster.PlanUpgrade(current, releases, cluster.UpgradePlanOptions{
+			Target:              getStringArg(args, "target"),
+			AllowSkippingMajors: getBoolArg(args, "allow-skip-majors"),
+			IncludePreviews:     getBoolArg(args, "include-previews"),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return mcp.NewToolResultText(formatUpgradePlan(targetCluster.Name, provider, plan)), nil
+	})
+}
+
+// formatUpgradePlan renders the result of cluster_upgrade_plan
+func formatUpgradePlan(clusterName, provider string, plan *cluster.UpgradePlan) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Upgrade plan of cluster %s (%s release %s", clusterName, provider, plan.Current.Version))
+	if plan.Current.State != "" {
+		output.WriteString(", " + plan.Current.State)
+	}
+	output.WriteString("):\n")
+	if plan.Current.Name == "" {
+		output.WriteString("WARNING: the current release was not found, component changes of the first hop are incomplete\n")
+	}
+
+	if len(plan.Candidates) == 0 {
+		output.WriteString("\nThe cluster is on the newest release, there is nothing to upgrade to.\n")
+		return output.String()
+	}
+
+	output.WriteString("\nValid next releases:\n")
+	for _, release := range plan.Candidates {
+		output.WriteString(fmt.Sprintf("- %s", release.Version))
+		if release.State != "" && release.State != cluster.ReleaseStateActive {
+			output.WriteString(" (" + release.State + ")")
+		}
+		if release.Date != "" {
+			output.WriteString(", released " + release.Date)
+		}
+		output.WriteString("\n")
+	}
+
+	output.WriteString(fmt.Sprintf("\nPath to %s (%d hops):\n", plan.Target.Version, len(plan.Hops)))
+	for i, hop := range plan.Hops {
+		kind := "minor/patch"
+		if hop.Major {
+			kind = "major"
+		}
+		output.WriteString(fmt.Sprintf("\n%d. %s -> %s (%s upgrade)\n", i+1, hop.From.Version, hop.To.Version, kind))
+		if len(hop.Changes) == 0 {
+			output.WriteString("   No component changes\n")
+			continue
+		}
+		for _, change := range hop.Changes {
+			label := "component"
+			if change.App {
+				label = "app"
+			}
+			switch {
+			case change.From == "":
+				output.WriteString(fmt.Sprintf("   + %s %s %s (added)\n", label, change.Name, change.To))
+			case change.To == "":
+				output.WriteString(fmt.Sprintf("   - %s %s %s (removed)\n", label, change.Name, change.From))
+			default:
+				output.WriteString(fmt.Sprintf("   ~ %s %s: %s -> %s\n", label, change.Name, change.From, change.To))
+			}
+		}
+	}
+
+	output.WriteString(fmt.Sprintf("\nReview the release notes of each hop (releasenotes://%s/{version}) before upgrading; upgrade one hop at a time and let the cluster settle in between.\n", provider))
+	return output.String()
+}
+
+// clusterValuesSource is the key of the ConfigMap or Secret holding the user
+// values of a cluster app
+type clusterValuesSource struct {
+	config.BundleRef
+	Key string
+}
+
+func (s *clusterValuesSource) String() string {
+	return fmt.Sprintf("%s %s/%s, key %s", s.Type, s.Namespace, s.Name, s.Key)
+}
+
+// readClusterValues reads the user values of a cluster app. The returned
+// source is nil when the app has no user config.
+func readClusterValues(ctx context.Context, configClient *config.Client, clusterApp *app.App) (map[string]interface{}, *clusterValuesSource, error) {
+	userConfig := clusterApp.Spec.UserConfig
+	if userConfig == nil || (userConfig.ConfigMap == nil && userConfig.Secret == nil) {
+		return map[string]interface{}{}, nil, nil
+	}
+
+	ref := &clusterValuesSource{BundleRef: config.BundleRef{Layer: "userConfig", Type: config.ConfigTypeConfigMap}}
+	if userConfig.ConfigMap != nil {
+		ref.Name, ref.Namespace = userConfig.ConfigMap.Name, refNamespace(userConfig.ConfigMap.Namespace, clusterApp)
+	} else {
+		ref.Type = config.ConfigTypeSecret
+		ref.Name, ref.Namespace = userConfig.Secret.Name, refNamespace(userConfig.Secret.Namespace, clusterApp)
+	}
+
+	cfg, err := configClient.Get(ctx, ref.Namespace, ref.Name, ref.Type)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The values are in the values key, or the only key
+	ref.Key = cluster.UserConfigKey
+	if _, ok := cfg.Data[ref.Key]; !ok && len(cfg.Data) == 1 {
+		for k := range cfg.Data {
+			ref.Key = k
+		}
+	}
+
+	values, _, err := config.ParseValues(cfg.Data[ref.Key])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", ref, err)
+	}
+	return values, ref, nil
+}
@@ -3,18 +3,26 @@ package tools
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	mcpserver "github.com/mark3labs/mcp-go/server"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
 	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
 	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
 )
 
 // RegisterConfigTools registers all configuration management tools
 func RegisterConfigTools(s *mcpserver.MCPServer, ctx *server.Context) error {
-	client := config.NewClient(ctx.K8sClient)
+	client := newConfigClient(ctx)
+	history := config.NewHistory(ctx.K8sClient, ctx.ConfigHistoryRevisions)
+	appClient := app.NewClient(ctx.DynamicClient)
 
 	// config_get tool
 	getTool := mcp.NewTool(
@@ -25,6 +33,7 @@ func RegisterConfigTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 		mcp.WithString("type", mcp.Description("Type: configmap or secret (default: configmap)")),
 		mcp.WithString("format", mcp.Description("Output format: yaml, json, or text (default: text)")),
 		mcp.WithBoolean("decode", mcp.Description("Decode base64 values for secrets (default: false)")),
+		withPart(),
 	)
 
 	s.AddTool(getTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -112,6 +121,7 @@ func RegisterConfigTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 		mcp.WithString("key", mcp.Required(), mcp.Description("Configuration key to set")),
 		mcp.WithString("value", mcp.Required(), mcp.Description("Configuration value")),
 		mcp.WithBoolean("create", mcp.Description("Create if it doesn't exist (default: false)")),
+		mcp.WithBoolean("force", mcp.Description("Overwrite concurrent changes instead of retrying (default: false)")),
 	)
 
 	s.AddTool(setTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -138,29 +148,26 @@ func RegisterConfigTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 			return nil, fmt.Errorf("invalid type: %s (must be configmap or secret)", configType)
 		}
 
-		// Get or create the configuration
-		cfg, err := client.Get(toolCtx, namespace, name, cfgType)
+		revision, err := recordRevision(toolCtx, history, client, namespace, name, cfgType)
 		if err != nil {
-			if !create {
-				return nil, err
-			}
-			// Create new config
-			cfg = &config.Config{
+			return nil, err
+		}
+
+		// Update the configuration, creating it if requested
+		opts := k8s.UpdateOptions{Force: getBoolArg(args, "force")}
+		err = client.Update(toolCtx, namespace, name, cfgType, opts, func(cfg *config.Config) error {
+			cfg.SetValue(key, value)
+			return nil
+		})
+		if apierrors.IsNotFound(err) && create {
+			cfg := &config.Config{
 				Name:      name,
 				Namespace: namespace,
 				Type:      cfgType,
 				Data:      make(map[string]string),
 				Labels:    make(map[string]string),
 			}
-		}
-
-		// Set the value
-		cfg.SetValue(key, value)
-
-		// Update or create
-		if err == nil {
-			err = client.Update(toolCtx, cfg)
-		} else {
+			cfg.SetValue(key, value)
 			err = client.Create(toolCtx, cfg)
 		}
 
@@ -168,363 +175,198 @@ func RegisterConfigTools(s *mcpserver.MCPServer, ctx *server.Context) error {
 			return nil, err
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully set %s=%s in %s %s/%s", key, value, configType, namespace, name)), nil
+		result := fmt.Sprintf("Successfully set %s=%s in %s %s/%s", key, value, configType, namespace, name)
+		return mcp.NewToolResultText(result + revision), nil
 	})
 
-	// config_validate tool
-	validateTool := mcp.NewTool(
-		"config_validate",
-		mcp.WithDescription("Validate configuration against a schema"),
+	// config_history tool
+	historyTool := mcp.NewTool(
+		"config_history",
+		mcp.WithDescription("List the previous revisions of a ConfigMap or Secret recorded by config_set and secret_update"),
 		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the ConfigMap or Secret")),
 		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace")),
 		mcp.WithString("type", mcp.Description("Type: configmap or secret (default: configmap)")),
-		mcp.WithString("required-keys", mcp.Description("Comma-separated list of required keys")),
-		mcp.WithString("optional-keys", mcp.Description("Comma-separated list of optional keys")),
 	)
 
-	s.AddTool(validateTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.AddTool(historyTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := req.Params.Arguments.(map[string]interface{})
 		name := args["name"].(string)
 		namespace := args["namespace"].(string)
-		configType := getStringArg(args, "type")
-		requiredKeys := getStringArg(args, "required-keys")
-		optionalKeys := getStringArg(args, "optional-keys")
-
-		if configType == "" {
-			configType = "configmap"
-		}
 
-		// Determine config type
-		var cfgType config.ConfigType
-		switch configType {
-		case "configmap":
-			cfgType = config.ConfigTypeConfigMap
-		case "secret":
-			cfgType = config.ConfigTypeSecret
-		default:
-			return nil, fmt.Errorf("invalid type: %s (must be configmap or secret)", configType)
-		}
-
-		// Get the configuration
-		cfg, err := client.Get(toolCtx, namespace, name, cfgType)
+		cfgType, err := parseConfigType(getStringArg(args, "type"))
 		if err != nil {
 			return nil, err
 		}
 
-		// Build schema
-		schema := &config.ConfigSchema{
-			RequiredKeys: []string{},
-			OptionalKeys: []string{},
-			KeyPatterns:  make(map[string]string),
-		}
-
-		if requiredKeys != "" {
-			schema.RequiredKeys = strings.Split(requiredKeys, ",")
-		}
-		if optionalKeys != "" {
-			schema.OptionalKeys = strings.Split(optionalKeys, ",")
-		}
-
-		// Validate
-		result := client.Validate(cfg, schema)
-
-		var output strings.Builder
-		if result.Valid {
-			output.WriteString("✓ Configuration is valid\n")
-		} else {
-			output.WriteString("✗ Configuration is invalid\n\nErrors:\n")
-			for _, err := range result.Errors {
-				output.WriteString(fmt.Sprintf("  - %s\n", err))
-			}
-		}
-
-		return mcp.NewToolResultText(output.String()), nil
-	})
-
-	// config_diff tool
-	diffTool := mcp.NewTool(
-		"config_diff",
-		mcp.WithDescription("Show differences between two configurations"),
-		mcp.WithString("name1", mcp.Required(), mcp.Description("Name of the first ConfigMap/Secret")),
-		mcp.WithString("namespace1", mcp.Required(), mcp.Description("Namespace of the first config")),
-		mcp.WithString("name2", mcp.Required(), mcp.Description("Name of the second ConfigMap/Secret")),
-		mcp.WithString("namespace2", mcp.Required(), mcp.Description("Namespace of the second config")),
-		mcp.WithString("type", mcp.Description("Type: configmap or secret (default: configmap)")),
-	)
-
-	s.AddTool(diffTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args := req.Params.Arguments.(map[string]interface{})
-		name1 := args["name1"].(string)
-		namespace1 := args["namespace1"].(string)
-		name2 := args["name2"].(string)
-		namespace2 := args["namespace2"].(string)
-		configType := getStringArg(args, "type")
-
-		if configType == "" {
-			configType = "configmap"
-		}
-
-		// Determine config type
-		var cfgType config.ConfigType
-		switch configType {
-		case "configmap":
-			cfgType = config.ConfigTypeConfigMap
-		case "secret":
-			cfgType = config.ConfigTypeSecret
-		default:
-			return nil, fmt.Errorf("invalid type: %s (must be configmap or secret)", configType)
-		}
-
-		// Get both configurations
-		cfg1, err := client.Get(toolCtx, namespace1, name1, cfgType)
+		current, err := client.Get(toolCtx, namespace, name, cfgType)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get first config: %w", err)
+			return nil, err
 		}
 
-		cfg2, err := client.Get(toolCtx, namespace2, name2, cfgType)
+		revisions, err := history.List(toolCtx, namespace, name, cfgType)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get second config: %w", err)
+			return nil, err
 		}
 
-		// Calculate diff
-		diff := cfg1.Diff(cfg2)
-
 		var output strings.Builder
-		output.WriteString(fmt.Sprintf("Diff between %s/%s and %s/%s:\n\n", namespace1, name1, namespace2, name2))
-
-		if !diff.HasChanges() {
-			output.WriteString("No differences found\n")
-		} else {
-			if len(diff.Added) > 0 {
-				output.WriteString("Added keys:\n")
-				for k, v := range diff.Added {
-					output.WriteString(fmt.Sprintf("  + %s: %s\n", k, v))
-				}
-			}
-
-			if len(diff.Modified) > 0 {
-				output.WriteString("\nModified keys:\n")
-				for k, entry := range diff.Modified {
-					output.WriteString(fmt.Sprintf("  ~ %s:\n", k))
-					output.WriteString(fmt.Sprintf("    - %s\n", entry.Old))
-					output.WriteString(fmt.Sprintf("    + %s\n", entry.New))
-				}
-			}
-
-			if len(diff.Removed) > 0 {
-				output.WriteString("\nRemoved keys:\n")
-				for k, v := range diff.Removed {
-					output.WriteString(fmt.Sprintf("  - %s: %s\n", k, v))
-				}
-			}
+		if !history.Enabled() {
+			output.WriteString("Note: config history is disabled, start the server with --config-history-revisions to record new revisions\n\n")
 		}
-
-		return mcp.NewToolResultText(output.String()), nil
-	})
-
-	// secret_create tool
-	createSecretTool := mcp.NewTool(
-		"secret_create",
-		mcp.WithDescription("Create a new secret for an app"),
-		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the secret")),
-		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace")),
-		mcp.WithString("data", mcp.Required(), mcp.Description("Secret data in key=value format (comma-separated)")),
-		mcp.WithString("app", mcp.Description("App name to associate with the secret")),
-		mcp.WithString("labels", mcp.Description("Additional labels in key=value format (comma-separated)")),
-	)
-
-	s.AddTool(createSecretTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args := req.Params.Arguments.(map[string]interface{})
-		name := args["name"].(string)
-		namespace := args["namespace"].(string)
-		dataStr := args["data"].(string)
-		appName := getStringArg(args, "app")
-		labelsStr := getStringArg(args, "labels")
-
-		// Parse data
-		data := make(map[string]string)
-		for _, kv := range strings.Split(dataStr, ",") {
-			parts := strings.SplitN(kv, "=", 2)
-			if len(parts) != 2 {
-				return nil, fmt.Errorf("invalid data format: %s (expected key=value)", kv)
-			}
-			data[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		if len(revisions) == 0 {
+			output.WriteString(fmt.Sprintf("No revisions recorded for %s %s/%s\n", cfgType, namespace, name))
+			return mcp.NewToolResultText(output.String()), nil
 		}
 
-		// Parse labels
-		labels := make(map[string]string)
-		if appName != "" {
-			labels["app.kubernetes.io/name"] = appName
-		}
-		if labelsStr != "" {
-			for _, kv := range strings.Split(labelsStr, ",") {
-				parts := strings.SplitN(kv, "=", 2)
-				if len(parts) != 2 {
-					return nil, fmt.Errorf("invalid label format: %s (expected key=value)", kv)
-				}
-				labels[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		output.WriteString(fmt.Sprintf("Found %d revisions of %s %s/%s (newest first):\n\n", len(revisions), cfgType, namespace, name))
+		for _, rev := range revisions {
+			output.WriteString(fmt.Sprintf("Revision: %d\n", rev.Number))
+			if !rev.Created.IsZero() {
+				output.WriteString(fmt.Sprintf("Recorded: %s\n", rev.Created.Format(time.RFC3339)))
 			}
-		}
+			output.WriteString(fmt.Sprintf("Keys: %d\n", len(rev.Data)))
 
-		// Create secret
-		secret := &config.Config{
-			Name:      name,
-			Namespace: namespace,
-			Type:      config.ConfigTypeSecret,
-			Data:      data,
-			Labels:    labels,
-		}
-
-		err := client.Create(toolCtx, secret)
-		if err != nil {
-			return nil, err
+			// Only key names are shown so secret values are never printed
+			diff := (&config.Config{Data: rev.Data}).Diff(current)
+			if diff.HasChanges() {
+				output.WriteString(fmt.Sprintf("Changed since: %s\n", formatDiffKeys(diff)))
+			} else {
+				output.WriteString("Changed since: nothing\n")
+			}
+			output.WriteString("---\n")
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully created secret %s/%s with %d keys", namespace, name, len(data))), nil
+		return mcp.NewToolResultText(output.String()), nil
 	})
 
-	// secret_update tool
-	updateSecretTool := mcp.NewTool(
-		"secret_update",
-		mcp.WithDescription("Update an existing secret"),
-		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the secret")),
+	// config_rollback tool
+	rollbackTool := mcp.NewTool(
+		"config_rollback",
+		mcp.WithDescription("Restore the data of a ConfigMap or Secret from a recorded revision"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the ConfigMap or Secret")),
 		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace")),
-		mcp.WithString("key", mcp.Description("Key to update (if not specified, replaces all data)")),
-		mcp.WithString("value", mcp.Description("Value for the key")),
-		mcp.WithString("data", mcp.Description("Complete data in key=value format (comma-separated)")),
-		mcp.WithBoolean("merge", mcp.Description("Merge with existing data instead of replacing (default: false)")),
+		mcp.WithString("type", mcp.Description("Type: configmap or secret (default: configmap)")),
+		mcp.WithString("revision", mcp.Description("Revision number to restore (default: the latest revision)")),
+		mcp.WithBoolean("force", mcp.Description("Overwrite concurrent changes instead of retrying (default: false)")),
 	)
 
-	s.AddTool(updateSecretTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.AddTool(rollbackTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := req.Params.Arguments.(map[string]interface{})
 		name := args["name"].(string)
 		namespace := args["namespace"].(string)
-		key := getStringArg(args, "key")
-		value := getStringArg(args, "value")
-		dataStr := getStringArg(args, "data")
-		merge := getBoolArg(args, "merge")
 
-		// Get current secret
-		secret, err := client.Get(toolCtx, namespace, name, config.ConfigTypeSecret)
+		cfgType, err := parseConfigType(getStringArg(args, "type"))
 		if err != nil {
 			return nil, err
 		}
 
-		// Update data
-		if key != "" && value != "" {
-			// Update single key
-			secret.SetValue(key, value)
-		} else if dataStr != "" {
-			// Parse new data
-			newData := make(map[string]string)
-			for _, kv := range strings.Split(dataStr, ",") {
-				parts := strings.SplitN(kv, "=", 2)
-				if len(parts) != 2 {
-					return nil, fmt.Errorf("invalid data format: %s (expected key=value)", kv)
-				}
-				newData[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		var rev *config.Revision
+		if revisionStr := getStringArg(args, "revision"); revisionStr != "" {
+			number, err := strconv.Atoi(revisionStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid revision %q: must be a number", revisionStr)
 			}
-
-			if merge {
-				// Merge with existing data
-				for k, v := range newData {
-					secret.SetValue(k, v)
-				}
-			} else {
-				// Replace all data
-				secret.Data = newData
+			rev, err = history.Get(toolCtx, namespace, name, cfgType, number)
+			if err != nil {
+				return nil, err
 			}
 		} else {
-			return nil, fmt.Errorf("either key/value or data must be specified")
+			revisions, err := history.List(toolCtx, namespace, name, cfgType)
+			if err != nil {
+				return nil, err
+			}
+			if len(revisions) == 0 {
+				return nil, fmt.Errorf("no revisions recorded for %s %s/%s", cfgType, namespace, name)
+			}
+			rev = revisions[0]
 		}
 
-		// Update secret
-		err = client.Update(toolCtx, secret)
+		revision, err := recordRevision(toolCtx, history, client, namespace, name, cfgType)
 		if err != nil {
 			return nil, err
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully updated secret %s/%s", namespace, name)), nil
-	})
-
-	// config_merge tool for merging configurations
-	mergeTool := mcp.NewTool(
-		"config_merge",
-		mcp.WithDescription("Merge multiple configurations (later ones take precedence)"),
-		mcp.WithString("configs", mcp.Required(), mcp.Description("Comma-separated list of namespace/name pairs")),
-		mcp.WithString("type", mcp.Description("Type: configmap or secret (default: configmap)")),
-		mcp.WithString("format", mcp.Description("Output format: yaml, json, or text (default: text)")),
-	)
-
-	s.AddTool(mergeTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args := req.Params.Arguments.(map[string]interface{})
-		configsStr := args["configs"].(string)
-		configType := getStringArg(args, "type")
-		format := getStringArg(args, "format")
-
-		if configType == "" {
-			configType = "configmap"
-		}
-		if format == "" {
-			format = "text"
-		}
-
-		// Determine config type
-		var cfgType config.ConfigType
-		switch configType {
-		case "configmap":
-			cfgType = config.ConfigTypeConfigMap
-		case "secret":
-			cfgType = config.ConfigTypeSecret
-		default:
-			return nil, fmt.Errorf("invalid type: %s (must be configmap or secret)", configType)
+		opts := k8s.UpdateOptions{Force: getBoolArg(args, "force")}
+		err = client.Update(toolCtx, namespace, name, cfgType, opts, func(cfg *config.Config) error {
+			cfg.Data = make(map[string]string, len(rev.Data))
+			for k, v := range rev.Data {
+				cfg.Data[k] = v
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
 		}
 
-		// Parse config references
-		configRefs := strings.Split(configsStr, ",")
-		configs := make([]*config.Config, 0, len(configRefs))
+		result := fmt.Sprintf("Restored %s %s/%s to revision %d", cfgType, namespace, name, rev.Number)
+		return mcp.NewToolResultText(result + revision), nil
+	})
 
-		for _, ref := range configRefs {
-			parts := strings.Split(strings.TrimSpace(ref), "/")
-			if len(parts) != 2 {
-				return nil, fmt.Errorf("invalid config reference: %s (expected namespace/name)", ref)
-			}
+	registerConfigValidateTools(s, ctx, client)
+	registerConfigInspectTools(s, ctx, client, appClient)
+	registerConfigBundleTools(s, ctx, client, history, appClient)
+	registerSecretTools(s, ctx, client, history)
 
-			cfg, err := client.Get(toolCtx, parts[0], parts[1], cfgType)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get %s: %w", ref, err)
-			}
-			configs = append(configs, cfg)
-		}
+	return nil
+}
 
-		// Merge configurations
-		merged := config.MergeConfigs(configs...)
+// parseConfigType parses the type argument of the config tools
+func parseConfigType(value string) (config.ConfigType, error) {
+	switch value {
+	case "", "configmap":
+		return config.ConfigTypeConfigMap, nil
+	case "secret":
+		return config.ConfigTypeSecret, nil
+	default:
+		return "", fmt.Errorf("invalid type: %s (must be configmap or secret)", value)
+	}
+}
 
-		// Format output
-		var output string
-		var err error
-		switch format {
-		case "yaml":
-			output, err = merged.ToYAML()
-			if err != nil {
-				return nil, err
-			}
-		case "json":
-			output, err = merged.ToJSON()
-			if err != nil {
-				return nil, err
-			}
-		default: // text
-			var sb strings.Builder
-			sb.WriteString("Merged configuration:\n\n")
-			for k, v := range merged.Data {
-				sb.WriteString(fmt.Sprintf("%s: %s\n", k, v))
-			}
-			output = sb.String()
-		}
+// newConfigClient creates a config client that drops the pooled client of a
+// workload cluster when it changes the cluster's kubeconfig secret
+func newConfigClient(ctx *server.Context) *config.Client {
+	client := config.NewClient(ctx.K8sClient)
+	if ctx.WorkloadClients != nil {
+		client.WithSecretHook(ctx.WorkloadClients.InvalidateSecret)
+	}
+	return client
+}
 
-		return mcp.NewToolResultText(output), nil
-	})
+// recordRevision snapshots the current state of a configuration before it is
+// changed, so the change can be rolled back. A configuration that doesn't
+// exist yet has nothing to record.
+func recordRevision(ctx context.Context, history *config.History, client *config.Client, namespace, name string, configType config.ConfigType) (string, error) {
+	if !history.Enabled() {
+		return "", nil
+	}
+
+	current, err := client.Get(ctx, namespace, name, configType)
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	rev, err := history.Snapshot(ctx, current)
+	if err != nil {
+		return "", fmt.Errorf("failed to record the previous revision, nothing was changed: %w", err)
+	}
+
+	return fmt.Sprintf("\nPrevious data recorded as revision %d", rev.Number), nil
+}
 
-	return nil
+// formatDiffKeys lists the changed keys of a diff without their values
+func formatDiffKeys(diff *config.ConfigDiff) string {
+	parts := make([]string, 0, len(diff.Added)+len(diff.Modified)+len(diff.Removed))
+	for k := range diff.Added {
+		parts = append(parts, "+"+k)
+	}
+	for k := range diff.Modified {
+		parts = append(parts, "~"+k)
+	}
+	for k := range diff.Removed {
+		parts = append(parts, "-"+k)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
 }
@@ -0,0 +1,238 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+)
+
+// registerConfigBundleTools registers the tools exporting and importing the
+// configs of apps as bundles
+func registerConfigBundleTools(s *mcpserver.MCPServer, ctx *server.Context, client *config.Client, history *config.History, appClient *app.Client) {
+	// config_export tool
+	exportTool := mcp.NewTool(
+		"config_export",
+		mcp.WithDescription("Export the user configuration of an app, or of all apps in a namespace or organization, as one YAML bundle "+
+			"that config_import can apply in another namespace or cluster. Secret values are left out unless secret-values is set."),
+		mcp.WithString("name", mcp.Description("Name of a single app to export (requires namespace)")),
+		mcp.WithString("namespace", mcp.Description("Namespace of the app, or whose apps to export")),
+		mcp.WithString("organization", mcp.Description("Organization whose apps to export")),
+		mcp.WithBoolean("include-config", mcp.Description("Also export the spec.config resources, which are usually managed by Giant Swarm (default: false)")),
+		mcp.WithBoolean("secret-values", mcp.Description("Include the values of secrets in the bundle (default: false)")),
+		withPart(),
+	)
+
+	s.AddTool(exportTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		apps, source, err := selectExportApps(toolCtx, ctx, appClient, args)
+		if err != nil {
+			return nil, err
+		}
+
+		bundle, warnings := exportConfigBundle(toolCtx, client, apps, source, getBoolArg(args, "include-config"), getBoolArg(args, "secret-values"))
+		output, err := bundle.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		// Warnings are YAML comments so that the bundle can be passed on as is
+		var header strings.Builder
+		header.WriteString(fmt.Sprintf("# Configuration of %d apps, %d resources\n", len(bundle.Apps), len(bundle.Items)))
+		for _, warning := range warnings {
+			header.WriteString("# Warning: " + warning + "\n")
+		}
+		return mcp.NewToolResultText(header.String() + output), nil
+	})
+
+	// config_import tool
+	importTool := mcp.NewTool(
+		"config_import",
+		mcp.WithDescription("Import a config_export bundle, e.g. to promote configuration from staging to production. "+
+			"Renames are applied consistently to resource names, namespaces and the apps' references to them."),
+		mcp.WithString("bundle", mcp.Required(), mcp.Description("YAML bundle returned by config_export")),
+		mcp.WithString("namespace", mcp.Description("Namespace to import all resources into (default: their namespaces in the bundle, after renames)")),
+		mcp.WithString("rename", mcp.Description("Comma-separated old=new replacements for names and namespaces, e.g. staging=prod")),
+		mcp.WithBoolean("update-apps", mcp.Description("Point the config and userConfig references of existing apps at the imported resources (default: false)")),
+		mcp.WithBoolean("dry-run", mcp.Description("Only show what would be imported (default: false)")),
+		mcp.WithBoolean("force", mcp.Description("Overwrite concurrent changes instead of retrying (default: false)")),
+	)
+
+	s.AddTool(importTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		opts := k8s.UpdateOptions{Force: getBoolArg(args, "force")}
+
+		bundle, err := config.ParseBundle(getStringArg(args, "bundle"))
+		if err != nil {
+			return nil, err
+		}
+		renames, err := config.ParseRenames(getStringArg(args, "rename"))
+		if err != nil {
+			return nil, err
+		}
+		bundle = bundle.Retarget(getStringArg(args, "namespace"), renames)
+
+		// The namespace policy middleware only sees the namespace argument
+		for _, item := range bundle.Items {
+			if !ctx.NamespacePolicy.Allows(item.Namespace) {
+				return nil, fmt.Errorf("namespace %s is not allowed on this server", item.Namespace)
+			}
+		}
+
+		dryRun := getBoolArg(args, "dry-run")
+		var output strings.Builder
+		if dryRun {
+			output.WriteString(fmt.Sprintf("Dry run: importing the bundle of %s would apply:\n\n", bundle.Source))
+		} else {
+			output.WriteString(fmt.Sprintf("Importing the bundle of %s:\n\n", bundle.Source))
+		}
+
+		for _, item := range bundle.Items {
+			m := &manifest{Kind: "ConfigMap", Namespace: item.Namespace, Name: item.Name, Config: item.Config()}
+			if item.Type == config.ConfigTypeSecret {
+				m.Kind = "Secret"
+			}
+			switch {
+			case item.Redacted:
+				output.WriteString(fmt.Sprintf("- %s: skipped, the bundle has no secret values; create it with secret_create\n", m))
+			case dryRun:
+				output.WriteString(fmt.Sprintf("- %s: %d keys\n", m, len(item.Data)))
+			default:
+				result, err := applyManifest(toolCtx, m, appClient, nil, client, history, opts)
+				if err != nil {
+					output.WriteString(fmt.Sprintf("- %s: failed: %v\n", m, err))
+					continue
+				}
+				output.WriteString(fmt.Sprintf("- %s: %s\n", m, result))
+			}
+		}
+
+		if len(bundle.Apps) > 0 {
+			output.WriteString("\nApp references:\n")
+		}
+		for _, a := range bundle.Apps {
+			var refs []string
+			for _, ref := range a.References {
+				refs = append(refs, fmt.Sprintf("%s %s %s/%s", ref.Layer, ref.Type, ref.Namespace, ref.Name))
+			}
+			line := fmt.Sprintf("- %s/%s: %s", a.Namespace, a.Name, strings.Join(refs, ", "))
+			if getBoolArg(args, "update-apps") && !dryRun {
+				_, err := appClient.Update(toolCtx, a.Namespace, a.Name, opts, func(current *app.App) error {
+					for _, ref := range a.References {
+						setAppConfigRef(current, ref)
+					}
+					return nil
+				})
+				switch {
+				case apierrors.IsNotFound(err):
+					line += " (app not found)"
+				case err != nil:
+					line += fmt.Sprintf(" (failed to update the app: %v)", err)
+				default:
+					line += " (updated)"
+				}
+			}
+			output.WriteString(line + "\n")
+		}
+
+		return mcp.NewToolResultText(output.String()), nil
+	})
+}
+
+// selectExportApps returns the apps selected by the name, namespace and
+// organization arguments of the export tools, and a description of them
+func selectExportApps(toolCtx context.Context, ctx *server.Context, appClient *app.Client, args map[string]interface{}) ([]*app.App, string, error) {
+	name := getStringArg(args, "name")
+	namespace := getStringArg(args, "namespace")
+	org := getStringArg(args, "organization")
+
+	switch {
+	case name != "":
+		if namespace == "" {
+			return nil, "", fmt.Errorf("namespace is required together with name")
+		}
+		a, err := appClient.Get(toolCtx, namespace, name)
+		if err != nil {
+			return nil, "", err
+		}
+		return []*app.App{a}, namespace + "/" + name, nil
+	case org != "":
+		apps, err := appClient.ListByOrganization(toolCtx, ctx.K8sClient, org, "")
+		return apps, "organization " + org, err
+	case namespace != "":
+		apps, err := appClient.List(toolCtx, namespace, "")
+		return apps, "namespace " + namespace, err
+	}
+	return nil, "", fmt.Errorf("one of name and namespace, namespace or organization is required")
+}
+
+// exportConfigBundle adds the resources the apps reference to a bundle and
+// returns warnings about resources that could not be read
+func exportConfigBundle(ctx context.Context, client *config.Client, apps []*app.App, source string, includeConfig, secretValues bool) (*config.Bundle, []string) {
+	bundle := config.NewBundle(source)
+	var warnings []string
+
+	for _, a := range apps {
+		type layer struct {
+			name   string
+			config *app.AppConfig
+		}
+		layers := []layer{{"userConfig", a.Spec.UserConfig}}
+		if includeConfig {
+			layers = []layer{{"config", a.Spec.Config}, {"userConfig", a.Spec.UserConfig}}
+		}
+
+		bundleApp := config.BundleApp{Name: a.Name, Namespace: a.Namespace}
+		for _, layer := range layers {
+			if layer.config == nil {
+				continue
+			}
+			var refs []config.BundleRef
+			if cm := layer.config.ConfigMap; cm != nil && cm.Name != "" {
+				refs = append(refs, config.BundleRef{Layer: layer.name, Type: config.ConfigTypeConfigMap, Name: cm.Name, Namespace: refNamespace(cm.Namespace, a)})
+			}
+			if secret := layer.config.Secret; secret != nil && secret.Name != "" {
+				refs = append(refs, config.BundleRef{Layer: layer.name, Type: config.ConfigTypeSecret, Name: secret.Name, Namespace: refNamespace(secret.Namespace, a)})
+			}
+
+			for _, ref := range refs {
+				cfg, err := client.Get(ctx, ref.Namespace, ref.Name, ref.Type)
+				if err != nil {
+					warnings = append(warnings, fmt.Sprintf("%s of app %s/%s: %v", ref.Layer, a.Namespace, a.Name, err))
+					continue
+				}
+				bundle.AddConfig(cfg, secretValues)
+				bundleApp.References = append(bundleApp.References, ref)
+			}
+		}
+		if len(bundleApp.References) > 0 {
+			bundle.Apps = append(bundle.Apps, bundleApp)
+		}
+	}
+
+	return bundle, warnings
+}
+
+// setAppConfigRef points the config or userConfig of an app at a resource
+func setAppConfigRef(a *app.App, ref config.BundleRef) {
+	target := &a.Spec.UserConfig
+	if ref.Layer == "config" {
+		target = &a.Spec.Config
+	}
+	if *target == nil {
+		*target = &app.AppConfig{}
+	}
+	if ref.Type == config.ConfigTypeSecret {
+		(*target).Secret = &app.SecretReference{Name: ref.Name, Namespace: ref.Namespace}
+	} else {
+		(*target).ConfigMap = &app.ConfigMapReference{Name: ref.Name, Namespace: ref.Namespace}
+	}
+}
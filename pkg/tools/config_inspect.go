@@ -0,0 +1,369 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/organization"
+)
+
+// registerConfigInspectTools registers the tools comparing, merging and
+// searching configs and finding orphaned ones
+func registerConfigInspectTools(s *mcpserver.MCPServer, ctx *server.Context, client *config.Client, appClient *app.Client) {
+	// config_diff tool
+	diffTool := mcp.NewTool(
+		"config_diff",
+		mcp.WithDescription("Show differences between two configurations"),
+		mcp.WithString("name1", mcp.Required(), mcp.Description("Name of the first ConfigMap/Secret")),
+		mcp.WithString("namespace1", mcp.Required(), mcp.Description("Namespace of the first config")),
+		mcp.WithString("name2", mcp.Required(), mcp.Description("Name of the second ConfigMap/Secret")),
+		mcp.WithString("namespace2", mcp.Required(), mcp.Description("Namespace of the second config")),
+		mcp.WithString("type", mcp.Description("Type: configmap or secret (default: configmap)")),
+	)
+
+	s.AddTool(diffTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		name1 := args["name1"].(string)
+		namespace1 := args["namespace1"].(string)
+		name2 := args["name2"].(string)
+		namespace2 := args["namespace2"].(string)
+		configType := getStringArg(args, "type")
+
+		if configType == "" {
+			configType = "configmap"
+		}
+
+		// Determine config type
+		var cfgType config.ConfigType
+		switch configType {
+		case "configmap":
+			cfgType = config.ConfigTypeConfigMap
+		case "secret":
+			cfgType = config.ConfigTypeSecret
+		default:
+			return nil, fmt.Errorf("invalid type: %s (must be configmap or secret)", configType)
+		}
+
+		// Get both configurations
+		cfg1, err := client.Get(toolCtx, namespace1, name1, cfgType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get first config: %w", err)
+		}
+
+		cfg2, err := client.Get(toolCtx, namespace2, name2, cfgType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get second config: %w", err)
+		}
+
+		// Calculate diff
+		diff := cfg1.Diff(cfg2)
+
+		var output strings.Builder
+		output.WriteString(fmt.Sprintf("Diff between %s/%s and %s/%s:\n\n", namespace1, name1, namespace2, name2))
+
+		if !diff.HasChanges() {
+			output.WriteString("No differences found\n")
+		} else {
+			if len(diff.Added) > 0 {
+				output.WriteString("Added keys:\n")
+				for k, v := range diff.Added {
+					output.WriteString(fmt.Sprintf("  + %s: %s\n", k, v))
+				}
+			}
+
+			if len(diff.Modified) > 0 {
+				output.WriteString("\nModified keys:\n")
+				for k, entry := range diff.Modified {
+					output.WriteString(fmt.Sprintf("  ~ %s:\n", k))
+					output.WriteString(fmt.Sprintf("    - %s\n", entry.Old))
+					output.WriteString(fmt.Sprintf("    + %s\n", entry.New))
+				}
+			}
+
+			if len(diff.Removed) > 0 {
+				output.WriteString("\nRemoved keys:\n")
+				for k, v := range diff.Removed {
+					output.WriteString(fmt.Sprintf("  - %s: %s\n", k, v))
+				}
+			}
+		}
+
+		return mcp.NewToolResultText(output.String()), nil
+	})
+
+	// config_merge tool for merging configurations
+	mergeTool := mcp.NewTool(
+		"config_merge",
+		mcp.WithDescription("Merge multiple configurations (later ones take precedence)"),
+		mcp.WithString("configs", mcp.Required(), mcp.Description("Comma-separated list of namespace/name pairs")),
+		mcp.WithString("type", mcp.Description("Type: configmap or secret (default: configmap)")),
+		mcp.WithString("format", mcp.Description("Output format: yaml, json, or text (default: text)")),
+	)
+
+	s.AddTool(mergeTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		configsStr := args["configs"].(string)
+		configType := getStringArg(args, "type")
+		format := getStringArg(args, "format")
+
+		if configType == "" {
+			configType = "configmap"
+		}
+		if format == "" {
+			format = "text"
+		}
+
+		// Determine config type
+		var cfgType config.ConfigType
+		switch configType {
+		case "configmap":
+			cfgType = config.ConfigTypeConfigMap
+		case "secret":
+			cfgType = config.ConfigTypeSecret
+		default:
+			return nil, fmt.Errorf("invalid type: %s (must be configmap or secret)", configType)
+		}
+
+		// Parse config references
+		configRefs := strings.Split(configsStr, ",")
+		configs := make([]*config.Config, 0, len(configRefs))
+
+		for _, ref := range configRefs {
+			parts := strings.Split(strings.TrimSpace(ref), "/")
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("invalid config reference: %s (expected namespace/name)", ref)
+			}
+
+			cfg, err := client.Get(toolCtx, parts[0], parts[1], cfgType)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get %s: %w", ref, err)
+			}
+			configs = append(configs, cfg)
+		}
+
+		// Merge configurations
+		merged := config.MergeConfigs(configs...)
+
+		// Format output
+		var output string
+		var err error
+		switch format {
+		case "yaml":
+			output, err = merged.ToYAML()
+			if err != nil {
+				return nil, err
+			}
+		case "json":
+			output, err = merged.ToJSON()
+			if err != nil {
+				return nil, err
+			}
+		default: // text
+			var sb strings.Builder
+			sb.WriteString("Merged configuration:\n\n")
+			for k, v := range merged.Data {
+				sb.WriteString(fmt.Sprintf("%s: %s\n", k, v))
+			}
+			output = sb.String()
+		}
+
+		return mcp.NewToolResultText(output), nil
+	})
+
+	// config_search tool
+	searchTool := mcp.NewTool(
+		"config_search",
+		mcp.WithDescription("Search ConfigMaps and Secrets for a key, value path or value substring, e.g. proxy.noProxy, to find where a setting is configured. "+
+			"Secrets are matched on their keys only unless secret-values is set, and their values are never shown."),
+		mcp.WithString("query", mcp.Required(), mcp.Description("Case-insensitive substring of a key, a dotted value path or a value")),
+		mcp.WithString("organization", mcp.Description("Organization whose namespaces to search")),
+		mcp.WithString("namespace", mcp.Description("Namespace to search (alternative to organization)")),
+		mcp.WithString("type", mcp.Description("Search configmap, secret or all (default: all)"), mcp.Enum("all", "configmap", "secret")),
+		mcp.WithBoolean("secret-values", mcp.Description("Also match the values of secrets (default: false)")),
+		withContinue(),
+	)
+
+	s.AddTool(searchTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		query := getStringArg(args, "query")
+		org := getStringArg(args, "organization")
+		namespace := getStringArg(args, "namespace")
+		searchType := getStringArg(args, "type")
+
+		if query == "" {
+			return nil, fmt.Errorf("query is required")
+		}
+		if (org == "") == (namespace == "") {
+			return nil, fmt.Errorf("exactly one of organization or namespace is required")
+		}
+
+		namespaces := []string{namespace}
+		if org != "" {
+			orgNamespaces, err := organization.GetNamespacesByOrganization(toolCtx, ctx.K8sClient, org)
+			if err != nil {
+				return nil, err
+			}
+			// The namespace policy middleware only checks the organization namespace
+			namespaces = namespaces[:0]
+			for _, ns := range orgNamespaces {
+				if ctx.NamespacePolicy.Allows(ns) {
+					namespaces = append(namespaces, ns)
+				}
+			}
+		}
+
+		var configs []*config.Config
+		for _, ns := range namespaces {
+			if searchType != "secret" {
+				configMaps, err := client.ListConfigMaps(toolCtx, ns, "")
+				if err != nil {
+					return nil, fmt.Errorf("failed to search namespace %s: %w", ns, err)
+				}
+				configs = append(configs, configMaps...)
+			}
+			if searchType != "configmap" {
+				secrets, err := client.ListSecrets(toolCtx, ns, "")
+				if err != nil {
+					return nil, fmt.Errorf("failed to search namespace %s: %w", ns, err)
+				}
+				configs = append(configs, secrets...)
+			}
+		}
+
+		matches := config.Search(configs, config.SearchOptions{Query: query, SecretValues: getBoolArg(args, "secret-values")})
+		if len(matches) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("No ConfigMaps or Secrets in %s match %q", strings.Join(namespaces, ", "), query)), nil
+		}
+
+		entries := make([]string, 0, len(matches))
+		for _, m := range matches {
+			location := m.Key
+			if m.Path != "" {
+				location += ": " + m.Path
+			}
+			entry := fmt.Sprintf("- %s %s/%s [%s]", m.Type, m.Namespace, m.Name, location)
+			switch {
+			case m.Type == config.ConfigTypeSecret && m.InValue:
+				entry += " (value matches, hidden)"
+			case m.Value != "":
+				value := m.Value
+				if len(value) > 100 || strings.Contains(value, "\n") {
+					value = strings.SplitN(value, "\n", 2)[0]
+					if len(value) > 97 {
+						value = value[:97]
+					}
+					value += "..."
+				}
+				entry += " = " + value
+			}
+			entries = append(entries, entry+"\n")
+		}
+
+		title := fmt.Sprintf("Found %d matches for %q in %d namespaces:\n\n", len(matches), query, len(namespaces))
+		output, err := budgetedList(ctx.OutputBudget, args, title, entries)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText(output), nil
+	})
+
+	// config_orphans tool
+	orphansTool := mcp.NewTool(
+		"config_orphans",
+		mcp.WithDescription("Find ConfigMaps and Secrets made for an app (labelled app.kubernetes.io/name or named <app>-userconfig/-user-values) "+
+			"that no App references, e.g. because the app was deleted, and optionally delete them"),
+		mcp.WithString("namespace", mcp.Description("Namespace to search (default: all organization namespaces)")),
+		mcp.WithString("organization", mcp.Description("Organization whose namespaces to search")),
+		mcp.WithString("delete", mcp.Description("Orphans in the namespace to delete, as a comma-separated list of <type>/<name>, e.g. configmap/nginx-userconfig; "+
+			"each must still be an orphan (requires namespace)")),
+	)
+
+	s.AddTool(orphansTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		namespace := getStringArg(args, "namespace")
+		org := getStringArg(args, "organization")
+		toDelete := getStringArg(args, "delete")
+
+		if toDelete != "" && namespace == "" {
+			return nil, fmt.Errorf("delete requires the namespace of the orphans")
+		}
+
+		var namespaces []string
+		var err error
+		switch {
+		case namespace != "":
+			namespaces = []string{namespace}
+		case org != "":
+			namespaces, err = organization.GetNamespacesByOrganization(toolCtx, ctx.K8sClient, org)
+		default:
+			namespaces, err = organization.ListOrganizationNamespaces(toolCtx, ctx.K8sClient)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var configs []*config.Config
+		for _, ns := range namespaces {
+			configMaps, err := client.ListConfigMaps(toolCtx, ns, "")
+			if err != nil {
+				return nil, err
+			}
+			secrets, err := client.ListSecrets(toolCtx, ns, "")
+			if err != nil {
+				return nil, err
+			}
+			configs = append(append(configs, configMaps...), secrets...)
+		}
+
+		// Apps in other namespaces may reference the configs too; fall back to
+		// the searched namespaces when the user may not list all apps
+		var note string
+		apps, err := appClient.List(toolCtx, "", "")
+		if err != nil {
+			apps = nil
+			for _, ns := range namespaces {
+				nsApps, err := appClient.List(toolCtx, ns, "")
+				if err != nil {
+					return nil, err
+				}
+				apps = append(apps, nsApps...)
+			}
+			note = "\nNote: apps outside the searched namespaces could not be listed, references from them were not checked.\n"
+		}
+
+		orphans := findOrphanedConfigs(configs, apps)
+		if toDelete == "" {
+			return mcp.NewToolResultText(formatOrphans(orphans, namespaces) + note), nil
+		}
+
+		byName := make(map[string]*config.Config, len(orphans))
+		for _, orphan := range orphans {
+			byName[string(orphan.Config.Type)+"/"+orphan.Config.Name] = orphan.Config
+		}
+		var targets []*config.Config
+		for _, ref := range strings.Split(toDelete, ",") {
+			ref = strings.TrimSpace(ref)
+			cfg, ok := byName[strings.ToLower(ref)]
+			if !ok {
+				return nil, fmt.Errorf("%s is not an orphaned ConfigMap or Secret in %s", ref, namespace)
+			}
+			targets = append(targets, cfg)
+		}
+
+		var output strings.Builder
+		for _, cfg := range targets {
+			if err := client.Delete(toolCtx, cfg.Namespace, cfg.Name, cfg.Type); err != nil {
+				return nil, err
+			}
+			output.WriteString(fmt.Sprintf("Deleted %s %s/%s\n", cfg.Type, cfg.Namespace, cfg.Name))
+		}
+		return mcp.NewToolResultText(output.String() + note), nil
+	})
+}
@@ -0,0 +1,273 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+)
+
+// registerConfigValidateTools registers the tools validating and linting
+// configs against the values schema of their charts
+func registerConfigValidateTools(s *mcpserver.MCPServer, ctx *server.Context, client *config.Client) {
+	// config_validate tool
+	validateTool := mcp.NewTool(
+		"config_validate",
+		mcp.WithDescription("Validate configuration against a schema"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the ConfigMap or Secret")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace")),
+		mcp.WithString("type", mcp.Description("Type: configmap or secret (default: configmap)")),
+		mcp.WithString("required-keys", mcp.Description("Comma-separated list of required keys")),
+		mcp.WithString("optional-keys", mcp.Description("Comma-separated list of optional keys")),
+	)
+
+	s.AddTool(validateTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		name := args["name"].(string)
+		namespace := args["namespace"].(string)
+		configType := getStringArg(args, "type")
+		requiredKeys := getStringArg(args, "required-keys")
+		optionalKeys := getStringArg(args, "optional-keys")
+
+		if configType == "" {
+			configType = "configmap"
+		}
+
+		// Determine config type
+		var cfgType config.ConfigType
+		switch configType {
+		case "configmap":
+			cfgType = config.ConfigTypeConfigMap
+		case "secret":
+			cfgType = config.ConfigTypeSecret
+		default:
+			return nil, fmt.Errorf("invalid type: %s (must be configmap or secret)", configType)
+		}
+
+		// Get the configuration
+		cfg, err := client.Get(toolCtx, namespace, name, cfgType)
+		if err != nil {
+			return nil, err
+		}
+
+		// Build schema
+		schema := &config.ConfigSchema{
+			RequiredKeys: []string{},
+			OptionalKeys: []string{},
+			KeyPatterns:  make(map[string]string),
+		}
+
+		if requiredKeys != "" {
+			schema.RequiredKeys = strings.Split(requiredKeys, ",")
+		}
+		if optionalKeys != "" {
+			schema.OptionalKeys = strings.Split(optionalKeys, ",")
+		}
+
+		// Validate
+		result := client.Validate(cfg, schema)
+
+		var output strings.Builder
+		if result.Valid {
+			output.WriteString("✓ Configuration is valid\n")
+		} else {
+			output.WriteString("✗ Configuration is invalid\n\nErrors:\n")
+			for _, err := range result.Errors {
+				output.WriteString(fmt.Sprintf("  - %s\n", err))
+			}
+		}
+
+		return mcp.NewToolResultText(output.String()), nil
+	})
+
+	// config_lint tool
+	lintTool := mcp.NewTool(
+		"config_lint",
+		mcp.WithDescription("Lint Helm values for tabs, indentation errors, unknown top-level keys and numbers quoted as strings, with line numbers"),
+		mcp.WithString("values", mcp.Description("Values YAML to lint (instead of name and namespace)")),
+		mcp.WithString("name", mcp.Description("Name of the ConfigMap or Secret holding the values")),
+		mcp.WithString("namespace", mcp.Description("Namespace of the ConfigMap or Secret")),
+		mcp.WithString("type", mcp.Description("Type: configmap or secret (default: configmap)")),
+		mcp.WithString("key", mcp.Description("Data key holding the values (default: all keys)")),
+		mcp.WithString("catalog", mcp.Description("Catalog of the app, to check against its chart schema and default values")),
+		mcp.WithString("app", mcp.Description("App name, to check against its chart schema and default values")),
+		mcp.WithString("version", mcp.Description("App version (defaults to the newest version in the catalog)")),
+	)
+
+	s.AddTool(lintTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		name := getStringArg(args, "name")
+		namespace := getStringArg(args, "namespace")
+		key := getStringArg(args, "key")
+		catalogName := getStringArg(args, "catalog")
+		appName := getStringArg(args, "app")
+
+		documents := make(map[string]string)
+		switch {
+		case getStringArg(args, "values") != "":
+			documents["values"] = getStringArg(args, "values")
+		case name != "" && namespace != "":
+			cfgType := config.ConfigTypeConfigMap
+			if getStringArg(args, "type") == "secret" {
+				cfgType = config.ConfigTypeSecret
+			}
+			cfg, err := client.Get(toolCtx, namespace, name, cfgType)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range cfg.Data {
+				if key == "" || k == key {
+					documents[k] = v
+				}
+			}
+			if len(documents) == 0 {
+				return nil, fmt.Errorf("%s/%s has no key %s", namespace, name, key)
+			}
+		default:
+			return nil, fmt.Errorf("either values or name and namespace must be specified")
+		}
+
+		var output strings.Builder
+		var opts config.LintOptions
+		if catalogName != "" && appName != "" {
+			files, err := fetchAppChart(toolCtx, ctx, catalogName, appName, getStringArg(args, "version"))
+			if err != nil {
+				output.WriteString(fmt.Sprintf("Warning: chart not checked: %v\n\n", err))
+			} else {
+				opts.Schema, _ = files.ValuesSchema()
+				opts.Defaults, _ = files.DefaultValues()
+			}
+		} else if catalogName != "" || appName != "" {
+			return nil, fmt.Errorf("catalog and app must be specified together")
+		}
+
+		total := 0
+		for _, k := range sortedKeys(documents) {
+			issues, err := config.Lint(documents[k], opts)
+			if err != nil {
+				return nil, err
+			}
+			total += len(issues)
+			if len(issues) == 0 {
+				continue
+			}
+			output.WriteString(fmt.Sprintf("%s:\n", k))
+			for _, issue := range issues {
+				output.WriteString(fmt.Sprintf("  %s\n", issue))
+			}
+		}
+
+		if total == 0 {
+			output.WriteString("✓ No issues found\n")
+		} else {
+			output.WriteString(fmt.Sprintf("\n%d issue(s) found\n", total))
+		}
+
+		return mcp.NewToolResultText(output.String()), nil
+	})
+
+	// config_schema tool
+	schemaTool := mcp.NewTool(
+		"config_schema",
+		mcp.WithDescription("Explain the configurable values of an app version: the keys of its values.yaml with their types, defaults and helm-docs descriptions"),
+		mcp.WithString("catalog", mcp.Required(), mcp.Description("Catalog of the app")),
+		mcp.WithString("app", mcp.Required(), mcp.Description("App name")),
+		mcp.WithString("version", mcp.Description("App version (defaults to the newest version in the catalog)")),
+		mcp.WithString("key", mcp.Description("Only show keys under this path, e.g. ingress")),
+		withContinue(),
+		withRefresh(),
+	)
+
+	s.AddTool(schemaTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		catalogName := getStringArg(args, "catalog")
+		appName := getStringArg(args, "app")
+		version := getStringArg(args, "version")
+		prefix := getStringArg(args, "key")
+
+		if catalogName == "" || appName == "" {
+			return nil, fmt.Errorf("catalog and app are required")
+		}
+
+		files, err := fetchAppChart(toolCtx, ctx, catalogName, appName, version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch chart: %w", err)
+		}
+		docs, err := files.ValuesDocs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse values.yaml: %w", err)
+		}
+
+		entries := make([]string, 0, len(docs))
+		described := 0
+		for _, doc := range docs {
+			if prefix != "" && doc.Key != prefix && !strings.HasPrefix(doc.Key, prefix+".") {
+				continue
+			}
+			entry := fmt.Sprintf("- %s (%s", doc.Key, doc.Type)
+			if doc.Default != "" {
+				entry += ", default: " + doc.Default
+			}
+			entry += ")"
+			if doc.Description != "" {
+				entry += ": " + doc.Description
+				described++
+			}
+			entries = append(entries, entry+"\n")
+		}
+
+		if version == "" {
+			version = "newest version"
+		}
+		title := fmt.Sprintf("Values of %s %s (catalog %s): %d keys, %d documented\n", appName, version, catalogName, len(entries), described)
+		if _, ok := files.ValuesSchema(); ok {
+			title += fmt.Sprintf("The chart validates values against %s.\n", appcatalogentry.ValuesSchemaFile)
+		}
+		title += "\n"
+		if len(entries) == 0 {
+			if prefix != "" {
+				return mcp.NewToolResultText(title + fmt.Sprintf("No keys under %s\n", prefix)), nil
+			}
+			return mcp.NewToolResultText(title + "The chart has no values.yaml\n"), nil
+		}
+
+		output, err := budgetedList(ctx.OutputBudget, args, title, entries)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText(output), nil
+	})
+}
+
+// fetchAppChart fetches the chart of an app version, or of the newest version
+// in the catalog when version is empty
+func fetchAppChart(ctx context.Context, serverCtx *server.Context, catalogName, appName, version string) (appcatalogentry.ChartFiles, error) {
+	entryClient := appcatalogentry.NewClient(serverCtx.DynamicClient).WithIndex(serverCtx.AppCatalogEntryIndex)
+	if version == "" {
+		entries, err := entryClient.ListByCatalog(ctx, catalogName, "")
+		if err != nil {
+			return nil, err
+		}
+		versions := make([]*appcatalogentry.AppCatalogEntry, 0)
+		for _, entry := range entries {
+			if entry.MatchesApp(appName) {
+				versions = append(versions, entry)
+			}
+		}
+		if version = appcatalogentry.NewestVersion(versions); version == "" {
+			return nil, fmt.Errorf("app %s not found in catalog %s", appName, catalogName)
+		}
+	}
+
+	entry, err := entryClient.FindVersion(ctx, catalogName, appName, version)
+	if err != nil {
+		return nil, err
+	}
+	return appcatalogentry.FetchChart(ctx, entry)
+}
@@ -0,0 +1,138 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+)
+
+// appDeployment is one cluster's deployment of an app compared by app_drift.
+// App is nil when the app is not deployed in the cluster; Values and Secrets
+// hold the flattened user configuration.
+type appDeployment struct {
+	Cluster string
+	App     *app.App
+	Values  *config.Config
+	Secrets *config.Config
+}
+
+// driftReport compares deployments of an app against the first one and lists
+// the inconsistencies in version, catalog, target namespace and user values.
+// Secret values are never shown, only the keys that differ.
+func driftReport(appName string, deployments []appDeployment) string {
+	var output strings.Builder
+	baseline := deployments[0]
+
+	clusterNames := make([]string, 0, len(deployments))
+	for _, d := range deployments {
+		clusterNames = append(clusterNames, d.Cluster)
+	}
+	output.WriteString(fmt.Sprintf("Drift of app %s across clusters %s (baseline: %s)\n\n", appName, strings.Join(clusterNames, ", "), baseline.Cluster))
+
+	for _, d := range deployments {
+		if d.App == nil {
+			output.WriteString(fmt.Sprintf("%s: not deployed\n", d.Cluster))
+			continue
+		}
+		output.WriteString(fmt.Sprintf("%s: %s v%s from %s into %s (%s)\n",
+			d.Cluster, d.App.Name, deployedVersion(d.App), d.App.Spec.Catalog, d.App.Spec.Namespace, d.App.Status.Release.Status))
+	}
+
+	issues := make([]string, 0)
+	fields := []struct {
+		name  string
+		value func(*app.App) string
+	}{
+		{"version", deployedVersion},
+		{"catalog", func(a *app.App) string { return a.Spec.Catalog }},
+		{"target namespace", func(a *app.App) string { return a.Spec.Namespace }},
+	}
+	for _, field := range fields {
+		values := make([]string, 0, len(deployments))
+		distinct := make(map[string]bool)
+		for _, d := range deployments {
+			if d.App == nil {
+				continue
+			}
+			value := field.value(d.App)
+			distinct[value] = true
+			values = append(values, fmt.Sprintf("%s=%s", d.Cluster, value))
+		}
+		if len(distinct) > 1 {
+			issues = append(issues, fmt.Sprintf("%s differs: %s", field.name, strings.Join(values, ", ")))
+		}
+	}
+
+	for _, d := range deployments[1:] {
+		if d.App == nil || baseline.App == nil {
+			if (d.App == nil) != (baseline.App == nil) {
+				issues = append(issues, fmt.Sprintf("deployed in %s but not in %s", deployedIn(baseline, d), notDeployedIn(baseline, d)))
+			}
+			continue
+		}
+
+		if diff := emptyIfNil(baseline.Values).Diff(emptyIfNil(d.Values)); diff.HasChanges() {
+			var lines strings.Builder
+			lines.WriteString(fmt.Sprintf("values of %s differ from %s:", d.Cluster, baseline.Cluster))
+			for _, k := range sortedKeys(diff.Added) {
+				lines.WriteString(fmt.Sprintf("\n    + %s: %s", k, diff.Added[k]))
+			}
+			for _, k := range sortedKeys(diff.Modified) {
+				lines.WriteString(fmt.Sprintf("\n    ~ %s: %s -> %s", k, diff.Modified[k].Old, diff.Modified[k].New))
+			}
+			for _, k := range sortedKeys(diff.Removed) {
+				lines.WriteString(fmt.Sprintf("\n    - %s: %s", k, diff.Removed[k]))
+			}
+			issues = append(issues, lines.String())
+		}
+
+		if diff := emptyIfNil(baseline.Secrets).Diff(emptyIfNil(d.Secrets)); diff.HasChanges() {
+			issues = append(issues, fmt.Sprintf("secret values of %s differ from %s: %s", d.Cluster, baseline.Cluster, formatDiffKeys(diff)))
+		}
+	}
+
+	if len(issues) == 0 {
+		output.WriteString("\nNo inconsistencies found\n")
+		return output.String()
+	}
+
+	output.WriteString(fmt.Sprintf("\nInconsistencies (%d):\n", len(issues)))
+	for _, issue := range issues {
+		output.WriteString(fmt.Sprintf("  - %s\n", issue))
+	}
+
+	return output.String()
+}
+
+func deployedIn(a, b appDeployment) string {
+	if a.App != nil {
+		return a.Cluster
+	}
+	return b.Cluster
+}
+
+func notDeployedIn(a, b appDeployment) string {
+	if a.App == nil {
+		return a.Cluster
+	}
+	return b.Cluster
+}
+
+func emptyIfNil(cfg *config.Config) *config.Config {
+	if cfg == nil {
+		return &config.Config{}
+	}
+	return cfg
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
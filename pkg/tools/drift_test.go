@@ -0,0 +1,50 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+)
+
+func TestDriftReport(t *testing.T) {
+	newDeployment := func(clusterName, version, values string) appDeployment {
+		return appDeployment{
+			Cluster: clusterName,
+			App: &app.App{
+				Name: clusterName + "-ingress",
+				Spec: app.AppSpec{Catalog: "giantswarm", Name: "ingress-nginx", Namespace: "kube-system", Version: version},
+			},
+			Values:  (&config.Config{Data: map[string]string{"values": values}}).Flatten(),
+			Secrets: (&config.Config{Data: map[string]string{"values": "token: secret-" + version}}).Flatten(),
+		}
+	}
+
+	same := driftReport("ingress-nginx", []appDeployment{
+		newDeployment("dev", "1.2.0", "replicas: 2\n"),
+		newDeployment("prod", "1.2.0", "replicas: 2\n"),
+	})
+	if !strings.Contains(same, "No inconsistencies found") {
+		t.Errorf("report of identical deployments:\n%s", same)
+	}
+
+	report := driftReport("ingress-nginx", []appDeployment{
+		newDeployment("dev", "1.2.0", "replicas: 2\n"),
+		newDeployment("prod", "1.1.0", "replicas: 3\n"),
+		{Cluster: "test"},
+	})
+	for _, want := range []string{
+		"version differs: dev=1.2.0, prod=1.1.0",
+		"~ values/replicas: 2 -> 3",
+		"secret values of prod differ from dev: ~values/token",
+		"deployed in dev but not in test",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report does not contain %q:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "secret-1") {
+		t.Errorf("report leaks secret values:\n%s", report)
+	}
+}
@@ -0,0 +1,524 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/backstage"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/flux"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/gitops"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/iac"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/organization"
+)
+
+// helmManagedLabel marks resources rendered by a Helm release, e.g. the apps
+// of a default-apps chart, which are recreated from their chart
+const helmManagedLabel = "app.kubernetes.io/managed-by"
+
+// RegisterGitOpsTools registers the tools that move resources into GitOps
+// repositories
+func RegisterGitOpsTools(s *mcpserver.MCPServer, ctx *server.Context) error {
+	appClient := app.NewClient(ctx.DynamicClient)
+	configClient := newConfigClient(ctx)
+	clusterClient := cluster.NewClient(ctx.DynamicClient, ctx.K8sClient, appClient)
+	fluxClient := flux.NewClient(ctx.DynamicClient)
+
+	// gitops_export tool
+	exportTool := mcp.NewTool(
+		"gitops_export",
+		mcp.WithDescription("Export the apps, user configs and cluster definitions of an organization in the directory structure of the Giant Swarm GitOps template "+
+			"(management-clusters/<mc>/organizations/<org>/...), with a kustomization.yaml per directory. Secret values are left out and have to be encrypted with SOPS."),
+		mcp.WithString("organization", mcp.Required(), mcp.Description("Organization to export")),
+		mcp.WithString("management-cluster", mcp.Required(), mcp.Description("Name of the management cluster, the top directory of the export")),
+		mcp.WithString("cluster", mcp.Description("Only export this workload cluster and its apps")),
+		mcp.WithString("format", mcp.Description("files for a JSON map of path to content, tarball for a gzipped tar archive (default: files)")),
+		withPart(),
+	)
+
+	s.AddTool(exportTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		org := args["organization"].(string)
+		onlyCluster := getStringArg(args, "cluster")
+		format := getStringArg(args, "format")
+		if format == "" {
+			format = "files"
+		}
+		if format != "files" && format != "tarball" {
+			return nil, fmt.Errorf("unsupported format %q (supported: files, tarball)", format)
+		}
+
+		apps, err := appClient.ListByOrganization(toolCtx, ctx.K8sClient, org, "")
+		if err != nil {
+			return nil, err
+		}
+		clusters, err := clusterClient.ListByOrganization(toolCtx, org)
+		if err != nil {
+			return nil, err
+		}
+
+		export := gitops.NewExport(gitops.Layout{ManagementCluster: args["management-cluster"].(string), Organization: org})
+		var warnings []string
+		exported, skipped := 0, 0
+		for _, a := range apps {
+			if a.Labels[helmManagedLabel] == "Helm" {
+				skipped++
+				continue
+			}
+			clusterName := exportedAppCluster(a, clusters)
+			if onlyCluster != "" && clusterName != onlyCluster {
+				continue
+			}
+
+			bundle, bundleWarnings := exportConfigBundle(toolCtx, configClient, []*app.App{a}, "", false, false)
+			warnings = append(warnings, bundleWarnings...)
+			configs := make([]*config.Config, 0, len(bundle.Items))
+			for _, item := range bundle.Items {
+				configs = append(configs, item.Config())
+			}
+
+			if err := export.AddApp(clusterName, a, configs); err != nil {
+				return nil, err
+			}
+			exported++
+		}
+		if exported == 0 {
+			return nil, fmt.Errorf("no apps to export in organization %s", org)
+		}
+
+		var summary strings.Builder
+		summary.WriteString(fmt.Sprintf("Exported %d apps of organization %s to %s", exported, org, export.Layout.OrganizationDir()))
+		if skipped > 0 {
+			summary.WriteString(fmt.Sprintf(", skipped %d apps rendered by Helm releases", skipped))
+		}
+		summary.WriteString("\n")
+		for _, warning := range warnings {
+			summary.WriteString("Warning: " + warning + "\n")
+		}
+
+		if format == "tarball" {
+			archive, err := export.Tarball()
+			if err != nil {
+				return nil, err
+			}
+			summary.WriteString(fmt.Sprintf("%d files in the attached archive\n", len(export.Paths())))
+			return mcp.NewToolResultResource(summary.String(), mcp.BlobResourceContents{
+				URI:      fmt.Sprintf("file:///%s-%s-gitops.tar.gz", export.Layout.ManagementCluster, org),
+				MIMEType: "application/gzip",
+				Blob:     base64.StdEncoding.EncodeToString(archive),
+			}), nil
+		}
+
+		files, err := json.MarshalIndent(export.Files(), "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode files: %w", err)
+		}
+		return mcp.NewToolResultText(summary.String() + "\n" + string(files)), nil
+	})
+
+	// iac_export tool
+	iacExportTool := mcp.NewTool(
+		"iac_export",
+		mcp.WithDescription("Convert apps and their user configs into infrastructure as code: Terraform configuration with kubernetes_manifest resources of the hashicorp/kubernetes provider, "+
+			"or Crossplane provider-kubernetes Objects. Secret values are not exported; Terraform gets sensitive variables for them."),
+		mcp.WithString("format", mcp.Required(), mcp.Description("terraform or crossplane")),
+		mcp.WithString("name", mcp.Description("Name of a single app to export (requires namespace)")),
+		mcp.WithString("namespace", mcp.Description("Namespace of the app, or whose apps to export")),
+		mcp.WithString("organization", mcp.Description("Organization whose apps to export")),
+		mcp.WithString("provider-config", mcp.Description("Crossplane ProviderConfig of provider-kubernetes (default: default)")),
+		withPart(),
+	)
+
+	s.AddTool(iacExportTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		format := args["format"].(string)
+		if format != "terraform" && format != "crossplane" {
+			return nil, fmt.Errorf("unsupported format %q (supported: terraform, crossplane)", format)
+		}
+
+		apps, source, err := selectExportApps(toolCtx, ctx, appClient, args)
+		if err != nil {
+			return nil, err
+		}
+
+		var definitions []iac.Definition
+		var warnings []string
+		for _, a := range apps {
+			bundle, bundleWarnings := exportConfigBundle(toolCtx, configClient, []*app.App{a}, "", false, false)
+			warnings = append(warnings, bundleWarnings...)
+			def := iac.Definition{App: a}
+			for _, item := range bundle.Items {
+				def.Configs = append(def.Configs, item.Config())
+			}
+			definitions = append(definitions, def)
+		}
+
+		var output string
+		if format == "terraform" {
+			output, err = iac.Terraform(definitions)
+		} else {
+			providerConfig := getStringArg(args, "provider-config")
+			if providerConfig == "" {
+				providerConfig = "default"
+			}
+			output, err = iac.Crossplane(definitions, providerConfig)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to export %s: %w", source, err)
+		}
+
+		// Notes are comments in both formats, so that the output can be saved as is
+		var header strings.Builder
+		header.WriteString(fmt.Sprintf("# %d apps of %s\n", len(definitions), source))
+		if format == "terraform" {
+			header.WriteString("# Import the existing resources before applying, e.g. terraform import kubernetes_manifest.<name> \"apiVersion=v1,kind=ConfigMap,namespace=<ns>,name=<name>\"\n")
+		} else {
+			header.WriteString("# Secrets are exported without values; fill them in or patch them from a secret store\n")
+		}
+		for _, warning := range warnings {
+			header.WriteString("# Warning: " + warning + "\n")
+		}
+		return mcp.NewToolResultText(header.String() + "\n" + output), nil
+	})
+
+	// backstage_export tool
+	backstageTool := mcp.NewTool(
+		"backstage_export",
+		mcp.WithDescription("Generate Backstage catalog-info.yaml Component entities for deployed apps, owned by their organization, "+
+			"with links to the chart home and sources of the catalog entry and the runbook annotated on the app"),
+		mcp.WithString("name", mcp.Description("Name of a single app to export (requires namespace)")),
+		mcp.WithString("namespace", mcp.Description("Namespace of the app, or whose apps to export")),
+		mcp.WithString("organization", mcp.Description("Organization whose apps to export")),
+		mcp.WithString("owner", mcp.Description("Owner of the components (default: group:<organization>)")),
+		withPart(),
+	)
+
+	s.AddTool(backstageTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		apps, source, err := selectExportApps(toolCtx, ctx, appClient, args)
+		if err != nil {
+			return nil, err
+		}
+		if len(apps) == 0 {
+			return nil, fmt.Errorf("no apps found in %s", source)
+		}
+
+		entryClient := appcatalogentry.NewClient(ctx.DynamicClient).WithIndex(ctx.AppCatalogEntryIndex)
+		components := make([]*backstage.Component, 0, len(apps))
+		var unowned, missingEntries []string
+		for _, a := range apps {
+			owner := getStringArg(args, "owner")
+			if owner == "" {
+				org := appOrganization(a, getStringArg(args, "organization"))
+				if org == "" {
+					unowned = append(unowned, a.Namespace+"/"+a.Name)
+					org = "unknown"
+				}
+				owner = "group:" + org
+			}
+
+			entry, err := entryClient.FindVersion(toolCtx, a.Spec.Catalog, a.Spec.Name, a.Spec.Version)
+			if err != nil {
+				missingEntries = append(missingEntries, a.Namespace+"/"+a.Name)
+			}
+
+			system := a.Labels[cluster.ClusterLabel]
+			components = append(components, backstage.NewComponent(a, backstage.ComponentOptions{Owner: owner, System: system, Entry: entry}))
+		}
+
+		output, err := backstage.Marshal(components)
+		if err != nil {
+			return nil, err
+		}
+
+		// Notes are YAML comments so that the output can be saved as is
+		var header strings.Builder
+		header.WriteString(fmt.Sprintf("# Backstage components of %d apps of %s\n", len(components), source))
+		if len(unowned) > 0 {
+			header.WriteString(fmt.Sprintf("# Warning: no organization found for %s, owner set to group:unknown\n", strings.Join(unowned, ", ")))
+		}
+		if len(missingEntries) > 0 {
+			header.WriteString(fmt.Sprintf("# Warning: no catalog entry found for %s, description and links are missing\n", strings.Join(missingEntries, ", ")))
+		}
+		return mcp.NewToolResultText(header.String() + output), nil
+	})
+
+	// flux_list tool
+	fluxListTool := mcp.NewTool(
+		"flux_list",
+		mcp.WithDescription("List the Flux HelmReleases and Kustomizations of an organization or namespace next to its App CRs, showing which workloads are deployed "+
+			"through the App Platform, which of those are applied by Flux, and which run as plain Flux HelmReleases"),
+		mcp.WithString("organization", mcp.Description("Organization whose namespaces to inspect")),
+		mcp.WithString("namespace", mcp.Description("Namespace to inspect")),
+		withContinue(),
+	)
+
+	s.AddTool(fluxListTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		org := getStringArg(args, "organization")
+		namespace := getStringArg(args, "namespace")
+
+		var namespaces []string
+		switch {
+		case namespace != "":
+			namespaces = []string{namespace}
+		case org != "":
+			var err error
+			if namespaces, err = organization.GetNamespacesByOrganization(toolCtx, ctx.K8sClient, org); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("either organization or namespace must be specified")
+		}
+
+		scan, err := scanFlux(toolCtx, appClient, fluxClient, namespaces)
+		if err != nil {
+			return nil, err
+		}
+		inventory := flux.NewInventory(scan.apps, scan.helmReleases, scan.kustomizations)
+
+		scope := "namespace " + namespace
+		if namespace == "" {
+			scope = fmt.Sprintf("organization %s (%d namespaces)", org, len(namespaces))
+		}
+		return formatFluxInventory(ctx, args, scope, inventory, scan.missing)
+	})
+
+	return nil
+}
+
+// fluxScan holds the Apps and Flux resources of a set of namespaces
+type fluxScan struct {
+	apps           []*app.App
+	helmReleases   []*flux.Resource
+	kustomizations []*flux.Resource
+	// missing names the Flux kinds whose CRDs are not installed
+	missing []string
+}
+
+// scanFlux lists the Apps, HelmReleases and Kustomizations of the namespaces.
+// Kustomizations and HelmReleases outside of them that manage one of the Apps
+// are included as well, since Flux usually runs in its own namespace.
+func scanFlux(ctx context.Context, appClient *app.Client, fluxClient *flux.Client, namespaces []string) (*fluxScan, error) {
+	scan := &fluxScan{}
+	missing := make(map[string]bool)
+	listers := map[string]func(context.Context, string) ([]*flux.Resource, error){
+		flux.KindHelmRelease:   fluxClient.ListHelmReleases,
+		flux.KindKustomization: fluxClient.ListKustomizations,
+	}
+	scanned := make(map[string]bool, len(namespaces))
+
+	listFlux := func(ns string, keep func(*flux.Resource) bool) error {
+		for _, kind := range []string{flux.KindHelmRelease, flux.KindKustomization} {
+			if missing[kind] {
+				continue
+			}
+			resources, err := listers[kind](ctx, ns)
+			if flux.IsNotInstalled(err) {
+				missing[kind] = true
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			for _, r := range resources {
+				if !keep(r) {
+					continue
+				}
+				if kind == flux.KindHelmRelease {
+					scan.helmReleases = append(scan.helmReleases, r)
+				} else {
+					scan.kustomizations = append(scan.kustomizations, r)
+				}
+			}
+		}
+		return nil
+	}
+
+	all := func(*flux.Resource) bool { return true }
+	for _, ns := range namespaces {
+		scanned[ns] = true
+		apps, err := appClient.List(ctx, ns, "")
+		if err != nil {
+			return nil, err
+		}
+		scan.apps = append(scan.apps, apps...)
+		if err := listFlux(ns, all); err != nil {
+			return nil, err
+		}
+	}
+
+	// Managers of the Apps living in other namespaces
+	managers := make(map[string]bool)
+	external := make(map[string]bool)
+	for _, a := range scan.apps {
+		managedBy, manager := flux.ManagerOf(a.Labels)
+		if manager == "" {
+			continue
+		}
+		managers[managedBy+":"+manager] = true
+		if ns, _, _ := strings.Cut(manager, "/"); ns != "" && !scanned[ns] {
+			external[ns] = true
+		}
+	}
+	for ns := range external {
+		err := listFlux(ns, func(r *flux.Resource) bool {
+			kind := flux.ManagedByKustomization
+			if r.Kind == flux.KindHelmRelease {
+				kind = flux.ManagedByHelmRelease
+			}
+			return managers[kind+":"+r.Ref()]
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for kind := range missing {
+		scan.missing = append(scan.missing, kind)
+	}
+	sort.Strings(scan.missing)
+	return scan, nil
+}
+
+// formatFluxInventory renders the result of flux_list
+func formatFluxInventory(ctx *server.Context, args map[string]interface{}, scope string, inventory *flux.Inventory, missing []string) (*mcp.CallToolResult, error) {
+	counts := inventory.Counts()
+	var title strings.Builder
+	title.WriteString(fmt.Sprintf("Flux and App Platform workloads in %s:\n", scope))
+	title.WriteString(fmt.Sprintf("Apps: %d (%d applied by Flux Kustomizations, %d rendered by HelmReleases, %d applied manually)\n",
+		len(inventory.Apps), counts[flux.ManagedByKustomization], counts[flux.ManagedByHelmRelease], counts[flux.ManagedManually]))
+	title.WriteString(fmt.Sprintf("Flux HelmReleases: %d, Kustomizations: %d\n", len(inventory.HelmReleases), len(inventory.Kustomizations)))
+	for _, kind := range missing {
+		title.WriteString(fmt.Sprintf("Note: the %s CRD is not installed\n", kind))
+	}
+	title.WriteString("\n")
+
+	var entries []string
+	if len(inventory.Apps) > 0 {
+		entries = append(entries, "App Platform apps:\n")
+	}
+	for _, managed := range inventory.Apps {
+		a := managed.App
+		via := "applied manually or by another tool"
+		switch managed.ManagedBy {
+		case flux.ManagedByKustomization:
+			via = "Flux Kustomization " + managed.Manager
+		case flux.ManagedByHelmRelease:
+			via = "Flux HelmRelease " + managed.Manager
+		}
+		entries = append(entries, fmt.Sprintf("- %s/%s (%s %s): %s\n", a.Namespace, a.Name, a.Spec.Name, a.Spec.Version, via))
+	}
+
+	if len(inventory.HelmReleases) > 0 {
+		entries = append(entries, "\nFlux HelmReleases (plain Flux workloads):\n")
+	}
+	for _, hr := range inventory.HelmReleases {
+		var entry strings.Builder
+		entry.WriteString(fmt.Sprintf("- %s: ", hr.Ref()))
+		if hr.Chart != "" {
+			entry.WriteString(fmt.Sprintf("chart %s %s", hr.Chart, hr.ChartVersion))
+		} else {
+			entry.WriteString("chart reference")
+		}
+		if hr.Source != "" {
+			entry.WriteString(" from " + hr.Source)
+		}
+		entry.WriteString(formatFluxStatus(hr.Resource))
+		if len(hr.Apps) > 0 {
+			entry.WriteString(fmt.Sprintf("  Renders Apps: %s\n", strings.Join(hr.Apps, ", ")))
+		}
+		if len(hr.SameChartApps) > 0 {
+			entry.WriteString(fmt.Sprintf("  Warning: the chart is also deployed as App %s\n", strings.Join(hr.SameChartApps, ", ")))
+		}
+		entries = append(entries, entry.String())
+	}
+
+	if len(inventory.Kustomizations) > 0 {
+		entries = append(entries, "\nFlux Kustomizations:\n")
+	}
+	for _, ks := range inventory.Kustomizations {
+		var entry strings.Builder
+		entry.WriteString(fmt.Sprintf("- %s: path %s", ks.Ref(), ks.Path))
+		if ks.Source != "" {
+			entry.WriteString(" from " + ks.Source)
+		}
+		entry.WriteString(formatFluxStatus(ks.Resource))
+		if len(ks.Apps) > 0 {
+			entry.WriteString(fmt.Sprintf("  Applies %d Apps: %s\n", len(ks.Apps), strings.Join(ks.Apps, ", ")))
+		}
+		entries = append(entries, entry.String())
+	}
+
+	if len(entries) == 0 {
+		return mcp.NewToolResultText(title.String() + "No Apps or Flux resources found\n"), nil
+	}
+
+	result, err := budgetedList(ctx.OutputBudget, args, title.String(), entries)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+// formatFluxStatus renders the readiness of a Flux resource, ending the line
+func formatFluxStatus(r *flux.Resource) string {
+	status := fmt.Sprintf(", Ready %s", r.Ready)
+	if r.Revision != "" {
+		status += ", revision " + r.Revision
+	}
+	if r.Suspended {
+		status += " [suspended]"
+	}
+	status += "\n"
+	if r.Ready == "False" && r.Message != "" {
+		status += "  " + r.Message + "\n"
+	}
+	return status
+}
+
+// appOrganization returns the organization owning an app from its namespace
+// or organization label, falling back to org
+func appOrganization(a *app.App, org string) string {
+	if name, err := organization.GetOrganizationFromNamespace(a.Namespace); err == nil {
+		return name
+	}
+	if name := a.Labels[organization.OrganizationLabel]; name != "" {
+		return name
+	}
+	return strings.TrimPrefix(org, organization.OrganizationNamespacePrefix)
+}
+
+// exportedAppCluster returns the workload cluster an app belongs to, or ""
+// for apps of the management cluster. Cluster definitions run on the
+// management cluster but are named after, or labeled with, their cluster.
+func exportedAppCluster(a *app.App, clusters []*cluster.Cluster) string {
+	for _, cl := range clusters {
+		if cluster.AppTargetsCluster(a, cl) {
+			return cl.Name
+		}
+	}
+	if !gitops.IsClusterDefinition(a) {
+		return ""
+	}
+	if name := a.Labels[cluster.ClusterLabel]; name != "" {
+		return name
+	}
+	for _, cl := range clusters {
+		if a.Name == cl.Name || a.Name == cl.Name+"-default-apps" {
+			return cl.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/resources"
+)
+
+// textWithLinks returns a text result followed by links to resources the
+// client can read or subscribe to without re-deriving their identifiers
+func textWithLinks(text string, links ...mcp.ResourceLink) *mcp.CallToolResult {
+	content := make([]mcp.Content, 0, len(links)+1)
+	content = append(content, mcp.NewTextContent(text))
+	for _, link := range links {
+		content = append(content, link)
+	}
+	return &mcp.CallToolResult{Content: content}
+}
+
+// appLinks returns the resources related to an app
+func appLinks(a *app.App) []mcp.ResourceLink {
+	links := []mcp.ResourceLink{
+		resourceLink(resources.ResourceURI{Type: resources.ResourceTypeApp, Namespace: a.Namespace, Name: a.Name},
+			fmt.Sprintf("App: %s/%s", a.Namespace, a.Name), "App details and status"),
+	}
+
+	if a.Spec.Config != nil || a.Spec.UserConfig != nil {
+		links = append(links, resourceLink(resources.ResourceURI{Type: resources.ResourceTypeConfig, Namespace: a.Namespace, Name: a.Name},
+			fmt.Sprintf("Config: %s/%s", a.Namespace, a.Name), "Configuration values of the app"))
+	}
+
+	if a.Spec.Catalog != "" && a.Spec.Name != "" {
+		links = append(links, resourceLink(resources.ResourceURI{Type: resources.ResourceTypeChangelog, Catalog: a.Spec.Catalog, Name: a.Spec.Name},
+			fmt.Sprintf("Changelog: %s/%s", a.Spec.Catalog, a.Spec.Name), "Version history of the app"))
+
+		if a.Spec.Version != "" {
+			links = append(links,
+				resourceLink(resources.ResourceURI{Type: resources.ResourceTypeSchema, Catalog: a.Spec.Catalog, Name: a.Spec.Name, Version: a.Spec.Version},
+					fmt.Sprintf("Schema: %s/%s@%s", a.Spec.Catalog, a.Spec.Name, a.Spec.Version), "Values schema of the deployed version"),
+				resourceLink(resources.ResourceURI{Type: resources.ResourceTypeReadme, Catalog: a.Spec.Catalog, Name: a.Spec.Name, Version: a.Spec.Version},
+					fmt.Sprintf("README: %s/%s@%s", a.Spec.Catalog, a.Spec.Name, a.Spec.Version), "README of the deployed version"),
+			)
+		}
+	}
+
+	return links
+}
+
+// clusterLink returns the resource of a cluster
+func clusterLink(cl *cluster.Cluster) mcp.ResourceLink {
+	return resourceLink(resources.ResourceURI{Type: resources.ResourceTypeCluster, Namespace: cl.Namespace, Name: cl.Name},
+		fmt.Sprintf("Cluster: %s/%s", cl.Namespace, cl.Name), "Cluster details and status")
+}
+
+func resourceLink(uri resources.ResourceURI, name, description string) mcp.ResourceLink {
+	return mcp.NewResourceLink(uri.String(), name, description, "application/json")
+}
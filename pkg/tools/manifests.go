@@ -0,0 +1,333 @@
+package tools
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/catalog"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+)
+
+// giantswarmAPIVersion is the API version of the App and Catalog resources
+const giantswarmAPIVersion = "application.giantswarm.io/v1alpha1"
+
+// manifestOrder is the order documents are applied in, so that apps find the
+// catalogs and configuration they reference
+var manifestOrder = map[string]int{
+	"Catalog":   0,
+	"ConfigMap": 1,
+	"Secret":    1,
+	"App":       2,
+}
+
+// manifest is a validated document of manifest_apply
+type manifest struct {
+	Index       int
+	Kind        string
+	Namespace   string
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+	App         *app.App
+	Catalog     *catalog.Catalog
+	Config      *config.Config
+}
+
+func (m *manifest) String() string {
+	return fmt.Sprintf("%s %s/%s", m.Kind, m.Namespace, m.Name)
+}
+
+// RegisterManifestTools registers the tools working on raw manifests
+func RegisterManifestTools(s *mcpserver.MCPServer, ctx *server.Context) error {
+	appClient := app.NewClient(ctx.DynamicClient)
+	catalogClient := catalog.NewClient(ctx.DynamicClient)
+	configClient := newConfigClient(ctx)
+	history := config.NewHistory(ctx.K8sClient, ctx.ConfigHistoryRevisions)
+
+	// manifest_apply tool
+	applyTool := mcp.NewTool(
+		"manifest_apply",
+		mcp.WithDescription("Create or update App, Catalog, ConfigMap and Secret resources from a multi-document YAML, applying catalogs and configuration before apps. "+
+			"Updates replace the spec or data and merge the labels and annotations of the documents into the existing ones."),
+		mcp.WithString("manifests", mcp.Required(), mcp.Description("Multi-document YAML with the resources to apply")),
+		mcp.WithString("namespace", mcp.Description("Namespace for documents that do not set one")),
+		mcp.WithBoolean("force", mcp.Description("Overwrite concurrent changes to existing resources instead of retrying")),
+		mcp.WithString("ticket", mcp.Description("Ticket or pull request reference recorded on created apps")),
+	)
+
+	s.AddTool(applyTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		opts := k8s.UpdateOptions{Force: getBoolArg(args, "force")}
+
+		manifests, err := parseManifests(args["manifests"].(string), getStringArg(args, "namespace"))
+		if err != nil {
+			return nil, err
+		}
+
+		// The namespace policy middleware only sees the namespace argument
+		for _, m := range manifests {
+			if !ctx.NamespacePolicy.Allows(m.Namespace) {
+				return nil, fmt.Errorf("document %d: namespace %s is not allowed on this server", m.Index, m.Namespace)
+			}
+		}
+
+		creator := ctx.Creator(toolCtx)
+		now := time.Now()
+		for _, m := range manifests {
+			if m.App != nil {
+				m.App.MarkCreated(creator, getStringArg(args, "ticket"), now)
+			}
+		}
+
+		var output strings.Builder
+		output.WriteString(fmt.Sprintf("Applying %d documents:\n\n", len(manifests)))
+
+		failed := 0
+		for _, m := range manifests {
+			result, err := applyManifest(toolCtx, m, appClient, catalogClient, configClient, history, opts)
+			if err != nil {
+				failed++
+				output.WriteString(fmt.Sprintf("- [%d] %s: failed: %v\n", m.Index, m, err))
+				continue
+			}
+			output.WriteString(fmt.Sprintf("- [%d] %s: %s\n", m.Index, m, result))
+		}
+
+		if failed > 0 {
+			output.WriteString(fmt.Sprintf("\n%d of %d documents failed\n", failed, len(manifests)))
+		} else {
+			output.WriteString("\nAll documents applied\n")
+		}
+
+		return mcp.NewToolResultText(output.String()), nil
+	})
+
+	return nil
+}
+
+// parseManifests splits a multi-document YAML and validates each document.
+// Documents are returned in apply order; Index is their 1-based position in
+// the input. All documents are validated before anything is applied.
+func parseManifests(input, defaultNamespace string) ([]*manifest, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(strings.NewReader(input)))
+
+	manifests := make([]*manifest, 0)
+	var problems []string
+	index := 0
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifests: %w", err)
+		}
+
+		var object map[string]interface{}
+		if err := yaml.Unmarshal(doc, &object); err != nil {
+			index++
+			problems = append(problems, fmt.Sprintf("document %d: invalid YAML: %v", index, err))
+			continue
+		}
+		// Skip empty documents, e.g. after a trailing separator
+		if len(object) == 0 {
+			continue
+		}
+		index++
+
+		m, err := parseManifest(&unstructured.Unstructured{Object: object}, defaultNamespace)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("document %d: %v", index, err))
+			continue
+		}
+		m.Index = index
+		manifests = append(manifests, m)
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid manifests, nothing was applied:\n%s", strings.Join(problems, "\n"))
+	}
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("no documents found in manifests")
+	}
+
+	sort.SliceStable(manifests, func(i, j int) bool {
+		return manifestOrder[manifests[i].Kind] < manifestOrder[manifests[j].Kind]
+	})
+
+	return manifests, nil
+}
+
+// parseManifest validates a single document against the supported types
+func parseManifest(obj *unstructured.Unstructured, defaultNamespace string) (*manifest, error) {
+	kind := obj.GetKind()
+	apiVersion := obj.GetAPIVersion()
+
+	switch kind {
+	case "App", "Catalog":
+		if apiVersion != giantswarmAPIVersion {
+			return nil, fmt.Errorf("unsupported apiVersion %q for %s, expected %s", apiVersion, kind, giantswarmAPIVersion)
+		}
+	case "ConfigMap", "Secret":
+		if apiVersion != "v1" {
+			return nil, fmt.Errorf("unsupported apiVersion %q for %s, expected v1", apiVersion, kind)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported kind %q, expected App, Catalog, ConfigMap or Secret", kind)
+	}
+
+	if obj.GetName() == "" {
+		return nil, fmt.Errorf("%s has no metadata.name", kind)
+	}
+	if obj.GetNamespace() == "" {
+		if defaultNamespace == "" {
+			return nil, fmt.Errorf("%s %s has no metadata.namespace and no namespace was given", kind, obj.GetName())
+		}
+		obj.SetNamespace(defaultNamespace)
+	}
+
+	m := &manifest{
+		Kind:        kind,
+		Namespace:   obj.GetNamespace(),
+		Name:        obj.GetName(),
+		Labels:      obj.GetLabels(),
+		Annotations: obj.GetAnnotations(),
+	}
+
+	var err error
+	switch kind {
+	case "App":
+		m.App, err = app.NewAppFromUnstructured(obj)
+		if err == nil && (m.App.Spec.Catalog == "" || m.App.Spec.Name == "" || m.App.Spec.Version == "") {
+			err = fmt.Errorf("app %s needs spec.catalog, spec.name and spec.version", m.Name)
+		}
+	case "Catalog":
+		m.Catalog, err = catalog.NewCatalogFromUnstructured(obj)
+		if err == nil && m.Catalog.Spec.Storage.URL == "" && len(m.Catalog.Spec.Repositories) == 0 {
+			err = fmt.Errorf("catalog %s needs spec.storage.URL or spec.repositories", m.Name)
+		}
+	case "ConfigMap":
+		var cm corev1.ConfigMap
+		if err = decodeTyped(obj, &cm); err == nil {
+			m.Config = config.NewConfigFromConfigMap(&cm)
+		}
+	case "Secret":
+		var secret corev1.Secret
+		if err = decodeTyped(obj, &secret); err == nil {
+			m.Config = config.NewConfigFromSecret(&secret)
+			for k, v := range secret.StringData {
+				m.Config.Data[k] = v
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Resource versions in manifests are stale, updates re-read the object
+	if m.Config != nil {
+		m.Config.ResourceVersion = ""
+		if m.Config.Data == nil {
+			m.Config.Data = make(map[string]string)
+		}
+	}
+
+	return m, nil
+}
+
+// decodeTyped converts a document into a typed object through JSON, which
+// decodes base64 encoded Secret data
+func decodeTyped(obj *unstructured.Unstructured, into interface{}) error {
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, into); err != nil {
+		return fmt.Errorf("invalid %s: %w", obj.GetKind(), err)
+	}
+	return nil
+}
+
+// applyManifest creates the resource of a document, or updates it when it
+// already exists, and describes what was done. Updates replace the spec or
+// data and merge the labels and annotations of the document into the
+// object's.
+func applyManifest(ctx context.Context, m *manifest, appClient *app.Client, catalogClient *catalog.Client, configClient *config.Client, history *config.History, opts k8s.UpdateOptions) (string, error) {
+	switch {
+	case m.App != nil:
+		if _, err := appClient.Create(ctx, m.App); !apierrors.IsAlreadyExists(err) {
+			return "created", err
+		}
+		_, err := appClient.Update(ctx, m.Namespace, m.Name, opts, func(current *app.App) error {
+			current.Spec = m.App.Spec
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		if len(m.Labels) > 0 {
+			if _, err := appClient.SetLabels(ctx, m.Namespace, m.Name, m.Labels, nil); err != nil {
+				return "", err
+			}
+		}
+		if len(m.Annotations) > 0 {
+			if _, err := appClient.SetAnnotations(ctx, m.Namespace, m.Name, m.Annotations, nil); err != nil {
+				return "", err
+			}
+		}
+		return "updated", nil
+
+	case m.Catalog != nil:
+		if _, err := catalogClient.Create(ctx, m.Catalog); !apierrors.IsAlreadyExists(err) {
+			return "created", err
+		}
+		_, err := catalogClient.Update(ctx, m.Namespace, m.Name, opts, func(current *catalog.Catalog) error {
+			current.Spec = m.Catalog.Spec
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		return "updated", catalogClient.MergeMetadata(ctx, m.Namespace, m.Name, m.Labels, m.Annotations)
+
+	case m.Config != nil:
+		if err := configClient.Create(ctx, m.Config); !apierrors.IsAlreadyExists(err) {
+			return "created", err
+		}
+		revision, err := recordRevision(ctx, history, configClient, m.Namespace, m.Name, m.Config.Type)
+		if err != nil {
+			return "", err
+		}
+		err = configClient.Update(ctx, m.Namespace, m.Name, m.Config.Type, opts, func(current *config.Config) error {
+			current.Data = m.Config.Data
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		if err := configClient.MergeMetadata(ctx, m.Namespace, m.Name, m.Config.Type, m.Labels, m.Annotations); err != nil {
+			return "", err
+		}
+		return "updated" + strings.ReplaceAll(revision, "\n", " "), nil
+	}
+
+	return "", fmt.Errorf("nothing to apply")
+}
@@ -0,0 +1,184 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+	gstesting "github.com/giantswarm/mcp-giantswarm-apps/pkg/testing"
+)
+
+func TestParseManifests(t *testing.T) {
+	input := `apiVersion: application.giantswarm.io/v1alpha1
+kind: App
+metadata:
+  name: ingress
+spec:
+  catalog: giantswarm
+  name: ingress-nginx
+  version: 1.0.0
+  namespace: kube-system
+  kubeConfig:
+    inCluster: true
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: ingress-user-secrets
+  namespace: org-acme
+data:
+  values: dG9rZW46IHNlY3JldAo=
+stringData:
+  extra: "true"
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ingress-user-values
+data:
+  values: |
+    replicas: 2
+---
+`
+
+	manifests, err := parseManifests(input, "org-acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kinds := make([]string, 0, len(manifests))
+	for _, m := range manifests {
+		kinds = append(kinds, m.Kind)
+	}
+	if got := strings.Join(kinds, ","); got != "Secret,ConfigMap,App" {
+		t.Errorf("apply order = %s, want Secret,ConfigMap,App", got)
+	}
+
+	secret := manifests[0]
+	if secret.Index != 2 {
+		t.Errorf("secret index = %d, want 2", secret.Index)
+	}
+	if secret.Config.Data["values"] != "token: secret\n" || secret.Config.Data["extra"] != "true" {
+		t.Errorf("secret data = %v", secret.Config.Data)
+	}
+
+	if app := manifests[2]; app.Namespace != "org-acme" || app.App.Spec.Version != "1.0.0" {
+		t.Errorf("app = %s version %s", app, app.App.Spec.Version)
+	}
+}
+
+func TestParseManifestsInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "unsupported kind",
+			input: "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: x\n  namespace: ns\n",
+			want:  `unsupported kind "Deployment"`,
+		},
+		{
+			name:  "missing namespace",
+			input: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: x\n",
+			want:  "no metadata.namespace",
+		},
+		{
+			name:  "incomplete app",
+			input: "apiVersion: application.giantswarm.io/v1alpha1\nkind: App\nmetadata:\n  name: x\n  namespace: ns\nspec:\n  catalog: giantswarm\n",
+			want:  "needs spec.catalog, spec.name and spec.version",
+		},
+		{
+			name:  "empty",
+			input: "---\n",
+			want:  "no documents found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseManifests(tt.input, "")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyManifestMergesMetadata(t *testing.T) {
+	ctx := context.Background()
+	existing := gstesting.DeployedApp("org-acme", "ingress", "giantswarm", "1.0.0")
+	existing.SetLabels(map[string]string{"team": "platform"})
+	k8sClient, dynamicClient := gstesting.NewClients(existing)
+	appClient := app.NewClient(dynamicClient)
+	configClient := config.NewClient(k8sClient)
+
+	// Seeded objects are owned by no field manager, create the configmap the
+	// way the server does so that updating it doesn't conflict
+	err := configClient.Create(ctx, &config.Config{
+		Name:      "ingress-user-values",
+		Namespace: "org-acme",
+		Type:      config.ConfigTypeConfigMap,
+		Data:      map[string]string{"values": "replicas: 1\n"},
+		Labels:    map[string]string{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := configClient.MergeMetadata(ctx, "org-acme", "ingress-user-values", config.ConfigTypeConfigMap, nil, map[string]string{"owner": "platform"}); err != nil {
+		t.Fatal(err)
+	}
+
+	manifests, err := parseManifests(`apiVersion: application.giantswarm.io/v1alpha1
+kind: App
+metadata:
+  name: ingress
+  labels:
+    env: prod
+  annotations:
+    note: from-git
+spec:
+  catalog: giantswarm
+  name: ingress
+  version: 1.1.0
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ingress-user-values
+  labels:
+    env: prod
+data:
+  values: |
+    replicas: 2
+`, "org-acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range manifests {
+		if result, err := applyManifest(ctx, m, appClient, nil, configClient, config.NewHistory(k8sClient, 0), k8s.UpdateOptions{}); err != nil || result != "updated" {
+			t.Fatalf("applyManifest(%s) = %q, %v; want updated", m, result, err)
+		}
+	}
+
+	updated, err := appClient.Get(ctx, "org-acme", "ingress")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Spec.Version != "1.1.0" || updated.Labels["team"] != "platform" || updated.Labels["env"] != "prod" || updated.Annotations["note"] != "from-git" {
+		t.Errorf("app = version %s, labels %v, annotations %v", updated.Spec.Version, updated.Labels, updated.Annotations)
+	}
+
+	cm, err := k8sClient.CoreV1().ConfigMaps("org-acme").Get(ctx, "ingress-user-values", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cm.Data["values"] != "replicas: 2\n" || cm.Labels["env"] != "prod" || cm.Annotations["owner"] != "platform" {
+		t.Errorf("configmap = data %v, labels %v, annotations %v", cm.Data, cm.Labels, cm.Annotations)
+	}
+}
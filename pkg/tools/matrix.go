@@ -0,0 +1,164 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+)
+
+// appsMatrix is a table of apps by clusters with the version deployed in each
+type appsMatrix struct {
+	Clusters []string
+	Rows     []appsMatrixRow
+}
+
+// appsMatrixRow is one app of an apps matrix
+type appsMatrixRow struct {
+	Catalog  string
+	App      string
+	Newest   string
+	Versions map[string]string // cluster name -> deployed version
+}
+
+// buildAppsMatrix builds an apps matrix from the apps of each cluster and the
+// catalog entries of those apps, which give the newest available versions
+func buildAppsMatrix(clusters []string, appsByCluster map[string][]*app.App, entries []*appcatalogentry.AppCatalogEntry) *appsMatrix {
+	rows := make(map[string]*appsMatrixRow)
+	for _, clusterName := range clusters {
+		for _, a := range appsByCluster[clusterName] {
+			key := a.Spec.Catalog + "/" + a.Spec.Name
+			row, ok := rows[key]
+			if !ok {
+				row = &appsMatrixRow{
+					Catalog:  a.Spec.Catalog,
+					App:      a.Spec.Name,
+					Versions: make(map[string]string),
+				}
+				rows[key] = row
+			}
+			row.Versions[clusterName] = deployedVersion(a)
+		}
+	}
+
+	matrix := &appsMatrix{Clusters: clusters}
+	for _, row := range rows {
+		versions := make([]*appcatalogentry.AppCatalogEntry, 0)
+		for _, entry := range entries {
+			if entry.Spec.Catalog.Name == row.Catalog && entry.MatchesApp(row.App) {
+				versions = append(versions, entry)
+			}
+		}
+		row.Newest = appcatalogentry.NewestVersion(versions)
+		matrix.Rows = append(matrix.Rows, *row)
+	}
+
+	sort.Slice(matrix.Rows, func(i, j int) bool {
+		if matrix.Rows[i].App != matrix.Rows[j].App {
+			return matrix.Rows[i].App < matrix.Rows[j].App
+		}
+		return matrix.Rows[i].Catalog < matrix.Rows[j].Catalog
+	})
+
+	return matrix
+}
+
+// deployedVersion returns the version app-operator reports as deployed,
+// falling back to the desired version while it has not reconciled the app
+func deployedVersion(a *app.App) string {
+	if a.Status.Version != "" {
+		return a.Status.Version
+	}
+	return a.Spec.Version
+}
+
+// Behind returns the clusters running an older version than the newest one
+func (r appsMatrixRow) Behind() []string {
+	newest, err := semver.NewVersion(r.Newest)
+	if err != nil {
+		return nil
+	}
+
+	behind := make([]string, 0)
+	for clusterName, version := range r.Versions {
+		if v, err := semver.NewVersion(version); err == nil && v.LessThan(newest) {
+			behind = append(behind, clusterName)
+		}
+	}
+	sort.Strings(behind)
+	return behind
+}
+
+// Mixed reports whether the app runs different versions across clusters
+func (r appsMatrixRow) Mixed() bool {
+	seen := ""
+	for _, version := range r.Versions {
+		if seen != "" && strings.TrimPrefix(version, "v") != seen {
+			return true
+		}
+		seen = strings.TrimPrefix(version, "v")
+	}
+	return false
+}
+
+// Drift summarizes how the app's deployments differ from each other and from
+// the newest version
+func (r appsMatrixRow) Drift() string {
+	var drift []string
+	if r.Mixed() {
+		drift = append(drift, "mixed versions")
+	}
+	if behind := r.Behind(); len(behind) > 0 {
+		drift = append(drift, fmt.Sprintf("%d/%d behind", len(behind), len(r.Versions)))
+	}
+	if len(drift) == 0 {
+		return "ok"
+	}
+	return strings.Join(drift, ", ")
+}
+
+// String renders the matrix as a markdown table. Versions older than the
+// newest one are marked with an asterisk, "-" means not deployed.
+func (m *appsMatrix) String() string {
+	var output strings.Builder
+
+	output.WriteString("| App | Catalog | Newest |")
+	for _, clusterName := range m.Clusters {
+		output.WriteString(fmt.Sprintf(" %s |", clusterName))
+	}
+	output.WriteString(" Drift |\n|---|---|---|")
+	for range m.Clusters {
+		output.WriteString("---|")
+	}
+	output.WriteString("---|\n")
+
+	for _, row := range m.Rows {
+		behind := make(map[string]bool)
+		for _, clusterName := range row.Behind() {
+			behind[clusterName] = true
+		}
+
+		newest := row.Newest
+		if newest == "" {
+			newest = "unknown"
+		}
+		output.WriteString(fmt.Sprintf("| %s | %s | %s |", row.App, row.Catalog, newest))
+		for _, clusterName := range m.Clusters {
+			version, ok := row.Versions[clusterName]
+			switch {
+			case !ok:
+				version = "-"
+			case behind[clusterName]:
+				version += "*"
+			}
+			output.WriteString(fmt.Sprintf(" %s |", version))
+		}
+		output.WriteString(fmt.Sprintf(" %s |\n", row.Drift()))
+	}
+
+	return output.String()
+}
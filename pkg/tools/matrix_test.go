@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/appcatalogentry"
+)
+
+func TestBuildAppsMatrix(t *testing.T) {
+	newApp := func(name, version string) *app.App {
+		return &app.App{Spec: app.AppSpec{Catalog: "giantswarm", Name: name, Version: version}}
+	}
+	newEntry := func(name, version string) *appcatalogentry.AppCatalogEntry {
+		return &appcatalogentry.AppCatalogEntry{Spec: appcatalogentry.AppCatalogEntrySpec{
+			AppName: name,
+			Catalog: appcatalogentry.CatalogReference{Name: "giantswarm"},
+			Chart:   appcatalogentry.ChartSpec{Name: name, Version: version},
+		}}
+	}
+
+	appsByCluster := map[string][]*app.App{
+		"dev":  {newApp("ingress-nginx", "1.2.0"), newApp("external-dns", "2.0.0")},
+		"prod": {newApp("ingress-nginx", "1.1.0")},
+	}
+	entries := []*appcatalogentry.AppCatalogEntry{
+		newEntry("ingress-nginx", "1.1.0"),
+		newEntry("ingress-nginx", "1.2.0"),
+		newEntry("ingress-nginx", "1.3.0-rc.1"),
+		newEntry("external-dns", "2.0.0"),
+	}
+
+	matrix := buildAppsMatrix([]string{"dev", "prod"}, appsByCluster, entries)
+	if len(matrix.Rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(matrix.Rows))
+	}
+
+	dns, ingress := matrix.Rows[0], matrix.Rows[1]
+	if dns.App != "external-dns" || dns.Drift() != "ok" {
+		t.Errorf("row 0 = %s with drift %q, want external-dns without drift", dns.App, dns.Drift())
+	}
+	if ingress.Newest != "1.2.0" {
+		t.Errorf("newest ingress-nginx = %s, want stable 1.2.0", ingress.Newest)
+	}
+	if behind := ingress.Behind(); len(behind) != 1 || behind[0] != "prod" {
+		t.Errorf("behind = %v, want [prod]", behind)
+	}
+	if drift := ingress.Drift(); drift != "mixed versions, 1/2 behind" {
+		t.Errorf("drift = %q", drift)
+	}
+
+	table := matrix.String()
+	for _, want := range []string{"| external-dns | giantswarm | 2.0.0 | 2.0.0 | - | ok |", "| 1.2.0 | 1.1.0* |"} {
+		if !strings.Contains(table, want) {
+			t.Errorf("table does not contain %q:\n%s", want, table)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+)
+
+// Tools are named <resource>_<action>, e.g. app_list. renamedTools maps the
+// former names of tools that did not follow this convention to their
+// current names.
+var renamedTools = map[string]string{
+	"apps_matrix":     "app_matrix",
+	"apply_manifests": "manifest_apply",
+	"machine_list":    "cluster_machines",
+}
+
+// dottedToolName returns the dotted form of a tool name used by older
+// clients and documentation, e.g. app.list for app_list
+func dottedToolName(name string) string {
+	return strings.Replace(name, "_", ".", 1)
+}
+
+// ToolAliases keeps tools callable under their legacy names: the dotted form
+// and the former names of renamed tools. Aliases are hidden from tool
+// listings, and calls through them return a deprecation warning.
+type ToolAliases struct {
+	mu      sync.RWMutex
+	aliases map[string]string
+}
+
+// NewToolAliases creates an empty set of aliases
+func NewToolAliases() *ToolAliases {
+	return &ToolAliases{aliases: make(map[string]string)}
+}
+
+// Register adds the legacy names of all tools registered on s. The
+// descriptions of renamed tools mention their former name.
+func (a *ToolAliases) Register(s *mcpserver.MCPServer) {
+	formerNames := make(map[string][]string)
+	for former, current := range renamedTools {
+		formerNames[current] = append(formerNames[current], former)
+	}
+
+	tools := s.ListTools()
+	aliases := make(map[string]string)
+	for name := range tools {
+		if dotted := dottedToolName(name); dotted != name {
+			aliases[dotted] = name
+		}
+		for _, former := range formerNames[name] {
+			aliases[former] = name
+			aliases[dottedToolName(former)] = name
+		}
+	}
+
+	updated := make([]mcpserver.ServerTool, 0)
+	for name, st := range tools {
+		if former := formerNames[name]; len(former) > 0 {
+			sort.Strings(former)
+			tool := st.Tool
+			tool.Description += fmt.Sprintf(" (Formerly %s, which is deprecated.)", strings.Join(former, ", "))
+			updated = append(updated, mcpserver.ServerTool{Tool: tool, Handler: st.Handler})
+		}
+	}
+	for alias, name := range aliases {
+		if _, exists := tools[alias]; exists {
+			continue
+		}
+		st := tools[name]
+		tool := st.Tool
+		tool.Name = alias
+		tool.Description = fmt.Sprintf("Deprecated: use %s. %s", name, st.Tool.Description)
+		updated = append(updated, mcpserver.ServerTool{Tool: tool, Handler: deprecated(alias, name, st.Handler)})
+	}
+
+	a.mu.Lock()
+	for alias, name := range aliases {
+		a.aliases[alias] = name
+	}
+	a.mu.Unlock()
+
+	s.AddTools(updated...)
+}
+
+// Filter hides aliases from tool listings; it is a server tool filter
+func (a *ToolAliases) Filter(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	filtered := make([]mcp.Tool, 0, len(tools))
+	for _, tool := range tools {
+		if _, isAlias := a.aliases[tool.Name]; !isAlias {
+			filtered = append(filtered, tool)
+		}
+	}
+	return filtered
+}
+
+// Resolve returns the current name of a tool called by one of its aliases, and
+// other names unchanged
+func (a *ToolAliases) Resolve(name string) string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if current, ok := a.aliases[name]; ok {
+		return current
+	}
+	return name
+}
+
+// deprecated calls handler and adds a warning that alias is deprecated
+func deprecated(alias, name string, handler mcpserver.ToolHandlerFunc) mcpserver.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, req)
+		if err != nil || result == nil {
+			return result, err
+		}
+		result.Content = append(result.Content, mcp.NewTextContent(
+			fmt.Sprintf("Warning: the tool name %s is deprecated and will be removed, use %s instead.", alias, name)))
+		return result, nil
+	}
+}
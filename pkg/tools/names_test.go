@@ -0,0 +1,45 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+)
+
+func TestToolAliases(t *testing.T) {
+	s := mcpserver.NewMCPServer("test", "0.0.0")
+	s.AddTool(mcp.NewTool("app_matrix", mcp.WithDescription("Compare versions.")), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("matrix"), nil
+	})
+
+	aliases := NewToolAliases()
+	aliases.Register(s)
+
+	if desc := s.GetTool("app_matrix").Tool.Description; !strings.Contains(desc, "Formerly apps_matrix") {
+		t.Errorf("description %q does not mention the former name", desc)
+	}
+
+	for _, alias := range []string{"app.matrix", "apps_matrix", "apps.matrix"} {
+		st := s.GetTool(alias)
+		if st == nil {
+			t.Errorf("alias %s is not registered", alias)
+			continue
+		}
+		result, err := st.Handler(context.Background(), mcp.CallToolRequest{})
+		if err != nil {
+			t.Fatalf("%s: %v", alias, err)
+		}
+		warning := result.Content[len(result.Content)-1].(mcp.TextContent).Text
+		if !strings.Contains(warning, alias+" is deprecated") || !strings.Contains(warning, "use app_matrix") {
+			t.Errorf("%s: warning = %q", alias, warning)
+		}
+	}
+
+	tools := []mcp.Tool{{Name: "app_matrix"}, {Name: "app.matrix"}, {Name: "apps_matrix"}}
+	if listed := aliases.Filter(context.Background(), tools); len(listed) != 1 || listed[0].Name != "app_matrix" {
+		t.Errorf("Filter listed %v, want only app_matrix", listed)
+	}
+}
@@ -0,0 +1,118 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/organization"
+)
+
+// namespaceArguments are the tool arguments naming a management cluster namespace
+var namespaceArguments = []string{"namespace", "namespace1", "namespace2", "catalog-namespace"}
+
+// ApplyNamespacePolicy wraps all registered tools to enforce the namespace
+// allow and deny lists. Every namespace or organization passed to a tool is
+// checked, and tools that would otherwise search all namespaces must be scoped
+// to one. It must be called before ApplySessionDefaults, so that the checks
+// see the arguments filled from the session defaults.
+func ApplyNamespacePolicy(s *mcpserver.MCPServer, ctx *server.Context) {
+	wrapped := make([]mcpserver.ServerTool, 0)
+	for name, st := range s.ListTools() {
+		// session_set_defaults checks the defaults it sets itself
+		if name == "session_set_defaults" {
+			continue
+		}
+		wrapped = append(wrapped, mcpserver.ServerTool{
+			Tool:    st.Tool,
+			Handler: withNamespacePolicy(ctx, st.Tool.InputSchema.Properties, st.Handler),
+		})
+	}
+
+	if len(wrapped) > 0 {
+		s.AddTools(wrapped...)
+	}
+}
+
+func withNamespacePolicy(ctx *server.Context, properties map[string]any, next mcpserver.ToolHandlerFunc) mcpserver.ToolHandlerFunc {
+	return func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if !ctx.NamespacePolicy.Active() {
+			return next(toolCtx, req)
+		}
+
+		args, _ := req.Params.Arguments.(map[string]interface{})
+		if err := checkNamespacePolicy(ctx.NamespacePolicy, properties, args); err != nil {
+			return nil, err
+		}
+
+		return next(toolCtx, req)
+	}
+}
+
+// checkNamespacePolicy checks the namespaces a tool call would operate on,
+// after omitted arguments were filled from the session defaults
+func checkNamespacePolicy(policy server.NamespacePolicy, properties map[string]any, args map[string]interface{}) error {
+	scoped := false
+
+	for _, arg := range namespaceArguments {
+		if namespace := getStringArg(args, arg); namespace != "" {
+			if !policy.Allows(namespace) {
+				return fmt.Errorf("namespace %s is not allowed on this server", namespace)
+			}
+			if arg != "catalog-namespace" {
+				scoped = true
+			}
+		}
+	}
+
+	if org := getStringArg(args, "organization"); org != "" {
+		if namespace := organization.GetOrganizationNamespace(org); !policy.Allows(namespace) {
+			return fmt.Errorf("organization %s (namespace %s) is not allowed on this server", org, namespace)
+		}
+		scoped = true
+	}
+
+	// config_merge takes a list of namespace/name pairs
+	if configs := getStringArg(args, "configs"); configs != "" {
+		for _, ref := range strings.Split(configs, ",") {
+			namespace, _, found := strings.Cut(strings.TrimSpace(ref), "/")
+			if found && !policy.Allows(namespace) {
+				return fmt.Errorf("namespace %s is not allowed on this server", namespace)
+			}
+		}
+	}
+
+	// organization_create_automation_sa takes a list of catalog namespaces
+	if namespaces := getStringArg(args, "catalog-namespaces"); namespaces != "" {
+		for _, namespace := range strings.Split(namespaces, ",") {
+			if namespace = strings.TrimSpace(namespace); namespace != "" && !policy.Allows(namespace) {
+				return fmt.Errorf("namespace %s is not allowed on this server", namespace)
+			}
+		}
+	}
+
+	_, acceptsNamespace := properties["namespace"]
+	_, acceptsOrg := properties["organization"]
+	if scoped || (!acceptsNamespace && !acceptsOrg) {
+		return nil
+	}
+
+	return fmt.Errorf("this server is restricted to a subset of namespaces: pass an allowed namespace or organization")
+}
+
+// CheckDefaultsPolicy makes sure session defaults cannot be used to bypass the namespace policy
+func CheckDefaultsPolicy(policy server.NamespacePolicy, defaults server.Defaults) error {
+	if defaults.Namespace != "" && !policy.Allows(defaults.Namespace) {
+		return fmt.Errorf("namespace %s is not allowed on this server", defaults.Namespace)
+	}
+	if defaults.Organization != "" {
+		if namespace := organization.GetOrganizationNamespace(defaults.Organization); !policy.Allows(namespace) {
+			return fmt.Errorf("organization %s (namespace %s) is not allowed on this server", defaults.Organization, namespace)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	gstesting "github.com/giantswarm/mcp-giantswarm-apps/pkg/testing"
+)
+
+func TestCheckNamespacePolicy(t *testing.T) {
+	policy, err := server.NewNamespacePolicy([]string{"org-*"}, []string{"org-giantswarm"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scoped := map[string]any{"namespace": nil, "organization": nil}
+
+	tests := []struct {
+		name       string
+		properties map[string]any
+		args       map[string]interface{}
+		wantErr    bool
+	}{
+		{name: "allowed namespace", properties: scoped, args: map[string]interface{}{"namespace": "org-acme"}},
+		{name: "denied namespace", properties: scoped, args: map[string]interface{}{"namespace": "org-giantswarm"}, wantErr: true},
+		{name: "namespace outside allow list", properties: scoped, args: map[string]interface{}{"namespace": "kube-system"}, wantErr: true},
+		{name: "denied organization", properties: scoped, args: map[string]interface{}{"organization": "giantswarm"}, wantErr: true},
+		{name: "all namespaces", properties: scoped, args: map[string]interface{}{}, wantErr: true},
+		{name: "denied catalog namespace", properties: scoped, args: map[string]interface{}{"namespace": "org-acme", "catalog-namespace": "org-giantswarm"}, wantErr: true},
+		{name: "catalog namespace only", properties: scoped, args: map[string]interface{}{"catalog-namespace": "org-acme"}, wantErr: true},
+		{name: "denied catalog namespaces", properties: map[string]any{}, args: map[string]interface{}{"catalog-namespaces": "org-acme, kube-system"}, wantErr: true},
+		{name: "unscoped tool", properties: map[string]any{}, args: map[string]interface{}{}},
+		{name: "config merge", args: map[string]interface{}{"configs": "org-acme/a, default/b"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkNamespacePolicy(policy, tt.properties, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkNamespacePolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNamespacePolicyWithSessionDefaults(t *testing.T) {
+	ctx := gstesting.NewServerContext()
+	policy, err := server.NewNamespacePolicy([]string{"org-*"}, []string{"org-giantswarm"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx.NamespacePolicy = policy
+	ctx.SetDefaults(context.Background(), server.Defaults{Organization: "acme"})
+
+	s := mcpserver.NewMCPServer("test", "0.0.0")
+	for _, register := range []func(*mcpserver.MCPServer, *server.Context) error{RegisterAppTools, RegisterAppCatalogEntryTools} {
+		if err := register(s, ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ApplyNamespacePolicy(s, ctx)
+	ApplySessionDefaults(s, ctx)
+
+	tests := []struct {
+		name    string
+		tool    string
+		args    map[string]interface{}
+		wantErr bool
+	}{
+		// The default organization scopes the call to its namespace
+		{name: "organization from defaults", tool: "app_list", args: map[string]interface{}{}},
+		// An optional namespace is not filled from the default organization,
+		// so the call would list all namespaces
+		{name: "optional namespace", tool: "appcatalogentry_list", args: map[string]interface{}{}, wantErr: true},
+		{name: "allowed namespace", tool: "appcatalogentry_list", args: map[string]interface{}{"namespace": "org-acme"}},
+		{name: "denied catalog namespace", tool: "appcatalogentry_list", args: map[string]interface{}{"namespace": "org-acme", "catalog": "giantswarm", "catalog-namespace": "org-giantswarm"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := gstesting.CallTool(context.Background(), s, tt.tool, tt.args)
+			denied := err != nil && (strings.Contains(err.Error(), "not allowed") || strings.Contains(err.Error(), "restricted"))
+			if tt.wantErr && !denied {
+				t.Errorf("%s error = %v, want denied", tt.tool, err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("%s error = %v", tt.tool, err)
+			}
+		})
+	}
+}
@@ -3,17 +3,28 @@ package tools
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	mcpserver "github.com/mark3labs/mcp-go/server"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 
 	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
 	"github.com/giantswarm/mcp-giantswarm-apps/pkg/organization"
 )
 
 // RegisterOrganizationTools registers all organization management tools
 func RegisterOrganizationTools(s *mcpserver.MCPServer, ctx *server.Context) error {
+	orgClient := organization.NewClient(ctx.DynamicClient, ctx.K8sClient)
+	appClient := app.NewClient(ctx.DynamicClient)
+	configClient := config.NewClient(ctx.K8sClient)
+
 	// organization_list tool
 	listTool := mcp.NewTool(
 		"organization_list",
@@ -25,6 +36,16 @@ func RegisterOrganizationTools(s *mcpserver.MCPServer, ctx *server.Context) erro
 		args := req.Params.Arguments.(map[string]interface{})
 		detailed := getBoolArg(args, "detailed")
 
+		// Prefer the Organization resources, falling back to namespaces on
+		// clusters without the Organization CRD
+		orgs, err := orgClient.List(toolCtx)
+		if err == nil {
+			return formatOrganizations(toolCtx, ctx, orgClient, orgs, detailed)
+		}
+		if !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return nil, err
+		}
+
 		// Get all organization namespaces
 		orgNamespaces, err := organization.ListOrganizationNamespaces(toolCtx, ctx.K8sClient)
 		if err != nil {
@@ -44,7 +65,7 @@ func RegisterOrganizationTools(s *mcpserver.MCPServer, ctx *server.Context) erro
 
 			if detailed {
 				// Get namespace info
-				info, err := organization.GetNamespaceInfo(toolCtx, ctx.K8sClient, ns)
+				info, err := organization.GetNamespaceInfo(toolCtx, ctx.K8sClient, ns, ctx.SystemNamespaces)
 				if err == nil && len(info.Labels) > 0 {
 					output.WriteString("  Labels:\n")
 					for k, v := range info.Labels {
@@ -96,7 +117,7 @@ func RegisterOrganizationTools(s *mcpserver.MCPServer, ctx *server.Context) erro
 
 		for _, ns := range namespaces {
 			if includeDetails {
-				info, err := organization.GetNamespaceInfo(toolCtx, ctx.K8sClient, ns)
+				info, err := organization.GetNamespaceInfo(toolCtx, ctx.K8sClient, ns, ctx.SystemNamespaces)
 				if err == nil {
 					output.WriteString(fmt.Sprintf("- %s (type: %s)\n", ns, info.Type))
 					if info.ClusterID != "" {
@@ -116,7 +137,7 @@ func RegisterOrganizationTools(s *mcpserver.MCPServer, ctx *server.Context) erro
 	// organization_info tool
 	infoTool := mcp.NewTool(
 		"organization_info",
-		mcp.WithDescription("Get detailed information about a namespace and its organization context"),
+		mcp.WithDescription("Get detailed information about a namespace and its organization context, including whether it is classified as a system namespace"),
 		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace name")),
 	)
 
@@ -125,7 +146,7 @@ func RegisterOrganizationTools(s *mcpserver.MCPServer, ctx *server.Context) erro
 		namespace := args["namespace"].(string)
 
 		// Get namespace info
-		info, err := organization.GetNamespaceInfo(toolCtx, ctx.K8sClient, namespace)
+		info, err := organization.GetNamespaceInfo(toolCtx, ctx.K8sClient, namespace, ctx.SystemNamespaces)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get namespace info: %w", err)
 		}
@@ -133,6 +154,9 @@ func RegisterOrganizationTools(s *mcpserver.MCPServer, ctx *server.Context) erro
 		var output strings.Builder
 		output.WriteString(fmt.Sprintf("Namespace: %s\n", info.Name))
 		output.WriteString(fmt.Sprintf("Type: %s\n", info.Type))
+		if pattern, ok := ctx.SystemNamespaces.Match(namespace); ok && info.Type == organization.NamespaceTypeSystem {
+			output.WriteString(fmt.Sprintf("System namespace pattern: %s (configured: %s)\n", pattern, ctx.SystemNamespaces))
+		}
 
 		if info.Organization != "" {
 			output.WriteString(fmt.Sprintf("Organization: %s\n", info.Organization))
@@ -150,68 +174,209 @@ func RegisterOrganizationTools(s *mcpserver.MCPServer, ctx *server.Context) erro
 		}
 
 		// Check access
-		err = organization.ValidateNamespaceAccess(toolCtx, ctx.K8sClient, namespace)
+		checks, err := organization.ValidateNamespaceAccess(toolCtx, ctx.K8sClient, namespace)
 		if err != nil {
-			output.WriteString(fmt.Sprintf("\nAccess: No (%v)\n", err))
+			output.WriteString(fmt.Sprintf("\nAccess: unknown (%v)\n", err))
 		} else {
-			output.WriteString("\nAccess: Yes\n")
+			output.WriteString(fmt.Sprintf("\nAccess: %s\n", organization.AccessLevel(checks)))
 		}
 
 		return mcp.NewToolResultText(output.String()), nil
 	})
 
-	// organization_validate_access tool
-	validateTool := mcp.NewTool(
-		"organization_validate_access",
-		mcp.WithDescription("Validate access to a namespace or organization"),
-		mcp.WithString("namespace", mcp.Description("Namespace to validate access to")),
-		mcp.WithString("organization", mcp.Description("Organization to validate access to")),
+	// org_health_rollup tool
+	healthRollupTool := mcp.NewTool(
+		"org_health_rollup",
+		mcp.WithDescription("Roll up the release status of the apps of an organization, or of every organization, into deployed, "+
+			"failed, pending and unknown counts, with the worst offenders and their runbook and monitoring links"),
+		mcp.WithString("organization", mcp.Description("Organization to roll up (default: all organizations)")),
+		mcp.WithString("top", mcp.Description("Number of worst offenders to list (default: 10)")),
 	)
 
-	s.AddTool(validateTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.AddTool(healthRollupTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := req.Params.Arguments.(map[string]interface{})
-		namespace := getStringArg(args, "namespace")
-		orgName := getStringArg(args, "organization")
+		org := getStringArg(args, "organization")
 
-		if namespace == "" && orgName == "" {
-			return nil, fmt.Errorf("either namespace or organization must be specified")
+		top := 10
+		if value := getStringArg(args, "top"); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid top %q: must be a non-negative number", value)
+			}
+			top = n
 		}
 
-		var output strings.Builder
-
-		if namespace != "" {
-			err := organization.ValidateNamespaceAccess(toolCtx, ctx.K8sClient, namespace)
+		byOrganization := map[string][]*app.App{}
+		if org != "" {
+			apps, err := appClient.ListByOrganization(toolCtx, ctx.K8sClient, org, "")
 			if err != nil {
-				output.WriteString(fmt.Sprintf("Access to namespace '%s': DENIED\n", namespace))
-				output.WriteString(fmt.Sprintf("Reason: %v\n", err))
-			} else {
-				output.WriteString(fmt.Sprintf("Access to namespace '%s': GRANTED\n", namespace))
+				return nil, err
 			}
-		}
-
-		if orgName != "" {
-			// Check access to organization namespaces
-			namespaces, err := organization.GetNamespacesByOrganization(toolCtx, ctx.K8sClient, orgName)
+			byOrganization[org] = apps
+		} else {
+			apps, err := appClient.List(toolCtx, "", "")
 			if err != nil {
-				output.WriteString(fmt.Sprintf("\nFailed to get namespaces for organization '%s': %v\n", orgName, err))
-			} else {
-				output.WriteString(fmt.Sprintf("\nAccess to organization '%s' namespaces:\n", orgName))
-				accessible := 0
-				for _, ns := range namespaces {
-					err := organization.ValidateNamespaceAccess(toolCtx, ctx.K8sClient, ns)
-					if err == nil {
-						accessible++
-						output.WriteString(fmt.Sprintf("  - %s: GRANTED\n", ns))
-					} else {
-						output.WriteString(fmt.Sprintf("  - %s: DENIED\n", ns))
-					}
+				return nil, err
+			}
+			for _, a := range apps {
+				owner, err := organization.GetOrganizationFromNamespace(a.Namespace)
+				if err != nil {
+					owner = a.Labels[organization.OrganizationLabel]
 				}
-				output.WriteString(fmt.Sprintf("\nAccessible: %d/%d namespaces\n", accessible, len(namespaces)))
+				byOrganization[owner] = append(byOrganization[owner], a)
 			}
 		}
 
-		return mcp.NewToolResultText(output.String()), nil
+		return mcp.NewToolResultText(formatHealthRollup(byOrganization, top)), nil
 	})
 
+	registerOrganizationAccessTools(s, ctx)
+	registerOrganizationDefaultsTools(s, ctx, appClient, configClient)
+
 	return nil
 }
+
+// formatOrganizations lists Organization resources with their namespace status
+// and any drift between them and the organization namespaces
+func formatOrganizations(ctx context.Context, serverCtx *server.Context, orgClient *organization.Client, orgs []*organization.Organization, detailed bool) (*mcp.CallToolResult, error) {
+	drift, err := orgClient.DetectDrift(ctx, orgs)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := make(map[string]organization.DriftType, len(drift))
+	for _, d := range drift {
+		if d.Type != organization.DriftOrphanedNamespace {
+			problems[d.Organization] = d.Type
+		}
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Found %d organizations:\n\n", len(orgs)))
+
+	for _, org := range orgs {
+		status := "Active"
+		switch {
+		case org.Terminating:
+			status = "Terminating"
+		case problems[org.Name] == organization.DriftNamespaceNotProvisioned:
+			status = "Namespace not provisioned"
+		case problems[org.Name] == organization.DriftNamespaceMissing:
+			status = "Namespace missing"
+		}
+
+		namespace := org.Namespace
+		if namespace == "" {
+			namespace = "-"
+		}
+
+		output.WriteString(fmt.Sprintf("- %s (namespace: %s, status: %s", org.Name, namespace, status))
+		if !org.Created.IsZero() {
+			output.WriteString(fmt.Sprintf(", created: %s", org.Created.Format("2006-01-02")))
+		}
+		output.WriteString(")\n")
+
+		if detailed {
+			related, err := organization.GetNamespacesByOrganization(ctx, serverCtx.K8sClient, org.Name)
+			if err == nil && len(related) > 0 {
+				output.WriteString("  Namespaces:\n")
+				for _, ns := range related {
+					output.WriteString(fmt.Sprintf("    - %s\n", ns))
+				}
+			}
+		}
+	}
+
+	if len(drift) > 0 {
+		output.WriteString("\nDrift between Organizations and namespaces:\n")
+		for _, d := range drift {
+			switch d.Type {
+			case organization.DriftNamespaceNotProvisioned:
+				output.WriteString(fmt.Sprintf("  - Organization %s has no namespace in its status yet\n", d.Organization))
+			case organization.DriftNamespaceMissing:
+				output.WriteString(fmt.Sprintf("  - Organization %s refers to namespace %s, which does not exist\n", d.Organization, d.Namespace))
+			case organization.DriftOrphanedNamespace:
+				output.WriteString(fmt.Sprintf("  - Namespace %s has no Organization resource\n", d.Namespace))
+			}
+		}
+	}
+
+	return mcp.NewToolResultText(output.String()), nil
+}
+
+// formatHealthRollup renders the health counts per organization, the least
+// deployed first, and the worst offenders across them. Apps outside
+// organizations are rolled up under an empty name.
+func formatHealthRollup(byOrganization map[string][]*app.App, top int) string {
+	type orgRollup struct {
+		name   string
+		rollup *app.HealthRollup
+	}
+	var rollups []orgRollup
+	var all []*app.App
+	for name, apps := range byOrganization {
+		rollups = append(rollups, orgRollup{name, app.NewHealthRollup(apps)})
+		all = append(all, apps...)
+	}
+	sort.Slice(rollups, func(i, j int) bool {
+		a, b := rollups[i].rollup.DeployedPercent(), rollups[j].rollup.DeployedPercent()
+		if a != b {
+			return a < b
+		}
+		return rollups[i].name < rollups[j].name
+	})
+
+	counts := func(r *app.HealthRollup) string {
+		return fmt.Sprintf("%d apps: %d deployed, %d failed, %d pending, %d unknown (%.0f%% deployed)", r.Total,
+			r.Counts[app.HealthDeployed], r.Counts[app.HealthFailed], r.Counts[app.HealthPending], r.Counts[app.HealthUnknown], r.DeployedPercent())
+	}
+
+	total := app.NewHealthRollup(all)
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("App health: %s\n", counts(total)))
+	if len(rollups) > 1 || (len(rollups) == 1 && rollups[0].name == "") {
+		output.WriteString("\nPer organization:\n")
+		for _, r := range rollups {
+			name := r.name
+			if name == "" {
+				name = "(no organization)"
+			}
+			output.WriteString(fmt.Sprintf("- %s: %s\n", name, counts(r.rollup)))
+		}
+	}
+
+	if len(total.Offenders) == 0 {
+		output.WriteString("\nAll apps are deployed\n")
+		return output.String()
+	}
+	offenders := total.Offenders
+	if top > 0 && len(offenders) > top {
+		offenders = offenders[:top]
+	}
+	output.WriteString(fmt.Sprintf("\nWorst offenders (%d of %d):\n", len(offenders), len(total.Offenders)))
+	for _, a := range offenders {
+		status := a.Status.Release.Status
+		if status == "" {
+			status = "no release yet"
+		}
+		line := fmt.Sprintf("- %s/%s: %s (%s), version %s", a.Namespace, a.Name, a.HealthState(), status, a.Spec.Version)
+		if a.Status.Version != "" && a.Status.Version != a.Spec.Version {
+			line += fmt.Sprintf(", %s deployed", a.Status.Version)
+		}
+		if clusterName := a.Labels[cluster.ClusterLabel]; clusterName != "" {
+			line += ", cluster " + clusterName
+		}
+		if a.Status.Release.LastDeployed != "" {
+			line += ", last deployed " + a.Status.Release.LastDeployed
+		}
+		output.WriteString(line + "\n")
+		health := a.HealthInfo()
+		if health.RunbookURL != "" {
+			output.WriteString(fmt.Sprintf("  Runbook: %s\n", health.RunbookURL))
+		}
+		if health.MonitoringURL != "" {
+			output.WriteString(fmt.Sprintf("  Monitoring: %s\n", health.MonitoringURL))
+		}
+	}
+	return output.String()
+}
@@ -0,0 +1,281 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/organization"
+)
+
+// registerOrganizationAccessTools registers the tools checking and granting
+// access to organizations
+func registerOrganizationAccessTools(s *mcpserver.MCPServer, ctx *server.Context) {
+	// organization_validate_access tool
+	validateTool := mcp.NewTool(
+		"organization_validate_access",
+		mcp.WithDescription("Check which verbs the current identity may use on apps, ConfigMaps and Secrets in a namespace or in each namespace of an organization"),
+		mcp.WithString("namespace", mcp.Description("Namespace to validate access to")),
+		mcp.WithString("organization", mcp.Description("Organization to validate access to")),
+	)
+
+	s.AddTool(validateTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		namespace := getStringArg(args, "namespace")
+		orgName := getStringArg(args, "organization")
+
+		if namespace == "" && orgName == "" {
+			return nil, fmt.Errorf("either namespace or organization must be specified")
+		}
+
+		var output strings.Builder
+
+		if namespace != "" {
+			checks, err := organization.ValidateNamespaceAccess(toolCtx, ctx.K8sClient, namespace)
+			if err != nil {
+				return nil, err
+			}
+			output.WriteString(fmt.Sprintf("Access to namespace '%s': %s\n", namespace, strings.ToUpper(organization.AccessLevel(checks))))
+			output.WriteString(formatAccessChecks(checks))
+		}
+
+		if orgName != "" {
+			// Check access to organization namespaces
+			namespaces, err := organization.GetNamespacesByOrganization(toolCtx, ctx.K8sClient, orgName)
+			if err != nil {
+				output.WriteString(fmt.Sprintf("\nFailed to get namespaces for organization '%s': %v\n", orgName, err))
+			} else {
+				output.WriteString(fmt.Sprintf("\nAccess to organization '%s' namespaces:\n", orgName))
+				full := 0
+				for _, ns := range namespaces {
+					checks, err := organization.ValidateNamespaceAccess(toolCtx, ctx.K8sClient, ns)
+					if err != nil {
+						output.WriteString(fmt.Sprintf("\n%s: UNKNOWN (%v)\n", ns, err))
+						continue
+					}
+					level := organization.AccessLevel(checks)
+					if level == organization.AccessFull {
+						full++
+					}
+					output.WriteString(fmt.Sprintf("\n%s: %s\n", ns, strings.ToUpper(level)))
+					output.WriteString(formatAccessChecks(checks))
+				}
+				output.WriteString(fmt.Sprintf("\nFull access: %d/%d namespaces\n", full, len(namespaces)))
+			}
+		}
+
+		return mcp.NewToolResultText(output.String()), nil
+	})
+
+	// organization_access_report tool
+	accessReportTool := mcp.NewTool(
+		"organization_access_report",
+		mcp.WithDescription("Summarize which verbs are allowed on apps, catalogs, clusters and secrets in each namespace of an organization, for the current identity or a named user"),
+		mcp.WithString("organization", mcp.Required(), mcp.Description("Organization to report on")),
+		mcp.WithString("user", mcp.Description("User to check access for with SubjectAccessReviews (default: the current identity)")),
+		mcp.WithString("groups", mcp.Description("Comma-separated groups of the user")),
+	)
+
+	s.AddTool(accessReportTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		orgName := args["organization"].(string)
+		user := getStringArg(args, "user")
+		groups := getStringArg(args, "groups")
+
+		var subject *organization.Subject
+		if user != "" {
+			subject = &organization.Subject{User: user}
+			for _, group := range strings.Split(groups, ",") {
+				if group = strings.TrimSpace(group); group != "" {
+					subject.Groups = append(subject.Groups, group)
+				}
+			}
+		} else if groups != "" {
+			return nil, fmt.Errorf("groups require a user")
+		}
+
+		namespaces, err := organization.GetNamespacesByOrganization(toolCtx, ctx.K8sClient, orgName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get namespaces for organization %s: %w", orgName, err)
+		}
+		if len(namespaces) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("No namespaces found for organization %s", orgName)), nil
+		}
+
+		report, err := organization.AccessReport(toolCtx, ctx.K8sClient, namespaces, subject)
+		if err != nil {
+			return nil, err
+		}
+
+		who := "the current identity"
+		if subject != nil {
+			who = "user " + subject.User
+		}
+
+		var output strings.Builder
+		output.WriteString(fmt.Sprintf("Access of %s in organization %s:\n", who, orgName))
+		for _, access := range report {
+			output.WriteString(fmt.Sprintf("\n%s:\n", access.Namespace))
+			for _, resource := range organization.AccessResources {
+				verbs := access.Allowed[resource.Name]
+				allowed := "none"
+				switch {
+				case len(verbs) == len(organization.AccessVerbs):
+					allowed = "all"
+				case len(verbs) > 0:
+					allowed = strings.Join(verbs, ", ")
+				}
+				output.WriteString(fmt.Sprintf("  %-9s %s\n", resource.Name+":", allowed))
+			}
+			if access.Incomplete {
+				output.WriteString("  (incomplete: not all authorizers could list rules)\n")
+			}
+		}
+
+		return mcp.NewToolResultText(output.String()), nil
+	})
+
+	// namespace_create tool
+	namespaceCreateTool := mcp.NewTool(
+		"namespace_create",
+		mcp.WithDescription("Create a namespace owned by an organization, e.g. as an app target namespace, with the Giant Swarm organization, owner and (for workload-* namespaces) cluster labels"),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Name of the namespace to create")),
+		mcp.WithString("organization", mcp.Required(), mcp.Description("Organization that owns the namespace")),
+		mcp.WithString("cluster", mcp.Description("Workload cluster the namespace belongs to (default for workload-* namespaces: the name without the prefix)")),
+		mcp.WithString("labels", mcp.Description("Additional labels in key=value format (comma-separated)")),
+	)
+
+	s.AddTool(namespaceCreateTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		name := args["namespace"].(string)
+		orgName := args["organization"].(string)
+
+		var extraLabels map[string]string
+		if labels := getStringArg(args, "labels"); labels != "" {
+			var err error
+			if extraLabels, _, err = parseMetadataChanges(labels, "", true); err != nil {
+				return nil, err
+			}
+		}
+
+		ns, err := organization.CreateNamespace(toolCtx, ctx.K8sClient, name, orgName, getStringArg(args, "cluster"), ctx.SystemNamespaces, extraLabels)
+		if err != nil {
+			return nil, err
+		}
+
+		return mcp.NewToolResultText(formatMetadata(fmt.Sprintf("Created namespace %s with labels:", ns.Name), ns.Labels)), nil
+	})
+
+	// organization_create_automation_sa tool
+	automationTool := mcp.NewTool(
+		"organization_create_automation_sa",
+		mcp.WithDescription("Create a service account for CI automation of an organization, allowed to manage apps, ConfigMaps and Secrets in the organization namespace and to read catalogs, and return a kubeconfig with a token for it. Calling it again for the same account rotates the token; existing service accounts, Roles and RoleBindings of the same names that this server did not create are refused."),
+		mcp.WithString("organization", mcp.Required(), mcp.Description("Organization name")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the service account, e.g. github-actions")),
+		mcp.WithString("catalog-namespaces", mcp.Description("Comma-separated namespaces of catalogs the account may read besides the organization namespace (default: default)")),
+		mcp.WithString("token-duration", mcp.Description("Requested token lifetime, e.g. 720h (default: 8760h); the API server may grant less")),
+	)
+
+	s.AddTool(automationTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+
+		catalogNamespaces := organization.DefaultCatalogNamespaces
+		if value := getStringArg(args, "catalog-namespaces"); value != "" {
+			catalogNamespaces = nil
+			for _, ns := range strings.Split(value, ",") {
+				if ns = strings.TrimSpace(ns); ns != "" {
+					catalogNamespaces = append(catalogNamespaces, ns)
+				}
+			}
+		}
+		// The namespace policy middleware only sees the organization argument
+		for _, ns := range catalogNamespaces {
+			if !ctx.NamespacePolicy.Allows(ns) {
+				return nil, fmt.Errorf("catalog namespace %s is not allowed on this server", ns)
+			}
+		}
+
+		tokenDuration := 365 * 24 * time.Hour
+		if value := getStringArg(args, "token-duration"); value != "" {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid token-duration %q: %w", value, err)
+			}
+			tokenDuration = d
+		}
+
+		// RestConfig keeps the server of the kubeconfig the server started with
+		var caData []byte
+		restConfig := ctx.K8sClient.ServerConfig()
+		if caData = restConfig.CAData; len(caData) == 0 && restConfig.CAFile != "" {
+			data, err := os.ReadFile(restConfig.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read certificate authority: %w", err)
+			}
+			caData = data
+		}
+
+		account, err := organization.CreateAutomationAccount(toolCtx, ctx.K8sClient, args["organization"].(string), args["name"].(string), catalogNamespaces, tokenDuration)
+		if err != nil {
+			return nil, err
+		}
+		kubeconfig, err := organization.AutomationKubeconfig(account, restConfig.Host, caData)
+		if err != nil {
+			return nil, err
+		}
+
+		var output strings.Builder
+		verb := "Created"
+		if !account.Created {
+			verb = "Updated existing"
+		}
+		output.WriteString(fmt.Sprintf("%s service account %s/%s with role bindings:\n", verb, account.Namespace, account.Name))
+		for _, binding := range account.RoleBindings {
+			output.WriteString(fmt.Sprintf("- %s\n", binding))
+		}
+		output.WriteString(fmt.Sprintf("\nToken expires %s (in %s).\n", account.ExpiresAt.UTC().Format(time.RFC3339), time.Until(account.ExpiresAt).Round(time.Hour)))
+		output.WriteString("The kubeconfig below contains the token; store it as a CI secret and do not share it.\n\n")
+		output.Write(kubeconfig)
+		return mcp.NewToolResultText(output.String()), nil
+	})
+}
+
+// formatAccessChecks renders the allowed and denied verbs per resource, with
+// the reasons the authorizer gave for denials
+func formatAccessChecks(checks []organization.AccessCheck) string {
+	var output strings.Builder
+	for _, requirement := range organization.AppManagementRequirements {
+		allowed := make([]string, 0)
+		denied := make([]string, 0)
+		for _, check := range checks {
+			if check.Resource != requirement.Resource.Name {
+				continue
+			}
+			if check.Allowed {
+				allowed = append(allowed, check.Verb)
+				continue
+			}
+			if check.Reason != "" {
+				denied = append(denied, fmt.Sprintf("%s: %s", check.Verb, check.Reason))
+			} else {
+				denied = append(denied, check.Verb)
+			}
+		}
+
+		line := "none"
+		if len(allowed) > 0 {
+			line = strings.Join(allowed, ", ")
+		}
+		if len(denied) > 0 {
+			line += fmt.Sprintf(" (denied: %s)", strings.Join(denied, ", "))
+		}
+		output.WriteString(fmt.Sprintf("  %-11s %s\n", requirement.Resource.Name+":", line))
+	}
+	return output.String()
+}
@@ -0,0 +1,189 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/organization"
+)
+
+// registerOrganizationDefaultsTools registers the tools managing the default
+// app configurations of organizations
+func registerOrganizationDefaultsTools(s *mcpserver.MCPServer, ctx *server.Context, appClient *app.Client, configClient *config.Client) {
+	// organization_defaults_list tool
+	defaultsListTool := mcp.NewTool(
+		"organization_defaults_list",
+		mcp.WithDescription("List the default app configurations of an organization ({app}-org-defaults ConfigMaps) and the apps that use them"),
+		mcp.WithString("organization", mcp.Required(), mcp.Description("Organization name")),
+	)
+
+	s.AddTool(defaultsListTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		orgNamespace := organization.GetOrganizationNamespace(args["organization"].(string))
+
+		defaults, err := configClient.ListOrgDefaults(toolCtx, orgNamespace)
+		if err != nil {
+			return nil, err
+		}
+		if len(defaults) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("No organization defaults in %s\n", orgNamespace)), nil
+		}
+		apps, err := appClient.List(toolCtx, orgNamespace, "")
+		if err != nil {
+			return nil, err
+		}
+
+		var output strings.Builder
+		output.WriteString(fmt.Sprintf("Organization defaults in %s:\n\n", orgNamespace))
+		for _, cfg := range defaults {
+			appName, _ := config.OrgDefaultsApp(cfg.Name)
+			var using, missing []string
+			for _, a := range apps {
+				if a.Spec.Name != appName {
+					continue
+				}
+				if a.HasExtraConfig(app.ExtraConfigKindConfigMap, orgNamespace, cfg.Name) {
+					using = append(using, a.Name)
+				} else {
+					missing = append(missing, a.Name)
+				}
+			}
+
+			output.WriteString(fmt.Sprintf("- %s (app %s)\n", cfg.Name, appName))
+			if len(using) > 0 {
+				output.WriteString(fmt.Sprintf("  Used by: %s\n", strings.Join(using, ", ")))
+			}
+			if len(missing) > 0 {
+				output.WriteString(fmt.Sprintf("  Not attached to: %s\n", strings.Join(missing, ", ")))
+			}
+		}
+
+		return mcp.NewToolResultText(output.String()), nil
+	})
+
+	// organization_defaults_set tool
+	defaultsSetTool := mcp.NewTool(
+		"organization_defaults_set",
+		mcp.WithDescription("Create or replace the default configuration of an app for an organization. New apps in the organization namespace get it attached as an extra config below the cluster and user config."),
+		mcp.WithString("organization", mcp.Required(), mcp.Description("Organization name")),
+		mcp.WithString("app", mcp.Required(), mcp.Description("App name from the catalog (e.g., nginx-ingress-controller)")),
+		mcp.WithString("values", mcp.Required(), mcp.Description("Default values YAML")),
+		mcp.WithBoolean("attach-existing", mcp.Description("Also attach the defaults to existing apps of the organization that do not use them yet (default: false)")),
+	)
+
+	s.AddTool(defaultsSetTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		orgNamespace := organization.GetOrganizationNamespace(args["organization"].(string))
+		appName := args["app"].(string)
+		values := args["values"].(string)
+
+		if _, _, err := config.ParseValues(values); err != nil {
+			return nil, fmt.Errorf("invalid values: %w", err)
+		}
+
+		created, err := configClient.SetOrgDefaults(toolCtx, orgNamespace, appName, values, k8s.UpdateOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		name := config.OrgDefaultsName(appName)
+		var output strings.Builder
+		if created {
+			output.WriteString(fmt.Sprintf("Created organization defaults %s/%s\n", orgNamespace, name))
+		} else {
+			output.WriteString(fmt.Sprintf("Updated organization defaults %s/%s\n", orgNamespace, name))
+		}
+
+		if getBoolArg(args, "attach-existing") {
+			apps, err := appClient.List(toolCtx, orgNamespace, "")
+			if err != nil {
+				return nil, err
+			}
+			for _, a := range apps {
+				if a.Spec.Name != appName || a.HasExtraConfig(app.ExtraConfigKindConfigMap, orgNamespace, name) {
+					continue
+				}
+				_, err := appClient.Update(toolCtx, a.Namespace, a.Name, k8s.UpdateOptions{}, func(current *app.App) error {
+					if !current.HasExtraConfig(app.ExtraConfigKindConfigMap, orgNamespace, name) {
+						current.Spec.ExtraConfigs = append(current.Spec.ExtraConfigs, app.ExtraConfig{
+							Kind:      app.ExtraConfigKindConfigMap,
+							Name:      name,
+							Namespace: orgNamespace,
+							Priority:  app.DefaultExtraConfigPriority,
+						})
+					}
+					return nil
+				})
+				if err != nil {
+					output.WriteString(fmt.Sprintf("  ✗ %s: %v\n", a.Name, err))
+					continue
+				}
+				output.WriteString(fmt.Sprintf("  ✓ attached to %s\n", a.Name))
+			}
+		}
+
+		return mcp.NewToolResultText(output.String()), nil
+	})
+
+	// organization_defaults_delete tool
+	defaultsDeleteTool := mcp.NewTool(
+		"organization_defaults_delete",
+		mcp.WithDescription("Delete the default configuration of an app for an organization. Refuses while apps use it unless detach is set."),
+		mcp.WithString("organization", mcp.Required(), mcp.Description("Organization name")),
+		mcp.WithString("app", mcp.Required(), mcp.Description("App name from the catalog")),
+		mcp.WithBoolean("detach", mcp.Description("Remove the defaults from the apps that use them first (default: false)")),
+	)
+
+	s.AddTool(defaultsDeleteTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		orgNamespace := organization.GetOrganizationNamespace(args["organization"].(string))
+		name := config.OrgDefaultsName(args["app"].(string))
+
+		apps, err := appClient.List(toolCtx, orgNamespace, "")
+		if err != nil {
+			return nil, err
+		}
+		var using []*app.App
+		for _, a := range apps {
+			if a.HasExtraConfig(app.ExtraConfigKindConfigMap, orgNamespace, name) {
+				using = append(using, a)
+			}
+		}
+
+		var output strings.Builder
+		if len(using) > 0 {
+			if !getBoolArg(args, "detach") {
+				names := make([]string, 0, len(using))
+				for _, a := range using {
+					names = append(names, a.Name)
+				}
+				return nil, fmt.Errorf("organization defaults %s/%s are used by %s; set detach to remove them from these apps", orgNamespace, name, strings.Join(names, ", "))
+			}
+			for _, a := range using {
+				_, err := appClient.Update(toolCtx, a.Namespace, a.Name, k8s.UpdateOptions{}, func(current *app.App) error {
+					current.RemoveExtraConfig(app.ExtraConfigKindConfigMap, orgNamespace, name)
+					return nil
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to detach organization defaults from %s: %w", a.Name, err)
+				}
+				output.WriteString(fmt.Sprintf("Detached from app %s\n", a.Name))
+			}
+		}
+
+		if err := configClient.DeleteConfigMap(toolCtx, orgNamespace, name); err != nil {
+			return nil, err
+		}
+		output.WriteString(fmt.Sprintf("Deleted organization defaults %s/%s\n", orgNamespace, name))
+
+		return mcp.NewToolResultText(output.String()), nil
+	})
+}
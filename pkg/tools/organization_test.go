@@ -0,0 +1,39 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	gstesting "github.com/giantswarm/mcp-giantswarm-apps/pkg/testing"
+)
+
+func TestCreateAutomationSANamespacePolicy(t *testing.T) {
+	ctx := gstesting.NewServerContext(gstesting.OrganizationNamespace("acme"))
+	policy, err := server.NewNamespacePolicy([]string{"org-*"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx.NamespacePolicy = policy
+	s := mcpserver.NewMCPServer("test", "0.0.0")
+	if err := RegisterOrganizationTools(s, ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// The default catalog namespace and explicit ones are checked
+	for _, catalogNamespaces := range []string{"", "org-acme, kube-system"} {
+		_, err := gstesting.CallTool(context.Background(), s, "organization_create_automation_sa", map[string]interface{}{
+			"organization": "acme", "name": "ci", "catalog-namespaces": catalogNamespaces,
+		})
+		if err == nil || !strings.Contains(err.Error(), "is not allowed") {
+			t.Errorf("catalog-namespaces %q: error = %v, want not allowed", catalogNamespaces, err)
+		}
+	}
+	if _, err := ctx.K8sClient.CoreV1().ServiceAccounts("org-acme").Get(context.Background(), "ci", metav1.GetOptions{}); err == nil {
+		t.Error("service account was created")
+	}
+}
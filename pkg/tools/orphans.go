@@ -0,0 +1,157 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+)
+
+// appNameLabel marks the ConfigMaps and Secrets created for an app, e.g. by
+// secret_create
+const appNameLabel = "app.kubernetes.io/name"
+
+// helmManagedValue is the managed-by label value of objects deployed by Helm
+const helmManagedValue = "Helm"
+
+// userConfigSuffixes are the name suffixes of user configs created by
+// kubectl-gs and the GitOps template
+var userConfigSuffixes = []string{"-userconfig", "-user-values", "-user-config"}
+
+// configKey identifies a ConfigMap or Secret, e.g. configmap/org-acme/nginx-userconfig
+func configKey(configType config.ConfigType, namespace, name string) string {
+	return string(configType) + "/" + namespace + "/" + name
+}
+
+// orphanedConfig is a ConfigMap or Secret made for an app that no App uses
+type orphanedConfig struct {
+	Config *config.Config
+	// App is the app the object was made for
+	App string
+	// Reason explains why the object is considered orphaned
+	Reason string
+}
+
+// appConfigReferences returns the keys of the ConfigMaps and Secrets the apps
+// reference as config, user config, extra config or kubeconfig
+func appConfigReferences(apps []*app.App) map[string]bool {
+	refs := make(map[string]bool)
+	add := func(configType config.ConfigType, namespace, name string, a *app.App) {
+		if name != "" {
+			refs[configKey(configType, refNamespace(namespace, a), name)] = true
+		}
+	}
+
+	for _, a := range apps {
+		for _, cfg := range []*app.AppConfig{a.Spec.Config, a.Spec.UserConfig} {
+			if cfg == nil {
+				continue
+			}
+			if cfg.ConfigMap != nil {
+				add(config.ConfigTypeConfigMap, cfg.ConfigMap.Namespace, cfg.ConfigMap.Name, a)
+			}
+			if cfg.Secret != nil {
+				add(config.ConfigTypeSecret, cfg.Secret.Namespace, cfg.Secret.Name, a)
+			}
+		}
+		for _, ec := range a.Spec.ExtraConfigs {
+			configType := config.ConfigTypeConfigMap
+			if ec.Kind == app.ExtraConfigKindSecret {
+				configType = config.ConfigTypeSecret
+			}
+			add(configType, ec.Namespace, ec.Name, a)
+		}
+		if secret := a.Spec.KubeConfig.Secret; secret != nil {
+			add(config.ConfigTypeSecret, secret.Namespace, secret.Name, a)
+		}
+	}
+	return refs
+}
+
+// configOwnerApp returns the app a ConfigMap or Secret was made for: the app
+// of its app.kubernetes.io/name label, or of its user config name. Objects
+// deployed by Helm, organization defaults and config history snapshots are
+// not made for a single app.
+func configOwnerApp(cfg *config.Config) (string, string, bool) {
+	if cfg.Labels[helmManagedLabel] == helmManagedValue ||
+		cfg.Labels[config.HistoryOfLabel] != "" || cfg.Labels[config.OrgDefaultsLabel] != "" {
+		return "", "", false
+	}
+	if _, ok := config.OrgDefaultsApp(cfg.Name); ok {
+		return "", "", false
+	}
+
+	if name := cfg.Labels[appNameLabel]; name != "" {
+		return name, "labelled for app " + name, true
+	}
+	for _, suffix := range userConfigSuffixes {
+		if name := strings.TrimSuffix(cfg.Name, suffix); name != cfg.Name && name != "" {
+			return name, "named as user config of app " + name, true
+		}
+	}
+	return "", "", false
+}
+
+// findOrphanedConfigs returns the configs made for an app that no app
+// references, sorted by namespace, type and name. apps must include every
+// app that may reference the configs.
+func findOrphanedConfigs(configs []*config.Config, apps []*app.App) []orphanedConfig {
+	refs := appConfigReferences(apps)
+	existing := make(map[string]bool)
+	for _, a := range apps {
+		existing[a.Namespace+"/"+a.Name] = true
+		if a.Spec.Name != "" {
+			existing[a.Namespace+"/"+a.Spec.Name] = true
+		}
+	}
+
+	orphans := make([]orphanedConfig, 0)
+	for _, cfg := range configs {
+		if refs[configKey(cfg.Type, cfg.Namespace, cfg.Name)] {
+			continue
+		}
+		appName, origin, ok := configOwnerApp(cfg)
+		if !ok {
+			continue
+		}
+		reason := origin + ", which no longer exists"
+		if existing[cfg.Namespace+"/"+appName] {
+			reason = origin + ", which does not reference it"
+		}
+		orphans = append(orphans, orphanedConfig{Config: cfg, App: appName, Reason: reason})
+	}
+
+	sort.Slice(orphans, func(i, j int) bool {
+		a, b := orphans[i].Config, orphans[j].Config
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return configKey(a.Type, a.Namespace, a.Name) < configKey(b.Type, b.Namespace, b.Name)
+	})
+	return orphans
+}
+
+// formatOrphans renders the orphaned configs found in namespaces
+func formatOrphans(orphans []orphanedConfig, namespaces []string) string {
+	var output strings.Builder
+	if len(orphans) == 0 {
+		output.WriteString(fmt.Sprintf("No orphaned ConfigMaps or Secrets in %d namespace(s)\n", len(namespaces)))
+		return output.String()
+	}
+
+	output.WriteString(fmt.Sprintf("Found %d orphaned ConfigMaps and Secrets in %d namespace(s):\n", len(orphans), len(namespaces)))
+	namespace := ""
+	for _, orphan := range orphans {
+		cfg := orphan.Config
+		if cfg.Namespace != namespace {
+			namespace = cfg.Namespace
+			output.WriteString(fmt.Sprintf("\n%s:\n", namespace))
+		}
+		output.WriteString(fmt.Sprintf("- %s/%s (%d keys): %s\n", cfg.Type, cfg.Name, len(cfg.Data), orphan.Reason))
+	}
+	output.WriteString("\nDelete them by calling again with the namespace and delete: a comma-separated list of <type>/<name>, e.g. " +
+		fmt.Sprintf("%s/%s\n", orphans[0].Config.Type, orphans[0].Config.Name))
+	return output.String()
+}
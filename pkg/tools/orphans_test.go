@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+)
+
+func TestFindOrphanedConfigs(t *testing.T) {
+	apps := []*app.App{
+		{
+			Name:      "nginx",
+			Namespace: "org-acme",
+			Spec: app.AppSpec{
+				Name:       "nginx-ingress-controller",
+				UserConfig: &app.AppConfig{ConfigMap: &app.ConfigMapReference{Name: "nginx-userconfig"}},
+				ExtraConfigs: []app.ExtraConfig{
+					{Kind: app.ExtraConfigKindSecret, Name: "shared-secrets", Namespace: "org-acme"},
+				},
+			},
+		},
+		{Name: "kyverno", Namespace: "org-acme"},
+	}
+	configs := []*config.Config{
+		{Name: "nginx-userconfig", Namespace: "org-acme", Type: config.ConfigTypeConfigMap},
+		{Name: "shared-secrets", Namespace: "org-acme", Type: config.ConfigTypeSecret, Labels: map[string]string{appNameLabel: "nginx"}},
+		{Name: "old-app-userconfig", Namespace: "org-acme", Type: config.ConfigTypeConfigMap},
+		{Name: "kyverno-values", Namespace: "org-acme", Type: config.ConfigTypeSecret, Labels: map[string]string{appNameLabel: "kyverno"}},
+		{Name: "chart-config", Namespace: "org-acme", Type: config.ConfigTypeConfigMap, Labels: map[string]string{appNameLabel: "chart", helmManagedLabel: "Helm"}},
+		{Name: "nginx-org-defaults", Namespace: "org-acme", Type: config.ConfigTypeConfigMap, Labels: map[string]string{config.OrgDefaultsLabel: "nginx"}},
+		{Name: "kube-root-ca.crt", Namespace: "org-acme", Type: config.ConfigTypeConfigMap},
+		{Name: "nginx-userconfig", Namespace: "org-globex", Type: config.ConfigTypeConfigMap},
+	}
+
+	orphans := findOrphanedConfigs(configs, apps)
+	var got []string
+	for _, orphan := range orphans {
+		got = append(got, configKey(orphan.Config.Type, orphan.Config.Namespace, orphan.Config.Name)+": "+orphan.Reason)
+	}
+	want := []string{
+		"configmap/org-acme/old-app-userconfig: named as user config of app old-app, which no longer exists",
+		"secret/org-acme/kyverno-values: labelled for app kyverno, which does not reference it",
+		"configmap/org-globex/nginx-userconfig: named as user config of app nginx, which no longer exists",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("findOrphanedConfigs() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	output := formatOrphans(orphans, []string{"org-acme", "org-globex"})
+	if !strings.Contains(output, "Found 3 orphaned") || !strings.Contains(output, "\norg-globex:\n") {
+		t.Errorf("formatOrphans() = %s", output)
+	}
+}
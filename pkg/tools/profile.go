@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+
+	mcpserver "github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// ProfileAll registers all tools of the enabled tool groups
+	ProfileAll = "all"
+
+	// ProfileReadOnly registers only the tools that don't change resources,
+	// e.g. for a support bot
+	ProfileReadOnly = "read-only"
+)
+
+// Profiles are the valid tool profiles
+var Profiles = []string{ProfileAll, ProfileReadOnly}
+
+// readOnlyTools are the tools of the read-only profile: they only read from
+// the management and workload clusters. config_orphans is left out since it
+// can delete the orphans it finds.
+var readOnlyTools = map[string]bool{
+	"alerts_list":                  true,
+	"app_capacity_check":           true,
+	"app_cost":                     true,
+	"app_dependencies":             true,
+	"app_drift":                    true,
+	"app_get":                      true,
+	"app_list":                     true,
+	"app_matrix":                   true,
+	"app_scaffold":                 true,
+	"app_vulnerabilities":          true,
+	"appcatalogentry_get":          true,
+	"appcatalogentry_list":         true,
+	"appcatalogentry_readme":       true,
+	"appcatalogentry_search":       true,
+	"appcatalogentry_versions":     true,
+	"backstage_export":             true,
+	"catalog_get":                  true,
+	"catalog_list":                 true,
+	"cluster_apps":                 true,
+	"cluster_get":                  true,
+	"cluster_health":               true,
+	"cluster_healthchecks":         true,
+	"cluster_infrastructure":       true,
+	"cluster_list":                 true,
+	"cluster_machines":             true,
+	"cluster_metrics":              true,
+	"cluster_nodes":                true,
+	"cluster_ping":                 true,
+	"cluster_upgrade_plan":         true,
+	"cluster_values_get":           true,
+	"config_diff":                  true,
+	"config_export":                true,
+	"config_get":                   true,
+	"config_history":               true,
+	"config_lint":                  true,
+	"config_merge":                 true,
+	"config_schema":                true,
+	"config_search":                true,
+	"config_validate":              true,
+	"flux_list":                    true,
+	"gitops_export":                true,
+	"health":                       true,
+	"iac_export":                   true,
+	"kubeconfigs_expiring":         true,
+	"kubernetes_contexts":          true,
+	"org_health_rollup":            true,
+	"organization_access_report":   true,
+	"organization_defaults_list":   true,
+	"organization_info":            true,
+	"organization_list":            true,
+	"organization_namespaces":      true,
+	"organization_validate_access": true,
+	"server_info":                  true,
+	"session_set_defaults":         true,
+}
+
+// ApplyProfile removes the tools a tool profile leaves out from s. It must be
+// called after all tools are registered.
+func ApplyProfile(s *mcpserver.MCPServer, profile string) error {
+	switch profile {
+	case "", ProfileAll:
+		return nil
+	case ProfileReadOnly:
+		var removed []string
+		for name := range s.ListTools() {
+			if !readOnlyTools[name] {
+				removed = append(removed, name)
+			}
+		}
+		sort.Strings(removed)
+		s.DeleteTools(removed...)
+		return nil
+	default:
+		return fmt.Errorf("unknown tool profile %q (valid: %s, %s)", profile, ProfileAll, ProfileReadOnly)
+	}
+}
@@ -0,0 +1,140 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+)
+
+// promotedApp returns the App that app_promote creates from source. Names,
+// label values and the kubeconfig reference are renamed; references in the
+// source app's namespace move to namespace when it is set. The version is
+// pinned to the one the source runs. The spec.config resources, which Giant
+// Swarm manages per cluster, are referenced under their renamed names; user
+// config references are set from the retargeted config bundle.
+func promotedApp(source *app.App, namespace string, renames []config.Rename) *app.App {
+	targetNamespace := func(ns string) string {
+		if namespace != "" && (ns == "" || ns == source.Namespace) {
+			return namespace
+		}
+		return config.ApplyRenames(ns, renames)
+	}
+
+	promoted := &app.App{
+		Name:      config.ApplyRenames(source.Name, renames),
+		Namespace: targetNamespace(source.Namespace),
+		Labels:    make(map[string]string, len(source.Labels)),
+		Spec: app.AppSpec{
+			Catalog:          source.Spec.Catalog,
+			CatalogNamespace: source.Spec.CatalogNamespace,
+			Name:             source.Spec.Name,
+			Namespace:        source.Spec.Namespace,
+			Version:          deployedVersion(source),
+			KubeConfig: app.KubeConfig{
+				InCluster: source.Spec.KubeConfig.InCluster,
+				Context:   config.ApplyRenames(source.Spec.KubeConfig.Context, renames),
+			},
+		},
+	}
+	for k, v := range source.Labels {
+		promoted.Labels[k] = config.ApplyRenames(v, renames)
+	}
+	if secret := source.Spec.KubeConfig.Secret; secret != nil {
+		promoted.Spec.KubeConfig.Secret = &app.SecretReference{
+			Name:      config.ApplyRenames(secret.Name, renames),
+			Namespace: targetNamespace(secret.Namespace),
+		}
+	}
+
+	if cfg := source.Spec.Config; cfg != nil {
+		promoted.Spec.Config = &app.AppConfig{}
+		if cm := cfg.ConfigMap; cm != nil {
+			promoted.Spec.Config.ConfigMap = &app.ConfigMapReference{Name: config.ApplyRenames(cm.Name, renames), Namespace: targetNamespace(cm.Namespace)}
+		}
+		if secret := cfg.Secret; secret != nil {
+			promoted.Spec.Config.Secret = &app.SecretReference{Name: config.ApplyRenames(secret.Name, renames), Namespace: targetNamespace(secret.Namespace)}
+		}
+	}
+
+	return promoted
+}
+
+// appSpecDiff lists the differences between the spec of an existing app and
+// the one replacing it
+func appSpecDiff(existing, promoted *app.App) []string {
+	ref := func(config *app.AppConfig) string {
+		if config == nil {
+			return "none"
+		}
+		var parts []string
+		if config.ConfigMap != nil {
+			parts = append(parts, fmt.Sprintf("configmap %s/%s", config.ConfigMap.Namespace, config.ConfigMap.Name))
+		}
+		if config.Secret != nil {
+			parts = append(parts, fmt.Sprintf("secret %s/%s", config.Secret.Namespace, config.Secret.Name))
+		}
+		if len(parts) == 0 {
+			return "none"
+		}
+		return strings.Join(parts, ", ")
+	}
+	kubeconfig := func(a *app.App) string {
+		if a.Spec.KubeConfig.InCluster {
+			return "in-cluster"
+		}
+		if a.Spec.KubeConfig.Secret == nil {
+			return "none"
+		}
+		return fmt.Sprintf("%s/%s", a.Spec.KubeConfig.Secret.Namespace, a.Spec.KubeConfig.Secret.Name)
+	}
+
+	fields := []struct {
+		name     string
+		old, new string
+	}{
+		{"version", existing.Spec.Version, promoted.Spec.Version},
+		{"catalog", existing.Spec.Catalog, promoted.Spec.Catalog},
+		{"chart", existing.Spec.Name, promoted.Spec.Name},
+		{"target namespace", existing.Spec.Namespace, promoted.Spec.Namespace},
+		{"kubeconfig", kubeconfig(existing), kubeconfig(promoted)},
+		{"config", ref(existing.Spec.Config), ref(promoted.Spec.Config)},
+		{"user config", ref(existing.Spec.UserConfig), ref(promoted.Spec.UserConfig)},
+	}
+
+	var diff []string
+	for _, f := range fields {
+		if f.old != f.new {
+			diff = append(diff, fmt.Sprintf("%s: %s -> %s", f.name, f.old, f.new))
+		}
+	}
+	return diff
+}
+
+// configDataDiff lists the flattened keys that differ between an existing
+// config and the one replacing it. Secret values are not shown.
+func configDataDiff(existing, promoted *config.Config) []string {
+	diff := existing.Flatten().Diff(promoted.Flatten())
+	secret := promoted.IsSecret()
+	show := func(v string) string {
+		if secret {
+			return "(hidden)"
+		}
+		return v
+	}
+
+	var lines []string
+	for k, v := range diff.Added {
+		lines = append(lines, fmt.Sprintf("+ %s: %s", k, show(v)))
+	}
+	for k, e := range diff.Modified {
+		lines = append(lines, fmt.Sprintf("~ %s: %s -> %s", k, show(e.Old), show(e.New)))
+	}
+	for k := range diff.Removed {
+		lines = append(lines, fmt.Sprintf("- %s", k))
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i][2:] < lines[j][2:] })
+	return lines
+}
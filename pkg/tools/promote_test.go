@@ -0,0 +1,118 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+	gstesting "github.com/giantswarm/mcp-giantswarm-apps/pkg/testing"
+)
+
+func TestPromotedApp(t *testing.T) {
+	source := &app.App{
+		Name:      "staging-ingress",
+		Namespace: "org-acme",
+		Labels:    map[string]string{"giantswarm.io/cluster": "staging"},
+		Spec: app.AppSpec{
+			Catalog:   "giantswarm",
+			Name:      "ingress-nginx",
+			Namespace: "kube-system",
+			Version:   "3.1.0",
+			KubeConfig: app.KubeConfig{
+				Context: "staging-admin@staging",
+				Secret:  &app.SecretReference{Name: "staging-kubeconfig", Namespace: "org-acme"},
+			},
+			Config: &app.AppConfig{ConfigMap: &app.ConfigMapReference{Name: "staging-cluster-values", Namespace: "org-acme"}},
+		},
+		// The upgrade to 3.1.0 has not been rolled out yet
+		Status: app.AppStatus{Version: "3.0.0"},
+	}
+
+	promoted := promotedApp(source, "", []config.Rename{{Old: "staging", New: "prod"}})
+	if promoted.Name != "prod-ingress" || promoted.Namespace != "org-acme" {
+		t.Errorf("promoted app is %s/%s", promoted.Namespace, promoted.Name)
+	}
+	if promoted.Spec.Version != "3.0.0" {
+		t.Errorf("version = %s, want the deployed 3.0.0", promoted.Spec.Version)
+	}
+	if promoted.Labels["giantswarm.io/cluster"] != "prod" || promoted.Spec.KubeConfig.Secret.Name != "prod-kubeconfig" ||
+		promoted.Spec.KubeConfig.Context != "prod-admin@prod" {
+		t.Errorf("cluster references were not renamed: %+v", promoted)
+	}
+	if promoted.Spec.Config.ConfigMap.Name != "prod-cluster-values" {
+		t.Errorf("config = %+v", promoted.Spec.Config.ConfigMap)
+	}
+
+	moved := promotedApp(source, "org-prod", nil)
+	if moved.Namespace != "org-prod" || moved.Spec.KubeConfig.Secret.Namespace != "org-prod" {
+		t.Errorf("target namespace was not applied: %+v", moved)
+	}
+
+	existing := promotedApp(source, "", []config.Rename{{Old: "staging", New: "prod"}})
+	existing.Spec.Version = "2.9.0"
+	diff := appSpecDiff(existing, promoted)
+	if len(diff) != 1 || diff[0] != "version: 2.9.0 -> 3.0.0" {
+		t.Errorf("appSpecDiff = %v", diff)
+	}
+}
+
+func TestConfigDataDiffHidesSecrets(t *testing.T) {
+	current := &config.Config{Type: config.ConfigTypeSecret, Data: map[string]string{"values": "password: old\n"}}
+	promoted := &config.Config{Type: config.ConfigTypeSecret, Data: map[string]string{"values": "password: new\ntoken: abc\n"}}
+
+	diff := strings.Join(configDataDiff(current, promoted), "\n")
+	if strings.Contains(diff, "old") || strings.Contains(diff, "abc") {
+		t.Errorf("secret values shown: %s", diff)
+	}
+	if !strings.Contains(diff, "~ values/password") || !strings.Contains(diff, "+ values/token") {
+		t.Errorf("configDataDiff = %s", diff)
+	}
+}
+
+func TestPromoteNamespacePolicy(t *testing.T) {
+	ctx := gstesting.NewServerContext(
+		gstesting.App(&app.App{
+			Name:      "staging-ingress",
+			Namespace: "org-acme",
+			Spec: app.AppSpec{
+				Catalog:    "giantswarm",
+				Name:       "ingress-nginx",
+				Namespace:  "kube-system",
+				Version:    "3.1.0",
+				KubeConfig: app.KubeConfig{InCluster: true},
+				UserConfig: &app.AppConfig{ConfigMap: &app.ConfigMapReference{Name: "ingress-values", Namespace: "staging-values"}},
+			},
+		}),
+		gstesting.ConfigMap("staging-values", "ingress-values", "replicas: 2\n"),
+	)
+	policy, err := server.NewNamespacePolicy([]string{"org-*", "staging-*"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx.NamespacePolicy = policy
+	s := mcpserver.NewMCPServer("test", "0.0.0")
+	if err := RegisterAppTools(s, ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// The app stays in org-acme, but the rename moves its values to prod-values
+	_, err = gstesting.CallTool(context.Background(), s, "app_promote", map[string]interface{}{
+		"name": "staging-ingress", "namespace": "org-acme", "rename": "staging=prod",
+	})
+	if err == nil || !strings.Contains(err.Error(), "namespace prod-values") {
+		t.Fatalf("app_promote error = %v, want prod-values not allowed", err)
+	}
+	if _, err := app.NewClient(ctx.DynamicClient).Get(context.Background(), "org-acme", "prod-ingress"); !apierrors.IsNotFound(err) {
+		t.Errorf("promoted app was created: %v", err)
+	}
+	if _, err := ctx.K8sClient.CoreV1().ConfigMaps("prod-values").Get(context.Background(), "ingress-values", metav1.GetOptions{}); err == nil {
+		t.Error("values were copied to the denied namespace")
+	}
+}
@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"bytes"
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/app"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/cluster"
+)
+
+// scaffoldOptions describes the manifests generated by app_scaffold
+type scaffoldOptions struct {
+	Name            string
+	Namespace       string
+	Catalog         string
+	App             string
+	Version         string
+	TargetNamespace string
+	Cluster         string
+	Values          map[string]interface{}
+	WithSecret      bool
+}
+
+// scaffoldManifests renders an App together with its user values ConfigMap and,
+// optionally, an empty user secret as a multi-document YAML
+func scaffoldManifests(opts scaffoldOptions) (string, error) {
+	configMapName := opts.Name + "-user-values"
+	secretName := opts.Name + "-user-secrets"
+
+	scaffolded := &app.App{
+		Name:      opts.Name,
+		Namespace: opts.Namespace,
+		Spec: app.AppSpec{
+			Catalog:   opts.Catalog,
+			Name:      opts.App,
+			Namespace: opts.TargetNamespace,
+			Version:   opts.Version,
+			KubeConfig: app.KubeConfig{
+				InCluster: opts.Cluster == "",
+			},
+			UserConfig: &app.AppConfig{
+				ConfigMap: &app.ConfigMapReference{
+					Name:      configMapName,
+					Namespace: opts.Namespace,
+				},
+			},
+		},
+	}
+
+	// Apps of workload clusters use the cluster's kubeconfig secret, which
+	// CAPI creates next to the cluster
+	if opts.Cluster != "" {
+		scaffolded.Labels = map[string]string{
+			cluster.ClusterLabel:                 opts.Cluster,
+			"app-operator.giantswarm.io/version": "0.0.0",
+		}
+		scaffolded.Spec.KubeConfig.Context = fmt.Sprintf("%s-admin@%s", opts.Cluster, opts.Cluster)
+		scaffolded.Spec.KubeConfig.Secret = &app.SecretReference{
+			Name:      opts.Cluster + "-kubeconfig",
+			Namespace: opts.Namespace,
+		}
+	}
+
+	if opts.WithSecret {
+		scaffolded.Spec.UserConfig.Secret = &app.SecretReference{
+			Name:      secretName,
+			Namespace: opts.Namespace,
+		}
+	}
+
+	values, err := yaml.Marshal(opts.Values)
+	if err != nil {
+		return "", fmt.Errorf("failed to render values: %w", err)
+	}
+
+	// Plain maps keep server-populated fields like creationTimestamp out of the output
+	documents := []map[string]interface{}{
+		scaffolded.ToUnstructured().Object,
+		{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": configMapName, "namespace": opts.Namespace},
+			"data":       map[string]interface{}{"values": string(values)},
+		},
+	}
+	if opts.WithSecret {
+		documents = append(documents, map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata":   map[string]interface{}{"name": secretName, "namespace": opts.Namespace},
+			"type":       "Opaque",
+			"stringData": map[string]interface{}{"values": "# Sensitive values, e.g. credentials\n"},
+		})
+	}
+
+	var out bytes.Buffer
+	for i, doc := range documents {
+		data, err := yaml.Marshal(doc)
+		if err != nil {
+			return "", fmt.Errorf("failed to render manifest: %w", err)
+		}
+		if i > 0 {
+			out.WriteString("---\n")
+		}
+		out.Write(data)
+	}
+
+	return out.String(), nil
+}
@@ -0,0 +1,58 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+func TestScaffoldManifests(t *testing.T) {
+	out, err := scaffoldManifests(scaffoldOptions{
+		Name:            "prod-ingress",
+		Namespace:       "org-acme",
+		Catalog:         "giantswarm",
+		App:             "ingress",
+		Version:         "1.2.3",
+		TargetNamespace: "kube-system",
+		Cluster:         "prod",
+		Values:          map[string]interface{}{"replicas": 2},
+		WithSecret:      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs := strings.Split(out, "---\n")
+	if len(docs) != 3 {
+		t.Fatalf("got %d documents, want 3:\n%s", len(docs), out)
+	}
+
+	var appDoc map[string]interface{}
+	if err := yaml.Unmarshal([]byte(docs[0]), &appDoc); err != nil {
+		t.Fatal(err)
+	}
+	spec := appDoc["spec"].(map[string]interface{})
+	kubeConfig := spec["kubeConfig"].(map[string]interface{})
+	if kubeConfig["inCluster"] != false {
+		t.Errorf("inCluster = %v, want false", kubeConfig["inCluster"])
+	}
+	if secret := kubeConfig["secret"].(map[string]interface{}); secret["name"] != "prod-kubeconfig" {
+		t.Errorf("kubeconfig secret = %v, want prod-kubeconfig", secret["name"])
+	}
+	userConfig := spec["userConfig"].(map[string]interface{})
+	if userConfig["configMap"].(map[string]interface{})["name"] != "prod-ingress-user-values" {
+		t.Errorf("userConfig configMap = %v", userConfig["configMap"])
+	}
+	if userConfig["secret"].(map[string]interface{})["name"] != "prod-ingress-user-secrets" {
+		t.Errorf("userConfig secret = %v", userConfig["secret"])
+	}
+
+	var configMap map[string]interface{}
+	if err := yaml.Unmarshal([]byte(docs[1]), &configMap); err != nil {
+		t.Fatal(err)
+	}
+	if values := configMap["data"].(map[string]interface{})["values"]; values != "replicas: 2\n" {
+		t.Errorf("values = %q, want %q", values, "replicas: 2\n")
+	}
+}
@@ -0,0 +1,270 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/k8s"
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/config"
+)
+
+// registerSecretTools registers the tools creating and updating Secrets
+func registerSecretTools(s *mcpserver.MCPServer, ctx *server.Context, client *config.Client, history *config.History) {
+	// secret_create tool
+	createSecretTool := mcp.NewTool(
+		"secret_create",
+		mcp.WithDescription("Create a new secret for an app, optionally with generated passwords, a TLS key pair or docker registry credentials"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the secret")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace")),
+		mcp.WithString("data", mcp.Description("Secret data in key=value format (comma-separated)")),
+		mcp.WithString("app", mcp.Description("App name to associate with the secret")),
+		mcp.WithString("labels", mcp.Description("Additional labels in key=value format (comma-separated)")),
+		mcp.WithString("type", mcp.Description("Secret type: opaque, tls or docker-registry (default: opaque, or tls with from-tls)")),
+		mcp.WithString("generate-password", mcp.Description("Keys to fill with random passwords in key=LENGTH format (comma-separated)")),
+		mcp.WithString("from-tls", mcp.Description("Certificate and key as cert,key; each inline PEM, or a PEM file path when the server runs over stdio")),
+		mcp.WithString("docker-server", mcp.Description("Registry server for docker-registry secrets")),
+		mcp.WithString("docker-username", mcp.Description("Registry username for docker-registry secrets")),
+		mcp.WithString("docker-password", mcp.Description("Registry password for docker-registry secrets")),
+		mcp.WithString("docker-email", mcp.Description("Registry email for docker-registry secrets")),
+	)
+
+	s.AddTool(createSecretTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		name := args["name"].(string)
+		namespace := args["namespace"].(string)
+		dataStr := getStringArg(args, "data")
+		appName := getStringArg(args, "app")
+		labelsStr := getStringArg(args, "labels")
+		fromTLS := getStringArg(args, "from-tls")
+
+		// Parse data
+		data := make(map[string]string)
+		if dataStr != "" {
+			for _, kv := range strings.Split(dataStr, ",") {
+				parts := strings.SplitN(kv, "=", 2)
+				if len(parts) != 2 {
+					return nil, fmt.Errorf("invalid data format: %s (expected key=value)", kv)
+				}
+				data[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+			}
+		}
+
+		secretType, err := parseSecretType(getStringArg(args, "type"), fromTLS != "")
+		if err != nil {
+			return nil, err
+		}
+
+		// Generate data
+		var generated []string
+		switch secretType {
+		case corev1.SecretTypeTLS:
+			if fromTLS == "" {
+				return nil, fmt.Errorf("tls secrets require from-tls")
+			}
+			tlsData, err := readTLSKeyPair(fromTLS, ctx.LocalFiles)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range tlsData {
+				data[k] = v
+			}
+		case corev1.SecretTypeDockerConfigJson:
+			dockerData, err := config.DockerConfigSecretData(config.DockerRegistry{
+				Server:   getStringArg(args, "docker-server"),
+				Username: getStringArg(args, "docker-username"),
+				Password: getStringArg(args, "docker-password"),
+				Email:    getStringArg(args, "docker-email"),
+			})
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range dockerData {
+				data[k] = v
+			}
+		}
+
+		if passwords := getStringArg(args, "generate-password"); passwords != "" {
+			for _, kv := range strings.Split(passwords, ",") {
+				parts := strings.SplitN(kv, "=", 2)
+				if len(parts) != 2 {
+					return nil, fmt.Errorf("invalid generate-password format: %s (expected key=LENGTH)", kv)
+				}
+				key := strings.TrimSpace(parts[0])
+				length, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+				if err != nil {
+					return nil, fmt.Errorf("invalid password length for %s: %s", key, parts[1])
+				}
+				if _, exists := data[key]; exists {
+					return nil, fmt.Errorf("key %s is both given and generated", key)
+				}
+				if data[key], err = config.GeneratePassword(length); err != nil {
+					return nil, err
+				}
+				generated = append(generated, key)
+			}
+		}
+
+		if len(data) == 0 {
+			return nil, fmt.Errorf("secret has no data: specify data, generate-password, from-tls or docker-registry credentials")
+		}
+
+		// Parse labels
+		labels := make(map[string]string)
+		if appName != "" {
+			labels["app.kubernetes.io/name"] = appName
+		}
+		if labelsStr != "" {
+			for _, kv := range strings.Split(labelsStr, ",") {
+				parts := strings.SplitN(kv, "=", 2)
+				if len(parts) != 2 {
+					return nil, fmt.Errorf("invalid label format: %s (expected key=value)", kv)
+				}
+				labels[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+			}
+		}
+
+		// Create secret
+		secret := &config.Config{
+			Name:       name,
+			Namespace:  namespace,
+			Type:       config.ConfigTypeSecret,
+			Data:       data,
+			Labels:     labels,
+			SecretType: secretType,
+		}
+
+		err = client.Create(toolCtx, secret)
+		if err != nil {
+			return nil, err
+		}
+
+		message := fmt.Sprintf("Successfully created %s secret %s/%s with %d keys", secretType, namespace, name, len(data))
+		if len(generated) > 0 {
+			message += fmt.Sprintf("\nGenerated passwords for: %s (read them with config_get type=secret decode=true)", strings.Join(generated, ", "))
+		}
+
+		return mcp.NewToolResultText(message), nil
+	})
+
+	// secret_update tool
+	updateSecretTool := mcp.NewTool(
+		"secret_update",
+		mcp.WithDescription("Update an existing secret"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the secret")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace")),
+		mcp.WithString("key", mcp.Description("Key to update (if not specified, replaces all data)")),
+		mcp.WithString("value", mcp.Description("Value for the key")),
+		mcp.WithString("data", mcp.Description("Complete data in key=value format (comma-separated)")),
+		mcp.WithBoolean("merge", mcp.Description("Merge with existing data instead of replacing (default: false)")),
+		mcp.WithBoolean("force", mcp.Description("Overwrite concurrent changes instead of retrying (default: false)")),
+	)
+
+	s.AddTool(updateSecretTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+		name := args["name"].(string)
+		namespace := args["namespace"].(string)
+		key := getStringArg(args, "key")
+		value := getStringArg(args, "value")
+		dataStr := getStringArg(args, "data")
+		merge := getBoolArg(args, "merge")
+
+		// Parse new data
+		newData := make(map[string]string)
+		replaceAll := false
+		if key != "" && value != "" {
+			newData[key] = value
+		} else if dataStr != "" {
+			for _, kv := range strings.Split(dataStr, ",") {
+				parts := strings.SplitN(kv, "=", 2)
+				if len(parts) != 2 {
+					return nil, fmt.Errorf("invalid data format: %s (expected key=value)", kv)
+				}
+				newData[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+			}
+			replaceAll = !merge
+		} else {
+			return nil, fmt.Errorf("either key/value or data must be specified")
+		}
+
+		revision, err := recordRevision(toolCtx, history, client, namespace, name, config.ConfigTypeSecret)
+		if err != nil {
+			return nil, err
+		}
+
+		// Update secret
+		opts := k8s.UpdateOptions{Force: getBoolArg(args, "force")}
+		err = client.Update(toolCtx, namespace, name, config.ConfigTypeSecret, opts, func(secret *config.Config) error {
+			if replaceAll {
+				secret.Data = newData
+				return nil
+			}
+			// Update single key or merge with existing data
+			for k, v := range newData {
+				secret.SetValue(k, v)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result := fmt.Sprintf("Successfully updated secret %s/%s", namespace, name)
+		return mcp.NewToolResultText(result + revision), nil
+	})
+}
+
+// parseSecretType maps the secret_create type argument to a Secret type
+func parseSecretType(value string, hasTLS bool) (corev1.SecretType, error) {
+	switch value {
+	case "":
+		if hasTLS {
+			return corev1.SecretTypeTLS, nil
+		}
+		return corev1.SecretTypeOpaque, nil
+	case "opaque":
+		return corev1.SecretTypeOpaque, nil
+	case "tls":
+		return corev1.SecretTypeTLS, nil
+	case "docker-registry":
+		return corev1.SecretTypeDockerConfigJson, nil
+	default:
+		return "", fmt.Errorf("invalid secret type: %s (must be opaque, tls or docker-registry)", value)
+	}
+}
+
+// readTLSKeyPair reads a "cert,key" pair, each given as inline PEM or, when
+// localFiles is set, as the path of a PEM file, and returns it as TLS secret
+// data
+func readTLSKeyPair(value string, localFiles bool) (map[string]string, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid from-tls format (expected cert,key)")
+	}
+
+	pems := make([][]byte, 2)
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "-----BEGIN") {
+			pems[i] = []byte(part)
+			continue
+		}
+		if !localFiles {
+			return nil, fmt.Errorf("from-tls must be inline PEM: reading files on the server is only allowed over the stdio transport")
+		}
+		data, err := os.ReadFile(part)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", part, err)
+		}
+		pems[i] = data
+	}
+
+	return config.TLSSecretData(pems[0], pems[1])
+}
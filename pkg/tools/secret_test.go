@@ -0,0 +1,29 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadTLSKeyPairLocalFiles(t *testing.T) {
+	dir := t.TempDir()
+	cert, key := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	for _, path := range []string{cert, key} {
+		if err := os.WriteFile(path, []byte("not a PEM"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := readTLSKeyPair(cert+","+key, false)
+	if err == nil || !strings.Contains(err.Error(), "inline PEM") {
+		t.Errorf("reading files over a remote transport: error = %v, want inline PEM required", err)
+	}
+
+	// Over stdio the files are read, and then rejected as invalid PEM
+	_, err = readTLSKeyPair(cert+","+key, true)
+	if err == nil || strings.Contains(err.Error(), "inline PEM") {
+		t.Errorf("reading files over stdio: error = %v, want invalid PEM", err)
+	}
+}
@@ -0,0 +1,173 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+	"github.com/giantswarm/mcp-giantswarm-apps/pkg/organization"
+)
+
+// RegisterSessionTools registers the tools managing session defaults
+func RegisterSessionTools(s *mcpserver.MCPServer, ctx *server.Context) error {
+	// session_set_defaults tool
+	setDefaultsTool := mcp.NewTool(
+		"session_set_defaults",
+		mcp.WithDescription("Set the default organization, cluster and namespace used when a tool call of this session omits them. "+
+			"Call without arguments to show the current defaults."),
+		mcp.WithString("organization", mcp.Description("Default organization (empty string to unset)")),
+		mcp.WithString("cluster", mcp.Description("Default workload cluster (empty string to unset)")),
+		mcp.WithString("namespace", mcp.Description("Default namespace (empty string to unset)")),
+		mcp.WithBoolean("clear", mcp.Description("Unset all defaults")),
+	)
+
+	s.AddTool(setDefaultsTool, func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments.(map[string]interface{})
+
+		defaults := ctx.Defaults(toolCtx)
+		if getBoolArg(args, "clear") {
+			defaults = server.Defaults{}
+		}
+		if _, ok := args["organization"]; ok {
+			defaults.Organization = getStringArg(args, "organization")
+		}
+		if _, ok := args["cluster"]; ok {
+			defaults.Cluster = getStringArg(args, "cluster")
+		}
+		if _, ok := args["namespace"]; ok {
+			defaults.Namespace = getStringArg(args, "namespace")
+		}
+		if err := CheckDefaultsPolicy(ctx.NamespacePolicy, defaults); err != nil {
+			return nil, err
+		}
+		ctx.SetDefaults(toolCtx, defaults)
+
+		return mcp.NewToolResultText(formatDefaults(defaults)), nil
+	})
+
+	return nil
+}
+
+// ApplySessionDefaults wraps all registered tools so that omitted organization,
+// cluster and namespace arguments are filled from the session defaults. These
+// arguments are no longer advertised as required; a tool call still fails when
+// neither the caller nor the defaults provide them.
+func ApplySessionDefaults(s *mcpserver.MCPServer, ctx *server.Context) {
+	wrapped := make([]mcpserver.ServerTool, 0)
+	for name, st := range s.ListTools() {
+		if name == "session_set_defaults" {
+			continue
+		}
+
+		accepted := defaultableArguments(st.Tool)
+		if len(accepted) == 0 {
+			continue
+		}
+
+		tool := st.Tool
+		required := make([]string, 0)
+		remaining := make([]string, 0, len(tool.InputSchema.Required))
+		for _, arg := range tool.InputSchema.Required {
+			if _, ok := accepted[arg]; ok {
+				required = append(required, arg)
+				continue
+			}
+			remaining = append(remaining, arg)
+		}
+		tool.InputSchema.Required = remaining
+
+		wrapped = append(wrapped, mcpserver.ServerTool{
+			Tool:    tool,
+			Handler: withDefaults(ctx, accepted, required, st.Handler),
+		})
+	}
+
+	if len(wrapped) > 0 {
+		s.AddTools(wrapped...)
+	}
+}
+
+// defaultableArguments maps the arguments of a tool that can be defaulted to
+// the default they take. The cluster tools name their cluster argument "name".
+func defaultableArguments(tool mcp.Tool) map[string]string {
+	accepted := make(map[string]string)
+	for _, arg := range []string{"organization", "cluster", "namespace"} {
+		if _, ok := tool.InputSchema.Properties[arg]; ok {
+			accepted[arg] = arg
+		}
+	}
+
+	if _, ok := tool.InputSchema.Properties["name"]; ok && strings.HasPrefix(tool.Name, "cluster_") {
+		accepted["name"] = "cluster"
+	}
+
+	return accepted
+}
+
+func withDefaults(ctx *server.Context, accepted map[string]string, required []string, next mcpserver.ToolHandlerFunc) mcpserver.ToolHandlerFunc {
+	return func(toolCtx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, _ := req.Params.Arguments.(map[string]interface{})
+		filled := make(map[string]interface{}, len(args)+len(accepted))
+		for k, v := range args {
+			filled[k] = v
+		}
+
+		fillDefaults(filled, accepted, required, ctx.Defaults(toolCtx))
+
+		for _, arg := range required {
+			if getStringArg(filled, arg) == "" {
+				return nil, fmt.Errorf("%s is required; pass it or set a default with session_set_defaults", arg)
+			}
+		}
+
+		req.Params.Arguments = filled
+		return next(toolCtx, req)
+	}
+}
+
+// fillDefaults sets omitted arguments from the defaults. The namespace and
+// organization arguments both select where a tool looks, so they are only
+// defaulted when the caller passed neither of them.
+func fillDefaults(args map[string]interface{}, accepted map[string]string, required []string, defaults server.Defaults) {
+	for arg, kind := range accepted {
+		if kind == "cluster" && getStringArg(args, arg) == "" && defaults.Cluster != "" {
+			args[arg] = defaults.Cluster
+		}
+	}
+
+	_, acceptsNamespace := accepted["namespace"]
+	_, acceptsOrg := accepted["organization"]
+	if getStringArg(args, "namespace") != "" || getStringArg(args, "organization") != "" {
+		return
+	}
+
+	switch {
+	case acceptsNamespace && defaults.Namespace != "":
+		args["namespace"] = defaults.Namespace
+	case acceptsOrg && defaults.Organization != "":
+		args["organization"] = defaults.Organization
+	case acceptsNamespace && defaults.Organization != "" && slices.Contains(required, "namespace"):
+		args["namespace"] = organization.GetOrganizationNamespace(defaults.Organization)
+	}
+}
+
+func formatDefaults(defaults server.Defaults) string {
+	unset := func(value string) string {
+		if value == "" {
+			return "(not set)"
+		}
+		return value
+	}
+
+	var output strings.Builder
+	output.WriteString("Session defaults:\n")
+	output.WriteString(fmt.Sprintf("  Organization: %s\n", unset(defaults.Organization)))
+	output.WriteString(fmt.Sprintf("  Cluster: %s\n", unset(defaults.Cluster)))
+	output.WriteString(fmt.Sprintf("  Namespace: %s\n", unset(defaults.Namespace)))
+	return output.String()
+}
@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/giantswarm/mcp-giantswarm-apps/internal/server"
+)
+
+func TestFillDefaults(t *testing.T) {
+	defaults := server.Defaults{Organization: "acme", Cluster: "prod"}
+
+	tests := []struct {
+		name     string
+		accepted map[string]string
+		required []string
+		args     map[string]interface{}
+		want     map[string]interface{}
+	}{
+		{
+			name:     "organization for listing tools",
+			accepted: map[string]string{"namespace": "namespace", "organization": "organization"},
+			args:     map[string]interface{}{},
+			want:     map[string]interface{}{"organization": "acme"},
+		},
+		{
+			name:     "explicit namespace wins over default organization",
+			accepted: map[string]string{"namespace": "namespace", "organization": "organization"},
+			args:     map[string]interface{}{"namespace": "default"},
+			want:     map[string]interface{}{"namespace": "default"},
+		},
+		{
+			name:     "required namespace from default organization",
+			accepted: map[string]string{"namespace": "namespace"},
+			required: []string{"namespace"},
+			args:     map[string]interface{}{"name": "hello"},
+			want:     map[string]interface{}{"name": "hello", "namespace": "org-acme"},
+		},
+		{
+			name:     "cluster name argument",
+			accepted: map[string]string{"name": "cluster", "namespace": "namespace", "organization": "organization"},
+			required: []string{"name"},
+			args:     map[string]interface{}{},
+			want:     map[string]interface{}{"name": "prod", "organization": "acme"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fillDefaults(tt.args, tt.accepted, tt.required, defaults)
+			if !reflect.DeepEqual(tt.args, tt.want) {
+				t.Errorf("got %v, want %v", tt.args, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+// Package vulnerability summarizes known CVEs in the container images of
+// deployed apps.
+//
+// Images are taken from the running pods of an app's Helm release, which
+// app-operator names after the App. A Scanner looks up the vulnerabilities of
+// these images; TrivyOperatorScanner reads the VulnerabilityReports that the
+// Trivy Operator keeps in the target cluster. Other sources, such as a Trivy
+// server, can be added by implementing Scanner.
+package vulnerability
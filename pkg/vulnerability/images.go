@@ -0,0 +1,109 @@
+package vulnerability
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// releaseLabel is set by Helm charts on the resources of a release
+const releaseLabel = "app.kubernetes.io/instance"
+
+// ReleaseImages returns the images of the running pods of a Helm release,
+// sorted and without duplicates
+func ReleaseImages(ctx context.Context, k8sClient kubernetes.Interface, namespace, release string) ([]string, error) {
+	pods, err := k8sClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: releaseLabel + "=" + release,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods of release %s in namespace %s: %w", release, namespace, err)
+	}
+	return PodImages(pods.Items), nil
+}
+
+// PodImages returns the images of the containers and init containers of
+// pods, sorted and without duplicates
+func PodImages(pods []corev1.Pod) []string {
+	seen := make(map[string]bool)
+	for _, pod := range pods {
+		containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+		for _, container := range containers {
+			seen[container.Image] = true
+		}
+	}
+
+	images := make([]string, 0, len(seen))
+	for image := range seen {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	return images
+}
+
+// ImageRef is a parsed container image reference
+type ImageRef struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseImage splits an image reference into its parts, applying the Docker
+// Hub defaults for the registry, the library/ repository prefix and the
+// latest tag
+func ParseImage(image string) ImageRef {
+	var ref ImageRef
+
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Digest = name[i+1:]
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+	}
+
+	first, rest, found := strings.Cut(name, "/")
+	if found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		ref.Registry = first
+		ref.Repository = rest
+	} else {
+		ref.Registry = "docker.io"
+		ref.Repository = name
+	}
+
+	ref.Registry = normalizeRegistry(ref.Registry)
+	if ref.Registry == "docker.io" && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = "library/" + ref.Repository
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+	return ref
+}
+
+// Matches reports whether two references name the same image, by digest
+// when both have one and by tag otherwise
+func (r ImageRef) Matches(other ImageRef) bool {
+	if r.Registry != other.Registry || r.Repository != other.Repository {
+		return false
+	}
+	if r.Digest != "" && other.Digest != "" {
+		return r.Digest == other.Digest
+	}
+	return r.Tag == other.Tag
+}
+
+func normalizeRegistry(registry string) string {
+	switch registry {
+	case "index.docker.io", "registry-1.docker.io":
+		return "docker.io"
+	}
+	return registry
+}
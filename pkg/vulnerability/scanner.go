@@ -0,0 +1,154 @@
+package vulnerability
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// Severities in descending order
+var Severities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "UNKNOWN"}
+
+// Vulnerability is a CVE found in an image
+type Vulnerability struct {
+	ID               string
+	Severity         string
+	Package          string
+	InstalledVersion string
+	FixedVersion     string
+	Title            string
+}
+
+// ImageReport is the vulnerability summary of one image
+type ImageReport struct {
+	Image string
+
+	// Scanned is false when the scanner has no result for the image
+	Scanned   bool
+	UpdatedAt time.Time
+
+	// Counts maps each of Severities to the number of vulnerabilities
+	Counts          map[string]int
+	Vulnerabilities []Vulnerability
+}
+
+// Scanner looks up the vulnerabilities of the images running in a namespace
+type Scanner interface {
+	Scan(ctx context.Context, namespace string, images []string) ([]ImageReport, error)
+}
+
+// VulnerabilityReportGVR is the Trivy Operator resource holding the scan
+// result of the images of a workload
+var VulnerabilityReportGVR = schema.GroupVersionResource{
+	Group:    "aquasecurity.github.io",
+	Version:  "v1alpha1",
+	Resource: "vulnerabilityreports",
+}
+
+// TrivyOperatorScanner reads the VulnerabilityReports the Trivy Operator
+// creates for each container of the workloads in a cluster
+type TrivyOperatorScanner struct {
+	dynamicClient dynamic.Interface
+}
+
+// NewTrivyOperatorScanner creates a scanner reading reports with dynamicClient
+func NewTrivyOperatorScanner(dynamicClient dynamic.Interface) *TrivyOperatorScanner {
+	return &TrivyOperatorScanner{dynamicClient: dynamicClient}
+}
+
+// Scan returns a report per image, from the newest VulnerabilityReport of
+// that image in the namespace
+func (s *TrivyOperatorScanner) Scan(ctx context.Context, namespace string, images []string) ([]ImageReport, error) {
+	list, err := s.dynamicClient.Resource(VulnerabilityReportGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vulnerability reports in namespace %s (is the Trivy Operator installed?): %w", namespace, err)
+	}
+
+	scanned := make([]ImageReport, 0, len(list.Items))
+	refs := make([]ImageRef, 0, len(list.Items))
+	for i := range list.Items {
+		report, ref := parseVulnerabilityReport(&list.Items[i])
+		scanned = append(scanned, report)
+		refs = append(refs, ref)
+	}
+
+	reports := make([]ImageReport, 0, len(images))
+	for _, image := range images {
+		ref := ParseImage(image)
+		report := ImageReport{Image: image}
+		for i := range scanned {
+			if refs[i].Matches(ref) && scanned[i].UpdatedAt.After(report.UpdatedAt) {
+				report = scanned[i]
+				report.Image = image
+			}
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// parseVulnerabilityReport reads the image and findings of a report
+func parseVulnerabilityReport(obj *unstructured.Unstructured) (ImageReport, ImageRef) {
+	registry, _, _ := unstructured.NestedString(obj.Object, "report", "registry", "server")
+	repository, _, _ := unstructured.NestedString(obj.Object, "report", "artifact", "repository")
+	tag, _, _ := unstructured.NestedString(obj.Object, "report", "artifact", "tag")
+	digest, _, _ := unstructured.NestedString(obj.Object, "report", "artifact", "digest")
+	ref := ImageRef{Registry: normalizeRegistry(registry), Repository: repository, Tag: tag, Digest: digest}
+
+	report := ImageReport{Scanned: true, Counts: make(map[string]int)}
+	if updated, _, _ := unstructured.NestedString(obj.Object, "report", "updateTimestamp"); updated != "" {
+		report.UpdatedAt, _ = time.Parse(time.RFC3339, updated)
+	}
+	if report.UpdatedAt.IsZero() {
+		report.UpdatedAt = obj.GetCreationTimestamp().Time
+	}
+
+	vulnerabilities, _, _ := unstructured.NestedSlice(obj.Object, "report", "vulnerabilities")
+	for _, item := range vulnerabilities {
+		v, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		vuln := Vulnerability{
+			ID:               stringField(v, "vulnerabilityID"),
+			Severity:         stringField(v, "severity"),
+			Package:          stringField(v, "resource"),
+			InstalledVersion: stringField(v, "installedVersion"),
+			FixedVersion:     stringField(v, "fixedVersion"),
+			Title:            stringField(v, "title"),
+		}
+		if _, known := severityRank[vuln.Severity]; !known {
+			vuln.Severity = "UNKNOWN"
+		}
+		report.Counts[vuln.Severity]++
+		report.Vulnerabilities = append(report.Vulnerabilities, vuln)
+	}
+	SortBySeverity(report.Vulnerabilities)
+
+	return report, ref
+}
+
+var severityRank = map[string]int{"CRITICAL": 0, "HIGH": 1, "MEDIUM": 2, "LOW": 3, "UNKNOWN": 4}
+
+// SortBySeverity sorts vulnerabilities by severity, fixable ones first
+// within a severity
+func SortBySeverity(vulnerabilities []Vulnerability) {
+	sort.SliceStable(vulnerabilities, func(i, j int) bool {
+		a, b := vulnerabilities[i], vulnerabilities[j]
+		if severityRank[a.Severity] != severityRank[b.Severity] {
+			return severityRank[a.Severity] < severityRank[b.Severity]
+		}
+		return a.FixedVersion != "" && b.FixedVersion == ""
+	})
+}
+
+func stringField(m map[string]interface{}, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
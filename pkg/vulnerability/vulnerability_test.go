@@ -0,0 +1,86 @@
+package vulnerability
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestParseImage(t *testing.T) {
+	tests := []struct {
+		image string
+		want  ImageRef
+	}{
+		{"nginx", ImageRef{Registry: "docker.io", Repository: "library/nginx", Tag: "latest"}},
+		{"nginx:1.25", ImageRef{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25"}},
+		{"giantswarm/app-operator:7.0.0", ImageRef{Registry: "docker.io", Repository: "giantswarm/app-operator", Tag: "7.0.0"}},
+		{"gsoci.azurecr.io/giantswarm/kyverno:1.12.0", ImageRef{Registry: "gsoci.azurecr.io", Repository: "giantswarm/kyverno", Tag: "1.12.0"}},
+		{"localhost:5000/app@sha256:abc", ImageRef{Registry: "localhost:5000", Repository: "app", Digest: "sha256:abc"}},
+		{"index.docker.io/library/redis:7", ImageRef{Registry: "docker.io", Repository: "library/redis", Tag: "7"}},
+	}
+	for _, tt := range tests {
+		if got := ParseImage(tt.image); got != tt.want {
+			t.Errorf("ParseImage(%q) = %+v, want %+v", tt.image, got, tt.want)
+		}
+	}
+}
+
+func vulnerabilityReport(name, registry, repository, tag, updated string, vulnerabilities ...map[string]interface{}) *unstructured.Unstructured {
+	items := make([]interface{}, 0, len(vulnerabilities))
+	for _, v := range vulnerabilities {
+		items = append(items, v)
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "aquasecurity.github.io/v1alpha1",
+		"kind":       "VulnerabilityReport",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "kube-system"},
+		"report": map[string]interface{}{
+			"registry":        map[string]interface{}{"server": registry},
+			"artifact":        map[string]interface{}{"repository": repository, "tag": tag},
+			"updateTimestamp": updated,
+			"vulnerabilities": items,
+		},
+	}}
+}
+
+func TestTrivyOperatorScanner(t *testing.T) {
+	low := map[string]interface{}{"vulnerabilityID": "CVE-1", "severity": "LOW", "resource": "zlib"}
+	critical := map[string]interface{}{"vulnerabilityID": "CVE-2", "severity": "CRITICAL", "resource": "openssl", "fixedVersion": "3.0.8"}
+	high := map[string]interface{}{"vulnerabilityID": "CVE-3", "severity": "HIGH", "resource": "curl"}
+
+	scheme := runtime.NewScheme()
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme,
+		map[schema.GroupVersionResource]string{VulnerabilityReportGVR: "VulnerabilityReportList"},
+		vulnerabilityReport("old", "index.docker.io", "library/nginx", "1.25", "2026-01-01T00:00:00Z", low),
+		vulnerabilityReport("new", "index.docker.io", "library/nginx", "1.25", "2026-02-01T00:00:00Z", low, high, critical),
+		vulnerabilityReport("other", "gsoci.azurecr.io", "giantswarm/other", "1.0.0", "2026-02-01T00:00:00Z", critical),
+	)
+
+	reports, err := NewTrivyOperatorScanner(client).Scan(context.Background(), "kube-system",
+		[]string{"nginx:1.25", "gsoci.azurecr.io/giantswarm/unscanned:1.0.0"})
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("got %d reports, want 2", len(reports))
+	}
+
+	nginx := reports[0]
+	if !nginx.Scanned || nginx.Image != "nginx:1.25" {
+		t.Fatalf("nginx report = %+v, want the scanned report", nginx)
+	}
+	if nginx.Counts["CRITICAL"] != 1 || nginx.Counts["HIGH"] != 1 || nginx.Counts["LOW"] != 1 {
+		t.Errorf("counts = %v, want those of the newest report", nginx.Counts)
+	}
+	if got := nginx.Vulnerabilities[0].ID; got != "CVE-2" {
+		t.Errorf("first vulnerability = %s, want the critical CVE-2", got)
+	}
+
+	if reports[1].Scanned {
+		t.Errorf("unscanned image has a report: %+v", reports[1])
+	}
+}